@@ -0,0 +1,200 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/mrbooshehri/qix-go/internal/config"
+	"github.com/mrbooshehri/qix-go/internal/ui"
+)
+
+var aliasCmd = &cobra.Command{
+	Use:   "alias",
+	Short: "List command aliases",
+	Long: `Aliases are short names for the command lines you run most. Define them in
+the config file:
+
+  alias.t   = task create inbox
+  alias.day = report daily
+  alias.wip = --json task list inbox --status doing
+
+or with 'qix config set alias.day "report daily"'. 'qix t "Call Bob" -p high'
+then runs 'qix task create inbox "Call Bob" -p high': the alias is replaced by
+its command line, quoted words kept together, and the rest of the arguments
+follow it. An alias can use another alias, and global flags may come before
+it. Built-in commands always win over an alias of the same name.`,
+}
+
+var aliasListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List configured aliases",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		aliases, err := config.Aliases()
+		if err != nil {
+			return fail("Failed to read aliases: %v", err)
+		}
+
+		names := make([]string, 0, len(aliases))
+		for name := range aliases {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		if jsonOutput {
+			type aliasJSON struct {
+				Name     string `json:"name"`
+				Command  string `json:"command"`
+				Shadowed bool   `json:"shadowed"`
+			}
+			out := make([]aliasJSON, 0, len(names))
+			for _, name := range names {
+				out = append(out, aliasJSON{Name: name, Command: aliases[name], Shadowed: builtinCommand(name)})
+			}
+			printJSON(out)
+			return nil
+		}
+
+		ui.PrintHeader("🔗 Aliases")
+		if len(aliases) == 0 {
+			ui.PrintEmptyState("No aliases configured", fmt.Sprintf("Add alias.<name> = <command line> to %s", config.Get().ConfigFile))
+			return nil
+		}
+
+		table := ui.NewTableBuilder("Alias", "Command")
+		shadowed := make([]string, 0)
+		for _, name := range names {
+			line := "qix " + aliases[name]
+			if builtinCommand(name) {
+				line += " (shadowed)"
+				shadowed = append(shadowed, name)
+			}
+			table.Row(name, line)
+		}
+		table.PrintSimple()
+
+		if len(shadowed) > 0 {
+			fmt.Println()
+			ui.PrintWarning("Built-in commands win over aliases of the same name; never used: %s", strings.Join(shadowed, ", "))
+		}
+		return nil
+	},
+}
+
+// applyAliases expands an alias the command line runs, so cobra dispatches
+// the command it stands for
+func applyAliases() {
+	completing := len(os.Args) > 1 && (os.Args[1] == cobra.ShellCompRequestCmd || os.Args[1] == cobra.ShellCompNoDescRequestCmd)
+
+	aliases, err := config.Aliases()
+	if err != nil {
+		// Anything printed while completing would end up on the command line
+		if !completing {
+			ui.PrintWarning("Ignoring aliases: %v", err)
+		}
+		return
+	}
+	if len(aliases) == 0 {
+		return
+	}
+
+	args, err := expandAliases(os.Args[1:], aliases)
+	if err != nil {
+		if completing {
+			return
+		}
+		fatal("%v", err)
+	}
+	rootCmd.SetArgs(args)
+}
+
+// expandAliases replaces the alias args run, if any, with its command line,
+// until the command is a built-in one. When the shell asks for completions,
+// the word being completed is left alone.
+func expandAliases(args []string, aliases map[string]string) ([]string, error) {
+	start, last := 0, len(args)
+	if len(args) > 0 && (args[0] == cobra.ShellCompRequestCmd || args[0] == cobra.ShellCompNoDescRequestCmd) {
+		start, last = 1, len(args)-1
+	}
+
+	seen := make(map[string]bool)
+	for {
+		i := commandIndex(args, start)
+		if i < 0 || i >= last {
+			return args, nil
+		}
+		name := args[i]
+		line, ok := aliases[name]
+		if !ok || builtinCommand(name) {
+			return args, nil
+		}
+		if seen[name] {
+			return nil, fmt.Errorf("alias '%s' expands to itself", name)
+		}
+		seen[name] = true
+
+		words, err := splitCommandLine(line)
+		if err != nil {
+			return nil, fmt.Errorf("invalid alias '%s': %w", name, err)
+		}
+		expanded := make([]string, 0, len(args)+len(words))
+		expanded = append(expanded, args[:i]...)
+		expanded = append(expanded, words...)
+		expanded = append(expanded, args[i+1:]...)
+		args = expanded
+		last += len(words) - 1
+	}
+}
+
+// commandIndex returns the index of the first argument from start on that
+// isn't a global flag or its value, or -1 if there is none
+func commandIndex(args []string, start int) int {
+	flags := rootCmd.PersistentFlags()
+	for i := start; i < len(args); i++ {
+		arg := args[i]
+		switch {
+		case arg == "--":
+			return -1
+		case strings.HasPrefix(arg, "--"):
+			if !strings.Contains(arg, "=") && takesValue(flags.Lookup(arg[2:])) {
+				i++
+			}
+		case strings.HasPrefix(arg, "-") && len(arg) > 1:
+			if len(arg) == 2 && takesValue(flags.ShorthandLookup(arg[1:])) {
+				i++
+			}
+		default:
+			return i
+		}
+	}
+	return -1
+}
+
+// takesValue reports whether a flag is followed by its value, as in
+// --profile work
+func takesValue(flag *pflag.Flag) bool {
+	return flag != nil && flag.NoOptDefVal == ""
+}
+
+// builtinCommand reports whether name runs one of qix's own commands
+func builtinCommand(name string) bool {
+	if name == "help" {
+		return true
+	}
+	for _, cmd := range rootCmd.Commands() {
+		if cmd.Name() == name || cmd.HasAlias(name) {
+			return true
+		}
+	}
+	return false
+}
+
+func init() {
+	aliasCmd.AddCommand(aliasListCmd)
+	rootCmd.AddCommand(aliasCmd)
+}
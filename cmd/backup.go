@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"archive/tar"
+	"bytes"
 	"compress/gzip"
 	"fmt"
 	"io"
@@ -25,7 +26,7 @@ var backupCmd = &cobra.Command{
 var backupCreateCmd = &cobra.Command{
 	Use:   "create",
 	Short: "Create a backup",
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		cfg := config.Get()
 		store := storage.Get()
 		
@@ -34,24 +35,39 @@ var backupCreateCmd = &cobra.Command{
 			ui.PrintWarning("Some changes may not be saved: %v", err)
 		}
 		
-		ui.PrintInfo("Creating backup...")
+		incremental, _ := cmd.Flags().GetBool("incremental")
+		encrypt, _ := cmd.Flags().GetBool("encrypt")
 		
 		// Create backup filename
 		timestamp := time.Now().Format("20060102_150405")
 		backupName := fmt.Sprintf("qix_backup_%s.tar.gz", timestamp)
+		if incremental {
+			backupName = fmt.Sprintf("qix_backup_%s%s", timestamp, incrementalSuffix)
+		}
 		backupPath := filepath.Join(cfg.BackupDir, backupName)
 		
-		// Create tar.gz archive
-		if err := createTarGz(cfg.QixDir, backupPath); err != nil {
-			ui.PrintError("Failed to create backup: %v", err)
-			return
+		var manifest *backupManifest
+		if incremental {
+			ui.PrintInfo("Creating incremental backup...")
+			
+			var err error
+			manifest, err = createIncrementalBackup(cfg, backupPath, encrypt)
+			if err != nil {
+				return fail("Failed to create backup: %v", err)
+			}
+		} else {
+			ui.PrintInfo("Creating backup...")
+			
+			// Create tar.gz archive
+			if err := createTarGz(cfg.QixDir, backupPath, encrypt); err != nil {
+				return fail("Failed to create backup: %v", err)
+			}
 		}
 		
 		// Get backup size
 		info, err := os.Stat(backupPath)
 		if err != nil {
-			ui.PrintError("Failed to get backup info: %v", err)
-			return
+			return fail("Failed to get backup info: %v", err)
 		}
 		
 		size := float64(info.Size()) / 1024 / 1024 // MB
@@ -60,40 +76,101 @@ var backupCreateCmd = &cobra.Command{
 		ui.Cyan.Printf("  File: %s\n", backupName)
 		ui.Blue.Printf("  Location: %s\n", cfg.BackupDir)
 		ui.Yellow.Printf("  Size: %.2f MB\n", size)
-		ui.Dim.Printf("  Time: %s\n", time.Now().Format("2006-01-02 15:04:05"))
+		if manifest != nil {
+			ui.Magenta.Printf("  Changes: %d changed, %d deleted since %s\n", len(manifest.Changed), len(manifest.Deleted), manifest.Base)
+		}
+		ui.Dim.Printf("  Time: %s\n", ui.FormatDateTime(time.Now()))
+		
+		// Copy the backup off this machine if requested
+		if remoteName, _ := cmd.Flags().GetString("remote"); remoteName != "" {
+			target, err := openRemote(cfg, remoteName)
+			if err == nil {
+				ui.PrintInfo("Uploading to %s...", target)
+				err = uploadBackup(cmd.Context(), target, backupPath)
+			}
+			if err != nil {
+				ui.PrintError("Failed to upload backup: %v", err)
+				ui.PrintWarning("The local backup was kept: %s", backupName)
+			} else {
+				ui.PrintSuccess("Backup uploaded to %s", target)
+			}
+		}
 		
 		// Cleanup old backups
 		if _, err := cleanupOldBackups(cfg); err != nil {
 			ui.PrintWarning("Failed to cleanup old backups: %v", err)
 		}
+		return nil
 	},
 }
 
+// backupView is a backup as listed with --json
+type backupView struct {
+	Name        string    `json:"name"`
+	Incremental bool      `json:"incremental"`
+	Encrypted   bool      `json:"encrypted,omitempty"`
+	Date        time.Time `json:"date"`
+	Size        int64     `json:"size"`
+}
+
 var backupListCmd = &cobra.Command{
 	Use:   "list",
 	Short: "List available backups",
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		cfg := config.Get()
 		
+		if remoteName, _ := cmd.Flags().GetString("remote"); remoteName != "" {
+			target, err := openRemote(cfg, remoteName)
+			if err == nil {
+				err = printRemoteBackups(cmd.Context(), target)
+			}
+			if err != nil {
+				return fail("Failed to list remote backups: %v", err)
+			}
+			return nil
+		}
+		
 		// Find all backup files
 		pattern := filepath.Join(cfg.BackupDir, "qix_backup_*.tar.gz")
 		files, err := filepath.Glob(pattern)
 		if err != nil {
-			ui.PrintError("Failed to list backups: %v", err)
-			return
+			return fail("Failed to list backups: %v", err)
+		}
+		
+		if jsonOutput {
+			views := make([]backupView, 0, len(files))
+			for _, file := range files {
+				if info, err := os.Stat(file); err == nil {
+					name := filepath.Base(file)
+					views = append(views, backupView{
+						Name:        name,
+						Incremental: strings.HasSuffix(name, incrementalSuffix),
+						Encrypted:   isEncryptedBackup(file),
+						Date:        info.ModTime(),
+						Size:        info.Size(),
+					})
+				}
+			}
+			printJSON(views)
+			return nil
 		}
 		
 		if len(files) == 0 {
 			ui.PrintEmptyState("No backups found", "Create one with: qix backup create")
-			return
+			return nil
 		}
 		
 		ui.PrintHeader("📦 Available Backups")
 		
-		// Build table
-		table := ui.NewTableBuilder("Backup", "Date", "Size", "Age").
-			Align(2, ui.AlignRight).
-			Align(3, ui.AlignRight)
+		// Build table; the age is already in the date unless dates are absolute
+		showAge := ui.DateDisplay() == ui.DatesAbsolute
+		headers := []string{"Backup", "Type", "Date", "Size"}
+		if showAge {
+			headers = append(headers, "Age")
+		}
+		table := ui.NewTableBuilder(headers...).
+			Align(3, ui.AlignRight).
+			Align(4, ui.AlignRight)
 		
 		for _, file := range files {
 			info, err := os.Stat(file)
@@ -104,16 +181,25 @@ var backupListCmd = &cobra.Command{
 			name := filepath.Base(file)
 			modTime := info.ModTime()
 			size := float64(info.Size()) / 1024 / 1024 // MB
-			age := time.Since(modTime)
 			
-			ageStr := formatAge(age)
+			kind := "full"
+			if strings.HasSuffix(name, incrementalSuffix) {
+				kind = "incremental"
+			}
+			if isEncryptedBackup(file) {
+				kind += ", encrypted"
+			}
 			
-			table.Row(
+			row := []string{
 				name,
-				modTime.Format("2006-01-02 15:04"),
+				kind,
+				ui.FormatDateTime(modTime),
 				fmt.Sprintf("%.2f MB", size),
-				ageStr,
-			)
+			}
+			if showAge {
+				row = append(row, ui.FormatAgo(modTime))
+			}
+			table.Row(row...)
 		}
 		
 		table.PrintSimple()
@@ -121,6 +207,7 @@ var backupListCmd = &cobra.Command{
 		fmt.Println()
 		ui.Dim.Printf("Backup location: %s\n", cfg.BackupDir)
 		ui.Dim.Printf("Retention period: %d days\n", cfg.BackupRetentionDays)
+		return nil
 	},
 }
 
@@ -129,22 +216,29 @@ var backupRestoreCmd = &cobra.Command{
 	Short: "Restore from a backup",
 	Long:  "Restore QIX data from a backup file (creates safety backup first)",
 	Args:  cobra.ExactArgs(1),
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		backupFile := args[0]
 		cfg := config.Get()
 		
 		// Find backup file
-		var backupPath string
-		if filepath.IsAbs(backupFile) {
-			backupPath = backupFile
-		} else {
-			backupPath = filepath.Join(cfg.BackupDir, backupFile)
+		backupPath, err := resolveBackupPath(cmd, cfg, backupFile)
+		if err != nil {
+			return fail("%v", err)
 		}
 		
 		// Verify backup exists
 		if _, err := os.Stat(backupPath); os.IsNotExist(err) {
-			ui.PrintError("Backup file not found: %s", backupFile)
-			return
+			return notFound("Backup file not found: %s", backupFile)
+		}
+		
+		// The backup is extracted over the data directory rather than saved
+		// through the storage, so compare it instead
+		if dryRun, _ := cmd.Flags().GetBool("dry-run"); dryRun {
+			if err := printBackupDiff(cmd, backupPath, backupFile, ""); err != nil {
+				return err
+			}
+			ui.PrintInfo("Dry run: nothing was restored")
+			return nil
 		}
 		
 		// Confirmation
@@ -154,14 +248,9 @@ var backupRestoreCmd = &cobra.Command{
 			fmt.Println("⚠️  This will restore data from the backup and overwrite current data.")
 			fmt.Printf("Backup: %s\n", filepath.Base(backupPath))
 			fmt.Println()
-			fmt.Print("Type 'restore' to confirm: ")
-			
-			var confirm string
-			fmt.Scanln(&confirm)
-			
-			if confirm != "restore" {
+			if !ui.ConfirmTyped("restore") {
 				ui.PrintInfo("Restore cancelled")
-				return
+				return nil
 			}
 		}
 		
@@ -172,9 +261,8 @@ var backupRestoreCmd = &cobra.Command{
 			time.Now().Format("20060102_150405"))
 		safetyPath := filepath.Join(cfg.BackupDir, safetyName)
 		
-		if err := createTarGz(cfg.QixDir, safetyPath); err != nil {
-			ui.PrintError("Failed to create safety backup: %v", err)
-			return
+		if err := createTarGz(cfg.QixDir, safetyPath, false); err != nil {
+			return fail("Failed to create safety backup: %v", err)
 		}
 		
 		ui.PrintSuccess("Safety backup created: %s", safetyName)
@@ -182,11 +270,17 @@ var backupRestoreCmd = &cobra.Command{
 		
 		ui.PrintInfo("Restoring from backup...")
 		
-		// Extract backup
-		if err := extractTarGz(backupPath, filepath.Dir(cfg.QixDir)); err != nil {
-			ui.PrintError("Failed to restore backup: %v", err)
-			ui.PrintWarning("Your data was not modified. Safety backup: %s", safetyName)
-			return
+		// Checksums from the current data would not match restored files that predate them
+		checksums, _ := filepath.Glob(filepath.Join(cfg.ProjectsDir, "*.json.sha256"))
+		for _, file := range checksums {
+			os.Remove(file)
+		}
+		
+		// Extract backup, along with the backups an incremental one builds on
+		if err := restoreBackup(backupPath, filepath.Dir(cfg.QixDir)); err != nil {
+			// Checksums are gone and files may be half extracted, so the safety backup is the way back
+			return withHint(storageFailed("Failed to restore backup: %v", err),
+				"Your data may be partly restored. Bring it back as it was with: qix backup restore %s", safetyName)
 		}
 		
 		// Clear storage cache
@@ -194,7 +288,7 @@ var backupRestoreCmd = &cobra.Command{
 		store.ClearCache()
 		
 		// Rebuild index
-		if err := store.RebuildIndex(); err != nil {
+		if err := rebuildIndexWithProgress(store); err != nil {
 			ui.PrintWarning("Failed to rebuild index: %v", err)
 		}
 		
@@ -203,6 +297,7 @@ var backupRestoreCmd = &cobra.Command{
 		ui.Blue.Printf("  Safety backup: %s\n", safetyName)
 		fmt.Println()
 		ui.Dim.Println("💡 Tip: Run 'qix doctor' to verify data integrity")
+		return nil
 	},
 }
 
@@ -210,15 +305,14 @@ var backupCleanupCmd = &cobra.Command{
 	Use:   "cleanup",
 	Short: "Remove old backups",
 	Long:  "Delete backups older than the retention period",
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		cfg := config.Get()
 		
 		ui.PrintInfo("Cleaning up old backups (retention: %d days)...", cfg.BackupRetentionDays)
 		
 		count, err := cleanupOldBackups(cfg)
 		if err != nil {
-			ui.PrintError("Failed to cleanup backups: %v", err)
-			return
+			return fail("Failed to cleanup backups: %v", err)
 		}
 		
 		if count == 0 {
@@ -226,6 +320,7 @@ var backupCleanupCmd = &cobra.Command{
 		} else {
 			ui.PrintSuccess("Removed %d old backup(s)", count)
 		}
+		return nil
 	},
 }
 
@@ -234,7 +329,7 @@ var backupExportCmd = &cobra.Command{
 	Short: "Export backup to a specific location",
 	Long:  "Create a backup and save it to a custom location",
 	Args:  cobra.ExactArgs(1),
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		outputPath := args[0]
 		cfg := config.Get()
 		store := storage.Get()
@@ -252,16 +347,15 @@ var backupExportCmd = &cobra.Command{
 		}
 		
 		// Create backup
-		if err := createTarGz(cfg.QixDir, outputPath); err != nil {
-			ui.PrintError("Failed to export backup: %v", err)
-			return
+		encrypt, _ := cmd.Flags().GetBool("encrypt")
+		if err := createTarGz(cfg.QixDir, outputPath, encrypt); err != nil {
+			return fail("Failed to export backup: %v", err)
 		}
 		
 		// Get file info
 		info, err := os.Stat(outputPath)
 		if err != nil {
-			ui.PrintError("Failed to get backup info: %v", err)
-			return
+			return fail("Failed to get backup info: %v", err)
 		}
 		
 		size := float64(info.Size()) / 1024 / 1024 // MB
@@ -269,35 +363,82 @@ var backupExportCmd = &cobra.Command{
 		ui.PrintSuccess("Backup exported")
 		ui.Cyan.Printf("  Location: %s\n", outputPath)
 		ui.Yellow.Printf("  Size: %.2f MB\n", size)
+		return nil
 	},
 }
 
 // Helper functions
 
-func createTarGz(sourceDir, targetFile string) error {
-	// Create output file
-	outFile, err := os.Create(targetFile)
+// createTarGz archives sourceDir to targetFile, encrypting the archive if encrypt is set
+// or encryption at rest is enabled
+func createTarGz(sourceDir, targetFile string, encrypt bool) error {
+	total, err := countBackupFiles(sourceDir)
 	if err != nil {
 		return err
 	}
-	defer outFile.Close()
 	
+	progress := ui.StartProgress("Archiving", "files", total)
+	defer progress.Stop()
+	
+	var archive bytes.Buffer
+	if err := writeTarGz(sourceDir, &archive, progress); err != nil {
+		return err
+	}
+	
+	data, err := sealBackup(archive.Bytes(), encrypt)
+	if err != nil {
+		return fmt.Errorf("failed to encrypt backup: %w", err)
+	}
+	
+	return os.WriteFile(targetFile, data, 0600)
+}
+
+// countBackupFiles counts the files writeTarGz archives from sourceDir
+func countBackupFiles(sourceDir string) (int, error) {
+	filter := newBackupFilter(config.Get())
+	count := 0
+	err := filepath.Walk(sourceDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if rel, err := filepath.Rel(sourceDir, path); err == nil && filter.skip(rel, info.IsDir()) {
+			if info.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !info.IsDir() {
+			count++
+		}
+		return nil
+	})
+	return count, err
+}
+
+// writeTarGz writes a gzip-compressed tar of sourceDir to out, counting the files
+// archived in progress
+func writeTarGz(sourceDir string, out io.Writer, progress *ui.Progress) error {
 	// Create gzip writer
-	gzWriter := gzip.NewWriter(outFile)
+	gzWriter := gzip.NewWriter(out)
 	defer gzWriter.Close()
 	
 	// Create tar writer
 	tarWriter := tar.NewWriter(gzWriter)
 	defer tarWriter.Close()
 	
+	filter := newBackupFilter(config.Get())
+	
 	// Walk the source directory
 	return filepath.Walk(sourceDir, func(path string, info os.FileInfo, err error) error {
 		if err != nil {
 			return err
 		}
 		
-		// Skip the backups directory itself
-		if strings.Contains(path, "/backups/") {
+		// Skip the backups directory itself and anything filtered out
+		if rel, err := filepath.Rel(sourceDir, path); err == nil && filter.skip(rel, info.IsDir()) {
+			if info.IsDir() {
+				return filepath.SkipDir
+			}
 			return nil
 		}
 		
@@ -330,29 +471,22 @@ func createTarGz(sourceDir, targetFile string) error {
 			if _, err := io.Copy(tarWriter, file); err != nil {
 				return err
 			}
+			progress.Add(1)
 		}
 		
 		return nil
 	})
 }
 
+// extractTarGz extracts a backup archive into targetDir, decrypting it first if needed
 func extractTarGz(sourceFile, targetDir string) error {
-	// Open source file
-	file, err := os.Open(sourceFile)
+	tarReader, err := openBackupArchive(sourceFile)
 	if err != nil {
 		return err
 	}
-	defer file.Close()
 	
-	// Create gzip reader
-	gzReader, err := gzip.NewReader(file)
-	if err != nil {
-		return err
-	}
-	defer gzReader.Close()
-	
-	// Create tar reader
-	tarReader := tar.NewReader(gzReader)
+	progress := ui.StartProgress("Extracting "+filepath.Base(sourceFile), "files", 0)
+	defer progress.Stop()
 	
 	// Extract files
 	for {
@@ -364,7 +498,18 @@ func extractTarGz(sourceFile, targetDir string) error {
 			return err
 		}
 		
-		// Construct target path
+		// The manifest of an incremental backup is not part of the data
+		if header.Name == backupManifestName {
+			continue
+		}
+		
+		// Skip links and other special entries, and paths leading out of targetDir
+		if header.Typeflag != tar.TypeDir && header.Typeflag != tar.TypeReg {
+			continue
+		}
+		if !isSafeArchivePath(header.Name) {
+			continue
+		}
 		target := filepath.Join(targetDir, header.Name)
 		
 		switch header.Typeflag {
@@ -390,12 +535,20 @@ func extractTarGz(sourceFile, targetDir string) error {
 				return err
 			}
 			outFile.Close()
+			progress.Add(1)
 		}
 	}
 	
 	return nil
 }
 
+// rebuildIndexWithProgress rebuilds the task index, showing the projects indexed so far
+func rebuildIndexWithProgress(store *storage.Storage) error {
+	progress := ui.StartProgress("Indexing", "projects", 0)
+	defer progress.Stop()
+	return store.RebuildIndexProgress(progress.Set)
+}
+
 func cleanupOldBackups(cfg *config.Config) (int, error) {
 	pattern := filepath.Join(cfg.BackupDir, "qix_backup_*.tar.gz")
 	files, err := filepath.Glob(pattern)
@@ -406,13 +559,22 @@ func cleanupOldBackups(cfg *config.Config) (int, error) {
 	cutoff := time.Now().AddDate(0, 0, -cfg.BackupRetentionDays)
 	removed := 0
 	
+	// Keep old backups that newer incremental backups still build on
+	kept := make([]string, 0, len(files))
+	for _, file := range files {
+		if info, err := os.Stat(file); err == nil && !info.ModTime().Before(cutoff) {
+			kept = append(kept, file)
+		}
+	}
+	needed := backupBases(kept)
+	
 	for _, file := range files {
 		info, err := os.Stat(file)
 		if err != nil {
 			continue
 		}
 		
-		if info.ModTime().Before(cutoff) {
+		if info.ModTime().Before(cutoff) && !needed[filepath.Base(file)] {
 			if err := os.Remove(file); err != nil {
 				continue
 			}
@@ -423,38 +585,31 @@ func cleanupOldBackups(cfg *config.Config) (int, error) {
 	return removed, nil
 }
 
-func formatAge(d time.Duration) string {
-	days := int(d.Hours() / 24)
-	
-	if days == 0 {
-		return "today"
-	} else if days == 1 {
-		return "1 day"
-	} else if days < 7 {
-		return fmt.Sprintf("%d days", days)
-	} else if days < 30 {
-		weeks := days / 7
-		if weeks == 1 {
-			return "1 week"
-		}
-		return fmt.Sprintf("%d weeks", weeks)
-	} else {
-		months := days / 30
-		if months == 1 {
-			return "1 month"
-		}
-		return fmt.Sprintf("%d months", months)
-	}
-}
-
 func init() {
 	// backup restore flags
 	backupRestoreCmd.Flags().BoolP("force", "f", false, "Skip confirmation")
+	ownDryRun(backupRestoreCmd)
+	backupRestoreCmd.Flags().String("remote", "", "Download the backup from a remote target (URL or configured remote name)")
 	
+	// backup list flags
+	backupListCmd.Flags().String("remote", "", "List backups on a remote target (URL or configured remote name)")
+	
+	// backup create flags
+	backupCreateCmd.Flags().BoolP("incremental", "i", false, "Only back up files changed since the previous backup")
+	backupCreateCmd.Flags().Bool("encrypt", false, "Encrypt the backup with backup_passphrase (or the data passphrase)")
+	
+	// backup export flags
+	backupExportCmd.Flags().Bool("encrypt", false, "Encrypt the backup with backup_passphrase (or the data passphrase)")
+	backupCreateCmd.Flags().String("remote", "", "Also upload the backup to a remote target: s3://bucket/prefix, webdav://host/path, sftp://user@host/path or a configured remote name")
+	
+	noJSON(backupCreateCmd, backupRestoreCmd, backupCleanupCmd, backupExportCmd)
+
 	// Add subcommands
 	backupCmd.AddCommand(backupCreateCmd)
 	backupCmd.AddCommand(backupListCmd)
 	backupCmd.AddCommand(backupRestoreCmd)
 	backupCmd.AddCommand(backupCleanupCmd)
 	backupCmd.AddCommand(backupExportCmd)
+
+	usePager(true, backupListCmd)
 }
\ No newline at end of file
@@ -0,0 +1,230 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/mrbooshehri/qix-go/internal/config"
+	"github.com/mrbooshehri/qix-go/internal/storage"
+	"github.com/mrbooshehri/qix-go/internal/ui"
+	"github.com/mrbooshehri/qix-go/pkg/qix/models"
+	"github.com/spf13/cobra"
+)
+
+var backupDiffCmd = &cobra.Command{
+	Use:   "diff <backup_file>",
+	Short: "Show what restoring a backup would change",
+	Long: `Compare a backup with your current data, project by project: tasks that
+restoring would bring back (+), lose (-) or change (~), and differences in
+logged time. Your data is not touched.
+
+Examples:
+  qix backup diff qix_backup_20240101_120000.tar.gz
+  qix backup diff qix_backup_20240101_120000.tar.gz --project webapp`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg := config.Get()
+		only, _ := cmd.Flags().GetString("project")
+
+		backupPath, err := resolveBackupPath(cmd, cfg, args[0])
+		if err != nil {
+			return fail("%v", err)
+		}
+		if _, err := os.Stat(backupPath); os.IsNotExist(err) {
+			return notFound("Backup file not found: %s", args[0])
+		}
+		return printBackupDiff(cmd, backupPath, args[0], only)
+	},
+}
+
+// printBackupDiff prints how restoring a backup, as the user named it in
+// backupFile, would change the current data: of all projects, or only of the
+// one named only
+func printBackupDiff(cmd *cobra.Command, backupPath, backupFile, only string) error {
+	cfg := config.Get()
+	store := storage.Get()
+
+	backupStore, cleanup, err := openBackupStore(cfg, backupPath)
+	if err != nil {
+		return fail("%v", err)
+	}
+	defer cleanup()
+
+	backupProjects, err := backupStore.GetAllProjectsContext(cmd.Context())
+	if err != nil {
+		return fail("Failed to load backup projects: %v", err)
+	}
+	currentProjects, err := store.GetAllProjectsContext(cmd.Context())
+	if err != nil {
+		return fail("Failed to load projects: %v", err)
+	}
+
+	names := make([]string, 0)
+	seen := make(map[string]bool)
+	for _, list := range [][]*models.Project{backupProjects, currentProjects} {
+		for _, project := range list {
+			if !seen[project.Name] && (only == "" || project.Name == only) {
+				seen[project.Name] = true
+				names = append(names, project.Name)
+			}
+		}
+	}
+	sort.Strings(names)
+
+	ui.PrintHeader("🔀 Backup vs Current Data")
+	ui.Dim.Printf("Restoring %s would make these changes (+ restored, - lost, ~ changed)\n\n", backupFile)
+
+	changed := 0
+	for _, name := range names {
+		if printProjectDiff(name, findProjectByName(backupProjects, name), findProjectByName(currentProjects, name)) {
+			changed++
+		}
+	}
+
+	if changed == 0 {
+		ui.PrintSuccess("No differences; the backup matches your current data")
+		return nil
+	}
+	ui.Dim.Printf("%d project(s) differ\n", changed)
+	return nil
+}
+
+// findProjectByName returns the project with the given name, or nil
+func findProjectByName(projects []*models.Project, name string) *models.Project {
+	for _, project := range projects {
+		if project.Name == name {
+			return project
+		}
+	}
+	return nil
+}
+
+// printProjectDiff prints how restoring backup would change current, reporting whether anything differs
+func printProjectDiff(name string, backup, current *models.Project) bool {
+	switch {
+	case current == nil:
+		ui.Green.Printf("+ %s", name)
+		ui.Dim.Printf("  (not in current data; %d task(s), %.1fh logged)\n", len(backup.GetAllTasks()), backup.CalculateTotalActual())
+		return true
+	case backup == nil:
+		ui.Yellow.Printf("  %s", name)
+		ui.Dim.Println("  (not in backup; a full restore keeps it as is)")
+		return true
+	}
+
+	backupTasks := tasksWithLocation(backup)
+	currentTasks := tasksWithLocation(current)
+
+	lines := make([]string, 0)
+	for _, id := range sortedTaskIDs(backupTasks, currentTasks) {
+		before, inCurrent := currentTasks[id]
+		after, inBackup := backupTasks[id]
+
+		switch {
+		case !inCurrent:
+			lines = append(lines, ui.Green.Sprintf("    + [%s] %s", id, after.task.Title))
+		case !inBackup:
+			lines = append(lines, ui.Red.Sprintf("    - [%s] %s", id, before.task.Title))
+		default:
+			if changes := taskChanges(before, after); len(changes) > 0 {
+				lines = append(lines, ui.Yellow.Sprintf("    ~ [%s] %s: ", id, after.task.Title)+strings.Join(changes, "; "))
+			}
+		}
+	}
+
+	if len(lines) == 0 {
+		return false
+	}
+
+	ui.BoldCyan.Printf("~ %s\n", name)
+	for _, line := range lines {
+		fmt.Println(line)
+	}
+	if backupHours, currentHours := backup.CalculateTotalActual(), current.CalculateTotalActual(); backupHours != currentHours {
+		ui.Dim.Printf("    Logged time: %.1fh now, %.1fh in backup\n", currentHours, backupHours)
+	}
+	fmt.Println()
+	return true
+}
+
+// locatedTask is a task with the module it belongs to ("" for project level)
+type locatedTask struct {
+	task   models.Task
+	module string
+}
+
+// tasksWithLocation maps every task in a project by ID
+func tasksWithLocation(project *models.Project) map[string]locatedTask {
+	tasks := make(map[string]locatedTask)
+	for _, task := range project.Tasks {
+		tasks[task.ID] = locatedTask{task: task}
+	}
+	for _, module := range project.Modules {
+		for _, task := range module.Tasks {
+			tasks[task.ID] = locatedTask{task: task, module: module.Name}
+		}
+	}
+	return tasks
+}
+
+// sortedTaskIDs returns the IDs in either map, sorted
+func sortedTaskIDs(a, b map[string]locatedTask) []string {
+	ids := make([]string, 0, len(a)+len(b))
+	for id := range a {
+		ids = append(ids, id)
+	}
+	for id := range b {
+		if _, ok := a[id]; !ok {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// taskChanges describes how a task differs between current data (before) and
+// the backup or an unsaved change (after)
+func taskChanges(before, after locatedTask) []string {
+	changes := make([]string, 0)
+	change := func(field, from, to string) {
+		if from != to {
+			changes = append(changes, fmt.Sprintf("%s %s → %s", field, from, to))
+		}
+	}
+	orNone := func(s string) string {
+		if s == "" {
+			return "(none)"
+		}
+		return s
+	}
+
+	b, a := before.task, after.task
+	if b.Title != a.Title {
+		changes = append(changes, fmt.Sprintf("title was '%s'", b.Title))
+	}
+	change("module", orNone(before.module), orNone(after.module))
+	change("status", string(b.Status), string(a.Status))
+	change("priority", string(b.Priority), string(a.Priority))
+	change("estimate", fmt.Sprintf("%.1fh", b.EstimatedHours), fmt.Sprintf("%.1fh", a.EstimatedHours))
+	change("due", orNone(b.DueDate), orNone(a.DueDate))
+	change("assignee", orNone(b.Assignee), orNone(a.Assignee))
+	change("tags", orNone(strings.Join(b.Tags, ",")), orNone(strings.Join(a.Tags, ",")))
+	change("dependencies", orNone(strings.Join(b.Dependencies, ",")), orNone(strings.Join(a.Dependencies, ",")))
+	if b.Description != a.Description {
+		changes = append(changes, "description")
+	}
+	if bh, ah := b.CalculateActualHours(), a.CalculateActualHours(); len(b.TimeEntries) != len(a.TimeEntries) || bh != ah {
+		changes = append(changes, fmt.Sprintf("time %d entries/%.1fh → %d entries/%.1fh", len(b.TimeEntries), bh, len(a.TimeEntries), ah))
+	}
+	return changes
+}
+
+func init() {
+	backupDiffCmd.Flags().String("project", "", "Only compare this project")
+	backupDiffCmd.Flags().String("remote", "", "Download the backup from a remote target (URL or configured remote name)")
+
+	noJSON(backupDiffCmd)
+	backupCmd.AddCommand(backupDiffCmd)
+}
@@ -0,0 +1,79 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+
+	"github.com/mrbooshehri/qix-go/internal/config"
+	"github.com/mrbooshehri/qix-go/internal/encryption"
+)
+
+// Backups are encrypted when encryption at rest is enabled, or on request with
+// --encrypt. Requested encryption uses backup_passphrase if set, so backups can go to
+// untrusted storage under a different passphrase than the local data, and falls back
+// to the data passphrase otherwise.
+
+var (
+	backupKeyOnce sync.Once
+	backupKey     *encryption.Key // Key from backup_passphrase, nil if unset
+)
+
+// configuredBackupKey returns the key for backup_passphrase, or nil if it isn't set
+func configuredBackupKey() *encryption.Key {
+	backupKeyOnce.Do(func() {
+		if passphrase := config.Get().BackupPassphrase; passphrase != "" {
+			backupKey = encryption.NewKey(passphrase)
+		}
+	})
+	return backupKey
+}
+
+// sealBackup encrypts a backup archive. With encrypt set it always encrypts, failing
+// if no passphrase is configured; otherwise it follows the encryption-at-rest setting.
+func sealBackup(data []byte, encrypt bool) ([]byte, error) {
+	if encrypt {
+		if key := configuredBackupKey(); key != nil {
+			return key.Seal(data)
+		}
+		if !encryption.Enabled() {
+			return nil, fmt.Errorf("no passphrase configured for encrypted backups (set backup_passphrase or QIX_BACKUP_PASSPHRASE)")
+		}
+		return encryption.Seal(data)
+	}
+	return encryption.Encrypt(data)
+}
+
+// openBackupData decrypts a backup archive with whichever configured passphrase sealed it
+func openBackupData(data []byte) ([]byte, error) {
+	if !encryption.IsEncrypted(data) {
+		return data, nil
+	}
+
+	if key := configuredBackupKey(); key != nil {
+		if plain, err := key.Open(data); err == nil || !encryption.Enabled() {
+			return plain, err
+		}
+	}
+
+	plain, err := encryption.Decrypt(data)
+	if errors.Is(err, encryption.ErrNoKey) {
+		return nil, fmt.Errorf("backup is encrypted; set backup_passphrase (or QIX_BACKUP_PASSPHRASE) to the passphrase it was created with")
+	}
+	return plain, err
+}
+
+// isEncryptedBackup reports whether a backup file is encrypted, reading only its header
+func isEncryptedBackup(path string) bool {
+	file, err := os.Open(path)
+	if err != nil {
+		return false
+	}
+	defer file.Close()
+
+	header := make([]byte, 16)
+	n, _ := io.ReadFull(file, header)
+	return encryption.IsEncrypted(header[:n])
+}
@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/mrbooshehri/qix-go/internal/config"
+)
+
+// backupFilter decides which files in the data directory go into a backup. Patterns
+// are matched against the slash-separated path relative to the data directory and
+// against the base name; a pattern ending in "/" matches a directory and everything
+// in it. Include patterns win over exclude patterns. The backups directory itself is
+// never archived.
+type backupFilter struct {
+	include []string
+	exclude []string
+}
+
+// newBackupFilter returns the filter configured by backup_include and backup_exclude
+func newBackupFilter(cfg *config.Config) backupFilter {
+	return backupFilter{include: cfg.BackupInclude, exclude: cfg.BackupExclude}
+}
+
+// skip reports whether a path (relative to the data directory) is left out of backups
+func (f backupFilter) skip(rel string, isDir bool) bool {
+	rel = filepath.ToSlash(rel)
+	if rel == "." || rel == "" {
+		return false
+	}
+	if rel == "backups" || strings.HasPrefix(rel, "backups/") {
+		return true
+	}
+	return matchesAny(f.exclude, rel, isDir) && !matchesAny(f.include, rel, isDir)
+}
+
+// matchesAny reports whether a path matches one of the patterns
+func matchesAny(patterns []string, rel string, isDir bool) bool {
+	for _, pattern := range patterns {
+		if dirPattern := strings.TrimSuffix(pattern, "/"); dirPattern != pattern {
+			// Directory pattern: match the directory itself or any path below it
+			if isDir && matchPattern(dirPattern, rel) {
+				return true
+			}
+			for dir := path.Dir(rel); dir != "."; dir = path.Dir(dir) {
+				if matchPattern(dirPattern, dir) {
+					return true
+				}
+			}
+			continue
+		}
+		if matchPattern(pattern, rel) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchPattern matches a pattern against a full relative path or, for patterns
+// without a slash, against the base name
+func matchPattern(pattern, rel string) bool {
+	if ok, _ := path.Match(pattern, rel); ok {
+		return true
+	}
+	if !strings.Contains(pattern, "/") {
+		ok, _ := path.Match(pattern, path.Base(rel))
+		return ok
+	}
+	return false
+}
@@ -0,0 +1,384 @@
+package cmd
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mrbooshehri/qix-go/internal/config"
+	"github.com/mrbooshehri/qix-go/internal/ui"
+)
+
+// An incremental backup holds only the files that changed since the backup it builds
+// on (its base), plus a manifest recording the base, the checksum of every file in the
+// data directory at the time, and the files deleted since the base. Restoring one
+// extracts the full backup at the root of the chain and then each incremental in turn.
+
+// backupManifestName is the first entry of an incremental backup archive
+const backupManifestName = "qix_backup_manifest.json"
+
+// incrementalSuffix marks incremental backup files
+const incrementalSuffix = "_incr.tar.gz"
+
+// backupManifest describes an incremental backup
+type backupManifest struct {
+	Base      string            `json:"base"`              // File name of the backup this one builds on
+	CreatedAt time.Time         `json:"created_at"`        // When the backup was taken
+	Files     map[string]string `json:"files"`             // Checksum of every file in the data directory
+	Changed   []string          `json:"changed"`           // Files stored in this backup
+	Deleted   []string          `json:"deleted,omitempty"` // Files removed since the base
+}
+
+// createIncrementalBackup archives the files in the data directory that changed since the
+// most recent backup and returns the manifest written. encrypt is as for createTarGz.
+func createIncrementalBackup(cfg *config.Config, targetFile string, encrypt bool) (*backupManifest, error) {
+	base, err := latestBackup(cfg.BackupDir)
+	if err != nil {
+		return nil, err
+	}
+	if base == "" {
+		return nil, fmt.Errorf("no previous backup to build on; create a full backup first")
+	}
+
+	previous, err := backupFileSums(base)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read base backup %s: %w", filepath.Base(base), err)
+	}
+	current, err := snapshotFileSums(cfg.QixDir)
+	if err != nil {
+		return nil, err
+	}
+
+	manifest := &backupManifest{
+		Base:      filepath.Base(base),
+		CreatedAt: time.Now(),
+		Files:     current,
+		Changed:   make([]string, 0),
+	}
+	for name, sum := range current {
+		if previous[name] != sum {
+			manifest.Changed = append(manifest.Changed, name)
+		}
+	}
+	for name := range previous {
+		if _, ok := current[name]; !ok {
+			manifest.Deleted = append(manifest.Deleted, name)
+		}
+	}
+	sort.Strings(manifest.Changed)
+	sort.Strings(manifest.Deleted)
+
+	var archive bytes.Buffer
+	if err := writeIncrementalTarGz(filepath.Dir(cfg.QixDir), manifest, &archive); err != nil {
+		return nil, err
+	}
+
+	data, err := sealBackup(archive.Bytes(), encrypt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt backup: %w", err)
+	}
+	if err := os.WriteFile(targetFile, data, 0600); err != nil {
+		return nil, err
+	}
+	return manifest, nil
+}
+
+// writeIncrementalTarGz writes the manifest followed by the changed files, read relative to rootDir
+func writeIncrementalTarGz(rootDir string, manifest *backupManifest, out io.Writer) error {
+	gzWriter := gzip.NewWriter(out)
+	defer gzWriter.Close()
+
+	tarWriter := tar.NewWriter(gzWriter)
+	defer tarWriter.Close()
+
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+	header := &tar.Header{
+		Name:    backupManifestName,
+		Mode:    0600,
+		Size:    int64(len(data)),
+		ModTime: manifest.CreatedAt,
+	}
+	if err := tarWriter.WriteHeader(header); err != nil {
+		return err
+	}
+	if _, err := tarWriter.Write(data); err != nil {
+		return err
+	}
+
+	progress := ui.StartProgress("Archiving changes", "files", len(manifest.Changed))
+	defer progress.Stop()
+
+	for _, name := range manifest.Changed {
+		path := filepath.Join(rootDir, name)
+		info, err := os.Stat(path)
+		if err != nil {
+			return err
+		}
+
+		header, err := tar.FileInfoHeader(info, "")
+		if err != nil {
+			return err
+		}
+		header.Name = name
+		if err := tarWriter.WriteHeader(header); err != nil {
+			return err
+		}
+
+		file, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		_, err = io.Copy(tarWriter, file)
+		file.Close()
+		if err != nil {
+			return err
+		}
+		progress.Add(1)
+	}
+
+	return nil
+}
+
+// restoreBackup restores a backup into targetDir. An incremental backup is restored by
+// extracting every backup in its chain, oldest first, and removing files deleted along the way.
+func restoreBackup(backupPath, targetDir string) error {
+	chain, err := backupChain(backupPath)
+	if err != nil {
+		return err
+	}
+
+	for _, path := range chain {
+		if err := extractTarGz(path, targetDir); err != nil {
+			return fmt.Errorf("%s: %w", filepath.Base(path), err)
+		}
+
+		manifest, err := readBackupManifest(path)
+		if err != nil {
+			return err
+		}
+		if manifest == nil {
+			continue
+		}
+		for _, name := range manifest.Deleted {
+			if !isSafeArchivePath(name) {
+				continue
+			}
+			if err := os.Remove(filepath.Join(targetDir, name)); err != nil && !os.IsNotExist(err) {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// backupChain returns the backups needed to restore backupPath: the full backup it
+// ultimately builds on, followed by each incremental backup up to and including it
+func backupChain(backupPath string) ([]string, error) {
+	chain := []string{backupPath}
+	seen := map[string]bool{filepath.Base(backupPath): true}
+
+	for path := backupPath; ; {
+		manifest, err := readBackupManifest(path)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", filepath.Base(path), err)
+		}
+		if manifest == nil {
+			return chain, nil
+		}
+
+		if seen[manifest.Base] {
+			return nil, fmt.Errorf("backup %s has a circular chain of bases", filepath.Base(backupPath))
+		}
+		seen[manifest.Base] = true
+
+		base := filepath.Join(filepath.Dir(path), manifest.Base)
+		if _, err := os.Stat(base); err != nil {
+			return nil, fmt.Errorf("backup %s builds on %s, which is missing", filepath.Base(path), manifest.Base)
+		}
+		chain = append([]string{base}, chain...)
+		path = base
+	}
+}
+
+// readBackupManifest returns the manifest of an incremental backup, or nil for a full backup
+func readBackupManifest(backupPath string) (*backupManifest, error) {
+	tarReader, err := openBackupArchive(backupPath)
+	if err != nil {
+		return nil, err
+	}
+
+	header, err := tarReader.Next()
+	if err == io.EOF {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if header.Name != backupManifestName {
+		return nil, nil
+	}
+
+	var manifest backupManifest
+	if err := json.NewDecoder(tarReader).Decode(&manifest); err != nil {
+		return nil, fmt.Errorf("invalid backup manifest: %w", err)
+	}
+	return &manifest, nil
+}
+
+// backupFileSums returns the checksum of every file a backup restores to. Incremental
+// backups record them in their manifest; full backups are hashed entry by entry.
+func backupFileSums(backupPath string) (map[string]string, error) {
+	manifest, err := readBackupManifest(backupPath)
+	if err != nil {
+		return nil, err
+	}
+	if manifest != nil {
+		return manifest.Files, nil
+	}
+
+	tarReader, err := openBackupArchive(backupPath)
+	if err != nil {
+		return nil, err
+	}
+
+	sums := make(map[string]string)
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		hash := sha256.New()
+		if _, err := io.Copy(hash, tarReader); err != nil {
+			return nil, err
+		}
+		sums[header.Name] = hex.EncodeToString(hash.Sum(nil))
+	}
+	return sums, nil
+}
+
+// snapshotFileSums returns the checksum of every file a backup of sourceDir would
+// contain, keyed by archive path
+func snapshotFileSums(sourceDir string) (map[string]string, error) {
+	filter := newBackupFilter(config.Get())
+	sums := make(map[string]string)
+	err := filepath.Walk(sourceDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		// Same filtering as a full backup
+		if rel, err := filepath.Rel(sourceDir, path); err == nil && filter.skip(rel, info.IsDir()) {
+			if info.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+
+		relPath, err := filepath.Rel(filepath.Dir(sourceDir), path)
+		if err != nil {
+			return err
+		}
+
+		file, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+
+		hash := sha256.New()
+		if _, err := io.Copy(hash, file); err != nil {
+			return err
+		}
+		sums[relPath] = hex.EncodeToString(hash.Sum(nil))
+		return nil
+	})
+	return sums, err
+}
+
+// openBackupArchive decrypts and decompresses a backup, returning a reader over its entries
+func openBackupArchive(backupPath string) (*tar.Reader, error) {
+	data, err := os.ReadFile(backupPath)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err = openBackupData(data)
+	if err != nil {
+		return nil, err
+	}
+
+	gzReader, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	return tar.NewReader(gzReader), nil
+}
+
+// latestBackup returns the most recently written backup in backupDir, or "" if there is none
+func latestBackup(backupDir string) (string, error) {
+	files, err := filepath.Glob(filepath.Join(backupDir, "qix_backup_*.tar.gz"))
+	if err != nil {
+		return "", err
+	}
+
+	latest := ""
+	var latestTime time.Time
+	for _, file := range files {
+		info, err := os.Stat(file)
+		if err != nil {
+			continue
+		}
+		if latest == "" || info.ModTime().After(latestTime) {
+			latest, latestTime = file, info.ModTime()
+		}
+	}
+	return latest, nil
+}
+
+// backupBases returns the names of every backup that one of the given backups builds on,
+// directly or through other incremental backups
+func backupBases(backups []string) map[string]bool {
+	bases := make(map[string]bool)
+	for _, file := range backups {
+		if !strings.HasSuffix(file, incrementalSuffix) {
+			continue
+		}
+		chain, err := backupChain(file)
+		if err != nil {
+			continue
+		}
+		for _, base := range chain[:len(chain)-1] {
+			bases[filepath.Base(base)] = true
+		}
+	}
+	return bases
+}
+
+// isSafeArchivePath reports whether an archive path stays inside the directory it is extracted to
+func isSafeArchivePath(name string) bool {
+	clean := filepath.Clean(name)
+	return !filepath.IsAbs(clean) && clean != ".." && !strings.HasPrefix(clean, ".."+string(filepath.Separator))
+}
@@ -0,0 +1,224 @@
+package cmd
+
+import (
+	"archive/tar"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/mrbooshehri/qix-go/internal/config"
+	"github.com/mrbooshehri/qix-go/internal/storage"
+	"github.com/mrbooshehri/qix-go/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var backupRestoreProjectCmd = &cobra.Command{
+	Use:   "restore-project <backup_file> <project>",
+	Short: "Restore a single project from a backup",
+	Long: `Restore one project's files from a backup, leaving all other data untouched.
+If the project still exists, its current version is moved to the trash first.`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		backupFile, projectName := args[0], args[1]
+		force, _ := cmd.Flags().GetBool("force")
+
+		cfg := config.Get()
+		store := storage.Get()
+
+		if cfg.StorageBackend != "json" {
+			return fail("restore-project needs the json storage backend; use 'qix backup restore' instead")
+		}
+
+		backupPath, err := resolveBackupPath(cmd, cfg, backupFile)
+		if err != nil {
+			return fail("%v", err)
+		}
+
+		if _, err := os.Stat(backupPath); os.IsNotExist(err) {
+			return notFound("Backup file not found: %s", backupFile)
+		}
+
+		projects, err := backupProjects(backupPath)
+		if err != nil {
+			return fail("Failed to read backup: %v", err)
+		}
+		if i := sort.SearchStrings(projects, projectName); i == len(projects) || projects[i] != projectName {
+			err := notFound("Project '%s' is not in %s", projectName, filepath.Base(backupPath))
+			if len(projects) > 0 {
+				err = withHint(err, "Projects in this backup: %s", strings.Join(projects, ", "))
+			}
+			return err
+		}
+
+		if dryRun, _ := cmd.Flags().GetBool("dry-run"); dryRun {
+			if err := printBackupDiff(cmd, backupPath, backupFile, projectName); err != nil {
+				return err
+			}
+			ui.PrintInfo("Dry run: nothing was restored")
+			return nil
+		}
+
+		exists := store.ProjectExists(projectName)
+		if exists && !force {
+			fmt.Printf("⚠️  Project '%s' exists and will be replaced by the version in %s.\n", projectName, filepath.Base(backupPath))
+			fmt.Println("The current version is moved to the trash.")
+			if !ui.ConfirmTyped(projectName) {
+				ui.PrintInfo("Restore cancelled")
+				return nil
+			}
+		}
+
+		if exists {
+			if err := store.DeleteProject(projectName); err != nil {
+				return fail("Failed to move current project to trash: %v", err)
+			}
+		}
+
+		count, err := extractProjectFiles(backupPath, projectName, cfg.QixDir)
+		if err != nil {
+			err := storageFailed("Failed to restore project: %v", err)
+			if exists {
+				err = withHint(err, "The previous version is in the trash; use 'qix trash list' to restore it")
+			}
+			return err
+		}
+
+		store.InvalidateCache(projectName)
+		if err := store.RebuildIndex(); err != nil {
+			ui.PrintWarning("Failed to rebuild index: %v", err)
+		}
+		if err := store.VerifyProject(projectName); err != nil {
+			ui.PrintWarning("%v", err)
+		}
+
+		ui.PrintSuccess("Project '%s' restored (%d file(s))", projectName, count)
+		ui.Green.Printf("  Restored from: %s\n", filepath.Base(backupPath))
+		if exists {
+			ui.Dim.Println("  The previous version was moved to the trash")
+		}
+		return nil
+	},
+}
+
+// backupProjects returns the names of the projects a backup restores
+func backupProjects(backupPath string) ([]string, error) {
+	sums, err := backupFileSums(backupPath)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool)
+	projects := make([]string, 0)
+	for name := range sums {
+		rel := dataRelativePath(name)
+		if filepath.Dir(rel) != "projects" {
+			continue
+		}
+		base := filepath.Base(rel)
+		for _, suffix := range []string{".json", ".json.gz"} {
+			if project := strings.TrimSuffix(base, suffix); project != base && !seen[project] {
+				seen[project] = true
+				projects = append(projects, project)
+			}
+		}
+	}
+	sort.Strings(projects)
+	return projects, nil
+}
+
+// extractProjectFiles extracts one project's files from a backup (and the backups it
+// builds on) into qixDir, returning the number of files written
+func extractProjectFiles(backupPath, projectName, qixDir string) (int, error) {
+	chain, err := backupChain(backupPath)
+	if err != nil {
+		return 0, err
+	}
+
+	written := make(map[string]bool)
+	for _, path := range chain {
+		tarReader, err := openBackupArchive(path)
+		if err != nil {
+			return 0, fmt.Errorf("%s: %w", filepath.Base(path), err)
+		}
+
+		for {
+			header, err := tarReader.Next()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				return 0, err
+			}
+
+			rel := dataRelativePath(header.Name)
+			if header.Typeflag != tar.TypeReg || !isProjectFile(rel, projectName) || !isSafeArchivePath(rel) {
+				continue
+			}
+
+			target := filepath.Join(qixDir, rel)
+			if err := os.MkdirAll(filepath.Dir(target), 0700); err != nil {
+				return 0, err
+			}
+			outFile, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+			if err != nil {
+				return 0, err
+			}
+			_, err = io.Copy(outFile, tarReader)
+			outFile.Close()
+			if err != nil {
+				return 0, err
+			}
+			written[rel] = true
+		}
+
+		manifest, err := readBackupManifest(path)
+		if err != nil {
+			return 0, err
+		}
+		if manifest == nil {
+			continue
+		}
+		for _, name := range manifest.Deleted {
+			rel := dataRelativePath(name)
+			if isProjectFile(rel, projectName) && isSafeArchivePath(rel) {
+				os.Remove(filepath.Join(qixDir, rel))
+				delete(written, rel)
+			}
+		}
+	}
+
+	return len(written), nil
+}
+
+// dataRelativePath strips the data directory's own name from an archive path, so
+// ".qix/projects/web.json" becomes "projects/web.json"
+func dataRelativePath(name string) string {
+	name = filepath.ToSlash(name)
+	if i := strings.Index(name, "/"); i >= 0 {
+		return name[i+1:]
+	}
+	return ""
+}
+
+// isProjectFile reports whether a data-relative path belongs to a project: its data
+// file (plain or compressed), checksum or time entries
+func isProjectFile(rel, projectName string) bool {
+	prefix := "projects/" + projectName
+	switch rel {
+	case prefix + ".json", prefix + ".json.gz", prefix + ".json.sha256":
+		return true
+	}
+	return strings.HasPrefix(rel, prefix+".time/")
+}
+
+func init() {
+	backupRestoreProjectCmd.Flags().BoolP("force", "f", false, "Skip confirmation")
+	ownDryRun(backupRestoreProjectCmd)
+	backupRestoreProjectCmd.Flags().String("remote", "", "Download the backup from a remote target (URL or configured remote name)")
+
+	noJSON(backupRestoreProjectCmd)
+	backupCmd.AddCommand(backupRestoreProjectCmd)
+}
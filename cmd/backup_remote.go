@@ -0,0 +1,162 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/mrbooshehri/qix-go/internal/config"
+	"github.com/mrbooshehri/qix-go/internal/remote"
+	"github.com/mrbooshehri/qix-go/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+// openRemote returns the remote target for a URL or for the name of a remote.<name>
+// entry in the config file
+func openRemote(cfg *config.Config, nameOrURL string) (remote.Target, error) {
+	target := nameOrURL
+	if !strings.Contains(nameOrURL, "://") {
+		configured, ok := config.Remotes()[strings.ToLower(nameOrURL)]
+		if !ok {
+			return nil, fmt.Errorf("unknown remote '%s' (use a URL or define remote.%s = <url> in the config file)", nameOrURL, nameOrURL)
+		}
+		target = configured
+	}
+
+	return remote.Open(target, remote.Options{
+		S3Region:       cfg.Remote.S3Region,
+		S3Endpoint:     cfg.Remote.S3Endpoint,
+		S3AccessKey:    cfg.Remote.S3AccessKey,
+		S3SecretKey:    cfg.Remote.S3SecretKey,
+		S3SessionToken: cfg.Remote.S3SessionToken,
+		WebDAVUser:     cfg.Remote.WebDAVUser,
+		WebDAVPassword: cfg.Remote.WebDAVPassword,
+	})
+}
+
+// resolveBackupPath returns the local path of a backup named on the command line,
+// downloading it first if the command's --remote flag is set
+func resolveBackupPath(cmd *cobra.Command, cfg *config.Config, backupFile string) (string, error) {
+	remoteName, _ := cmd.Flags().GetString("remote")
+	if remoteName == "" {
+		if filepath.IsAbs(backupFile) {
+			return backupFile, nil
+		}
+		return filepath.Join(cfg.BackupDir, backupFile), nil
+	}
+
+	target, err := openRemote(cfg, remoteName)
+	if err != nil {
+		return "", err
+	}
+
+	ui.PrintInfo("Downloading from %s...", target)
+	backupPath, err := fetchRemoteBackup(cmd.Context(), target, backupFile, cfg.BackupDir)
+	if err != nil {
+		return "", fmt.Errorf("failed to download backup: %w", err)
+	}
+	return backupPath, nil
+}
+
+// uploadBackup copies a local backup file to a remote target
+func uploadBackup(ctx context.Context, target remote.Target, backupPath string) error {
+	data, err := os.ReadFile(backupPath)
+	if err != nil {
+		return err
+	}
+	return target.Upload(ctx, filepath.Base(backupPath), data)
+}
+
+// fetchRemoteBackup downloads a backup into backupDir, along with any backups an
+// incremental one builds on that aren't already there, and returns its local path
+func fetchRemoteBackup(ctx context.Context, target remote.Target, name, backupDir string) (string, error) {
+	backupPath := filepath.Join(backupDir, filepath.Base(name))
+
+	for next := backupPath; next != ""; {
+		if _, err := os.Stat(next); os.IsNotExist(err) {
+			data, err := target.Download(ctx, filepath.Base(next))
+			if err != nil {
+				return "", err
+			}
+			if err := os.WriteFile(next, data, 0600); err != nil {
+				return "", err
+			}
+			ui.Dim.Printf("  Downloaded %s\n", filepath.Base(next))
+		}
+
+		manifest, err := readBackupManifest(next)
+		if err != nil {
+			return "", fmt.Errorf("%s: %w", filepath.Base(next), err)
+		}
+		next = ""
+		if manifest != nil {
+			next = filepath.Join(backupDir, manifest.Base)
+		}
+	}
+
+	return backupPath, nil
+}
+
+// printRemoteBackups lists the backups stored on a remote target
+func printRemoteBackups(ctx context.Context, target remote.Target) error {
+	objects, err := target.List(ctx)
+	if err != nil {
+		return err
+	}
+
+	backups := make([]remote.Object, 0, len(objects))
+	for _, object := range objects {
+		if strings.HasPrefix(object.Name, "qix_backup_") && strings.HasSuffix(object.Name, ".tar.gz") {
+			backups = append(backups, object)
+		}
+	}
+
+	sort.Slice(backups, func(i, j int) bool {
+		return backups[i].Name < backups[j].Name
+	})
+
+	if jsonOutput {
+		views := make([]backupView, 0, len(backups))
+		for _, backup := range backups {
+			views = append(views, backupView{
+				Name:        backup.Name,
+				Incremental: strings.HasSuffix(backup.Name, incrementalSuffix),
+				Date:        backup.ModTime,
+				Size:        backup.Size,
+			})
+		}
+		printJSON(views)
+		return nil
+	}
+
+	if len(backups) == 0 {
+		ui.PrintEmptyState("No backups found on "+target.String(), "Upload one with: qix backup create --remote <target>")
+		return nil
+	}
+
+	ui.PrintHeader("☁️  Remote Backups")
+
+	table := ui.NewTableBuilder("Backup", "Type", "Date", "Size").
+		Align(3, ui.AlignRight)
+	for _, backup := range backups {
+		kind := "full"
+		if strings.HasSuffix(backup.Name, incrementalSuffix) {
+			kind = "incremental"
+		}
+
+		date := "-"
+		if !backup.ModTime.IsZero() {
+			date = ui.FormatDateTime(backup.ModTime.Local())
+		}
+
+		table.Row(backup.Name, kind, date, fmt.Sprintf("%.2f MB", float64(backup.Size)/1024/1024))
+	}
+	table.PrintSimple()
+
+	fmt.Println()
+	ui.Dim.Printf("Remote: %s\n", target)
+	return nil
+}
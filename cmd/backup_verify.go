@@ -0,0 +1,203 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/mrbooshehri/qix-go/internal/config"
+	"github.com/mrbooshehri/qix-go/internal/storage"
+	"github.com/mrbooshehri/qix-go/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var backupVerifyCmd = &cobra.Command{
+	Use:   "verify <backup_file>",
+	Short: "Check that a backup can be restored",
+	Long: `Extract a backup (with the backups an incremental one builds on) into a
+temporary directory and check it the way 'qix doctor' checks live data: every
+archive is readable, every project decodes with the current schema and matches
+its checksum, tracking data and the journal parse, and the task index agrees
+with the projects. Your data is not touched.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg := config.Get()
+
+		backupPath, err := resolveBackupPath(cmd, cfg, args[0])
+		if err != nil {
+			return fail("%v", err)
+		}
+		if _, err := os.Stat(backupPath); os.IsNotExist(err) {
+			return notFound("Backup file not found: %s", args[0])
+		}
+
+		ui.PrintHeader("🔍 Verifying " + filepath.Base(backupPath))
+
+		issues, warnings := verifyBackup(cmd.Context(), cfg, backupPath)
+
+		ui.PrintSubHeader("📋 Summary")
+		if issues == 0 && warnings == 0 {
+			ui.PrintSuccess("Backup is intact and can be restored")
+		} else if issues == 0 {
+			ui.PrintWarning("Backup can be restored, with %d warning(s)", warnings)
+		} else {
+			return fail("Found %d issue(s) and %d warning(s); restoring this backup is not safe", issues, warnings)
+		}
+		return nil
+	},
+}
+
+// verifyBackup checks a backup and prints each result, returning the number of issues and warnings
+func verifyBackup(ctx context.Context, cfg *config.Config, backupPath string) (int, int) {
+	issues, warnings := 0, 0
+
+	// 1. Archives
+	ui.PrintSubHeader("📦 Checking archives...")
+
+	chain, err := backupChain(backupPath)
+	if err != nil {
+		ui.PrintError("%v", err)
+		fmt.Println()
+		return 1, 0
+	}
+	if len(chain) > 1 {
+		ui.PrintInfo("Incremental backup; chain of %d archive(s) from %s", len(chain), filepath.Base(chain[0]))
+	}
+
+	store, cleanup, err := openBackupStore(cfg, backupPath)
+	if err != nil {
+		ui.PrintError("%v", err)
+		fmt.Println()
+		return 1, 0
+	}
+	defer cleanup()
+
+	ui.PrintSuccess("All archives are readable")
+	fmt.Println()
+
+	// 2. Projects
+	ui.PrintSubHeader("📄 Checking projects...")
+
+	projects, err := store.ListProjects()
+	if err != nil {
+		ui.PrintError("Failed to list projects: %v", err)
+		issues++
+	} else {
+		ui.PrintInfo("Found %d project(s)", len(projects))
+		for _, name := range projects {
+			var checksumErr *storage.ChecksumError
+			err := store.VerifyProject(name)
+			if err == nil {
+				_, err = store.LoadProject(name)
+			}
+
+			if errors.As(err, &checksumErr) {
+				ui.PrintError("Checksum mismatch: %s", name)
+				issues++
+			} else if err != nil {
+				ui.PrintError("Unreadable project: %s (%v)", name, err)
+				issues++
+			} else {
+				ui.PrintSuccess("Valid: %s", name)
+			}
+		}
+	}
+
+	if _, err := store.GetAllTimeEntriesInRange(ctx, "0000-01-01", "9999-12-31"); err != nil {
+		ui.PrintError("Unreadable time entries: %v", err)
+		issues++
+	}
+
+	if files, err := store.SchemaStatus(); err == nil {
+		outdated := 0
+		for _, file := range files {
+			if file.NeedsMigration() {
+				outdated++
+			}
+		}
+		if outdated > 0 {
+			ui.PrintInfo("%d file(s) use an older schema and will be upgraded when loaded", outdated)
+		}
+	}
+	fmt.Println()
+
+	// 3. Other data files
+	ui.PrintSubHeader("🗂  Checking other data...")
+
+	if _, err := store.LoadTrackingData(); err != nil {
+		ui.PrintError("Unreadable tracking data: %v", err)
+		issues++
+	} else {
+		ui.PrintSuccess("Tracking data is valid")
+	}
+	if _, err := store.LoadSchedules(); err != nil {
+		ui.PrintError("Unreadable report schedules: %v", err)
+		issues++
+	}
+	if _, err := store.ReadJournal(); err != nil {
+		ui.PrintWarning("Unreadable journal: %v", err)
+		warnings++
+	}
+	fmt.Println()
+
+	// 4. Index
+	ui.PrintSubHeader("📇 Checking task index...")
+
+	problems, err := store.VerifyIndexFile()
+	if os.IsNotExist(err) {
+		ui.PrintWarning("Backup has no task index; it will be rebuilt after restoring")
+		warnings++
+	} else if err != nil {
+		ui.PrintWarning("Task index unusable (%v); it will be rebuilt after restoring", err)
+		warnings++
+	} else if len(problems) > 0 {
+		ui.PrintWarning("Index inconsistencies found (repaired by rebuilding the index after restoring):")
+		for _, problem := range problems {
+			ui.Dim.Println("  • " + problem)
+		}
+		warnings += len(problems)
+	} else {
+		ui.PrintSuccess("Index is consistent with the projects")
+	}
+	fmt.Println()
+
+	return issues, warnings
+}
+
+// openBackupStore restores a backup into a temporary directory and opens it as a
+// separate storage instance. Call cleanup to remove the directory when done.
+func openBackupStore(cfg *config.Config, backupPath string) (*storage.Storage, func(), error) {
+	tmpDir, err := os.MkdirTemp("", "qix-backup-")
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create temporary directory: %w", err)
+	}
+	cleanup := func() { os.RemoveAll(tmpDir) }
+
+	if err := restoreBackup(backupPath, tmpDir); err != nil {
+		cleanup()
+		return nil, nil, fmt.Errorf("archive unreadable: %w", err)
+	}
+
+	// The archive holds the data directory itself; find it
+	entries, err := os.ReadDir(tmpDir)
+	if err != nil || len(entries) != 1 || !entries[0].IsDir() {
+		cleanup()
+		return nil, nil, fmt.Errorf("backup does not contain a single data directory")
+	}
+
+	store, err := storage.Open(cfg.WithDataDir(filepath.Join(tmpDir, entries[0].Name())))
+	if err != nil {
+		cleanup()
+		return nil, nil, fmt.Errorf("failed to open backup data: %w", err)
+	}
+	return store, cleanup, nil
+}
+
+func init() {
+	backupVerifyCmd.Flags().String("remote", "", "Download the backup from a remote target (URL or configured remote name)")
+
+	noJSON(backupVerifyCmd)
+	backupCmd.AddCommand(backupVerifyCmd)
+}
@@ -0,0 +1,281 @@
+package cmd
+
+import (
+	"fmt"
+	"math/rand"
+	"os"
+	"time"
+
+	"github.com/mrbooshehri/qix-go/internal/config"
+	"github.com/mrbooshehri/qix-go/internal/storage"
+	"github.com/mrbooshehri/qix-go/internal/ui"
+	"github.com/mrbooshehri/qix-go/pkg/qix/models"
+	"github.com/spf13/cobra"
+)
+
+var benchCmd = &cobra.Command{
+	Use:    "bench",
+	Short:  "Benchmark storage with synthetic projects",
+	Hidden: true,
+	Long: `Generate synthetic projects in a temporary directory and time the main
+storage operations against them. Your own data is not touched.
+
+Examples:
+  qix bench
+  qix bench --projects 50 --tasks 500 --entries 20`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		opts := benchOptions{}
+		opts.projects, _ = cmd.Flags().GetInt("projects")
+		opts.modules, _ = cmd.Flags().GetInt("modules")
+		opts.tasks, _ = cmd.Flags().GetInt("tasks")
+		opts.entries, _ = cmd.Flags().GetInt("entries")
+		opts.lookups, _ = cmd.Flags().GetInt("lookups")
+		seed, _ := cmd.Flags().GetInt64("seed")
+		keep, _ := cmd.Flags().GetBool("keep")
+
+		if opts.projects < 1 || opts.tasks < 1 {
+			return invalid("--projects and --tasks must be at least 1")
+		}
+
+		dir, err := os.MkdirTemp("", "qix-bench-")
+		if err != nil {
+			return fail("Failed to create benchmark directory: %v", err)
+		}
+		if keep {
+			defer ui.Dim.Printf("Benchmark data kept in %s\n", dir)
+		} else {
+			defer os.RemoveAll(dir)
+		}
+
+		cfg := config.Get().WithDataDir(dir)
+		if err := os.MkdirAll(cfg.ProjectsDir, 0700); err != nil {
+			return fail("Failed to create benchmark directory: %v", err)
+		}
+
+		ui.PrintHeader("⏱  Storage Benchmark")
+		ui.Dim.Printf("%d project(s) x %d task(s) in %d module(s), %d time entries per task (seed %d)\n\n",
+			opts.projects, opts.tasks, opts.modules, opts.entries, seed)
+
+		results, err := runBench(cmd, cfg, opts, rand.New(rand.NewSource(seed)))
+		if err != nil {
+			return fail("Benchmark failed: %v", err)
+		}
+
+		table := ui.NewTableBuilder("Operation", "Count", "Total", "Per op")
+		for _, r := range results {
+			table.Row(r.name, fmt.Sprintf("%d", r.count), formatBenchDuration(r.total), formatBenchDuration(r.total/time.Duration(r.count)))
+		}
+		table.PrintSimple()
+		fmt.Println()
+		return nil
+	},
+}
+
+// benchOptions sizes the synthetic data set
+type benchOptions struct {
+	projects int
+	modules  int
+	tasks    int // Per project
+	entries  int // Per task
+	lookups  int
+}
+
+// benchResult is the timing of one benchmarked operation
+type benchResult struct {
+	name  string
+	count int
+	total time.Duration
+}
+
+// runBench generates the data set and times each storage operation against it
+func runBench(cmd *cobra.Command, cfg *config.Config, opts benchOptions, rng *rand.Rand) ([]benchResult, error) {
+	results := make([]benchResult, 0)
+	measure := func(name string, count int, fn func() error) error {
+		start := time.Now()
+		if err := fn(); err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+		results = append(results, benchResult{name: name, count: count, total: time.Since(start)})
+		return nil
+	}
+
+	projects := make([]*models.Project, opts.projects)
+	taskRefs := make([][2]string, 0, opts.projects*opts.tasks) // project, task ID
+	for i := range projects {
+		projects[i] = generateBenchProject(fmt.Sprintf("bench-%03d", i+1), opts, rng)
+		for _, task := range projects[i].GetAllTasks() {
+			taskRefs = append(taskRefs, [2]string{projects[i].Name, task.ID})
+		}
+	}
+
+	writer, err := storage.Open(cfg)
+	if err != nil {
+		return nil, err
+	}
+	err = measure("save project", len(projects), func() error {
+		for _, project := range projects {
+			if err := writer.SaveProject(project.Name, project); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// A fresh instance has an empty cache, like a new qix process
+	store, err := storage.Open(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	steps := []struct {
+		name  string
+		count int
+		fn    func() error
+	}{
+		{"load all (cold)", len(projects), func() error {
+			_, err := store.GetAllProjectsContext(cmd.Context())
+			return err
+		}},
+		{"load all (cached)", len(projects), func() error {
+			_, err := store.GetAllProjectsContext(cmd.Context())
+			return err
+		}},
+		{"rebuild index", 1, store.RebuildIndex},
+		{"search", len(benchWords), func() error {
+			for _, word := range benchWords {
+				if _, err := store.Search(word); err != nil {
+					return err
+				}
+			}
+			return nil
+		}},
+		{"find task", opts.lookups, func() error {
+			for i := 0; i < opts.lookups; i++ {
+				ref := taskRefs[rng.Intn(len(taskRefs))]
+				if _, _, err := store.FindTask(ref[0], ref[1]); err != nil {
+					return err
+				}
+			}
+			return nil
+		}},
+		{"update task", min(20, len(taskRefs)), func() error {
+			for i := 0; i < min(20, len(taskRefs)); i++ {
+				ref := taskRefs[rng.Intn(len(taskRefs))]
+				if err := store.UpdateTaskStatus(ref[0], ref[1], models.StatusDoing); err != nil {
+					return err
+				}
+			}
+			return nil
+		}},
+		{"time report (30 days)", 1, func() error {
+			today := time.Now()
+			_, err := store.GetAllTimeEntriesInRange(cmd.Context(), today.AddDate(0, 0, -29).Format("2006-01-02"), today.Format("2006-01-02"))
+			return err
+		}},
+		{"flush", 1, store.FlushAll},
+	}
+	for _, step := range steps {
+		if step.count == 0 {
+			continue
+		}
+		if err := measure(step.name, step.count, step.fn); err != nil {
+			return nil, err
+		}
+	}
+
+	return results, nil
+}
+
+// benchWords are used for synthetic titles and as search queries
+var benchWords = []string{
+	"login", "report", "export", "invoice", "dashboard", "cache", "migration", "billing",
+	"search", "upload", "profile", "sync", "webhook", "backup", "settings", "audit",
+}
+
+// generateBenchProject builds a project with the requested number of tasks and time entries
+func generateBenchProject(name string, opts benchOptions, rng *rand.Rand) *models.Project {
+	now := time.Now()
+	statuses := []models.TaskStatus{models.StatusTodo, models.StatusDoing, models.StatusDone, models.StatusBlocked}
+	priorities := []models.Priority{models.PriorityLow, models.PriorityMedium, models.PriorityHigh}
+
+	project := &models.Project{
+		Name:      name,
+		Tags:      []string{"bench"},
+		Modules:   make([]models.Module, 0, opts.modules),
+		Tasks:     make([]models.Task, 0),
+		Sprints:   make([]models.Sprint, 0),
+		CreatedAt: now,
+	}
+	for m := 0; m < opts.modules; m++ {
+		project.Modules = append(project.Modules, models.Module{
+			Name:      fmt.Sprintf("module-%d", m+1),
+			Tags:      make([]string, 0),
+			Tasks:     make([]models.Task, 0),
+			CreatedAt: now,
+		})
+	}
+
+	for t := 0; t < opts.tasks; t++ {
+		task := models.Task{
+			ID:             storage.GenerateTaskID(),
+			Title:          fmt.Sprintf("%s %s %d", benchWords[rng.Intn(len(benchWords))], benchWords[rng.Intn(len(benchWords))], t),
+			Description:    "Synthetic task generated by qix bench",
+			Status:         statuses[rng.Intn(len(statuses))],
+			Priority:       priorities[rng.Intn(len(priorities))],
+			EstimatedHours: float64(1 + rng.Intn(16)),
+			Tags:           []string{benchWords[rng.Intn(len(benchWords))]},
+			Dependencies:   make([]string, 0),
+			TimeEntries:    make([]models.TimeEntry, 0, opts.entries),
+			CreatedAt:      now,
+			UpdatedAt:      now,
+		}
+		for e := 0; e < opts.entries; e++ {
+			day := now.AddDate(0, 0, -rng.Intn(90))
+			task.TimeEntries = append(task.TimeEntries, models.TimeEntry{
+				Date:     day.Format("2006-01-02"),
+				Hours:    float64(1+rng.Intn(8)) / 2,
+				LoggedAt: day,
+			})
+		}
+
+		if opts.modules > 0 {
+			m := t % opts.modules
+			project.Modules[m].Tasks = append(project.Modules[m].Tasks, task)
+		} else {
+			project.Tasks = append(project.Tasks, task)
+		}
+	}
+
+	return project
+}
+
+// formatBenchDuration rounds a duration to a readable precision, in ASCII so table columns line up
+func formatBenchDuration(d time.Duration) string {
+	switch {
+	case d >= time.Second:
+		return fmt.Sprintf("%.2fs", d.Seconds())
+	case d >= time.Millisecond:
+		return fmt.Sprintf("%.2fms", float64(d)/float64(time.Millisecond))
+	case d >= time.Microsecond:
+		return fmt.Sprintf("%.2fus", float64(d)/float64(time.Microsecond))
+	default:
+		return fmt.Sprintf("%dns", d.Nanoseconds())
+	}
+}
+
+func init() {
+	benchCmd.Flags().Int("projects", 10, "Number of projects to generate")
+	benchCmd.Flags().Int("modules", 4, "Modules per project (0 for project-level tasks only)")
+	benchCmd.Flags().Int("tasks", 200, "Tasks per project")
+	benchCmd.Flags().Int("entries", 10, "Time entries per task")
+	benchCmd.Flags().Int("lookups", 1000, "Number of random task lookups")
+	benchCmd.Flags().Int64("seed", 1, "Random seed for the generated data")
+	benchCmd.Flags().Bool("keep", false, "Keep the generated data instead of deleting it")
+
+	noJSON(benchCmd)
+	rootCmd.AddCommand(benchCmd)
+}
@@ -0,0 +1,481 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mrbooshehri/qix-go/internal/i18n"
+	"github.com/mrbooshehri/qix-go/internal/storage"
+	"github.com/mrbooshehri/qix-go/internal/ui"
+	"github.com/mrbooshehri/qix-go/pkg/qix/models"
+	"github.com/spf13/cobra"
+)
+
+// boardGap is the space between board columns
+const boardGap = 2
+
+var boardCmd = &cobra.Command{
+	Use:   "board <project[/module]>",
+	Short: "Show tasks as a kanban board",
+	Long: `Show the tasks of a project or module in a column per status.
+
+In a terminal the board is interactive:
+
+  ←/→ h/l         choose a column
+  ↑/↓ k/j         choose a task
+  enter, space    pick up the task; move it with ←/→ and drop it with enter
+  < >             move the task one column left or right at once
+  esc             put a picked up task back
+  r               reload
+  q               quit
+
+Moved tasks are saved as soon as they are dropped. Elsewhere, and with --json,
+the board is printed once.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		projectName, moduleName := parsePath(args[0])
+		columns, _ := cmd.Flags().GetString("columns")
+
+		statuses, err := parseBoardColumns(columns)
+		if err != nil {
+			return invalid("%v", err)
+		}
+
+		board, err := loadBoard(projectName, moduleName, statuses)
+		if err != nil {
+			return fail("%v", err)
+		}
+
+		if jsonOutput {
+			printJSON(board)
+			return nil
+		}
+
+		screen, err := ui.OpenScreen()
+		if err != nil {
+			printBoard(board)
+			return nil
+		}
+		err = runBoard(screen, board)
+		screen.Close()
+		if err != nil {
+			return fail("%v", err)
+		}
+		return nil
+	},
+}
+
+type boardColumnView struct {
+	Status models.TaskStatus `json:"status"`
+	Tasks  []taskView        `json:"tasks"`
+}
+
+type boardView struct {
+	Project string            `json:"project"`
+	Module  string            `json:"module,omitempty"`
+	Columns []boardColumnView `json:"columns"`
+}
+
+// parseBoardColumns parses a comma separated list of statuses. Without any,
+// the board shows every status, open ones first.
+func parseBoardColumns(columns string) ([]models.TaskStatus, error) {
+	var statuses []models.TaskStatus
+	if strings.TrimSpace(columns) == "" {
+		for _, done := range []bool{false, true} {
+			for _, def := range models.Statuses() {
+				if def.Done == done {
+					statuses = append(statuses, def.Name)
+				}
+			}
+		}
+		return statuses, nil
+	}
+
+	seen := make(map[models.TaskStatus]bool)
+	for _, name := range strings.Split(columns, ",") {
+		status, err := models.ParseStatus(name)
+		if err != nil {
+			return nil, fmt.Errorf("invalid column '%s' (must be %s)", strings.TrimSpace(name), strings.Join(models.StatusNames(), ", "))
+		}
+		if seen[status] {
+			return nil, fmt.Errorf("column '%s' is listed twice", status)
+		}
+		seen[status] = true
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
+
+// loadBoard groups the tasks of a project or module by status. Tasks whose
+// status has no column are left out.
+func loadBoard(projectName, moduleName string, statuses []models.TaskStatus) (boardView, error) {
+	board := boardView{Project: projectName, Module: moduleName}
+
+	store := storage.Get()
+	project, err := store.LoadProject(projectName)
+	if err != nil {
+		return board, notFound("project not found: %s", projectName)
+	}
+
+	var views []taskView
+	if moduleName != "" {
+		tasks, err := store.ListTasksInModule(projectName, moduleName)
+		if err != nil {
+			return board, notFound("module not found: %v", err)
+		}
+		for _, task := range tasks {
+			views = append(views, newTaskView(projectName, moduleName, task))
+		}
+	} else {
+		for _, task := range project.Tasks {
+			views = append(views, newTaskView(projectName, "", task))
+		}
+		for _, module := range project.Modules {
+			for _, task := range module.Tasks {
+				views = append(views, newTaskView(projectName, module.Name, task))
+			}
+		}
+	}
+
+	for _, status := range statuses {
+		column := boardColumnView{Status: status, Tasks: []taskView{}}
+		for _, view := range views {
+			if view.Status == status {
+				column.Tasks = append(column.Tasks, view)
+			}
+		}
+		board.Columns = append(board.Columns, column)
+	}
+	return board, nil
+}
+
+// printBoard prints the board once, with every task
+func printBoard(board boardView) {
+	width := ui.TerminalWidth()
+	if width == 0 {
+		width = 80
+	}
+	colWidth := boardColumnWidth(width, len(board.Columns))
+
+	ui.PrintHeader(fmt.Sprintf("📌 Board: %s", boardTitle(board)))
+
+	rows := 0
+	for _, column := range board.Columns {
+		rows = max(rows, len(column.Tasks))
+	}
+
+	for _, line := range boardHeading(board, colWidth) {
+		fmt.Println(line)
+	}
+	for row := 0; row < rows; row++ {
+		cells := make([]string, len(board.Columns))
+		for i, column := range board.Columns {
+			if row < len(column.Tasks) {
+				cells[i] = boardCard(column.Tasks[row], colWidth, "  ")
+			}
+		}
+		fmt.Println(joinBoardCells(cells, colWidth))
+	}
+	fmt.Println()
+}
+
+// boardState is the selection of an interactive board
+type boardState struct {
+	board   boardView
+	col     int
+	rows    []int // Selected task in each column
+	offsets []int // First task shown in each column
+
+	// While a task is picked up, the column it was picked up from
+	held     bool
+	heldFrom int
+
+	message string
+}
+
+// runBoard shows the board until the user quits
+func runBoard(screen *ui.Screen, board boardView) error {
+	state := &boardState{
+		board:   board,
+		rows:    make([]int, len(board.Columns)),
+		offsets: make([]int, len(board.Columns)),
+	}
+
+	for {
+		screen.Draw(state.frame(screen.Size()))
+
+		key, err := screen.ReadKey()
+		if err != nil {
+			return fail("Failed to read the keyboard: %v", err)
+		}
+		state.message = ""
+
+		switch {
+		case key.Key == ui.KeyInterrupt, key.Key == ui.KeyRune && key.Rune == 'q':
+			if state.held {
+				state.drop(state.heldFrom)
+			}
+			return nil
+		case key.Key == ui.KeyEscape:
+			if !state.held {
+				return nil
+			}
+			state.moveHeld(state.heldFrom - state.col)
+			state.held = false
+		case key.Key == ui.KeyLeft, key.Key == ui.KeyRune && key.Rune == 'h':
+			if state.held {
+				state.moveHeld(-1)
+			} else {
+				state.selectColumn(-1)
+			}
+		case key.Key == ui.KeyRight, key.Key == ui.KeyRune && key.Rune == 'l':
+			if state.held {
+				state.moveHeld(1)
+			} else {
+				state.selectColumn(1)
+			}
+		case key.Key == ui.KeyUp, key.Key == ui.KeyRune && key.Rune == 'k':
+			state.selectRow(-1)
+		case key.Key == ui.KeyDown, key.Key == ui.KeyRune && key.Rune == 'j':
+			state.selectRow(1)
+		case key.Key == ui.KeyEnter, key.Key == ui.KeySpace:
+			if state.held {
+				state.drop(state.heldFrom)
+			} else if state.selected() != nil {
+				state.held = true
+				state.heldFrom = state.col
+			}
+		case key.Key == ui.KeyRune && (key.Rune == '<' || key.Rune == '>'):
+			if state.selected() == nil {
+				break
+			}
+			from := state.col
+			if state.held {
+				from = state.heldFrom
+			}
+			if key.Rune == '<' {
+				state.moveHeld(-1)
+			} else {
+				state.moveHeld(1)
+			}
+			state.drop(from)
+		case key.Key == ui.KeyRune && key.Rune == 'r':
+			state.reload()
+		}
+	}
+}
+
+// selected returns the selected task, or nil if its column is empty
+func (s *boardState) selected() *taskView {
+	tasks := s.board.Columns[s.col].Tasks
+	if len(tasks) == 0 {
+		return nil
+	}
+	return &tasks[s.rows[s.col]]
+}
+
+func (s *boardState) selectColumn(delta int) {
+	s.col = clampIndex(s.col+delta, len(s.board.Columns))
+}
+
+func (s *boardState) selectRow(delta int) {
+	if s.held {
+		return
+	}
+	s.rows[s.col] = clampIndex(s.rows[s.col]+delta, len(s.board.Columns[s.col].Tasks))
+}
+
+// moveHeld moves the selected task delta columns over, without saving it
+func (s *boardState) moveHeld(delta int) {
+	task := s.selected()
+	to := clampIndex(s.col+delta, len(s.board.Columns))
+	if task == nil || to == s.col {
+		return
+	}
+
+	moved := *task
+	from := &s.board.Columns[s.col]
+	row := s.rows[s.col]
+	from.Tasks = append(from.Tasks[:row], from.Tasks[row+1:]...)
+	s.rows[s.col] = clampIndex(row, len(from.Tasks))
+
+	s.col = to
+	target := &s.board.Columns[to]
+	row = min(s.rows[to], len(target.Tasks))
+	target.Tasks = append(target.Tasks[:row], append([]taskView{moved}, target.Tasks[row:]...)...)
+	s.rows[to] = row
+}
+
+// drop puts down the selected task, saving its new status if it was moved from
+// another column
+func (s *boardState) drop(from int) {
+	s.held = false
+	task := s.selected()
+	if task == nil || s.col == from {
+		return
+	}
+
+	status := s.board.Columns[s.col].Status
+	if err := storage.Get().UpdateTaskStatus(task.Project, task.ID, status); err != nil {
+		s.message = ui.Red.Sprint(i18n.Sprintf("Failed to move %s: %v", task.ID, err))
+		s.moveHeld(from - s.col)
+		return
+	}
+	task.Status = status
+	s.message = ui.Green.Sprint(i18n.Sprintf("Moved %s to %s", task.ID, status))
+}
+
+// reload reads the board again from storage, keeping the selected task selected
+func (s *boardState) reload() {
+	var id string
+	if task := s.selected(); task != nil {
+		id = task.ID
+	}
+
+	statuses := make([]models.TaskStatus, len(s.board.Columns))
+	for i, column := range s.board.Columns {
+		statuses[i] = column.Status
+	}
+	board, err := loadBoard(s.board.Project, s.board.Module, statuses)
+	if err != nil {
+		s.message = ui.Red.Sprint(err)
+		return
+	}
+	s.board = board
+
+	for i, column := range board.Columns {
+		s.rows[i] = clampIndex(s.rows[i], len(column.Tasks))
+		for row, task := range column.Tasks {
+			if task.ID == id {
+				s.col, s.rows[i] = i, row
+			}
+		}
+	}
+	s.message = i18n.T("Reloaded")
+}
+
+// frame draws the board for a screen of the given size
+func (s *boardState) frame(cols, rows int) []string {
+	colWidth := boardColumnWidth(cols, len(s.board.Columns))
+
+	lines := []string{ui.BoldCyan.Sprint(ui.TruncateWidth("📌 Board: "+boardTitle(s.board), cols)), ""}
+	lines = append(lines, boardHeading(s.board, colWidth)...)
+
+	// Room left for tasks, under the heading and above the status line
+	visible := max(rows-len(lines)-2, 1)
+
+	for i, column := range s.board.Columns {
+		// Scroll the selected task into view
+		row := s.rows[i]
+		if row < s.offsets[i] {
+			s.offsets[i] = row
+		} else if row >= s.offsets[i]+visible {
+			s.offsets[i] = row - visible + 1
+		}
+		s.offsets[i] = clampIndex(s.offsets[i], max(len(column.Tasks)-visible+1, 1))
+	}
+
+	for line := 0; line < visible; line++ {
+		cells := make([]string, len(s.board.Columns))
+		for i, column := range s.board.Columns {
+			if row := s.offsets[i] + line; row < len(column.Tasks) {
+				cells[i] = s.card(i, row, colWidth)
+			}
+		}
+		lines = append(lines, joinBoardCells(cells, colWidth))
+	}
+
+	lines = append(lines, "")
+	switch {
+	case s.message != "":
+		lines = append(lines, s.message)
+	case s.held:
+		lines = append(lines, ui.Dim.Sprint(ui.TruncateWidth(i18n.T("←/→ move  enter drop  esc put back"), cols)))
+	default:
+		lines = append(lines, ui.Dim.Sprint(ui.TruncateWidth(i18n.T("←/→ column  ↑/↓ task  enter pick up  < > move  r reload  q quit"), cols)))
+	}
+	return lines
+}
+
+// card draws a task of the board, highlighted if it is selected
+func (s *boardState) card(col, row, colWidth int) string {
+	task := s.board.Columns[col].Tasks[row]
+	if col != s.col || row != s.rows[col] {
+		return boardCard(task, colWidth, "  ")
+	}
+	marker := "> "
+	if s.held {
+		marker = "» "
+	}
+	return ui.Reverse(ui.PadRight(ui.TruncateWidth(marker+task.ID+" "+task.Title, colWidth), colWidth))
+}
+
+// boardTitle names the project or module on the board
+func boardTitle(board boardView) string {
+	if board.Module != "" {
+		return board.Project + "/" + board.Module
+	}
+	return board.Project
+}
+
+// boardHeading draws the column titles and the line under them
+func boardHeading(board boardView, colWidth int) []string {
+	titles := make([]string, len(board.Columns))
+	rules := make([]string, len(board.Columns))
+	for i, column := range board.Columns {
+		title := fmt.Sprintf("%s %s (%d)", ui.GetStatusIcon(column.Status), strings.ToUpper(string(column.Status)), len(column.Tasks))
+		titles[i] = ui.GetStatusColor(column.Status).Sprint(ui.TruncateWidth(title, colWidth))
+		rules[i] = ui.Dim.Sprint(strings.Repeat("─", colWidth))
+	}
+	return []string{joinBoardCells(titles, colWidth), joinBoardCells(rules, colWidth)}
+}
+
+// boardCard draws a task as a line of a column
+func boardCard(task taskView, colWidth int, indent string) string {
+	id := ui.Dim.Sprint(task.ID)
+	title := ui.TruncateWidth(task.Title, colWidth-len(indent)-len(task.ID)-1)
+	if len(indent)+len(task.ID) >= colWidth {
+		return ui.TruncateWidth(indent+task.ID, colWidth)
+	}
+	return indent + id + " " + title
+}
+
+// boardColumnWidth splits a width evenly between columns
+func boardColumnWidth(width, columns int) int {
+	if columns == 0 {
+		return width
+	}
+	return max((width-boardGap*(columns-1))/columns, 8)
+}
+
+// joinBoardCells lays cells out side by side
+func joinBoardCells(cells []string, colWidth int) string {
+	var b strings.Builder
+	for i, cell := range cells {
+		if i > 0 {
+			b.WriteString(strings.Repeat(" ", boardGap))
+		}
+		if i < len(cells)-1 {
+			cell = ui.PadRight(cell, colWidth)
+		}
+		b.WriteString(cell)
+	}
+	return strings.TrimRight(b.String(), " ")
+}
+
+// clampIndex keeps i within a list of n items
+func clampIndex(i, n int) int {
+	if i >= n {
+		i = n - 1
+	}
+	if i < 0 {
+		i = 0
+	}
+	return i
+}
+
+func init() {
+	boardCmd.Flags().String("columns", "", "Statuses to show as columns, in order (default: all, open ones first)")
+	boardCmd.ValidArgsFunction = taskPathCompletion
+	rootCmd.AddCommand(boardCmd)
+}
@@ -0,0 +1,396 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mrbooshehri/qix-go/internal/calsync"
+	"github.com/mrbooshehri/qix-go/internal/config"
+	"github.com/mrbooshehri/qix-go/internal/ics"
+	"github.com/mrbooshehri/qix-go/internal/storage"
+	"github.com/mrbooshehri/qix-go/internal/ui"
+	"github.com/mrbooshehri/qix-go/pkg/qix/models"
+)
+
+var calendarCmd = &cobra.Command{
+	Use:   "calendar",
+	Short: "Put sprints, due dates and logged work in your calendar",
+	Long: `Write qix dates into a calendar over CalDAV (Nextcloud, Radicale, Fastmail,
+iCloud and others) or with the Google Calendar API. Configure one in the
+config file:
+
+  caldav_url = https://dav.example.com/calendars/me/work/
+  caldav_user = me
+  caldav_password = ...        (or QIX_CALDAV_PASSWORD)
+
+or
+
+  google_calendar_id = primary
+  google_token_command = gcloud auth print-access-token
+
+where the command prints an OAuth access token with the calendar.events
+scope; google_token (or GOOGLE_OAUTH_ACCESS_TOKEN) sets one directly.
+
+To subscribe to a read-only feed instead, see 'qix serve ics'.`,
+}
+
+var calendarSyncCmd = &cobra.Command{
+	Use:   "sync",
+	Short: "Create and update calendar events for sprints, due dates and logged work",
+	Long: `Create an all-day event for each sprint and each task due date, a repeating
+event for each recurring task, and an event for each time entry logged since
+--since, from when the work started to when it was logged.
+
+qix records the events it created in calendar-sync.json in the data
+directory. Syncing again updates events that changed, deletes those of
+sprints, tasks and time entries that are gone (and due dates of tasks that
+are done, unless --done is given), and leaves the rest alone, so it can run
+from cron. With --project only those projects' events are synced or deleted.
+
+The calendar is the CalDAV one if caldav_url is set, else the Google one;
+--to picks one when both are configured.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		target, _ := cmd.Flags().GetString("to")
+		names, _ := cmd.Flags().GetStringSlice("project")
+		includeDone, _ := cmd.Flags().GetBool("done")
+		sessions, _ := cmd.Flags().GetBool("sessions")
+		sinceStr, _ := cmd.Flags().GetString("since")
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+		since := truncateDay(time.Now()).AddDate(0, 0, -30)
+		if sinceStr != "" {
+			parsed, err := ui.ParseDate(sinceStr)
+			if err != nil {
+				return invalid("Invalid date format. Use: %s", ui.DateFormats())
+			}
+			since = parsed
+		}
+
+		calendar, err := remoteCalendar(target)
+		if err != nil {
+			return fail("%v", err)
+		}
+
+		store := storage.Get()
+		var projects []*models.Project
+		if len(names) == 0 {
+			if projects, err = store.GetAllProjects(); err != nil {
+				return fail("Failed to load projects: %v", err)
+			}
+		} else {
+			for _, name := range names {
+				project, err := store.LoadProject(name)
+				if err != nil {
+					return notFound("Project not found: %s", name)
+				}
+				projects = append(projects, project)
+			}
+		}
+
+		state, err := store.LoadCalendarSync()
+		if err != nil {
+			return fail("%v", err)
+		}
+		synced := state.Calendars[calendar.Name()]
+		if synced == nil {
+			synced = make(map[string]models.SyncedEvent)
+			state.Calendars[calendar.Name()] = synced
+		}
+
+		events := calendarEvents(projects, includeDone, sessions, since.Format("2006-01-02"))
+		plan := planCalendarSync(events, synced, names)
+		if dryRun {
+			if jsonOutput {
+				printJSON(newCalendarSyncView(calendar.Name(), plan, nil))
+				return nil
+			}
+			printCalendarPlan(plan)
+			ui.PrintInfo("Dry run: %d event(s) would be created, %d updated and %d deleted in %s",
+				len(plan.Create), len(plan.Update), len(plan.Delete), calendar.Name())
+			return nil
+		}
+
+		ctx := context.Background()
+		failures := make([]string, 0)
+		var authErr error
+		done := calendarPlan{Unchanged: plan.Unchanged}
+		progress := ui.StartProgress("Syncing", "events", plan.changes())
+		for _, change := range plan.all() {
+			progress.Add(1)
+			if change.Delete {
+				err = calendar.Delete(ctx, change.Synced.Ref)
+			} else {
+				var ref string
+				if ref, err = calendar.Put(ctx, change.Synced.Ref, change.Event); err == nil {
+					synced[change.Event.UID] = models.SyncedEvent{
+						Ref:      ref,
+						Hash:     calsync.Hash(change.Event),
+						Project:  change.Project,
+						SyncedAt: time.Now(),
+					}
+				}
+			}
+			if errors.Is(err, calsync.ErrUnauthorized) {
+				authErr = err
+				break
+			}
+			if err != nil {
+				failures = append(failures, fmt.Sprintf("%s: %v", change.summary(), err))
+				continue
+			}
+			if change.Delete {
+				delete(synced, change.UID)
+			}
+			done.add(change)
+		}
+		progress.Stop()
+
+		// Record what was done, even if not everything was
+		if err := store.SaveCalendarSync(state); err != nil {
+			return fail("Failed to save calendar sync state: %v", err)
+		}
+		if authErr != nil {
+			return fail("%v. Check the calendar settings in %s.", authErr, config.Get().ConfigFile)
+		}
+
+		if jsonOutput {
+			printJSON(newCalendarSyncView(calendar.Name(), done, failures))
+			return nil
+		}
+		for _, failure := range failures {
+			ui.PrintWarning("%s", failure)
+		}
+		ui.PrintSuccess("Synced %s: %d created, %d updated, %d deleted, %d unchanged",
+			calendar.Name(), len(done.Create), len(done.Update), len(done.Delete), done.Unchanged)
+		return nil
+	},
+}
+
+// remoteCalendar returns the configured calendar, or the one named by
+// target (caldav or google)
+func remoteCalendar(target string) (calsync.Calendar, error) {
+	cfg := config.Get()
+	calendarCfg := cfg.Calendar
+	if target == "" {
+		switch {
+		case calendarCfg.CalDAVURL != "":
+			target = "caldav"
+		case calendarCfg.GoogleToken != "" || calendarCfg.GoogleTokenCommand != "":
+			target = "google"
+		default:
+			return nil, fmt.Errorf("no calendar configured: set caldav_url, or google_token_command, in %s", cfg.ConfigFile)
+		}
+	}
+
+	switch strings.ToLower(target) {
+	case "caldav":
+		return calsync.NewCalDAV(calendarCfg.CalDAVURL, calendarCfg.CalDAVUser, calendarCfg.CalDAVPassword)
+	case "google":
+		token := calendarCfg.GoogleToken
+		if calendarCfg.GoogleTokenCommand != "" {
+			args, err := splitCommandLine(calendarCfg.GoogleTokenCommand)
+			if err != nil || len(args) == 0 {
+				return nil, fmt.Errorf("invalid google_token_command: %s", calendarCfg.GoogleTokenCommand)
+			}
+			out, err := exec.Command(args[0], args[1:]...).Output()
+			if err != nil {
+				return nil, fmt.Errorf("google_token_command failed: %v", err)
+			}
+			token = strings.TrimSpace(string(out))
+		}
+		return calsync.NewGoogle(calendarCfg.GoogleCalendarID, token)
+	default:
+		return nil, fmt.Errorf("unknown calendar: %s (use caldav or google)", target)
+	}
+}
+
+// calendarEvent is an event and the project it belongs to
+type calendarEvent struct {
+	Project string
+	Event   ics.Event
+}
+
+// calendarEvents returns the events of the sprints, due dates and recurring
+// tasks of projects and, if sessions is set, of the time logged since since
+func calendarEvents(projects []*models.Project, includeDone, sessions bool, since string) []calendarEvent {
+	jiraBaseURL := config.Get().JiraBaseURL
+	events := make([]calendarEvent, 0)
+	for _, project := range projects {
+		for _, event := range buildCalendar([]*models.Project{project}, includeDone, jiraBaseURL).Events {
+			events = append(events, calendarEvent{Project: project.Name, Event: event})
+		}
+		if !sessions {
+			continue
+		}
+
+		modules := taskModules(project)
+		for _, task := range project.GetAllTasks() {
+			markers := worklogMarkers(task.ID, task.TimeEntries)
+			for i, entry := range task.TimeEntries {
+				if entry.Hours <= 0 || entry.Date < since {
+					continue
+				}
+				start := workStarted(entry)
+				events = append(events, calendarEvent{Project: project.Name, Event: ics.Event{
+					// The marker tells entries apart like it does for 'jira pushtime'
+					UID:         strings.ReplaceAll(strings.TrimPrefix(markers[i], "qix:"), ":", "-") + "@qix",
+					Summary:     fmt.Sprintf("%s [%s]", task.Title, task.ID),
+					Description: fmt.Sprintf("Logged: %s\n%s", ui.FormatHours(entry.Hours), taskEventDescription(project.Name, modules[task.ID], task)),
+					Start:       start,
+					End:         start.Add(time.Duration(entry.Hours * float64(time.Hour))),
+					Categories:  []string{project.Name, "time"},
+					Stamp:       entry.LoggedAt,
+					Timed:       true,
+				}})
+			}
+		}
+	}
+	return events
+}
+
+// calendarChange is an event to write or delete
+type calendarChange struct {
+	calendarEvent
+	UID    string
+	Synced models.SyncedEvent // From the last sync; zero for new events
+	Delete bool
+}
+
+func (c calendarChange) summary() string {
+	if c.Delete {
+		return c.UID
+	}
+	return c.Event.Summary
+}
+
+// calendarPlan is what a sync changes in the calendar
+type calendarPlan struct {
+	Create    []calendarChange
+	Update    []calendarChange
+	Delete    []calendarChange
+	Unchanged int
+}
+
+func (p *calendarPlan) add(change calendarChange) {
+	switch {
+	case change.Delete:
+		p.Delete = append(p.Delete, change)
+	case change.Synced.Ref == "":
+		p.Create = append(p.Create, change)
+	default:
+		p.Update = append(p.Update, change)
+	}
+}
+
+func (p calendarPlan) changes() int {
+	return len(p.Create) + len(p.Update) + len(p.Delete)
+}
+
+func (p calendarPlan) all() []calendarChange {
+	all := make([]calendarChange, 0, p.changes())
+	all = append(all, p.Create...)
+	all = append(all, p.Update...)
+	return append(all, p.Delete...)
+}
+
+// planCalendarSync compares events with those synced before. Synced events
+// of projects not in names (all if it is empty) are left alone.
+func planCalendarSync(events []calendarEvent, synced map[string]models.SyncedEvent, names []string) calendarPlan {
+	plan := calendarPlan{}
+	current := make(map[string]bool, len(events))
+	for _, event := range events {
+		current[event.Event.UID] = true
+		previous, ok := synced[event.Event.UID]
+		if ok && previous.Hash == calsync.Hash(event.Event) {
+			plan.Unchanged++
+			continue
+		}
+		plan.add(calendarChange{calendarEvent: event, UID: event.Event.UID, Synced: previous})
+	}
+
+	inScope := make(map[string]bool, len(names))
+	for _, name := range names {
+		inScope[name] = true
+	}
+	uids := make([]string, 0)
+	for uid, event := range synced {
+		if !current[uid] && (len(names) == 0 || inScope[event.Project]) {
+			uids = append(uids, uid)
+		}
+	}
+	sort.Strings(uids)
+	for _, uid := range uids {
+		plan.add(calendarChange{calendarEvent: calendarEvent{Project: synced[uid].Project}, UID: uid, Synced: synced[uid], Delete: true})
+	}
+	return plan
+}
+
+func printCalendarPlan(plan calendarPlan) {
+	if plan.changes() == 0 {
+		return
+	}
+	table := ui.NewTableBuilder("Change", "Project", "Event", "Date")
+	for _, change := range plan.all() {
+		action, date := "update", ""
+		switch {
+		case change.Delete:
+			action = "delete"
+		case change.Synced.Ref == "":
+			action = "create"
+		}
+		if !change.Delete {
+			date = change.Event.Start.Format("2006-01-02")
+		}
+		table.Row(action, change.Project, change.summary(), date)
+	}
+	table.Print()
+}
+
+// calendarSyncView is the JSON result of 'calendar sync'
+type calendarSyncView struct {
+	Calendar  string   `json:"calendar"`
+	Created   []string `json:"created"` // Event UIDs
+	Updated   []string `json:"updated"`
+	Deleted   []string `json:"deleted"`
+	Unchanged int      `json:"unchanged"`
+	Failures  []string `json:"failures"`
+}
+
+func newCalendarSyncView(name string, plan calendarPlan, failures []string) calendarSyncView {
+	uids := func(changes []calendarChange) []string {
+		list := make([]string, 0, len(changes))
+		for _, change := range changes {
+			list = append(list, change.UID)
+		}
+		return list
+	}
+	return calendarSyncView{
+		Calendar:  name,
+		Created:   uids(plan.Create),
+		Updated:   uids(plan.Update),
+		Deleted:   uids(plan.Delete),
+		Unchanged: plan.Unchanged,
+		Failures:  append(make([]string, 0), failures...),
+	}
+}
+
+func init() {
+	calendarSyncCmd.Flags().String("to", "", "Calendar to sync to: caldav or google (default the configured one)")
+	calendarSyncCmd.Flags().StringSliceP("project", "p", nil, "Only sync these projects (comma-separated or repeated)")
+	calendarSyncCmd.Flags().Bool("done", false, "Keep due dates of tasks that are done")
+	calendarSyncCmd.Flags().Bool("sessions", true, "Add an event for each time entry")
+	calendarSyncCmd.Flags().String("since", "", "Add time entries from this date (YYYY-MM-DD, default 30 days ago)")
+	calendarSyncCmd.RegisterFlagCompletionFunc("since", completeDates)
+	ownDryRun(calendarSyncCmd)
+
+	calendarCmd.AddCommand(calendarSyncCmd)
+	rootCmd.AddCommand(calendarCmd)
+}
@@ -0,0 +1,13 @@
+package cmd
+
+import (
+	"github.com/mrbooshehri/qix-go/internal/storage"
+	"github.com/mrbooshehri/qix-go/pkg/qix"
+)
+
+// qixClient returns a Client on the command's storage. Commands read and
+// change projects and tasks through it as other programs do; the events of
+// its changes are published with those of the command.
+func qixClient() *qix.Client {
+	return qix.New(storage.Get())
+}
@@ -0,0 +1,114 @@
+package cmd
+
+import (
+	"context"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mrbooshehri/qix-go/internal/config"
+	"github.com/mrbooshehri/qix-go/internal/hooks"
+	"github.com/mrbooshehri/qix-go/internal/logging"
+	"github.com/mrbooshehri/qix-go/internal/storage"
+	"github.com/mrbooshehri/qix-go/internal/ui"
+)
+
+// When a command hook runs, the first word of command_hook.<name>
+const (
+	hookBefore = "before"
+	hookAfter  = "after"
+)
+
+// runBeforeHooks runs the command hooks set to run before cmd, and returns
+// the name and error of the first that fails; the command doesn't run then
+func runBeforeHooks(cmd *cobra.Command) (string, error) {
+	for _, hook := range commandHooks(hookBefore, cmd) {
+		if err := runCommandHook(hook, cmd, "before-command"); err != nil {
+			return hook.Name, err
+		}
+	}
+	return "", nil
+}
+
+// runAfterHooks runs the command hooks set to run after cmd, which exited
+// with status. A hook that fails is warned about.
+func runAfterHooks(cmd *cobra.Command, status int) {
+	changed := storage.Get().Modified()
+	for _, hook := range commandHooks(hookAfter, cmd) {
+		if hook.ChangedOnly && !changed {
+			continue
+		}
+		env := []string{"QIX_STATUS=" + strconv.Itoa(status), "QIX_CHANGED=" + strconv.FormatBool(changed)}
+		if err := runCommandHook(hook, cmd, "after-command", env...); err != nil {
+			ui.PrintWarning("Command hook %s failed: %v", hook.Name, err)
+		}
+	}
+}
+
+// commandHooks returns the command hooks that run when for cmd. None run for
+// shell completions, for qix commands run by a hook so hooks can't loop, or
+// in a dry run, as a hook could change data.
+func commandHooks(when string, cmd *cobra.Command) []config.CommandHookConfig {
+	if os.Getenv(hooks.EnvHook) != "" || cmd.Name() == cobra.ShellCompRequestCmd || cmd.Name() == cobra.ShellCompNoDescRequestCmd || storage.DryRun() {
+		return nil
+	}
+
+	command := commandName(cmd)
+	matched := make([]config.CommandHookConfig, 0)
+	for _, hook := range config.CommandHooks() {
+		if hook.When != hookBefore && hook.When != hookAfter {
+			if when == hookBefore {
+				ui.PrintWarning("Ignoring command hook %s: it must start with before or after", hook.Name)
+			}
+			continue
+		}
+		if hook.When == when && hook.Command != "" && hookMatches(hook, command) {
+			matched = append(matched, hook)
+		}
+	}
+	return matched
+}
+
+// commandName returns the path of a command without the program name, such
+// as "task create"
+func commandName(cmd *cobra.Command) string {
+	return strings.TrimSpace(strings.TrimPrefix(cmd.CommandPath(), cmd.Root().Name()))
+}
+
+// hookMatches reports whether a hook runs for a command: if it lists no
+// commands, or the command or one of its parents
+func hookMatches(hook config.CommandHookConfig, command string) bool {
+	if len(hook.Commands) == 0 {
+		return true
+	}
+	for _, name := range hook.Commands {
+		name = strings.Join(strings.Fields(name), " ")
+		if command == name || strings.HasPrefix(command, name+" ") {
+			return true
+		}
+	}
+	return false
+}
+
+// runCommandHook runs a hook with the shell in the data directory. Besides
+// QIX_HOOK, it finds the command in QIX_COMMAND, the data directory in
+// QIX_DATA_DIR and the project the command is about in QIX_PROJECT.
+func runCommandHook(hook config.CommandHookConfig, cmd *cobra.Command, event string, env ...string) error {
+	cfg := config.Get()
+	env = append(os.Environ(), append([]string{
+		hooks.EnvHook + "=" + event,
+		"QIX_COMMAND=" + commandName(cmd),
+		"QIX_DATA_DIR=" + cfg.QixDir,
+	}, env...)...)
+	if project, ok := commandLog.fields["project"].(string); ok {
+		env = append(env, "QIX_PROJECT="+project)
+	}
+
+	start := time.Now()
+	err := hooks.Shell(context.Background(), hook.Command, cfg.QixDir, time.Duration(cfg.HookTimeout)*time.Second, env, os.Stderr)
+	logging.Debugf("Ran command hook %s %s %s in %s", hook.Name, hook.When, commandName(cmd), time.Since(start).Round(time.Millisecond))
+	return err
+}
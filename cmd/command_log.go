@@ -0,0 +1,120 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mrbooshehri/qix-go/internal/config"
+	"github.com/mrbooshehri/qix-go/internal/logging"
+	"github.com/mrbooshehri/qix-go/internal/storage"
+	"github.com/mrbooshehri/qix-go/internal/ui"
+)
+
+// commandLog is what is logged about the running command: its fields, and
+// when setup, the command itself and saving its changes started
+var commandLog struct {
+	fields           logging.Fields
+	start, run, save time.Time
+}
+
+// startCommandLog sets the log format and the fields every entry carries, and
+// logs that the command started
+func startCommandLog(cfg *config.Config, cmd *cobra.Command, args []string) {
+	if err := logging.SetFormat(cfg.LogFormat); err != nil {
+		fmt.Fprintf(os.Stderr, "Ignoring log_format: %v\n", err)
+	}
+	commandLog.fields = commandFields(cmd, args)
+	logging.SetContext(commandLog.fields)
+	logging.Infof("Starting command: %s %v", cmd.CommandPath(), args)
+}
+
+// commandFields returns the command being run, and the project, module and
+// task its arguments name, going by the placeholders in its usage
+func commandFields(cmd *cobra.Command, args []string) logging.Fields {
+	fields := logging.Fields{"command": cmd.CommandPath()}
+	placeholders := strings.Fields(cmd.Use)
+	if len(placeholders) > 0 {
+		placeholders = placeholders[1:]
+	}
+	for i, placeholder := range placeholders {
+		if i >= len(args) {
+			break
+		}
+		switch name := strings.Trim(placeholder, "<>[]."); {
+		case strings.HasPrefix(name, "project"):
+			projectName, moduleName := parsePath(args[i])
+			fields["project"] = projectName
+			if moduleName != "" {
+				fields["module"] = moduleName
+			}
+		case name == "task_id":
+			fields["task"] = args[i]
+		}
+	}
+	return fields
+}
+
+// endCommandLog logs how long the command took at debug level, or at error
+// level with the error if it failed
+func endCommandLog(err error) {
+	if commandLog.fields == nil {
+		// No command ran, e.g. only help was shown
+		return
+	}
+	end := time.Now()
+	fields := logging.Fields{"duration_ms": milliseconds(end.Sub(commandLog.start))}
+	phases := []struct {
+		name       string
+		start, end time.Time
+	}{
+		{"setup_ms", commandLog.start, commandLog.run},
+		{"run_ms", commandLog.run, commandLog.save},
+		{"save_ms", commandLog.save, end},
+	}
+	for _, phase := range phases {
+		if !phase.start.IsZero() && !phase.end.IsZero() {
+			fields[phase.name] = milliseconds(phase.end.Sub(phase.start))
+		}
+	}
+
+	if err == nil && ui.ErrorsPrinted() {
+		err = errors.New(ui.LastError())
+	}
+	if err != nil {
+		fields["error"] = err.Error()
+		logging.Log(logging.LevelError, "Command failed: "+commandLog.fields["command"].(string), fields)
+		return
+	}
+	logging.Log(logging.LevelDebug, "Finished command: "+commandLog.fields["command"].(string), fields)
+}
+
+// milliseconds returns d in milliseconds, to a hundredth
+func milliseconds(d time.Duration) float64 {
+	return math.Round(float64(d)/float64(time.Millisecond)*100) / 100
+}
+
+// recordUsage counts the command in the usage statistics if usage_stats is
+// set. Commands that stopped before storage was set up aren't counted.
+func recordUsage(failed bool) {
+	if commandLog.run.IsZero() || !config.Get().UsageStats || storage.DryRun() {
+		return
+	}
+	command := commandLog.fields["command"].(string)
+	switch command {
+	case statsUsageClearCmd.CommandPath():
+		// Don't start the statistics over with the command that removed them
+		return
+	case rootCmd.Name() + " " + cobra.ShellCompRequestCmd, rootCmd.Name() + " " + cobra.ShellCompNoDescRequestCmd:
+		// Shells ask for completions as you type; those aren't commands you ran
+		return
+	}
+	if err := storage.Get().RecordUsage(command, time.Since(commandLog.start), failed); err != nil {
+		logging.Warnf("Failed to record usage: %v", err)
+	}
+}
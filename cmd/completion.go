@@ -7,7 +7,7 @@ import (
 )
 
 var completionCmd = &cobra.Command{
-	Use:   "completion [bash|zsh]",
+	Use:   "completion [bash|zsh|fish|powershell]",
 	Short: "Generate shell completion scripts",
 	Long: `To load completions:
 
@@ -22,8 +22,21 @@ Bash:
 Zsh:
   qix completion zsh > "${fpath[1]}/_qix"
   autoload -U compinit && compinit
+
+Fish:
+  qix completion fish | source
+  # To load completions for each session, execute once:
+  qix completion fish > ~/.config/fish/completions/qix.fish
+
+PowerShell:
+  qix completion powershell | Out-String | Invoke-Expression
+  # To load completions for each session, add the line above to your
+  # profile ($PROFILE)
+
+Projects, modules, tasks, sprints and flag values are completed from your
+data in every shell; fish and PowerShell also show task titles.
 `,
-	ValidArgs: []string{"bash", "zsh"},
+	ValidArgs: []string{"bash", "zsh", "fish", "powershell"},
 	Args:      cobra.MatchAll(cobra.ExactArgs(1), cobra.OnlyValidArgs),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		switch args[0] {
@@ -31,11 +44,16 @@ Zsh:
 			return rootCmd.GenBashCompletion(os.Stdout)
 		case "zsh":
 			return rootCmd.GenZshCompletion(os.Stdout)
+		case "fish":
+			return rootCmd.GenFishCompletion(os.Stdout, true)
+		case "powershell":
+			return rootCmd.GenPowerShellCompletionWithDesc(os.Stdout)
 		}
 		return nil
 	},
 }
 
 func init() {
+	noJSON(completionCmd)
 	rootCmd.AddCommand(completionCmd)
 }
@@ -2,14 +2,18 @@ package cmd
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/spf13/cobra"
 
 	"github.com/mrbooshehri/qix-go/internal/config"
 	"github.com/mrbooshehri/qix-go/internal/logging"
 	"github.com/mrbooshehri/qix-go/internal/storage"
+	"github.com/mrbooshehri/qix-go/internal/ui"
+	"github.com/mrbooshehri/qix-go/pkg/qix/models"
 )
 
 var (
@@ -24,11 +28,20 @@ func ensureCompletionReady() error {
 			return
 		}
 		cfg := config.Get()
-		if err := logging.Init(cfg.LogFile); err != nil {
+		if err := logging.Init(cfg.LogFile, logRotation(cfg)); err != nil {
 			completionInitErr = err
 			return
 		}
 		logging.SetLevel(cfg.LogLevel)
+		if err := logging.SetFormat(cfg.LogFormat); err != nil {
+			logging.Warnf("Ignoring log_format: %v", err)
+		}
+		if err := config.ApplyStatuses(); err != nil {
+			logging.Warnf("Ignoring custom statuses: %v", err)
+		}
+		if err := ui.SetWeekStart(cfg.WeekStart); err != nil {
+			logging.Warnf("Ignoring week_start: %v", err)
+		}
 		logging.Debugf("Completion config initialized (projects: %s)", cfg.ProjectsDir)
 		completionInitErr = storage.Init()
 	})
@@ -142,6 +155,33 @@ func projectTaskArgCompletion(cmd *cobra.Command, args []string, toComplete stri
 	}
 }
 
+// taskUpdateArgCompletion completes the project, then task IDs, then the
+// status they are moved to
+func taskUpdateArgCompletion(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	switch len(args) {
+	case 0:
+		return completeProjectNames(toComplete)
+	case 1:
+		return completeTaskIDs(args[0], toComplete)
+	default:
+		ids, directive := completeTaskIDs(args[0], toComplete)
+		statuses, _ := completeStatuses(cmd, args, toComplete)
+		return append(statuses, ids...), directive
+	}
+}
+
+// completeStatuses completes the names of the statuses tasks can have
+func completeStatuses(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	ensureCompletionReady()
+	return models.StatusNames(), cobra.ShellCompDirectiveNoFileComp
+}
+
+// completePriorities completes the names of the priorities tasks can have
+func completePriorities(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	ensureCompletionReady()
+	return models.PriorityNames(), cobra.ShellCompDirectiveNoFileComp
+}
+
 func projectTwoTaskArgCompletion(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
 	switch len(args) {
 	case 0:
@@ -235,13 +275,16 @@ func trackPathTaskArgCompletion(cmd *cobra.Command, args []string, toComplete st
 	}
 }
 
-func twoProjectArgCompletion(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
-	switch len(args) {
-	case 0, 1:
-		return completeProjectNames(toComplete)
-	default:
+func projectModulePathsArgCompletion(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	return completeProjectModulePaths(toComplete)
+}
+
+// dirArgCompletion completes directories for a command's one argument
+func dirArgCompletion(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
 		return nil, cobra.ShellCompDirectiveNoFileComp
 	}
+	return nil, cobra.ShellCompDirectiveFilterDirs
 }
 
 func escapeCompletion(value string) string {
@@ -300,3 +343,143 @@ func sprintProjectSprintTaskArgCompletion(cmd *cobra.Command, args []string, toC
 		return nil, cobra.ShellCompDirectiveNoFileComp
 	}
 }
+
+// completeTags completes the tags already used in the project named by the
+// first argument, or in every project if there is none. Tags typed before the
+// last comma are kept and left out of the choices.
+func completeTags(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if err := ensureCompletionReady(); err != nil {
+		logging.Errorf("Tag completion init failed: %v", err)
+		return nil, cobra.ShellCompDirectiveError
+	}
+
+	store := storage.Get()
+	projectNames := []string{projectFromPath(firstArg(args))}
+	if projectNames[0] == "" || !store.ProjectExists(projectNames[0]) {
+		names, err := store.ListProjects()
+		if err != nil {
+			logging.Errorf("Failed to list projects for tag completion: %v", err)
+			return nil, cobra.ShellCompDirectiveError
+		}
+		projectNames = names
+	}
+
+	typed, partial := "", toComplete
+	if i := strings.LastIndex(toComplete, ","); i >= 0 {
+		typed, partial = toComplete[:i+1], toComplete[i+1:]
+	}
+	seen := make(map[string]bool)
+	for _, tag := range strings.Split(typed, ",") {
+		seen[tag] = true
+	}
+
+	add := func(tags []string, matches []string) []string {
+		for _, tag := range tags {
+			if !seen[tag] && strings.HasPrefix(strings.ToLower(tag), strings.ToLower(partial)) {
+				seen[tag] = true
+				matches = append(matches, typed+tag)
+			}
+		}
+		return matches
+	}
+	matches := make([]string, 0)
+	for _, name := range projectNames {
+		project, err := store.LoadProject(name)
+		if err != nil {
+			logging.Warnf("Unable to load project '%s' for tag completion: %v", name, err)
+			continue
+		}
+		matches = add(project.Tags, matches)
+		for _, module := range project.Modules {
+			matches = add(module.Tags, matches)
+		}
+		for _, task := range project.GetAllTasks() {
+			matches = add(task.Tags, matches)
+		}
+	}
+	sort.Strings(matches)
+
+	return matches, cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeSprintFlag completes the sprints of the project named by the first
+// argument
+func completeSprintFlag(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	projectName := projectFromPath(firstArg(args))
+	if projectName == "" {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return completeSprintNames(projectName, toComplete)
+}
+
+// completeDates completes the dates around today, described as "today",
+// "yesterday", "start of this week" and so on
+func completeDates(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	ensureCompletionReady()
+
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	weekStart := ui.StartOfWeek(today)
+	monthStart := today.AddDate(0, 0, 1-today.Day())
+	dates := []struct {
+		day  time.Time
+		name string
+	}{
+		{today, "today"},
+		{today.AddDate(0, 0, -1), "yesterday"},
+		{today.AddDate(0, 0, 1), "tomorrow"},
+		{weekStart, "start of this week"},
+		{weekStart.AddDate(0, 0, 6), "end of this week"},
+		{weekStart.AddDate(0, 0, -7), "start of last week"},
+		{weekStart.AddDate(0, 0, 7), "start of next week"},
+		{monthStart, "start of this month"},
+		{monthStart.AddDate(0, 1, -1), "end of this month"},
+		{monthStart.AddDate(0, -1, 0), "start of last month"},
+	}
+
+	// Days with several names, like today at the start of a week, are offered
+	// once with all of them
+	order := make([]string, 0, len(dates))
+	names := make(map[string][]string)
+	for _, date := range dates {
+		value := date.day.Format(ui.DateLayout)
+		if !strings.HasPrefix(value, toComplete) {
+			continue
+		}
+		if _, ok := names[value]; !ok {
+			order = append(order, value)
+		}
+		names[value] = append(names[value], date.name)
+	}
+
+	matches := make([]string, 0, len(order))
+	for _, value := range order {
+		matches = append(matches, fmt.Sprintf("%s\t%s", value, strings.Join(names[value], ", ")))
+	}
+	return matches, cobra.ShellCompDirectiveNoFileComp | cobra.ShellCompDirectiveKeepOrder
+}
+
+// completionFunc completes the arguments or a flag of a command
+type completionFunc func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective)
+
+// dateArgCompletion completes dates for the argument at position, and the
+// arguments before it with before
+func dateArgCompletion(position int, before completionFunc) completionFunc {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		switch {
+		case len(args) == position:
+			return completeDates(cmd, args, toComplete)
+		case len(args) < position && before != nil:
+			return before(cmd, args, toComplete)
+		default:
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+	}
+}
+
+func firstArg(args []string) string {
+	if len(args) == 0 {
+		return ""
+	}
+	return args[0]
+}
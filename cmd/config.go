@@ -0,0 +1,331 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mrbooshehri/qix-go/internal/config"
+	"github.com/mrbooshehri/qix-go/internal/ui"
+)
+
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Show and change settings",
+	Long: `Show and change the settings in the config file (~/.qix/config), such as
+color_output, backup_retention_days or jira_base_url, without editing it by
+hand. Values are checked before they are saved:
+
+  qix config list jira
+  qix config get date_format
+  qix config set backup_retention_days 14
+  qix config set webhook.team https://hooks.slack.com/services/T000/B000/XXXX
+  qix config unset theme
+
+Settings set in the environment, such as JIRA_API_TOKEN, take precedence over
+the file.`,
+}
+
+var configListCmd = &cobra.Command{
+	Use:   "list [filter]",
+	Short: "List settings, their values and where they come from",
+	Long: `List the settings whose key contains filter, or all of them, with their value
+and whether it comes from the config file, the environment or the default.
+Secrets are hidden; 'config get' shows them.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		fileValues, err := config.FileValues()
+		if err != nil {
+			return fail("Failed to read %s: %v", config.Get().ConfigFile, err)
+		}
+		filter := ""
+		if len(args) == 1 {
+			filter = strings.ToLower(args[0])
+		}
+
+		views := make([]settingView, 0)
+		for _, key := range settingKeys(fileValues) {
+			if strings.Contains(key, filter) {
+				views = append(views, newSettingView(key, fileValues, true))
+			}
+		}
+
+		if jsonOutput {
+			printJSON(views)
+			return nil
+		}
+		if len(views) == 0 {
+			ui.PrintWarning("No settings match %s", filter)
+			return nil
+		}
+		table := ui.NewTableBuilder("Key", "Value", "Source", "Description")
+		for _, view := range views {
+			table.Row(view.Key, view.Value, view.Source, view.Description)
+		}
+		table.Print()
+		return nil
+	},
+}
+
+var configGetCmd = &cobra.Command{
+	Use:   "get <key>",
+	Short: "Print the value of a setting",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		key := strings.ToLower(args[0])
+		fileValues, err := config.FileValues()
+		if err != nil {
+			return fail("Failed to read %s: %v", config.Get().ConfigFile, err)
+		}
+		if _, known := config.FindSetting(key); !known {
+			if _, set := fileValues[key]; !set {
+				return invalid("Unknown setting: %s (see 'qix config list')", key)
+			}
+		}
+
+		view := newSettingView(key, fileValues, false)
+		if jsonOutput {
+			printJSON(view)
+			return nil
+		}
+		fmt.Println(view.Value)
+		return nil
+	},
+}
+
+var configSetCmd = &cobra.Command{
+	Use:   "set <key> <value>",
+	Short: "Change a setting in the config file",
+	Long: `Change a setting in the config file, after checking that the value suits it.
+Keys qix doesn't know are refused unless --force is given.`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		key, value := strings.ToLower(args[0]), strings.TrimSpace(args[1])
+		force, _ := cmd.Flags().GetBool("force")
+
+		setting, known := config.FindSetting(key)
+		if !known && !force {
+			return invalid("Unknown setting: %s (see 'qix config list', or pass --force)", key)
+		}
+		if known {
+			if err := checkSetting(setting, key, value); err != nil {
+				return invalid("%v", err)
+			}
+		}
+
+		if err := config.SetValue(key, value); err != nil {
+			return fail("Failed to save %s: %v", config.Get().ConfigFile, err)
+		}
+		if setting.Secret {
+			value = "********"
+		}
+		ui.PrintSuccess("Set %s = %s", key, value)
+		if _, set := os.LookupEnv(setting.Env); setting.Env != "" && set {
+			ui.PrintWarning("%s is set in the environment, which takes precedence", setting.Env)
+		}
+		return nil
+	},
+}
+
+var configUnsetCmd = &cobra.Command{
+	Use:   "unset <key>",
+	Short: "Remove a setting from the config file, going back to its default",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		key := strings.ToLower(args[0])
+		removed, err := config.UnsetValue(key)
+		if err != nil {
+			return fail("Failed to save %s: %v", config.Get().ConfigFile, err)
+		}
+		if !removed {
+			ui.PrintWarning("%s is not set in %s", key, config.Get().ConfigFile)
+			return nil
+		}
+		ui.PrintSuccess("Unset %s", key)
+		return nil
+	},
+}
+
+var configEditCmd = &cobra.Command{
+	Use:   "edit",
+	Short: "Open the config file in your editor",
+	Long: `Open the config file in $VISUAL or $EDITOR, then check the settings in it
+once the editor exits.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		path := config.Get().ConfigFile
+		if _, err := os.Stat(path); os.IsNotExist(err) {
+			if err := os.WriteFile(path, nil, 0600); err != nil {
+				return fail("Failed to create %s: %v", path, err)
+			}
+		}
+
+		editor, err := splitCommandLine(editorCommand())
+		if err != nil || len(editor) == 0 {
+			return invalid("Invalid editor command: %s", editorCommand())
+		}
+		run := exec.Command(editor[0], append(editor[1:], path)...)
+		run.Stdin, run.Stdout, run.Stderr = os.Stdin, os.Stdout, os.Stderr
+		if err := run.Run(); err != nil {
+			return fail("Editor failed: %v", err)
+		}
+
+		fileValues, err := config.FileValues()
+		if err != nil {
+			return fail("The config file can't be read: %v", err)
+		}
+		keys := make([]string, 0, len(fileValues))
+		for key := range fileValues {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		problems := 0
+		for _, key := range keys {
+			setting, known := config.FindSetting(key)
+			if !known {
+				ui.PrintWarning("Unknown setting: %s", key)
+				problems++
+				continue
+			}
+			if err := checkSetting(setting, key, fileValues[key]); err != nil {
+				ui.PrintWarning("%v", err)
+				problems++
+			}
+		}
+		if problems == 0 {
+			ui.PrintSuccess("Saved %s", path)
+		}
+		return nil
+	},
+}
+
+// settingView is a setting as shown by 'config list' and 'config get'
+type settingView struct {
+	Key         string   `json:"key"`
+	Value       string   `json:"value"`
+	Source      string   `json:"source"` // file, env or default
+	Kind        string   `json:"kind,omitempty"`
+	Description string   `json:"description,omitempty"`
+	Env         string   `json:"env,omitempty"`
+	Values      []string `json:"values,omitempty"`
+}
+
+// newSettingView returns the setting of a key. hideSecrets masks the value of
+// secret settings.
+func newSettingView(key string, fileValues map[string]string, hideSecrets bool) settingView {
+	view := settingView{Key: key, Value: config.Value(key), Source: config.Source(key, fileValues)}
+	if setting, ok := config.FindSetting(key); ok {
+		view.Kind, view.Description, view.Env, view.Values = setting.Kind, setting.Description, setting.Env, setting.Values
+		if setting.Secret && hideSecrets && view.Value != "" {
+			view.Value = "********"
+		}
+	}
+	return view
+}
+
+// settingKeys returns the keys 'config list' shows: the settings qix knows,
+// and the keys of the config file, such as webhook.team
+func settingKeys(fileValues map[string]string) []string {
+	seen := make(map[string]bool)
+	keys := make([]string, 0, len(config.Settings)+len(fileValues))
+	for _, setting := range config.Settings {
+		if !setting.Named() {
+			keys = append(keys, setting.Key)
+			seen[setting.Key] = true
+		}
+	}
+	for key := range fileValues {
+		if !seen[key] {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// checkSetting returns an error if value doesn't suit the setting of key,
+// checking the values only other packages know, such as theme names
+func checkSetting(setting config.Setting, key, value string) error {
+	err := setting.Check(value)
+	if err != nil {
+		return fmt.Errorf("%s %w", key, err)
+	}
+	switch key {
+	case "theme":
+		if value == "" {
+			return nil
+		}
+		names := make([]string, 0)
+		for _, theme := range ui.Themes() {
+			if strings.EqualFold(theme.Name, value) {
+				return nil
+			}
+			names = append(names, theme.Name)
+		}
+		return fmt.Errorf("theme: unknown theme %q (use %s)", value, strings.Join(names, ", "))
+	case "task_fields":
+		err = ui.SetTaskFields(ui.ParseFields(value, ui.TaskFields))
+	case "project_fields":
+		err = ui.SetProjectFields(ui.ParseFields(value, ui.ProjectFields))
+	}
+	if err != nil {
+		return fmt.Errorf("%s: %w", key, err)
+	}
+	return nil
+}
+
+// editorCommand returns the command 'config edit' opens the file with
+func editorCommand() string {
+	for _, env := range []string{"VISUAL", "EDITOR"} {
+		if editor := strings.TrimSpace(os.Getenv(env)); editor != "" {
+			return editor
+		}
+	}
+	if runtime.GOOS == "windows" {
+		return "notepad"
+	}
+	return "vi"
+}
+
+// completeSettingKeys completes the keys of settings, and the values of the
+// key given to 'config set'
+func completeSettingKeys(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) == 1 && cmd == configSetCmd {
+		if setting, ok := config.FindSetting(args[0]); ok && setting.Kind == config.KindBool {
+			return []string{"true", "false"}, cobra.ShellCompDirectiveNoFileComp
+		} else if ok {
+			return setting.Values, cobra.ShellCompDirectiveNoFileComp
+		}
+	}
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	keys := make([]string, 0, len(config.Settings))
+	for _, setting := range config.Settings {
+		keys = append(keys, setting.Key)
+	}
+	return keys, cobra.ShellCompDirectiveNoFileComp
+}
+
+func init() {
+	configSetCmd.Flags().BoolP("force", "f", false, "Set keys qix doesn't know")
+
+	configGetCmd.ValidArgsFunction = completeSettingKeys
+	configSetCmd.ValidArgsFunction = completeSettingKeys
+	configUnsetCmd.ValidArgsFunction = completeSettingKeys
+
+	noJSON(configSetCmd, configUnsetCmd, configEditCmd)
+	configCmd.AddCommand(configListCmd)
+	configCmd.AddCommand(configGetCmd)
+	configCmd.AddCommand(configSetCmd)
+	configCmd.AddCommand(configUnsetCmd)
+	configCmd.AddCommand(configEditCmd)
+	rootCmd.AddCommand(configCmd)
+}
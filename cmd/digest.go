@@ -0,0 +1,351 @@
+package cmd
+
+import (
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mrbooshehri/qix-go/internal/config"
+	"github.com/mrbooshehri/qix-go/internal/mail"
+	"github.com/mrbooshehri/qix-go/internal/storage"
+	"github.com/mrbooshehri/qix-go/internal/ui"
+	"github.com/mrbooshehri/qix-go/pkg/qix/models"
+)
+
+var digestCmd = &cobra.Command{
+	Use:   "digest",
+	Short: "Email digests of your work",
+	Long: `Email a digest of the work logged, the tasks due and the active sprints,
+so qix reaches you instead of the other way around. Set up delivery in the
+config file, either over SMTP:
+
+  smtp_host = smtp.example.com
+  smtp_port = 587
+  smtp_user = me@example.com
+  smtp_password = ...          (or QIX_SMTP_PASSWORD)
+  mail_from = qix <me@example.com>
+  mail_to = me@example.com
+
+or through a local mail command that reads the message on standard input:
+
+  mail_command = sendmail -t
+
+Send digests on a schedule from cron, e.g. each weekday evening and on
+Friday afternoon:
+
+  0 18 * * 1-5  qix digest send daily
+  0 16 * * 5    qix digest send weekly`,
+}
+
+var digestSendCmd = &cobra.Command{
+	Use:   "send [daily|weekly]",
+	Short: "Email the daily or weekly digest",
+	Long: `Email the digest of a day, or with 'weekly' of the week containing it: the
+hours logged and tasks done, the tasks that are overdue or due in the next
+--days days, and the progress of active sprints. The day is today unless
+--date is given.
+
+--to, --from and --smtp override the config file, and --command sends
+through a mail command instead of SMTP. --dry-run prints the email.
+
+Example:
+  qix digest send weekly --to team@example.com --smtp smtp.example.com:587`,
+	Args:      cobra.MaximumNArgs(1),
+	ValidArgs: []string{"daily", "weekly"},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		dateStr, _ := cmd.Flags().GetString("date")
+		projectName, _ := cmd.Flags().GetString("project")
+		days, _ := cmd.Flags().GetInt("days")
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+		period := "daily"
+		if len(args) > 0 {
+			period = strings.ToLower(args[0])
+		}
+		if period != "daily" && period != "weekly" {
+			return invalid("Invalid period: %s (use daily or weekly)", args[0])
+		}
+
+		day := truncateDay(time.Now())
+		if dateStr != "" {
+			parsed, err := ui.ParseDate(dateStr)
+			if err != nil {
+				return invalid("Invalid date format. Use: %s", ui.DateFormats())
+			}
+			day = parsed
+		}
+
+		projects, err := loadReportProjects(storage.Get(), projectName)
+		if err != nil {
+			return fail("%v", err)
+		}
+
+		cfg := config.Get().Mail
+		message := mail.Message{From: cfg.From, To: cfg.To}
+		if message.From == "" && strings.Contains(cfg.SMTPUser, "@") {
+			message.From = cfg.SMTPUser
+		}
+		if from, _ := cmd.Flags().GetString("from"); from != "" {
+			message.From = from
+		}
+		if to, _ := cmd.Flags().GetString("to"); to != "" {
+			message.To = splitTags(to)
+		}
+
+		d := buildDigest(projects, period, day, days)
+		message.Subject = d.subject()
+		message.Body = d.text()
+
+		if dryRun {
+			fmt.Printf("From: %s\nTo: %s\nSubject: %s\n\n%s", message.From, strings.Join(message.To, ", "), message.Subject, message.Body)
+			return nil
+		}
+
+		sender, err := mailSender(cmd, cfg)
+		if err != nil {
+			return fail("%v", err)
+		}
+		if err := sender.Send(message); err != nil {
+			return fail("Failed to send the digest: %v", err)
+		}
+		ui.PrintSuccess("Sent the %s digest to %s", period, strings.Join(message.To, ", "))
+		return nil
+	},
+}
+
+// mailSender returns how to deliver mail: through --smtp, --command or the
+// config file, in that order
+func mailSender(cmd *cobra.Command, cfg config.MailConfig) (mail.Sender, error) {
+	smtpAddr, _ := cmd.Flags().GetString("smtp")
+	command, _ := cmd.Flags().GetString("command")
+	if command == "" && smtpAddr == "" {
+		command = cfg.Command
+	}
+
+	if command != "" {
+		args, err := splitCommandLine(command)
+		if err != nil {
+			return nil, fmt.Errorf("invalid mail command: %v", err)
+		}
+		return mail.Command{Args: args}, nil
+	}
+
+	server := mail.SMTP{Host: cfg.SMTPHost, Port: cfg.SMTPPort, Username: cfg.SMTPUser, Password: cfg.SMTPPassword}
+	if smtpAddr != "" {
+		server.Host = smtpAddr
+		if host, port, err := net.SplitHostPort(smtpAddr); err == nil {
+			server.Host = host
+			if server.Port, err = strconv.Atoi(port); err != nil {
+				return nil, fmt.Errorf("invalid SMTP port: %s", port)
+			}
+		}
+	}
+	if server.Host == "" {
+		return nil, fmt.Errorf("no mail delivery configured (set smtp_host or mail_command in the config file)")
+	}
+	return server, nil
+}
+
+// digest is what 'digest send' emails
+type digest struct {
+	Period  string // daily or weekly
+	Date    time.Time
+	Days    int // How far ahead DueSoon looks
+	Summary periodSummary
+	Overdue []projectTask
+	DueSoon []projectTask
+	Sprints []digestSprint
+}
+
+// digestSprint is the progress of an active sprint
+type digestSprint struct {
+	Project string
+	Sprint  models.Sprint
+	Done    int
+	Hours   float64 // Logged on the sprint's tasks during the sprint
+}
+
+// buildDigest collects a day's or week's work and what is due as of date
+func buildDigest(projects []*models.Project, period string, date time.Time, days int) digest {
+	d := digest{Period: period, Date: date, Days: days}
+	if period == "weekly" {
+		start := ui.StartOfWeek(date)
+		d.Summary = summarizePeriod(projects, start, start.AddDate(0, 0, 6))
+	} else {
+		d.Summary = summarizePeriod(projects, date, date)
+	}
+	sort.SliceStable(d.Summary.Worked, func(i, j int) bool {
+		return d.Summary.Worked[i].Hours > d.Summary.Worked[j].Hours
+	})
+
+	today := date.Format("2006-01-02")
+	horizon := date.AddDate(0, 0, days).Format("2006-01-02")
+	for _, project := range projects {
+		tasks := make(map[string]models.Task)
+		for _, task := range project.GetAllTasks() {
+			tasks[task.ID] = task
+			if task.DueDate == "" || task.Status.IsDone() {
+				continue
+			}
+			item := projectTask{Project: project.Name, Task: task}
+			if task.IsOverdue(today) {
+				d.Overdue = append(d.Overdue, item)
+			} else if task.DueDate <= horizon {
+				d.DueSoon = append(d.DueSoon, item)
+			}
+		}
+
+		for _, sprint := range project.Sprints {
+			if sprint.ClosedAt != nil || today < sprint.StartDate || today > sprint.EndDate {
+				continue
+			}
+			ds := digestSprint{Project: project.Name, Sprint: sprint}
+			for _, id := range sprint.TaskIDs {
+				task, ok := tasks[id]
+				if !ok {
+					continue
+				}
+				if task.Status.IsDone() {
+					ds.Done++
+				}
+				for _, entry := range task.TimeEntries {
+					if entry.Date >= sprint.StartDate && entry.Date <= sprint.EndDate {
+						ds.Hours += entry.Hours
+					}
+				}
+			}
+			d.Sprints = append(d.Sprints, ds)
+		}
+	}
+
+	byDue := func(tasks []projectTask) {
+		sort.SliceStable(tasks, func(i, j int) bool { return tasks[i].Task.DueDate < tasks[j].Task.DueDate })
+	}
+	byDue(d.Overdue)
+	byDue(d.DueSoon)
+	return d
+}
+
+// span returns the dates the digest covers, e.g. "2024-05-06 to 2024-05-12"
+func (d digest) span() string {
+	if d.Period == "weekly" {
+		return d.Summary.startDate() + " to " + d.Summary.endDate()
+	}
+	return d.Summary.startDate()
+}
+
+func (d digest) subject() string {
+	subject := fmt.Sprintf("qix %s digest for %s: %s logged, %d task(s) done",
+		d.Period, d.span(), ui.FormatHours(d.Summary.TotalHours), len(d.Summary.Completed))
+	if len(d.Overdue) > 0 {
+		subject += fmt.Sprintf(", %d overdue", len(d.Overdue))
+	}
+	return subject
+}
+
+// text is the body of the email
+func (d digest) text() string {
+	var b strings.Builder
+	line := func(format string, args ...interface{}) {
+		fmt.Fprintf(&b, format+"\n", args...)
+	}
+	taskLabel := func(item projectTask) string {
+		return fmt.Sprintf("[%s] %s (%s)", item.Project, item.Task.Title, item.Task.ID)
+	}
+
+	title := "Daily digest for " + d.Date.Format("Monday, 2006-01-02")
+	if d.Period == "weekly" {
+		title = "Weekly digest for " + d.span()
+	}
+	line("%s", title)
+	line("%s", strings.Repeat("=", len(title)))
+	line("")
+
+	line("WORK")
+	line("%s logged on %d task(s), %d task(s) done", ui.FormatHours(d.Summary.TotalHours), len(d.Summary.Worked), len(d.Summary.Completed))
+	if d.Period == "weekly" {
+		line("")
+		for i := 0; i < d.Summary.days(); i++ {
+			day := d.Summary.Start.AddDate(0, 0, i)
+			line("  %s  %8s", day.Format("Mon 01-02"), ui.FormatHours(d.Summary.HoursByDay[day.Format("2006-01-02")]))
+		}
+	}
+	if len(d.Summary.Worked) > 0 {
+		line("")
+		for _, item := range d.Summary.Worked {
+			done := ""
+			if item.Task.Status.IsDone() {
+				done = ", done"
+			}
+			line("  - %s: %s%s", taskLabel(item.projectTask), ui.FormatHours(item.Hours), done)
+		}
+	}
+	if len(d.Summary.Completed) > 0 {
+		line("")
+		line("Done:")
+		for _, item := range d.Summary.Completed {
+			line("  - %s", taskLabel(item))
+		}
+	}
+	line("")
+
+	line("DUE")
+	if len(d.Overdue) == 0 && len(d.DueSoon) == 0 {
+		line("Nothing overdue or due in the next %d day(s).", d.Days)
+	}
+	for _, section := range []struct {
+		title string
+		tasks []projectTask
+	}{
+		{"Overdue:", d.Overdue},
+		{fmt.Sprintf("Due in the next %d day(s):", d.Days), d.DueSoon},
+	} {
+		if len(section.tasks) == 0 {
+			continue
+		}
+		line("%s", section.title)
+		for _, item := range section.tasks {
+			assignee := ""
+			if item.Task.Assignee != "" {
+				assignee = ", @" + item.Task.Assignee
+			}
+			line("  - %s, due %s (%s)%s", taskLabel(item), item.Task.DueDate, describeDueOffset(item.Task.DueDate, d.Date), assignee)
+		}
+	}
+	line("")
+
+	line("ACTIVE SPRINTS")
+	if len(d.Sprints) == 0 {
+		line("No active sprints.")
+	}
+	for _, s := range d.Sprints {
+		line("  - [%s] %s: %d/%d task(s) done, %s logged, ends %s (%s)", s.Project, s.Sprint.Name,
+			s.Done, len(s.Sprint.TaskIDs), ui.FormatHours(s.Hours), s.Sprint.EndDate, describeDueOffset(s.Sprint.EndDate, d.Date))
+	}
+
+	line("")
+	line("-- ")
+	line("Sent by qix digest send %s", d.Period)
+	return b.String()
+}
+
+func init() {
+	digestSendCmd.Flags().String("date", "", "Day of the digest, or a day in its week (YYYY-MM-DD, default today)")
+	digestSendCmd.RegisterFlagCompletionFunc("date", completeDates)
+	digestSendCmd.Flags().StringP("project", "p", "", "Only include this project")
+	digestSendCmd.Flags().Int("days", 7, "Include tasks due within this many days")
+	digestSendCmd.Flags().String("to", "", "Comma-separated recipients (default mail_to)")
+	digestSendCmd.Flags().String("from", "", "Sender address (default mail_from)")
+	digestSendCmd.Flags().String("smtp", "", "SMTP server as host[:port] (default smtp_host and smtp_port)")
+	digestSendCmd.Flags().String("command", "", "Send through this mail command, e.g. \"sendmail -t\" (default mail_command)")
+	ownDryRun(digestSendCmd)
+
+	noJSON(digestSendCmd)
+	digestCmd.AddCommand(digestSendCmd)
+	rootCmd.AddCommand(digestCmd)
+}
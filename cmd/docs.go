@@ -0,0 +1,115 @@
+package cmd
+
+import (
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/cobra/doc"
+
+	"github.com/mrbooshehri/qix-go/internal/ui"
+)
+
+var docsCmd = &cobra.Command{
+	Use:   "docs",
+	Short: "Generate the command reference as man pages or markdown",
+	Long: `Generate a page for every qix command, for packagers shipping man pages and
+for the website's command reference.
+
+Neither needs a data directory or config, so they work in a build sandbox.
+Set SOURCE_DATE_EPOCH to date the man pages for reproducible builds.`,
+	// Skip setting up config, storage and the rest; docs only read the
+	// command tree. Pages don't carry the date they were generated, which
+	// would make builds unreproducible.
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		rootCmd.DisableAutoGenTag = true
+	},
+	PersistentPostRun: func(cmd *cobra.Command, args []string) {},
+}
+
+var docsManCmd = &cobra.Command{
+	Use:   "man <dir>",
+	Short: "Write a man page for each command to dir",
+	Example: `  qix docs man /usr/share/man/man1
+  qix docs man build/man --section 1`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		section, _ := cmd.Flags().GetString("section")
+
+		date, err := sourceDate()
+		if err != nil {
+			return invalid("Invalid SOURCE_DATE_EPOCH: %v", err)
+		}
+		if err := os.MkdirAll(args[0], 0755); err != nil {
+			return fail("Failed to create %s: %v", args[0], err)
+		}
+
+		header := &doc.GenManHeader{
+			Section: section,
+			Source:  "qix " + version,
+			Manual:  "QIX Manual",
+			Date:    &date,
+		}
+		if err := doc.GenManTree(rootCmd, header, args[0]); err != nil {
+			return fail("Failed to write man pages: %v", err)
+		}
+		ui.PrintSuccess("Wrote %d man pages to %s", docPages(rootCmd), args[0])
+		return nil
+	},
+}
+
+var docsMarkdownCmd = &cobra.Command{
+	Use:     "markdown <dir>",
+	Short:   "Write a markdown page for each command to dir",
+	Example: `  qix docs markdown website/docs/commands`,
+	Args:    cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := os.MkdirAll(args[0], 0755); err != nil {
+			return fail("Failed to create %s: %v", args[0], err)
+		}
+
+		if err := doc.GenMarkdownTree(rootCmd, args[0]); err != nil {
+			return fail("Failed to write markdown pages: %v", err)
+		}
+		ui.PrintSuccess("Wrote %d markdown pages to %s", docPages(rootCmd), args[0])
+		return nil
+	},
+}
+
+// docPages returns the number of pages written for cmd and the commands
+// under it: all but hidden, deprecated and help commands, as cobra/doc skips
+func docPages(cmd *cobra.Command) int {
+	pages := 1
+	for _, child := range cmd.Commands() {
+		if child.IsAvailableCommand() && !child.IsAdditionalHelpTopicCommand() {
+			pages += docPages(child)
+		}
+	}
+	return pages
+}
+
+// sourceDate returns the date man pages carry: SOURCE_DATE_EPOCH if set, for
+// reproducible builds, else now
+func sourceDate() (time.Time, error) {
+	epoch := os.Getenv("SOURCE_DATE_EPOCH")
+	if epoch == "" {
+		return time.Now(), nil
+	}
+	seconds, err := strconv.ParseInt(epoch, 10, 64)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.Unix(seconds, 0).UTC(), nil
+}
+
+func init() {
+	docsManCmd.Flags().String("section", "1", "Manual section of the pages")
+	docsManCmd.ValidArgsFunction = dirArgCompletion
+	docsMarkdownCmd.ValidArgsFunction = dirArgCompletion
+
+	noJSON(docsCmd)
+	docsCmd.AddCommand(docsManCmd)
+	docsCmd.AddCommand(docsMarkdownCmd)
+	rootCmd.AddCommand(docsCmd)
+}
@@ -0,0 +1,256 @@
+package cmd
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mrbooshehri/qix-go/internal/storage"
+	"github.com/mrbooshehri/qix-go/internal/ui"
+	"github.com/mrbooshehri/qix-go/pkg/qix/models"
+)
+
+// Kinds of time anomalies
+const (
+	anomalyNegative  = "negative"
+	anomalyLong      = "long"
+	anomalyFuture    = "future"
+	anomalyDuplicate = "duplicate"
+	anomalyFullDay   = "full-day"
+	anomalyOrphaned  = "orphaned-session"
+)
+
+// timeAnomaly is a time entry, day or tracking session doctor finds
+// suspicious
+type timeAnomaly struct {
+	Kind    string
+	Project string // Empty for days, which span projects
+	TaskID  string
+	Entry   int // Index of the entry in the task's time entries
+	Problem string
+	Fix     string // What fixing does; empty if it has to be fixed by hand
+}
+
+// duplicateWindow is how close together two identical entries of a task must
+// have been logged to count as one session logged twice
+const duplicateWindow = time.Minute
+
+// findTimeAnomalies looks for negative entries, entries and days over 24
+// hours, entries dated after today, sessions logged twice, and a tracking
+// session on a task that no longer exists
+func findTimeAnomalies(store *storage.Storage, projectNames []string, now time.Time) []timeAnomaly {
+	today := now.Format("2006-01-02")
+	anomalies := make([]timeAnomaly, 0)
+
+	type dayEntry struct {
+		project, taskID string
+		hours           float64
+	}
+	days := make(map[string][]dayEntry)
+	longDays := make(map[string]bool)
+	projects := make(map[string]*models.Project)
+
+	for _, name := range projectNames {
+		project, err := store.LoadProject(name)
+		if err != nil {
+			// Reported by the project file checks
+			continue
+		}
+		projects[name] = project
+
+		for _, task := range project.GetAllTasks() {
+			where := fmt.Sprintf("%s/%s", name, task.ID)
+			for i, entry := range task.TimeEntries {
+				anomaly := timeAnomaly{Project: name, TaskID: task.ID, Entry: i}
+				switch {
+				case entry.Hours < 0:
+					anomaly.Kind = anomalyNegative
+					anomaly.Problem = fmt.Sprintf("%s: %s logged on %s", where, ui.FormatHours(entry.Hours), ui.FormatDate(entry.Date))
+					anomaly.Fix = "remove the entry"
+				case entry.Hours > 24:
+					anomaly.Kind = anomalyLong
+					anomaly.Problem = fmt.Sprintf("%s: %s logged on %s, more than a day", where, ui.FormatHours(entry.Hours), ui.FormatDate(entry.Date))
+					anomaly.Fix = "cut the entry to 24h"
+					longDays[entry.Date] = true
+				case entry.Date > today:
+					anomaly.Kind = anomalyFuture
+					anomaly.Problem = fmt.Sprintf("%s: %s logged on %s, in the future", where, ui.FormatHours(entry.Hours), ui.FormatDate(entry.Date))
+					anomaly.Fix = "remove the entry"
+					if logged := entry.LoggedAt.Local().Format("2006-01-02"); !entry.LoggedAt.IsZero() && logged <= today {
+						anomaly.Fix = "move the entry to " + ui.FormatDate(logged) + ", the day it was logged"
+					}
+				case isDuplicateEntry(task.TimeEntries, i):
+					anomaly.Kind = anomalyDuplicate
+					anomaly.Problem = fmt.Sprintf("%s: %s on %s logged twice", where, ui.FormatHours(entry.Hours), ui.FormatDate(entry.Date))
+					anomaly.Fix = "remove the second entry"
+				default:
+					days[entry.Date] = append(days[entry.Date], dayEntry{name, task.ID, entry.Hours})
+					continue
+				}
+				anomalies = append(anomalies, anomaly)
+			}
+		}
+	}
+
+	dates := make([]string, 0, len(days))
+	for date := range days {
+		dates = append(dates, date)
+	}
+	sort.Strings(dates)
+	for _, date := range dates {
+		total := 0.0
+		tasks := make([]string, 0, len(days[date]))
+		for _, entry := range days[date] {
+			total += entry.hours
+			tasks = append(tasks, entry.project+"/"+entry.taskID)
+		}
+		if total <= 24 || longDays[date] {
+			continue
+		}
+		anomalies = append(anomalies, timeAnomaly{
+			Kind:    anomalyFullDay,
+			Problem: fmt.Sprintf("%s logged on %s, more than a day (%s)", ui.FormatHours(total), ui.FormatDate(date), strings.Join(uniqueStrings(tasks), ", ")),
+		})
+	}
+
+	if session, err := store.GetActiveSession(); err == nil && session != nil {
+		projectName := strings.SplitN(session.Path, "/", 2)[0]
+		project, loaded := projects[projectName]
+		if !loaded && containsString(projectNames, projectName) {
+			// The project is there but can't be read; reported above
+			return anomalies
+		}
+		if project == nil || !hasTask(project, session.TaskID) {
+			anomalies = append(anomalies, timeAnomaly{
+				Kind:    anomalyOrphaned,
+				Project: projectName,
+				TaskID:  session.TaskID,
+				Problem: fmt.Sprintf("Tracking %s/%s since %s, which no longer exists", session.Path, session.TaskID, ui.FormatDateTime(session.StartTime)),
+				Fix:     "stop tracking without logging the time",
+			})
+		}
+	}
+	return anomalies
+}
+
+// isDuplicateEntry reports whether the entry at i repeats an earlier one: the
+// same hours on the same day, logged at the same time
+func isDuplicateEntry(entries []models.TimeEntry, i int) bool {
+	entry := entries[i]
+	for _, earlier := range entries[:i] {
+		if earlier.Date == entry.Date && math.Abs(earlier.Hours-entry.Hours) < 1e-9 &&
+			earlier.LoggedAt.Sub(entry.LoggedAt).Abs() < duplicateWindow {
+			return true
+		}
+	}
+	return false
+}
+
+func hasTask(project *models.Project, taskID string) bool {
+	for _, task := range project.GetAllTasks() {
+		if task.ID == taskID {
+			return true
+		}
+	}
+	return false
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func uniqueStrings(values []string) []string {
+	seen := make(map[string]bool)
+	unique := make([]string, 0, len(values))
+	for _, value := range values {
+		if !seen[value] {
+			seen[value] = true
+			unique = append(unique, value)
+		}
+	}
+	return unique
+}
+
+// fixTimeAnomalies fixes the anomalies that can be fixed, asking about each
+// one first unless fix is set, and returns how many were fixed
+func fixTimeAnomalies(store *storage.Storage, anomalies []timeAnomaly, fix bool) (int, error) {
+	chosen := make(map[string][]timeAnomaly)
+	order := make([]string, 0)
+	discard := false
+	for _, anomaly := range anomalies {
+		if anomaly.Fix == "" {
+			continue
+		}
+		if !fix && !ui.Confirm(fmt.Sprintf("%s: %s?", anomaly.Problem, anomaly.Fix)) {
+			continue
+		}
+		if anomaly.Kind == anomalyOrphaned {
+			discard = true
+			continue
+		}
+		key := anomaly.Project + "/" + anomaly.TaskID
+		if _, ok := chosen[key]; !ok {
+			order = append(order, key)
+		}
+		chosen[key] = append(chosen[key], anomaly)
+	}
+
+	fixed := 0
+	for _, key := range order {
+		fixes := chosen[key]
+		err := store.UpdateTask(fixes[0].Project, fixes[0].TaskID, func(t *models.Task) error {
+			t.TimeEntries = fixTimeEntries(t.TimeEntries, fixes)
+			return nil
+		})
+		if err != nil {
+			return fixed, fmt.Errorf("failed to fix %s: %w", key, err)
+		}
+		fixed += len(fixes)
+	}
+	if discard {
+		if err := store.DiscardTracking(); err != nil {
+			return fixed, fmt.Errorf("failed to stop tracking: %w", err)
+		}
+		fixed++
+	}
+	return fixed, nil
+}
+
+// fixTimeEntries returns a task's time entries with the fixes applied
+func fixTimeEntries(entries []models.TimeEntry, fixes []timeAnomaly) []models.TimeEntry {
+	remove := make(map[int]bool)
+	result := append([]models.TimeEntry(nil), entries...)
+	for _, anomaly := range fixes {
+		if anomaly.Entry >= len(result) {
+			continue
+		}
+		entry := &result[anomaly.Entry]
+		switch anomaly.Kind {
+		case anomalyNegative, anomalyDuplicate:
+			remove[anomaly.Entry] = true
+		case anomalyLong:
+			entry.Hours = 24
+		case anomalyFuture:
+			if entry.LoggedAt.IsZero() || entry.LoggedAt.After(time.Now()) {
+				remove[anomaly.Entry] = true
+			} else {
+				entry.Date = entry.LoggedAt.Local().Format("2006-01-02")
+			}
+		}
+	}
+
+	kept := result[:0]
+	for i, entry := range result {
+		if !remove[i] {
+			kept = append(kept, entry)
+		}
+	}
+	return kept
+}
@@ -0,0 +1,147 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mrbooshehri/qix-go/internal/config"
+	"github.com/mrbooshehri/qix-go/internal/storage"
+	"github.com/mrbooshehri/qix-go/internal/ui"
+	"github.com/mrbooshehri/qix-go/pkg/qix/models"
+)
+
+// dryRunAnnotation marks commands that can be run with --dry-run. Its value
+// tells how they report what they would have done.
+const dryRunAnnotation = "dry-run"
+
+const (
+	dryRunStorage = "storage" // The changes the storage skipped are listed afterwards
+	dryRunOwn     = "own"     // The command reports what it would do itself
+)
+
+// supportDryRun lets commands run with --dry-run. They run as usual against
+// the storage, which writes nothing, and the changes they would have saved
+// are listed afterwards. Only commands that change nothing but qix data
+// should support it.
+func supportDryRun(cmds ...*cobra.Command) {
+	annotateDryRun(dryRunStorage, cmds)
+}
+
+// ownDryRun lets commands run with --dry-run that report themselves what
+// they would do, such as importers. The storage writes nothing all the same.
+func ownDryRun(cmds ...*cobra.Command) {
+	annotateDryRun(dryRunOwn, cmds)
+}
+
+func annotateDryRun(kind string, cmds []*cobra.Command) {
+	for _, cmd := range cmds {
+		if cmd.Annotations == nil {
+			cmd.Annotations = make(map[string]string)
+		}
+		cmd.Annotations[dryRunAnnotation] = kind
+	}
+}
+
+// dryRunning reports whether the command runs with --dry-run
+func dryRunning(cmd *cobra.Command) bool {
+	return dryRunFlag && cmd.Annotations[dryRunAnnotation] != ""
+}
+
+// skipConfirm reports whether a command asks before changing data: not with
+// --force, nor in a dry run, which changes nothing
+func skipConfirm(cmd *cobra.Command) bool {
+	force, _ := cmd.Flags().GetBool("force")
+	return force || storage.DryRun()
+}
+
+// printDryRun lists the task changes and files a dry run didn't save
+func printDryRun(cmd *cobra.Command, changes []models.JournalEntry) {
+	if cmd.Annotations[dryRunAnnotation] != dryRunStorage {
+		// Commands with their own --dry-run report what they would do
+		return
+	}
+	files := storage.DryRunChanges()
+	if !porcelain {
+		fmt.Println()
+	}
+	if len(changes) == 0 && len(files) == 0 {
+		ui.PrintInfo("Dry run: nothing would change")
+		return
+	}
+	ui.PrintWarning("Dry run: nothing was saved")
+	if porcelain {
+		return
+	}
+
+	if tasks := netTaskChanges(changes); len(tasks) > 0 {
+		ui.PrintSubHeader("Task changes")
+		for _, change := range tasks {
+			printTaskChange(change)
+		}
+	}
+
+	ui.PrintSubHeader("Files")
+	for _, file := range files {
+		name := file.Path
+		if rel, err := filepath.Rel(config.Get().QixDir, file.Path); err == nil {
+			name = rel
+		}
+		switch {
+		case file.Removed:
+			ui.Red.Printf("  remove  %s\n", name)
+		case file.Appended:
+			ui.Yellow.Printf("  append  %s", name)
+			ui.Dim.Printf(" (%d bytes)\n", file.Size)
+		default:
+			ui.Yellow.Printf("  write   %s", name)
+			ui.Dim.Printf(" (%d bytes)\n", file.Size)
+		}
+	}
+}
+
+// netTaskChanges folds the changes to each task into one, from the task as
+// it was before the first to the task after the last, in the order the tasks
+// were first changed
+func netTaskChanges(changes []models.JournalEntry) []models.JournalEntry {
+	net := make([]models.JournalEntry, 0, len(changes))
+	index := make(map[string]int)
+	for _, change := range changes {
+		key := change.Project + "/" + change.TaskID
+		i, ok := index[key]
+		if !ok {
+			index[key] = len(net)
+			net = append(net, change)
+			continue
+		}
+		net[i].After = change.After
+		net[i].Module = change.Module
+	}
+	return net
+}
+
+// printTaskChange prints a task that would be added (+), removed (-) or
+// changed (~)
+func printTaskChange(change models.JournalEntry) {
+	where := change.Project
+	if change.Module != "" {
+		where += "/" + change.Module
+	}
+	switch {
+	case change.Before == nil && change.After != nil:
+		ui.Green.Printf("  + [%s] %s", change.After.ID, change.After.Title)
+		ui.Dim.Printf(" (%s)\n", where)
+	case change.Before != nil && change.After == nil:
+		ui.Red.Printf("  - [%s] %s", change.Before.ID, change.Before.Title)
+		ui.Dim.Printf(" (%s)\n", where)
+	case change.Before != nil:
+		before := locatedTask{task: *change.Before, module: change.Module}
+		after := locatedTask{task: *change.After, module: change.Module}
+		if details := taskChanges(before, after); len(details) > 0 {
+			ui.Yellow.Printf("  ~ [%s] %s: ", change.After.ID, change.After.Title)
+			fmt.Println(strings.Join(details, "; "))
+		}
+	}
+}
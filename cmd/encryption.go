@@ -0,0 +1,125 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/mrbooshehri/qix-go/internal/config"
+	"github.com/mrbooshehri/qix-go/internal/encryption"
+	"github.com/mrbooshehri/qix-go/internal/storage"
+	"github.com/mrbooshehri/qix-go/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var encryptionCmd = &cobra.Command{
+	Use:   "encryption",
+	Short: "Manage encryption of data at rest",
+	Long: `Project files, time entries, tracking data, the journal, the trash and
+backups are encrypted with AES-256-GCM when a passphrase is configured:
+
+  encryption_passphrase = <passphrase>      (or the QIX_PASSPHRASE variable)
+  encryption_key_file   = /path/to/keyfile  (takes precedence)
+
+Files are encrypted as they are next written. 'qix encryption apply' encrypts
+everything at once; 'qix encryption apply --decrypt' reverses it before you
+remove the passphrase. Keep the passphrase safe: encrypted data cannot be
+recovered without it.`,
+}
+
+// encryptionView is what 'encryption status' shows, for --json
+type encryptionView struct {
+	Enabled   bool   `json:"enabled"`
+	KeySource string `json:"key_source,omitempty"`
+	Encrypted int    `json:"encrypted"` // Data files
+	Plain     int    `json:"plain"`
+}
+
+var encryptionStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show whether data files are encrypted",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		store := storage.Get()
+		status, err := store.GetEncryptionStatus()
+		if err != nil {
+			return fail("Failed to read data files: %v", err)
+		}
+
+		if jsonOutput {
+			printJSON(encryptionView{
+				Enabled:   encryption.Enabled(),
+				KeySource: encryption.Source(),
+				Encrypted: status.Encrypted,
+				Plain:     status.Plain,
+			})
+			return nil
+		}
+
+		ui.PrintHeader("🔐 Encryption")
+
+		if source := encryption.Source(); source != "" {
+			ui.PrintSuccess("Encryption enabled (key from %s)", source)
+		} else {
+			ui.PrintInfo("Encryption disabled")
+		}
+
+		fmt.Println()
+		fmt.Printf("Encrypted files: %d\n", status.Encrypted)
+		fmt.Printf("Plain files:     %d\n", status.Plain)
+
+		if encryption.Enabled() && status.Plain > 0 {
+			fmt.Println()
+			ui.Dim.Println("Run 'qix encryption apply' to encrypt the remaining files now")
+		}
+		return nil
+	},
+}
+
+var encryptionApplyCmd = &cobra.Command{
+	Use:   "apply",
+	Short: "Encrypt (or decrypt) all data files now",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		decrypt, _ := cmd.Flags().GetBool("decrypt")
+		noBackup, _ := cmd.Flags().GetBool("no-backup")
+
+		if !encryption.Enabled() {
+			return fail("No passphrase configured; set encryption_passphrase or encryption_key_file first")
+		}
+
+		cfg := config.Get()
+		store := storage.Get()
+
+		if !noBackup {
+			backupName := fmt.Sprintf("qix_backup_pre_encryption_%s.tar.gz", time.Now().Format("20060102_150405"))
+			if err := createTarGz(cfg.QixDir, filepath.Join(cfg.BackupDir, backupName), false); err != nil {
+				return fail("Failed to create backup: %v", err)
+			}
+			ui.PrintInfo("Backup created: %s", backupName)
+		}
+
+		count, err := store.RewriteDataFiles(!decrypt)
+		if err != nil {
+			return fail("Failed after rewriting %d file(s): %v", count, err)
+		}
+
+		if decrypt {
+			ui.PrintSuccess("Decrypted %d file(s)", count)
+			ui.Dim.Println("Remove the passphrase from your config now, or files will be encrypted again as they are saved")
+		} else {
+			ui.PrintSuccess("Encrypted %d file(s)", count)
+		}
+		return nil
+	},
+}
+
+func init() {
+	encryptionApplyCmd.Flags().Bool("decrypt", false, "Write all data files unencrypted instead")
+	encryptionApplyCmd.Flags().Bool("no-backup", false, "Skip the backup taken before rewriting files")
+
+	noJSON(encryptionApplyCmd)
+	encryptionCmd.AddCommand(encryptionStatusCmd)
+	encryptionCmd.AddCommand(encryptionApplyCmd)
+	rootCmd.AddCommand(encryptionCmd)
+}
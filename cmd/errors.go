@@ -0,0 +1,177 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mrbooshehri/qix-go/internal/i18n"
+	"github.com/mrbooshehri/qix-go/internal/storage"
+	"github.com/mrbooshehri/qix-go/internal/ui"
+)
+
+// Exit statuses of failed commands, so scripts can tell failures apart
+const (
+	exitFailure  = 1 // Any other failure
+	exitUsage    = 2 // Unknown commands or flags, and invalid arguments or values
+	exitNotFound = 3 // A project, module, task or other item that doesn't exist
+	exitStorage  = 4 // Data files that can't be read, written or locked
+)
+
+// commandError is the error a command fails with. Its message is kept as a
+// format and arguments so the error handler prints it translated, like
+// ui.PrintError.
+type commandError struct {
+	format string
+	args   []interface{}
+	status int // Exit status; 0 to go by the errors among args
+
+	hint     string // Printed under the error, e.g. how to get past it
+	hintArgs []interface{}
+	usage    bool // cobra's error, for an unknown command or flag or the wrong arguments
+}
+
+func (e *commandError) Error() string {
+	return fmt.Sprintf(e.format, e.args...)
+}
+
+// Unwrap returns the errors among the arguments, so errors.Is and errors.As
+// look into them
+func (e *commandError) Unwrap() []error {
+	errs := make([]error, 0)
+	for _, arg := range e.args {
+		if err, ok := arg.(error); ok {
+			errs = append(errs, err)
+		}
+	}
+	return errs
+}
+
+// fail returns the error of a failed command, whose exit status goes by the
+// errors among args: not found or storage, else exitFailure
+func fail(format string, args ...interface{}) error {
+	return &commandError{format: format, args: args}
+}
+
+// invalid returns the error of a command given an invalid argument or value
+func invalid(format string, args ...interface{}) error {
+	return &commandError{format: format, args: args, status: exitUsage}
+}
+
+// notFound returns the error of a command whose project, module, task or
+// other item doesn't exist
+func notFound(format string, args ...interface{}) error {
+	return &commandError{format: format, args: args, status: exitNotFound}
+}
+
+// storageFailed returns the error of a command that couldn't read or write
+// the data files
+func storageFailed(format string, args ...interface{}) error {
+	return &commandError{format: format, args: args, status: exitStorage}
+}
+
+// usageError returns the error cobra fails with for an unknown command or
+// flag or the wrong arguments, which is printed with a pointer to --help
+func usageError(err error) error {
+	return &commandError{format: "%v", args: []interface{}{err}, status: exitUsage, usage: true}
+}
+
+// withHint adds a hint, printed dimmed under the error, to an error from fail,
+// invalid or notFound
+func withHint(err error, format string, args ...interface{}) error {
+	var cmdErr *commandError
+	if errors.As(err, &cmdErr) {
+		cmdErr.hint, cmdErr.hintArgs = format, args
+	}
+	return err
+}
+
+// exitCode returns the exit status of a command that failed with err
+func exitCode(err error) int {
+	var cmdErr *commandError
+	if !errors.As(err, &cmdErr) {
+		// Goes by the error itself, like fail
+		return fail("%v", err).(*commandError).exitCode()
+	}
+	return cmdErr.exitCode()
+}
+
+// exitCode returns the status the error was given, else the one of the first
+// error among its arguments that tells
+func (e *commandError) exitCode() int {
+	if e.status != 0 {
+		return e.status
+	}
+	for _, err := range e.Unwrap() {
+		var inner *commandError
+		if errors.As(err, &inner) {
+			if status := inner.exitCode(); status != exitFailure {
+				return status
+			}
+		}
+	}
+
+	var pathErr *fs.PathError
+	var linkErr *os.LinkError
+	switch {
+	case errors.Is(e, storage.ErrInvalidName):
+		return exitUsage
+	case errors.Is(e, storage.ErrNotFound), errors.Is(e, fs.ErrNotExist):
+		return exitNotFound
+	case errors.Is(e, storage.ErrStorage), errors.As(e, &pathErr), errors.As(e, &linkErr):
+		return exitStorage
+	}
+	return exitFailure
+}
+
+// printCommandError prints the error a command failed with
+func printCommandError(err error) {
+	var cmdErr *commandError
+	if !errors.As(err, &cmdErr) {
+		ui.PrintError("%v", err)
+		return
+	}
+	ui.PrintError(cmdErr.format, cmdErr.args...)
+	if cmdErr.hint != "" && !porcelain {
+		ui.Dim.Printf("  "+i18n.T(cmdErr.hint)+"\n", cmdErr.hintArgs...)
+	}
+}
+
+// checkUsage makes cobra's errors for the wrong arguments or flags of cmd
+// and its subcommands usage errors. The required flags and flag groups are
+// checked along with the arguments, before the command runs.
+func checkUsage(cmd *cobra.Command) {
+	for _, sub := range cmd.Commands() {
+		checkUsage(sub)
+	}
+	if !cmd.Runnable() {
+		// cobra only reports an unknown command while the Args of its parent
+		// are unset
+		return
+	}
+
+	validate := cmd.Args
+	cmd.Args = func(cmd *cobra.Command, args []string) error {
+		if validate != nil {
+			if err := validate(cmd, args); err != nil {
+				return usageError(err)
+			}
+		}
+		if err := cmd.ValidateRequiredFlags(); err != nil {
+			return usageError(err)
+		}
+		if err := cmd.ValidateFlagGroups(); err != nil {
+			return usageError(err)
+		}
+		if jsonOutput && !printsJSON(cmd) {
+			return &commandError{format: "'%s' has no JSON output", args: []interface{}{cmd.CommandPath()}, status: exitUsage, usage: true}
+		}
+		if dryRunFlag && !dryRunning(cmd) {
+			return &commandError{format: "'%s' has no dry run", args: []interface{}{cmd.CommandPath()}, status: exitUsage, usage: true}
+		}
+		return nil
+	}
+}
@@ -0,0 +1,302 @@
+package cmd
+
+import (
+	"context"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mrbooshehri/qix-go/internal/config"
+	"github.com/mrbooshehri/qix-go/internal/events"
+	"github.com/mrbooshehri/qix-go/internal/hooks"
+	"github.com/mrbooshehri/qix-go/internal/notify"
+	"github.com/mrbooshehri/qix-go/internal/storage"
+	"github.com/mrbooshehri/qix-go/internal/subscribers"
+	"github.com/mrbooshehri/qix-go/internal/ui"
+	"github.com/mrbooshehri/qix-go/pkg/qix/models"
+)
+
+var eventsCmd = &cobra.Command{
+	Use:   "events",
+	Short: "Send qix changes to webhooks, scripts and logs",
+	Long: `Every change qix makes is published as an event to its subscribers: the
+webhooks of 'qix notify', the scripts in the hooks directory, and those
+defined in the config file:
+
+  subscriber.ci = webhook https://example.com/qix-events
+  subscriber.audit = log ~/qix-events.log
+  subscriber.deploy = hook ~/bin/on-qix-event
+  subscriber.alerts = notify https://hooks.slack.com/services/T000/B000/XXXX
+
+A webhook gets each event as JSON, a log gets it as a line of JSON, a hook
+script reads it as JSON on standard input, and notify posts the message of
+task-done, task-blocked, sprint-closed and daily-summary events like
+'qix notify' does.
+
+Subscribers get every event unless filtered by event (a trailing * matches a
+prefix), project, task tag or task status:
+
+  subscriber_events.ci = task-*,sprint-closed
+  subscriber_projects.ci = web,api
+  subscriber_tags.alerts = urgent
+  subscriber_status.alerts = blocked
+
+Events:
+  project-created A project was created
+  project-removed A project was removed
+  module-created  A module was added to a project
+  module-removed  A module was removed
+  task-created    A task was added
+  task-updated    A task changed other than its status or time
+  task-status     A task changed status
+  task-started    A task changed to doing
+  task-done       A task changed to done
+  task-blocked    A task changed to blocked
+  task-removed    A task was removed
+  time-logged     Time was logged on a task
+  track-start     Time tracking started on a task
+  track-stop      Time tracking stopped, and the time was logged
+  sprint-created  A sprint was added to a project
+  sprint-closed   A sprint was closed
+  sprint-removed  A sprint was removed
+  daily-summary   A day's work, sent by 'qix notify daily'
+
+Changes are published by the storage, so those made through the Go API reach
+the subscribers too. Events are delivered in turn once the command has saved
+its changes; a subscriber that fails is reported but undoes nothing.`,
+}
+
+var eventsListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the subscribers and the events they get",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		for _, err := range eventBusErrors {
+			ui.PrintWarning("%v", err)
+		}
+
+		views := make([]subscriberView, 0, len(eventBus.Subscriptions()))
+		for _, sub := range eventBus.Subscriptions() {
+			views = append(views, newSubscriberView(sub))
+		}
+		if jsonOutput {
+			printJSON(views)
+			return nil
+		}
+
+		table := ui.NewTableBuilder("Name", "Kind", "Target", "Events")
+		for _, view := range views {
+			table.Row(view.Name, view.Kind, view.Target, view.Filter)
+		}
+		table.Print()
+		return nil
+	},
+}
+
+var eventsPublishCmd = &cobra.Command{
+	Use:   "publish <event> [project] [task_id]",
+	Short: "Publish a sample event to the subscribers",
+	Long: `Publish an event as if it happened, to try out the subscribers that get it.
+The event is about the task given, or an example task.`,
+	Args: cobra.RangeArgs(1, 3),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		event, err := sampleEvent(args)
+		if err != nil {
+			return err
+		}
+
+		for _, err := range eventBusErrors {
+			ui.PrintWarning("%v", err)
+		}
+		delivered := subscribersOf(event)
+		if delivered == 0 {
+			ui.PrintWarning("No subscribers get %s events", event.Type)
+			return nil
+		}
+
+		errs := eventBus.Publish(context.Background(), event)
+		for _, err := range errs {
+			ui.PrintError("%v", err)
+		}
+		if len(errs) == 0 {
+			ui.PrintSuccess("Published %s to %d subscriber(s)", event.Type, delivered)
+		}
+		return nil
+	},
+}
+
+// subscriberView is a subscriber as listed by 'events list'. Only the host of
+// URLs is shown since they usually hold a secret.
+type subscriberView struct {
+	Name     string   `json:"name"`
+	Kind     string   `json:"kind"`
+	Target   string   `json:"target,omitempty"`
+	Filter   string   `json:"filter"`
+	Events   []string `json:"events,omitempty"`
+	Projects []string `json:"projects,omitempty"`
+	Tags     []string `json:"tags,omitempty"`
+	Statuses []string `json:"statuses,omitempty"`
+}
+
+func newSubscriberView(sub events.Subscription) subscriberView {
+	view := subscriberView{
+		Name:     sub.Subscriber.Name(),
+		Filter:   sub.Filter.String(),
+		Events:   sub.Filter.Events,
+		Projects: sub.Filter.Projects,
+		Tags:     sub.Filter.Tags,
+		Statuses: sub.Filter.Statuses,
+	}
+	switch s := sub.Subscriber.(type) {
+	case hooks.Runner:
+		view.Kind, view.Target = "hooks", s.Dir
+	case subscribers.Named:
+		view.Kind, view.Target = s.Kind(), s.Target()
+		if u, err := url.Parse(s.Target()); err == nil && u.Host != "" {
+			view.Target = u.Host
+		}
+	default:
+		view.Kind, view.Target = "notify", "qix notify list"
+	}
+	return view
+}
+
+// eventBus gets the events of the changes the storage saves, and those
+// commands publish themselves
+var eventBus = &events.Bus{}
+
+// eventBusErrors are the subscribers that couldn't be set up, reported when
+// events are published
+var eventBusErrors []error
+
+// setUpEventBus subscribes the built-in subscribers and those in the config
+// file, and has the storage publish its changes to them. Hook output goes to
+// stderr so it doesn't mix with --json output.
+func setUpEventBus() {
+	eventBus, eventBusErrors = subscribers.Bus(config.Get(), os.Stderr)
+	storage.Get().SetBus(eventBus)
+}
+
+// publishEvents delivers the events of the changes saved so far, warning
+// about subscribers that fail
+func publishEvents() {
+	published, errs := storage.Get().PublishEvents(context.Background())
+	if published == 0 {
+		return
+	}
+	for _, err := range append(eventBusErrors, errs...) {
+		ui.PrintWarning("%v", err)
+	}
+}
+
+// subscribersOf returns how many subscribers act on an event: those whose
+// filter takes it, less the hooks directory and webhooks if none of their
+// hooks take it
+func subscribersOf(event events.Event) int {
+	count := 0
+	for _, sub := range eventBus.Subscriptions() {
+		if sub.Filter.Match(event) && actsOn(sub.Subscriber, event.Type) {
+			count++
+		}
+	}
+	return count
+}
+
+func actsOn(subscriber events.Subscriber, typ string) bool {
+	switch s := subscriber.(type) {
+	case subscribers.Named:
+		return actsOn(s.Subscriber, typ)
+	case hooks.Runner:
+		return len(s.Scripts(typ)) > 0
+	case *events.Notifications:
+		return s.Wants(typ)
+	}
+	return true
+}
+
+// eventTask returns the task an event is about, or nil if it can't be found
+func eventTask(projectName, taskID string) *notify.TaskInfo {
+	task, location, err := storage.Get().FindTask(projectName, taskID)
+	if err != nil {
+		return nil
+	}
+	info := events.NewTaskInfo(projectName, locationModule(location), *task)
+	return &info
+}
+
+// sampleEvent returns the event 'events publish' and 'hooks test' send for
+// args: the event type, then optionally a project and task
+func sampleEvent(args []string) (events.Event, error) {
+	event := events.Event{Type: args[0], Project: "example"}
+	if !events.Known(event.Type) {
+		return event, invalid("Unknown event: %s (use %s)", event.Type, strings.Join(events.Types, ", "))
+	}
+
+	task := &notify.TaskInfo{ID: "abcd1234", Title: "Example task", Project: "example", Status: "todo", Priority: "medium"}
+	if len(args) > 1 {
+		event.Project = args[1]
+		task.Project = args[1]
+	}
+	if len(args) == 3 {
+		if task = eventTask(args[1], args[2]); task == nil {
+			return event, notFound("Task not found: %s", args[2])
+		}
+	}
+	today := time.Now().Format("2006-01-02")
+	switch event.Type {
+	case events.TaskStarted:
+		task.Status, task.Previous = string(models.StatusDoing), string(models.StatusTodo)
+	case events.TaskDone, events.TaskStatus:
+		task.Status, task.Previous = string(models.StatusDone), string(models.StatusDoing)
+	case events.TaskBlocked:
+		task.Status, task.Previous = string(models.StatusBlocked), string(models.StatusDoing)
+	case events.TrackStart, events.TrackStop:
+		start := time.Now().Add(-time.Hour).Truncate(time.Second)
+		event.Session = &events.Session{Path: event.Project, Start: start}
+		if event.Type == events.TrackStop {
+			end := start.Add(time.Hour)
+			event.Session.End, event.Session.Hours = &end, 1
+		}
+	case events.ProjectCreated, events.ProjectRemoved:
+		task = nil
+	case events.ModuleCreated, events.ModuleRemoved:
+		event.Module = "example-module"
+		task = nil
+	case events.SprintCreated, events.SprintClosed, events.SprintRemoved:
+		event.Sprint = &notify.SprintInfo{Name: "example-sprint", StartDate: today, EndDate: today, Tasks: 4, Done: 3, Hours: 12}
+		task = nil
+	case events.DailySummary:
+		worked := *task
+		worked.Hours = 2
+		event.Project = ""
+		event.Summary = &notify.SummaryInfo{Date: today, Hours: 2, Worked: []notify.TaskInfo{worked}, Completed: []notify.TaskInfo{}}
+		task = nil
+	}
+	event.Task = task
+	return event, nil
+}
+
+// completeSampleEvent completes the arguments of 'events publish' and 'hooks test'
+func completeSampleEvent(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	switch len(args) {
+	case 0:
+		return events.Types, cobra.ShellCompDirectiveNoFileComp
+	case 1:
+		return completeProjectNames(toComplete)
+	case 2:
+		return completeTaskIDs(args[1], toComplete)
+	}
+	return nil, cobra.ShellCompDirectiveNoFileComp
+}
+
+func init() {
+	eventsPublishCmd.ValidArgsFunction = completeSampleEvent
+
+	noJSON(eventsPublishCmd)
+	eventsCmd.AddCommand(eventsListCmd)
+	eventsCmd.AddCommand(eventsPublishCmd)
+	rootCmd.AddCommand(eventsCmd)
+}
@@ -0,0 +1,89 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/mrbooshehri/qix-go/internal/config"
+	"github.com/mrbooshehri/qix-go/internal/storage"
+	"github.com/mrbooshehri/qix-go/internal/ui"
+	"github.com/mrbooshehri/qix-go/internal/vault"
+)
+
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export projects to other tools",
+	Long: `Write projects in formats other tools read. To export the tasks of a
+project as CSV or JSON, see 'qix task export'.`,
+}
+
+var exportMarkdownCmd = &cobra.Command{
+	Use:   "markdown <project> <dir>",
+	Short: "Export a project as Markdown notes for Obsidian or Notion",
+	Long: `Write a project as a folder of Markdown notes under dir, such as an
+Obsidian vault or a folder to import into Notion:
+
+  <dir>/<project>/<project>.md                   The project, with its modules and sprints
+  <dir>/<project>/<module>/<module>.md           Each module, with its tasks
+  <dir>/<project>/<module>/<title> (<id>).md     Each task
+
+Notes start with YAML frontmatter (status, priority, tags, estimates, due
+date, links to the project, module, parent and dependencies) that Obsidian
+shows as properties and Dataview can query. Links are Markdown links, or
+Obsidian [[wiki links]] with --wikilinks.
+
+Running the export again only rewrites notes that changed and removes notes
+of deleted tasks, so it can run often, or continuously with --watch. Notes
+qix wrote are overwritten; keep your own notes in other files and link to
+them.
+
+Example:
+  qix export markdown web ~/Notes/Work --wikilinks --watch=1m`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		projectName, dir := args[0], args[1]
+		wikiLinks, _ := cmd.Flags().GetBool("wikilinks")
+
+		project, err := storage.Get().LoadProject(projectName)
+		if err != nil {
+			return notFound("Project not found: %s", projectName)
+		}
+
+		notes := vault.Notes(project, vault.Options{WikiLinks: wikiLinks, JiraBaseURL: config.Get().JiraBaseURL})
+		result, err := vault.Sync(dir, project.Name, notes)
+		if err != nil {
+			return fail("Failed to export %s: %v", projectName, err)
+		}
+
+		if jsonOutput {
+			printJSON(result)
+			return nil
+		}
+		if len(result.Written) == 0 && len(result.Removed) == 0 {
+			ui.PrintInfo("%s is up to date (%d notes)", result.Dir, result.Unchanged)
+			return nil
+		}
+		ui.PrintSuccess("Exported %s to %s: %d written, %d removed, %d unchanged",
+			projectName, result.Dir, len(result.Written), len(result.Removed), result.Unchanged)
+		for _, path := range result.Written {
+			ui.Dim.Printf("  + %s\n", path)
+		}
+		for _, path := range result.Removed {
+			ui.Dim.Printf("  - %s\n", path)
+		}
+		return nil
+	},
+}
+
+func init() {
+	exportMarkdownCmd.Flags().Bool("wikilinks", false, "Link notes with Obsidian [[wiki links]]")
+	exportMarkdownCmd.ValidArgsFunction = func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) == 0 {
+			return completeProjectNames(toComplete)
+		}
+		return nil, cobra.ShellCompDirectiveFilterDirs
+	}
+	watchable(exportMarkdownCmd)
+
+	exportCmd.AddCommand(exportMarkdownCmd)
+	rootCmd.AddCommand(exportCmd)
+}
@@ -0,0 +1,229 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mrbooshehri/qix-go/internal/githooks"
+	"github.com/mrbooshehri/qix-go/internal/storage"
+	"github.com/mrbooshehri/qix-go/internal/ui"
+	"github.com/mrbooshehri/qix-go/pkg/qix/models"
+)
+
+var gitCmd = &cobra.Command{
+	Use:   "git",
+	Short: "Link commits in your code repositories to tasks",
+	Long: `Link commits in your code repositories to tasks. This is about repositories
+you work in; to version qix's own data with git, see 'qix sync git'.`,
+}
+
+var gitInstallHooksCmd = &cobra.Command{
+	Use:   "install-hooks [repo_dir]",
+	Short: "Install git hooks that link commits to the tracked task",
+	Long: `Install hooks in a git repository (the current directory by default):
+
+  prepare-commit-msg  While a task is tracked, adds trailers naming it, and its
+                      Jira issue if it has one, to the commit message:
+                        Qix-Task: myproject/abcd1234
+                        Jira: ACME-42
+                      Delete them from the message to leave a commit unlinked.
+  commit-msg          Drops the trailers again if the message is otherwise
+                      empty, so git still aborts the commit.
+  post-commit         Records the commit on the task its Qix-Task trailer
+                      names, where 'task show' lists it.
+
+The hooks never stop a commit: if qix is missing or fails, the commit is made
+unlinked. Hooks that qix did not install are only replaced with --force.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		force, _ := cmd.Flags().GetBool("force")
+
+		repo, err := githooks.Open(repoDirArg(args))
+		if err != nil {
+			return fail("%v", err)
+		}
+
+		qixPath, err := os.Executable()
+		if err != nil {
+			qixPath = "qix"
+		} else if resolved, err := filepath.EvalSymlinks(qixPath); err == nil {
+			qixPath = resolved
+		}
+
+		paths, err := repo.Install(qixPath, force)
+		if err != nil {
+			return fail("Failed to install hooks: %v", err)
+		}
+
+		ui.PrintSuccess("Installed git hooks in %s", repo.Dir())
+		for _, path := range paths {
+			ui.Dim.Printf("  %s\n", path)
+		}
+		ui.Dim.Println("  Commits made while tracking a task are now linked to it.")
+		return nil
+	},
+}
+
+var gitUninstallHooksCmd = &cobra.Command{
+	Use:   "uninstall-hooks [repo_dir]",
+	Short: "Remove the git hooks qix installed",
+	Args:  cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		repo, err := githooks.Open(repoDirArg(args))
+		if err != nil {
+			return fail("%v", err)
+		}
+
+		removed, err := repo.Uninstall()
+		if err != nil {
+			return fail("Failed to remove hooks: %v", err)
+		}
+		if len(removed) == 0 {
+			ui.PrintInfo("No qix hooks installed in %s", repo.Dir())
+			return nil
+		}
+
+		ui.PrintSuccess("Removed git hooks from %s", repo.Dir())
+		for _, path := range removed {
+			ui.Dim.Printf("  %s\n", path)
+		}
+		return nil
+	},
+}
+
+// gitHookCmd is what the installed hooks run; it stays quiet unless something fails
+var gitHookCmd = &cobra.Command{
+	Use:    "hook <name> [args...]",
+	Short:  "Run a git hook (called by the installed hooks)",
+	Hidden: true,
+	Args:   cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		repo, err := githooks.Open(".")
+		if err != nil {
+			return fail("%v", err)
+		}
+
+		switch args[0] {
+		case "prepare-commit-msg":
+			if len(args) < 2 {
+				return invalid("prepare-commit-msg needs the message file")
+			}
+			source := ""
+			if len(args) > 2 {
+				source = args[2]
+			}
+			prepareCommitMsg(repo, args[1], source)
+		case "commit-msg":
+			if len(args) < 2 {
+				return invalid("commit-msg needs the message file")
+			}
+			if err := repo.DropLoneTrailers(args[1]); err != nil {
+				ui.PrintWarning("qix: could not check the commit message: %v", err)
+			}
+		case "post-commit":
+			recordCommit(repo)
+		default:
+			return invalid("Unknown hook: %s", args[0])
+		}
+		return nil
+	},
+}
+
+// prepareCommitMsg adds trailers naming the tracked task to a new commit message
+func prepareCommitMsg(repo *githooks.Repo, file, source string) {
+	// Merges, squashes and amended or reused messages keep what they have
+	if source == "merge" || source == "squash" || source == "commit" {
+		return
+	}
+
+	store := storage.Get()
+	session, err := store.GetActiveSession()
+	if err != nil || session == nil {
+		return
+	}
+	projectName, _ := parsePath(session.Path)
+	task, _, err := store.FindTask(projectName, session.TaskID)
+	if err != nil {
+		return
+	}
+
+	trailers := []string{fmt.Sprintf("%s: %s/%s", githooks.TaskTrailer, projectName, task.ID)}
+	if issue := strings.TrimSpace(task.JiraIssue); issue != "" {
+		trailers = append(trailers, fmt.Sprintf("%s: %s", githooks.JiraTrailer, issue))
+	}
+	if err := repo.AddTrailers(file, trailers...); err != nil {
+		ui.PrintWarning("qix: could not add the tracked task to the commit message: %v", err)
+	}
+}
+
+// recordCommit records the commit just made on the task its message names
+func recordCommit(repo *githooks.Repo) {
+	commit, err := repo.Head()
+	if err != nil {
+		ui.PrintWarning("qix: could not read the commit: %v", err)
+		return
+	}
+	ref := commit.Trailers[githooks.TaskTrailer]
+	if ref == "" {
+		return
+	}
+	projectName, taskID := parsePath(ref)
+	if taskID == "" {
+		return
+	}
+
+	store := storage.Get()
+	task, _, err := store.FindTask(projectName, taskID)
+	if err != nil {
+		ui.PrintWarning("qix: could not link commit %s: %v", shortHash(commit.Hash), err)
+		return
+	}
+	for _, c := range task.Commits {
+		if c.Hash == commit.Hash {
+			return
+		}
+	}
+
+	err = store.UpdateTask(projectName, taskID, func(t *models.Task) error {
+		t.AddCommit(models.CommitRef{
+			Hash:    commit.Hash,
+			Subject: commit.Subject,
+			Repo:    filepath.Base(repo.Dir()),
+			At:      commit.At,
+		})
+		return nil
+	})
+	if err != nil {
+		ui.PrintWarning("qix: could not link commit %s to task [%s]: %v", shortHash(commit.Hash), taskID, err)
+	}
+}
+
+// repoDirArg returns the repository directory argument, or the current directory
+func repoDirArg(args []string) string {
+	if len(args) > 0 {
+		return args[0]
+	}
+	return "."
+}
+
+// shortHash abbreviates a commit hash the way git usually shows it
+func shortHash(hash string) string {
+	if len(hash) > 7 {
+		return hash[:7]
+	}
+	return hash
+}
+
+func init() {
+	gitInstallHooksCmd.Flags().Bool("force", false, "Replace hooks that qix did not install")
+
+	gitCmd.AddCommand(gitInstallHooksCmd)
+	gitCmd.AddCommand(gitUninstallHooksCmd)
+	gitCmd.AddCommand(gitHookCmd)
+	noJSON(gitCmd)
+	rootCmd.AddCommand(gitCmd)
+}
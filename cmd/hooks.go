@@ -0,0 +1,169 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mrbooshehri/qix-go/internal/config"
+	"github.com/mrbooshehri/qix-go/internal/events"
+	"github.com/mrbooshehri/qix-go/internal/hooks"
+	"github.com/mrbooshehri/qix-go/internal/subscribers"
+	"github.com/mrbooshehri/qix-go/internal/ui"
+)
+
+var hooksCmd = &cobra.Command{
+	Use:   "hooks",
+	Short: "Run your own scripts on qix events",
+	Long: `Run executables from the hooks directory (~/.qix/hooks, or hooks_dir in the
+config file) when things happen in qix. A hook is named after its event, or
+sits in a directory named after the event plus .d to have several:
+
+  ~/.qix/hooks/task-done
+  ~/.qix/hooks/track-start.d/10-tmux
+  ~/.qix/hooks/track-start.d/20-slack-status
+
+The events are those of 'qix events', from project-created to daily-summary.
+
+Hooks read the event as JSON on standard input, with the task, sprint or
+tracked session it is about, and find the event, project and task ID in
+QIX_EVENT, QIX_PROJECT and QIX_TASK_ID. They run in turn once the change is
+saved, for up to hook_timeout seconds each (30 by default); a hook that fails
+is reported but undoes nothing. qix commands run by a hook don't run hooks.
+
+Example hook renaming the tmux window to the tracked task:
+
+  #!/bin/sh
+  tmux rename-window "$(jq -r .task.title)"
+
+Command hooks are shell commands the config file runs before or after qix
+commands, in the data directory:
+
+  command_hook.pull          = before git pull --quiet --rebase
+  command_hook.push          = after git push --quiet
+  command_hook_changed.push  = true
+  command_hook_commands.pull = task, track
+
+A failing before hook stops the command.`,
+}
+
+var hooksListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the hooks run for each event, and the command hooks",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		runner := hookRunner()
+		views := make([]hookView, 0, len(events.Types))
+		for _, event := range events.Types {
+			views = append(views, hookView{Event: event, Scripts: runner.Scripts(event)})
+		}
+
+		commandHooks := config.CommandHooks()
+
+		if jsonOutput {
+			printJSON(struct {
+				Dir          string            `json:"dir"`
+				Hooks        []hookView        `json:"hooks"`
+				CommandHooks []commandHookView `json:"command_hooks"`
+			}{runner.Dir, views, newCommandHookViews(commandHooks)})
+			return nil
+		}
+
+		ui.Dim.Printf("Hooks directory: %s\n\n", runner.Dir)
+		table := ui.NewTableBuilder("Event", "Hooks")
+		for _, view := range views {
+			scripts := "-"
+			if len(view.Scripts) > 0 {
+				scripts = strings.Join(view.Scripts, ", ")
+			}
+			table.Row(view.Event, scripts)
+		}
+		table.Print()
+
+		if len(commandHooks) > 0 {
+			fmt.Println()
+			table := ui.NewTableBuilder("Command hook", "When", "Commands", "Runs")
+			for _, hook := range commandHooks {
+				when := hook.When
+				if hook.When == hookAfter && hook.ChangedOnly {
+					when += " changes"
+				}
+				commands := "all"
+				if len(hook.Commands) > 0 {
+					commands = strings.Join(hook.Commands, ", ")
+				}
+				table.Row(hook.Name, when, commands, hook.Command)
+			}
+			table.Print()
+		}
+		return nil
+	},
+}
+
+var hooksTestCmd = &cobra.Command{
+	Use:   "test <event> [project] [task_id]",
+	Short: "Run the hooks of an event with a sample payload",
+	Long: `Run the hooks of an event as if it happened, to try them out. The payload
+is about the task given, or an example task.`,
+	Args: cobra.RangeArgs(1, 3),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		event, err := sampleEvent(args)
+		if err != nil {
+			return err
+		}
+
+		runner := hookRunner()
+		scripts := runner.Scripts(event.Type)
+		if len(scripts) == 0 {
+			ui.PrintWarning("No hooks for %s in %s", event.Type, runner.Dir)
+			return nil
+		}
+		errs := runner.Run(context.Background(), event)
+		for _, err := range errs {
+			ui.PrintError("%v", err)
+		}
+		if len(errs) == 0 {
+			ui.PrintSuccess("Ran %d hook(s) for %s", len(scripts), event.Type)
+		}
+		return nil
+	},
+}
+
+// hookView is the hooks of an event, as listed by 'hooks list'
+type hookView struct {
+	Event   string   `json:"event"`
+	Scripts []string `json:"scripts"`
+}
+
+// commandHookView is a command hook, as listed by 'hooks list'
+type commandHookView struct {
+	Name        string   `json:"name"`
+	When        string   `json:"when"`
+	Command     string   `json:"command"`
+	Commands    []string `json:"commands"`
+	ChangedOnly bool     `json:"changed_only"`
+}
+
+func newCommandHookViews(commandHooks []config.CommandHookConfig) []commandHookView {
+	views := make([]commandHookView, 0, len(commandHooks))
+	for _, hook := range commandHooks {
+		views = append(views, commandHookView(hook))
+	}
+	return views
+}
+
+func hookRunner() hooks.Runner {
+	return subscribers.HookRunner(config.Get(), os.Stderr)
+}
+
+func init() {
+	hooksTestCmd.ValidArgsFunction = completeSampleEvent
+
+	noJSON(hooksTestCmd)
+	hooksCmd.AddCommand(hooksListCmd)
+	hooksCmd.AddCommand(hooksTestCmd)
+	rootCmd.AddCommand(hooksCmd)
+}
@@ -0,0 +1,293 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mrbooshehri/qix-go/internal/importer"
+	"github.com/mrbooshehri/qix-go/internal/storage"
+	"github.com/mrbooshehri/qix-go/internal/ui"
+	"github.com/mrbooshehri/qix-go/pkg/qix/models"
+)
+
+var importCmd = &cobra.Command{
+	Use:   "import",
+	Short: "Import projects from other task managers",
+	Long: `Create qix projects from Todoist and Trello exports. Nothing is merged into
+existing projects: each import creates new ones, and stops if a project of
+the same name exists. Run with --dry-run first to see how the export maps to
+projects, modules, tags and tasks.`,
+}
+
+var importTodoistCmd = &cobra.Command{
+	Use:   "todoist <file>",
+	Short: "Import a Todoist export",
+	Long: `Import a Todoist project exported as a CSV template (Project menu > Export
+as a template), or a JSON dump of the Todoist sync API with every project.
+
+  Project   -> project (a CSV is named after its file)
+  Section   -> module; tasks outside sections stay at project level
+  Label     -> tag (@labels in CSV task names too)
+  Sub-task  -> subtask of its parent
+  Priority  -> p1 high, p3 low, p2 and p4 medium
+  Completed -> done
+
+Dates that are not YYYY-MM-DD, such as "every monday", are kept in the
+description. Archived projects and sections are left out unless --archived.
+
+Example:
+  qix import todoist Website.csv --dry-run`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runImport(cmd, args[0], func(f *os.File, opts importer.Options) (*importer.Plan, error) {
+			name := strings.TrimSuffix(filepath.Base(f.Name()), filepath.Ext(f.Name()))
+			return importer.Todoist(f, name, opts)
+		})
+	},
+}
+
+var importTrelloCmd = &cobra.Command{
+	Use:   "trello <file>",
+	Short: "Import a Trello board export",
+	Long: `Import a Trello board exported as JSON (board menu > Print, export and
+share > Export as JSON).
+
+  Board     -> project
+  List      -> module
+  Card      -> task; the first member becomes the assignee
+  Label     -> tag (named after its color if it has no name)
+  Checklist -> checkbox lines in the task's description
+
+Cards take their status from their list's name: lists with "doing",
+"progress" or "review" in the name give doing, "done" or "complete" give
+done and "block" gives blocked. Cards with a completed due date are done.
+Archived lists and cards are left out unless --archived.
+
+Example:
+  qix import trello roadmap.json --project roadmap --dry-run`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runImport(cmd, args[0], func(f *os.File, opts importer.Options) (*importer.Plan, error) {
+			return importer.Trello(f, opts)
+		})
+	},
+}
+
+// runImport reads an export with read, shows the projects it maps to and
+// creates them unless --dry-run is given
+func runImport(cmd *cobra.Command, path string, read func(*os.File, importer.Options) (*importer.Plan, error)) error {
+	projectName, _ := cmd.Flags().GetString("project")
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	archived, _ := cmd.Flags().GetBool("archived")
+
+	f, err := os.Open(path)
+	if err != nil {
+		return fail("Failed to open %s: %v", path, err)
+	}
+	defer f.Close()
+
+	plan, err := read(f, importer.Options{NewID: storage.Get().UniqueTaskID, Archived: archived})
+	if err != nil {
+		return fail("Failed to read %s: %v", path, err)
+	}
+	if len(plan.Projects) == 0 {
+		ui.PrintEmptyState("Nothing to import", "The export has no projects, or only archived ones (see --archived)")
+		return nil
+	}
+
+	if projectName != "" {
+		if len(plan.Projects) > 1 {
+			return invalid("--project needs an export of one project; this one has %d", len(plan.Projects))
+		}
+		if err := storage.ValidateProjectName(projectName); err != nil {
+			return invalid("Invalid project name: %s", projectName)
+		}
+		plan.Projects[0].Name = projectName
+	}
+
+	store := storage.Get()
+	for _, project := range plan.Projects {
+		if store.ProjectExists(project.Name) {
+			return withHint(fail("Project already exists: %s", project.Name), "Import under another name with --project <name>")
+		}
+	}
+
+	if !dryRun {
+		for _, project := range plan.Projects {
+			if err := createImportedProject(store, plan.Source, project); err != nil {
+				return fail("Failed to import %s: %v", project.From, err)
+			}
+		}
+	}
+
+	if jsonOutput {
+		printJSON(newImportView(plan, dryRun))
+		return nil
+	}
+
+	for _, project := range plan.Projects {
+		printImportPlan(plan.Source, project)
+	}
+	fmt.Println()
+	for _, project := range plan.Projects {
+		if dryRun {
+			ui.PrintInfo("Dry run: project %s would be created with %d module(s) and %d task(s)", project.Name, len(project.Modules), len(project.Tasks))
+		} else {
+			ui.PrintSuccess("Imported %s into project %s: %d module(s), %d task(s)", project.From, project.Name, len(project.Modules), len(project.Tasks))
+		}
+		if project.Skipped > 0 {
+			ui.Dim.Printf("  %d archived item(s) left out; include them with --archived\n", project.Skipped)
+		}
+	}
+	return nil
+}
+
+// createImportedProject creates a planned project with its modules and tasks.
+// If a module or task can't be created, the project is removed again.
+func createImportedProject(store *storage.Storage, source string, plan importer.ProjectPlan) error {
+	description := plan.Description
+	if description == "" {
+		description = fmt.Sprintf("Imported from %s %s", source, plan.From)
+	}
+	if _, err := store.CreateProject(plan.Name, description, nil); err != nil {
+		return err
+	}
+
+	err := store.WithTx(plan.Name, func() error {
+		for _, module := range plan.Modules {
+			m := models.Module{Name: module.Name, Description: module.From, Tags: make([]string, 0)}
+			if err := store.AddModule(plan.Name, m); err != nil {
+				return err
+			}
+		}
+		for _, t := range plan.Tasks {
+			if err := store.AddTask(plan.Name, t.Module, t.Task); err != nil {
+				return fmt.Errorf("task %q: %w", t.Task.Title, err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		if delErr := store.DeleteProject(plan.Name); delErr != nil {
+			ui.PrintWarning("Project %s was created but is incomplete: %v", plan.Name, delErr)
+		}
+		return err
+	}
+	return nil
+}
+
+// printImportPlan shows how an exported project maps to qix
+func printImportPlan(source string, project importer.ProjectPlan) {
+	ui.PrintHeader(fmt.Sprintf("%s %q -> project %s", source, project.From, project.Name))
+
+	modules := ui.NewTableBuilder("Module", "From", "Tasks").Align(2, ui.AlignRight)
+	if n := project.TaskCount(""); n > 0 {
+		modules.Row("-", "(no list or section)", fmt.Sprintf("%d", n))
+	}
+	for _, module := range project.Modules {
+		modules.Row(module.Name, module.From, fmt.Sprintf("%d", project.TaskCount(module.Name)))
+	}
+	modules.Print()
+
+	if tags := project.Tags(); len(tags) > 0 {
+		fmt.Printf("\nTags: %s\n", strings.Join(tags, ", "))
+	}
+	if len(project.Tasks) == 0 {
+		return
+	}
+
+	fmt.Println()
+	tasks := ui.NewTableBuilder("Module", "Title", "Status", "Priority", "Due", "Tags")
+	for _, t := range project.Tasks {
+		title := t.Task.Title
+		if t.Task.ParentID != "" {
+			title = "↳ " + title
+		}
+		module := t.Module
+		if module == "" {
+			module = "-"
+		}
+		due := t.Task.DueDate
+		if due == "" {
+			due = "-"
+		}
+		tasks.Row(module, title, string(t.Task.Status), string(t.Task.Priority), due, strings.Join(t.Task.Tags, ", "))
+	}
+	tasks.Print()
+}
+
+// importView is the JSON result of 'import todoist' and 'import trello'
+type importView struct {
+	Source   string              `json:"source"`
+	DryRun   bool                `json:"dry_run"`
+	Projects []importProjectView `json:"projects"`
+}
+
+type importProjectView struct {
+	Name    string             `json:"name"`
+	From    string             `json:"from"`
+	Modules []importModuleView `json:"modules"`
+	Tags    []string           `json:"tags"`
+	Tasks   []importTaskView   `json:"tasks"`
+	Skipped int                `json:"skipped"` // Archived items left out
+}
+
+type importModuleView struct {
+	Name  string `json:"name"`
+	From  string `json:"from"`
+	Tasks int    `json:"tasks"`
+}
+
+type importTaskView struct {
+	taskRef
+	Module   string          `json:"module,omitempty"`
+	Priority models.Priority `json:"priority"`
+	DueDate  string          `json:"due_date,omitempty"`
+	Tags     []string        `json:"tags"`
+	Assignee string          `json:"assignee,omitempty"`
+	ParentID string          `json:"parent_id,omitempty"`
+}
+
+func newImportView(plan *importer.Plan, dryRun bool) importView {
+	view := importView{Source: plan.Source, DryRun: dryRun, Projects: make([]importProjectView, 0, len(plan.Projects))}
+	for _, project := range plan.Projects {
+		p := importProjectView{
+			Name:    project.Name,
+			From:    project.From,
+			Modules: make([]importModuleView, 0, len(project.Modules)),
+			Tags:    project.Tags(),
+			Tasks:   make([]importTaskView, 0, len(project.Tasks)),
+			Skipped: project.Skipped,
+		}
+		for _, module := range project.Modules {
+			p.Modules = append(p.Modules, importModuleView{Name: module.Name, From: module.From, Tasks: project.TaskCount(module.Name)})
+		}
+		for _, t := range project.Tasks {
+			p.Tasks = append(p.Tasks, importTaskView{
+				taskRef:  newTaskRef(t.Task),
+				Module:   t.Module,
+				Priority: t.Task.Priority,
+				DueDate:  t.Task.DueDate,
+				Tags:     t.Task.Tags,
+				Assignee: t.Task.Assignee,
+				ParentID: t.Task.ParentID,
+			})
+		}
+		view.Projects = append(view.Projects, p)
+	}
+	return view
+}
+
+func init() {
+	for _, c := range []*cobra.Command{importTodoistCmd, importTrelloCmd} {
+		c.Flags().String("project", "", "Name of the project to create (default: from the export)")
+		ownDryRun(c)
+		c.Flags().Bool("archived", false, "Also import archived projects, lists, sections and cards")
+		importCmd.AddCommand(c)
+	}
+	rootCmd.AddCommand(importCmd)
+}
@@ -1,6 +1,9 @@
 package cmd
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"os/exec"
 	"runtime"
 	"strings"
@@ -8,8 +11,10 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/mrbooshehri/qix-go/internal/config"
+	"github.com/mrbooshehri/qix-go/internal/jira"
 	"github.com/mrbooshehri/qix-go/internal/storage"
 	"github.com/mrbooshehri/qix-go/internal/ui"
+	"github.com/mrbooshehri/qix-go/pkg/qix/models"
 )
 
 var jiraCmd = &cobra.Command{
@@ -21,41 +26,228 @@ var jiraOpenCmd = &cobra.Command{
 	Use:   "open <project> <task_id>",
 	Short: "Open the Jira issue linked to a task",
 	Args:  cobra.ExactArgs(2),
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		projectName := args[0]
 		taskID := args[1]
 
 		store := storage.Get()
 		task, _, err := store.FindTask(projectName, taskID)
 		if err != nil {
-			ui.PrintError("Task not found: %v", err)
-			return
+			return notFound("Task not found: %v", err)
 		}
 
 		issueID := strings.TrimSpace(task.JiraIssue)
 		if issueID == "" {
-			ui.PrintError("Task [%s] has no Jira issue linked. Use 'qix task edit %s %s --jira-issue <ID>' to set one.", taskID, projectName, taskID)
-			return
+			return fail("Task [%s] has no Jira issue linked. Use 'qix task edit %s %s --jira-issue <ID>' to set one.", taskID, projectName, taskID)
 		}
 
 		cfg := config.Get()
 		baseURL := strings.TrimSpace(cfg.JiraBaseURL)
 		if baseURL == "" {
-			ui.PrintError("Jira base URL not configured. Set 'jira_base_url' in %s or export JIRA_BASE_URL.", cfg.ConfigFile)
-			return
+			return fail("Jira base URL not configured. Set 'jira_base_url' in %s or export JIRA_BASE_URL.", cfg.ConfigFile)
 		}
 
 		issueURL := strings.TrimRight(baseURL, "/") + "/" + issueID
 		if err := openInBrowser(issueURL); err != nil {
-			ui.PrintError("Failed to open Jira issue: %v", err)
-			ui.Dim.Printf("URL: %s\n", issueURL)
-			return
+			return withHint(fail("Failed to open Jira issue: %v", err), "URL: %s", issueURL)
 		}
 
 		ui.PrintSuccess("Opening Jira issue: %s", issueURL)
+		return nil
 	},
 }
 
+var jiraSyncCmd = &cobra.Command{
+	Use:   "sync <project>",
+	Short: "Pull Jira issue details into linked tasks",
+	Long: `Fetch the summary, status and assignee of the Jira issue linked to each
+task of a project and store them with the task, where 'task show' displays
+them. The Jira fields are read-only: the next sync overwrites them, and
+nothing is written back to Jira.
+
+Tasks that are done while their issue is still open in Jira, or the other way
+round, are listed as mismatches.
+
+Credentials come from the config file or the environment:
+  jira_base_url   JIRA_BASE_URL    https://your-domain.atlassian.net/browse
+  jira_user       JIRA_USER        Account email; leave empty to use
+                                   jira_token as a personal access token
+  jira_token      JIRA_API_TOKEN   API token
+  jira_api_url                     REST API root, if it is not the site root`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		projectName := args[0]
+
+		store := storage.Get()
+		project, err := store.LoadProject(projectName)
+		if err != nil {
+			return notFound("Project not found: %s", projectName)
+		}
+
+		client, err := newJiraClient()
+		if err != nil {
+			return err
+		}
+
+		linked := make([]models.Task, 0)
+		for _, task := range project.GetAllTasks() {
+			if strings.TrimSpace(task.JiraIssue) != "" {
+				linked = append(linked, task)
+			}
+		}
+		if len(linked) == 0 {
+			ui.PrintEmptyState(
+				fmt.Sprintf("No tasks in project '%s' are linked to Jira issues", projectName),
+				fmt.Sprintf("Link one with: qix task edit %s <task_id> --jira-issue <ID>", projectName),
+			)
+			return nil
+		}
+
+		// Issues are fetched before the project is locked, as Jira may be slow
+		synced := make(map[string]*models.JiraInfo)
+		failures := make([]string, 0)
+		progress := ui.StartProgress("Syncing", "issues", len(linked))
+		for _, task := range linked {
+			issue, err := client.Issue(context.Background(), strings.TrimSpace(task.JiraIssue))
+			progress.Add(1)
+			if errors.Is(err, jira.ErrUnauthorized) {
+				progress.Stop()
+				return jiraAuthError(err)
+			}
+			if err != nil {
+				failures = append(failures, fmt.Sprintf("[%s] %s: %v", task.ID, task.JiraIssue, err))
+				continue
+			}
+			synced[task.ID] = newJiraInfo(*issue)
+		}
+		progress.Stop()
+		for _, failure := range failures {
+			ui.PrintWarning("%s", failure)
+		}
+
+		if len(synced) > 0 {
+			err = store.UpdateProject(projectName, func(p *models.Project) error {
+				project = p
+				for _, task := range allTaskPointers(p) {
+					if info, ok := synced[task.ID]; ok {
+						task.Jira = info
+					}
+				}
+				return nil
+			})
+			if err != nil {
+				return fail("Failed to save Jira details: %v", err)
+			}
+		}
+
+		mismatches := make([]models.Task, 0)
+		for _, task := range project.GetAllTasks() {
+			if _, ok := synced[task.ID]; ok && task.JiraMismatch() {
+				mismatches = append(mismatches, task)
+			}
+		}
+
+		if jsonOutput {
+			printJSON(newJiraSyncView(project.Name, len(synced), len(failures), mismatches))
+			return nil
+		}
+
+		ui.PrintSuccess("Synced %d of %d Jira issue(s)", len(synced), len(linked))
+		if len(mismatches) == 0 {
+			return nil
+		}
+
+		fmt.Println()
+		ui.PrintWarning("%d task(s) disagree with Jira", len(mismatches))
+		table := ui.NewTableBuilder("Task", "Status", "Jira", "Jira status")
+		for _, task := range mismatches {
+			table.Row(
+				fmt.Sprintf("[%s] %s", task.ID, task.Title),
+				string(task.Status),
+				task.Jira.Key,
+				task.Jira.Status,
+			)
+		}
+		table.Print()
+		return nil
+	},
+}
+
+// newJiraClient returns a client for the configured Jira instance, or an
+// error saying what is missing if it is not configured
+func newJiraClient() (*jira.Client, error) {
+	cfg := config.Get()
+	client, err := jira.NewClient(jira.Options{
+		BaseURL: cfg.JiraBaseURL,
+		APIURL:  cfg.JiraAPIURL,
+		User:    cfg.JiraUser,
+		Token:   cfg.JiraToken,
+	})
+	if err != nil {
+		return nil, invalid("Cannot connect to Jira: %v. Set 'jira_base_url' and 'jira_token' in %s.", err, cfg.ConfigFile)
+	}
+	return client, nil
+}
+
+// newJiraInfo returns the fields of an issue a task keeps
+func newJiraInfo(issue jira.Issue) *models.JiraInfo {
+	return &models.JiraInfo{
+		Key:            issue.Key,
+		Summary:        issue.Summary,
+		Status:         issue.Status,
+		StatusCategory: issue.StatusCategory,
+		Assignee:       issue.Assignee,
+		Updated:        issue.Updated,
+	}
+}
+
+// jiraAuthError returns the error of credentials Jira rejected
+func jiraAuthError(err error) error {
+	return fail("%v. Check 'jira_user' and 'jira_token' in %s.", err, config.Get().ConfigFile)
+}
+
+// jiraSyncView is the JSON result of 'jira sync'
+type jiraSyncView struct {
+	Project    string             `json:"project"`
+	Synced     int                `json:"synced"`
+	Failed     int                `json:"failed"`
+	Mismatches []jiraMismatchView `json:"mismatches"`
+}
+
+// jiraMismatchView is a task whose done state differs from its Jira issue's
+type jiraMismatchView struct {
+	taskRef
+	Jira models.JiraInfo `json:"jira"`
+}
+
+func newJiraSyncView(projectName string, synced, failed int, mismatches []models.Task) jiraSyncView {
+	view := jiraSyncView{
+		Project:    projectName,
+		Synced:     synced,
+		Failed:     failed,
+		Mismatches: make([]jiraMismatchView, 0, len(mismatches)),
+	}
+	for _, task := range mismatches {
+		view.Mismatches = append(view.Mismatches, jiraMismatchView{taskRef: newTaskRef(task), Jira: *task.Jira})
+	}
+	return view
+}
+
+// allTaskPointers returns pointers to every task of a project, so they can be
+// changed in place
+func allTaskPointers(p *models.Project) []*models.Task {
+	tasks := make([]*models.Task, 0, len(p.Tasks))
+	for i := range p.Tasks {
+		tasks = append(tasks, &p.Tasks[i])
+	}
+	for i := range p.Modules {
+		for j := range p.Modules[i].Tasks {
+			tasks = append(tasks, &p.Modules[i].Tasks[j])
+		}
+	}
+	return tasks
+}
+
 func openInBrowser(url string) error {
 	var cmd *exec.Cmd
 	switch runtime.GOOS {
@@ -71,7 +263,11 @@ func openInBrowser(url string) error {
 
 func init() {
 	jiraOpenCmd.ValidArgsFunction = jiraOpenCompletion
+	noJSON(jiraOpenCmd)
 	jiraCmd.AddCommand(jiraOpenCmd)
+
+	jiraSyncCmd.ValidArgsFunction = projectArgCompletion
+	jiraCmd.AddCommand(jiraSyncCmd)
 }
 
 func jiraOpenCompletion(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
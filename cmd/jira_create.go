@@ -0,0 +1,160 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mrbooshehri/qix-go/internal/config"
+	"github.com/mrbooshehri/qix-go/internal/jira"
+	"github.com/mrbooshehri/qix-go/internal/storage"
+	"github.com/mrbooshehri/qix-go/internal/ui"
+	"github.com/mrbooshehri/qix-go/pkg/qix/models"
+)
+
+var jiraCreateCmd = &cobra.Command{
+	Use:   "create <project> <task_id>",
+	Short: "Create a Jira issue from a task",
+	Long: `Create a Jira issue with the task's title, description and estimate, and
+link the task to it.
+
+The issue goes into the Jira project given with --jira-project; without it,
+into the one most of the project's linked issues are in, or else the one set
+as 'jira_project' in the config. Its type is --type, or 'jira_issue_type'
+(Task by default). If the Jira project doesn't track time, the issue is
+created without the estimate.`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		projectName := args[0]
+		taskID := args[1]
+		jiraProject, _ := cmd.Flags().GetString("jira-project")
+		issueType, _ := cmd.Flags().GetString("type")
+
+		store := storage.Get()
+		project, err := store.LoadProject(projectName)
+		if err != nil {
+			return notFound("Project not found: %s", projectName)
+		}
+		task, _, err := store.FindTask(projectName, taskID)
+		if err != nil {
+			return notFound("Task not found: %v", err)
+		}
+		if task.JiraIssue != "" {
+			return fail("Task [%s] is already linked to %s", task.ID, task.JiraIssue)
+		}
+
+		cfg := config.Get()
+		if jiraProject == "" {
+			jiraProject = linkedJiraProject(project)
+		}
+		if jiraProject == "" {
+			jiraProject = cfg.JiraProject
+		}
+		if jiraProject == "" {
+			return fail("No Jira project to create the issue in. Pass --jira-project or set 'jira_project' in %s.", cfg.ConfigFile)
+		}
+		if issueType == "" {
+			issueType = cfg.JiraIssueType
+		}
+		if issueType == "" {
+			issueType = "Task"
+		}
+
+		client, err := newJiraClient()
+		if err != nil {
+			return err
+		}
+
+		ctx := context.Background()
+		issue := jira.NewIssue{
+			Project:     strings.ToUpper(jiraProject),
+			Type:        issueType,
+			Summary:     task.Title,
+			Description: task.Description,
+			Estimate:    time.Duration(task.EstimatedHours * float64(time.Hour)),
+		}
+		key, err := client.CreateIssue(ctx, issue)
+
+		// Time tracking is off, or not on the create screen, in some Jira projects
+		var apiErr *jira.APIError
+		if errors.As(err, &apiErr) && apiErr.Fields["timetracking"] != "" && issue.Estimate > 0 {
+			ui.PrintWarning("Jira doesn't take an estimate here (%s); creating the issue without it", apiErr.Fields["timetracking"])
+			issue.Estimate = 0
+			key, err = client.CreateIssue(ctx, issue)
+		}
+		if errors.Is(err, jira.ErrUnauthorized) {
+			return jiraAuthError(err)
+		}
+		if err != nil {
+			return fail("Failed to create Jira issue: %v", err)
+		}
+
+		// The new issue's status and such, as 'jira sync' would store them
+		var info *models.JiraInfo
+		if created, err := client.Issue(ctx, key); err == nil {
+			info = newJiraInfo(*created)
+		}
+
+		err = store.UpdateTask(projectName, task.ID, func(t *models.Task) error {
+			t.JiraIssue = key
+			t.Jira = info
+			return nil
+		})
+		if err != nil {
+			return withHint(fail("Created %s, but failed to link the task to it: %v", key, err),
+				"Link it with: qix task edit %s %s --jira-issue %s", projectName, task.ID, key)
+		}
+
+		if porcelain {
+			ui.PrintRecord(key)
+			return nil
+		}
+
+		ui.PrintSuccess("Created Jira issue %s", key)
+		ui.Dim.Printf("  Task: [%s] %s\n", task.ID, task.Title)
+		if base := strings.TrimSpace(cfg.JiraBaseURL); base != "" {
+			ui.Dim.Printf("  URL: %s/%s\n", strings.TrimRight(base, "/"), key)
+		}
+		return nil
+	},
+}
+
+// linkedJiraProject returns the key of the Jira project most of a project's
+// linked issues are in, or "" if no task is linked
+func linkedJiraProject(project *models.Project) string {
+	counts := make(map[string]int)
+	for _, task := range project.GetAllTasks() {
+		issue := strings.TrimSpace(task.JiraIssue)
+		if i := strings.LastIndex(issue, "-"); i > 0 {
+			counts[strings.ToUpper(issue[:i])]++
+		}
+	}
+
+	keys := make([]string, 0, len(counts))
+	for key := range counts {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if counts[keys[i]] != counts[keys[j]] {
+			return counts[keys[i]] > counts[keys[j]]
+		}
+		return keys[i] < keys[j]
+	})
+	if len(keys) == 0 {
+		return ""
+	}
+	return keys[0]
+}
+
+func init() {
+	jiraCreateCmd.Flags().String("jira-project", "", "Key of the Jira project to create the issue in")
+	jiraCreateCmd.Flags().String("type", "", "Issue type (default: 'jira_issue_type' from the config, or Task)")
+	jiraCreateCmd.ValidArgsFunction = projectTaskArgCompletion
+
+	noJSON(jiraCreateCmd)
+	jiraCmd.AddCommand(jiraCreateCmd)
+}
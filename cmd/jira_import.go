@@ -0,0 +1,217 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mrbooshehri/qix-go/internal/jira"
+	"github.com/mrbooshehri/qix-go/internal/storage"
+	"github.com/mrbooshehri/qix-go/internal/ui"
+	"github.com/mrbooshehri/qix-go/pkg/qix/models"
+)
+
+var jiraImportCmd = &cobra.Command{
+	Use:   "import <project[/module]> --jql <query>",
+	Short: "Create tasks from Jira issues",
+	Long: `Create a task for each Jira issue a JQL query finds, linked back to the
+issue, so a Jira backlog can be planned in qix. Issues already linked to a
+task of the project are skipped, so importing again only adds new ones.
+
+Tasks take the issue's summary, description, labels (as tags), due date and
+assignee. The original estimate, or the remaining estimate if there is none,
+becomes the estimate. Statuses and priorities are mapped:
+
+  To Do, Open, ...         todo      Highest, High, ...   high
+  In Progress, Review, ... doing     Medium               medium
+  Done, Closed, ...        done      Low, Lowest, ...     low
+  Any status with "block"  blocked
+
+Example:
+  qix jira import web --jql "project = WEB AND sprint in openSprints()"`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		projectName, moduleName := parsePath(args[0])
+		jql, _ := cmd.Flags().GetString("jql")
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+		if strings.TrimSpace(jql) == "" {
+			return invalid("A query is required: --jql \"project = KEY\"")
+		}
+
+		store := storage.Get()
+		project, err := store.LoadProject(projectName)
+		if err != nil {
+			return notFound("Project not found: %s", projectName)
+		}
+		if moduleName != "" {
+			if _, err := store.GetModule(projectName, moduleName); err != nil {
+				return notFound("Module not found: %v", err)
+			}
+		}
+
+		client, err := newJiraClient()
+		if err != nil {
+			return err
+		}
+
+		issues, err := client.Search(context.Background(), jql)
+		if errors.Is(err, jira.ErrUnauthorized) {
+			return jiraAuthError(err)
+		}
+		if err != nil {
+			return fail("Jira search failed: %v", err)
+		}
+
+		linked := make(map[string]bool)
+		for _, task := range project.GetAllTasks() {
+			if key := strings.TrimSpace(task.JiraIssue); key != "" {
+				linked[strings.ToUpper(key)] = true
+			}
+		}
+
+		tasks := make([]models.Task, 0, len(issues))
+		skipped := 0
+		for _, issue := range issues {
+			if linked[strings.ToUpper(issue.Key)] {
+				skipped++
+				continue
+			}
+			linked[strings.ToUpper(issue.Key)] = true
+			tasks = append(tasks, taskFromIssue(issue))
+		}
+
+		if !dryRun && len(tasks) > 0 {
+			err = store.WithTx(projectName, func() error {
+				for _, task := range tasks {
+					if err := store.AddTask(projectName, moduleName, task); err != nil {
+						return fmt.Errorf("failed to create task for %s: %w", task.JiraIssue, err)
+					}
+				}
+				return nil
+			})
+			if err != nil {
+				return fail("%v", err)
+			}
+		}
+
+		if jsonOutput {
+			printJSON(newJiraImportView(project.Name, moduleName, tasks, skipped, dryRun))
+			return nil
+		}
+
+		if len(tasks) == 0 {
+			ui.PrintInfo("No new issues to import (%d found, %d already linked)", len(issues), skipped)
+			return nil
+		}
+
+		table := ui.NewTableBuilder("ID", "Jira", "Title", "Status", "Priority", "Estimated").Align(5, ui.AlignRight)
+		for _, task := range tasks {
+			table.Row(task.ID, task.JiraIssue, task.Title, string(task.Status), string(task.Priority), ui.FormatHours(task.EstimatedHours))
+		}
+		table.Print()
+
+		if dryRun {
+			ui.PrintInfo("Dry run: %d task(s) would be created", len(tasks))
+		} else {
+			ui.PrintSuccess("Imported %d issue(s) into %s", len(tasks), args[0])
+		}
+		if skipped > 0 {
+			ui.Dim.Printf("  %d issue(s) already linked to tasks were skipped\n", skipped)
+		}
+		return nil
+	},
+}
+
+// taskFromIssue returns a new task linked to a Jira issue
+func taskFromIssue(issue jira.Issue) models.Task {
+	estimate := issue.OriginalEstimate
+	if estimate == 0 {
+		estimate = issue.RemainingEstimate
+	}
+
+	task := models.Task{
+		ID:             storage.Get().UniqueTaskID(),
+		Title:          strings.TrimSpace(issue.Summary),
+		Description:    strings.TrimSpace(issue.Description),
+		Status:         jiraTaskStatus(issue),
+		Priority:       jiraTaskPriority(issue.Priority),
+		EstimatedHours: estimate.Hours(),
+		Tags:           append(make([]string, 0, len(issue.Labels)), issue.Labels...),
+		JiraIssue:      issue.Key,
+		Jira:           newJiraInfo(issue),
+		DueDate:        issue.DueDate,
+		Assignee:       issue.Assignee,
+	}
+	if task.Title == "" {
+		task.Title = issue.Key
+	}
+	return task
+}
+
+// jiraTaskStatus maps an issue's status to a task status by its category
+func jiraTaskStatus(issue jira.Issue) models.TaskStatus {
+	if strings.Contains(strings.ToLower(issue.Status), "block") {
+		return models.StatusBlocked
+	}
+	switch issue.StatusCategory {
+	case "indeterminate":
+		return models.StatusDoing
+	case "done":
+		return models.StatusDone
+	default:
+		return models.StatusTodo
+	}
+}
+
+// jiraTaskPriority maps a Jira priority name to a task priority
+func jiraTaskPriority(name string) models.Priority {
+	switch strings.ToLower(name) {
+	case "highest", "high", "blocker", "critical":
+		return models.PriorityHigh
+	case "low", "lowest", "minor", "trivial":
+		return models.PriorityLow
+	default:
+		return models.PriorityMedium
+	}
+}
+
+// jiraImportView is the JSON result of 'jira import'
+type jiraImportView struct {
+	Project string        `json:"project"`
+	Module  string        `json:"module,omitempty"`
+	DryRun  bool          `json:"dry_run"`
+	Created []importedRef `json:"created"`
+	Skipped int           `json:"skipped"` // Issues already linked to tasks
+}
+
+// importedRef is a task created from a Jira issue
+type importedRef struct {
+	taskRef
+	JiraIssue string `json:"jira_issue"`
+}
+
+func newJiraImportView(projectName, moduleName string, tasks []models.Task, skipped int, dryRun bool) jiraImportView {
+	view := jiraImportView{
+		Project: projectName,
+		Module:  moduleName,
+		DryRun:  dryRun,
+		Created: make([]importedRef, 0, len(tasks)),
+		Skipped: skipped,
+	}
+	for _, task := range tasks {
+		view.Created = append(view.Created, importedRef{taskRef: newTaskRef(task), JiraIssue: task.JiraIssue})
+	}
+	return view
+}
+
+func init() {
+	jiraImportCmd.Flags().String("jql", "", "JQL query selecting the issues to import")
+	ownDryRun(jiraImportCmd)
+	jiraImportCmd.ValidArgsFunction = taskPathCompletion
+
+	jiraCmd.AddCommand(jiraImportCmd)
+}
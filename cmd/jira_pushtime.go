@@ -0,0 +1,342 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mrbooshehri/qix-go/internal/jira"
+	"github.com/mrbooshehri/qix-go/internal/storage"
+	"github.com/mrbooshehri/qix-go/internal/ui"
+	"github.com/mrbooshehri/qix-go/pkg/qix/models"
+)
+
+var jiraPushTimeCmd = &cobra.Command{
+	Use:   "pushtime <project>",
+	Short: "Log time entries as Jira worklogs",
+	Long: `Post the time logged on tasks linked to Jira issues as worklogs on those
+issues, so hours don't have to be entered twice. --from and --to limit the
+entries pushed to a range of days; by default all of them are.
+
+Each entry is pushed once: qix remembers the worklog it created, and the
+worklog's comment carries a marker so entries are not logged again even if
+qix could not save that it pushed them. The remaining estimate in Jira is
+left as it is.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		projectName := args[0]
+		fromStr, _ := cmd.Flags().GetString("from")
+		toStr, _ := cmd.Flags().GetString("to")
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+		for _, date := range []*string{&fromStr, &toStr} {
+			if *date == "" {
+				continue
+			}
+			normalized, err := ui.NormalizeDate(*date)
+			if err != nil {
+				return invalid("Invalid date format. Use: %s", ui.DateFormats())
+			}
+			*date = normalized
+		}
+		if fromStr != "" && toStr != "" && toStr < fromStr {
+			return invalid("End date must be after start date")
+		}
+
+		store := storage.Get()
+		project, err := store.LoadProject(projectName)
+		if err != nil {
+			return notFound("Project not found: %s", projectName)
+		}
+
+		pending := pendingWorklogs(project, fromStr, toStr)
+		if len(pending) == 0 {
+			if jsonOutput {
+				printJSON(newPushTimeView(project.Name, nil, 0, nil))
+				return nil
+			}
+			ui.PrintInfo("No time entries to push")
+			return nil
+		}
+
+		if dryRun {
+			if jsonOutput {
+				printJSON(newPushTimeView(project.Name, pending, 0, nil))
+				return nil
+			}
+			printPendingWorklogs(pending)
+			ui.PrintInfo("Dry run: %d time entry(s) would be pushed", len(pending))
+			return nil
+		}
+
+		client, err := newJiraClient()
+		if err != nil {
+			return err
+		}
+
+		ctx := context.Background()
+		existing := make(map[string]map[string]string) // Issue key -> marker -> worklog ID
+		pushed := make([]pendingWorklog, 0, len(pending))
+		added, skipped := 0, 0
+		hours := 0.0
+		failures := make([]string, 0)
+		var authErr error
+		progress := ui.StartProgress("Pushing", "worklogs", len(pending))
+		for _, entry := range pending {
+			progress.Add(1)
+			if authErr != nil {
+				break
+			}
+
+			markers, ok := existing[entry.Issue]
+			if !ok {
+				worklogs, err := client.Worklogs(ctx, entry.Issue)
+				if errors.Is(err, jira.ErrUnauthorized) {
+					authErr = err
+					continue
+				}
+				if err != nil {
+					failures = append(failures, fmt.Sprintf("%s: %v", entry.Issue, err))
+					existing[entry.Issue] = nil
+					continue
+				}
+				markers = markersIn(worklogs)
+				existing[entry.Issue] = markers
+			}
+			if markers == nil {
+				// Reading the issue's worklogs failed; without them duplicates can't be ruled out
+				continue
+			}
+
+			if id, ok := markers[entry.Marker]; ok {
+				entry.WorklogID = id
+				pushed = append(pushed, entry)
+				skipped++
+				continue
+			}
+
+			// Jira doesn't take worklogs under a minute
+			spent := time.Duration(entry.Entry.Hours * float64(time.Hour)).Round(time.Second)
+			if spent < time.Minute {
+				spent = time.Minute
+			}
+			id, err := client.AddWorklog(ctx, entry.Issue, jira.Worklog{
+				Comment: fmt.Sprintf("%s\n[%s]", entry.TaskTitle, entry.Marker),
+				Started: entry.started(),
+				Spent:   spent,
+			})
+			if errors.Is(err, jira.ErrUnauthorized) {
+				authErr = err
+				continue
+			}
+			if err != nil {
+				failures = append(failures, fmt.Sprintf("[%s] %s on %s: %v", entry.TaskID, entry.Issue, entry.Entry.Date, err))
+				continue
+			}
+			entry.WorklogID = id
+			pushed = append(pushed, entry)
+			added++
+			hours += entry.Entry.Hours
+		}
+		progress.Stop()
+		for _, failure := range failures {
+			ui.PrintWarning("%s", failure)
+		}
+
+		// Record the worklogs, including ones pushed before credentials were rejected
+		if len(pushed) > 0 {
+			if err := recordWorklogs(store, projectName, pushed); err != nil {
+				return withHint(fail("Failed to record pushed worklogs: %v", err), "They are marked in Jira and won't be pushed again.")
+			}
+		}
+
+		if authErr != nil {
+			return jiraAuthError(authErr)
+		}
+
+		if jsonOutput {
+			printJSON(newPushTimeView(project.Name, pushed, skipped, failures))
+			return nil
+		}
+
+		ui.PrintSuccess("Pushed %d worklog(s) to Jira (%s)", added, ui.FormatHours(hours))
+		if skipped > 0 {
+			ui.Dim.Printf("  %d time entry(s) were already in Jira\n", skipped)
+		}
+		return nil
+	},
+}
+
+// pendingWorklog is a time entry not yet pushed to the Jira issue of its task
+type pendingWorklog struct {
+	TaskID    string
+	TaskTitle string
+	Issue     string
+	Entry     models.TimeEntry
+	Marker    string // Identifies the entry in the worklog comment
+	WorklogID string // Set once pushed
+}
+
+// pendingWorklogs returns the time entries from..to (inclusive, open if
+// empty) of tasks linked to Jira that have not been pushed yet
+func pendingWorklogs(project *models.Project, from, to string) []pendingWorklog {
+	pending := make([]pendingWorklog, 0)
+	for _, task := range project.GetAllTasks() {
+		issue := strings.TrimSpace(task.JiraIssue)
+		if issue == "" {
+			continue
+		}
+		markers := worklogMarkers(task.ID, task.TimeEntries)
+		for i, entry := range task.TimeEntries {
+			if entry.JiraWorklog != "" || entry.Hours <= 0 ||
+				(from != "" && entry.Date < from) || (to != "" && entry.Date > to) {
+				continue
+			}
+			pending = append(pending, pendingWorklog{
+				TaskID:    task.ID,
+				TaskTitle: task.Title,
+				Issue:     issue,
+				Entry:     entry,
+				Marker:    markers[i],
+			})
+		}
+	}
+	return pending
+}
+
+// worklogMarkers returns the markers identifying a task's time entries in the
+// comments of the worklogs they are pushed as. Entries logged at the same time
+// on the same day are told apart by their order.
+func worklogMarkers(taskID string, entries []models.TimeEntry) []string {
+	markers := make([]string, len(entries))
+	seen := make(map[string]int)
+	for i, entry := range entries {
+		marker := fmt.Sprintf("qix:%s:%s:%d", taskID, entry.Date, entry.LoggedAt.Unix())
+		seen[marker]++
+		if n := seen[marker]; n > 1 {
+			marker += fmt.Sprintf(":%d", n)
+		}
+		markers[i] = marker
+	}
+	return markers
+}
+
+// markersIn maps the markers found in worklog comments to the worklog IDs
+func markersIn(worklogs []jira.Worklog) map[string]string {
+	markers := make(map[string]string)
+	for _, w := range worklogs {
+		if marker := markerIn(w.Comment); marker != "" {
+			markers[marker] = w.ID
+		}
+	}
+	return markers
+}
+
+// markerIn returns the time entry marker at the end of text, or ""
+func markerIn(text string) string {
+	start := strings.LastIndex(text, "[qix:")
+	end := strings.LastIndex(text, "]")
+	if start >= 0 && end > start {
+		return text[start+1 : end]
+	}
+	return ""
+}
+
+// started returns when the work began
+func (p pendingWorklog) started() time.Time {
+	return workStarted(p.Entry)
+}
+
+// workStarted returns when the work of a time entry began: the time it was
+// logged at less its hours if that is on the same day, or 9:00 on the day
+// otherwise
+func workStarted(entry models.TimeEntry) time.Time {
+	day, _ := time.ParseInLocation("2006-01-02", entry.Date, time.Local)
+	start := entry.LoggedAt.In(time.Local).Add(-time.Duration(entry.Hours * float64(time.Hour)))
+	if truncateDay(start).Equal(day) {
+		return start
+	}
+	return day.Add(9 * time.Hour)
+}
+
+// recordWorklogs stores the IDs of the worklogs entries were pushed as
+func recordWorklogs(store *storage.Storage, projectName string, pushed []pendingWorklog) error {
+	ids := make(map[string]string, len(pushed))
+	for _, entry := range pushed {
+		ids[entry.Marker] = entry.WorklogID
+	}
+	return store.UpdateProject(projectName, func(p *models.Project) error {
+		for _, task := range allTaskPointers(p) {
+			for i, marker := range worklogMarkers(task.ID, task.TimeEntries) {
+				if id, ok := ids[marker]; ok {
+					task.TimeEntries[i].JiraWorklog = id
+				}
+			}
+		}
+		return nil
+	})
+}
+
+func printPendingWorklogs(pending []pendingWorklog) {
+	table := ui.NewTableBuilder("Task", "Jira", "Date", "Hours").Align(3, ui.AlignRight)
+	for _, entry := range pending {
+		table.Row(
+			fmt.Sprintf("[%s] %s", entry.TaskID, entry.TaskTitle),
+			entry.Issue,
+			ui.FormatDate(entry.Entry.Date),
+			ui.FormatHours(entry.Entry.Hours),
+		)
+	}
+	table.Print()
+}
+
+// pushTimeView is the JSON result of 'jira pushtime'
+type pushTimeView struct {
+	Project  string        `json:"project"`
+	Worklogs []worklogView `json:"worklogs"`
+	Skipped  int           `json:"skipped"` // Already in Jira
+	Failures []string      `json:"failures"`
+}
+
+// worklogView is a time entry and the worklog it was, or would be, pushed as
+type worklogView struct {
+	TaskID    string  `json:"task_id"`
+	Issue     string  `json:"issue"`
+	Date      string  `json:"date"`
+	Hours     float64 `json:"hours"`
+	WorklogID string  `json:"worklog_id,omitempty"`
+}
+
+func newPushTimeView(projectName string, worklogs []pendingWorklog, skipped int, failures []string) pushTimeView {
+	view := pushTimeView{
+		Project:  projectName,
+		Worklogs: make([]worklogView, 0, len(worklogs)),
+		Skipped:  skipped,
+		Failures: append(make([]string, 0), failures...),
+	}
+	for _, entry := range worklogs {
+		view.Worklogs = append(view.Worklogs, worklogView{
+			TaskID:    entry.TaskID,
+			Issue:     entry.Issue,
+			Date:      entry.Entry.Date,
+			Hours:     entry.Entry.Hours,
+			WorklogID: entry.WorklogID,
+		})
+	}
+	return view
+}
+
+func init() {
+	jiraPushTimeCmd.Flags().String("from", "", "Push entries from this date (YYYY-MM-DD)")
+	jiraPushTimeCmd.Flags().String("to", "", "Push entries up to this date (YYYY-MM-DD)")
+	jiraPushTimeCmd.RegisterFlagCompletionFunc("from", completeDates)
+	jiraPushTimeCmd.RegisterFlagCompletionFunc("to", completeDates)
+	ownDryRun(jiraPushTimeCmd)
+	jiraPushTimeCmd.ValidArgsFunction = projectArgCompletion
+
+	jiraCmd.AddCommand(jiraPushTimeCmd)
+}
@@ -0,0 +1,149 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/mrbooshehri/qix-go/internal/storage"
+	"github.com/mrbooshehri/qix-go/internal/ui"
+	"github.com/mrbooshehri/qix-go/pkg/qix/models"
+	"github.com/spf13/cobra"
+)
+
+var journalCmd = &cobra.Command{
+	Use:   "journal",
+	Short: "Show the mutation journal",
+	Long: `Show recent entries from the append-only journal of task changes.
+
+Every task creation, update, status change, time entry and removal is
+journaled before it is applied. Entries marked pending were never confirmed
+as saved, usually because qix was interrupted; 'qix journal recover' replays them.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		projectName, _ := cmd.Flags().GetString("project")
+		limit, _ := cmd.Flags().GetInt("limit")
+		pendingOnly, _ := cmd.Flags().GetBool("pending")
+
+		store := storage.Get()
+
+		records, err := store.ReadJournal()
+		if err != nil {
+			return fail("Failed to read journal: %v", err)
+		}
+
+		filtered := make([]storage.JournalRecord, 0, len(records))
+		for _, record := range records {
+			if projectName != "" && record.Project != projectName {
+				continue
+			}
+			if pendingOnly && record.Committed {
+				continue
+			}
+			filtered = append(filtered, record)
+		}
+		if limit > 0 && len(filtered) > limit {
+			filtered = filtered[len(filtered)-limit:]
+		}
+
+		if jsonOutput {
+			type journalJSON struct {
+				models.JournalEntry
+				Committed bool `json:"committed"`
+			}
+			out := make([]journalJSON, 0, len(filtered))
+			for _, record := range filtered {
+				out = append(out, journalJSON{record.JournalEntry, record.Committed})
+			}
+			printJSON(out)
+			return nil
+		}
+
+		ui.PrintHeader("📓 Journal")
+
+		if len(filtered) == 0 {
+			ui.PrintEmptyState("No journal entries", "Changes to tasks are journaled as they are made")
+			return nil
+		}
+
+		table := ui.NewTableBuilder("When", "Operation", "Task", "Change", "State")
+		for _, record := range filtered {
+			state := "ok"
+			if !record.Committed {
+				state = "PENDING"
+			}
+			table.Row(
+				ui.FormatDateTime(record.At.Local()),
+				string(record.Op),
+				record.Project+"/"+record.TaskID,
+				describeJournalChange(record.JournalEntry),
+				state,
+			)
+		}
+		table.PrintSimple()
+		return nil
+	},
+}
+
+var journalRecoverCmd = &cobra.Command{
+	Use:   "recover",
+	Short: "Replay journaled changes that were not saved",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		store := storage.Get()
+
+		pending, err := store.PendingJournal()
+		if err != nil {
+			return fail("Failed to read journal: %v", err)
+		}
+		if len(pending) == 0 {
+			ui.PrintSuccess("No pending journal entries")
+			return nil
+		}
+
+		replayed, err := store.RecoverJournal()
+		if err != nil {
+			return fail("Recovery stopped: %v", err)
+		}
+
+		ui.PrintSuccess("Checked %d pending journal entries", len(pending))
+		if replayed > 0 {
+			ui.Yellow.Printf("  Replayed: %d change(s) that had not reached disk\n", replayed)
+		} else {
+			ui.Dim.Println("  All changes were already saved")
+		}
+		return nil
+	},
+}
+
+// describeJournalChange summarizes what a journal entry changed
+func describeJournalChange(entry models.JournalEntry) string {
+	switch entry.Op {
+	case models.OpTaskCreated:
+		if entry.After != nil {
+			return ganttLabel(*entry.After)
+		}
+	case models.OpTaskRemoved:
+		if entry.Before != nil {
+			return ganttLabel(*entry.Before)
+		}
+	case models.OpStatusChanged:
+		if entry.Before != nil && entry.After != nil {
+			return fmt.Sprintf("%s -> %s", entry.Before.Status, entry.After.Status)
+		}
+	case models.OpTimeLogged:
+		if entry.Before != nil && entry.After != nil {
+			logged := entry.After.CalculateActualHours() - entry.Before.CalculateActualHours()
+			return "+" + ui.FormatHours(logged)
+		}
+	}
+	return ""
+}
+
+func init() {
+	journalCmd.Flags().StringP("project", "p", "", "Only show entries for a project")
+	journalCmd.Flags().IntP("limit", "n", 20, "Number of most recent entries to show (0 for all)")
+	journalCmd.Flags().Bool("pending", false, "Only show entries that were never confirmed as saved")
+
+	noJSON(journalRecoverCmd)
+	journalCmd.AddCommand(journalRecoverCmd)
+	rootCmd.AddCommand(journalCmd)
+}
@@ -0,0 +1,62 @@
+package cmd
+
+import (
+	"strings"
+
+	"github.com/mrbooshehri/qix-go/internal/config"
+	"github.com/mrbooshehri/qix-go/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+// listingAnnotation marks commands with --fields and --compact, and whether
+// their fields are task or project ones
+const listingAnnotation = "qix-listing"
+
+// listingFlags adds --fields and --compact to commands listing tasks or
+// projects, as kind says
+func listingFlags(kind string, cmds ...*cobra.Command) {
+	fields := ui.TaskFields
+	if kind == "project" {
+		fields = ui.ProjectFields
+	}
+	for _, cmd := range cmds {
+		cmd.Flags().String("fields", "", "Fields to show, replacing the configured ones or +field/-field to change them ("+strings.Join(fields, ", ")+")")
+		cmd.Flags().Bool("compact", false, "Show one line per "+kind)
+		if cmd.Annotations == nil {
+			cmd.Annotations = make(map[string]string)
+		}
+		cmd.Annotations[listingAnnotation] = kind
+	}
+}
+
+// applyListing selects the fields listings show and whether they are compact,
+// from the config and the command's --fields and --compact flags
+func applyListing(cmd *cobra.Command) error {
+	cfg := config.Get()
+	taskFields := ui.ParseFields(strings.Join(cfg.TaskFields, ","), ui.TaskFields)
+	projectFields := ui.ParseFields(strings.Join(cfg.ProjectFields, ","), ui.ProjectFields)
+	compact := cfg.CompactLists
+
+	if kind, ok := cmd.Annotations[listingAnnotation]; ok {
+		if cmd.Flags().Changed("fields") {
+			spec, _ := cmd.Flags().GetString("fields")
+			if kind == "project" {
+				projectFields = ui.ParseFields(spec, projectFields)
+			} else {
+				taskFields = ui.ParseFields(spec, taskFields)
+			}
+		}
+		if cmd.Flags().Changed("compact") {
+			compact, _ = cmd.Flags().GetBool("compact")
+		}
+	}
+
+	if err := ui.SetTaskFields(taskFields); err != nil {
+		return err
+	}
+	if err := ui.SetProjectFields(projectFields); err != nil {
+		return err
+	}
+	ui.SetCompactLists(compact)
+	return nil
+}
@@ -0,0 +1,251 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mrbooshehri/qix-go/internal/config"
+	"github.com/mrbooshehri/qix-go/internal/logging"
+	"github.com/mrbooshehri/qix-go/internal/ui"
+)
+
+// logPollInterval is how often 'logs tail --follow' looks for new entries
+const logPollInterval = 500 * time.Millisecond
+
+var logsCmd = &cobra.Command{
+	Use:   "logs",
+	Short: "Show the qix log",
+	Long: `Show the log qix writes to (log_file in the config, ~/.qix/qix.log by
+default), without having to find the file.
+
+The log is rotated once it reaches log_max_size_kb (1024 by default) or has
+collected entries for log_rotate_days (7), and rotated logs are removed after
+log_retention_days (30). Rotated logs sit next to the log, named after the
+time they were rotated, such as qix-20261016-153000.log.`,
+}
+
+var logsShowCmd = &cobra.Command{
+	Use:   "show",
+	Short: "Print the log",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		lines, _ := cmd.Flags().GetInt("lines")
+		level, _ := cmd.Flags().GetString("level")
+		all, _ := cmd.Flags().GetBool("all")
+
+		path := config.Get().LogFile
+		files := []string{path}
+		if all {
+			files = append(logging.RotatedFiles(path), path)
+		}
+
+		entries := make([]string, 0)
+		for _, file := range files {
+			read, err := readLogLines(file, level)
+			if err != nil && !os.IsNotExist(err) {
+				return fail("Failed to read %s: %v", file, err)
+			}
+			entries = append(entries, read...)
+		}
+		if lines > 0 && len(entries) > lines {
+			entries = entries[len(entries)-lines:]
+		}
+
+		if len(entries) == 0 {
+			ui.PrintEmptyState("The log is empty", "Entries are written to "+path)
+			return nil
+		}
+		for _, line := range entries {
+			printLogLine(line)
+		}
+		return nil
+	},
+}
+
+var logsTailCmd = &cobra.Command{
+	Use:   "tail",
+	Short: "Print the end of the log, and with --follow what is logged next",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		lines, _ := cmd.Flags().GetInt("lines")
+		level, _ := cmd.Flags().GetString("level")
+		follow, _ := cmd.Flags().GetBool("follow")
+
+		path := config.Get().LogFile
+		entries, err := readLogLines(path, level)
+		if err != nil && !os.IsNotExist(err) {
+			return fail("Failed to read %s: %v", path, err)
+		}
+		if lines >= 0 && len(entries) > lines {
+			entries = entries[len(entries)-lines:]
+		}
+		for _, line := range entries {
+			printLogLine(line)
+		}
+
+		if follow {
+			if err := followLog(path, level); err != nil {
+				return fail("Failed to follow %s: %v", path, err)
+			}
+		}
+		return nil
+	},
+}
+
+// readLogLines returns the lines of a log file, keeping only the entries of
+// level or above if level is set. Lines continuing an entry go with it.
+func readLogLines(path, level string) ([]string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	filter := newLogFilter(level)
+	lines := make([]string, 0)
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		if filter(scanner.Text()) {
+			lines = append(lines, scanner.Text())
+		}
+	}
+	return lines, scanner.Err()
+}
+
+// newLogFilter returns a function reporting whether a line of the log is
+// shown: all lines if level is empty, else those of entries of level or above
+func newLogFilter(level string) func(line string) bool {
+	if level == "" {
+		return func(string) bool { return true }
+	}
+	minimum := logging.ParseLevel(level)
+	shown := true
+	return func(line string) bool {
+		if entryLevel, ok := logging.EntryLevel(line); ok {
+			shown = entryLevel >= minimum
+		}
+		return shown
+	}
+}
+
+// followLog prints what is added to the log until the user presses Ctrl+C,
+// starting over when the log is rotated
+func followLog(path, level string) error {
+	interrupt := make(chan os.Signal, 1)
+	signal.Notify(interrupt, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(interrupt)
+
+	file, err := os.Open(path)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	var offset int64
+	if file != nil {
+		if offset, err = file.Seek(0, io.SeekEnd); err != nil {
+			file.Close()
+			return err
+		}
+	}
+	defer func() {
+		if file != nil {
+			file.Close()
+		}
+	}()
+
+	filter := newLogFilter(level)
+	pending := ""
+	ticker := time.NewTicker(logPollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-interrupt:
+			return nil
+		case <-ticker.C:
+		}
+
+		// A rotated log is replaced by a new file; read that from the start
+		if info, err := os.Stat(path); err == nil {
+			var current os.FileInfo
+			if file != nil {
+				current, _ = file.Stat()
+			}
+			if current == nil || !os.SameFile(info, current) || info.Size() < offset {
+				if file != nil {
+					file.Close()
+				}
+				if file, err = os.Open(path); err != nil {
+					file = nil
+					continue
+				}
+				offset, pending = 0, ""
+			}
+		}
+		if file == nil {
+			continue
+		}
+
+		data, err := io.ReadAll(file)
+		if err != nil {
+			return err
+		}
+		offset += int64(len(data))
+		pending += string(data)
+		for {
+			end := strings.IndexByte(pending, '\n')
+			if end < 0 {
+				break
+			}
+			if line := pending[:end]; filter(line) {
+				printLogLine(line)
+			}
+			pending = pending[end+1:]
+		}
+	}
+}
+
+// printLogLine prints a line of the log, colored by the level of its entry
+func printLogLine(line string) {
+	level, ok := logging.EntryLevel(line)
+	switch {
+	case !ok:
+		fmt.Println(line)
+	case level == logging.LevelError:
+		ui.Red.Println(line)
+	case level == logging.LevelWarn:
+		ui.Yellow.Println(line)
+	case level == logging.LevelDebug:
+		ui.Dim.Println(line)
+	default:
+		fmt.Println(line)
+	}
+}
+
+func init() {
+	logsShowCmd.Flags().IntP("lines", "n", 0, "Show only the last n lines (0 for all)")
+	logsShowCmd.Flags().String("level", "", "Show only entries of this level or above (debug, info, warn, error)")
+	logsShowCmd.Flags().Bool("all", false, "Include rotated logs, oldest first")
+	logsTailCmd.Flags().IntP("lines", "n", 10, "Number of lines to show")
+	logsTailCmd.Flags().String("level", "", "Show only entries of this level or above (debug, info, warn, error)")
+	logsTailCmd.Flags().BoolP("follow", "f", false, "Keep printing entries as they are logged, until Ctrl+C")
+
+	for _, cmd := range []*cobra.Command{logsShowCmd, logsTailCmd} {
+		cmd.RegisterFlagCompletionFunc("level", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			return []string{"debug", "info", "warn", "error"}, cobra.ShellCompDirectiveNoFileComp
+		})
+	}
+
+	logsCmd.AddCommand(logsShowCmd)
+	logsCmd.AddCommand(logsTailCmd)
+	rootCmd.AddCommand(logsCmd)
+	usePager(true, logsShowCmd)
+	noJSON(logsCmd)
+}
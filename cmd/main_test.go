@@ -0,0 +1,23 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"testing"
+)
+
+// TestMain points the storage at a data directory of its own, so tests
+// running commands leave the user's data alone
+func TestMain(m *testing.M) {
+	dir, err := os.MkdirTemp("", "qix-test-")
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	os.Setenv("QIX_DIR", dir)
+	os.Unsetenv("QIX_PROFILE")
+
+	code := m.Run()
+	os.RemoveAll(dir)
+	os.Exit(code)
+}
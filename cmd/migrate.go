@@ -0,0 +1,103 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/mrbooshehri/qix-go/internal/config"
+	"github.com/mrbooshehri/qix-go/internal/migrations"
+	"github.com/mrbooshehri/qix-go/internal/storage"
+	"github.com/mrbooshehri/qix-go/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var migrateCmd = &cobra.Command{
+	Use:   "migrate",
+	Short: "Upgrade data files to the current schema",
+	Long: `Upgrade project files, tracking data and the task index to the current schema version.
+
+Older files are already upgraded in memory whenever they are loaded; this
+command rewrites them on disk. A backup is created before anything is changed.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		noBackup, _ := cmd.Flags().GetBool("no-backup")
+
+		store := storage.Get()
+
+		ui.PrintHeader("🔧 Schema Migration")
+
+		files, err := store.SchemaStatus()
+		if err != nil {
+			return fail("Failed to read data files: %v", err)
+		}
+
+		pending := make([]storage.SchemaFile, 0)
+		problems := 0
+		for _, file := range files {
+			switch {
+			case file.Err != nil:
+				ui.PrintError("%s %s: %v", file.Kind, file.Name, file.Err)
+				problems++
+			case file.Version > file.Latest:
+				ui.PrintError("%s %s has schema %d, newer than this qix supports (%d)", file.Kind, file.Name, file.Version, file.Latest)
+				problems++
+			case file.NeedsMigration():
+				pending = append(pending, file)
+			}
+		}
+
+		if len(pending) == 0 {
+			if problems == 0 {
+				ui.PrintSuccess("All %d data file(s) are up to date", len(files))
+			}
+			return nil
+		}
+
+		for _, file := range pending {
+			ui.Cyan.Printf("%s %s: schema %d -> %d\n", file.Kind, file.Name, file.Version, file.Latest)
+			for _, m := range migrations.Pending(file.Kind, file.Version) {
+				ui.Dim.Printf("  • %s\n", m.Description)
+			}
+		}
+		fmt.Println()
+
+		if dryRun {
+			ui.PrintInfo("%d file(s) need migration (dry run, nothing changed)", len(pending))
+			return nil
+		}
+
+		if !noBackup {
+			cfg := config.Get()
+			backupName := fmt.Sprintf("qix_backup_%s.tar.gz", time.Now().Format("20060102_150405"))
+			if err := createTarGz(cfg.QixDir, filepath.Join(cfg.BackupDir, backupName), false); err != nil {
+				return fail("Failed to create backup, nothing migrated: %v", err)
+			}
+			ui.PrintInfo("Backup created: %s", backupName)
+		}
+
+		failed := 0
+		for _, file := range pending {
+			if err := store.Migrate(file); err != nil {
+				ui.PrintError("Failed to migrate %s %s: %v", file.Kind, file.Name, err)
+				failed++
+			}
+		}
+
+		if failed > 0 {
+			ui.PrintWarning("Migrated %d of %d file(s)", len(pending)-failed, len(pending))
+			return nil
+		}
+		ui.PrintSuccess("Migrated %d file(s)", len(pending))
+		return nil
+	},
+}
+
+func init() {
+	ownDryRun(migrateCmd)
+	migrateCmd.Flags().Bool("no-backup", false, "Skip the backup taken before migrating")
+
+	noJSON(migrateCmd)
+	rootCmd.AddCommand(migrateCmd)
+}
@@ -4,9 +4,9 @@ import (
 	"fmt"
 	"strings"
 
-	"github.com/mrbooshehri/qix-go/internal/models"
 	"github.com/mrbooshehri/qix-go/internal/storage"
 	"github.com/mrbooshehri/qix-go/internal/ui"
+	"github.com/mrbooshehri/qix-go/pkg/qix/models"
 	"github.com/spf13/cobra"
 )
 
@@ -20,7 +20,7 @@ var moduleCreateCmd = &cobra.Command{
 	Use:   "create <project/module> [description]",
 	Short: "Create a new module",
 	Args:  cobra.MinimumNArgs(1),
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		path := args[0]
 		description := ""
 		if len(args) > 1 {
@@ -30,8 +30,7 @@ var moduleCreateCmd = &cobra.Command{
 		// Parse path
 		parts := strings.SplitN(path, "/", 2)
 		if len(parts) != 2 {
-			ui.PrintError("Invalid path format. Use: <project>/<module>")
-			return
+			return invalid("Invalid path format. Use: <project>/<module>")
 		}
 
 		projectName := parts[0]
@@ -48,14 +47,19 @@ var moduleCreateCmd = &cobra.Command{
 		}
 
 		if err := store.AddModule(projectName, module); err != nil {
-			ui.PrintError("Failed to create module: %v", err)
-			return
+			return fail("Failed to create module: %v", err)
+		}
+
+		if jsonOutput {
+			printJSON(newModuleSummary(module))
+			return nil
 		}
 
 		ui.PrintSuccess("Module '%s' created in project '%s'", moduleName, projectName)
 		if description != "" {
 			ui.Dim.Printf("  Description: %s\n", description)
 		}
+		return nil
 	},
 }
 
@@ -63,14 +67,35 @@ var moduleListCmd = &cobra.Command{
 	Use:   "list <project>",
 	Short: "List modules in a project",
 	Args:  cobra.ExactArgs(1),
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		projectName := args[0]
 		store := storage.Get()
 
 		project, err := store.LoadProject(projectName)
 		if err != nil {
-			ui.PrintError("Project not found: %s", projectName)
-			return
+			return notFound("Project not found: %s", projectName)
+		}
+
+		if jsonOutput {
+			modules := make([]moduleSummary, 0, len(project.Modules))
+			for _, module := range project.Modules {
+				modules = append(modules, newModuleSummary(module))
+			}
+			printJSON(modules)
+			return nil
+		}
+		if porcelain {
+			// Name, tasks, done, completion, estimated and actual hours
+			for _, module := range project.Modules {
+				summary := newModuleSummary(module)
+				ui.PrintRecord(summary.Name,
+					fmt.Sprintf("%d", summary.Tasks),
+					fmt.Sprintf("%d", summary.Done),
+					fmt.Sprintf("%.1f", summary.Completion),
+					ui.FormatRecordHours(summary.EstimatedHours),
+					ui.FormatRecordHours(summary.ActualHours))
+			}
+			return nil
 		}
 
 		if len(project.Modules) == 0 {
@@ -78,34 +103,25 @@ var moduleListCmd = &cobra.Command{
 				fmt.Sprintf("No modules in project '%s'", projectName),
 				fmt.Sprintf("Create one with: qix module create %s/<module_name>", projectName),
 			)
-			return
+			return nil
 		}
 
 		ui.PrintHeader(fmt.Sprintf("📦 Modules in '%s'", projectName))
 
 		for _, module := range project.Modules {
+			summary := newModuleSummary(module)
 			ui.BoldCyan.Printf("\n• %s\n", module.Name)
 
 			if module.Description != "" {
 				ui.Blue.Printf("  %s\n", module.Description)
 			}
 
-			taskCount := len(module.Tasks)
-			ui.Yellow.Printf("  Tasks: %d\n", taskCount)
+			ui.Yellow.Printf("  Tasks: %d\n", summary.Tasks)
 
-			if taskCount > 0 {
-				// Calculate completion
-				done := 0
-				for _, task := range module.Tasks {
-					if task.Status == models.StatusDone {
-						done++
-					}
-				}
-
-				completion := float64(done) / float64(taskCount) * 100
+			if summary.Tasks > 0 {
 				ui.Cyan.Printf("  Progress: ")
-				ui.PrintProgressBar(completion, 30)
-				fmt.Printf(" %.1f%%\n", completion)
+				ui.PrintProgressBar(summary.Completion, 30)
+				fmt.Printf(" %.1f%%\n", summary.Completion)
 			}
 
 			if len(module.Tags) > 0 {
@@ -113,6 +129,7 @@ var moduleListCmd = &cobra.Command{
 			}
 		}
 		fmt.Println()
+		return nil
 	},
 }
 
@@ -120,14 +137,13 @@ var moduleShowCmd = &cobra.Command{
 	Use:   "show <project/module>",
 	Short: "Show module details",
 	Args:  cobra.ExactArgs(1),
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		path := args[0]
 
 		// Parse path
 		parts := strings.SplitN(path, "/", 2)
 		if len(parts) != 2 {
-			ui.PrintError("Invalid path format. Use: <project>/<module>")
-			return
+			return invalid("Invalid path format. Use: <project>/<module>")
 		}
 
 		projectName := parts[0]
@@ -137,8 +153,13 @@ var moduleShowCmd = &cobra.Command{
 
 		module, err := store.GetModule(projectName, moduleName)
 		if err != nil {
-			ui.PrintError("Module not found: %v", err)
-			return
+			return notFound("Module not found: %v", err)
+		}
+
+		details := newModuleDetails(projectName, *module)
+		if jsonOutput {
+			printJSON(details)
+			return nil
 		}
 
 		ui.PrintHeader(fmt.Sprintf("📦 %s", module.Name))
@@ -149,32 +170,18 @@ var moduleShowCmd = &cobra.Command{
 		}
 
 		// Statistics
-		taskCount := len(module.Tasks)
-		done := 0
-		totalEst := 0.0
-		totalAct := 0.0
-
-		for _, task := range module.Tasks {
-			if task.Status == models.StatusDone {
-				done++
-			}
-			totalEst += task.EstimatedHours
-			totalAct += task.CalculateActualHours()
-		}
-
 		table := ui.NewTableBuilder("Metric", "Value").
-			Row("Total Tasks", fmt.Sprintf("%d", taskCount)).
-			Row("Completed", fmt.Sprintf("%d", done))
+			Row("Total Tasks", fmt.Sprintf("%d", details.moduleSummary.Tasks)).
+			Row("Completed", fmt.Sprintf("%d", details.Done))
 
-		if taskCount > 0 {
-			completion := float64(done) / float64(taskCount) * 100
-			table.Row("Completion", fmt.Sprintf("%.1f%%", completion))
+		if details.moduleSummary.Tasks > 0 {
+			table.Row("Completion", fmt.Sprintf("%.1f%%", details.Completion))
 		}
 
-		if totalEst > 0 {
+		if details.EstimatedHours > 0 {
 			table.Row("", "").
-				Row("Estimated", ui.FormatHours(totalEst)).
-				Row("Actual", ui.FormatHours(totalAct))
+				Row("Estimated", ui.FormatHours(details.EstimatedHours)).
+				Row("Actual", ui.FormatHours(details.ActualHours))
 		}
 
 		table.Align(1, ui.AlignRight).PrintSimple()
@@ -195,6 +202,7 @@ var moduleShowCmd = &cobra.Command{
 			ui.PrintSubHeader("🏷️  Tags")
 			ui.PrintList(module.Tags, "•")
 		}
+		return nil
 	},
 }
 
@@ -202,14 +210,13 @@ var moduleRemoveCmd = &cobra.Command{
 	Use:   "remove <project/module>",
 	Short: "Remove a module",
 	Args:  cobra.ExactArgs(1),
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		path := args[0]
 
 		// Parse path
 		parts := strings.SplitN(path, "/", 2)
 		if len(parts) != 2 {
-			ui.PrintError("Invalid path format. Use: <project>/<module>")
-			return
+			return invalid("Invalid path format. Use: <project>/<module>")
 		}
 
 		projectName := parts[0]
@@ -220,33 +227,28 @@ var moduleRemoveCmd = &cobra.Command{
 		// Check if module exists
 		module, err := store.GetModule(projectName, moduleName)
 		if err != nil {
-			ui.PrintError("Module not found: %v", err)
-			return
+			return notFound("Module not found: %v", err)
 		}
 
 		// Confirmation
-		force, _ := cmd.Flags().GetBool("force")
+		force := skipConfirm(cmd)
 
 		if !force {
 			fmt.Printf("⚠️  This will delete module '%s' and its %d task(s).\n",
 				moduleName, len(module.Tasks))
-			fmt.Print("Type the module name to confirm: ")
-
-			var confirm string
-			fmt.Scanln(&confirm)
-
-			if confirm != moduleName {
+			if !ui.ConfirmTyped(moduleName) {
 				ui.PrintInfo("Deletion cancelled")
-				return
+				return nil
 			}
 		}
 
 		if err := store.RemoveModule(projectName, moduleName); err != nil {
-			ui.PrintError("Failed to remove module: %v", err)
-			return
+			return fail("Failed to remove module: %v", err)
 		}
 
 		ui.PrintSuccess("Module '%s' removed from project '%s'", moduleName, projectName)
+		ui.Dim.Println("Moved to trash; use 'qix trash list' to restore it")
+		return nil
 	},
 }
 
@@ -254,14 +256,13 @@ var moduleEditCmd = &cobra.Command{
 	Use:   "edit <project/module>",
 	Short: "Edit module details",
 	Args:  cobra.ExactArgs(1),
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		path := args[0]
 
 		// Parse path
 		parts := strings.SplitN(path, "/", 2)
 		if len(parts) != 2 {
-			ui.PrintError("Invalid path format. Use: <project>/<module>")
-			return
+			return invalid("Invalid path format. Use: <project>/<module>")
 		}
 
 		projectName := parts[0]
@@ -271,8 +272,7 @@ var moduleEditCmd = &cobra.Command{
 		newDesc, _ := cmd.Flags().GetString("description")
 
 		if newName == "" && newDesc == "" {
-			ui.PrintError("Specify at least --name or --description")
-			return
+			return invalid("Specify at least --name or --description")
 		}
 
 		store := storage.Get()
@@ -288,8 +288,7 @@ var moduleEditCmd = &cobra.Command{
 		})
 
 		if err != nil {
-			ui.PrintError("Failed to update module: %v", err)
-			return
+			return fail("Failed to update module: %v", err)
 		}
 
 		if newName != "" && newName != moduleName {
@@ -301,12 +300,14 @@ var moduleEditCmd = &cobra.Command{
 		if newDesc != "" {
 			ui.Dim.Printf("  Description: %s\n", newDesc)
 		}
+		return nil
 	},
 }
 
 func init() {
 	// module create flags
 	moduleCreateCmd.Flags().StringSliceP("tags", "t", []string{}, "Tags for the module")
+	moduleCreateCmd.RegisterFlagCompletionFunc("tags", completeTags)
 	moduleCreateCmd.ValidArgsFunction = moduleCreateArgCompletion
 
 	// module remove flags
@@ -320,6 +321,9 @@ func init() {
 
 	moduleListCmd.ValidArgsFunction = projectArgCompletion
 	moduleShowCmd.ValidArgsFunction = modulePathArgCompletion
+	listingFlags("task", moduleShowCmd)
+
+	noJSON(moduleRemoveCmd, moduleEditCmd)
 
 	// Add subcommands
 	moduleCmd.AddCommand(moduleCreateCmd)
@@ -327,4 +331,7 @@ func init() {
 	moduleCmd.AddCommand(moduleShowCmd)
 	moduleCmd.AddCommand(moduleRemoveCmd)
 	moduleCmd.AddCommand(moduleEditCmd)
+
+	usePager(true, moduleListCmd, moduleShowCmd)
+	supportDryRun(moduleCreateCmd, moduleRemoveCmd, moduleEditCmd)
 }
@@ -0,0 +1,238 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mrbooshehri/qix-go/internal/config"
+	"github.com/mrbooshehri/qix-go/internal/events"
+	"github.com/mrbooshehri/qix-go/internal/notify"
+	"github.com/mrbooshehri/qix-go/internal/storage"
+	"github.com/mrbooshehri/qix-go/internal/subscribers"
+	"github.com/mrbooshehri/qix-go/internal/ui"
+	"github.com/mrbooshehri/qix-go/pkg/qix/models"
+)
+
+var notifyCmd = &cobra.Command{
+	Use:   "notify",
+	Short: "Send notifications to webhooks",
+	Long: `Post messages about what happens in qix to webhooks: any URL that takes
+JSON, or Slack and Discord incoming webhooks. Define them in the config file:
+
+  webhook.team = https://hooks.slack.com/services/T000/B000/XXXX
+  webhook_events.team = task-done,sprint-closed
+  webhook_format.team = slack
+
+The format is json, slack or discord, and is guessed from the URL if not set.
+A webhook gets every event unless webhook_events lists some:
+
+  task-done       A task was marked done
+  task-blocked    A task was marked blocked
+  sprint-closed   A sprint was closed with 'sprint close'
+  daily-summary   Sent by 'notify daily', e.g. from cron
+
+The events are those of 'qix events', and webhooks are one of its subscribers.
+The names of earlier versions, such as task_completed, are still accepted.
+
+Messages are Go templates and can be changed per event, e.g.
+  notify_template.task-done = Done: {{.Task.Title}} ({{hours .Task.Hours}})
+JSON webhooks get the event with its details and the message as "text".`,
+}
+
+var notifyListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the configured webhooks",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		notifier := newNotifier()
+
+		if jsonOutput {
+			views := make([]webhookView, 0, len(notifier.Hooks()))
+			for _, hook := range notifier.Hooks() {
+				views = append(views, newWebhookView(hook))
+			}
+			printJSON(views)
+			return nil
+		}
+
+		if !notifier.Enabled() {
+			ui.PrintEmptyState(
+				"No webhooks configured",
+				"Add one to the config file: webhook.<name> = <url>",
+			)
+			return nil
+		}
+
+		table := ui.NewTableBuilder("Name", "Format", "Events", "Host")
+		for _, hook := range notifier.Hooks() {
+			view := newWebhookView(hook)
+			table.Row(view.Name, view.Format, strings.Join(view.Events, ", "), view.Host)
+		}
+		table.Print()
+		return nil
+	},
+}
+
+var notifyTestCmd = &cobra.Command{
+	Use:   "test [name]",
+	Short: "Send a test message to the webhooks",
+	Args:  cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		notifier := newNotifier()
+		if !notifier.Enabled() {
+			return withHint(fail("No webhooks configured"), "Add one to the config file: webhook.<name> = <url>")
+		}
+
+		sent := 0
+		for _, hook := range notifier.Hooks() {
+			if len(args) > 0 && hook.Name != strings.ToLower(args[0]) {
+				continue
+			}
+			sent++
+			if err := notifier.SendTo(context.Background(), hook, notify.Event{Type: notify.Test}); err != nil {
+				ui.PrintError("%s: %v", hook.Name, err)
+				continue
+			}
+			ui.PrintSuccess("Sent a test message to %s", hook.Name)
+		}
+		if sent == 0 {
+			return notFound("Unknown webhook: %s", args[0])
+		}
+		return nil
+	},
+}
+
+var notifyDailyCmd = &cobra.Command{
+	Use:   "daily [date]",
+	Short: "Send a summary of a day's work",
+	Long: `Publish the hours logged and the tasks done on a day (today by default) as a
+daily-summary event, sent to the webhooks and subscribers that take it. Run it
+from cron at the end of the day, e.g.:
+
+  55 17 * * 1-5  qix notify daily`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+		date := time.Now().Format("2006-01-02")
+		if len(args) > 0 {
+			normalized, err := ui.NormalizeDate(args[0])
+			if err != nil {
+				return invalid("Invalid date format. Use: %s", ui.DateFormats())
+			}
+			date = normalized
+		}
+
+		projects, err := storage.Get().GetAllProjects()
+		if err != nil {
+			return fail("Failed to load projects: %v", err)
+		}
+		event := events.Event{Type: events.DailySummary, Summary: dailySummary(projects, date)}
+
+		if dryRun {
+			text, err := newNotifier().Render(notify.Event{Type: notify.DailySummary, Summary: event.Summary})
+			if err != nil {
+				return fail("%v", err)
+			}
+			fmt.Println(text)
+			return nil
+		}
+		if subscribersOf(event) == 0 {
+			return withHint(fail("No subscribers get %s events", event.Type), "Add one to the config file: webhook.<name> = <url>")
+		}
+
+		if errs := eventBus.Publish(context.Background(), event); len(errs) > 0 {
+			for _, err := range errs {
+				ui.PrintError("%v", err)
+			}
+			return nil
+		}
+		ui.PrintSuccess("Sent the summary of %s", date)
+		return nil
+	},
+}
+
+// newNotifier returns a notifier for the webhooks in the config file
+func newNotifier() *notify.Notifier {
+	return subscribers.Notifier(config.Get())
+}
+
+// dailySummary collects the hours logged and the tasks done on a day
+func dailySummary(projects []*models.Project, date string) *notify.SummaryInfo {
+	summary := &notify.SummaryInfo{
+		Date:      date,
+		Worked:    make([]notify.TaskInfo, 0),
+		Completed: make([]notify.TaskInfo, 0),
+	}
+	for _, project := range projects {
+		modules := taskModules(project)
+		for _, task := range project.GetAllTasks() {
+			hours := 0.0
+			for _, entry := range task.TimeEntries {
+				if entry.Date == date {
+					hours += entry.Hours
+				}
+			}
+			completed, done := task.CompletedAt()
+			doneToday := done && completed.In(time.Local).Format("2006-01-02") == date
+			if hours == 0 && !doneToday {
+				continue
+			}
+
+			info := events.NewTaskInfo(project.Name, modules[task.ID], task)
+			info.Hours = hours
+			if hours > 0 {
+				summary.Worked = append(summary.Worked, info)
+				summary.Hours += hours
+			}
+			if doneToday {
+				summary.Completed = append(summary.Completed, info)
+			}
+		}
+	}
+
+	sort.SliceStable(summary.Worked, func(i, j int) bool {
+		return summary.Worked[i].Hours > summary.Worked[j].Hours
+	})
+	return summary
+}
+
+// webhookView is a webhook as listed by 'notify list'; the URL is left out
+// since it usually holds a secret
+type webhookView struct {
+	Name   string   `json:"name"`
+	Format string   `json:"format"`
+	Events []string `json:"events"`
+	Host   string   `json:"host"`
+}
+
+func newWebhookView(hook notify.Webhook) webhookView {
+	view := webhookView{Name: hook.Name, Format: hook.Kind(), Events: notify.Events}
+	if len(hook.Events) > 0 {
+		view.Events = make([]string, 0, len(hook.Events))
+		for _, event := range hook.Events {
+			view.Events = append(view.Events, notify.EventName(event))
+		}
+	}
+	if u, err := url.Parse(hook.URL); err == nil {
+		view.Host = u.Host
+	}
+	return view
+}
+
+func init() {
+	ownDryRun(notifyDailyCmd)
+	notifyDailyCmd.ValidArgsFunction = dateArgCompletion(0, nil)
+
+	noJSON(notifyTestCmd, notifyDailyCmd)
+	notifyCmd.AddCommand(notifyListCmd)
+	notifyCmd.AddCommand(notifyTestCmd)
+	notifyCmd.AddCommand(notifyDailyCmd)
+	rootCmd.AddCommand(notifyCmd)
+}
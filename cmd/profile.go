@@ -0,0 +1,111 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/mrbooshehri/qix-go/internal/config"
+	"github.com/mrbooshehri/qix-go/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var profileCmd = &cobra.Command{
+	Use:   "profile",
+	Short: "List data-directory profiles",
+	Long: `Profiles keep separate sets of data (for example work and personal) in
+their own directories. Define them in the config file:
+
+  profile.work     = ~/work/qix
+  profile.personal = ~/.qix-personal
+
+and select one with --profile <name> or the QIX_PROFILE environment variable.
+Settings always come from the main config file.`,
+}
+
+var profileListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List configured profiles",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg := config.Get()
+		profiles := config.Profiles()
+
+		if jsonOutput {
+			printJSON(newProfileViews(cfg, profiles))
+			return nil
+		}
+
+		ui.PrintHeader("👤 Profiles")
+
+		if len(profiles) == 0 {
+			ui.PrintEmptyState("No profiles configured", fmt.Sprintf("Add profile.<name> = <dir> to %s", cfg.ConfigFile))
+			return nil
+		}
+
+		names := make([]string, 0, len(profiles))
+		for name := range profiles {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		table := ui.NewTableBuilder("", "Profile", "Directory", "Projects")
+		for _, name := range names {
+			active := ""
+			if name == cfg.Profile {
+				active = "*"
+			}
+
+			dir, err := config.ProfileDir(name)
+			if err != nil {
+				dir = profiles[name]
+			}
+
+			projects := "-"
+			if projectNames, err := cfg.WithDataDir(dir).ListProjectFiles(); err == nil && len(projectNames) > 0 {
+				projects = fmt.Sprintf("%d", len(projectNames))
+			}
+
+			table.Row(active, name, dir, projects)
+		}
+		table.PrintSimple()
+
+		fmt.Println()
+		if cfg.Profile == "" {
+			ui.Dim.Printf("No profile active; using %s\n", cfg.QixDir)
+		}
+		ui.Dim.Println("Select a profile with --profile <name> or QIX_PROFILE=<name>")
+		return nil
+	},
+}
+
+// profileView is a profile as listed with --json
+type profileView struct {
+	Name     string `json:"name"`
+	Dir      string `json:"dir"`
+	Active   bool   `json:"active"`
+	Projects int    `json:"projects"`
+}
+
+// newProfileViews returns the views of the configured profiles, by name
+func newProfileViews(cfg *config.Config, profiles map[string]string) []profileView {
+	views := make([]profileView, 0, len(profiles))
+	for name, dir := range profiles {
+		if resolved, err := config.ProfileDir(name); err == nil {
+			dir = resolved
+		}
+		view := profileView{Name: name, Dir: dir, Active: name == cfg.Profile}
+		if projectNames, err := cfg.WithDataDir(dir).ListProjectFiles(); err == nil {
+			view.Projects = len(projectNames)
+		}
+		views = append(views, view)
+	}
+	sort.Slice(views, func(i, j int) bool {
+		return views[i].Name < views[j].Name
+	})
+	return views
+}
+
+func init() {
+	profileCmd.AddCommand(profileListCmd)
+	rootCmd.AddCommand(profileCmd)
+}
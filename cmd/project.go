@@ -3,13 +3,14 @@ package cmd
 import (
 	"fmt"
 	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/spf13/cobra"
 
-	"github.com/mrbooshehri/qix-go/internal/models"
 	"github.com/mrbooshehri/qix-go/internal/storage"
 	"github.com/mrbooshehri/qix-go/internal/ui"
+	"github.com/mrbooshehri/qix-go/pkg/qix/models"
 )
 
 var projectCmd = &cobra.Command{
@@ -22,7 +23,7 @@ var projectCreateCmd = &cobra.Command{
 	Use:   "create <name> [description]",
 	Short: "Create a new project",
 	Args:  cobra.MinimumNArgs(1),
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		name := args[0]
 		description := ""
 		if len(args) > 1 {
@@ -31,11 +32,18 @@ var projectCreateCmd = &cobra.Command{
 
 		tags, _ := cmd.Flags().GetStringSlice("tags")
 
-		store := storage.Get()
-		project, err := store.CreateProject(name, description, tags)
+		client := qixClient()
+		if err := client.CreateProject(name, description, tags); err != nil {
+			return fail("Failed to create project: %v", err)
+		}
+		project, err := client.Project(name)
 		if err != nil {
-			ui.PrintError("Failed to create project: %v", err)
-			return
+			return fail("Failed to create project: %v", err)
+		}
+
+		if jsonOutput {
+			printJSON(newProjectSummary(project))
+			return nil
 		}
 
 		ui.PrintSuccess("Project '%s' created", project.Name)
@@ -45,6 +53,7 @@ var projectCreateCmd = &cobra.Command{
 		if len(project.Tags) > 0 {
 			ui.Dim.Printf("  Tags: %s\n", strings.Join(project.Tags, ", "))
 		}
+		return nil
 	},
 }
 
@@ -52,35 +61,58 @@ var projectListCmd = &cobra.Command{
 	Use:   "list",
 	Short: "List existing projects",
 	Args:  cobra.NoArgs,
-	Run: func(cmd *cobra.Command, args []string) {
-		store := storage.Get()
-		names, err := store.ListProjects()
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client := qixClient()
+		names, err := client.Projects()
 		if err != nil {
-			ui.PrintError("Failed to list projects: %v", err)
-			return
+			return fail("Failed to list projects: %v", err)
 		}
 
-		if len(names) == 0 {
+		if len(names) == 0 && !jsonOutput && !porcelain {
 			ui.PrintEmptyState(
 				"No projects found",
 				"Create one with: qix project create <name>",
 			)
-			return
+			return nil
 		}
 
 		sort.Strings(names)
-		ui.PrintHeader("📁 Projects")
-
+		summaries := make([]projectSummary, 0, len(names))
 		for _, name := range names {
-			project, err := store.LoadProject(name)
+			project, err := client.Project(name)
 			if err != nil {
 				ui.PrintError("Failed to load project %s: %v", name, err)
 				continue
 			}
+			summaries = append(summaries, newProjectSummary(project))
+		}
 
-			printProjectSummary(project)
-			fmt.Println()
+		if jsonOutput {
+			printJSON(summaries)
+			return nil
+		}
+		if porcelain {
+			// Name, tasks, done, completion, estimated and actual hours, deadline
+			for _, summary := range summaries {
+				ui.PrintRecord(summary.Name,
+					fmt.Sprintf("%d", summary.Tasks),
+					fmt.Sprintf("%d", models.CountDone(summary.StatusCounts)),
+					fmt.Sprintf("%.1f", summary.Completion),
+					ui.FormatRecordHours(summary.EstimatedHours),
+					ui.FormatRecordHours(summary.ActualHours),
+					summary.Deadline)
+			}
+			return nil
 		}
+
+		ui.PrintHeader("📁 Projects")
+		for _, summary := range summaries {
+			printProjectSummary(summary)
+			if !ui.CompactLists() {
+				fmt.Println()
+			}
+		}
+		return nil
 	},
 }
 
@@ -88,14 +120,19 @@ var projectShowCmd = &cobra.Command{
 	Use:   "show <name>",
 	Short: "Show project details",
 	Args:  cobra.ExactArgs(1),
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		name := args[0]
 
-		store := storage.Get()
-		project, err := store.LoadProject(name)
+		client := qixClient()
+		project, err := client.Project(name)
 		if err != nil {
-			ui.PrintError("Project not found: %v", err)
-			return
+			return notFound("Project not found: %v", err)
+		}
+
+		details := newProjectDetails(project)
+		if jsonOutput {
+			printJSON(details)
+			return nil
 		}
 
 		ui.PrintHeader(fmt.Sprintf("📁 %s", project.Name))
@@ -104,39 +141,37 @@ var projectShowCmd = &cobra.Command{
 			fmt.Println()
 		}
 
+		if project.Deadline != "" {
+			ui.Yellow.Printf("⏰ Deadline: %s\n", ui.FormatDate(project.Deadline))
+		}
+		if project.Budget > 0 {
+			ui.Yellow.Printf("💰 Budget: %s\n", ui.FormatMoney(project.Budget))
+		}
+		if project.Deadline != "" || project.Budget > 0 {
+			fmt.Println()
+		}
+
 		if len(project.Tags) > 0 {
 			ui.PrintSubHeader("🏷️  Tags")
 			ui.PrintList(project.Tags, "•")
 			fmt.Println()
 		}
 
-		printProjectStats(project)
+		printProjectStats(details.projectSummary)
 		fmt.Println()
 
 		// Show modules
-		if len(project.Modules) > 0 {
+		if len(details.Modules) > 0 {
 			ui.PrintSubHeader("🧩 Modules")
-			for _, module := range project.Modules {
-				done := 0
-				for _, task := range module.Tasks {
-					if task.Status == models.StatusDone {
-						done++
-					}
-				}
-
-				completion := 0.0
-				if len(module.Tasks) > 0 {
-					completion = (float64(done) / float64(len(module.Tasks))) * 100
-				}
-
+			for _, module := range details.Modules {
 				ui.BoldCyan.Printf("\n• %s\n", module.Name)
 				if module.Description != "" {
 					ui.Blue.Printf("  %s\n", module.Description)
 				}
-				ui.Dim.Printf("  Tasks: %d\n", len(module.Tasks))
+				ui.Dim.Printf("  Tasks: %d\n", module.Tasks)
 				ui.Cyan.Printf("  Progress: ")
-				ui.PrintProgressBar(completion, 25)
-				fmt.Printf(" %.1f%%\n", completion)
+				ui.PrintProgressBar(module.Completion, 25)
+				fmt.Printf(" %.1f%%\n", module.Completion)
 			}
 			fmt.Println()
 		}
@@ -159,6 +194,7 @@ var projectShowCmd = &cobra.Command{
 			}
 			fmt.Println()
 		}
+		return nil
 	},
 }
 
@@ -166,35 +202,203 @@ var projectDeleteCmd = &cobra.Command{
 	Use:   "delete <name>",
 	Short: "Delete a project",
 	Args:  cobra.ExactArgs(1),
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		name := args[0]
-		force, _ := cmd.Flags().GetBool("force")
+		force := skipConfirm(cmd)
 
-		store := storage.Get()
-		project, err := store.LoadProject(name)
+		client := qixClient()
+		project, err := client.Project(name)
 		if err != nil {
-			ui.PrintError("Project not found: %v", err)
-			return
+			return notFound("Project not found: %v", err)
 		}
 
 		if !force {
 			fmt.Printf("⚠️  This will delete project '%s' and all its data.\n", name)
-			fmt.Print("Type the project name to confirm: ")
-
-			var confirm string
-			fmt.Scanln(&confirm)
-			if confirm != name {
+			if !ui.ConfirmTyped(name) {
 				ui.PrintInfo("Deletion cancelled")
-				return
+				return nil
 			}
 		}
 
-		if err := store.DeleteProject(name); err != nil {
-			ui.PrintError("Failed to delete project: %v", err)
-			return
+		if err := client.DeleteProject(name); err != nil {
+			return fail("Failed to delete project: %v", err)
 		}
 
 		ui.PrintSuccess("Project '%s' deleted", project.Name)
+		ui.Dim.Println("Moved to trash; use 'qix trash list' to restore it")
+		return nil
+	},
+}
+
+var projectDeadlineCmd = &cobra.Command{
+	Use:   "deadline <name> [YYYY-MM-DD|clear]",
+	Short: "Show or set the project deadline",
+	Args:  cobra.RangeArgs(1, 2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name := args[0]
+		client := qixClient()
+
+		if len(args) == 1 {
+			project, err := client.Project(name)
+			if err != nil {
+				return notFound("Project not found: %v", err)
+			}
+			if project.Deadline == "" {
+				ui.PrintInfo("Project '%s' has no deadline", project.Name)
+				return nil
+			}
+			ui.PrintInfo("Project '%s' is due %s", project.Name, ui.FormatDate(project.Deadline))
+			return nil
+		}
+
+		deadline := args[1]
+		if deadline == "clear" {
+			deadline = ""
+		} else if normalized, err := ui.NormalizeDate(deadline); err != nil {
+			return invalid("Invalid date format. Use: %s", ui.DateFormats())
+		} else {
+			deadline = normalized
+		}
+
+		err := client.UpdateProject(name, func(p *models.Project) error {
+			p.Deadline = deadline
+			return nil
+		})
+		if err != nil {
+			return fail("Failed to update project: %v", err)
+		}
+
+		if deadline == "" {
+			ui.PrintSuccess("Deadline cleared for '%s'", name)
+		} else {
+			ui.PrintSuccess("Deadline for '%s' set to %s", name, ui.FormatDate(deadline))
+		}
+		return nil
+	},
+}
+
+var projectBudgetCmd = &cobra.Command{
+	Use:   "budget <name> [amount|clear]",
+	Short: "Show or set the project budget",
+	Args:  cobra.RangeArgs(1, 2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name := args[0]
+		client := qixClient()
+
+		if len(args) == 1 {
+			project, err := client.Project(name)
+			if err != nil {
+				return notFound("Project not found: %v", err)
+			}
+			if project.Budget == 0 {
+				ui.PrintInfo("Project '%s' has no budget", project.Name)
+				return nil
+			}
+			ui.PrintInfo("Project '%s' budget: %s", project.Name, ui.FormatMoney(project.Budget))
+			return nil
+		}
+
+		budget := 0.0
+		if args[1] != "clear" {
+			var err error
+			budget, err = strconv.ParseFloat(args[1], 64)
+			if err != nil || budget < 0 {
+				return invalid("Invalid budget: %s", args[1])
+			}
+		}
+
+		err := client.UpdateProject(name, func(p *models.Project) error {
+			p.Budget = budget
+			return nil
+		})
+		if err != nil {
+			return fail("Failed to update project: %v", err)
+		}
+
+		if budget == 0 {
+			ui.PrintSuccess("Budget cleared for '%s'", name)
+		} else {
+			ui.PrintSuccess("Budget for '%s' set to %s", name, ui.FormatMoney(budget))
+		}
+		return nil
+	},
+}
+
+var projectRateCmd = &cobra.Command{
+	Use:   "rate <name> [rate|clear]",
+	Short: "Show or set hourly rates",
+	Long: `Show or set the hourly rate used by cost reports.
+
+Without --person the project's default rate is set. With --person the rate
+applies to tasks assigned to that person.`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name := args[0]
+		person, _ := cmd.Flags().GetString("person")
+		client := qixClient()
+
+		if len(args) == 1 {
+			project, err := client.Project(name)
+			if err != nil {
+				return notFound("Project not found: %v", err)
+			}
+
+			ui.PrintHeader(fmt.Sprintf("💰 Rates • %s", project.Name))
+			table := ui.NewTableBuilder("Who", "Rate").
+				Align(1, ui.AlignRight).
+				Row("(default)", fmt.Sprintf("%s/h", ui.FormatMoney(project.HourlyRate)))
+
+			people := make([]string, 0, len(project.Rates))
+			for who := range project.Rates {
+				people = append(people, who)
+			}
+			sort.Strings(people)
+			for _, who := range people {
+				table.Row(who, fmt.Sprintf("%s/h", ui.FormatMoney(project.Rates[who])))
+			}
+			table.PrintSimple()
+			return nil
+		}
+
+		clearRate := args[1] == "clear"
+		rate := 0.0
+		if !clearRate {
+			var err error
+			rate, err = strconv.ParseFloat(args[1], 64)
+			if err != nil || rate < 0 {
+				return invalid("Invalid rate: %s", args[1])
+			}
+		}
+
+		err := client.UpdateProject(name, func(p *models.Project) error {
+			if person == "" {
+				p.HourlyRate = rate
+				return nil
+			}
+			if clearRate {
+				delete(p.Rates, person)
+				return nil
+			}
+			if p.Rates == nil {
+				p.Rates = make(map[string]float64)
+			}
+			p.Rates[person] = rate
+			return nil
+		})
+		if err != nil {
+			return fail("Failed to update project: %v", err)
+		}
+
+		who := "default"
+		if person != "" {
+			who = person
+		}
+		if clearRate {
+			ui.PrintSuccess("Rate cleared for %s in '%s'", who, name)
+		} else {
+			ui.PrintSuccess("Rate for %s in '%s' set to %s/h", who, name, ui.FormatMoney(rate))
+		}
+		return nil
 	},
 }
 
@@ -202,80 +406,130 @@ var projectStatsCmd = &cobra.Command{
 	Use:   "stats <name>",
 	Short: "Show project KPIs",
 	Args:  cobra.ExactArgs(1),
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		name := args[0]
 		store := storage.Get()
 		project, err := store.LoadProject(name)
 		if err != nil {
-			ui.PrintError("Project not found: %v", err)
-			return
+			return notFound("Project not found: %v", err)
+		}
+
+		summary := newProjectSummary(project)
+		if jsonOutput {
+			printJSON(summary)
+			return nil
 		}
 
 		ui.PrintHeader(fmt.Sprintf("📊 Project KPIs • %s", project.Name))
-		printProjectStats(project)
+		printProjectStats(summary)
 		fmt.Println()
 
-		data := map[string]float64{
-			"Todo":    float64(project.CountByStatus()[models.StatusTodo]),
-			"Doing":   float64(project.CountByStatus()[models.StatusDoing]),
-			"Blocked": float64(project.CountByStatus()[models.StatusBlocked]),
-			"Done":    float64(project.CountByStatus()[models.StatusDone]),
+		data := make(map[string]float64)
+		for _, def := range models.Statuses() {
+			data[ui.StatusLabel(def.Name)] = float64(summary.StatusCounts[def.Name])
 		}
 		ui.PrintChart(data, 30, true)
+		return nil
 	},
 }
 
-func printProjectSummary(project *models.Project) {
-	counts := project.CountByStatus()
-	totalTasks := len(project.GetAllTasks())
+// printProjectSummary prints a project in the project list, with the fields
+// selected in the config or with --fields
+func printProjectSummary(summary projectSummary) {
+	if ui.CompactLists() {
+		printProjectLine(summary)
+		return
+	}
+	counts := summary.StatusCounts
+
+	ui.BoldCyan.Printf("• %s\n", summary.Name)
+	if ui.ShowProjectField("description") && summary.Description != "" {
+		ui.Blue.Printf("  %s\n", summary.Description)
+	}
+
+	if ui.ShowProjectField("counts") {
+		ui.Dim.Printf("  Modules: %d | Tasks: %d\n", summary.Modules, summary.Tasks)
+	}
+	if ui.ShowProjectField("status") {
+		parts := make([]string, 0, len(counts))
+		for _, def := range models.Statuses() {
+			parts = append(parts, fmt.Sprintf("%d %s", counts[def.Name], def.Name))
+		}
+		ui.Dim.Printf("  Status: %s\n", strings.Join(parts, " • "))
+	}
 
-	ui.BoldCyan.Printf("• %s\n", project.Name)
-	if project.Description != "" {
-		ui.Blue.Printf("  %s\n", project.Description)
+	if ui.ShowProjectField("progress") {
+		ui.Cyan.Printf("  Progress: ")
+		ui.PrintProgressBar(summary.Completion, 25)
+		fmt.Printf(" %.1f%%\n", summary.Completion)
 	}
+}
 
-	ui.Dim.Printf("  Modules: %d | Tasks: %d\n", len(project.Modules), totalTasks)
-	ui.Dim.Printf("  Status: %d todo • %d in progress • %d done • %d blocked\n",
-		counts[models.StatusTodo],
-		counts[models.StatusDoing],
-		counts[models.StatusDone],
-		counts[models.StatusBlocked],
-	)
-
-	ui.Cyan.Printf("  Progress: ")
-	ui.PrintProgressBar(project.GetCompletionPercentage(), 25)
-	fmt.Printf(" %.1f%%\n", project.GetCompletionPercentage())
+// printProjectLine prints a project on one line, for compact listings
+func printProjectLine(summary projectSummary) {
+	ui.BoldCyan.Printf("• %s", summary.Name)
+	if ui.ShowProjectField("counts") {
+		ui.Dim.Printf("  Modules: %d | Tasks: %d", summary.Modules, summary.Tasks)
+	}
+	if ui.ShowProjectField("status") {
+		for _, def := range models.Statuses() {
+			ui.GetStatusColor(def.Name).Printf("  %s %d", ui.GetStatusIcon(def.Name), summary.StatusCounts[def.Name])
+		}
+	}
+	if ui.ShowProjectField("progress") {
+		fmt.Print("  ")
+		ui.PrintProgressBar(summary.Completion, 15)
+		fmt.Printf(" %.1f%%", summary.Completion)
+	}
+	if ui.ShowProjectField("description") && summary.Description != "" {
+		ui.Dim.Printf("  %s", summary.Description)
+	}
+	fmt.Println()
 }
 
-func printProjectStats(project *models.Project) {
-	counts := project.CountByStatus()
+func printProjectStats(summary projectSummary) {
+	counts := summary.StatusCounts
 
 	table := ui.NewTableBuilder("Metric", "Value").
-		Row("Total Tasks", fmt.Sprintf("%d", len(project.GetAllTasks()))).
-		Row("Todo", fmt.Sprintf("%d", counts[models.StatusTodo])).
-		Row("Doing", fmt.Sprintf("%d", counts[models.StatusDoing])).
-		Row("Done", fmt.Sprintf("%d", counts[models.StatusDone])).
-		Row("Blocked", fmt.Sprintf("%d", counts[models.StatusBlocked])).
-		Row("Modules", fmt.Sprintf("%d", len(project.Modules))).
-		Row("Sprints", fmt.Sprintf("%d", len(project.Sprints))).
-		Row("Estimated", ui.FormatHours(project.CalculateTotalEstimated())).
-		Row("Actual", ui.FormatHours(project.CalculateTotalActual())).
-		Row("Completion", fmt.Sprintf("%.1f%%", project.GetCompletionPercentage()))
+		Row("Total Tasks", fmt.Sprintf("%d", summary.Tasks))
+	for _, def := range models.Statuses() {
+		table.Row(ui.StatusLabel(def.Name), fmt.Sprintf("%d", counts[def.Name]))
+	}
+	table.Row("Modules", fmt.Sprintf("%d", summary.Modules)).
+		Row("Sprints", fmt.Sprintf("%d", summary.Sprints)).
+		Row("Estimated", ui.FormatHours(summary.EstimatedHours)).
+		Row("Actual", ui.FormatHours(summary.ActualHours)).
+		Row("Completion", fmt.Sprintf("%.1f%%", summary.Completion))
 
 	table.Align(1, ui.AlignRight).PrintSimple()
 }
 
 func init() {
 	projectCreateCmd.Flags().StringSliceP("tags", "t", []string{}, "Tags for the project")
+	projectCreateCmd.RegisterFlagCompletionFunc("tags", completeTags)
 	projectDeleteCmd.Flags().BoolP("force", "f", false, "Skip confirmation prompt")
 
 	projectShowCmd.ValidArgsFunction = projectArgCompletion
+	listingFlags("project", projectListCmd)
+	listingFlags("task", projectShowCmd)
 	projectDeleteCmd.ValidArgsFunction = projectArgCompletion
 	projectStatsCmd.ValidArgsFunction = projectArgCompletion
+	projectDeadlineCmd.ValidArgsFunction = dateArgCompletion(1, projectArgCompletion)
+	projectBudgetCmd.ValidArgsFunction = projectArgCompletion
+	projectRateCmd.ValidArgsFunction = projectArgCompletion
+
+	projectRateCmd.Flags().String("person", "", "Set the rate for a specific assignee")
 
+	noJSON(projectDeleteCmd, projectDeadlineCmd, projectBudgetCmd, projectRateCmd)
 	projectCmd.AddCommand(projectCreateCmd)
 	projectCmd.AddCommand(projectListCmd)
 	projectCmd.AddCommand(projectShowCmd)
 	projectCmd.AddCommand(projectDeleteCmd)
 	projectCmd.AddCommand(projectStatsCmd)
+	projectCmd.AddCommand(projectDeadlineCmd)
+	projectCmd.AddCommand(projectBudgetCmd)
+	projectCmd.AddCommand(projectRateCmd)
+
+	usePager(true, projectListCmd, projectShowCmd, projectStatsCmd)
+	supportDryRun(projectCreateCmd, projectDeleteCmd, projectDeadlineCmd, projectBudgetCmd, projectRateCmd)
 }
@@ -0,0 +1,222 @@
+package cmd
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mrbooshehri/qix-go/internal/storage"
+	"github.com/mrbooshehri/qix-go/internal/ui"
+	"github.com/mrbooshehri/qix-go/pkg/qix/models"
+)
+
+// scriptedPrompter answers prompts in order from a script, an empty answer
+// keeping the current value. Once the script runs out, the user backs out.
+type scriptedPrompter struct {
+	answers []string
+}
+
+func (p *scriptedPrompter) next() (string, error) {
+	if len(p.answers) == 0 {
+		return "", ui.ErrCancelled
+	}
+	answer := p.answers[0]
+	p.answers = p.answers[1:]
+	return answer, nil
+}
+
+func (p *scriptedPrompter) Input(label, current string, validate func(string) error) (string, error) {
+	answer, err := p.next()
+	if err != nil || answer == "" {
+		return current, err
+	}
+	if validate != nil {
+		if err := validate(answer); err != nil {
+			return current, err
+		}
+	}
+	return answer, nil
+}
+
+func (p *scriptedPrompter) Select(label string, options []string, current string) (string, error) {
+	answer, err := p.next()
+	if err != nil || answer == "" {
+		return current, err
+	}
+	return answer, nil
+}
+
+func (p *scriptedPrompter) MultiSelect(label string, options, selected []string) ([]string, error) {
+	answer, err := p.next()
+	if err != nil || answer == "" {
+		return selected, err
+	}
+	return strings.Split(answer, ","), nil
+}
+
+func (p *scriptedPrompter) Confirm(question, expected string) (bool, error) {
+	answer, err := p.next()
+	if expected == "" {
+		expected = "y"
+	}
+	return answer == expected, err
+}
+
+// answering makes prompts answer from a script for the rest of the test
+func answering(t *testing.T, answers ...string) {
+	t.Helper()
+	previous := ui.SetPrompter(&scriptedPrompter{answers: answers})
+	t.Cleanup(func() { ui.SetPrompter(previous) })
+}
+
+// runCommand runs a command with flags, resetting them afterwards
+func runCommand(t *testing.T, cmd *cobra.Command, flags map[string]string, args ...string) error {
+	t.Helper()
+	for name, value := range flags {
+		if err := cmd.Flags().Set(name, value); err != nil {
+			t.Fatalf("--%s: %v", name, err)
+		}
+	}
+	t.Cleanup(func() {
+		for name := range flags {
+			flag := cmd.Flags().Lookup(name)
+			flag.Value.Set(flag.DefValue)
+			flag.Changed = false
+		}
+	})
+	return cmd.RunE(cmd, args)
+}
+
+// testProject creates an empty project for a test
+func testProject(t *testing.T) string {
+	t.Helper()
+	name := strings.NewReplacer("/", "-", " ", "-").Replace(t.Name())
+	if _, err := storage.Get().CreateProject(name, "", nil); err != nil {
+		t.Fatalf("creating project: %v", err)
+	}
+	return name
+}
+
+func TestTaskCreateInteractive(t *testing.T) {
+	t.Run("accepted", func(t *testing.T) {
+		project := testProject(t)
+		// Description, estimate, status, priority, tags and Jira issue
+		answering(t, "Behind a feature flag", "2.5", "doing", "high", "api, auth", "")
+
+		if err := runCommand(t, taskCreateCmd, map[string]string{"interactive": "true"}, project, "Rate", "limit"); err != nil {
+			t.Fatalf("task create: %v", err)
+		}
+
+		p, err := storage.Get().LoadProject(project)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(p.Tasks) != 1 {
+			t.Fatalf("project has %d tasks, want 1", len(p.Tasks))
+		}
+		task := p.Tasks[0]
+		if task.Title != "Rate limit" || task.Description != "Behind a feature flag" ||
+			task.EstimatedHours != 2.5 || task.Status != models.StatusDoing ||
+			task.Priority != models.PriorityHigh || strings.Join(task.Tags, ",") != "api,auth" {
+			t.Errorf("created task = %+v", task)
+		}
+	})
+
+	t.Run("cancelled", func(t *testing.T) {
+		project := testProject(t)
+		answering(t, "Behind a feature flag")
+
+		err := runCommand(t, taskCreateCmd, map[string]string{"interactive": "true"}, project, "Rate limit")
+		if !errors.Is(err, ui.ErrCancelled) {
+			t.Fatalf("task create = %v, want cancelled", err)
+		}
+
+		p, err := storage.Get().LoadProject(project)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(p.Tasks) != 0 {
+			t.Errorf("cancelled create saved %d tasks", len(p.Tasks))
+		}
+	})
+}
+
+func TestTaskEditInteractive(t *testing.T) {
+	original := models.Task{
+		Title:    "Write docs",
+		Status:   models.StatusTodo,
+		Priority: models.PriorityMedium,
+	}
+
+	t.Run("accepted", func(t *testing.T) {
+		project := testProject(t)
+		id, err := qixClient().AddTask(project, "", original)
+		if err != nil {
+			t.Fatal(err)
+		}
+		// Title, description, status, priority, estimate and Jira issue
+		answering(t, "Write the API docs", "", "done", "", "3", "QIX-7")
+
+		if err := runCommand(t, taskEditCmd, nil, project, id); err != nil {
+			t.Fatalf("task edit: %v", err)
+		}
+
+		task, _, err := qixClient().Task(project, id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if task.Title != "Write the API docs" || task.Status != models.StatusDone ||
+			task.Priority != models.PriorityMedium || task.EstimatedHours != 3 || task.JiraIssue != "QIX-7" {
+			t.Errorf("edited task = %+v", task)
+		}
+	})
+
+	t.Run("cancelled", func(t *testing.T) {
+		project := testProject(t)
+		id, err := qixClient().AddTask(project, "", original)
+		if err != nil {
+			t.Fatal(err)
+		}
+		answering(t, "Write the API docs", "", "done")
+
+		err = runCommand(t, taskEditCmd, nil, project, id)
+		if !errors.Is(err, ui.ErrCancelled) {
+			t.Fatalf("task edit = %v, want cancelled", err)
+		}
+
+		task, _, err := qixClient().Task(project, id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if task.Title != original.Title || task.Status != original.Status {
+			t.Errorf("cancelled edit changed the task to %+v", task)
+		}
+	})
+}
+
+func TestProjectDeleteConfirm(t *testing.T) {
+	tests := []struct {
+		name    string
+		answer  func(project string) string
+		deleted bool
+	}{
+		{"accepted", func(project string) string { return project }, true},
+		{"cancelled", func(string) string { return "no" }, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			project := testProject(t)
+			answering(t, tt.answer(project))
+
+			if err := runCommand(t, projectDeleteCmd, nil, project); err != nil {
+				t.Fatalf("project delete: %v", err)
+			}
+			if exists := storage.Get().ProjectExists(project); exists == tt.deleted {
+				t.Errorf("project exists = %v after delete was %s", exists, tt.name)
+			}
+		})
+	}
+}
@@ -0,0 +1,180 @@
+package cmd
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/mrbooshehri/qix-go/internal/ui"
+	"github.com/mrbooshehri/qix-go/pkg/qix/models"
+)
+
+// accuracySegment accumulates estimated and actual hours for a group of completed tasks
+type accuracySegment struct {
+	Name      string
+	Tasks     int
+	Estimated float64
+	Actual    float64
+}
+
+// Variance returns how far actual hours landed from the estimate, in percent
+func (s accuracySegment) Variance() float64 {
+	return (s.Actual - s.Estimated) / s.Estimated * 100
+}
+
+// estimateBuckets are the upper bounds (inclusive) of the estimate size buckets
+var estimateBuckets = []struct {
+	Label string
+	Max   float64
+}{
+	{"0-2h", 2},
+	{"2-4h", 4},
+	{"4-8h", 8},
+	{"8-16h", 16},
+	{"16h+", math.Inf(1)},
+}
+
+// printAccuracyBreakdown segments estimation accuracy of completed tasks by tag, module and size
+func printAccuracyBreakdown(project *models.Project) {
+	ui.PrintSubHeader("🎯 Estimation Accuracy Breakdown")
+
+	byTag := make(map[string]*accuracySegment)
+	byModule := make(map[string]*accuracySegment)
+	bySize := make(map[string]*accuracySegment)
+
+	add := func(segments map[string]*accuracySegment, name string, task models.Task) {
+		segment, ok := segments[name]
+		if !ok {
+			segment = &accuracySegment{Name: name}
+			segments[name] = segment
+		}
+		segment.Tasks++
+		segment.Estimated += task.EstimatedHours
+		segment.Actual += task.CalculateActualHours()
+	}
+
+	collect := func(module string, tasks []models.Task) {
+		for _, task := range tasks {
+			if !task.Status.IsDone() || task.EstimatedHours <= 0 {
+				continue
+			}
+
+			for _, tag := range task.Tags {
+				add(byTag, tag, task)
+			}
+			add(byModule, module, task)
+
+			for _, bucket := range estimateBuckets {
+				if task.EstimatedHours <= bucket.Max {
+					add(bySize, bucket.Label, task)
+					break
+				}
+			}
+		}
+	}
+
+	collect("(project)", project.Tasks)
+	for _, module := range project.Modules {
+		collect(module.Name, module.Tasks)
+	}
+
+	if len(byModule) == 0 {
+		ui.Dim.Println("No completed tasks with estimates yet")
+		fmt.Println()
+		return
+	}
+
+	printAccuracySegments("By tag", byTag, nil)
+	printAccuracySegments("By module", byModule, nil)
+
+	sizeOrder := make([]string, len(estimateBuckets))
+	for i, bucket := range estimateBuckets {
+		sizeOrder[i] = bucket.Label
+	}
+	printAccuracySegments("By estimate size", bySize, sizeOrder)
+
+	// Call out segments that are systematically off
+	insights := make([]string, 0)
+	for _, group := range []struct {
+		suffix   string
+		segments map[string]*accuracySegment
+	}{
+		{"tasks", byTag},
+		{"module tasks", byModule},
+		{"estimates", bySize},
+	} {
+		for _, segment := range sortedAccuracySegments(group.segments, nil) {
+			variance := segment.Variance()
+			if segment.Tasks < 2 || math.Abs(variance) < 25 {
+				continue
+			}
+			direction := "over"
+			if variance < 0 {
+				direction = "under"
+			}
+			insights = append(insights, fmt.Sprintf("%s %s run %.0f%% %s", segment.Name, group.suffix, math.Abs(variance), direction))
+		}
+	}
+
+	if len(insights) > 0 {
+		ui.Yellow.Println("Systematic misses:")
+		for _, insight := range insights {
+			ui.Yellow.Printf("  • %s\n", insight)
+		}
+		fmt.Println()
+	}
+}
+
+// sortedAccuracySegments orders segments by the given names, or by largest miss first
+func sortedAccuracySegments(segments map[string]*accuracySegment, order []string) []accuracySegment {
+	sorted := make([]accuracySegment, 0, len(segments))
+	if order != nil {
+		for _, name := range order {
+			if segment, ok := segments[name]; ok {
+				sorted = append(sorted, *segment)
+			}
+		}
+		return sorted
+	}
+
+	for _, segment := range segments {
+		sorted = append(sorted, *segment)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		vi, vj := math.Abs(sorted[i].Variance()), math.Abs(sorted[j].Variance())
+		if vi != vj {
+			return vi > vj
+		}
+		return sorted[i].Name < sorted[j].Name
+	})
+	return sorted
+}
+
+func printAccuracySegments(title string, segments map[string]*accuracySegment, order []string) {
+	if len(segments) == 0 {
+		return
+	}
+
+	ui.Cyan.Println(title)
+
+	table := ui.NewTableBuilder("Segment", "Tasks", "Estimated", "Actual", "Variance", "Accuracy").
+		Align(1, ui.AlignRight).
+		Align(2, ui.AlignRight).
+		Align(3, ui.AlignRight).
+		Align(4, ui.AlignRight).
+		Align(5, ui.AlignRight)
+
+	for _, segment := range sortedAccuracySegments(segments, order) {
+		table.Row(
+			segment.Name,
+			fmt.Sprintf("%d", segment.Tasks),
+			ui.FormatHours(segment.Estimated),
+			ui.FormatHours(segment.Actual),
+			fmt.Sprintf("%+.1f%%", segment.Variance()),
+			ui.FormatPercentage(estimationAccuracy(segment.Estimated, segment.Actual)),
+		)
+	}
+
+	table.PrintSimple()
+	fmt.Println()
+}
@@ -0,0 +1,264 @@
+package cmd
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/mrbooshehri/qix-go/internal/storage"
+	"github.com/mrbooshehri/qix-go/internal/ui"
+	"github.com/mrbooshehri/qix-go/pkg/qix/models"
+	"github.com/spf13/cobra"
+)
+
+var reportBlockersCmd = &cobra.Command{
+	Use:   "blockers <project>",
+	Short: "Root-cause analysis of blocked tasks",
+	Long: `Walk dependency chains from blocked tasks to find the root-cause blockers:
+unfinished tasks that hold others up without waiting on anything themselves.
+A blocked task with no unfinished dependencies is its own root cause.
+
+Root causes are ranked by how many open tasks depend on them, directly or
+transitively, and by the remaining estimated hours of that work.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		projectName := args[0]
+
+		store := storage.Get()
+
+		project, err := store.LoadProject(projectName)
+		if err != nil {
+			return notFound("Project not found: %s", projectName)
+		}
+
+		graph := buildTaskGraph(project)
+		analysis := analyzeBlockers(graph)
+
+		if jsonOutput {
+			printJSON(analysis.view(projectName, graph))
+			return nil
+		}
+
+		ui.PrintHeader(fmt.Sprintf("🧱 Blockers: %s", projectName))
+
+		if len(analysis.Blocked) == 0 {
+			ui.PrintEmptyState("No blocked tasks", "")
+			return nil
+		}
+
+		ui.PrintSubHeader("🎯 Root Causes")
+		table := ui.NewTableBuilder("Rank", "Task", "Status", "Holds Up", "Hours Held", "Blocked Tasks").
+			Align(0, ui.AlignRight).
+			Align(3, ui.AlignRight).
+			Align(4, ui.AlignRight).
+			Align(5, ui.AlignRight)
+		for i, root := range analysis.Roots {
+			table.Row(
+				fmt.Sprintf("%d", i+1),
+				ganttLabel(graph.Tasks[root.ID]),
+				string(graph.Tasks[root.ID].Status),
+				fmt.Sprintf("%d", len(root.Downstream)),
+				ui.FormatHours(root.HoursHeld),
+				fmt.Sprintf("%d", len(root.Blocked)),
+			)
+		}
+		table.PrintSimple()
+		fmt.Println()
+
+		ui.PrintSubHeader("🔗 Chains")
+		for _, id := range analysis.Blocked {
+			task := graph.Tasks[id]
+			fmt.Printf("%s [%s] %s\n", ui.GetStatusIcon(task.Status), id, task.Title)
+			for _, chain := range analysis.Chains[id] {
+				if len(chain) == 1 {
+					ui.Dim.Println("  └─ blocked externally (no open dependencies)")
+					continue
+				}
+				ui.Dim.Printf("  └─ waits on %s\n", strings.Join(chain[1:], " → "))
+			}
+		}
+		fmt.Println()
+
+		top := analysis.Roots[0]
+		topTask := graph.Tasks[top.ID]
+		action := "Start"
+		switch topTask.Status {
+		case models.StatusDoing:
+			action = "Finish"
+		case models.StatusBlocked:
+			action = "Unblock"
+		}
+		ui.BoldGreen.Printf("💡 %s [%s] %s first: it holds up %d task(s) and %s of work\n",
+			action, top.ID, topTask.Title, len(top.Downstream), ui.FormatHours(top.HoursHeld))
+		return nil
+	},
+}
+
+// rootBlocker is an unfinished task at the end of one or more blocked chains
+type rootBlocker struct {
+	ID         string
+	Downstream map[string]bool // Open tasks that depend on it, directly or transitively
+	Blocked    map[string]bool // Blocked tasks whose chains end here
+	HoursHeld  float64
+}
+
+// blockerAnalysis is the result of walking dependency chains from blocked tasks
+type blockerAnalysis struct {
+	Blocked []string
+	Chains  map[string][][]string // Paths from each blocked task to its root causes
+	Roots   []rootBlocker
+}
+
+// blockersReport is a project's blocked tasks and their ranked root causes
+type blockersReport struct {
+	Project string        `json:"project"`
+	Roots   []blockerRoot `json:"roots"` // Most impact first
+	Blocked []blockedTask `json:"blocked"`
+}
+
+// blockerRoot is a rootBlocker as shown by --json
+type blockerRoot struct {
+	taskRef
+	HoldsUp   []string `json:"holds_up"`
+	HoursHeld float64  `json:"hours_held"`
+	Blocks    []string `json:"blocks"` // Blocked tasks whose chains end here
+}
+
+// blockedTask is a blocked task with the dependency chains to its root causes
+type blockedTask struct {
+	taskRef
+	Chains [][]string `json:"chains"` // Each starts with the task itself
+}
+
+// view assembles the JSON view of the analysis
+func (a blockerAnalysis) view(projectName string, graph taskGraph) blockersReport {
+	report := blockersReport{
+		Project: projectName,
+		Roots:   make([]blockerRoot, 0, len(a.Roots)),
+		Blocked: make([]blockedTask, 0, len(a.Blocked)),
+	}
+	for _, root := range a.Roots {
+		report.Roots = append(report.Roots, blockerRoot{
+			taskRef:   newTaskRef(graph.Tasks[root.ID]),
+			HoldsUp:   sortedIDs(root.Downstream),
+			HoursHeld: root.HoursHeld,
+			Blocks:    sortedIDs(root.Blocked),
+		})
+	}
+	for _, id := range a.Blocked {
+		report.Blocked = append(report.Blocked, blockedTask{taskRef: newTaskRef(graph.Tasks[id]), Chains: a.Chains[id]})
+	}
+	return report
+}
+
+// sortedIDs returns the IDs in a set, sorted
+func sortedIDs(set map[string]bool) []string {
+	ids := make([]string, 0, len(set))
+	for id := range set {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// analyzeBlockers finds the root causes of every blocked task and ranks them by impact
+func analyzeBlockers(graph taskGraph) blockerAnalysis {
+	analysis := blockerAnalysis{Chains: make(map[string][][]string)}
+
+	isOpen := func(id string) bool {
+		return !graph.Tasks[id].Status.IsDone()
+	}
+
+	openDeps := func(id string) []string {
+		deps := make([]string, 0)
+		for _, depID := range graph.Depends[id] {
+			if isOpen(depID) {
+				deps = append(deps, depID)
+			}
+		}
+		return deps
+	}
+
+	dependents := make(map[string][]string)
+	for id, deps := range graph.Depends {
+		for _, depID := range deps {
+			dependents[depID] = append(dependents[depID], id)
+		}
+	}
+
+	roots := make(map[string]*rootBlocker)
+
+	for _, id := range graph.Order {
+		if graph.Tasks[id].Status != models.StatusBlocked {
+			continue
+		}
+		analysis.Blocked = append(analysis.Blocked, id)
+
+		// Depth-first walk to every open task with no open dependencies
+		var walk func(path []string, visited map[string]bool)
+		walk = func(path []string, visited map[string]bool) {
+			current := path[len(path)-1]
+			deps := openDeps(current)
+			if len(deps) == 0 {
+				chain := append([]string(nil), path...)
+				analysis.Chains[id] = append(analysis.Chains[id], chain)
+
+				root, ok := roots[current]
+				if !ok {
+					root = &rootBlocker{ID: current, Downstream: make(map[string]bool), Blocked: make(map[string]bool)}
+					roots[current] = root
+				}
+				root.Blocked[id] = true
+				return
+			}
+			for _, depID := range deps {
+				if visited[depID] {
+					continue // Dependency cycle
+				}
+				visited[depID] = true
+				walk(append(path, depID), visited)
+				delete(visited, depID)
+			}
+		}
+		walk([]string{id}, map[string]bool{id: true})
+	}
+
+	// Everything open that transitively depends on a root is held up by it
+	for _, root := range roots {
+		queue := []string{root.ID}
+		for len(queue) > 0 {
+			current := queue[0]
+			queue = queue[1:]
+			for _, dependent := range dependents[current] {
+				if root.Downstream[dependent] || dependent == root.ID || !isOpen(dependent) {
+					continue
+				}
+				root.Downstream[dependent] = true
+				task := graph.Tasks[dependent]
+				root.HoursHeld += math.Max(task.EstimatedHours-task.CalculateActualHours(), 0)
+				queue = append(queue, dependent)
+			}
+		}
+		analysis.Roots = append(analysis.Roots, *root)
+	}
+
+	sort.Slice(analysis.Roots, func(i, j int) bool {
+		a, b := analysis.Roots[i], analysis.Roots[j]
+		if len(a.Downstream) != len(b.Downstream) {
+			return len(a.Downstream) > len(b.Downstream)
+		}
+		if a.HoursHeld != b.HoursHeld {
+			return a.HoursHeld > b.HoursHeld
+		}
+		return a.ID < b.ID
+	})
+
+	return analysis
+}
+
+func init() {
+	reportBlockersCmd.ValidArgsFunction = projectArgCompletion
+
+	reportCmd.AddCommand(reportBlockersCmd)
+}
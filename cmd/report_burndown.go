@@ -0,0 +1,307 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mrbooshehri/qix-go/internal/storage"
+	"github.com/mrbooshehri/qix-go/internal/ui"
+	"github.com/mrbooshehri/qix-go/pkg/qix/models"
+	"github.com/spf13/cobra"
+)
+
+var reportBurndownCmd = &cobra.Command{
+	Use:   "burndown <project>",
+	Short: "Project burndown/burnup chart",
+	Long: `Chart remaining work over time for a whole project, independent of sprints.
+
+Scope grows as tasks are created and shrinks as they are completed. By default
+remaining estimated hours are charted; use --tasks to chart task counts instead.
+Use --burnup to chart completed work against total scope.
+
+The ideal line runs from the remaining work on --from down to zero on --to.
+Defaults to the project's creation date through today.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		projectName := args[0]
+		fromStr, _ := cmd.Flags().GetString("from")
+		toStr, _ := cmd.Flags().GetString("to")
+		byTasks, _ := cmd.Flags().GetBool("tasks")
+		burnup, _ := cmd.Flags().GetBool("burnup")
+		width, _ := cmd.Flags().GetInt("width")
+
+		store := storage.Get()
+
+		project, err := store.LoadProject(projectName)
+		if err != nil {
+			return notFound("Project not found: %s", projectName)
+		}
+
+		from := truncateDay(project.CreatedAt)
+		to := truncateDay(time.Now())
+
+		if fromStr != "" {
+			from, err = ui.ParseDate(fromStr)
+			if err != nil {
+				return invalid("Invalid start date format. Use: %s", ui.DateFormats())
+			}
+		}
+		if toStr != "" {
+			to, err = ui.ParseDate(toStr)
+			if err != nil {
+				return invalid("Invalid end date format. Use: %s", ui.DateFormats())
+			}
+		}
+		if to.Before(from) {
+			return invalid("End date must be after start date")
+		}
+
+		points := buildBurnSeries(project.GetAllTasks(), from, to, byTasks)
+
+		unit := "hours"
+		format := func(v float64) string { return ui.FormatHours(v) }
+		if byTasks {
+			unit = "tasks"
+			format = func(v float64) string { return fmt.Sprintf("%.0f", v) }
+		}
+
+		if jsonOutput {
+			report := burnReport{
+				Project: projectName,
+				Unit:    unit,
+				From:    from.Format("2006-01-02"),
+				To:      to.Format("2006-01-02"),
+				Days:    make([]burnDay, 0, len(points)),
+			}
+			for _, point := range points {
+				report.Days = append(report.Days, burnDay{
+					Date:      point.Date.Format("2006-01-02"),
+					Scope:     point.Scope,
+					Completed: point.Completed,
+					Remaining: point.Remaining(),
+					Ideal:     point.Ideal,
+				})
+			}
+			printJSON(report)
+			return nil
+		}
+
+		title := "📉 Burndown"
+		if burnup {
+			title = "📈 Burnup"
+		}
+		ui.PrintHeader(fmt.Sprintf("%s: %s (%s)", title, projectName, unit))
+		fmt.Printf("Period: %s to %s\n\n", ui.FormatDate(from.Format("2006-01-02")), ui.FormatDate(to.Format("2006-01-02")))
+
+		if burnup {
+			printBurnupChart(points, width, format)
+		} else {
+			printBurndownChart(points, width, format)
+		}
+
+		last := points[len(points)-1]
+		fmt.Println()
+		ui.Cyan.Printf("Scope:     %s\n", format(last.Scope))
+		ui.Green.Printf("Completed: %s\n", format(last.Completed))
+		ui.Yellow.Printf("Remaining: %s\n", format(last.Remaining()))
+
+		ideal := last.Ideal
+		if last.Remaining() > ideal {
+			ui.Red.Printf("⚠️  Behind the ideal line by %s\n", format(last.Remaining()-ideal))
+		} else {
+			ui.Green.Printf("✨ On or ahead of the ideal line by %s\n", format(ideal-last.Remaining()))
+		}
+		return nil
+	},
+}
+
+// burnPoint is the state of the project at the end of a single day
+type burnPoint struct {
+	Date      time.Time
+	Scope     float64
+	Completed float64
+	Ideal     float64
+}
+
+// burnReport is the burndown or burnup series of a project, one point per day
+type burnReport struct {
+	Project string    `json:"project"`
+	Unit    string    `json:"unit"` // hours or tasks
+	From    string    `json:"from"`
+	To      string    `json:"to"`
+	Days    []burnDay `json:"days"`
+}
+
+// burnDay is a burnPoint as shown by --json
+type burnDay struct {
+	Date      string  `json:"date"`
+	Scope     float64 `json:"scope"`
+	Completed float64 `json:"completed"`
+	Remaining float64 `json:"remaining"`
+	Ideal     float64 `json:"ideal"`
+}
+
+// Remaining returns the outstanding work at this point
+func (p burnPoint) Remaining() float64 {
+	return p.Scope - p.Completed
+}
+
+// buildBurnSeries computes daily scope and completion between from and to (inclusive)
+func buildBurnSeries(tasks []models.Task, from, to time.Time, byTasks bool) []burnPoint {
+	days := daysBetween(from, to) + 1
+	points := make([]burnPoint, days)
+
+	for i := 0; i < days; i++ {
+		day := from.AddDate(0, 0, i)
+		dayEnd := day.AddDate(0, 0, 1)
+		point := burnPoint{Date: day}
+
+		for _, task := range tasks {
+			weight := task.EstimatedHours
+			if byTasks {
+				weight = 1
+			}
+
+			if task.CreatedAt.Before(dayEnd) {
+				point.Scope += weight
+			}
+			if completedAt, ok := taskCompletedAt(task); ok && completedAt.Before(dayEnd) {
+				point.Completed += weight
+			}
+		}
+
+		points[i] = point
+	}
+
+	// Ideal line burns the starting remaining work down to zero on the last day
+	startRemaining := points[0].Remaining()
+	for i := range points {
+		if days == 1 {
+			points[i].Ideal = 0
+			continue
+		}
+		points[i].Ideal = startRemaining * (1 - float64(i)/float64(days-1))
+	}
+
+	return points
+}
+
+// taskCompletedAt returns when a done task was completed, from its status history
+func taskCompletedAt(task models.Task) (time.Time, bool) {
+	return task.CompletedAt()
+}
+
+// sampleBurnPoints keeps at most limit points, always including the last one
+func sampleBurnPoints(points []burnPoint, limit int) []burnPoint {
+	if len(points) <= limit {
+		return points
+	}
+
+	step := (len(points) + limit - 1) / limit
+	sampled := make([]burnPoint, 0, limit+1)
+	for i := 0; i < len(points); i += step {
+		sampled = append(sampled, points[i])
+	}
+	if sampled[len(sampled)-1].Date != points[len(points)-1].Date {
+		sampled = append(sampled, points[len(points)-1])
+	}
+	return sampled
+}
+
+func printBurndownChart(points []burnPoint, width int, format func(float64) string) {
+	maxValue := 0.0
+	for _, p := range points {
+		if p.Remaining() > maxValue {
+			maxValue = p.Remaining()
+		}
+		if p.Ideal > maxValue {
+			maxValue = p.Ideal
+		}
+	}
+
+	for _, p := range sampleBurnPoints(points, 30) {
+		remaining := p.Remaining()
+		fmt.Printf("%s  ", p.Date.Format("Jan 02"))
+		printBurnBar(remaining, p.Ideal, maxValue, width, remaining > p.Ideal)
+		fmt.Printf(" %s", format(remaining))
+		ui.Dim.Printf(" (ideal %s)\n", format(p.Ideal))
+	}
+
+	fmt.Println()
+	ui.Cyan.Print("█ Remaining  ")
+	ui.Dim.Println("┃ Ideal")
+}
+
+func printBurnupChart(points []burnPoint, width int, format func(float64) string) {
+	maxValue := 0.0
+	for _, p := range points {
+		if p.Scope > maxValue {
+			maxValue = p.Scope
+		}
+	}
+
+	for _, p := range sampleBurnPoints(points, 30) {
+		fmt.Printf("%s  ", p.Date.Format("Jan 02"))
+		printBurnBar(p.Completed, p.Scope, maxValue, width, false)
+		fmt.Printf(" %s", format(p.Completed))
+		ui.Dim.Printf(" / %s\n", format(p.Scope))
+	}
+
+	fmt.Println()
+	ui.Green.Print("█ Completed  ")
+	ui.Dim.Println("┃ Scope")
+}
+
+// printBurnBar draws a bar of value with a marker at the reference position
+func printBurnBar(value, marker, maxValue float64, width int, behind bool) {
+	scale := func(v float64) int {
+		if maxValue <= 0 {
+			return 0
+		}
+		n := int(v / maxValue * float64(width))
+		if n > width {
+			n = width
+		}
+		return n
+	}
+
+	filled := scale(value)
+	markerAt := scale(marker)
+	if markerAt >= width {
+		markerAt = width - 1
+	}
+
+	barColor := ui.Green
+	if behind {
+		barColor = ui.Red
+	}
+
+	var bar strings.Builder
+	for i := 0; i < width; i++ {
+		switch {
+		case i == markerAt:
+			barColor.Print(bar.String())
+			bar.Reset()
+			ui.Dim.Print("┃")
+		case i < filled:
+			bar.WriteString("█")
+		default:
+			bar.WriteString(" ")
+		}
+	}
+	barColor.Print(bar.String())
+}
+
+func init() {
+	reportBurndownCmd.Flags().String("from", "", "Start date (YYYY-MM-DD, defaults to project creation)")
+	reportBurndownCmd.Flags().String("to", "", "End date (YYYY-MM-DD, defaults to today)")
+	reportBurndownCmd.RegisterFlagCompletionFunc("from", completeDates)
+	reportBurndownCmd.RegisterFlagCompletionFunc("to", completeDates)
+	reportBurndownCmd.Flags().Bool("tasks", false, "Chart task counts instead of estimated hours")
+	reportBurndownCmd.Flags().Bool("burnup", false, "Chart completed work against total scope")
+	reportBurndownCmd.Flags().IntP("width", "w", 40, "Chart width in columns")
+	reportBurndownCmd.ValidArgsFunction = projectArgCompletion
+
+	reportCmd.AddCommand(reportBurndownCmd)
+}
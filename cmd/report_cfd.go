@@ -0,0 +1,265 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/mrbooshehri/qix-go/internal/storage"
+	"github.com/mrbooshehri/qix-go/internal/ui"
+	"github.com/mrbooshehri/qix-go/pkg/qix/models"
+	"github.com/spf13/cobra"
+)
+
+var reportCFDCmd = &cobra.Command{
+	Use:   "cfd <project>",
+	Short: "Cumulative flow diagram",
+	Long: `Chart the number of tasks in each status at the end of every day.
+
+Bands are stacked done, doing, blocked, todo from left to right. A widening
+doing or blocked band shows work-in-progress building up; a flat done band
+shows a stall. Status is taken from each task's status history; tasks created
+before history was recorded are assumed to be todo until their last update.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		projectName := args[0]
+		days, _ := cmd.Flags().GetInt("days")
+		width, _ := cmd.Flags().GetInt("width")
+
+		if days < 1 {
+			return invalid("--days must be at least 1")
+		}
+
+		store := storage.Get()
+
+		project, err := store.LoadProject(projectName)
+		if err != nil {
+			return notFound("Project not found: %s", projectName)
+		}
+
+		to := truncateDay(time.Now())
+		from := to.AddDate(0, 0, -(days - 1))
+
+		points := buildCFDSeries(project.GetAllTasks(), from, to)
+		first, last := points[0], points[len(points)-1]
+
+		// Throughput and WIP over the period
+		throughput := float64(last.Counts[models.StatusDone]-first.Counts[models.StatusDone]) / float64(len(points))
+		totalWIP := 0
+		for _, p := range points {
+			totalWIP += p.WIP()
+		}
+		avgWIP := float64(totalWIP) / float64(len(points))
+
+		if jsonOutput {
+			report := cfdReport{
+				Project:    projectName,
+				From:       from.Format("2006-01-02"),
+				To:         to.Format("2006-01-02"),
+				Days:       make([]cfdDay, 0, len(points)),
+				AverageWIP: avgWIP,
+				Throughput: throughput,
+			}
+			if throughput > 0 {
+				cycleTime := avgWIP / throughput
+				report.CycleTimeDays = &cycleTime
+			}
+			for _, point := range points {
+				report.Days = append(report.Days, cfdDay{
+					Date:    point.Date.Format("2006-01-02"),
+					Todo:    point.Counts[models.StatusTodo],
+					Doing:   point.Counts[models.StatusDoing],
+					Blocked: point.Counts[models.StatusBlocked],
+					Done:    point.Counts[models.StatusDone],
+				})
+			}
+			printJSON(report)
+			return nil
+		}
+
+		ui.PrintHeader(fmt.Sprintf("🌊 Cumulative Flow: %s", projectName))
+		fmt.Printf("Period: %s to %s\n\n", ui.FormatDate(from.Format("2006-01-02")), ui.FormatDate(to.Format("2006-01-02")))
+
+		printCFDChart(points, width)
+
+		fmt.Println()
+		table := ui.NewTableBuilder("Status", "Start", "End", "Change").
+			Align(1, ui.AlignRight).
+			Align(2, ui.AlignRight).
+			Align(3, ui.AlignRight)
+		for _, band := range cfdBands {
+			start, end := first.Counts[band.Status], last.Counts[band.Status]
+			table.Row(band.Label, fmt.Sprintf("%d", start), fmt.Sprintf("%d", end), fmt.Sprintf("%+d", end-start))
+		}
+		table.PrintSimple()
+		fmt.Println()
+
+		fmt.Printf("Average WIP:  %.1f tasks\n", avgWIP)
+		fmt.Printf("Throughput:   %.2f tasks/day\n", throughput)
+		if throughput > 0 {
+			// Little's law: cycle time = WIP / throughput
+			fmt.Printf("Cycle time:   ~%.1f days\n", avgWIP/throughput)
+		}
+		fmt.Println()
+
+		switch {
+		case last.Counts[models.StatusBlocked] > first.Counts[models.StatusBlocked]:
+			ui.Red.Printf("⚠️  Blocked work grew from %d to %d tasks\n", first.Counts[models.StatusBlocked], last.Counts[models.StatusBlocked])
+		case last.WIP() > first.WIP() && throughput == 0:
+			ui.Yellow.Printf("⚠️  WIP is building up (%d → %d) with nothing completed\n", first.WIP(), last.WIP())
+		case last.WIP() > first.WIP():
+			ui.Yellow.Printf("⚠️  WIP grew from %d to %d tasks\n", first.WIP(), last.WIP())
+		default:
+			ui.Green.Println("✨ Work in progress is stable")
+		}
+		return nil
+	},
+}
+
+// cfdBands lists the chart bands in stacking order
+var cfdBands = []struct {
+	Status models.TaskStatus
+	Label  string
+	Color  *color.Color
+}{
+	{models.StatusDone, "Done", ui.Green},
+	{models.StatusDoing, "Doing", ui.Yellow},
+	{models.StatusBlocked, "Blocked", ui.Red},
+	{models.StatusTodo, "Todo", ui.Dim},
+}
+
+// cfdPoint is the number of tasks in each status at the end of a day
+type cfdPoint struct {
+	Date   time.Time
+	Counts map[models.TaskStatus]int
+}
+
+// cfdReport is the cumulative flow of a project, one point per day
+type cfdReport struct {
+	Project       string   `json:"project"`
+	From          string   `json:"from"`
+	To            string   `json:"to"`
+	Days          []cfdDay `json:"days"`
+	AverageWIP    float64  `json:"average_wip"`
+	Throughput    float64  `json:"throughput"`                // Tasks completed per day
+	CycleTimeDays *float64 `json:"cycle_time_days,omitempty"` // Omitted without throughput
+}
+
+// cfdDay is a cfdPoint as shown by --json
+type cfdDay struct {
+	Date    string `json:"date"`
+	Todo    int    `json:"todo"`
+	Doing   int    `json:"doing"`
+	Blocked int    `json:"blocked"`
+	Done    int    `json:"done"`
+}
+
+// Total returns the number of tasks that existed on the day
+func (p cfdPoint) Total() int {
+	total := 0
+	for _, n := range p.Counts {
+		total += n
+	}
+	return total
+}
+
+// WIP returns the number of tasks in progress or blocked
+func (p cfdPoint) WIP() int {
+	return p.Counts[models.StatusDoing] + p.Counts[models.StatusBlocked]
+}
+
+// buildCFDSeries counts tasks by status at the end of each day from from to to.
+// Statuses without a band of their own count as done if they are, and as in
+// progress otherwise.
+func buildCFDSeries(tasks []models.Task, from, to time.Time) []cfdPoint {
+	points := make([]cfdPoint, 0)
+
+	for day := from; !day.After(to); day = day.AddDate(0, 0, 1) {
+		endOfDay := day.AddDate(0, 0, 1).Add(-time.Nanosecond)
+		point := cfdPoint{Date: day, Counts: make(map[models.TaskStatus]int)}
+
+		for i := range tasks {
+			if status, ok := tasks[i].StatusAt(endOfDay); ok {
+				point.Counts[cfdBand(status)]++
+			}
+		}
+
+		points = append(points, point)
+	}
+
+	return points
+}
+
+// cfdBand returns the status whose band a task in status is counted in
+func cfdBand(status models.TaskStatus) models.TaskStatus {
+	for _, band := range cfdBands {
+		if band.Status == status {
+			return status
+		}
+	}
+	if status.IsDone() {
+		return models.StatusDone
+	}
+	return models.StatusDoing
+}
+
+func printCFDChart(points []cfdPoint, width int) {
+	maxTotal := 0
+	for _, p := range points {
+		if p.Total() > maxTotal {
+			maxTotal = p.Total()
+		}
+	}
+
+	step := 1
+	if len(points) > 30 {
+		step = (len(points) + 29) / 30
+	}
+
+	for i := 0; i < len(points); i += step {
+		// Always end on the last day
+		if i+step >= len(points) {
+			i = len(points) - 1
+		}
+		p := points[i]
+
+		fmt.Printf("%s  ", p.Date.Format("Jan 02"))
+
+		drawn := 0
+		cumulative := 0
+		for _, band := range cfdBands {
+			cumulative += p.Counts[band.Status]
+			end := 0
+			if maxTotal > 0 {
+				end = cumulative * width / maxTotal
+			}
+			if end > drawn {
+				band.Color.Print(strings.Repeat("█", end-drawn))
+				drawn = end
+			}
+		}
+		fmt.Print(strings.Repeat(" ", width-drawn))
+
+		counts := make([]string, len(cfdBands))
+		for j, band := range cfdBands {
+			counts[j] = fmt.Sprintf("%d", p.Counts[band.Status])
+		}
+		ui.Dim.Printf(" %s\n", strings.Join(counts, "/"))
+	}
+
+	fmt.Println()
+	for _, band := range cfdBands {
+		band.Color.Printf("█ %s  ", band.Label)
+	}
+	fmt.Println()
+	ui.Dim.Println("Counts: done/doing/blocked/todo")
+}
+
+func init() {
+	reportCFDCmd.Flags().Int("days", 30, "Number of days to chart, ending today")
+	reportCFDCmd.Flags().IntP("width", "w", 40, "Chart width in columns")
+	reportCFDCmd.ValidArgsFunction = projectArgCompletion
+
+	reportCmd.AddCommand(reportCFDCmd)
+}
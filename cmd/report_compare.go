@@ -0,0 +1,362 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/mrbooshehri/qix-go/internal/storage"
+	"github.com/mrbooshehri/qix-go/internal/ui"
+	"github.com/mrbooshehri/qix-go/pkg/qix/models"
+	"github.com/spf13/cobra"
+)
+
+var reportCompareCmd = &cobra.Command{
+	Use:   "compare <project[/module]> <project[/module]>...",
+	Short: "Compare projects or modules",
+	Long: `Side-by-side comparison of any number of projects or modules.
+
+With --from/--to, activity metrics (tasks completed, hours logged, estimation
+accuracy) only count work inside the period; status counts always reflect
+the current state. The best value of each ranked metric is marked with *,
+and targets are ranked by the number of metrics they win.`,
+	Args: cobra.MinimumNArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		fromStr, _ := cmd.Flags().GetString("from")
+		toStr, _ := cmd.Flags().GetString("to")
+
+		var from, to time.Time
+		var err error
+		if fromStr != "" {
+			if from, err = ui.ParseDate(fromStr); err != nil {
+				return invalid("Invalid start date format. Use: %s", ui.DateFormats())
+			}
+		}
+		if toStr != "" {
+			if to, err = ui.ParseDate(toStr); err != nil {
+				return invalid("Invalid end date format. Use: %s", ui.DateFormats())
+			}
+		}
+		if !from.IsZero() && !to.IsZero() && to.Before(from) {
+			return invalid("End date must be after start date")
+		}
+
+		store := storage.Get()
+
+		targets := make([]compareTarget, 0, len(args))
+		for _, path := range args {
+			target, err := loadCompareTarget(store, path)
+			if err != nil {
+				return fail("%v", err)
+			}
+			targets = append(targets, target)
+		}
+
+		stats := make([]compareStats, len(targets))
+		for i, target := range targets {
+			stats[i] = computeCompareStats(target, from, to)
+		}
+
+		wins := make([]int, len(targets))
+		bests := make([][]bool, len(compareMetrics))
+		for m, metric := range compareMetrics {
+			bests[m] = bestCompareIndexes(stats, metric)
+			for i, best := range bests[m] {
+				if best {
+					wins[i]++
+				}
+			}
+		}
+
+		// Ranking by metrics won, ties broken by completion
+		ranking := make([]int, len(targets))
+		for i := range ranking {
+			ranking[i] = i
+		}
+		sort.SliceStable(ranking, func(a, b int) bool {
+			i, j := ranking[a], ranking[b]
+			if wins[i] != wins[j] {
+				return wins[i] > wins[j]
+			}
+			return stats[i].Completion > stats[j].Completion
+		})
+
+		if jsonOutput {
+			report := compareReport{From: fromStr, To: toStr, Targets: make([]compareResult, len(targets))}
+			for rank, i := range ranking {
+				result := compareResult{Target: targets[i].Label, compareStats: stats[i], Wins: wins[i], Rank: rank + 1}
+				if stats[i].HasAccuracy {
+					accuracy := stats[i].Accuracy
+					result.Accuracy = &accuracy
+				}
+				report.Targets[i] = result
+			}
+			printJSON(report)
+			return nil
+		}
+
+		ui.PrintHeader("📊 Comparison")
+		switch {
+		case fromStr != "" && toStr != "":
+			fmt.Printf("Period: %s to %s\n\n", ui.FormatDate(fromStr), ui.FormatDate(toStr))
+		case fromStr != "":
+			fmt.Printf("Period: since %s\n\n", ui.FormatDate(fromStr))
+		case toStr != "":
+			fmt.Printf("Period: until %s\n\n", ui.FormatDate(toStr))
+		default:
+			fmt.Println("Period: all time")
+			fmt.Println()
+		}
+
+		headers := []string{"Metric"}
+		for _, target := range targets {
+			headers = append(headers, target.Label)
+		}
+		table := ui.NewTableBuilder(headers...)
+		for i := range targets {
+			table.Align(i+1, ui.AlignRight)
+		}
+
+		for m, metric := range compareMetrics {
+			row := []string{metric.Name}
+			best := bests[m]
+			for i, s := range stats {
+				value, ok := metric.Value(s)
+				cell := "n/a"
+				if ok {
+					cell = metric.Format(value)
+				}
+				if best[i] {
+					cell += " *"
+				} else {
+					cell += "  "
+				}
+				row = append(row, cell)
+			}
+			table.Row(row...)
+		}
+		table.PrintSimple()
+		fmt.Println()
+
+		ui.PrintSubHeader("🏆 Ranking")
+		rankTable := ui.NewTableBuilder("Rank", "Target", "Metrics won").
+			Align(0, ui.AlignRight).
+			Align(2, ui.AlignRight)
+		for rank, i := range ranking {
+			rankTable.Row(fmt.Sprintf("%d", rank+1), targets[i].Label, fmt.Sprintf("%d", wins[i]))
+		}
+		rankTable.PrintSimple()
+		fmt.Println()
+
+		// Visual comparison
+		ui.PrintSubHeader("📈 Completion Comparison")
+
+		labelWidth := 20
+		for _, target := range targets {
+			if len(target.Label) > labelWidth {
+				labelWidth = len(target.Label)
+			}
+		}
+		for i, target := range targets {
+			fmt.Printf("%-*s ", labelWidth, target.Label)
+			ui.PrintProgressBar(stats[i].Completion, 40)
+			fmt.Printf(" %.1f%%\n", stats[i].Completion)
+		}
+		return nil
+	},
+}
+
+// compareTarget is a project or module being compared
+type compareTarget struct {
+	Label   string
+	Tasks   []models.Task
+	Modules int
+	Sprints int
+}
+
+// loadCompareTarget resolves a project or project/module path
+func loadCompareTarget(store *storage.Storage, path string) (compareTarget, error) {
+	projectName, moduleName := parsePath(path)
+
+	project, err := store.LoadProject(projectName)
+	if err != nil {
+		return compareTarget{}, notFound("project not found: %s", projectName)
+	}
+
+	if moduleName == "" {
+		return compareTarget{
+			Label:   project.Name,
+			Tasks:   project.GetAllTasks(),
+			Modules: len(project.Modules),
+			Sprints: len(project.Sprints),
+		}, nil
+	}
+
+	for _, module := range project.Modules {
+		if module.Name == moduleName {
+			return compareTarget{
+				Label: fmt.Sprintf("%s/%s", project.Name, module.Name),
+				Tasks: module.Tasks,
+			}, nil
+		}
+	}
+
+	return compareTarget{}, notFound("module not found: %s", path)
+}
+
+// compareStats holds the metrics computed for one target
+type compareStats struct {
+	Tasks       int     `json:"tasks"`
+	Completed   int     `json:"completed"`
+	InProgress  int     `json:"in_progress"`
+	Blocked     int     `json:"blocked"`
+	Overdue     int     `json:"overdue"`
+	Completion  float64 `json:"completion"`
+	Estimated   float64 `json:"estimated_hours"`
+	HoursLogged float64 `json:"hours_logged"`
+	Accuracy    float64 `json:"-"`
+	HasAccuracy bool    `json:"-"`
+	Modules     int     `json:"modules"`
+	Sprints     int     `json:"sprints"`
+}
+
+// compareReport is the comparison of several projects or modules, in argument order
+type compareReport struct {
+	From    string          `json:"from,omitempty"`
+	To      string          `json:"to,omitempty"`
+	Targets []compareResult `json:"targets"`
+}
+
+// compareResult is one target's metrics and ranking
+type compareResult struct {
+	Target string `json:"target"`
+	compareStats
+	Accuracy *float64 `json:"accuracy"` // null without completed, estimated tasks
+	Wins     int      `json:"wins"`     // Ranked metrics with the best value
+	Rank     int      `json:"rank"`
+}
+
+// computeCompareStats computes metrics for a target, limiting activity to [from, to] when set
+func computeCompareStats(target compareTarget, from, to time.Time) compareStats {
+	stats := compareStats{
+		Tasks:   len(target.Tasks),
+		Modules: target.Modules,
+		Sprints: target.Sprints,
+	}
+
+	inPeriod := func(day time.Time) bool {
+		day = truncateDay(day)
+		return (from.IsZero() || !day.Before(from)) && (to.IsZero() || !day.After(to))
+	}
+
+	today := time.Now().Format("2006-01-02")
+	done := 0
+	completed := make([]projectTask, 0)
+
+	for _, task := range target.Tasks {
+		switch {
+		case task.Status.IsDone():
+			done++
+		case task.Status == models.StatusDoing:
+			stats.InProgress++
+		case task.Status == models.StatusBlocked:
+			stats.Blocked++
+		}
+		if task.IsOverdue(today) {
+			stats.Overdue++
+		}
+
+		stats.Estimated += task.EstimatedHours
+
+		for _, entry := range task.TimeEntries {
+			if day, err := time.ParseInLocation("2006-01-02", entry.Date, time.Local); err == nil && inPeriod(day) {
+				stats.HoursLogged += entry.Hours
+			}
+		}
+
+		if completedAt, ok := taskCompletedAt(task); ok && inPeriod(completedAt) {
+			stats.Completed++
+			completed = append(completed, projectTask{Task: task})
+		}
+	}
+
+	if stats.Tasks > 0 {
+		stats.Completion = float64(done) / float64(stats.Tasks) * 100
+	}
+	stats.Accuracy, stats.HasAccuracy = completedAccuracy(completed)
+
+	return stats
+}
+
+// compareMetric describes one comparison row; Better is 1 if higher wins, -1 if lower wins, 0 if unranked
+type compareMetric struct {
+	Name   string
+	Better int
+	Value  func(compareStats) (float64, bool)
+	Format func(float64) string
+}
+
+func formatCount(v float64) string {
+	return fmt.Sprintf("%.0f", v)
+}
+
+var compareMetrics = []compareMetric{
+	{"Total Tasks", 0, func(s compareStats) (float64, bool) { return float64(s.Tasks), true }, formatCount},
+	{"Completed", 1, func(s compareStats) (float64, bool) { return float64(s.Completed), true }, formatCount},
+	{"In Progress", 0, func(s compareStats) (float64, bool) { return float64(s.InProgress), true }, formatCount},
+	{"Blocked", -1, func(s compareStats) (float64, bool) { return float64(s.Blocked), true }, formatCount},
+	{"Overdue", -1, func(s compareStats) (float64, bool) { return float64(s.Overdue), true }, formatCount},
+	{"Completion", 1, func(s compareStats) (float64, bool) { return s.Completion, true }, ui.FormatPercentage},
+	{"Estimated Hours", 0, func(s compareStats) (float64, bool) { return s.Estimated, true }, ui.FormatHours},
+	{"Hours Logged", 0, func(s compareStats) (float64, bool) { return s.HoursLogged, true }, ui.FormatHours},
+	{"Estimation Accuracy", 1, func(s compareStats) (float64, bool) { return s.Accuracy, s.HasAccuracy }, ui.FormatPercentage},
+	{"Modules", 0, func(s compareStats) (float64, bool) { return float64(s.Modules), true }, formatCount},
+	{"Sprints", 0, func(s compareStats) (float64, bool) { return float64(s.Sprints), true }, formatCount},
+}
+
+// bestCompareIndexes marks the targets holding the best value of a ranked metric.
+// Nothing is marked when every target ties.
+func bestCompareIndexes(stats []compareStats, metric compareMetric) []bool {
+	best := make([]bool, len(stats))
+	if metric.Better == 0 {
+		return best
+	}
+
+	var bestValue float64
+	found := false
+	allEqual := true
+	for _, s := range stats {
+		value, ok := metric.Value(s)
+		if !ok {
+			continue
+		}
+		if found && value != bestValue {
+			allEqual = false
+		}
+		if !found || float64(metric.Better)*(value-bestValue) > 0 {
+			bestValue = value
+		}
+		found = true
+	}
+
+	if !found || allEqual {
+		return best
+	}
+
+	for i, s := range stats {
+		if value, ok := metric.Value(s); ok && value == bestValue {
+			best[i] = true
+		}
+	}
+	return best
+}
+
+func init() {
+	reportCompareCmd.Flags().String("from", "", "Start of the period (YYYY-MM-DD)")
+	reportCompareCmd.Flags().String("to", "", "End of the period (YYYY-MM-DD)")
+	reportCompareCmd.RegisterFlagCompletionFunc("from", completeDates)
+	reportCompareCmd.RegisterFlagCompletionFunc("to", completeDates)
+	reportCompareCmd.ValidArgsFunction = projectModulePathsArgCompletion
+
+	reportCmd.AddCommand(reportCompareCmd)
+}
@@ -0,0 +1,289 @@
+package cmd
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/mrbooshehri/qix-go/internal/storage"
+	"github.com/mrbooshehri/qix-go/internal/ui"
+	"github.com/mrbooshehri/qix-go/pkg/qix/models"
+	"github.com/spf13/cobra"
+)
+
+var reportCostCmd = &cobra.Command{
+	Use:   "cost <project>",
+	Short: "Cost report from logged hours",
+	Long: `Convert logged hours into cost using the project's hourly rates.
+
+Each task is charged at its assignee's rate (qix project rate <project> <rate>
+--person <name>), falling back to the project's default rate. With --from/--to
+only hours logged in that period are costed. If the project has a budget
+(qix project budget), the budget summary always uses all-time spend and
+projects the cost at completion from remaining estimates.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		projectName := args[0]
+		fromStr, _ := cmd.Flags().GetString("from")
+		toStr, _ := cmd.Flags().GetString("to")
+
+		for _, date := range []*string{&fromStr, &toStr} {
+			if *date == "" {
+				continue
+			}
+			normalized, err := ui.NormalizeDate(*date)
+			if err != nil {
+				return invalid("Invalid date format. Use: %s", ui.DateFormats())
+			}
+			*date = normalized
+		}
+		if fromStr != "" && toStr != "" && toStr < fromStr {
+			return invalid("End date must be after start date")
+		}
+
+		store := storage.Get()
+
+		project, err := store.LoadProject(projectName)
+		if err != nil {
+			return notFound("Project not found: %s", projectName)
+		}
+
+		period := computeProjectCost(project, fromStr, toStr)
+
+		if jsonOutput {
+			report := costReport{projectCost: period, Project: projectName, From: fromStr, To: toStr}
+			// A module's hours are charged at several rates
+			report.ByModule = make([]costLine, 0, len(period.ByModule))
+			for _, line := range period.ByModule {
+				line.Rate = 0
+				report.ByModule = append(report.ByModule, line)
+			}
+			if project.Budget > 0 {
+				allTime := period
+				if fromStr != "" || toStr != "" {
+					allTime = computeProjectCost(project, "", "")
+				}
+				report.Budget = &budgetStatus{
+					Budget:            project.Budget,
+					Spent:             allTime.Cost,
+					Remaining:         project.Budget - allTime.Cost,
+					Used:              allTime.Cost / project.Budget * 100,
+					RemainingEstimate: allTime.RemainingCost,
+					Projected:         allTime.Cost + allTime.RemainingCost,
+				}
+			}
+			printJSON(report)
+			return nil
+		}
+
+		ui.PrintHeader(fmt.Sprintf("💰 Cost Report: %s", projectName))
+		switch {
+		case fromStr != "" && toStr != "":
+			fmt.Printf("Period: %s to %s\n", ui.FormatDate(fromStr), ui.FormatDate(toStr))
+		case fromStr != "":
+			fmt.Printf("Period: since %s\n", ui.FormatDate(fromStr))
+		case toStr != "":
+			fmt.Printf("Period: until %s\n", ui.FormatDate(toStr))
+		default:
+			fmt.Println("Period: all time")
+		}
+
+		if project.HourlyRate == 0 && len(project.Rates) == 0 {
+			fmt.Println()
+			ui.PrintWarning("No rates configured. Set one with: qix project rate %s <rate>", projectName)
+		}
+
+		ui.PrintSubHeader("👥 By Person")
+		printCostBreakdown(period.ByPerson, true)
+
+		ui.PrintSubHeader("🧩 By Module")
+		printCostBreakdown(period.ByModule, false)
+
+		ui.BoldGreen.Printf("Total: %s (%s)\n", ui.FormatMoney(period.Cost), ui.FormatHours(period.Hours))
+
+		if project.Budget <= 0 {
+			return nil
+		}
+
+		allTime := period
+		if fromStr != "" || toStr != "" {
+			allTime = computeProjectCost(project, "", "")
+		}
+
+		remaining := project.Budget - allTime.Cost
+		used := allTime.Cost / project.Budget * 100
+		projected := allTime.Cost + allTime.RemainingCost
+
+		ui.PrintSubHeader("📒 Budget")
+		ui.NewTableBuilder("Metric", "Value").
+			Align(1, ui.AlignRight).
+			Row("Budget", ui.FormatMoney(project.Budget)).
+			Row("Spent to date", ui.FormatMoney(allTime.Cost)).
+			Row("Remaining", ui.FormatMoney(remaining)).
+			Row("Used", ui.FormatPercentage(used)).
+			Row("Remaining estimate", ui.FormatMoney(allTime.RemainingCost)).
+			Row("Projected at completion", ui.FormatMoney(projected)).
+			PrintSimple()
+		fmt.Println()
+
+		fmt.Print("Budget used: ")
+		ui.PrintProgressBar(math.Min(used, 100), 40)
+		fmt.Printf(" %.1f%%\n", used)
+		fmt.Println()
+
+		switch {
+		case remaining < 0:
+			ui.Red.Printf("🚨 Over budget by %s\n", ui.FormatMoney(-remaining))
+		case projected > project.Budget:
+			ui.Yellow.Printf("⚠️  Projected to exceed budget by %s\n", ui.FormatMoney(projected-project.Budget))
+		default:
+			ui.Green.Printf("✨ Projected to finish %s under budget\n", ui.FormatMoney(project.Budget-projected))
+		}
+		return nil
+	},
+}
+
+// costLine is the hours and cost attributed to one person or module
+type costLine struct {
+	Name  string  `json:"name"`
+	Rate  float64 `json:"rate,omitempty"`
+	Hours float64 `json:"hours"`
+	Cost  float64 `json:"cost"`
+}
+
+// projectCost is the cost of a project's logged hours within a period
+type projectCost struct {
+	Hours         float64    `json:"hours"`
+	Cost          float64    `json:"cost"`
+	RemainingCost float64    `json:"remaining_cost"` // Remaining estimates of open tasks, at their rates
+	ByPerson      []costLine `json:"by_person"`
+	ByModule      []costLine `json:"by_module"`
+}
+
+// costReport is a project's cost for a period and, with a budget, its all-time budget status
+type costReport struct {
+	projectCost
+	Project string        `json:"project"`
+	From    string        `json:"from,omitempty"`
+	To      string        `json:"to,omitempty"`
+	Budget  *budgetStatus `json:"budget,omitempty"`
+}
+
+// budgetStatus compares all-time spend and projected cost with a project's budget
+type budgetStatus struct {
+	Budget            float64 `json:"budget"`
+	Spent             float64 `json:"spent"`
+	Remaining         float64 `json:"remaining"`
+	Used              float64 `json:"used"` // Percent of the budget
+	RemainingEstimate float64 `json:"remaining_estimate"`
+	Projected         float64 `json:"projected"`
+}
+
+// computeProjectCost costs hours logged between from and to (inclusive, either may be empty)
+func computeProjectCost(project *models.Project, from, to string) projectCost {
+	result := projectCost{}
+	byPerson := make(map[string]*costLine)
+	byModule := make(map[string]*costLine)
+
+	add := func(lines map[string]*costLine, name string, rate, hours float64) {
+		line, ok := lines[name]
+		if !ok {
+			line = &costLine{Name: name, Rate: rate}
+			lines[name] = line
+		}
+		line.Hours += hours
+		line.Cost += hours * rate
+	}
+
+	collect := func(module string, tasks []models.Task) {
+		for _, task := range tasks {
+			rate := project.RateFor(task.Assignee)
+
+			hours := 0.0
+			for _, entry := range task.TimeEntries {
+				if (from == "" || entry.Date >= from) && (to == "" || entry.Date <= to) {
+					hours += entry.Hours
+				}
+			}
+
+			if !task.Status.IsDone() {
+				result.RemainingCost += math.Max(task.EstimatedHours-task.CalculateActualHours(), 0) * rate
+			}
+
+			if hours == 0 {
+				continue
+			}
+
+			person := task.Assignee
+			if person == "" {
+				person = unassignedLabel
+			}
+			add(byPerson, person, rate, hours)
+			add(byModule, module, rate, hours)
+
+			result.Hours += hours
+			result.Cost += hours * rate
+		}
+	}
+
+	collect("(project)", project.Tasks)
+	for _, module := range project.Modules {
+		collect(module.Name, module.Tasks)
+	}
+
+	result.ByPerson = sortedCostLines(byPerson)
+	result.ByModule = sortedCostLines(byModule)
+	return result
+}
+
+func sortedCostLines(lines map[string]*costLine) []costLine {
+	sorted := make([]costLine, 0, len(lines))
+	for _, line := range lines {
+		sorted = append(sorted, *line)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].Cost != sorted[j].Cost {
+			return sorted[i].Cost > sorted[j].Cost
+		}
+		return sorted[i].Name < sorted[j].Name
+	})
+	return sorted
+}
+
+func printCostBreakdown(lines []costLine, showRate bool) {
+	if len(lines) == 0 {
+		ui.Dim.Println("  No time logged in this period")
+		fmt.Println()
+		return
+	}
+
+	headers := []string{"Name", "Hours", "Cost"}
+	if showRate {
+		headers = []string{"Name", "Rate", "Hours", "Cost"}
+	}
+	table := ui.NewTableBuilder(headers...)
+	for i := 1; i < len(headers); i++ {
+		table.Align(i, ui.AlignRight)
+	}
+
+	for _, line := range lines {
+		if showRate {
+			table.Row(line.Name, fmt.Sprintf("%s/h", ui.FormatMoney(line.Rate)), ui.FormatHours(line.Hours), ui.FormatMoney(line.Cost))
+		} else {
+			table.Row(line.Name, ui.FormatHours(line.Hours), ui.FormatMoney(line.Cost))
+		}
+	}
+
+	table.PrintSimple()
+	fmt.Println()
+}
+
+func init() {
+	reportCostCmd.Flags().String("from", "", "Start date (YYYY-MM-DD)")
+	reportCostCmd.Flags().String("to", "", "End date (YYYY-MM-DD)")
+	reportCostCmd.RegisterFlagCompletionFunc("from", completeDates)
+	reportCostCmd.RegisterFlagCompletionFunc("to", completeDates)
+	reportCostCmd.ValidArgsFunction = projectArgCompletion
+
+	reportCmd.AddCommand(reportCostCmd)
+}
@@ -0,0 +1,353 @@
+package cmd
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/mrbooshehri/qix-go/internal/config"
+	"github.com/mrbooshehri/qix-go/internal/storage"
+	"github.com/mrbooshehri/qix-go/pkg/qix/models"
+)
+
+// dailyReport is the time logged across all projects on one date
+type dailyReport struct {
+	Date          string                  `json:"date"`
+	Projects      []projectTime           `json:"projects"`
+	TotalHours    float64                 `json:"total_hours"`
+	ActiveSession *models.TrackingSession `json:"active_session,omitempty"` // Not yet logged
+}
+
+// projectTime is the time entries logged to a project in some period
+type projectTime struct {
+	Project    string             `json:"project"`
+	Entries    []models.TimeEntry `json:"entries"`
+	TotalHours float64            `json:"total_hours"`
+}
+
+func newProjectTime(name string, entries []models.TimeEntry) projectTime {
+	pt := projectTime{Project: name, Entries: entries}
+	if pt.Entries == nil {
+		pt.Entries = []models.TimeEntry{}
+	}
+	for _, entry := range entries {
+		pt.TotalHours += entry.Hours
+	}
+	return pt
+}
+
+// sortedKeys returns the keys of a map of time entries, sorted
+func sortedKeys(m map[string][]models.TimeEntry) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// projectReport is the project performance report for a date range
+type projectReport struct {
+	projectSummary
+	From              string          `json:"from"`
+	To                string          `json:"to"`
+	CompletedInPeriod int             `json:"completed_in_period"`
+	Velocity          float64         `json:"velocity"` // Tasks completed per day in the period
+	ModuleBreakdown   []moduleSummary `json:"module_breakdown"`
+	TopTasks          []taskView      `json:"top_tasks"` // Most time logged, up to 5
+}
+
+func buildProjectReport(project *models.Project, startDate, endDate string) projectReport {
+	report := projectReport{
+		projectSummary:  newProjectSummary(project),
+		From:            startDate,
+		To:              endDate,
+		ModuleBreakdown: make([]moduleSummary, 0, len(project.Modules)),
+		TopTasks:        make([]taskView, 0, 5),
+	}
+
+	for _, module := range project.Modules {
+		report.ModuleBreakdown = append(report.ModuleBreakdown, newModuleSummary(module))
+	}
+
+	for _, task := range project.GetAllTasks() {
+		if task.Status.IsDone() {
+			updatedDate := task.UpdatedAt.Format("2006-01-02")
+			if updatedDate >= startDate && updatedDate <= endDate {
+				report.CompletedInPeriod++
+			}
+		}
+	}
+	start, _ := time.Parse("2006-01-02", startDate)
+	end, _ := time.Parse("2006-01-02", endDate)
+	if days := daysBetween(start, end) + 1; days > 0 {
+		report.Velocity = float64(report.CompletedInPeriod) / float64(days)
+	}
+
+	modules := taskModules(project)
+	for _, task := range project.GetAllTasks() {
+		if task.CalculateActualHours() > 0 {
+			report.TopTasks = append(report.TopTasks, newTaskView(project.Name, modules[task.ID], task))
+		}
+	}
+	sort.SliceStable(report.TopTasks, func(i, j int) bool {
+		return report.TopTasks[i].ActualHours > report.TopTasks[j].ActualHours
+	})
+	if len(report.TopTasks) > 5 {
+		report.TopTasks = report.TopTasks[:5]
+	}
+
+	return report
+}
+
+// kpiReport is the KPI metrics of a project
+type kpiReport struct {
+	projectSummary
+	WithDependencies int          `json:"with_dependencies"`
+	WithTimeLogged   int          `json:"with_time_logged"`
+	Recurring        int          `json:"recurring"`
+	Health           *healthScore `json:"health,omitempty"` // nil when every component is disabled
+}
+
+// healthScore is a project's weighted health score, in percent
+type healthScore struct {
+	Score           float64  `json:"score"`
+	Rating          string   `json:"rating"` // excellent, good, needs attention, requires improvement
+	Recommendations []string `json:"recommendations"`
+}
+
+func buildKPIReport(project *models.Project, kpi config.KPIConfig) kpiReport {
+	report := kpiReport{projectSummary: newProjectSummary(project)}
+
+	allTasks := project.GetAllTasks()
+	for _, task := range allTasks {
+		if len(task.Dependencies) > 0 {
+			report.WithDependencies++
+		}
+		if len(task.TimeEntries) > 0 {
+			report.WithTimeLogged++
+		}
+		if task.IsRecurring() {
+			report.Recurring++
+		}
+	}
+
+	score := 0.0
+	maxScore := 0.0
+
+	// Completion rate
+	completion := report.Completion
+	if kpi.CompletionWeight > 0 {
+		score += (completion / 100.0) * kpi.CompletionWeight
+		maxScore += kpi.CompletionWeight
+	}
+
+	// Estimation accuracy
+	estimated := report.EstimatedHours
+	actual := report.ActualHours
+	if kpi.AccuracyWeight > 0 {
+		if estimated > 0 {
+			accuracy := 100.0
+			variance := ((actual - estimated) / estimated) * 100
+			if variance < 0 {
+				accuracy = 100 + variance
+			} else {
+				accuracy = 100 - variance
+			}
+			if accuracy < 0 {
+				accuracy = 0
+			}
+			score += (accuracy / 100.0) * kpi.AccuracyWeight
+		}
+		maxScore += kpi.AccuracyWeight
+	}
+
+	// Task tracking adoption
+	if kpi.TrackingWeight > 0 {
+		if len(allTasks) > 0 {
+			trackingRate := float64(report.WithTimeLogged) / float64(len(allTasks)) * 100
+			score += (trackingRate / 100.0) * kpi.TrackingWeight
+		}
+		maxScore += kpi.TrackingWeight
+	}
+
+	// Active work - balance between todo and doing
+	counts := report.StatusCounts
+	active := counts[models.StatusDoing]
+	if kpi.ActiveWeight > 0 {
+		if len(allTasks) > 0 {
+			activeRate := float64(active) / float64(len(allTasks)) * 100
+			// Full marks inside the configured active range
+			if activeRate >= kpi.ActiveMin && activeRate <= kpi.ActiveMax {
+				score += kpi.ActiveWeight
+			} else if activeRate > kpi.ActiveMax {
+				score += kpi.ActiveWeight * math.Max(0, 1.0-(activeRate-kpi.ActiveMax)/(100-kpi.ActiveMax))
+			} else {
+				score += kpi.ActiveWeight * (activeRate / kpi.ActiveMin)
+			}
+		}
+		maxScore += kpi.ActiveWeight
+	}
+
+	if maxScore == 0 {
+		return report
+	}
+
+	health := &healthScore{Score: (score / maxScore) * 100, Recommendations: make([]string, 0)}
+	switch {
+	case health.Score >= kpi.ExcellentThreshold:
+		health.Rating = "excellent"
+	case health.Score >= kpi.GoodThreshold:
+		health.Rating = "good"
+	case health.Score >= kpi.AttentionThreshold:
+		health.Rating = "needs attention"
+	default:
+		health.Rating = "requires improvement"
+	}
+
+	if health.Score < kpi.ExcellentThreshold {
+		if completion < 20 {
+			health.Recommendations = append(health.Recommendations, "Focus on completing tasks to improve progress")
+		}
+		if report.WithTimeLogged < len(allTasks)/2 {
+			health.Recommendations = append(health.Recommendations, "Track time more consistently for better insights")
+		}
+		if estimated > 0 && actual > estimated*1.5 {
+			health.Recommendations = append(health.Recommendations, "Review estimates - tasks are taking longer than expected")
+		}
+		if counts[models.StatusBlocked] > 0 {
+			health.Recommendations = append(health.Recommendations, "Address blocked tasks to maintain momentum")
+		}
+	}
+
+	report.Health = health
+	return report
+}
+
+// wbsReport is a project's work breakdown structure with task relationships
+type wbsReport struct {
+	Project      string             `json:"project"`
+	Completion   float64            `json:"completion"`
+	Modules      []moduleDetails    `json:"modules"`
+	Tasks        []taskView         `json:"tasks"` // Project-level tasks
+	Dependencies []taskDependency   `json:"dependencies"`
+	Hierarchy    []taskWithChildren `json:"hierarchy"`
+}
+
+// taskDependency is a task and the tasks it depends on. A dependency that no
+// longer exists has no status.
+type taskDependency struct {
+	taskRef
+	DependsOn []taskRef `json:"depends_on"`
+}
+
+// taskWithChildren is a top-level task and its child tasks
+type taskWithChildren struct {
+	taskRef
+	Children []taskRef `json:"children"`
+}
+
+func buildWBSReport(store *storage.Storage, project *models.Project) wbsReport {
+	report := wbsReport{
+		Project:      project.Name,
+		Completion:   project.GetCompletionPercentage(),
+		Modules:      make([]moduleDetails, 0, len(project.Modules)),
+		Tasks:        make([]taskView, 0, len(project.Tasks)),
+		Dependencies: make([]taskDependency, 0),
+		Hierarchy:    make([]taskWithChildren, 0),
+	}
+
+	for _, module := range project.Modules {
+		report.Modules = append(report.Modules, newModuleDetails(project.Name, module))
+	}
+	for _, task := range project.Tasks {
+		report.Tasks = append(report.Tasks, newTaskView(project.Name, "", task))
+	}
+
+	for _, task := range project.GetAllTasks() {
+		if len(task.Dependencies) > 0 {
+			dependency := taskDependency{taskRef: newTaskRef(task), DependsOn: make([]taskRef, 0, len(task.Dependencies))}
+			for _, depID := range task.Dependencies {
+				if depTask, _, err := store.FindTask(project.Name, depID); err == nil {
+					dependency.DependsOn = append(dependency.DependsOn, newTaskRef(*depTask))
+				} else {
+					dependency.DependsOn = append(dependency.DependsOn, taskRef{ID: depID})
+				}
+			}
+			report.Dependencies = append(report.Dependencies, dependency)
+		}
+
+		// Root tasks (no parent) with children
+		if task.ParentID == "" {
+			children, _ := store.GetChildTasks(project.Name, task.ID)
+			if len(children) > 0 {
+				parent := taskWithChildren{taskRef: newTaskRef(task), Children: make([]taskRef, 0, len(children))}
+				for _, child := range children {
+					parent.Children = append(parent.Children, newTaskRef(child))
+				}
+				report.Hierarchy = append(report.Hierarchy, parent)
+			}
+		}
+	}
+
+	return report
+}
+
+// timelineReport is a project's activity per day
+type timelineReport struct {
+	Project string        `json:"project"`
+	Days    []dayActivity `json:"days"`
+}
+
+// dayActivity counts a project's task events on one day
+type dayActivity struct {
+	Date      string `json:"date"`
+	Created   int    `json:"created"`
+	Started   int    `json:"started"`
+	Blocked   int    `json:"blocked"`
+	Reopened  int    `json:"reopened"`
+	Completed int    `json:"completed"`
+}
+
+func (a dayActivity) total() int {
+	return a.Created + a.Started + a.Blocked + a.Reopened + a.Completed
+}
+
+// buildTimeline collects activity by day, for the days up to and including end,
+// from each task's event history
+func buildTimeline(project *models.Project, end time.Time, days int) []dayActivity {
+	startDate := end.AddDate(0, 0, -days+1)
+
+	activities := make([]dayActivity, days)
+	byDate := make(map[string]*dayActivity, days)
+	for i := 0; i < days; i++ {
+		activities[i].Date = startDate.AddDate(0, 0, i).Format("2006-01-02")
+		byDate[activities[i].Date] = &activities[i]
+	}
+
+	for _, task := range project.GetAllTasks() {
+		for _, event := range task.Events() {
+			activity, ok := byDate[event.At.Format("2006-01-02")]
+			if !ok {
+				continue
+			}
+
+			if event.Type == models.EventCreated {
+				activity.Created++
+				continue
+			}
+			switch {
+			case event.Status.IsDone():
+				activity.Completed++
+			case event.Status == models.StatusDoing:
+				activity.Started++
+			case event.Status == models.StatusBlocked:
+				activity.Blocked++
+			case event.Status == models.StatusTodo:
+				activity.Reopened++
+			}
+		}
+	}
+
+	return activities
+}
@@ -0,0 +1,187 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/mrbooshehri/qix-go/internal/storage"
+	"github.com/mrbooshehri/qix-go/internal/ui"
+	"github.com/mrbooshehri/qix-go/pkg/qix/models"
+	"github.com/spf13/cobra"
+)
+
+var reportDueCmd = &cobra.Command{
+	Use:   "due [project]",
+	Short: "Overdue and due-soon triage list",
+	Long: `List open tasks that are overdue (most late first), tasks due within the
+next --days days, and recurring tasks that are pending. Defaults to all projects.
+With --porcelain, exits 1 when any task is overdue.
+
+Set due dates with: qix task create ... --due YYYY-MM-DD
+                or: qix task edit <project> <task_id> --due YYYY-MM-DD`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		days, _ := cmd.Flags().GetInt("days")
+
+		projectName := ""
+		if len(args) > 0 {
+			projectName = args[0]
+		}
+
+		store := storage.Get()
+
+		projects, err := loadReportProjects(store, projectName)
+		if err != nil {
+			return fail("%v", err)
+		}
+
+		today := truncateDay(time.Now())
+		todayStr := today.Format("2006-01-02")
+		horizon := today.AddDate(0, 0, days).Format("2006-01-02")
+
+		var overdue, upcoming, recurring []projectTask
+		for _, project := range projects {
+			for _, task := range project.GetAllTasks() {
+				item := projectTask{Project: project.Name, Task: task}
+
+				if task.IsRecurring() && task.Recurrence.NextDue <= todayStr {
+					recurring = append(recurring, item)
+					continue
+				}
+
+				if task.DueDate == "" || task.Status.IsDone() {
+					continue
+				}
+
+				if task.IsOverdue(todayStr) {
+					overdue = append(overdue, item)
+				} else if task.DueDate <= horizon {
+					upcoming = append(upcoming, item)
+				}
+			}
+		}
+
+		sort.SliceStable(overdue, func(i, j int) bool {
+			return overdue[i].Task.DueDate < overdue[j].Task.DueDate
+		})
+		sort.SliceStable(upcoming, func(i, j int) bool {
+			return upcoming[i].Task.DueDate < upcoming[j].Task.DueDate
+		})
+		sort.SliceStable(recurring, func(i, j int) bool {
+			return recurring[i].Task.Recurrence.NextDue < recurring[j].Task.Recurrence.NextDue
+		})
+
+		if jsonOutput {
+			modules := projectTaskModules(projects)
+			printJSON(dueReport{
+				Project:   projectName,
+				Date:      todayStr,
+				Days:      days,
+				Overdue:   projectTaskViews(modules, overdue),
+				Upcoming:  projectTaskViews(modules, upcoming),
+				Recurring: projectTaskViews(modules, recurring),
+			})
+			return nil
+		}
+		if porcelain {
+			// The list (overdue, upcoming or recurring), then the task record
+			modules := projectTaskModules(projects)
+			for _, list := range []struct {
+				name  string
+				tasks []projectTask
+			}{{"overdue", overdue}, {"upcoming", upcoming}, {"recurring", recurring}} {
+				for _, view := range projectTaskViews(modules, list.tasks) {
+					ui.PrintRecord(append([]string{list.name}, taskRecord(view)...)...)
+				}
+			}
+			if len(overdue) > 0 {
+				setPorcelainStatus(1)
+			}
+			return nil
+		}
+
+		scope := "All Projects"
+		if projectName != "" {
+			scope = projectName
+		}
+		ui.PrintHeader(fmt.Sprintf("🚦 Due Triage: %s", scope))
+		fmt.Printf("As of %s, looking %d days ahead\n", ui.FormatDate(todayStr), days)
+
+		if len(overdue) == 0 && len(upcoming) == 0 && len(recurring) == 0 {
+			fmt.Println()
+			ui.PrintEmptyState("Nothing overdue or due soon", "")
+			return nil
+		}
+
+		ui.PrintSubHeader(fmt.Sprintf("🔥 Overdue (%d)", len(overdue)))
+		printDueTable(overdue, today, func(t models.Task) string { return t.DueDate })
+
+		ui.PrintSubHeader(fmt.Sprintf("📅 Due in the Next %d Days (%d)", days, len(upcoming)))
+		printDueTable(upcoming, today, func(t models.Task) string { return t.DueDate })
+
+		ui.PrintSubHeader(fmt.Sprintf("🔔 Recurring Pending (%d)", len(recurring)))
+		printDueTable(recurring, today, func(t models.Task) string { return t.Recurrence.NextDue })
+		return nil
+	},
+}
+
+// dueReport is the due triage list as of a date
+type dueReport struct {
+	Project   string     `json:"project,omitempty"` // Empty for all projects
+	Date      string     `json:"date"`
+	Days      int        `json:"days"` // How far ahead upcoming looks
+	Overdue   []taskView `json:"overdue"`
+	Upcoming  []taskView `json:"upcoming"`
+	Recurring []taskView `json:"recurring"` // Recurring tasks due today or earlier
+}
+
+func printDueTable(tasks []projectTask, today time.Time, dueDate func(models.Task) string) {
+	if len(tasks) == 0 {
+		ui.Dim.Println("  None")
+		return
+	}
+
+	table := ui.NewTableBuilder("Task", "Project", "Priority", "Due", "When").
+		Align(4, ui.AlignRight)
+
+	for _, item := range tasks {
+		due := dueDate(item.Task)
+		table.Row(
+			fmt.Sprintf("[%s] %s", item.Task.ID, item.Task.Title),
+			item.Project,
+			string(item.Task.Priority),
+			ui.FormatDate(due),
+			describeDueOffset(due, today),
+		)
+	}
+
+	table.PrintSimple()
+}
+
+// describeDueOffset renders a due date relative to today, e.g. "3d late" or "in 2d"
+func describeDueOffset(due string, today time.Time) string {
+	dueDate, err := time.ParseInLocation("2006-01-02", due, time.Local)
+	if err != nil {
+		return ""
+	}
+
+	days := int(dueDate.Sub(today).Hours() / 24)
+	switch {
+	case days < 0:
+		return fmt.Sprintf("%dd late", -days)
+	case days == 0:
+		return "today"
+	case days == 1:
+		return "tomorrow"
+	default:
+		return fmt.Sprintf("in %dd", days)
+	}
+}
+
+func init() {
+	reportDueCmd.Flags().Int("days", 7, "Days ahead to include as due soon")
+	reportDueCmd.ValidArgsFunction = projectArgCompletion
+
+	reportCmd.AddCommand(reportDueCmd)
+}
@@ -0,0 +1,240 @@
+package cmd
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/mrbooshehri/qix-go/internal/storage"
+	"github.com/mrbooshehri/qix-go/internal/ui"
+	"github.com/mrbooshehri/qix-go/pkg/qix/models"
+	"github.com/spf13/cobra"
+)
+
+var reportForecastCmd = &cobra.Command{
+	Use:   "forecast <project>",
+	Short: "Project completion forecast",
+	Long: `Project a completion date from recent velocity and remaining estimates.
+
+Velocity is the estimated hours of tasks completed per week over the last
+--weeks weeks. The expected date uses the average weekly velocity; the
+optimistic and pessimistic dates use one standard deviation above and
+below it. If the project has a deadline (qix project deadline), the
+forecast is checked against it.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		projectName := args[0]
+		weeks, _ := cmd.Flags().GetInt("weeks")
+
+		if weeks < 1 {
+			return invalid("--weeks must be at least 1")
+		}
+
+		store := storage.Get()
+
+		project, err := store.LoadProject(projectName)
+		if err != nil {
+			return notFound("Project not found: %s", projectName)
+		}
+
+		today := truncateDay(time.Now())
+		forecast := buildForecast(project.GetAllTasks(), today, weeks)
+
+		if jsonOutput {
+			printJSON(buildForecastReport(project, forecast, today))
+			return nil
+		}
+
+		ui.PrintHeader(fmt.Sprintf("🔮 Forecast: %s", projectName))
+
+		table := ui.NewTableBuilder("Metric", "Value").
+			Align(1, ui.AlignRight).
+			Row("Remaining estimate", ui.FormatHours(forecast.Remaining)).
+			Row("Open tasks", fmt.Sprintf("%d", forecast.OpenTasks)).
+			Row("Velocity window", fmt.Sprintf("%d weeks", weeks)).
+			Row("Avg velocity", fmt.Sprintf("%s/week", ui.FormatHours(forecast.Mean))).
+			Row("Std deviation", ui.FormatHours(forecast.StdDev))
+		if project.Deadline != "" {
+			table.Row("Deadline", ui.FormatDate(project.Deadline))
+		}
+		table.PrintSimple()
+		fmt.Println()
+
+		if forecast.Unestimated > 0 {
+			ui.PrintWarning("%d open task(s) have no estimate and are not included", forecast.Unestimated)
+			fmt.Println()
+		}
+
+		if forecast.Remaining <= 0 {
+			ui.PrintSuccess("No estimated work remaining")
+			return nil
+		}
+
+		if forecast.Mean <= 0 {
+			ui.PrintWarning("No estimated work completed in the last %d weeks; cannot forecast", weeks)
+			return nil
+		}
+
+		ui.PrintSubHeader("📅 Projected Completion")
+		bands := ui.NewTableBuilder("Scenario", "Velocity", "Date").
+			Align(1, ui.AlignRight)
+
+		scenarios := []struct {
+			name     string
+			velocity float64
+		}{
+			{"Optimistic", forecast.Mean + forecast.StdDev},
+			{"Expected", forecast.Mean},
+			{"Pessimistic", forecast.Mean - forecast.StdDev},
+		}
+
+		var deadline time.Time
+		hasDeadline := false
+		if project.Deadline != "" {
+			if d, err := time.ParseInLocation("2006-01-02", project.Deadline, time.Local); err == nil {
+				deadline = d
+				hasDeadline = true
+			}
+		}
+
+		slips := make(map[string]bool)
+		for _, scenario := range scenarios {
+			date, ok := projectCompletionDate(today, forecast.Remaining, scenario.velocity)
+			dateStr := "never at this pace"
+			if ok {
+				dateStr = ui.FormatDate(date.Format("2006-01-02"))
+			}
+			bands.Row(scenario.name, fmt.Sprintf("%s/week", ui.FormatHours(math.Max(scenario.velocity, 0))), dateStr)
+
+			if hasDeadline && (!ok || date.After(deadline)) {
+				slips[scenario.name] = true
+			}
+		}
+		bands.PrintSimple()
+		fmt.Println()
+
+		if !hasDeadline {
+			return nil
+		}
+
+		switch {
+		case slips["Optimistic"]:
+			ui.Red.Println("🚨 Even the optimistic forecast misses the deadline")
+		case slips["Expected"]:
+			ui.Red.Println("⚠️  The expected forecast slips past the deadline")
+		case slips["Pessimistic"]:
+			ui.Yellow.Println("⚠️  The deadline is at risk if velocity drops")
+		default:
+			ui.Green.Println("✨ On track to finish before the deadline")
+		}
+		return nil
+	},
+}
+
+// forecastData summarizes remaining work and recent velocity
+type forecastData struct {
+	Remaining   float64   `json:"remaining_hours"`
+	OpenTasks   int       `json:"open_tasks"`
+	Unestimated int       `json:"unestimated"`
+	Weekly      []float64 `json:"weekly_velocity"` // Oldest week first
+	Mean        float64   `json:"mean_velocity"`
+	StdDev      float64   `json:"std_dev"`
+}
+
+// forecastReport is a project's forecast with its projected completion dates
+type forecastReport struct {
+	forecastData
+	Project   string             `json:"project"`
+	Deadline  string             `json:"deadline,omitempty"`
+	Scenarios []forecastScenario `json:"scenarios"` // Empty when nothing is left or nothing was completed
+}
+
+// forecastScenario is the completion date at one velocity; the date is empty if
+// work never finishes at that pace
+type forecastScenario struct {
+	Name           string  `json:"name"`
+	Velocity       float64 `json:"velocity"`
+	Date           string  `json:"date,omitempty"`
+	MissesDeadline bool    `json:"misses_deadline"`
+}
+
+func buildForecastReport(project *models.Project, forecast forecastData, today time.Time) forecastReport {
+	report := forecastReport{
+		forecastData: forecast,
+		Project:      project.Name,
+		Deadline:     project.Deadline,
+		Scenarios:    make([]forecastScenario, 0, 3),
+	}
+	if forecast.Remaining <= 0 || forecast.Mean <= 0 {
+		return report
+	}
+
+	for _, scenario := range []forecastScenario{
+		{Name: "optimistic", Velocity: forecast.Mean + forecast.StdDev},
+		{Name: "expected", Velocity: forecast.Mean},
+		{Name: "pessimistic", Velocity: math.Max(forecast.Mean-forecast.StdDev, 0)},
+	} {
+		date, ok := projectCompletionDate(today, forecast.Remaining, scenario.Velocity)
+		if ok {
+			scenario.Date = date.Format("2006-01-02")
+		}
+		if project.Deadline != "" {
+			scenario.MissesDeadline = !ok || scenario.Date > project.Deadline
+		}
+		report.Scenarios = append(report.Scenarios, scenario)
+	}
+	return report
+}
+
+// buildForecast measures remaining estimates and weekly velocity over the trailing window
+func buildForecast(tasks []models.Task, today time.Time, weeks int) forecastData {
+	data := forecastData{Weekly: make([]float64, weeks)}
+	windowStart := today.AddDate(0, 0, 1-weeks*7)
+
+	for _, task := range tasks {
+		completedAt, done := taskCompletedAt(task)
+		if !done {
+			data.OpenTasks++
+			if task.EstimatedHours <= 0 {
+				data.Unestimated++
+			}
+			data.Remaining += task.EstimatedHours
+			continue
+		}
+
+		completed := truncateDay(completedAt)
+		if completed.Before(windowStart) || completed.After(today) {
+			continue
+		}
+		week := daysBetween(windowStart, completed) / 7
+		data.Weekly[week] += task.EstimatedHours
+	}
+
+	for _, v := range data.Weekly {
+		data.Mean += v
+	}
+	data.Mean /= float64(weeks)
+
+	for _, v := range data.Weekly {
+		data.StdDev += (v - data.Mean) * (v - data.Mean)
+	}
+	data.StdDev = math.Sqrt(data.StdDev / float64(weeks))
+
+	return data
+}
+
+// projectCompletionDate returns the day remaining work is finished at the given weekly velocity
+func projectCompletionDate(today time.Time, remaining, velocity float64) (time.Time, bool) {
+	if velocity <= 0 {
+		return time.Time{}, false
+	}
+	days := int(math.Ceil(remaining / velocity * 7))
+	return today.AddDate(0, 0, days), true
+}
+
+func init() {
+	reportForecastCmd.Flags().Int("weeks", 4, "Number of recent weeks used to measure velocity")
+	reportForecastCmd.ValidArgsFunction = projectArgCompletion
+
+	reportCmd.AddCommand(reportForecastCmd)
+}
@@ -0,0 +1,352 @@
+package cmd
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mrbooshehri/qix-go/internal/storage"
+	"github.com/mrbooshehri/qix-go/internal/ui"
+	"github.com/mrbooshehri/qix-go/pkg/qix/models"
+	"github.com/spf13/cobra"
+)
+
+var reportGanttCmd = &cobra.Command{
+	Use:   "gantt <project>",
+	Short: "Gantt chart of project tasks",
+	Long: `Render project tasks on a timeline grouped by module.
+
+Bars start when a task was created (or first logged, if earlier) and end when
+it was completed. Open tasks extend to the later of today and their estimate
+(8h per day). Bars are ordered so that dependencies come before the tasks
+that depend on them.
+
+Formats:
+  ascii     In-terminal chart (default)
+  mermaid   Mermaid gantt definition for embedding in Markdown`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		projectName := args[0]
+		format, _ := cmd.Flags().GetString("format")
+		width, _ := cmd.Flags().GetInt("width")
+
+		store := storage.Get()
+
+		project, err := store.LoadProject(projectName)
+		if err != nil {
+			return notFound("Project not found: %s", projectName)
+		}
+
+		bars := buildGanttBars(project)
+		if jsonOutput {
+			report := ganttReport{Project: project.Name, Bars: make([]ganttItem, 0, len(bars))}
+			for _, bar := range bars {
+				dependsOn := bar.Task.Dependencies
+				if dependsOn == nil {
+					dependsOn = []string{}
+				}
+				report.Bars = append(report.Bars, ganttItem{
+					taskRef:   newTaskRef(bar.Task),
+					Section:   bar.Section,
+					Start:     bar.Start.Format("2006-01-02"),
+					End:       bar.End.Format("2006-01-02"),
+					DependsOn: dependsOn,
+				})
+			}
+			printJSON(report)
+			return nil
+		}
+		if len(bars) == 0 {
+			ui.PrintEmptyState(
+				fmt.Sprintf("No tasks in project '%s'", projectName),
+				fmt.Sprintf("Create one with: qix task create %s <title>", projectName),
+			)
+			return nil
+		}
+
+		switch format {
+		case "ascii", "":
+			printGanttASCII(project.Name, bars, width)
+		case "mermaid":
+			fmt.Print(renderGanttMermaid(project.Name, bars))
+		default:
+			return invalid("Invalid format. Use: ascii, mermaid")
+		}
+		return nil
+	},
+}
+
+// ganttBar is a single task placed on the timeline
+type ganttBar struct {
+	Task    models.Task
+	Section string
+	Start   time.Time
+	End     time.Time
+}
+
+// ganttReport is a project's timeline, in dependency order within each section
+type ganttReport struct {
+	Project string      `json:"project"`
+	Bars    []ganttItem `json:"bars"`
+}
+
+// ganttItem is a ganttBar as shown by --json
+type ganttItem struct {
+	taskRef
+	Section   string   `json:"section"` // Module name, or "Project"
+	Start     string   `json:"start"`
+	End       string   `json:"end"`
+	DependsOn []string `json:"depends_on"`
+}
+
+const ganttHoursPerDay = 8.0
+
+// buildGanttBars places every project task on a timeline in dependency order
+func buildGanttBars(project *models.Project) []ganttBar {
+	today := truncateDay(time.Now())
+
+	sections := make(map[string]string)
+	sectionOrder := map[string]int{"Project": 0}
+	for _, task := range project.Tasks {
+		sections[task.ID] = "Project"
+	}
+	for i, module := range project.Modules {
+		sectionOrder[module.Name] = i + 1
+		for _, task := range module.Tasks {
+			sections[task.ID] = module.Name
+		}
+	}
+
+	bars := make([]ganttBar, 0)
+	for _, task := range orderByDependencies(project.GetAllTasks()) {
+		start := truncateDay(task.CreatedAt)
+		for _, entry := range task.TimeEntries {
+			if entryDate, err := time.ParseInLocation("2006-01-02", entry.Date, time.Local); err == nil && entryDate.Before(start) {
+				start = entryDate
+			}
+		}
+
+		var end time.Time
+		if task.Status.IsDone() {
+			end = truncateDay(task.UpdatedAt)
+		} else {
+			days := int(math.Ceil(task.EstimatedHours / ganttHoursPerDay))
+			end = start.AddDate(0, 0, days)
+			if end.Before(today) {
+				end = today
+			}
+		}
+		if end.Before(start) {
+			end = start
+		}
+
+		bars = append(bars, ganttBar{
+			Task:    task,
+			Section: sections[task.ID],
+			Start:   start,
+			End:     end,
+		})
+	}
+
+	// Group by section while keeping dependency order within each section
+	sort.SliceStable(bars, func(i, j int) bool {
+		return sectionOrder[bars[i].Section] < sectionOrder[bars[j].Section]
+	})
+
+	return bars
+}
+
+// orderByDependencies topologically sorts tasks so dependencies come first;
+// ties (and cycles) fall back to creation order
+func orderByDependencies(tasks []models.Task) []models.Task {
+	sorted := make([]models.Task, len(tasks))
+	copy(sorted, tasks)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].CreatedAt.Before(sorted[j].CreatedAt)
+	})
+
+	byID := make(map[string]models.Task, len(sorted))
+	for _, task := range sorted {
+		byID[task.ID] = task
+	}
+
+	visited := make(map[string]bool)
+	ordered := make([]models.Task, 0, len(sorted))
+
+	var visit func(task models.Task, path map[string]bool)
+	visit = func(task models.Task, path map[string]bool) {
+		if visited[task.ID] || path[task.ID] {
+			return
+		}
+		path[task.ID] = true
+		for _, depID := range task.Dependencies {
+			if dep, ok := byID[depID]; ok {
+				visit(dep, path)
+			}
+		}
+		delete(path, task.ID)
+		visited[task.ID] = true
+		ordered = append(ordered, task)
+	}
+
+	for _, task := range sorted {
+		visit(task, make(map[string]bool))
+	}
+
+	return ordered
+}
+
+func truncateDay(t time.Time) time.Time {
+	t = t.In(time.Local)
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.Local)
+}
+
+// daysBetween returns the number of days from one midnight to another; a day
+// across a daylight saving change is 23 or 25 hours long, so round the hours
+func daysBetween(from, to time.Time) int {
+	return int(math.Round(to.Sub(from).Hours() / 24))
+}
+
+func printGanttASCII(projectName string, bars []ganttBar, width int) {
+	if width < 10 {
+		width = 10
+	}
+
+	minStart := bars[0].Start
+	maxEnd := bars[0].End
+	for _, bar := range bars {
+		if bar.Start.Before(minStart) {
+			minStart = bar.Start
+		}
+		if bar.End.After(maxEnd) {
+			maxEnd = bar.End
+		}
+	}
+
+	totalDays := daysBetween(minStart, maxEnd) + 1
+	column := func(t time.Time) int {
+		day := daysBetween(minStart, t)
+		return day * width / totalDays
+	}
+
+	labelWidth := 0
+	for _, bar := range bars {
+		if l := len(ganttLabel(bar.Task)); l > labelWidth {
+			labelWidth = l
+		}
+	}
+
+	ui.PrintHeader(fmt.Sprintf("📊 Gantt: %s", projectName))
+	fmt.Printf("%s → %s (%d days)\n", ui.FormatDate(minStart.Format("2006-01-02")),
+		ui.FormatDate(maxEnd.Format("2006-01-02")), totalDays)
+
+	todayCol := column(truncateDay(time.Now()))
+
+	section := ""
+	for _, bar := range bars {
+		if bar.Section != section {
+			section = bar.Section
+			ui.PrintSubHeader("📦 " + section)
+		}
+
+		startCol := column(bar.Start)
+		endCol := column(bar.End.AddDate(0, 0, 1))
+		if endCol <= startCol {
+			endCol = startCol + 1
+		}
+		if endCol > width {
+			endCol = width
+		}
+
+		label := ganttLabel(bar.Task)
+		fmt.Printf("  %s%s ", label, strings.Repeat(" ", labelWidth-len(label)))
+
+		line := make([]string, width)
+		for i := range line {
+			switch {
+			case i >= startCol && i < endCol:
+				line[i] = ganttFill(bar.Task.Status)
+			case i == todayCol:
+				line[i] = "┊"
+			default:
+				line[i] = "·"
+			}
+		}
+
+		ui.Dim.Print(strings.Join(line[:startCol], ""))
+		ui.GetStatusColor(bar.Task.Status).Print(strings.Join(line[startCol:endCol], ""))
+		ui.Dim.Print(strings.Join(line[endCol:], ""))
+		fmt.Println()
+	}
+
+	fmt.Println()
+	ui.Green.Print("█ Done  ")
+	ui.Cyan.Print("▓ Doing  ")
+	ui.Yellow.Print("░ Todo  ")
+	ui.Red.Print("▒ Blocked  ")
+	ui.Dim.Println("┊ Today")
+}
+
+func ganttLabel(task models.Task) string {
+	title := task.Title
+	if len(title) > 30 {
+		title = title[:27] + "..."
+	}
+	return fmt.Sprintf("[%s] %s", task.ID, title)
+}
+
+func ganttFill(status models.TaskStatus) string {
+	switch {
+	case status.IsDone():
+		return "█"
+	case status == models.StatusDoing:
+		return "▓"
+	case status == models.StatusBlocked:
+		return "▒"
+	default:
+		return "░"
+	}
+}
+
+// renderGanttMermaid emits a Mermaid gantt definition for the bars
+func renderGanttMermaid(projectName string, bars []ganttBar) string {
+	chart := ui.MermaidGantt{Title: projectName}
+
+	for _, bar := range bars {
+		if len(chart.Sections) == 0 || chart.Sections[len(chart.Sections)-1].Name != bar.Section {
+			chart.Sections = append(chart.Sections, ui.MermaidGanttSection{Name: bar.Section})
+		}
+		section := &chart.Sections[len(chart.Sections)-1]
+
+		var tags []string
+		switch {
+		case bar.Task.Status.IsDone():
+			tags = append(tags, "done")
+		case bar.Task.Status == models.StatusDoing:
+			tags = append(tags, "active")
+		case bar.Task.Status == models.StatusBlocked:
+			tags = append(tags, "crit")
+		}
+
+		// Bars end on their last day, Mermaid tasks the day after
+		section.Tasks = append(section.Tasks, ui.MermaidGanttTask{
+			Name:  bar.Task.Title,
+			ID:    "t" + bar.Task.ID,
+			Tags:  tags,
+			Start: bar.Start,
+			End:   bar.End.AddDate(0, 0, 1),
+		})
+	}
+
+	return chart.String()
+}
+
+func init() {
+	reportGanttCmd.Flags().StringP("format", "f", "ascii", "Output format (ascii, mermaid)")
+	reportGanttCmd.Flags().IntP("width", "w", 60, "Chart width in columns (ascii)")
+	reportGanttCmd.ValidArgsFunction = projectArgCompletion
+
+	reportCmd.AddCommand(reportGanttCmd)
+}
@@ -0,0 +1,305 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mrbooshehri/qix-go/internal/storage"
+	"github.com/mrbooshehri/qix-go/internal/ui"
+	"github.com/mrbooshehri/qix-go/pkg/qix/models"
+	"github.com/spf13/cobra"
+)
+
+var reportGraphCmd = &cobra.Command{
+	Use:   "graph <project>",
+	Short: "Task dependency graph",
+	Long: `Export the task dependency and parent/child graph of a project.
+
+Solid edges point from a task to the tasks it depends on; dashed edges point
+from a parent task to its children. Tasks are grouped by module.
+
+Formats:
+  ascii     In-terminal adjacency view (default)
+  dot       Graphviz DOT, e.g. qix report graph web -f dot | dot -Tsvg > graph.svg
+  mermaid   Mermaid flowchart for embedding in Markdown`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		projectName := args[0]
+		format, _ := cmd.Flags().GetString("format")
+
+		store := storage.Get()
+
+		project, err := store.LoadProject(projectName)
+		if err != nil {
+			return notFound("Project not found: %s", projectName)
+		}
+
+		graph := buildTaskGraph(project)
+		if jsonOutput {
+			printJSON(graph.view(project.Name))
+			return nil
+		}
+		if len(graph.Tasks) == 0 {
+			ui.PrintEmptyState(
+				fmt.Sprintf("No tasks in project '%s'", projectName),
+				fmt.Sprintf("Create one with: qix task create %s <title>", projectName),
+			)
+			return nil
+		}
+
+		switch format {
+		case "ascii", "":
+			printTaskGraphASCII(project.Name, graph)
+		case "dot":
+			fmt.Print(renderTaskGraphDOT(project.Name, graph))
+		case "mermaid":
+			fmt.Print(renderTaskGraphMermaid(graph))
+		default:
+			return invalid("Invalid format. Use: ascii, dot, mermaid")
+		}
+		return nil
+	},
+}
+
+// taskGraph holds project tasks grouped by section along with their edges
+type taskGraph struct {
+	Sections []string
+	Tasks    map[string]models.Task
+	Section  map[string]string
+	Order    []string
+	Depends  map[string][]string
+	Children map[string][]string
+}
+
+// buildTaskGraph collects dependency and parent/child edges between project tasks
+func buildTaskGraph(project *models.Project) taskGraph {
+	graph := taskGraph{
+		Tasks:    make(map[string]models.Task),
+		Section:  make(map[string]string),
+		Depends:  make(map[string][]string),
+		Children: make(map[string][]string),
+	}
+
+	add := func(section string, tasks []models.Task) {
+		if len(tasks) == 0 {
+			return
+		}
+		graph.Sections = append(graph.Sections, section)
+		for _, task := range tasks {
+			graph.Tasks[task.ID] = task
+			graph.Section[task.ID] = section
+			graph.Order = append(graph.Order, task.ID)
+		}
+	}
+
+	add("Project", project.Tasks)
+	for _, module := range project.Modules {
+		add(module.Name, module.Tasks)
+	}
+
+	for _, id := range graph.Order {
+		task := graph.Tasks[id]
+		for _, depID := range task.Dependencies {
+			if _, ok := graph.Tasks[depID]; ok {
+				graph.Depends[id] = append(graph.Depends[id], depID)
+			}
+		}
+		if task.ParentID != "" {
+			if _, ok := graph.Tasks[task.ParentID]; ok {
+				graph.Children[task.ParentID] = append(graph.Children[task.ParentID], id)
+			}
+		}
+	}
+
+	return graph
+}
+
+// graphReport is a project's task graph as nodes and edges
+type graphReport struct {
+	Project string      `json:"project"`
+	Nodes   []graphNode `json:"nodes"`
+	Edges   []graphEdge `json:"edges"`
+}
+
+// graphNode is a task in the graph
+type graphNode struct {
+	taskRef
+	Section string `json:"section"` // Module name, or "Project"
+}
+
+// graphEdge links two tasks: From depends on To, or From is the parent of To
+type graphEdge struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+	Type string `json:"type"` // depends_on or parent_of
+}
+
+// view assembles the JSON view of the graph
+func (g taskGraph) view(projectName string) graphReport {
+	report := graphReport{
+		Project: projectName,
+		Nodes:   make([]graphNode, 0, len(g.Order)),
+		Edges:   make([]graphEdge, 0),
+	}
+	for _, id := range g.Order {
+		report.Nodes = append(report.Nodes, graphNode{taskRef: newTaskRef(g.Tasks[id]), Section: g.Section[id]})
+	}
+	for _, id := range g.Order {
+		for _, depID := range g.Depends[id] {
+			report.Edges = append(report.Edges, graphEdge{From: id, To: depID, Type: "depends_on"})
+		}
+		for _, childID := range g.Children[id] {
+			report.Edges = append(report.Edges, graphEdge{From: id, To: childID, Type: "parent_of"})
+		}
+	}
+	return report
+}
+
+// sectionTasks returns task IDs in a section in their stored order
+func (g taskGraph) sectionTasks(section string) []string {
+	ids := make([]string, 0)
+	for _, id := range g.Order {
+		if g.Section[id] == section {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+func printTaskGraphASCII(projectName string, graph taskGraph) {
+	ui.PrintHeader(fmt.Sprintf("🕸️  Dependency Graph: %s", projectName))
+
+	dependents := make(map[string][]string)
+	for id, deps := range graph.Depends {
+		for _, depID := range deps {
+			dependents[depID] = append(dependents[depID], id)
+		}
+	}
+
+	describe := func(id string) string {
+		task := graph.Tasks[id]
+		return fmt.Sprintf("%s [%s] %s", ui.GetStatusIcon(task.Status), id, task.Title)
+	}
+
+	for _, section := range graph.Sections {
+		ui.PrintSubHeader("📦 " + section)
+
+		for _, id := range graph.sectionTasks(section) {
+			task := graph.Tasks[id]
+			ui.GetStatusColor(task.Status).Printf("  %s\n", describe(id))
+
+			for _, depID := range graph.Depends[id] {
+				ui.Dim.Print("    ├─ depends on  ")
+				fmt.Println(describe(depID))
+			}
+			for _, depID := range dependents[id] {
+				ui.Dim.Print("    ├─ blocks      ")
+				fmt.Println(describe(depID))
+			}
+			if task.ParentID != "" {
+				if _, ok := graph.Tasks[task.ParentID]; ok {
+					ui.Dim.Print("    ├─ child of    ")
+					fmt.Println(describe(task.ParentID))
+				}
+			}
+			for _, childID := range graph.Children[id] {
+				ui.Dim.Print("    ├─ parent of   ")
+				fmt.Println(describe(childID))
+			}
+		}
+	}
+	fmt.Println()
+}
+
+// renderTaskGraphDOT emits a Graphviz digraph with one cluster per module
+func renderTaskGraphDOT(projectName string, graph taskGraph) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "digraph %s {\n", dotQuote(projectName))
+	b.WriteString("    rankdir=LR;\n")
+	b.WriteString("    node [shape=box, style=\"rounded,filled\", fontname=\"Helvetica\"];\n")
+
+	for i, section := range graph.Sections {
+		fmt.Fprintf(&b, "\n    subgraph cluster_%d {\n", i)
+		fmt.Fprintf(&b, "        label=%s;\n", dotQuote(section))
+		for _, id := range graph.sectionTasks(section) {
+			task := graph.Tasks[id]
+			fmt.Fprintf(&b, "        t%s [label=%s, fillcolor=%s];\n",
+				id,
+				dotQuote(fmt.Sprintf("[%s] %s", id, task.Title)),
+				dotQuote(graphStatusColor(task.Status)))
+		}
+		b.WriteString("    }\n")
+	}
+
+	b.WriteString("\n")
+	for _, id := range graph.Order {
+		for _, depID := range graph.Depends[id] {
+			fmt.Fprintf(&b, "    t%s -> t%s;\n", id, depID)
+		}
+		for _, childID := range graph.Children[id] {
+			fmt.Fprintf(&b, "    t%s -> t%s [style=dashed, arrowhead=empty];\n", id, childID)
+		}
+	}
+
+	b.WriteString("}\n")
+	return b.String()
+}
+
+// renderTaskGraphMermaid emits a Mermaid flowchart with one subgraph per module
+func renderTaskGraphMermaid(graph taskGraph) string {
+	chart := ui.MermaidFlowchart{Direction: "LR"}
+
+	for i, section := range graph.Sections {
+		subgraph := ui.MermaidSubgraph{ID: fmt.Sprintf("s%d", i), Label: section}
+		for _, id := range graph.sectionTasks(section) {
+			task := graph.Tasks[id]
+			subgraph.Nodes = append(subgraph.Nodes, ui.MermaidNode{
+				ID:    "t" + id,
+				Label: fmt.Sprintf("[%s] %s", id, task.Title),
+				Class: string(task.Status),
+			})
+		}
+		chart.Subgraphs = append(chart.Subgraphs, subgraph)
+	}
+
+	for _, id := range graph.Order {
+		for _, depID := range graph.Depends[id] {
+			chart.Edges = append(chart.Edges, ui.MermaidEdge{From: "t" + id, To: "t" + depID})
+		}
+		for _, childID := range graph.Children[id] {
+			chart.Edges = append(chart.Edges, ui.MermaidEdge{From: "t" + id, To: "t" + childID, Dotted: true})
+		}
+	}
+
+	for _, def := range models.Statuses() {
+		chart.Classes = append(chart.Classes, ui.MermaidClass{Name: string(def.Name), Style: "fill:" + graphStatusColor(def.Name)})
+	}
+
+	return chart.String()
+}
+
+func graphStatusColor(status models.TaskStatus) string {
+	switch {
+	case status.IsDone():
+		return "#c8e6c9"
+	case status == models.StatusDoing:
+		return "#b3e5fc"
+	case status == models.StatusBlocked:
+		return "#ffcdd2"
+	default:
+		return "#fff9c4"
+	}
+}
+
+// dotQuote returns s as a quoted DOT string
+func dotQuote(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", " ").Replace(s) + `"`
+}
+
+func init() {
+	reportGraphCmd.Flags().StringP("format", "f", "ascii", "Output format (ascii, dot, mermaid)")
+	reportGraphCmd.ValidArgsFunction = projectArgCompletion
+
+	reportCmd.AddCommand(reportGraphCmd)
+}
@@ -0,0 +1,350 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mrbooshehri/qix-go/internal/storage"
+	"github.com/mrbooshehri/qix-go/internal/ui"
+	"github.com/mrbooshehri/qix-go/pkg/qix/models"
+	"github.com/spf13/cobra"
+)
+
+var reportHabitsCmd = &cobra.Command{
+	Use:   "habits [project]",
+	Short: "Recurring task compliance and streaks",
+	Long: `Show how reliably recurring tasks are completed.
+
+Each occurrence due in the last --days days (or since the task was created)
+counts as kept if the task was completed on or before its due date and after
+the previous occurrence. Streaks count consecutive kept occurrences; the
+current streak ends at the most recent occurrence. Occurrences due today are
+not counted as missed until the day is over.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		days, _ := cmd.Flags().GetInt("days")
+		if days < 1 {
+			return invalid("--days must be at least 1")
+		}
+
+		projectName := ""
+		if len(args) > 0 {
+			projectName = args[0]
+		}
+
+		store := storage.Get()
+
+		projects, err := loadReportProjects(store, projectName)
+		if err != nil {
+			return fail("%v", err)
+		}
+
+		today := truncateDay(time.Now())
+		since := today.AddDate(0, 0, -(days - 1))
+
+		habits := make([]habitStats, 0)
+		for _, project := range projects {
+			for _, task := range project.GetAllTasks() {
+				if !task.IsRecurring() {
+					continue
+				}
+				habits = append(habits, computeHabitStats(project.Name, task, since, today))
+			}
+		}
+
+		sort.SliceStable(habits, func(i, j int) bool {
+			return habits[i].rate() < habits[j].rate()
+		})
+
+		if jsonOutput {
+			printJSON(buildHabitsReport(projectName, habits, since, today))
+			return nil
+		}
+
+		scope := "All Projects"
+		if projectName != "" {
+			scope = projectName
+		}
+		ui.PrintHeader(fmt.Sprintf("🔁 Habits: %s", scope))
+		fmt.Printf("Period: %s to %s\n\n", ui.FormatDate(since.Format("2006-01-02")), ui.FormatDate(today.Format("2006-01-02")))
+
+		if len(habits) == 0 {
+			ui.PrintEmptyState("No recurring tasks", "Make a task recurring with: qix task recur <project> <task_id> <pattern>")
+			return nil
+		}
+
+		headers := []string{"Task", "Pattern", "Kept", "Rate", "Streak", "Best", "Next Due"}
+		if projectName == "" {
+			headers = append([]string{"Project"}, headers...)
+		}
+		table := ui.NewTableBuilder(headers...)
+		offset := len(headers) - 7
+		for i := offset + 2; i < offset+6; i++ {
+			table.Align(i, ui.AlignRight)
+		}
+
+		kept, due := 0, 0
+		for _, habit := range habits {
+			kept += habit.Kept
+			due += habit.Due
+
+			rate := "n/a"
+			if habit.Due > 0 {
+				rate = ui.FormatPercentage(habit.rate())
+			}
+			row := []string{
+				ganttLabel(habit.Task),
+				habit.Pattern,
+				fmt.Sprintf("%d/%d", habit.Kept, habit.Due),
+				rate,
+				fmt.Sprintf("%d", habit.Current),
+				fmt.Sprintf("%d", habit.Longest),
+				habit.Task.Recurrence.NextDue,
+			}
+			if projectName == "" {
+				row = append([]string{habit.Project}, row...)
+			}
+			table.Row(row...)
+		}
+		table.PrintSimple()
+		fmt.Println()
+
+		// Missed occurrences, most recent first
+		todayStr := today.Format("2006-01-02")
+		for _, habit := range habits {
+			if len(habit.Missed) == 0 && habit.Task.Recurrence.NextDue >= todayStr {
+				continue
+			}
+
+			ui.Yellow.Printf("[%s] %s\n", habit.Task.ID, habit.Task.Title)
+			if habit.Task.Recurrence.NextDue < todayStr {
+				ui.Red.Printf("  ⚠️  Overdue since %s\n", ui.FormatDate(habit.Task.Recurrence.NextDue))
+			}
+			if len(habit.Missed) > 0 {
+				missed := make([]string, 0, 5)
+				for i := len(habit.Missed) - 1; i >= 0 && len(missed) < 5; i-- {
+					missed = append(missed, ui.FormatDate(habit.Missed[i]))
+				}
+				more := ""
+				if len(habit.Missed) > len(missed) {
+					more = fmt.Sprintf(" (+%d more)", len(habit.Missed)-len(missed))
+				}
+				ui.Dim.Printf("  Missed: %s%s\n", strings.Join(missed, ", "), more)
+			}
+		}
+
+		if due > 0 {
+			fmt.Println()
+			overall := float64(kept) / float64(due) * 100
+			fmt.Print("Overall compliance: ")
+			ui.PrintProgressBar(overall, 30)
+			fmt.Printf(" %.1f%% (%d/%d)\n", overall, kept, due)
+		}
+		return nil
+	},
+}
+
+// habitStats summarizes how reliably one recurring task was completed
+type habitStats struct {
+	Project string
+	Task    models.Task
+	Pattern string
+	Due     int
+	Kept    int
+	Current int
+	Longest int
+	Missed  []string
+}
+
+// habitsReport is the habit compliance of recurring tasks, least reliable first
+type habitsReport struct {
+	Project    string      `json:"project,omitempty"` // Empty for all projects
+	From       string      `json:"from"`
+	To         string      `json:"to"`
+	Habits     []habitView `json:"habits"`
+	Kept       int         `json:"kept"`
+	Due        int         `json:"due"`
+	Compliance *float64    `json:"compliance"` // null when nothing was due
+}
+
+// habitView is a habitStats as shown by --json
+type habitView struct {
+	taskRef
+	Project       string   `json:"project"`
+	Pattern       string   `json:"pattern"`
+	Due           int      `json:"due"`
+	Kept          int      `json:"kept"`
+	CurrentStreak int      `json:"current_streak"`
+	LongestStreak int      `json:"longest_streak"`
+	Missed        []string `json:"missed"` // Oldest first
+	NextDue       string   `json:"next_due"`
+}
+
+func buildHabitsReport(projectName string, habits []habitStats, since, today time.Time) habitsReport {
+	report := habitsReport{
+		Project: projectName,
+		From:    since.Format("2006-01-02"),
+		To:      today.Format("2006-01-02"),
+		Habits:  make([]habitView, 0, len(habits)),
+	}
+	for _, habit := range habits {
+		missed := habit.Missed
+		if missed == nil {
+			missed = []string{}
+		}
+		report.Habits = append(report.Habits, habitView{
+			taskRef:       newTaskRef(habit.Task),
+			Project:       habit.Project,
+			Pattern:       habit.Pattern,
+			Due:           habit.Due,
+			Kept:          habit.Kept,
+			CurrentStreak: habit.Current,
+			LongestStreak: habit.Longest,
+			Missed:        missed,
+			NextDue:       habit.Task.Recurrence.NextDue,
+		})
+		report.Kept += habit.Kept
+		report.Due += habit.Due
+	}
+	if report.Due > 0 {
+		compliance := float64(report.Kept) / float64(report.Due) * 100
+		report.Compliance = &compliance
+	}
+	return report
+}
+
+// rate returns the percentage of due occurrences that were kept
+func (h habitStats) rate() float64 {
+	if h.Due == 0 {
+		return 100
+	}
+	return float64(h.Kept) / float64(h.Due) * 100
+}
+
+// computeHabitStats checks each occurrence due between since and today against the task's completions
+func computeHabitStats(projectName string, task models.Task, since, today time.Time) habitStats {
+	r := task.Recurrence
+	stats := habitStats{Project: projectName, Task: task, Pattern: string(r.Type)}
+	if r.Value != "" {
+		stats.Pattern += ":" + r.Value
+	}
+
+	completions := r.Completions
+	if len(completions) == 0 && r.LastCompleted != "" {
+		completions = []string{r.LastCompleted}
+	}
+
+	from := since
+	if created := truncateDay(task.CreatedAt); created.After(from) {
+		from = created
+	}
+
+	todayStr := today.Format("2006-01-02")
+	previous := ""
+	for _, occurrence := range recurrenceDueDates(r, from, today) {
+		dueStr := occurrence.Format("2006-01-02")
+
+		kept := false
+		for _, done := range completions {
+			if done > previous && done <= dueStr {
+				kept = true
+				break
+			}
+		}
+		previous = dueStr
+
+		// Today's occurrence is still open until it is completed
+		if dueStr == todayStr && !kept {
+			break
+		}
+
+		stats.Due++
+		if kept {
+			stats.Kept++
+			stats.Current++
+			if stats.Current > stats.Longest {
+				stats.Longest = stats.Current
+			}
+		} else {
+			stats.Current = 0
+			stats.Missed = append(stats.Missed, dueStr)
+		}
+	}
+
+	return stats
+}
+
+// recurrenceDueDates lists the days between from and to (inclusive) on which a recurring task is due
+func recurrenceDueDates(r *models.Recurrence, from, to time.Time) []time.Time {
+	dates := make([]time.Time, 0)
+
+	switch r.Type {
+	case models.RecurDaily:
+		for day := from; !day.After(to); day = day.AddDate(0, 0, 1) {
+			dates = append(dates, day)
+		}
+
+	case models.RecurWeekly:
+		weekday, ok := parseWeekday(r.Value)
+		if !ok {
+			return dates
+		}
+		day := from.AddDate(0, 0, (int(weekday)-int(from.Weekday())+7)%7)
+		for ; !day.After(to); day = day.AddDate(0, 0, 7) {
+			dates = append(dates, day)
+		}
+
+	case models.RecurMonthly:
+		dayOfMonth, err := strconv.Atoi(r.Value)
+		if err != nil {
+			return dates
+		}
+		for month := time.Date(from.Year(), from.Month(), 1, 0, 0, 0, 0, time.Local); !month.After(to); month = month.AddDate(0, 1, 0) {
+			lastDay := month.AddDate(0, 1, -1).Day()
+			day := month.AddDate(0, 0, min(dayOfMonth, lastDay)-1)
+			if !day.Before(from) && !day.After(to) {
+				dates = append(dates, day)
+			}
+		}
+
+	case models.RecurInterval:
+		interval, err := strconv.Atoi(r.Value)
+		if err != nil || interval < 1 {
+			return dates
+		}
+		// Occurrences are aligned to the next due date
+		anchor, err := time.ParseInLocation("2006-01-02", r.NextDue, time.Local)
+		if err != nil {
+			return dates
+		}
+		offset := daysBetween(from, anchor) % interval
+		if offset < 0 {
+			offset += interval
+		}
+		for day := from.AddDate(0, 0, offset); !day.After(to); day = day.AddDate(0, 0, interval) {
+			dates = append(dates, day)
+		}
+	}
+
+	return dates
+}
+
+// parseWeekday parses a weekday name such as "friday"
+func parseWeekday(name string) (time.Weekday, bool) {
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		if strings.EqualFold(day.String(), name) {
+			return day, true
+		}
+	}
+	return time.Sunday, false
+}
+
+func init() {
+	reportHabitsCmd.Flags().Int("days", 90, "Number of days to look back")
+	reportHabitsCmd.ValidArgsFunction = projectArgCompletion
+
+	reportCmd.AddCommand(reportHabitsCmd)
+}
@@ -0,0 +1,165 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mrbooshehri/qix-go/internal/storage"
+	"github.com/mrbooshehri/qix-go/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var reportHeatmapCmd = &cobra.Command{
+	Use:   "heatmap [project]",
+	Short: "Calendar heatmap of logged hours",
+	Long: `Render a calendar heatmap of daily logged hours, one column per week and one
+row per weekday, to show consistency and gaps at a glance. Defaults to the
+last 26 weeks across all projects.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		weeks, _ := cmd.Flags().GetInt("weeks")
+		if weeks < 1 {
+			return invalid("--weeks must be at least 1")
+		}
+
+		projectName := ""
+		if len(args) > 0 {
+			projectName = args[0]
+		}
+
+		store := storage.Get()
+
+		projects, err := loadReportProjects(store, projectName)
+		if err != nil {
+			return fail("%v", err)
+		}
+
+		today := truncateDay(time.Now())
+		start := ui.StartOfWeek(today).AddDate(0, 0, -7*(weeks-1))
+		summary := summarizePeriod(projects, start, today)
+		activeDays, longest, current := heatmapStreaks(summary, today)
+
+		if jsonOutput {
+			report := heatmapReport{
+				Project:       projectName,
+				From:          summary.startDate(),
+				To:            summary.endDate(),
+				Daily:         make([]dateHours, 0, summary.days()),
+				TotalHours:    summary.TotalHours,
+				ActiveDays:    activeDays,
+				LongestStreak: longest,
+				CurrentStreak: current,
+			}
+			for day := start; !day.After(today); day = day.AddDate(0, 0, 1) {
+				date := day.Format("2006-01-02")
+				report.Daily = append(report.Daily, dateHours{Date: date, Hours: summary.HoursByDay[date]})
+			}
+			printJSON(report)
+			return nil
+		}
+
+		// Rows are weekdays from the one weeks start on, columns are weeks
+		data := make([][]float64, 7)
+		for i := range data {
+			data[i] = make([]float64, weeks)
+		}
+		for day := start; !day.After(today); day = day.AddDate(0, 0, 1) {
+			offset := daysBetween(start, day)
+			data[offset%7][offset/7] = summary.HoursByDay[day.Format("2006-01-02")]
+		}
+
+		scope := "All Projects"
+		if projectName != "" {
+			scope = projectName
+		}
+		ui.PrintHeader(fmt.Sprintf("🔥 Activity Heatmap: %s", scope))
+		fmt.Printf("Period: %s to %s\n\n", ui.FormatDate(summary.startDate()), ui.FormatDate(summary.endDate()))
+
+		ui.Dim.Printf("     %s\n", heatmapMonthLabels(start, weeks))
+		labels := make([]string, 0, 7)
+		for _, day := range ui.Weekdays() {
+			labels = append(labels, day.String()[:3])
+		}
+		ui.PrintHeatmap(data, labels)
+
+		fmt.Println()
+		table := ui.NewTableBuilder("Metric", "Value").
+			Align(1, ui.AlignRight).
+			Row("Total logged", ui.FormatHours(summary.TotalHours)).
+			Row("Active days", fmt.Sprintf("%d / %d", activeDays, summary.days()))
+		if activeDays > 0 {
+			table.Row("Avg per active day", ui.FormatHours(summary.TotalHours/float64(activeDays)))
+		}
+		table.Row("Longest streak", fmt.Sprintf("%d days", longest)).
+			Row("Current streak", fmt.Sprintf("%d days", current)).
+			PrintSimple()
+		return nil
+	},
+}
+
+// heatmapReport is the daily logged hours behind the heatmap, with streaks in days
+type heatmapReport struct {
+	Project       string      `json:"project,omitempty"` // Empty for all projects
+	From          string      `json:"from"`
+	To            string      `json:"to"`
+	Daily         []dateHours `json:"daily"`
+	TotalHours    float64     `json:"total_hours"`
+	ActiveDays    int         `json:"active_days"`
+	LongestStreak int         `json:"longest_streak"`
+	CurrentStreak int         `json:"current_streak"`
+}
+
+// heatmapMonthLabels places abbreviated month names above the week where each month begins
+func heatmapMonthLabels(start time.Time, weeks int) string {
+	labels := []byte(strings.Repeat(" ", weeks+3))
+	lastMonth := time.Month(0)
+	for week := 0; week < weeks; week++ {
+		weekStart := start.AddDate(0, 0, week*7)
+		month := weekStart.AddDate(0, 0, 6).Month()
+		if month == lastMonth {
+			continue
+		}
+		lastMonth = month
+		if week > 0 && labels[week-1] != ' ' {
+			continue
+		}
+		copy(labels[week:], month.String()[:3])
+	}
+	return strings.TrimRight(string(labels), " ")
+}
+
+// heatmapStreaks returns the number of active days and the longest and current runs of them
+func heatmapStreaks(summary periodSummary, today time.Time) (active, longest, current int) {
+	run := 0
+	for day := summary.Start; !day.After(summary.End); day = day.AddDate(0, 0, 1) {
+		if summary.HoursByDay[day.Format("2006-01-02")] > 0 {
+			active++
+			run++
+			if run > longest {
+				longest = run
+			}
+		} else {
+			run = 0
+		}
+	}
+
+	// The current streak may still be extended today, so start from yesterday if today is empty
+	day := today
+	if summary.HoursByDay[day.Format("2006-01-02")] == 0 {
+		day = day.AddDate(0, 0, -1)
+	}
+	for !day.Before(summary.Start) && summary.HoursByDay[day.Format("2006-01-02")] > 0 {
+		current++
+		day = day.AddDate(0, 0, -1)
+	}
+
+	return active, longest, current
+}
+
+func init() {
+	reportHeatmapCmd.Flags().Int("weeks", 26, "Number of weeks to show")
+	reportHeatmapCmd.ValidArgsFunction = projectArgCompletion
+
+	reportCmd.AddCommand(reportHeatmapCmd)
+}
@@ -0,0 +1,315 @@
+package cmd
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/mrbooshehri/qix-go/internal/storage"
+	"github.com/mrbooshehri/qix-go/internal/ui"
+	"github.com/mrbooshehri/qix-go/pkg/qix/models"
+	"github.com/spf13/cobra"
+)
+
+var reportMonthlyCmd = &cobra.Command{
+	Use:   "monthly [project] [YYYY-MM]",
+	Short: "Monthly status report",
+	Long: `Summarize a month of work with per-week rollups, the most time-consuming
+tasks, estimation accuracy for tasks completed in the month, and a
+month-over-month comparison. Defaults to the current month across all projects.`,
+	Args: cobra.MaximumNArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		projectName := ""
+		monthArg := ""
+
+		switch len(args) {
+		case 1:
+			if _, err := parseMonthArg(args[0]); err == nil {
+				monthArg = args[0]
+			} else {
+				projectName = args[0]
+			}
+		case 2:
+			projectName = args[0]
+			monthArg = args[1]
+		}
+
+		now := time.Now()
+		monthStart := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.Local)
+		if monthArg != "" {
+			parsed, err := parseMonthArg(monthArg)
+			if err != nil {
+				return invalid("Invalid month format. Use: YYYY-MM")
+			}
+			monthStart = parsed
+		}
+		monthEnd := monthStart.AddDate(0, 1, -1)
+		prevStart := monthStart.AddDate(0, -1, 0)
+
+		store := storage.Get()
+
+		projects, err := loadReportProjects(store, projectName)
+		if err != nil {
+			return fail("%v", err)
+		}
+
+		current := summarizePeriod(projects, monthStart, monthEnd)
+		previous := summarizePeriod(projects, prevStart, monthStart.AddDate(0, 0, -1))
+
+		weeks := monthWeeks(projects, monthStart, monthEnd)
+
+		if jsonOutput {
+			report := monthlyReport{
+				Project:  projectName,
+				Month:    monthStart.Format("2006-01"),
+				Current:  current.view(projects),
+				Previous: previous.view(projects),
+				Weeks:    weeks,
+			}
+			if accuracy, ok := completedAccuracy(current.Completed); ok {
+				report.EstimationAccuracy = &accuracy
+			}
+			if accuracy, ok := completedAccuracy(previous.Completed); ok {
+				report.PreviousAccuracy = &accuracy
+			}
+			printJSON(report)
+			return nil
+		}
+
+		scope := "All Projects"
+		if projectName != "" {
+			scope = projectName
+		}
+
+		ui.PrintHeader(fmt.Sprintf("🗓️  Monthly Report: %s (%s)", scope, monthStart.Format("January 2006")))
+		fmt.Printf("Period: %s to %s\n", ui.FormatDate(current.startDate()), ui.FormatDate(current.endDate()))
+
+		// Per-week rollups
+		ui.PrintSubHeader("📆 Weekly Rollup")
+		printWeeklyRollup(weeks)
+		fmt.Println()
+		ui.BoldGreen.Printf("Total: %s\n", ui.FormatHours(current.TotalHours))
+		fmt.Println()
+
+		// Top time-consuming tasks
+		ui.PrintSubHeader("⏱️  Top Time-Consuming Tasks")
+		printTopTasks(current.Worked, 10)
+		fmt.Println()
+
+		// Estimation accuracy
+		ui.PrintSubHeader("🎯 Estimation Accuracy")
+		currentAccuracy, ok := printEstimationAccuracy(current.Completed)
+		fmt.Println()
+
+		// Month-over-month
+		ui.PrintSubHeader("📊 Month-over-Month")
+		table := periodComparisonTable(
+			monthStart.Format("Jan 2006"),
+			prevStart.Format("Jan 2006"),
+			current, previous)
+
+		prevAccuracy, prevOK := completedAccuracy(previous.Completed)
+		if ok || prevOK {
+			table.Row("Estimation accuracy",
+				formatOptionalPercentage(currentAccuracy, ok),
+				formatOptionalPercentage(prevAccuracy, prevOK),
+				formatAccuracyDelta(currentAccuracy, ok, prevAccuracy, prevOK))
+		}
+
+		table.Row("Avg hours/day",
+			ui.FormatHours(current.hoursPerDay()),
+			ui.FormatHours(previous.hoursPerDay()),
+			formatDelta(current.hoursPerDay()-previous.hoursPerDay(), "%+.2fh"))
+
+		table.PrintSimple()
+		return nil
+	},
+}
+
+// parseMonthArg parses YYYY-MM into the first day of that month
+func parseMonthArg(value string) (time.Time, error) {
+	return time.ParseInLocation("2006-01", value, time.Local)
+}
+
+// monthlyReport is the monthly status report, with the previous month for comparison
+type monthlyReport struct {
+	Project            string       `json:"project,omitempty"` // Empty for all projects
+	Month              string       `json:"month"`
+	Current            periodView   `json:"current"`
+	Previous           periodView   `json:"previous"`
+	Weeks              []weekRollup `json:"weeks"`
+	EstimationAccuracy *float64     `json:"estimation_accuracy"` // null without completed, estimated tasks
+	PreviousAccuracy   *float64     `json:"previous_accuracy"`
+}
+
+// weekRollup is the activity in the part of a week that falls inside a month
+type weekRollup struct {
+	Week       string  `json:"week"`
+	From       string  `json:"from"`
+	To         string  `json:"to"`
+	TotalHours float64 `json:"total_hours"`
+	Completed  int     `json:"completed"`
+	Started    int     `json:"started"`
+}
+
+// monthWeeks rolls up the month week by week, clipping the first and last weeks to the month
+func monthWeeks(projects []*models.Project, monthStart, monthEnd time.Time) []weekRollup {
+	weeks := make([]weekRollup, 0, 6)
+	for weekStart := ui.StartOfWeek(monthStart); !weekStart.After(monthEnd); weekStart = weekStart.AddDate(0, 0, 7) {
+		start := weekStart
+		if start.Before(monthStart) {
+			start = monthStart
+		}
+		end := weekStart.AddDate(0, 0, 6)
+		if end.After(monthEnd) {
+			end = monthEnd
+		}
+
+		week := summarizePeriod(projects, start, end)
+		year, number := ui.WeekNumber(weekStart)
+
+		weeks = append(weeks, weekRollup{
+			Week:       fmt.Sprintf("%d-W%02d", year, number),
+			From:       week.startDate(),
+			To:         week.endDate(),
+			TotalHours: week.TotalHours,
+			Completed:  len(week.Completed),
+			Started:    len(week.Started),
+		})
+	}
+	return weeks
+}
+
+func printWeeklyRollup(weeks []weekRollup) {
+	table := ui.NewTableBuilder("Week", "Dates", "Hours", "Completed", "Started").
+		Align(2, ui.AlignRight).
+		Align(3, ui.AlignRight).
+		Align(4, ui.AlignRight)
+
+	for _, week := range weeks {
+		start, _ := time.Parse("2006-01-02", week.From)
+		end, _ := time.Parse("2006-01-02", week.To)
+		table.Row(
+			week.Week,
+			fmt.Sprintf("%s - %s", start.Format("Jan 02"), end.Format("Jan 02")),
+			ui.FormatHours(week.TotalHours),
+			fmt.Sprintf("%d", week.Completed),
+			fmt.Sprintf("%d", week.Started),
+		)
+	}
+
+	table.PrintSimple()
+}
+
+func printTopTasks(worked []taskHours, limit int) {
+	if len(worked) == 0 {
+		ui.Dim.Println("  No time logged in this period")
+		return
+	}
+
+	sort.Slice(worked, func(i, j int) bool {
+		return worked[i].Hours > worked[j].Hours
+	})
+
+	table := ui.NewTableBuilder("Task", "Project", "Hours").
+		Align(2, ui.AlignRight)
+
+	for i, item := range worked {
+		if i >= limit {
+			break
+		}
+		table.Row(fmt.Sprintf("[%s] %s", item.Task.ID, item.Task.Title), item.Project, ui.FormatHours(item.Hours))
+	}
+
+	table.PrintSimple()
+}
+
+// printEstimationAccuracy prints per-task variance for completed tasks and returns overall accuracy
+func printEstimationAccuracy(completed []projectTask) (float64, bool) {
+	table := ui.NewTableBuilder("Task", "Estimated", "Actual", "Variance").
+		Align(1, ui.AlignRight).
+		Align(2, ui.AlignRight).
+		Align(3, ui.AlignRight)
+
+	rows := 0
+	for _, item := range completed {
+		if item.Task.EstimatedHours <= 0 {
+			continue
+		}
+		rows++
+		table.Row(
+			fmt.Sprintf("[%s] %s", item.Task.ID, item.Task.Title),
+			ui.FormatHours(item.Task.EstimatedHours),
+			ui.FormatHours(item.Task.CalculateActualHours()),
+			fmt.Sprintf("%+.1f%%", item.Task.GetVariancePercentage()),
+		)
+	}
+
+	accuracy, ok := completedAccuracy(completed)
+	if !ok {
+		ui.Dim.Println("  No completed tasks with estimates in this period")
+		return 0, false
+	}
+
+	table.PrintSimple()
+	fmt.Println()
+
+	fmt.Printf("Tasks with estimates: %d\n", rows)
+	fmt.Print("Accuracy:             ")
+	if accuracy >= 80 {
+		ui.Green.Printf("%.1f%%\n", accuracy)
+	} else if accuracy >= 60 {
+		ui.Yellow.Printf("%.1f%%\n", accuracy)
+	} else {
+		ui.Red.Printf("%.1f%%\n", accuracy)
+	}
+
+	return accuracy, true
+}
+
+// completedAccuracy returns estimation accuracy over completed tasks that carry an estimate
+func completedAccuracy(completed []projectTask) (float64, bool) {
+	estimated := 0.0
+	actual := 0.0
+	for _, item := range completed {
+		if item.Task.EstimatedHours > 0 {
+			estimated += item.Task.EstimatedHours
+			actual += item.Task.CalculateActualHours()
+		}
+	}
+
+	if estimated == 0 {
+		return 0, false
+	}
+	return estimationAccuracy(estimated, actual), true
+}
+
+// estimationAccuracy scores how close actual hours landed to the estimate (0-100)
+func estimationAccuracy(estimated, actual float64) float64 {
+	variance := ((actual - estimated) / estimated) * 100
+	accuracy := 100 - math.Abs(variance)
+	if accuracy < 0 {
+		accuracy = 0
+	}
+	return accuracy
+}
+
+func formatOptionalPercentage(value float64, ok bool) string {
+	if !ok {
+		return "n/a"
+	}
+	return ui.FormatPercentage(value)
+}
+
+func formatAccuracyDelta(current float64, currentOK bool, previous float64, previousOK bool) string {
+	if !currentOK || !previousOK {
+		return "n/a"
+	}
+	return formatDelta(current-previous, "%+.1f%%")
+}
+
+func init() {
+	reportMonthlyCmd.ValidArgsFunction = projectArgCompletion
+	reportCmd.AddCommand(reportMonthlyCmd)
+}
@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mrbooshehri/qix-go/pkg/qix/models"
+)
+
+func TestMonthlyReportAcrossDST(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skipf("time zone data unavailable: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		month   time.Time
+		days    int
+		lastDay string
+	}{
+		{"clocks forward", time.Date(2026, time.March, 1, 0, 0, 0, 0, berlin), 31, "2026-03-31"},
+		{"clocks back", time.Date(2026, time.October, 1, 0, 0, 0, 0, berlin), 31, "2026-10-31"},
+		{"no change", time.Date(2026, time.June, 1, 0, 0, 0, 0, berlin), 30, "2026-06-30"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			monthEnd := tt.month.AddDate(0, 1, -1)
+			project := &models.Project{
+				Name: "demo",
+				Tasks: []models.Task{{
+					ID: "T1",
+					TimeEntries: []models.TimeEntry{
+						{Date: tt.month.Format("2006-01-02"), Hours: 2},
+						{Date: tt.lastDay, Hours: 4},
+					},
+				}},
+			}
+			projects := []*models.Project{project}
+
+			summary := summarizePeriod(projects, tt.month, monthEnd)
+			if got := summary.days(); got != tt.days {
+				t.Errorf("days() = %d, want %d", got, tt.days)
+			}
+			if got, want := summary.hoursPerDay(), 6/float64(tt.days); got != want {
+				t.Errorf("hoursPerDay() = %v, want %v", got, want)
+			}
+
+			daily := summary.view(projects).Daily
+			if len(daily) != tt.days {
+				t.Fatalf("daily rollup has %d days, want %d", len(daily), tt.days)
+			}
+			if last := daily[len(daily)-1]; last.Date != tt.lastDay || last.Hours != 4 {
+				t.Errorf("last day = %+v, want %s with 4h", last, tt.lastDay)
+			}
+
+			weeks := monthWeeks(projects, tt.month, monthEnd)
+			if last := weeks[len(weeks)-1]; last.To != tt.lastDay {
+				t.Errorf("last week ends %s, want %s", last.To, tt.lastDay)
+			}
+			total := 0.0
+			for _, week := range weeks {
+				total += week.TotalHours
+			}
+			if total != 6 {
+				t.Errorf("weekly rollup totals %vh, want 6h", total)
+			}
+		})
+	}
+}
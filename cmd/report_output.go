@@ -0,0 +1,136 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/mrbooshehri/qix-go/internal/clipboard"
+	"github.com/mrbooshehri/qix-go/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var (
+	reportOutputFile    *os.File
+	reportCopyBuffer    *bytes.Buffer
+	reportCapture       *os.File
+	reportCaptureDone   chan struct{}
+	reportOutputRestore func()
+)
+
+// reportFlag returns the value of a report persistent flag, ignoring same-named local flags
+func reportFlag(cmd *cobra.Command, name string) string {
+	flag := cmd.Flags().Lookup(name)
+	if flag == nil || flag != reportCmd.PersistentFlags().Lookup(name) {
+		return ""
+	}
+	return flag.Value.String()
+}
+
+// openReportOutput captures report output for --output and --copy, if given
+func openReportOutput(cmd *cobra.Command) error {
+	output := reportFlag(cmd, "output")
+	copyOutput := reportFlag(cmd, "copy") == "true"
+	if output == "" && !copyOutput {
+		return nil
+	}
+
+	sinks := make([]io.Writer, 0, 2)
+
+	if output != "" {
+		path, err := expandOutputPath(output, time.Now())
+		if err != nil {
+			return err
+		}
+
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return fmt.Errorf("failed to create output directory: %w", err)
+		}
+
+		file, err := os.Create(path)
+		if err != nil {
+			return fmt.Errorf("failed to create output file: %w", err)
+		}
+		reportOutputFile = file
+		sinks = append(sinks, file)
+	} else {
+		// Still show the report when it is only being copied
+		sinks = append(sinks, ui.DataOutput())
+	}
+
+	if copyOutput {
+		reportCopyBuffer = &bytes.Buffer{}
+		sinks = append(sinks, reportCopyBuffer)
+	}
+
+	reader, writer, err := os.Pipe()
+	if err != nil {
+		return fmt.Errorf("failed to capture output: %w", err)
+	}
+
+	reportCapture = writer
+	reportCaptureDone = make(chan struct{})
+	go func() {
+		io.Copy(io.MultiWriter(sinks...), reader)
+		reader.Close()
+		close(reportCaptureDone)
+	}()
+
+	reportOutputRestore = ui.RedirectOutput(writer)
+	return nil
+}
+
+// closeReportOutput restores standard output, closes the report file and fills the clipboard
+func closeReportOutput() {
+	if reportCapture == nil {
+		return
+	}
+
+	reportOutputRestore()
+	reportCapture.Close()
+	<-reportCaptureDone
+	reportCapture = nil
+
+	if reportOutputFile != nil {
+		path := reportOutputFile.Name()
+		if err := reportOutputFile.Close(); err != nil {
+			ui.PrintError("Failed to write report: %v", err)
+		} else {
+			ui.PrintSuccess("Report written to %s", path)
+		}
+		reportOutputFile = nil
+	}
+
+	if reportCopyBuffer != nil {
+		if err := clipboard.Write(reportCopyBuffer.String()); err != nil {
+			ui.PrintError("Failed to copy report to clipboard: %v", err)
+		} else {
+			ui.PrintSuccess("Report copied to clipboard")
+		}
+		reportCopyBuffer = nil
+	}
+}
+
+// expandOutputPath expands ~ and the {{date}}, {{week}}, {{month}} and {{time}} placeholders
+func expandOutputPath(path string, now time.Time) (string, error) {
+	if path == "~" || strings.HasPrefix(path, "~/") {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", err
+		}
+		path = filepath.Join(home, strings.TrimPrefix(path, "~"))
+	}
+
+	year, week := ui.WeekNumber(ui.StartOfWeek(now))
+	replacer := strings.NewReplacer(
+		"{{date}}", now.Format("2006-01-02"),
+		"{{week}}", fmt.Sprintf("%d-W%02d", year, week),
+		"{{month}}", now.Format("2006-01"),
+		"{{time}}", now.Format("150405"),
+	)
+	return replacer.Replace(path), nil
+}
@@ -0,0 +1,186 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/mrbooshehri/qix-go/internal/storage"
+	"github.com/mrbooshehri/qix-go/internal/ui"
+	"github.com/mrbooshehri/qix-go/pkg/qix/models"
+)
+
+// runDailyRange prints logged hours for every day from fromStr to toStr with per-project totals
+func runDailyRange(fromStr, toStr string) error {
+	today := truncateDay(time.Now())
+
+	to := today
+	if toStr != "" {
+		parsed, err := ui.ParseDate(toStr)
+		if err != nil {
+			return invalid("Invalid end date format. Use: %s", ui.DateFormats())
+		}
+		to = parsed
+	}
+
+	from := to
+	if fromStr != "" {
+		parsed, err := ui.ParseDate(fromStr)
+		if err != nil {
+			return invalid("Invalid start date format. Use: %s", ui.DateFormats())
+		}
+		from = parsed
+	}
+
+	if to.Before(from) {
+		return invalid("End date must be after start date")
+	}
+
+	store := storage.Get()
+
+	entriesInRange, err := store.GetAllTimeEntriesInRange(context.Background(), from.Format("2006-01-02"), to.Format("2006-01-02"))
+	if err != nil {
+		return fail("Failed to get time entries: %v", err)
+	}
+
+	report := buildDailyRange(entriesInRange, from, to)
+	if jsonOutput {
+		printJSON(report)
+		return nil
+	}
+
+	ui.PrintHeader(fmt.Sprintf("Daily Report - %s to %s", ui.FormatDate(report.From), ui.FormatDate(report.To)))
+
+	for _, day := range report.Days {
+		date, _ := time.Parse("2006-01-02", day.Date)
+		ui.BoldCyan.Printf("%s  %s\n", date.Format("Mon"), ui.FormatDate(day.Date))
+
+		for _, project := range day.Projects {
+			fmt.Printf("   • %-20s %8s\n", project.Project, ui.FormatHours(project.Hours))
+		}
+		ui.Dim.Printf("   %-22s %8s\n", "Subtotal", ui.FormatHours(day.TotalHours))
+		fmt.Println()
+	}
+
+	if len(report.Days) == 0 {
+		ui.PrintEmptyState(
+			"No time entries found in this period",
+			"Start tracking with: qix track start <project> <task_id>",
+		)
+		return nil
+	}
+
+	ui.PrintSubHeader("📁 Per-Project Totals")
+
+	table := ui.NewTableBuilder("Project", "Hours", "Share", "Days").
+		Align(1, ui.AlignRight).
+		Align(2, ui.AlignRight).
+		Align(3, ui.AlignRight)
+	for _, project := range report.Projects {
+		table.Row(
+			project.Project,
+			ui.FormatHours(project.Hours),
+			ui.FormatPercentage(project.Hours/report.TotalHours*100),
+			fmt.Sprintf("%d", project.Days),
+		)
+	}
+	table.PrintSimple()
+	fmt.Println()
+
+	ui.PrintSeparator()
+	ui.BoldGreen.Printf("Total time logged: %s\n", ui.FormatHours(report.TotalHours))
+	fmt.Printf("Days with time logged: %d / %d\n", len(report.Days), report.DaysInRange)
+	fmt.Printf("Average per logged day: %s\n", ui.FormatHours(report.TotalHours/float64(len(report.Days))))
+	fmt.Println()
+	return nil
+}
+
+// dailyRangeReport is the time logged per day and per project over a date range
+type dailyRangeReport struct {
+	From        string         `json:"from"`
+	To          string         `json:"to"`
+	DaysInRange int            `json:"days_in_range"`
+	Days        []rangeDay     `json:"days"` // Only days with time logged
+	Projects    []projectHours `json:"projects"`
+	TotalHours  float64        `json:"total_hours"`
+}
+
+// rangeDay is the time logged per project on one day
+type rangeDay struct {
+	Date       string         `json:"date"`
+	Projects   []projectHours `json:"projects"`
+	TotalHours float64        `json:"total_hours"`
+}
+
+// projectHours is the time logged to a project, and on how many days
+type projectHours struct {
+	Project string  `json:"project"`
+	Hours   float64 `json:"hours"`
+	Days    int     `json:"days,omitempty"`
+}
+
+// buildDailyRange groups time entries by day, then project, with per-project totals
+// ordered by most hours first
+func buildDailyRange(entriesInRange map[string][]models.TimeEntry, from, to time.Time) dailyRangeReport {
+	report := dailyRangeReport{
+		From:        from.Format("2006-01-02"),
+		To:          to.Format("2006-01-02"),
+		DaysInRange: daysBetween(from, to) + 1,
+		Days:        make([]rangeDay, 0),
+		Projects:    make([]projectHours, 0),
+	}
+
+	hoursByDay := make(map[string]map[string]float64)
+	for project, entries := range entriesInRange {
+		for _, entry := range entries {
+			if hoursByDay[entry.Date] == nil {
+				hoursByDay[entry.Date] = make(map[string]float64)
+			}
+			hoursByDay[entry.Date][project] += entry.Hours
+		}
+	}
+
+	totals := make(map[string]*projectHours)
+	for day := from; !day.After(to); day = day.AddDate(0, 0, 1) {
+		dateStr := day.Format("2006-01-02")
+
+		hoursByProject := hoursByDay[dateStr]
+		if len(hoursByProject) == 0 {
+			continue
+		}
+
+		projects := make([]string, 0, len(hoursByProject))
+		for project := range hoursByProject {
+			projects = append(projects, project)
+		}
+		sort.Strings(projects)
+
+		rd := rangeDay{Date: dateStr, Projects: make([]projectHours, 0, len(projects))}
+		for _, project := range projects {
+			hours := hoursByProject[project]
+			rd.Projects = append(rd.Projects, projectHours{Project: project, Hours: hours})
+			rd.TotalHours += hours
+
+			if totals[project] == nil {
+				totals[project] = &projectHours{Project: project}
+			}
+			totals[project].Hours += hours
+			totals[project].Days++
+		}
+		report.Days = append(report.Days, rd)
+		report.TotalHours += rd.TotalHours
+	}
+
+	for _, total := range totals {
+		report.Projects = append(report.Projects, *total)
+	}
+	sort.Slice(report.Projects, func(i, j int) bool {
+		if report.Projects[i].Hours != report.Projects[j].Hours {
+			return report.Projects[i].Hours > report.Projects[j].Hours
+		}
+		return report.Projects[i].Project < report.Projects[j].Project
+	})
+
+	return report
+}
@@ -0,0 +1,316 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/mrbooshehri/qix-go/internal/cron"
+	"github.com/mrbooshehri/qix-go/internal/logging"
+	"github.com/mrbooshehri/qix-go/internal/storage"
+	"github.com/mrbooshehri/qix-go/internal/ui"
+	"github.com/mrbooshehri/qix-go/pkg/qix/models"
+	"github.com/spf13/cobra"
+)
+
+var reportScheduleCmd = &cobra.Command{
+	Use:   "schedule",
+	Short: "Manage scheduled reports",
+	Long: `Run report commands automatically on a cron schedule.
+
+Schedules are executed by 'qix report run-due', which is meant to be invoked
+regularly by the system scheduler, for example every 15 minutes from crontab:
+
+  */15 * * * * qix report run-due`,
+}
+
+var reportScheduleAddCmd = &cobra.Command{
+	Use:   "add <report command>",
+	Short: "Schedule a report",
+	Long: `Schedule a report command to run on a cron expression.
+
+The command is everything after 'qix report'. Output paths given with
+--output may use {{date}}, {{week}}, {{month}} and {{time}} placeholders,
+which are expanded each time the report runs.
+
+Example:
+  qix report schedule add "weekly web --output ~/reports/{{week}}.txt" --cron "0 17 * * FRI"`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cronExpr, _ := cmd.Flags().GetString("cron")
+		command := strings.TrimSpace(strings.Join(args, " "))
+		command = strings.TrimSpace(strings.TrimPrefix(command, "qix "))
+		command = strings.TrimSpace(strings.TrimPrefix(command, "report "))
+
+		if cronExpr == "" {
+			return invalid("A schedule is required: --cron \"<min> <hour> <dom> <month> <dow>\"")
+		}
+
+		sched, err := cron.Parse(cronExpr)
+		if err != nil {
+			return invalid("Invalid cron expression: %v", err)
+		}
+
+		reportArgs, err := splitCommandLine(command)
+		if err != nil {
+			return invalid("Invalid command: %v", err)
+		}
+		if target, _, err := reportCmd.Find(reportArgs); err != nil || target == reportCmd || target == reportScheduleCmd || target.Parent() == reportScheduleCmd || target == reportRunDueCmd {
+			return invalid("Not a report command: %s", command)
+		}
+
+		schedule := models.ReportSchedule{
+			ID:        storage.GenerateTaskID(),
+			Command:   command,
+			Cron:      cronExpr,
+			CreatedAt: time.Now(),
+		}
+
+		store := storage.Get()
+		err = store.UpdateSchedules(func(schedules []models.ReportSchedule) ([]models.ReportSchedule, error) {
+			return append(schedules, schedule), nil
+		})
+		if err != nil {
+			return fail("Failed to save schedule: %v", err)
+		}
+
+		ui.PrintSuccess("Report scheduled with ID: %s", schedule.ID)
+		ui.Dim.Printf("  Command: qix report %s\n", schedule.Command)
+		ui.Dim.Printf("  Next run: %s\n", ui.FormatDateTime(sched.Next(time.Now())))
+		return nil
+	},
+}
+
+var reportScheduleListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List scheduled reports",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		store := storage.Get()
+
+		schedules, err := store.LoadSchedules()
+		if err != nil {
+			return fail("%v", err)
+		}
+
+		if jsonOutput {
+			type scheduleJSON struct {
+				models.ReportSchedule
+				NextRun *time.Time `json:"next_run,omitempty"` // Unset if the cron expression is invalid
+			}
+			out := make([]scheduleJSON, 0, len(schedules))
+			for _, schedule := range schedules {
+				view := scheduleJSON{ReportSchedule: schedule}
+				if sched, err := cron.Parse(schedule.Cron); err == nil {
+					next := sched.Next(time.Now())
+					view.NextRun = &next
+				}
+				out = append(out, view)
+			}
+			printJSON(out)
+			return nil
+		}
+
+		if len(schedules) == 0 {
+			ui.PrintEmptyState(
+				"No scheduled reports",
+				"Add one with: qix report schedule add \"<report>\" --cron \"<expr>\"",
+			)
+			return nil
+		}
+
+		ui.PrintHeader("⏰ Scheduled Reports")
+
+		table := ui.NewTableBuilder("ID", "Cron", "Command", "Last Run", "Next Run")
+		for _, schedule := range schedules {
+			lastRun := "never"
+			if !schedule.LastRun.IsZero() {
+				lastRun = ui.FormatDateTime(schedule.LastRun)
+			}
+
+			nextRun := "invalid schedule"
+			if sched, err := cron.Parse(schedule.Cron); err == nil {
+				nextRun = ui.FormatDateTime(sched.Next(time.Now()))
+			}
+
+			table.Row(schedule.ID, schedule.Cron, schedule.Command, lastRun, nextRun)
+		}
+		table.PrintSimple()
+		return nil
+	},
+}
+
+var reportScheduleRemoveCmd = &cobra.Command{
+	Use:   "remove <id>",
+	Short: "Remove a scheduled report",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		id := args[0]
+		store := storage.Get()
+
+		err := store.UpdateSchedules(func(schedules []models.ReportSchedule) ([]models.ReportSchedule, error) {
+			for i, schedule := range schedules {
+				if schedule.ID == id {
+					return append(schedules[:i], schedules[i+1:]...), nil
+				}
+			}
+			return nil, notFound("schedule not found: %s", id)
+		})
+		if err != nil {
+			return fail("Failed to remove schedule: %v", err)
+		}
+
+		ui.PrintSuccess("Schedule removed: %s", id)
+		return nil
+	},
+}
+
+var reportRunDueCmd = &cobra.Command{
+	Use:   "run-due",
+	Short: "Run scheduled reports that are due",
+	Long: `Run every scheduled report whose next run time has passed since it last ran.
+A report that missed several runs is only run once. Intended to be invoked
+from cron or another system scheduler.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		store := storage.Get()
+
+		schedules, err := store.LoadSchedules()
+		if err != nil {
+			return fail("%v", err)
+		}
+
+		executable, err := os.Executable()
+		if err != nil {
+			return fail("Failed to locate qix executable: %v", err)
+		}
+
+		now := time.Now()
+		ran := make(map[string]time.Time)
+
+		for _, schedule := range schedules {
+			sched, err := cron.Parse(schedule.Cron)
+			if err != nil {
+				ui.PrintWarning("Skipping %s: invalid cron expression: %v", schedule.ID, err)
+				continue
+			}
+
+			since := schedule.LastRun
+			if since.IsZero() {
+				since = schedule.CreatedAt
+			}
+			if sched.Next(since).After(now) {
+				continue
+			}
+
+			reportArgs, err := splitCommandLine(schedule.Command)
+			if err != nil {
+				ui.PrintWarning("Skipping %s: %v", schedule.ID, err)
+				continue
+			}
+
+			ui.PrintInfo("Running [%s] qix report %s", schedule.ID, schedule.Command)
+			logging.Infof("Running scheduled report %s: %s", schedule.ID, schedule.Command)
+
+			runArgs := append([]string{"report"}, reportArgs...)
+			if noColor {
+				runArgs = append(runArgs, "--no-color")
+			}
+
+			run := exec.Command(executable, runArgs...)
+			run.Stdout = os.Stdout
+			run.Stderr = os.Stderr
+			if err := run.Run(); err != nil {
+				ui.PrintError("Scheduled report %s failed: %v", schedule.ID, err)
+				logging.Errorf("Scheduled report %s failed: %v", schedule.ID, err)
+			}
+
+			ran[schedule.ID] = now
+		}
+
+		if len(ran) == 0 {
+			ui.PrintInfo("No scheduled reports due")
+			return nil
+		}
+
+		err = store.UpdateSchedules(func(schedules []models.ReportSchedule) ([]models.ReportSchedule, error) {
+			for i := range schedules {
+				if t, ok := ran[schedules[i].ID]; ok {
+					schedules[i].LastRun = t
+				}
+			}
+			return schedules, nil
+		})
+		if err != nil {
+			return fail("Failed to record schedule runs: %v", err)
+		}
+		return nil
+	},
+}
+
+// splitCommandLine splits a command string into arguments, honoring quotes and backslash escapes
+func splitCommandLine(line string) ([]string, error) {
+	args := make([]string, 0)
+	var current strings.Builder
+	inArg := false
+	var quote rune
+	escaped := false
+
+	for _, r := range line {
+		switch {
+		case escaped:
+			current.WriteRune(r)
+			escaped = false
+		case r == '\\' && quote != '\'':
+			escaped = true
+			inArg = true
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			} else {
+				current.WriteRune(r)
+			}
+		case r == '"' || r == '\'':
+			quote = r
+			inArg = true
+		case r == ' ' || r == '\t' || r == '\n':
+			if inArg {
+				args = append(args, current.String())
+				current.Reset()
+				inArg = false
+			}
+		default:
+			current.WriteRune(r)
+			inArg = true
+		}
+	}
+
+	if quote != 0 {
+		return nil, fmt.Errorf("unterminated quote")
+	}
+	if escaped {
+		return nil, fmt.Errorf("trailing backslash")
+	}
+	if inArg {
+		args = append(args, current.String())
+	}
+
+	return args, nil
+}
+
+func init() {
+	reportScheduleAddCmd.Flags().String("cron", "", "Cron expression, e.g. \"0 17 * * FRI\" or @daily")
+
+	noJSON(reportScheduleAddCmd, reportScheduleRemoveCmd, reportRunDueCmd)
+	reportScheduleCmd.AddCommand(reportScheduleAddCmd)
+	reportScheduleCmd.AddCommand(reportScheduleListCmd)
+	reportScheduleCmd.AddCommand(reportScheduleRemoveCmd)
+
+	reportCmd.AddCommand(reportScheduleCmd)
+	reportCmd.AddCommand(reportRunDueCmd)
+
+	// Scheduled reports run unattended and write files
+	usePager(false, reportScheduleCmd, reportRunDueCmd)
+}
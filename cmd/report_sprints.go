@@ -0,0 +1,258 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/mrbooshehri/qix-go/internal/storage"
+	"github.com/mrbooshehri/qix-go/internal/ui"
+	"github.com/mrbooshehri/qix-go/pkg/qix/models"
+	"github.com/spf13/cobra"
+)
+
+var reportSprintsCmd = &cobra.Command{
+	Use:   "sprints <project>",
+	Short: "Sprint-over-sprint comparison",
+	Long: `Compare the last N sprints of a project side by side.
+
+Committed counts tasks assigned by the sprint start; Added and Removed count
+scope changes after it started. Completed counts tasks finished by the sprint
+end, and Carry-over counts tasks still open when a finished sprint ended.
+Tasks assigned before assignment dates were recorded count as committed.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		projectName := args[0]
+		last, _ := cmd.Flags().GetInt("last")
+		if last < 1 {
+			return invalid("--last must be at least 1")
+		}
+
+		store := storage.Get()
+
+		project, err := store.LoadProject(projectName)
+		if err != nil {
+			return notFound("Project not found: %s", projectName)
+		}
+
+		today := time.Now().Format("2006-01-02")
+		sprints := make([]models.Sprint, 0, len(project.Sprints))
+		for _, sprint := range project.Sprints {
+			if sprint.StartDate <= today {
+				sprints = append(sprints, sprint)
+			}
+		}
+		sort.SliceStable(sprints, func(i, j int) bool {
+			return sprints[i].StartDate < sprints[j].StartDate
+		})
+		if len(sprints) > last {
+			sprints = sprints[len(sprints)-last:]
+		}
+
+		tasks := make(map[string]models.Task)
+		for _, task := range project.GetAllTasks() {
+			tasks[task.ID] = task
+		}
+
+		stats := make([]sprintStats, len(sprints))
+		for i, sprint := range sprints {
+			stats[i] = computeSprintStats(sprint, tasks)
+		}
+
+		if jsonOutput {
+			report := sprintsReport{Project: projectName, Sprints: make([]sprintResult, 0, len(stats))}
+			for _, s := range stats {
+				report.Sprints = append(report.Sprints, sprintResult{
+					Name:        s.Sprint.Name,
+					StartDate:   s.Sprint.StartDate,
+					EndDate:     s.Sprint.EndDate,
+					sprintStats: s,
+					Completion:  s.completion(),
+				})
+			}
+			printJSON(report)
+			return nil
+		}
+
+		ui.PrintHeader(fmt.Sprintf("🏃 Sprint Comparison: %s", projectName))
+
+		if len(sprints) == 0 {
+			ui.PrintEmptyState(
+				"No started sprints",
+				fmt.Sprintf("Create one with: qix sprint create %s <name> <start> <end>", projectName),
+			)
+			return nil
+		}
+
+		headers := []string{"Metric"}
+		for _, sprint := range sprints {
+			headers = append(headers, sprint.Name)
+		}
+		table := ui.NewTableBuilder(headers...)
+		for i := range sprints {
+			table.Align(i+1, ui.AlignRight)
+		}
+
+		rows := []struct {
+			name  string
+			value func(sprintStats) string
+		}{
+			{"Dates", func(s sprintStats) string { return s.Start.Format("Jan 02") + "-" + s.End.Format("Jan 02") }},
+			{"Committed", func(s sprintStats) string { return fmt.Sprintf("%d", s.Committed) }},
+			{"Added", func(s sprintStats) string { return fmt.Sprintf("+%d", s.Added) }},
+			{"Removed", func(s sprintStats) string { return fmt.Sprintf("-%d", s.Removed) }},
+			{"Completed", func(s sprintStats) string { return fmt.Sprintf("%d/%d", s.Completed, s.Final) }},
+			{"Completion", func(s sprintStats) string { return ui.FormatPercentage(s.completion()) }},
+			{"Estimated", func(s sprintStats) string { return ui.FormatHours(s.Estimated) }},
+			{"Velocity", func(s sprintStats) string { return ui.FormatHours(s.Velocity) }},
+			{"Hours Logged", func(s sprintStats) string { return ui.FormatHours(s.HoursLogged) }},
+			{"Carry-over", func(s sprintStats) string {
+				if !s.Finished {
+					return "-"
+				}
+				return fmt.Sprintf("%d", s.CarryOver)
+			}},
+		}
+		for _, row := range rows {
+			cells := []string{row.name}
+			for _, s := range stats {
+				cells = append(cells, row.value(s))
+			}
+			table.Row(cells...)
+		}
+		table.PrintSimple()
+		fmt.Println()
+
+		ui.PrintSubHeader("📈 Completion")
+		for _, s := range stats {
+			fmt.Printf("%-20s ", s.Sprint.Name)
+			ui.PrintProgressBar(s.completion(), 30)
+			fmt.Printf(" %.1f%%\n", s.completion())
+		}
+
+		if len(stats) < 2 {
+			return nil
+		}
+
+		fmt.Println()
+		prev, curr := stats[len(stats)-2], stats[len(stats)-1]
+		delta := curr.completion() - prev.completion()
+		switch {
+		case delta > 0:
+			ui.Green.Printf("✨ Completion up %.1f points from %s\n", delta, prev.Sprint.Name)
+		case delta < 0:
+			ui.Red.Printf("⚠️  Completion down %.1f points from %s\n", -delta, prev.Sprint.Name)
+		default:
+			ui.Dim.Printf("Completion unchanged from %s\n", prev.Sprint.Name)
+		}
+		if curr.Added > prev.Added {
+			ui.Yellow.Printf("⚠️  More scope added mid-sprint than in %s (%d vs %d)\n", prev.Sprint.Name, curr.Added, prev.Added)
+		}
+		return nil
+	},
+}
+
+// sprintStats holds delivery metrics for one sprint
+type sprintStats struct {
+	Sprint      models.Sprint `json:"-"`
+	Start       time.Time     `json:"-"`
+	End         time.Time     `json:"-"`
+	Committed   int           `json:"committed"`
+	Added       int           `json:"added"`
+	Removed     int           `json:"removed"`
+	Final       int           `json:"final"`
+	Completed   int           `json:"completed"`
+	CarryOver   int           `json:"carry_over"`
+	Finished    bool          `json:"finished"`
+	Estimated   float64       `json:"estimated_hours"`
+	Velocity    float64       `json:"velocity"` // Estimated hours of completed tasks
+	HoursLogged float64       `json:"hours_logged"`
+}
+
+// sprintsReport compares a project's most recent started sprints, oldest first
+type sprintsReport struct {
+	Project string         `json:"project"`
+	Sprints []sprintResult `json:"sprints"`
+}
+
+// sprintResult is one sprint's metrics as shown by --json
+type sprintResult struct {
+	Name      string `json:"name"`
+	StartDate string `json:"start_date"`
+	EndDate   string `json:"end_date"`
+	sprintStats
+	Completion float64 `json:"completion"`
+}
+
+// completion returns completed tasks as a percentage of final scope
+func (s sprintStats) completion() float64 {
+	if s.Final == 0 {
+		return 0
+	}
+	return float64(s.Completed) / float64(s.Final) * 100
+}
+
+// computeSprintStats measures commitment, scope change and delivery for a sprint
+func computeSprintStats(sprint models.Sprint, tasks map[string]models.Task) sprintStats {
+	stats := sprintStats{Sprint: sprint, Final: len(sprint.TaskIDs)}
+
+	start, _ := time.ParseInLocation("2006-01-02", sprint.StartDate, time.Local)
+	end, _ := time.ParseInLocation("2006-01-02", sprint.EndDate, time.Local)
+	stats.Start, stats.End = start, end
+	endOfSprint := end.AddDate(0, 0, 1)
+	stats.Finished = !time.Now().Before(endOfSprint)
+
+	// Anything assigned on the start day is still part of the commitment
+	addedLate := func(at time.Time) bool {
+		return !at.IsZero() && !at.Before(start.AddDate(0, 0, 1))
+	}
+
+	for _, id := range sprint.TaskIDs {
+		if addedLate(sprint.AddedAt[id]) {
+			stats.Added++
+		} else {
+			stats.Committed++
+		}
+
+		task, ok := tasks[id]
+		if !ok {
+			continue
+		}
+
+		stats.Estimated += task.EstimatedHours
+		for _, entry := range task.TimeEntries {
+			if entry.Date >= sprint.StartDate && entry.Date <= sprint.EndDate {
+				stats.HoursLogged += entry.Hours
+			}
+		}
+
+		if completedAt, ok := task.CompletedAt(); ok && completedAt.Before(endOfSprint) {
+			stats.Completed++
+			stats.Velocity += task.EstimatedHours
+		} else if stats.Finished {
+			stats.CarryOver++
+		}
+	}
+
+	for id, at := range sprint.RemovedAt {
+		// Removed before the sprint started: never part of it
+		if !addedLate(at) {
+			continue
+		}
+		if !addedLate(sprint.AddedAt[id]) {
+			stats.Committed++
+		}
+		if at.Before(endOfSprint) {
+			stats.Removed++
+		}
+	}
+
+	return stats
+}
+
+func init() {
+	reportSprintsCmd.Flags().Int("last", 5, "Number of most recent sprints to compare")
+	reportSprintsCmd.ValidArgsFunction = projectArgCompletion
+
+	reportCmd.AddCommand(reportSprintsCmd)
+}
@@ -0,0 +1,424 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mrbooshehri/qix-go/internal/storage"
+	"github.com/mrbooshehri/qix-go/internal/ui"
+	"github.com/mrbooshehri/qix-go/pkg/qix/models"
+	"github.com/spf13/cobra"
+)
+
+var reportWeeklyCmd = &cobra.Command{
+	Use:   "weekly [project] [week]",
+	Short: "Weekly status report",
+	Long: `Summarize a week of work: hours per day, tasks completed, tasks started
+and carry-over, compared against the previous week.
+
+The week can be given as an ISO week (2024-W07) or any date inside the
+week (YYYY-MM-DD). Defaults to the current week across all projects. Weeks
+start on the day set by week_start in the config file, Monday by default.
+
+A task counts as "started" in the week its first time entry was logged.
+Carry-over tasks were created before the week and were still open at its end.`,
+	Args: cobra.MaximumNArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		projectName := ""
+		weekArg := ""
+
+		switch len(args) {
+		case 1:
+			if isWeekArg(args[0]) {
+				weekArg = args[0]
+			} else {
+				projectName = args[0]
+			}
+		case 2:
+			projectName = args[0]
+			weekArg = args[1]
+		}
+
+		weekStart := ui.StartOfWeek(time.Now())
+		if weekArg != "" {
+			parsed, err := parseWeekArg(weekArg)
+			if err != nil {
+				return invalid("Invalid week: %v", err)
+			}
+			weekStart = parsed
+		}
+
+		store := storage.Get()
+
+		projects, err := loadReportProjects(store, projectName)
+		if err != nil {
+			return fail("%v", err)
+		}
+
+		current := summarizePeriod(projects, weekStart, weekStart.AddDate(0, 0, 6))
+		previous := summarizePeriod(projects, weekStart.AddDate(0, 0, -7), weekStart.AddDate(0, 0, -1))
+
+		scope := "All Projects"
+		if projectName != "" {
+			scope = projectName
+		}
+
+		year, week := ui.WeekNumber(weekStart)
+		if jsonOutput {
+			printJSON(weeklyReport{
+				Project:  projectName,
+				Week:     fmt.Sprintf("%d-W%02d", year, week),
+				Current:  current.view(projects),
+				Previous: previous.view(projects),
+			})
+			return nil
+		}
+
+		ui.PrintHeader(fmt.Sprintf("📆 Weekly Report: %s (%d-W%02d)", scope, year, week))
+		fmt.Printf("Period: %s to %s\n", ui.FormatDate(current.startDate()), ui.FormatDate(current.endDate()))
+
+		// Hours per day
+		ui.PrintSubHeader("⏱️  Hours per Day")
+		printDailyHoursTable(current)
+		fmt.Println()
+
+		// Comparison with the previous week
+		ui.PrintSubHeader("📊 Compared to Previous Week")
+		periodComparisonTable("This week", "Last week", current, previous).PrintSimple()
+		fmt.Println()
+
+		printPeriodTaskList("✅ Completed", current.Completed, models.StatusDone)
+		printPeriodTaskList("▶️  Started", current.Started, "")
+		printPeriodTaskList("↪️  Carry-over", current.CarryOver, "")
+		return nil
+	},
+}
+
+// projectTask pairs a task with the project it belongs to
+type projectTask struct {
+	Project string
+	Task    models.Task
+}
+
+// periodSummary holds aggregated activity for a date range
+type periodSummary struct {
+	Start      time.Time
+	End        time.Time
+	HoursByDay map[string]float64
+	TotalHours float64
+	Completed  []projectTask
+	Started    []projectTask
+	CarryOver  []projectTask
+	Worked     []taskHours
+}
+
+// taskHours pairs a task with the hours logged to it in a period
+type taskHours struct {
+	projectTask
+	Hours float64
+}
+
+// weeklyReport is the weekly status report, with the previous week for comparison
+type weeklyReport struct {
+	Project  string     `json:"project,omitempty"` // Empty for all projects
+	Week     string     `json:"week"`
+	Current  periodView `json:"current"`
+	Previous periodView `json:"previous"`
+}
+
+// periodView is a periodSummary as shown by --json
+type periodView struct {
+	From       string       `json:"from"`
+	To         string       `json:"to"`
+	Daily      []dateHours  `json:"daily"`
+	TotalHours float64      `json:"total_hours"`
+	Completed  []taskView   `json:"completed"`
+	Started    []taskView   `json:"started"`
+	CarryOver  []taskView   `json:"carry_over"`
+	Worked     []workedTask `json:"worked"`
+}
+
+// workedTask is a task with the hours logged to it in a period
+type workedTask struct {
+	taskView
+	PeriodHours float64 `json:"period_hours"`
+}
+
+// view assembles the JSON view of the period; projects are the ones it was summarized from
+func (p periodSummary) view(projects []*models.Project) periodView {
+	modules := projectTaskModules(projects)
+	view := periodView{
+		From:       p.startDate(),
+		To:         p.endDate(),
+		Daily:      make([]dateHours, 0, p.days()),
+		TotalHours: p.TotalHours,
+		Completed:  projectTaskViews(modules, p.Completed),
+		Started:    projectTaskViews(modules, p.Started),
+		CarryOver:  projectTaskViews(modules, p.CarryOver),
+		Worked:     make([]workedTask, 0, len(p.Worked)),
+	}
+	for i := 0; i < p.days(); i++ {
+		date := p.Start.AddDate(0, 0, i).Format("2006-01-02")
+		view.Daily = append(view.Daily, dateHours{Date: date, Hours: p.HoursByDay[date]})
+	}
+	for _, item := range p.Worked {
+		view.Worked = append(view.Worked, workedTask{
+			taskView:    newTaskView(item.Project, modules[item.Project][item.Task.ID], item.Task),
+			PeriodHours: item.Hours,
+		})
+	}
+	sort.SliceStable(view.Worked, func(i, j int) bool {
+		return view.Worked[i].PeriodHours > view.Worked[j].PeriodHours
+	})
+	return view
+}
+
+// projectTaskModules maps each project name to its task modules (see taskModules)
+func projectTaskModules(projects []*models.Project) map[string]map[string]string {
+	modules := make(map[string]map[string]string, len(projects))
+	for _, project := range projects {
+		modules[project.Name] = taskModules(project)
+	}
+	return modules
+}
+
+// projectTaskViews assembles the views of tasks from several projects
+func projectTaskViews(modules map[string]map[string]string, tasks []projectTask) []taskView {
+	views := make([]taskView, 0, len(tasks))
+	for _, item := range tasks {
+		views = append(views, newTaskView(item.Project, modules[item.Project][item.Task.ID], item.Task))
+	}
+	return views
+}
+
+func (p periodSummary) startDate() string {
+	return p.Start.Format("2006-01-02")
+}
+
+func (p periodSummary) endDate() string {
+	return p.End.Format("2006-01-02")
+}
+
+// days returns the number of calendar days covered by the period
+func (p periodSummary) days() int {
+	return daysBetween(p.Start, p.End) + 1
+}
+
+// hoursPerDay returns the hours logged per calendar day of the period
+func (p periodSummary) hoursPerDay() float64 {
+	return p.TotalHours / float64(p.days())
+}
+
+// summarizePeriod aggregates hours and task activity between start and end (inclusive)
+func summarizePeriod(projects []*models.Project, start, end time.Time) periodSummary {
+	summary := periodSummary{
+		Start:      start,
+		End:        end,
+		HoursByDay: make(map[string]float64),
+	}
+
+	startStr := summary.startDate()
+	endStr := summary.endDate()
+	periodEnd := end.AddDate(0, 0, 1)
+
+	for _, project := range projects {
+		for _, task := range project.GetAllTasks() {
+			item := projectTask{Project: project.Name, Task: task}
+
+			firstEntry := ""
+			worked := 0.0
+			for _, entry := range task.TimeEntries {
+				if firstEntry == "" || entry.Date < firstEntry {
+					firstEntry = entry.Date
+				}
+				if entry.Date >= startStr && entry.Date <= endStr {
+					summary.HoursByDay[entry.Date] += entry.Hours
+					summary.TotalHours += entry.Hours
+					worked += entry.Hours
+				}
+			}
+
+			if worked > 0 {
+				summary.Worked = append(summary.Worked, taskHours{projectTask: item, Hours: worked})
+			}
+
+			completedAt, done := taskCompletedAt(task)
+			if done {
+				completed := completedAt.Format("2006-01-02")
+				if completed >= startStr && completed <= endStr {
+					summary.Completed = append(summary.Completed, item)
+				}
+			}
+
+			if firstEntry >= startStr && firstEntry <= endStr {
+				summary.Started = append(summary.Started, item)
+			}
+
+			openAtEnd := !done || !completedAt.Before(periodEnd)
+			if task.CreatedAt.Before(start) && openAtEnd {
+				summary.CarryOver = append(summary.CarryOver, item)
+			}
+		}
+	}
+
+	return summary
+}
+
+// loadReportProjects loads a single project or, when name is empty, all projects
+func loadReportProjects(store *storage.Storage, name string) ([]*models.Project, error) {
+	if name != "" {
+		project, err := store.LoadProject(name)
+		if err != nil {
+			return nil, notFound("project not found: %s", name)
+		}
+		return []*models.Project{project}, nil
+	}
+
+	projects, err := store.GetAllProjects()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load projects: %w", err)
+	}
+	return projects, nil
+}
+
+// isWeekArg reports whether value looks like a week or date argument
+func isWeekArg(value string) bool {
+	_, err := parseWeekArg(value)
+	return err == nil
+}
+
+// parseWeekArg parses an ISO week (2024-W07) or a date and returns the first
+// day of the week
+func parseWeekArg(value string) (time.Time, error) {
+	if t, err := ui.ParseDate(value); err == nil {
+		return ui.StartOfWeek(t), nil
+	}
+
+	parts := strings.SplitN(strings.ToUpper(value), "-W", 2)
+	if len(parts) != 2 {
+		return time.Time{}, fmt.Errorf("use YYYY-Www or %s", ui.DateFormats())
+	}
+
+	year, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid year: %s", parts[0])
+	}
+	week, err := strconv.Atoi(parts[1])
+	if err != nil || week < 1 || week > 53 {
+		return time.Time{}, fmt.Errorf("week must be 1-53")
+	}
+
+	// January 4th is always in ISO week 1. Weeks are numbered after the ISO
+	// week of their fourth day, so the week numbered like the ISO week is the
+	// one its Thursday falls in.
+	jan4 := time.Date(year, time.January, 4, 0, 0, 0, 0, time.Local)
+	monday := jan4.AddDate(0, 0, -((int(jan4.Weekday()) + 6) % 7))
+	return ui.StartOfWeek(monday.AddDate(0, 0, (week-1)*7+3)), nil
+}
+
+func printDailyHoursTable(summary periodSummary) {
+	maxHours := 0.0
+	for _, hours := range summary.HoursByDay {
+		if hours > maxHours {
+			maxHours = hours
+		}
+	}
+
+	table := ui.NewTableBuilder("Day", "Date", "Hours", "Bar").
+		Align(2, ui.AlignRight)
+
+	for i := 0; i < summary.days(); i++ {
+		day := summary.Start.AddDate(0, 0, i)
+		dateStr := day.Format("2006-01-02")
+		hours := summary.HoursByDay[dateStr]
+
+		table.Row(day.Format("Mon"), ui.FormatDate(dateStr), ui.FormatHours(hours), hoursBar(hours, maxHours, ui.ScaleWidth(20)))
+	}
+
+	table.PrintSimple()
+	fmt.Println()
+	ui.BoldGreen.Printf("Total: %s\n", ui.FormatHours(summary.TotalHours))
+}
+
+// periodComparisonTable builds a side-by-side table of two periods; callers may append rows
+func periodComparisonTable(currentLabel, previousLabel string, current, previous periodSummary) *ui.TableBuilder {
+	table := ui.NewTableBuilder("Metric", currentLabel, previousLabel, "Change").
+		Align(1, ui.AlignRight).
+		Align(2, ui.AlignRight).
+		Align(3, ui.AlignRight)
+
+	table.Row("Hours logged",
+		ui.FormatHours(current.TotalHours),
+		ui.FormatHours(previous.TotalHours),
+		formatDelta(current.TotalHours-previous.TotalHours, "%+.2fh"))
+
+	table.Row("Tasks completed",
+		fmt.Sprintf("%d", len(current.Completed)),
+		fmt.Sprintf("%d", len(previous.Completed)),
+		formatDelta(float64(len(current.Completed)-len(previous.Completed)), "%+.0f"))
+
+	table.Row("Tasks started",
+		fmt.Sprintf("%d", len(current.Started)),
+		fmt.Sprintf("%d", len(previous.Started)),
+		formatDelta(float64(len(current.Started)-len(previous.Started)), "%+.0f"))
+
+	table.Row("Carry-over",
+		fmt.Sprintf("%d", len(current.CarryOver)),
+		fmt.Sprintf("%d", len(previous.CarryOver)),
+		formatDelta(float64(len(current.CarryOver)-len(previous.CarryOver)), "%+.0f"))
+
+	return table
+}
+
+func printPeriodTaskList(title string, tasks []projectTask, status models.TaskStatus) {
+	ui.PrintSubHeader(fmt.Sprintf("%s (%d)", title, len(tasks)))
+
+	if len(tasks) == 0 {
+		ui.Dim.Println("  None")
+		fmt.Println()
+		return
+	}
+
+	sort.Slice(tasks, func(i, j int) bool {
+		if tasks[i].Project != tasks[j].Project {
+			return tasks[i].Project < tasks[j].Project
+		}
+		return tasks[i].Task.Title < tasks[j].Task.Title
+	})
+
+	for _, item := range tasks {
+		taskStatus := item.Task.Status
+		if status != "" {
+			taskStatus = status
+		}
+		statusColor := ui.GetStatusColor(taskStatus)
+		statusColor.Printf("  %s [%s] %s", ui.GetStatusIcon(taskStatus), item.Task.ID, item.Task.Title)
+		ui.Dim.Printf(" (%s)\n", item.Project)
+	}
+	fmt.Println()
+}
+
+// hoursBar renders a fixed-width bar scaled against maxHours
+func hoursBar(hours, maxHours float64, width int) string {
+	filled := 0
+	if maxHours > 0 {
+		filled = int((hours / maxHours) * float64(width))
+	}
+	return strings.Repeat("█", filled) + strings.Repeat("░", width-filled)
+}
+
+// formatDelta formats a signed change, using "0" for no change
+func formatDelta(delta float64, format string) string {
+	if delta == 0 {
+		return "0"
+	}
+	return fmt.Sprintf(format, delta)
+}
+
+func init() {
+	reportWeeklyCmd.ValidArgsFunction = projectArgCompletion
+	reportCmd.AddCommand(reportWeeklyCmd)
+}
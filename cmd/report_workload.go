@@ -0,0 +1,264 @@
+package cmd
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/mrbooshehri/qix-go/internal/storage"
+	"github.com/mrbooshehri/qix-go/internal/ui"
+	"github.com/mrbooshehri/qix-go/pkg/qix/models"
+	"github.com/spf13/cobra"
+)
+
+const unassignedLabel = "(unassigned)"
+
+var reportWorkloadCmd = &cobra.Command{
+	Use:   "workload [project]",
+	Short: "Workload per assignee",
+	Long: `Show open tasks, remaining estimated hours and logged hours per assignee.
+
+With --sprint (or when the project has an active sprint), only tasks in that
+sprint are counted, hours are logged within the sprint dates, and each
+assignee's remaining work is compared against the sprint capacity
+(qix sprint create ... --capacity). Use --capacity to override it.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		sprintName, _ := cmd.Flags().GetString("sprint")
+		capacity, _ := cmd.Flags().GetFloat64("capacity")
+
+		projectName := ""
+		if len(args) > 0 {
+			projectName = args[0]
+		}
+		if sprintName != "" && projectName == "" {
+			return invalid("--sprint requires a project")
+		}
+
+		store := storage.Get()
+
+		projects, err := loadReportProjects(store, projectName)
+		if err != nil {
+			return fail("%v", err)
+		}
+
+		var sprint *models.Sprint
+		if projectName != "" {
+			sprint, err = findWorkloadSprint(projects[0], sprintName)
+			if err != nil {
+				return fail("%v", err)
+			}
+		}
+		if sprint != nil && !cmd.Flags().Changed("capacity") {
+			capacity = sprint.Capacity
+		}
+
+		rows := buildWorkload(projects, sprint)
+
+		if jsonOutput {
+			report := workloadReport{Project: projectName, Capacity: capacity, Assignees: make([]assigneeLoad, 0, len(rows))}
+			if sprint != nil {
+				report.Sprint = sprint.Name
+			}
+			for _, row := range rows {
+				item := assigneeLoad{workloadRow: row}
+				if row.Assignee == unassignedLabel {
+					item.Assignee = ""
+				}
+				if capacity > 0 {
+					load := row.load(capacity)
+					item.Load = &load
+					item.Overloaded = load > 100 && item.Assignee != ""
+				}
+				report.Assignees = append(report.Assignees, item)
+			}
+			printJSON(report)
+			return nil
+		}
+
+		scope := "All Projects"
+		if projectName != "" {
+			scope = projectName
+		}
+		ui.PrintHeader(fmt.Sprintf("👥 Workload: %s", scope))
+		if sprint != nil {
+			fmt.Printf("Sprint: %s (%s to %s)\n", sprint.Name, ui.FormatDate(sprint.StartDate), ui.FormatDate(sprint.EndDate))
+		}
+		if capacity > 0 {
+			fmt.Printf("Capacity: %s per assignee\n", ui.FormatHours(capacity))
+		}
+		fmt.Println()
+
+		if len(rows) == 0 {
+			ui.PrintEmptyState("No tasks to report", "")
+			return nil
+		}
+
+		headers := []string{"Assignee", "Open", "Doing", "Blocked", "Remaining", "Logged"}
+		if capacity > 0 {
+			headers = append(headers, "Load")
+		}
+		table := ui.NewTableBuilder(headers...)
+		for i := 1; i < len(headers); i++ {
+			table.Align(i, ui.AlignRight)
+		}
+
+		overloaded := make([]workloadRow, 0)
+		for _, row := range rows {
+			cells := []string{
+				row.Assignee,
+				fmt.Sprintf("%d", row.Open),
+				fmt.Sprintf("%d", row.Doing),
+				fmt.Sprintf("%d", row.Blocked),
+				ui.FormatHours(row.Remaining),
+				ui.FormatHours(row.Logged),
+			}
+			if capacity > 0 {
+				load := row.load(capacity)
+				cells = append(cells, ui.FormatPercentage(load))
+				if load > 100 && row.Assignee != unassignedLabel {
+					overloaded = append(overloaded, row)
+				}
+			}
+			table.Row(cells...)
+		}
+		table.PrintSimple()
+		fmt.Println()
+
+		for _, row := range overloaded {
+			available := math.Max(capacity-row.Logged, 0)
+			ui.Red.Printf("⚠️  %s is overloaded: %s remaining with %s of capacity left\n",
+				row.Assignee, ui.FormatHours(row.Remaining), ui.FormatHours(available))
+		}
+		if capacity > 0 && len(overloaded) == 0 {
+			ui.Green.Println("✨ Everyone is within capacity")
+		}
+		return nil
+	},
+}
+
+// workloadRow aggregates open work for one assignee
+type workloadRow struct {
+	Assignee  string  `json:"assignee"`
+	Open      int     `json:"open"`
+	Doing     int     `json:"doing"`
+	Blocked   int     `json:"blocked"`
+	Remaining float64 `json:"remaining_hours"`
+	Logged    float64 `json:"logged_hours"`
+}
+
+// workloadReport is the open work per assignee
+type workloadReport struct {
+	Project   string         `json:"project,omitempty"` // Empty for all projects
+	Sprint    string         `json:"sprint,omitempty"`
+	Capacity  float64        `json:"capacity"` // Hours per assignee; 0 when not set
+	Assignees []assigneeLoad `json:"assignees"`
+}
+
+// assigneeLoad is a workloadRow with its load against capacity; the assignee is
+// empty for unassigned tasks
+type assigneeLoad struct {
+	workloadRow
+	Load       *float64 `json:"load,omitempty"` // Percent of capacity
+	Overloaded bool     `json:"overloaded"`
+}
+
+// load returns logged plus remaining hours as a percentage of capacity
+func (r workloadRow) load(capacity float64) float64 {
+	return (r.Logged + r.Remaining) / capacity * 100
+}
+
+// findWorkloadSprint returns the named sprint, or the active one when name is empty
+func findWorkloadSprint(project *models.Project, name string) (*models.Sprint, error) {
+	today := time.Now().Format("2006-01-02")
+	for i := range project.Sprints {
+		sprint := &project.Sprints[i]
+		if name != "" && sprint.Name == name {
+			return sprint, nil
+		}
+		if name == "" && sprint.StartDate <= today && today <= sprint.EndDate {
+			return sprint, nil
+		}
+	}
+
+	if name != "" {
+		return nil, notFound("sprint not found: %s", name)
+	}
+	return nil, nil
+}
+
+// buildWorkload groups tasks by assignee, limited to the sprint's tasks and dates when given
+func buildWorkload(projects []*models.Project, sprint *models.Sprint) []workloadRow {
+	byAssignee := make(map[string]*workloadRow)
+
+	var inSprint map[string]bool
+	if sprint != nil {
+		inSprint = make(map[string]bool, len(sprint.TaskIDs))
+		for _, id := range sprint.TaskIDs {
+			inSprint[id] = true
+		}
+	}
+
+	for _, project := range projects {
+		for _, task := range project.GetAllTasks() {
+			if inSprint != nil && !inSprint[task.ID] {
+				continue
+			}
+
+			assignee := task.Assignee
+			if assignee == "" {
+				assignee = unassignedLabel
+			}
+			row, ok := byAssignee[assignee]
+			if !ok {
+				row = &workloadRow{Assignee: assignee}
+				byAssignee[assignee] = row
+			}
+
+			for _, entry := range task.TimeEntries {
+				if sprint == nil || (entry.Date >= sprint.StartDate && entry.Date <= sprint.EndDate) {
+					row.Logged += entry.Hours
+				}
+			}
+
+			if task.Status.IsDone() {
+				continue
+			}
+
+			row.Open++
+			switch task.Status {
+			case models.StatusDoing:
+				row.Doing++
+			case models.StatusBlocked:
+				row.Blocked++
+			}
+			row.Remaining += math.Max(task.EstimatedHours-task.CalculateActualHours(), 0)
+		}
+	}
+
+	rows := make([]workloadRow, 0, len(byAssignee))
+	for _, row := range byAssignee {
+		rows = append(rows, *row)
+	}
+	sort.Slice(rows, func(i, j int) bool {
+		if (rows[i].Assignee == unassignedLabel) != (rows[j].Assignee == unassignedLabel) {
+			return rows[j].Assignee == unassignedLabel
+		}
+		if rows[i].Remaining != rows[j].Remaining {
+			return rows[i].Remaining > rows[j].Remaining
+		}
+		return rows[i].Assignee < rows[j].Assignee
+	})
+
+	return rows
+}
+
+func init() {
+	reportWorkloadCmd.Flags().String("sprint", "", "Limit to a sprint (defaults to the project's active sprint)")
+	reportWorkloadCmd.Flags().Float64("capacity", 0, "Hours available per assignee (overrides sprint capacity)")
+	reportWorkloadCmd.ValidArgsFunction = projectArgCompletion
+	reportWorkloadCmd.RegisterFlagCompletionFunc("sprint", completeSprintFlag)
+
+	reportCmd.AddCommand(reportWorkloadCmd)
+}
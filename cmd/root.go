@@ -1,10 +1,16 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
 	"os"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/mrbooshehri/qix-go/internal/config"
+	"github.com/mrbooshehri/qix-go/internal/encryption"
+	"github.com/mrbooshehri/qix-go/internal/i18n"
 	"github.com/mrbooshehri/qix-go/internal/logging"
 	"github.com/mrbooshehri/qix-go/internal/storage"
 	"github.com/mrbooshehri/qix-go/internal/ui"
@@ -16,6 +22,18 @@ var (
 	noColor      bool
 	verbose      bool
 	logLevelFlag string
+	profileFlag  string
+	jsonOutput   bool
+	porcelain    bool
+	tableFormat  string
+	dateDisplay  string
+	asciiOutput  bool
+	noPager      bool
+	dryRunFlag   bool
+
+	// exitStatus is the status a command reports through --porcelain, e.g. 1 when
+	// 'track status' finds nothing being tracked
+	exitStatus int
 )
 
 // rootCmd represents the base command
@@ -31,16 +49,23 @@ var rootCmd = &cobra.Command{
   • Plan sprints and recurring tasks
 
 Version 2.0 - Rewritten in Go for blazing fast performance.`,
+	// Execute prints the error a command fails with and exits with its status
+	SilenceErrors: true,
+	SilenceUsage:  true,
 	PersistentPreRun: func(cmd *cobra.Command, args []string) {
 		// Initialize configuration
+		if cmd.Flags().Changed("profile") {
+			config.SetProfile(profileFlag)
+		}
 		if err := config.Init(); err != nil {
-			ui.PrintError("Failed to initialize configuration: %v", err)
-			os.Exit(1)
+			fatal("Failed to initialize configuration: %v", err)
 		}
+		// Before anything could write data
+		storage.SetDryRun(dryRunning(cmd))
 
 		// Initialize logging before other subsystems
 		cfg := config.Get()
-		if err := logging.Init(cfg.LogFile); err != nil {
+		if err := logging.Init(cfg.LogFile, logRotation(cfg)); err != nil {
 			fmt.Fprintf(os.Stderr, "Failed to initialize logging: %v\n", err)
 		}
 
@@ -48,42 +73,238 @@ Version 2.0 - Rewritten in Go for blazing fast performance.`,
 			cfg.LogLevel = logLevelFlag
 		}
 		logging.SetLevel(cfg.LogLevel)
-		logging.Infof("Starting command: %s %v", cmd.CommandPath(), args)
+		if verbose {
+			logging.SetVerbose(os.Stderr)
+			ui.SetVerbose(true)
+		}
+		startCommandLog(cfg, cmd, args)
 
 		// Override color setting if --no-color flag is used
 		if noColor {
 			cfg.ColorOutput = false
 		}
+		if asciiOutput {
+			cfg.ASCIIOutput = true
+		}
 
 		// Initialize UI
 		ui.Init()
+		if jsonOutput {
+			ui.EnableJSON()
+		}
+		if porcelain {
+			ui.EnablePorcelain()
+		}
+		if err := ui.SetTableFormat(tableFormat); err != nil {
+			fatal("%v", err)
+		}
+		if err := applyListing(cmd); err != nil {
+			fatal("%v", err)
+		}
+		if cmd.Flags().Changed("dates") {
+			cfg.DateDisplay = dateDisplay
+		}
+		if err := ui.SetDateDisplay(cfg.DateDisplay); err != nil {
+			fatal("%v", err)
+		}
+		if err := ui.SetWeekStart(cfg.WeekStart); err != nil {
+			ui.PrintWarning("Ignoring week_start: %v", err)
+		}
+		if cfg.UsePager && !noPager && !verbose && !jsonOutput && !porcelain && ui.TableFormat() != ui.TableFormatCSV && !watching(cmd) && pagesOutput(cmd) {
+			ui.StartPager(cfg.Pager)
+		}
+		// After JSON, which keeps the original stdout for the document itself, and
+		// the pager, which should only see the converted output
+		if cfg.ASCIIOutput {
+			ui.EnableASCII()
+		}
+		if err := config.ApplyStatuses(); err != nil {
+			ui.PrintWarning("Ignoring custom statuses: %v", err)
+		}
+		if err := ui.SetTheme(cfg.Theme, config.ThemeColors()); err != nil {
+			ui.PrintWarning("Ignoring theme: %v", err)
+		}
+		if err := i18n.SetLanguage(cfg.Language, cfg.LocalesDir); err != nil {
+			ui.PrintWarning("Ignoring language: %v", err)
+		}
+
+		// Before data is read, so a hook can bring it up to date
+		if hook, err := runBeforeHooks(cmd); err != nil {
+			fatal("Command hook %s failed: %v", hook, err)
+		}
+
+		// Set up encryption at rest before any data is read
+		if err := encryption.Init(cfg.EncryptionPassphrase, cfg.EncryptionKeyFile); err != nil {
+			fatal("Failed to initialize encryption: %v", err)
+		}
 
 		// Initialize storage
 		if err := storage.Init(); err != nil {
-			ui.PrintError("Failed to initialize storage: %v", err)
-			os.Exit(1)
+			fatal("Failed to initialize storage: %v", err)
 		}
+		setUpEventBus()
+
+		// Send report output to a file if requested
+		if err := openReportOutput(cmd); err != nil {
+			fatal("%v", err)
+		}
+		commandLog.run = time.Now()
 	},
 	PersistentPostRun: func(cmd *cobra.Command, args []string) {
+		commandLog.save = time.Now()
+		closeReportOutput()
+
 		// Flush any cached changes
 		if err := storage.Get().FlushAll(); err != nil {
 			ui.PrintWarning("Failed to save all changes: %v", err)
 		}
+		if storage.DryRun() {
+			// Nothing was saved, so there is nothing to publish or commit
+			printDryRun(cmd, storage.Get().Changes())
+			return
+		}
+
+		// Publish the changes to the event bus
+		publishEvents()
+
+		// Record the command's changes if the data directory is versioned with git
+		autoCommitData(cmd, args)
 	},
 }
 
-// Execute runs the root command
+// Execute runs the root command and exits with its status: 0 on success, the
+// status of the error a command failed with (see exitCode), 1 if it printed
+// an error, or the status it set for --porcelain
 func Execute() {
-	if err := rootCmd.Execute(); err != nil {
-		os.Exit(1)
+	commandLog.start = time.Now()
+	applyAliases()
+	checkUsage(rootCmd)
+	rootCmd.SetFlagErrorFunc(func(cmd *cobra.Command, err error) error {
+		return usageError(err)
+	})
+	cmd, err := rootCmd.ExecuteC()
+
+	status := exitStatus
+	if err != nil {
+		var cmdErr *commandError
+		if !errors.As(err, &cmdErr) && commandLog.run.IsZero() {
+			// cobra's, for an unknown command
+			err = usageError(err)
+		}
+		printCommandError(err)
+		status = exitCode(err)
+		if errors.As(err, &cmdErr) && cmdErr.usage {
+			ui.Dim.Printf("Run '%s --help' for usage.\n", cmd.CommandPath())
+		}
+		// cobra skips the post-run of a command that returns an error; save
+		// what it changed before failing all the same
+		if !commandLog.run.IsZero() && commandLog.save.IsZero() {
+			rootCmd.PersistentPostRun(cmd, cmd.Flags().Args())
+		}
+	} else if ui.ErrorsPrinted() {
+		status = exitFailure
+	}
+	if !commandLog.run.IsZero() {
+		runAfterHooks(cmd, status)
+	}
+
+	ui.FlushOutput()
+	endCommandLog(err)
+	recordUsage(err != nil || ui.ErrorsPrinted())
+	logging.Close()
+	os.Exit(status)
+}
+
+// fatal prints an error and exits before the command runs, with the status
+// the errors among args call for
+func fatal(format string, args ...interface{}) {
+	ui.PrintError(format, args...)
+	ui.FlushOutput()
+	endCommandLog(nil)
+	logging.Close()
+	os.Exit(exitCode(fail(format, args...)))
+}
+
+// logRotation returns when the log is rotated and how long rotated logs are kept
+func logRotation(cfg *config.Config) logging.Rotation {
+	return logging.Rotation{
+		MaxSize:   int64(cfg.LogMaxSizeKB) * 1024,
+		MaxAge:    time.Duration(cfg.LogRotateDays) * 24 * time.Hour,
+		Retention: time.Duration(cfg.LogRetentionDays) * 24 * time.Hour,
+	}
+}
+
+// pagerAnnotation marks commands whose output is shown through the pager
+const pagerAnnotation = "pager"
+
+// usePager sets whether the output of commands and their subcommands is shown
+// through the pager. Only commands that never prompt for input should use it.
+func usePager(enabled bool, cmds ...*cobra.Command) {
+	for _, cmd := range cmds {
+		if cmd.Annotations == nil {
+			cmd.Annotations = make(map[string]string)
+		}
+		cmd.Annotations[pagerAnnotation] = fmt.Sprint(enabled)
+	}
+}
+
+// pagesOutput reports whether a command's output is shown through the pager,
+// as set for it or its nearest parent
+func pagesOutput(cmd *cobra.Command) bool {
+	for c := cmd; c != nil; c = c.Parent() {
+		if enabled, ok := c.Annotations[pagerAnnotation]; ok {
+			return enabled == "true"
+		}
+	}
+	return false
+}
+
+// noJSONAnnotation marks commands that have no JSON output
+const noJSONAnnotation = "no-json"
+
+// noJSON marks commands, and their subcommands, that have no JSON output, so
+// they fail with a usage error when run with --json rather than ignore it
+func noJSON(cmds ...*cobra.Command) {
+	for _, cmd := range cmds {
+		if cmd.Annotations == nil {
+			cmd.Annotations = make(map[string]string)
+		}
+		cmd.Annotations[noJSONAnnotation] = "true"
+	}
+}
+
+// printsJSON reports whether a command has JSON output, i.e. neither it nor
+// a parent was marked by noJSON
+func printsJSON(cmd *cobra.Command) bool {
+	for c := cmd; c != nil; c = c.Parent() {
+		if c.Annotations[noJSONAnnotation] == "true" {
+			return false
+		}
+	}
+	return true
+}
+
+// setPorcelainStatus sets the exit status reported with --porcelain
+func setPorcelainStatus(status int) {
+	if porcelain {
+		exitStatus = status
 	}
 }
 
 func init() {
 	// Global flags
 	rootCmd.PersistentFlags().BoolVar(&noColor, "no-color", false, "Disable colored output")
-	rootCmd.PersistentFlags().BoolVarP(&verbose, "verbose", "v", false, "Enable verbose output")
+	rootCmd.PersistentFlags().BoolVarP(&verbose, "verbose", "v", false, "Print storage operations, cache hits and misses, timings and full error chains to stderr")
 	rootCmd.PersistentFlags().StringVar(&logLevelFlag, "log-level", "info", "Log level (debug, info, warn, error)")
+	rootCmd.PersistentFlags().BoolVar(&jsonOutput, "json", false, "Print machine-readable JSON on stdout (other output goes to stderr)")
+	rootCmd.PersistentFlags().BoolVar(&porcelain, "porcelain", false, "Print stable tab-separated output for scripts, with exit codes that reflect state")
+	rootCmd.MarkFlagsMutuallyExclusive("json", "porcelain")
+	rootCmd.PersistentFlags().StringVar(&tableFormat, "table-format", "text", "Print tables as text, markdown or csv (csv tables go to stdout, other output to stderr)")
+	rootCmd.PersistentFlags().StringVar(&dateDisplay, "dates", "absolute", "Show dates as absolute, relative (\"in 3 days\") or both")
+	rootCmd.PersistentFlags().BoolVar(&asciiOutput, "ascii", false, "Draw with plain ASCII instead of emoji and box-drawing characters")
+	rootCmd.PersistentFlags().BoolVar(&noPager, "no-pager", false, "Print long output directly instead of through the pager")
+	rootCmd.PersistentFlags().StringVar(&profileFlag, "profile", "", "Use the data directory of a profile defined in the config (overrides QIX_PROFILE)")
+	rootCmd.PersistentFlags().BoolVar(&dryRunFlag, "dry-run", false, "Show what the command would change without changing anything")
 
 	// Add subcommands
 	rootCmd.AddCommand(projectCmd)
@@ -93,43 +314,72 @@ func init() {
 	rootCmd.AddCommand(reportCmd)
 	rootCmd.AddCommand(sprintCmd)
 	rootCmd.AddCommand(backupCmd)
+	doctorCmd.Flags().Bool("rehash", false, "Accept project files edited outside qix by recording new checksums")
+	doctorCmd.Flags().Bool("fix", false, "Fix time entry problems without asking")
 	rootCmd.AddCommand(doctorCmd)
 	rootCmd.AddCommand(versionCmd)
 	rootCmd.AddCommand(jiraCmd)
 }
 
+// version is the qix release, as shown by 'qix version' and in the man pages
+const version = "2.0.0"
+
 // versionCmd displays version information
 var versionCmd = &cobra.Command{
 	Use:   "version",
 	Short: "Display version information",
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if jsonOutput {
+			printJSON(versionInfo{Version: version, Go: getGoVersion()})
+			return nil
+		}
 		ui.PrintHeader("QIX - Quick Insight X")
-		fmt.Println("Version:    2.0.0")
+		fmt.Println("Version:    " + version)
 		fmt.Println("Build:      Go " + getGoVersion())
 		fmt.Println("Author:     mrbooshehri")
 		fmt.Println("License:    MIT")
 		fmt.Println()
 		ui.PrintInfo("Rewritten in Go for 100x performance improvement!")
+		return nil
 	},
 }
 
+// versionInfo is what 'version' shows, for --json
+type versionInfo struct {
+	Version string `json:"version"`
+	Go      string `json:"go"`
+}
+
 // doctorCmd checks system health
 var doctorCmd = &cobra.Command{
 	Use:   "doctor",
 	Short: "Check data integrity and system health",
-	Run: func(cmd *cobra.Command, args []string) {
-		runDoctor()
+	Long: `Check data integrity and system health: directories, project files, the task
+index, task relationships and time entries.
+
+Time entries with negative hours, over 24 hours, dated in the future or
+logged twice, days with over 24 hours logged, and a tracking session on a
+task that was deleted are reported. On a terminal doctor offers to fix each
+one; --fix fixes them all without asking. Days with over 24 hours across
+several entries are left to fix by hand.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		rehash, _ := cmd.Flags().GetBool("rehash")
+		fix, _ := cmd.Flags().GetBool("fix")
+		runDoctor(rehash, fix)
+		return nil
 	},
 }
 
-func runDoctor() {
+// runDoctor runs all health checks. With rehash, project files that fail checksum
+// verification but are still valid JSON are accepted as they are. With fix,
+// time entry problems are fixed without asking.
+func runDoctor(rehash, fix bool) {
 	ui.PrintHeader("QIX Doctor - System Health Check")
 
 	store := storage.Get()
 	cfg := config.Get()
 
-	issues := 0
-	warnings := 0
+	findings := doctorFindings{Issues: []string{}, Warnings: []string{}}
 
 	// 1. Check directories
 	ui.PrintSubHeader("📁 Checking directories...")
@@ -137,8 +387,7 @@ func runDoctor() {
 	dirs := []string{cfg.QixDir, cfg.ProjectsDir, cfg.BackupDir}
 	for _, dir := range dirs {
 		if _, err := os.Stat(dir); os.IsNotExist(err) {
-			ui.PrintError("Directory missing: %s", dir)
-			issues++
+			findings.issue("Directory missing: %s", dir)
 		} else {
 			ui.PrintSuccess("Directory exists: %s", dir)
 		}
@@ -152,8 +401,7 @@ func runDoctor() {
 	if err == nil {
 		perms := info.Mode().Perm()
 		if perms != 0700 {
-			ui.PrintWarning("QIX directory permissions: %o (recommended: 700)", perms)
-			warnings++
+			findings.warn("QIX directory permissions: %o (recommended: 700)", perms)
 		} else {
 			ui.PrintSuccess("QIX directory permissions secure (700)")
 		}
@@ -165,28 +413,54 @@ func runDoctor() {
 
 	projects, err := store.ListProjects()
 	if err != nil {
-		ui.PrintError("Failed to list projects: %v", err)
-		issues++
+		findings.issue("Failed to list projects: %v", err)
 	} else {
 		ui.PrintInfo("Found %d project(s)", len(projects))
 
 		for _, name := range projects {
-			if _, err := store.LoadProject(name); err != nil {
-				ui.PrintError("Corrupted project: %s (%v)", name, err)
-				issues++
+			var checksumErr *storage.ChecksumError
+			err := store.VerifyProject(name)
+			if errors.As(err, &checksumErr) && rehash {
+				if err = store.RehashProject(name); err == nil {
+					ui.PrintInfo("Recorded new checksum: %s", name)
+				}
+			}
+			if err == nil {
+				_, err = store.LoadProject(name)
+			}
+
+			if errors.As(err, &checksumErr) {
+				findings.issue("Checksum mismatch: %s (corrupted, or edited outside qix; if intended, run: qix doctor --rehash)", name)
+			} else if err != nil {
+				findings.issue("Corrupted project: %s (%v)", name, err)
 			} else {
 				ui.PrintSuccess("Valid: %s", name)
 			}
 		}
 	}
+
+	if files, err := store.SchemaStatus(); err == nil {
+		outdated := 0
+		for _, file := range files {
+			if file.NeedsMigration() {
+				outdated++
+			}
+		}
+		if outdated > 0 {
+			findings.warn("%d data file(s) use an older schema (run: qix migrate)", outdated)
+		}
+	}
+
+	if pending, err := store.PendingJournal(); err == nil && len(pending) > 0 {
+		findings.warn("%d journaled change(s) were never confirmed as saved (run: qix journal recover)", len(pending))
+	}
 	fmt.Println()
 
 	// 4. Check index
 	ui.PrintSubHeader("📇 Checking task index...")
 
 	if err := store.EnsureIndexFresh(); err != nil {
-		ui.PrintError("Index error: %v", err)
-		issues++
+		findings.issue("Index error: %v", err)
 	} else {
 		ui.PrintSuccess("Index is up to date")
 	}
@@ -196,17 +470,38 @@ func runDoctor() {
 
 	// Validate index
 	if errors, err := store.ValidateIndex(); err != nil {
-		ui.PrintError("Index validation failed: %v", err)
-		issues++
+		findings.issue("Index validation failed: %v", err)
 	} else if len(errors) > 0 {
 		ui.PrintWarning("Index inconsistencies found:")
 		for _, e := range errors {
 			ui.Dim.Println("  • " + e)
 		}
-		warnings += len(errors)
+		findings.Warnings = append(findings.Warnings, errors...)
 	} else {
 		ui.PrintSuccess("Index is consistent")
 	}
+
+	// The index holds one task per ID, so tasks sharing one can't all be found
+	duplicates, err := store.DuplicateTaskIDs()
+	if err != nil {
+		findings.issue("Failed to check task IDs: %v", err)
+	} else if len(duplicates) > 0 {
+		ids := make([]string, 0, len(duplicates))
+		for id := range duplicates {
+			ids = append(ids, id)
+		}
+		sort.Strings(ids)
+		for _, id := range ids {
+			places := make([]string, 0, len(duplicates[id]))
+			for _, place := range duplicates[id] {
+				places = append(places, fmt.Sprintf("%s (%s)", place, place.Title))
+			}
+			findings.issue("Task ID %s is shared by: %s", id, strings.Join(places, ", "))
+		}
+		ui.Dim.Println("  Give one of each a new ID with: qix task reid <project[/module]> <task_id>")
+	} else {
+		ui.PrintSuccess("Task IDs are unique")
+	}
 	fmt.Println()
 
 	// 5. Check for orphaned references
@@ -224,6 +519,7 @@ func runDoctor() {
 				ui.PrintWarning("Orphaned %s in %s:", refType, projectName)
 				for _, ref := range refs {
 					ui.Dim.Println("  • " + ref)
+					findings.Warnings = append(findings.Warnings, fmt.Sprintf("Orphaned %s in %s: %s", refType, projectName, ref))
 				}
 				orphanCount += len(refs)
 			}
@@ -232,22 +528,54 @@ func runDoctor() {
 
 	if orphanCount == 0 {
 		ui.PrintSuccess("No orphaned references found")
-	} else {
-		warnings += orphanCount
 	}
 	fmt.Println()
 
-	// 6. Cache statistics
+	// 6. Check time entries
+	ui.PrintSubHeader("⏱️  Checking time entries...")
+
+	anomalies := findTimeAnomalies(store, projects, time.Now())
+	fixable := 0
+	for _, anomaly := range anomalies {
+		findings.warn("%s", anomaly.Problem)
+		if anomaly.Fix != "" {
+			fixable++
+		}
+	}
+	if len(anomalies) == 0 {
+		ui.PrintSuccess("No time entry problems found")
+	} else if fixable > 0 && (fix || ui.Interactive()) {
+		fixed, err := fixTimeAnomalies(store, anomalies, fix)
+		if err != nil {
+			findings.issue("%v", err)
+		}
+		if fixed > 0 {
+			ui.PrintSuccess("Fixed %d time entry problem(s)", fixed)
+		}
+		findings.Fixed = fixed
+	}
+	fmt.Println()
+
+	// 7. Cache statistics
 	ui.PrintSubHeader("💾 Cache statistics...")
 
 	cacheStats := store.GetCacheStats()
-	ui.PrintInfo("Cached projects: %v", cacheStats["cached_projects"])
+	if max, _ := cacheStats["max_projects"].(int); max > 0 {
+		ui.PrintInfo("Cached projects: %v (limit %d)", cacheStats["cached_projects"], max)
+	} else {
+		ui.PrintInfo("Cached projects: %v (unlimited)", cacheStats["cached_projects"])
+	}
 	ui.PrintInfo("Dirty projects:  %v", cacheStats["dirty_projects"])
+	ui.PrintInfo("Hits / misses:   %v / %v (%.1f%% hit rate)", cacheStats["hits"], cacheStats["misses"], cacheStats["hit_rate"])
+	ui.PrintInfo("Evictions:       %v", cacheStats["evictions"])
 	fmt.Println()
 
 	// Summary
 	ui.PrintSeparator()
 
+	issues := len(findings.Issues)
+	warnings := len(findings.Warnings) - findings.Fixed
+
 	if issues == 0 && warnings == 0 {
 		ui.PrintSuccess("All checks passed! Your QIX installation is healthy. ✨")
 	} else if issues == 0 {
@@ -258,6 +586,9 @@ func runDoctor() {
 		if orphanCount > 0 {
 			ui.Dim.Println("  • Remove orphaned references manually or recreate relationships")
 		}
+		if fixable > 0 && !fix {
+			ui.Dim.Println("  • Run 'qix doctor --fix' to fix time entry problems")
+		}
 	} else {
 		ui.PrintError("%d issue(s) and %d warning(s) found", issues, warnings)
 		fmt.Println()
@@ -266,6 +597,29 @@ func runDoctor() {
 		ui.Dim.Println("  • Run 'qix backup create' to create a safety backup")
 		ui.Dim.Println("  • Re-run doctor after fixing issues")
 	}
+
+	if jsonOutput {
+		printJSON(findings)
+	}
+}
+
+// doctorFindings are the problems doctor found, printed for --json
+type doctorFindings struct {
+	Issues   []string `json:"issues"`
+	Warnings []string `json:"warnings"`
+	Fixed    int      `json:"fixed"` // Warnings about time entries that were fixed
+}
+
+// issue reports a problem with the data
+func (f *doctorFindings) issue(format string, args ...interface{}) {
+	ui.PrintError(format, args...)
+	f.Issues = append(f.Issues, fmt.Sprintf(format, args...))
+}
+
+// warn reports a non-critical problem
+func (f *doctorFindings) warn(format string, args ...interface{}) {
+	ui.PrintWarning(format, args...)
+	f.Warnings = append(f.Warnings, fmt.Sprintf(format, args...))
 }
 
 func getGoVersion() string {
@@ -0,0 +1,122 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mrbooshehri/qix-go/internal/storage"
+	"github.com/mrbooshehri/qix-go/internal/ui"
+	"github.com/mrbooshehri/qix-go/pkg/qix/models"
+	"github.com/spf13/cobra"
+)
+
+var searchCmd = &cobra.Command{
+	Use:   "search <query>",
+	Short: "Search tasks across all projects",
+	Long: `Search task titles, descriptions, tags, Jira issues and module names.
+
+Results must contain every word of the query; a word also matches the start
+of a longer word ("auth" finds "authentication"). Title matches rank highest.
+With --porcelain, exits 1 when nothing matches.
+
+Examples:
+  qix search login bug
+  qix search deploy --project web --status todo`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		projectName, _ := cmd.Flags().GetString("project")
+		statusFilter, _ := cmd.Flags().GetString("status")
+		limit, _ := cmd.Flags().GetInt("limit")
+		query := strings.Join(args, " ")
+
+		store := storage.Get()
+
+		results, err := store.Search(query)
+		if err != nil {
+			return fail("Search failed: %v", err)
+		}
+
+		filtered := make([]storage.SearchResult, 0, len(results))
+		for _, result := range results {
+			if projectName != "" && result.Project != projectName {
+				continue
+			}
+			if statusFilter != "" && result.Status != models.TaskStatus(statusFilter) {
+				continue
+			}
+			filtered = append(filtered, result)
+		}
+
+		shown := filtered
+		if limit > 0 && len(shown) > limit {
+			shown = shown[:limit]
+		}
+		if len(shown) == 0 {
+			setPorcelainStatus(1)
+		}
+
+		if jsonOutput {
+			matches := make([]searchMatch, 0, len(shown))
+			for _, result := range shown {
+				matches = append(matches, searchMatch{
+					taskRef: taskRef{ID: result.TaskID, Title: result.Title, Status: result.Status},
+					Project: result.Project,
+					Module:  locationModule(result.Location),
+					Score:   result.Score,
+				})
+			}
+			printJSON(matches)
+			return nil
+		}
+		if porcelain {
+			// ID, status, project, module, title
+			for _, result := range shown {
+				ui.PrintRecord(result.TaskID, string(result.Status), result.Project, locationModule(result.Location), result.Title)
+			}
+			return nil
+		}
+
+		ui.PrintHeader(fmt.Sprintf("🔍 Search: %s", query))
+
+		if len(filtered) == 0 {
+			ui.PrintEmptyState("No matching tasks", "Try fewer or shorter words")
+			return nil
+		}
+
+		for _, result := range shown {
+			path := result.Project
+			if strings.HasPrefix(result.Location, "module:") {
+				path += "/" + strings.TrimPrefix(result.Location, "module:")
+			}
+			fmt.Printf("%s ", ui.GetStatusIcon(result.Status))
+			ui.Cyan.Printf("[%s] ", result.TaskID)
+			fmt.Print(result.Title)
+			ui.Dim.Printf("  %s\n", path)
+		}
+
+		if len(shown) < len(filtered) {
+			fmt.Println()
+			ui.Dim.Printf("Showing %d of %d matches (use --limit to see more)\n", len(shown), len(filtered))
+		}
+		return nil
+	},
+}
+
+// searchMatch is a search result as shown by --json, best match first
+type searchMatch struct {
+	taskRef
+	Project string  `json:"project"`
+	Module  string  `json:"module,omitempty"`
+	Score   float64 `json:"score"`
+}
+
+func init() {
+	searchCmd.Flags().StringP("project", "p", "", "Only search one project")
+	searchCmd.Flags().StringP("status", "s", "", "Only show tasks with this status (todo, doing, done, blocked, or one set in the config)")
+	searchCmd.RegisterFlagCompletionFunc("status", completeStatuses)
+	searchCmd.Flags().IntP("limit", "n", 20, "Maximum number of results (0 for all)")
+
+	rootCmd.AddCommand(searchCmd)
+
+	usePager(true, searchCmd)
+}
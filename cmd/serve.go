@@ -0,0 +1,341 @@
+package cmd
+
+import (
+	"crypto/subtle"
+	"fmt"
+	"net"
+	"net/http"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mrbooshehri/qix-go/internal/config"
+	"github.com/mrbooshehri/qix-go/internal/ics"
+	"github.com/mrbooshehri/qix-go/internal/logging"
+	"github.com/mrbooshehri/qix-go/internal/storage"
+	"github.com/mrbooshehri/qix-go/internal/ui"
+	"github.com/mrbooshehri/qix-go/pkg/qix/models"
+)
+
+var serveCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Serve qix data over HTTP",
+}
+
+var serveICSCmd = &cobra.Command{
+	Use:   "ics",
+	Short: "Serve a calendar feed of sprints and due dates",
+	Long: `Serve a live iCalendar feed that calendar apps (Google Calendar, Outlook,
+Apple Calendar, Thunderbird) can subscribe to. It has an all-day event for
+each sprint, for each task's due date, and a repeating event for each
+recurring task from its next due date. The feed is read afresh on every
+request, so subscribed calendars follow changes as they refresh.
+
+Due dates of tasks that are done are left out unless --done is given.
+--project limits the feed to some projects; subscribers can also pick with
+?project=a,b, among those of --project if it is given.
+
+The server listens on localhost unless --addr says otherwise, e.g.
+--addr :8099 to reach it from other machines. Set --token to require
+?token=<token> in the feed URL.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		addr, _ := cmd.Flags().GetString("addr")
+		projects, _ := cmd.Flags().GetStringSlice("project")
+		token, _ := cmd.Flags().GetString("token")
+		includeDone, _ := cmd.Flags().GetBool("done")
+		for _, name := range projects {
+			if err := storage.ValidateProjectName(name); err != nil {
+				return invalid("%v", err)
+			}
+		}
+
+		listener, err := net.Listen("tcp", addr)
+		if err != nil {
+			return fail("Failed to listen on %s: %v", addr, err)
+		}
+
+		feed := &icsFeed{projects: projects, token: token, includeDone: includeDone}
+		mux := http.NewServeMux()
+		mux.Handle("/", feed)
+		server := &http.Server{Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+
+		ui.PrintSuccess("Serving the calendar feed at %s", serveURL(listener.Addr(), "/qix.ics", token))
+		ui.Dim.Println("  Subscribe to it from your calendar app. Press Ctrl+C to stop.")
+		if err := server.Serve(listener); err != nil && err != http.ErrServerClosed {
+			return fail("Server stopped: %v", err)
+		}
+		return nil
+	},
+}
+
+// serveMu makes served requests read storage one at a time
+var serveMu sync.Mutex
+
+// icsFeed serves the calendar, built from the data directory on each request
+type icsFeed struct {
+	projects    []string
+	token       string
+	includeDone bool
+}
+
+func (f *icsFeed) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/" && r.URL.Path != "/qix.ics" {
+		http.NotFound(w, r)
+		return
+	}
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if !checkToken(w, r, f.token) {
+		return
+	}
+
+	names, status, err := f.requested(r.URL.Query().Get("project"))
+	if err != nil {
+		http.Error(w, err.Error(), status)
+		return
+	}
+
+	calendar, status, err := f.calendar(names)
+	if err != nil {
+		logging.Warnf("Calendar feed failed: %v", err)
+		http.Error(w, err.Error(), status)
+		return
+	}
+	logging.Infof("Served calendar feed (%d events) to %s", len(calendar.Events), r.RemoteAddr)
+
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	w.Header().Set("Content-Disposition", `inline; filename="qix.ics"`)
+	w.Header().Set("Cache-Control", "no-cache")
+	if r.Method == http.MethodHead {
+		return
+	}
+	calendar.WriteTo(w)
+}
+
+// requested returns the projects a request picks with ?project=, which must
+// be among those the feed serves, or the feed's own if it picks none. On
+// failure it returns the HTTP status to answer with.
+func (f *icsFeed) requested(query string) ([]string, int, error) {
+	if query == "" {
+		return f.projects, http.StatusOK, nil
+	}
+	names := make([]string, 0)
+	for _, name := range strings.Split(query, ",") {
+		name = strings.TrimSpace(name)
+		if err := storage.ValidateProjectName(name); err != nil {
+			return nil, http.StatusBadRequest, err
+		}
+		if len(f.projects) > 0 && !slices.Contains(f.projects, name) {
+			return nil, http.StatusNotFound, fmt.Errorf("project not found: %s", name)
+		}
+		names = append(names, name)
+	}
+	return names, http.StatusOK, nil
+}
+
+// calendar reads the projects named, or all of them, and builds the feed. On
+// failure it returns the HTTP status to answer with.
+func (f *icsFeed) calendar(names []string) (*ics.Calendar, int, error) {
+	serveMu.Lock()
+	defer serveMu.Unlock()
+
+	store := storage.Get()
+	// Pick up changes other qix commands made since the last request
+	store.ClearCache()
+
+	var projects []*models.Project
+	if len(names) == 0 {
+		all, err := store.GetAllProjects()
+		if err != nil {
+			return nil, http.StatusInternalServerError, err
+		}
+		projects = all
+	} else {
+		for _, name := range names {
+			project, err := store.LoadProject(name)
+			if err != nil {
+				return nil, http.StatusNotFound, fmt.Errorf("project not found: %s", name)
+			}
+			projects = append(projects, project)
+		}
+	}
+
+	return buildCalendar(projects, f.includeDone, config.Get().JiraBaseURL), http.StatusOK, nil
+}
+
+// buildCalendar returns the events of sprints, due dates and recurring tasks
+func buildCalendar(projects []*models.Project, includeDone bool, jiraBaseURL string) *ics.Calendar {
+	calendar := &ics.Calendar{Name: "qix", Method: "PUBLISH", Events: make([]ics.Event, 0)}
+	if len(projects) == 1 {
+		calendar.Name = "qix: " + projects[0].Name
+	}
+
+	for _, project := range projects {
+		modules := taskModules(project)
+
+		for _, sprint := range project.Sprints {
+			start, errStart := time.Parse("2006-01-02", sprint.StartDate)
+			end, errEnd := time.Parse("2006-01-02", sprint.EndDate)
+			if errStart != nil || errEnd != nil {
+				continue
+			}
+			description := fmt.Sprintf("Project: %s\nTasks: %d", project.Name, len(sprint.TaskIDs))
+			stamp := sprint.CreatedAt
+			if sprint.ClosedAt != nil {
+				description += "\nClosed: " + sprint.ClosedAt.Format("2006-01-02")
+				stamp = *sprint.ClosedAt
+			}
+			calendar.Events = append(calendar.Events, ics.Event{
+				UID:         fmt.Sprintf("sprint-%s-%s@qix", project.Name, sprint.Name),
+				Summary:     fmt.Sprintf("Sprint %s (%s)", sprint.Name, project.Name),
+				Description: description,
+				Start:       start,
+				End:         end,
+				Categories:  []string{project.Name, "sprint"},
+				Stamp:       stamp,
+			})
+		}
+
+		for _, task := range project.GetAllTasks() {
+			event := ics.Event{
+				Description: taskEventDescription(project.Name, modules[task.ID], task),
+				Categories:  []string{project.Name},
+				Stamp:       task.UpdatedAt,
+			}
+			if task.JiraIssue != "" && jiraBaseURL != "" {
+				event.URL = strings.TrimRight(jiraBaseURL, "/") + "/" + task.JiraIssue
+			}
+
+			if rec := task.Recurrence; rec != nil && rec.Enabled && rec.NextDue != "" {
+				start, err := time.Parse("2006-01-02", rec.NextDue)
+				rule := recurrenceRule(*rec)
+				if err != nil || rule == "" {
+					continue
+				}
+				event.UID = fmt.Sprintf("recur-%s@qix", task.ID)
+				event.Summary = fmt.Sprintf("%s [%s]", task.Title, task.ID)
+				event.Start, event.End = start, start
+				event.RRule = rule
+				event.Categories = append(event.Categories, "recurring")
+				calendar.Events = append(calendar.Events, event)
+				continue
+			}
+
+			if task.DueDate == "" || (task.Status.IsDone() && !includeDone) {
+				continue
+			}
+			due, err := time.Parse("2006-01-02", task.DueDate)
+			if err != nil {
+				continue
+			}
+			event.UID = fmt.Sprintf("due-%s@qix", task.ID)
+			event.Summary = fmt.Sprintf("Due: %s [%s]", task.Title, task.ID)
+			event.Start, event.End = due, due
+			event.Categories = append(event.Categories, "due")
+			calendar.Events = append(calendar.Events, event)
+		}
+	}
+
+	sort.SliceStable(calendar.Events, func(i, j int) bool {
+		return calendar.Events[i].Start.Before(calendar.Events[j].Start)
+	})
+	return calendar
+}
+
+// taskEventDescription lists what a calendar event about a task shows
+func taskEventDescription(projectName, moduleName string, task models.Task) string {
+	path := projectName
+	if moduleName != "" {
+		path += "/" + moduleName
+	}
+	lines := []string{
+		"Project: " + path,
+		"Status: " + string(task.Status),
+		"Priority: " + string(task.Priority),
+	}
+	if task.Assignee != "" {
+		lines = append(lines, "Assignee: "+task.Assignee)
+	}
+	if task.JiraIssue != "" {
+		lines = append(lines, "Jira: "+task.JiraIssue)
+	}
+	if task.Description != "" {
+		lines = append(lines, "", task.Description)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// recurrenceRule returns the iCalendar RRULE of a task recurrence, or "" if it
+// can't be expressed as one
+func recurrenceRule(rec models.Recurrence) string {
+	switch rec.Type {
+	case models.RecurDaily:
+		return "FREQ=DAILY"
+	case models.RecurWeekly:
+		if day := ics.Weekday(rec.Value); day != "" {
+			return "FREQ=WEEKLY;BYDAY=" + day
+		}
+	case models.RecurMonthly:
+		day, err := strconv.Atoi(rec.Value)
+		if err != nil || day < 1 || day > 31 {
+			return ""
+		}
+		if day <= 28 {
+			return fmt.Sprintf("FREQ=MONTHLY;BYMONTHDAY=%d", day)
+		}
+		// Like qix, fall back to the month's last day when it is shorter
+		days := make([]string, 0, 4)
+		for d := 28; d <= day; d++ {
+			days = append(days, strconv.Itoa(d))
+		}
+		return fmt.Sprintf("FREQ=MONTHLY;BYMONTHDAY=%s;BYSETPOS=-1", strings.Join(days, ","))
+	case models.RecurInterval:
+		if days, err := strconv.Atoi(rec.Value); err == nil && days > 0 {
+			return fmt.Sprintf("FREQ=DAILY;INTERVAL=%d", days)
+		}
+	}
+	return ""
+}
+
+// checkToken answers 401 and returns false unless the request has ?token=
+// matching token, if one is required
+func checkToken(w http.ResponseWriter, r *http.Request, token string) bool {
+	if token == "" || subtle.ConstantTimeCompare([]byte(r.URL.Query().Get("token")), []byte(token)) == 1 {
+		return true
+	}
+	http.Error(w, "invalid token", http.StatusUnauthorized)
+	return false
+}
+
+// serveURL returns the address of path on a server listening on addr
+func serveURL(addr net.Addr, path, token string) string {
+	host := addr.String()
+	if tcp, ok := addr.(*net.TCPAddr); ok && tcp.IP.IsUnspecified() {
+		host = net.JoinHostPort("localhost", strconv.Itoa(tcp.Port))
+	}
+	url := "http://" + host + path
+	if token != "" {
+		url += "?token=" + token
+	}
+	return url
+}
+
+func init() {
+	serveICSCmd.Flags().String("addr", "localhost:8099", "Address to listen on, e.g. :8099 for all interfaces")
+	serveICSCmd.Flags().StringSlice("project", nil, "Only include these projects (comma-separated or repeated)")
+	serveICSCmd.Flags().String("token", "", "Require ?token=<token> in the feed URL")
+	serveICSCmd.Flags().Bool("done", false, "Include tasks that are done")
+
+	noJSON(serveCmd)
+	serveCmd.AddCommand(serveICSCmd)
+	rootCmd.AddCommand(serveCmd)
+}
@@ -0,0 +1,237 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mrbooshehri/qix-go/internal/dashboard"
+	"github.com/mrbooshehri/qix-go/internal/logging"
+	"github.com/mrbooshehri/qix-go/internal/storage"
+	"github.com/mrbooshehri/qix-go/internal/ui"
+	"github.com/mrbooshehri/qix-go/pkg/qix/models"
+)
+
+// dashboardDays is how many days of logged hours the dashboard charts
+const dashboardDays = 14
+
+var serveWebCmd = &cobra.Command{
+	Use:   "web",
+	Short: "Serve a web dashboard of projects, tasks and timers",
+	Long: `Serve a read-only dashboard for people who don't use the CLI: an overview
+of every project, and for each project a kanban board, charts of task
+status, hours logged and module progress, and its sprints. The running
+timer is shown at the top. The page refreshes itself every 30 seconds.
+
+The calendar feed of 'serve ics' is served at /qix.ics as well.
+
+The server listens on localhost unless --addr says otherwise, e.g.
+--addr :8098 to share it with your team. Set --token to require
+?token=<token> in the URL.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		addr, _ := cmd.Flags().GetString("addr")
+		token, _ := cmd.Flags().GetString("token")
+
+		listener, err := net.Listen("tcp", addr)
+		if err != nil {
+			return fail("Failed to listen on %s: %v", addr, err)
+		}
+
+		mux := http.NewServeMux()
+		mux.Handle("/", dashboard.Handler())
+		mux.HandleFunc("/api/projects", dashboardAPI(token, dashboardProjects))
+		mux.HandleFunc("/api/project", dashboardAPI(token, dashboardProject))
+		mux.HandleFunc("/api/timer", dashboardAPI(token, dashboardTimer))
+		mux.HandleFunc("/api/statuses", dashboardAPI(token, dashboardStatuses))
+		mux.Handle("/qix.ics", &icsFeed{token: token})
+		server := &http.Server{Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+
+		ui.PrintSuccess("Serving the dashboard at %s", serveURL(listener.Addr(), "/", token))
+		ui.Dim.Println("  Open it in a browser. Press Ctrl+C to stop.")
+		if err := server.Serve(listener); err != nil && err != http.ErrServerClosed {
+			return fail("Server stopped: %v", err)
+		}
+		return nil
+	},
+}
+
+// dashboardAPI serves the JSON fn returns, read from fresh storage. On failure
+// fn returns the HTTP status to answer with.
+func dashboardAPI(token string, fn func(r *http.Request, store *storage.Storage) (interface{}, int, error)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			w.Header().Set("Allow", "GET, HEAD")
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		if !checkToken(w, r, token) {
+			return
+		}
+
+		serveMu.Lock()
+		store := storage.Get()
+		// Pick up changes other qix commands made since the last request
+		store.ClearCache()
+		result, status, err := fn(r, store)
+		serveMu.Unlock()
+
+		if err != nil {
+			logging.Warnf("Dashboard request %s failed: %v", r.URL.Path, err)
+			http.Error(w, err.Error(), status)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Cache-Control", "no-cache")
+		if r.Method == http.MethodHead {
+			return
+		}
+		json.NewEncoder(w).Encode(result)
+	}
+}
+
+// dashboardProjects returns the summary of every project
+func dashboardProjects(r *http.Request, store *storage.Storage) (interface{}, int, error) {
+	projects, err := store.GetAllProjects()
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	summaries := make([]projectSummary, 0, len(projects))
+	for _, project := range projects {
+		summaries = append(summaries, newProjectSummary(project))
+	}
+	return summaries, http.StatusOK, nil
+}
+
+// dashboardProject returns the project named by ?name=
+func dashboardProject(r *http.Request, store *storage.Storage) (interface{}, int, error) {
+	name := r.URL.Query().Get("name")
+	if err := storage.ValidateProjectName(name); err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+	project, err := store.LoadProject(name)
+	if err != nil {
+		return nil, http.StatusNotFound, fmt.Errorf("project not found: %s", name)
+	}
+	return newDashboardProject(project, time.Now()), http.StatusOK, nil
+}
+
+// dashboardStatuses returns the statuses tasks can have, in order
+func dashboardStatuses(r *http.Request, store *storage.Storage) (interface{}, int, error) {
+	return models.Statuses(), http.StatusOK, nil
+}
+
+// dashboardTimer returns the active tracking session
+func dashboardTimer(r *http.Request, store *storage.Storage) (interface{}, int, error) {
+	session, err := store.GetActiveSession()
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	if session == nil {
+		return trackingStatus{}, http.StatusOK, nil
+	}
+
+	projectName, moduleName := parsePath(session.Path)
+	status := trackingStatus{
+		Active:       true,
+		Project:      projectName,
+		Module:       moduleName,
+		TaskID:       session.TaskID,
+		StartedAt:    &session.StartTime,
+		ElapsedHours: time.Since(session.StartTime).Hours(),
+	}
+	if task, _, err := store.FindTask(projectName, session.TaskID); err == nil {
+		status.Title = task.Title
+		status.Status = task.Status
+		status.Priority = task.Priority
+		status.EstimatedHours = task.EstimatedHours
+		status.LoggedHours = task.CalculateActualHours()
+	}
+	return status, http.StatusOK, nil
+}
+
+// dashboardProjectView is a project as the dashboard shows it
+type dashboardProjectView struct {
+	projectSummary
+	Modules []moduleSummary       `json:"modules"`
+	Tasks   []taskView            `json:"tasks"` // All tasks, in modules or not
+	Sprints []dashboardSprintView `json:"sprints"`
+	Hours   []timeOnDay           `json:"hours"` // Logged per day, oldest first
+}
+
+// dashboardSprintView is a sprint's progress
+type dashboardSprintView struct {
+	Name      string     `json:"name"`
+	StartDate string     `json:"start_date"`
+	EndDate   string     `json:"end_date"`
+	Tasks     int        `json:"tasks"`
+	Done      int        `json:"done"`
+	Active    bool       `json:"active"`
+	ClosedAt  *time.Time `json:"closed_at,omitempty"`
+}
+
+// timeOnDay is the time logged on a date
+type timeOnDay struct {
+	Date  string  `json:"date"`
+	Hours float64 `json:"hours"`
+}
+
+func newDashboardProject(project *models.Project, now time.Time) dashboardProjectView {
+	view := dashboardProjectView{
+		projectSummary: newProjectSummary(project),
+		Modules:        make([]moduleSummary, 0, len(project.Modules)),
+		Tasks:          make([]taskView, 0),
+		Sprints:        make([]dashboardSprintView, 0, len(project.Sprints)),
+		Hours:          make([]timeOnDay, 0, dashboardDays),
+	}
+	for _, module := range project.Modules {
+		view.Modules = append(view.Modules, newModuleSummary(module))
+	}
+
+	modules := taskModules(project)
+	status := make(map[string]models.TaskStatus)
+	logged := make(map[string]float64)
+	for _, task := range project.GetAllTasks() {
+		view.Tasks = append(view.Tasks, newTaskView(project.Name, modules[task.ID], task))
+		status[task.ID] = task.Status
+		for _, entry := range task.TimeEntries {
+			logged[entry.Date] += entry.Hours
+		}
+	}
+
+	today := now.Format("2006-01-02")
+	for _, sprint := range project.Sprints {
+		sv := dashboardSprintView{
+			Name:      sprint.Name,
+			StartDate: sprint.StartDate,
+			EndDate:   sprint.EndDate,
+			Tasks:     len(sprint.TaskIDs),
+			Active:    sprint.ClosedAt == nil && sprint.StartDate <= today && today <= sprint.EndDate,
+			ClosedAt:  sprint.ClosedAt,
+		}
+		for _, id := range sprint.TaskIDs {
+			if status[id].IsDone() {
+				sv.Done++
+			}
+		}
+		view.Sprints = append(view.Sprints, sv)
+	}
+
+	for i := dashboardDays - 1; i >= 0; i-- {
+		date := now.AddDate(0, 0, -i).Format("2006-01-02")
+		view.Hours = append(view.Hours, timeOnDay{Date: date, Hours: logged[date]})
+	}
+	return view
+}
+
+func init() {
+	serveWebCmd.Flags().String("addr", "localhost:8098", "Address to listen on, e.g. :8098 for all interfaces")
+	serveWebCmd.Flags().String("token", "", "Require ?token=<token> in the URL")
+
+	serveCmd.AddCommand(serveWebCmd)
+}
@@ -4,9 +4,10 @@ import (
 	"fmt"
 	"time"
 
-	"github.com/mrbooshehri/qix-go/internal/models"
+	"github.com/mrbooshehri/qix-go/internal/events"
 	"github.com/mrbooshehri/qix-go/internal/storage"
 	"github.com/mrbooshehri/qix-go/internal/ui"
+	"github.com/mrbooshehri/qix-go/pkg/qix/models"
 	"github.com/spf13/cobra"
 )
 
@@ -19,43 +20,49 @@ var sprintCmd = &cobra.Command{
 var sprintCreateCmd = &cobra.Command{
 	Use:   "create <project> <name> <start_date> <end_date>",
 	Short: "Create a new sprint",
-	Long:  "Create a sprint with start and end dates (format: YYYY-MM-DD)",
+	Long:  "Create a sprint with start and end dates (YYYY-MM-DD, or the configured date_format)",
 	Args:  cobra.ExactArgs(4),
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		projectName := args[0]
 		sprintName := args[1]
-		startDate := args[2]
-		endDate := args[3]
 
 		// Validate dates
-		start, err := time.Parse("2006-01-02", startDate)
+		start, err := ui.ParseDate(args[2])
 		if err != nil {
-			ui.PrintError("Invalid start date format. Use: YYYY-MM-DD")
-			return
+			return invalid("Invalid start date format. Use: %s", ui.DateFormats())
 		}
 
-		end, err := time.Parse("2006-01-02", endDate)
+		end, err := ui.ParseDate(args[3])
 		if err != nil {
-			ui.PrintError("Invalid end date format. Use: YYYY-MM-DD")
-			return
+			return invalid("Invalid end date format. Use: %s", ui.DateFormats())
 		}
+		startDate, endDate := start.Format(ui.DateLayout), end.Format(ui.DateLayout)
 
 		if end.Before(start) {
-			ui.PrintError("End date must be after start date")
-			return
+			return invalid("End date must be after start date")
 		}
 
 		store := storage.Get()
 
+		capacity, _ := cmd.Flags().GetFloat64("capacity")
+		if capacity < 0 {
+			return invalid("Capacity cannot be negative")
+		}
+
 		sprint := models.Sprint{
 			Name:      sprintName,
 			StartDate: startDate,
 			EndDate:   endDate,
+			Capacity:  capacity,
 		}
 
 		if err := store.AddSprint(projectName, sprint); err != nil {
-			ui.PrintError("Failed to create sprint: %v", err)
-			return
+			return fail("Failed to create sprint: %v", err)
+		}
+
+		if jsonOutput {
+			printJSON(newSprintView(store, projectName, sprint, time.Now().Format("2006-01-02")))
+			return nil
 		}
 
 		duration := int(end.Sub(start).Hours() / 24)
@@ -64,6 +71,9 @@ var sprintCreateCmd = &cobra.Command{
 		ui.Cyan.Printf("  Project: %s\n", projectName)
 		ui.Blue.Printf("  Period:  %s → %s\n", ui.FormatDate(startDate), ui.FormatDate(endDate))
 		ui.Yellow.Printf("  Duration: %d days\n", duration)
+		if capacity > 0 {
+			ui.Dim.Printf("  Capacity: %s per assignee\n", ui.FormatHours(capacity))
+		}
 
 		// Show status
 		today := time.Now().Format("2006-01-02")
@@ -75,6 +85,7 @@ var sprintCreateCmd = &cobra.Command{
 			daysLeft := int(end.Sub(time.Now()).Hours() / 24)
 			ui.Yellow.Printf("  Status:  🔄 Active (%d days remaining)\n", daysLeft)
 		}
+		return nil
 	},
 }
 
@@ -82,15 +93,32 @@ var sprintListCmd = &cobra.Command{
 	Use:   "list <project>",
 	Short: "List all sprints",
 	Args:  cobra.ExactArgs(1),
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		projectName := args[0]
 
 		store := storage.Get()
 
 		project, err := store.LoadProject(projectName)
 		if err != nil {
-			ui.PrintError("Project not found: %s", projectName)
-			return
+			return notFound("Project not found: %s", projectName)
+		}
+
+		if jsonOutput || porcelain {
+			today := time.Now().Format("2006-01-02")
+			sprints := make([]sprintView, 0, len(project.Sprints))
+			for _, sprint := range project.Sprints {
+				sprints = append(sprints, newSprintView(store, project.Name, sprint, today))
+			}
+			if jsonOutput {
+				printJSON(sprints)
+				return nil
+			}
+			// Name, state, start and end dates, tasks, done
+			for _, sprint := range sprints {
+				ui.PrintRecord(sprint.Name, sprint.State, sprint.StartDate, sprint.EndDate,
+					fmt.Sprintf("%d", len(sprint.TaskIDs)), fmt.Sprintf("%d", sprint.Done))
+			}
+			return nil
 		}
 
 		if len(project.Sprints) == 0 {
@@ -98,7 +126,7 @@ var sprintListCmd = &cobra.Command{
 				fmt.Sprintf("No sprints in project '%s'", projectName),
 				fmt.Sprintf("Create one with: qix sprint create %s <name> <start> <end>", projectName),
 			)
-			return
+			return nil
 		}
 
 		ui.PrintHeader(fmt.Sprintf("🏃 Sprints in '%s'", projectName))
@@ -109,10 +137,10 @@ var sprintListCmd = &cobra.Command{
 		var upcoming, active, completed []models.Sprint
 
 		for _, sprint := range project.Sprints {
-			if today < sprint.StartDate {
-				upcoming = append(upcoming, sprint)
-			} else if today > sprint.EndDate {
+			if sprint.ClosedAt != nil || today > sprint.EndDate {
 				completed = append(completed, sprint)
+			} else if today < sprint.StartDate {
+				upcoming = append(upcoming, sprint)
 			} else {
 				active = append(active, sprint)
 			}
@@ -141,46 +169,59 @@ var sprintListCmd = &cobra.Command{
 				printSprintSummary(sprint, project, store)
 			}
 		}
+		return nil
 	},
 }
 
 var sprintAssignCmd = &cobra.Command{
-	Use:   "assign <project> <sprint_name> <task_id>",
-	Short: "Assign a task to a sprint",
-	Args:  cobra.ExactArgs(3),
-	Run: func(cmd *cobra.Command, args []string) {
+	Use:   "assign <project> <sprint_name> <task_id>...",
+	Short: "Assign tasks to a sprint",
+	Args:  cobra.MinimumNArgs(3),
+	RunE: func(cmd *cobra.Command, args []string) error {
 		projectName := args[0]
 		sprintName := args[1]
-		taskID := args[2]
+		taskIDs := args[2:]
 
 		store := storage.Get()
 
 		// Verify sprint exists
 		sprint, err := store.GetSprint(projectName, sprintName)
 		if err != nil {
-			ui.PrintError("Sprint not found: %v", err)
-			return
+			return notFound("Sprint not found: %v", err)
 		}
 
-		// Verify task exists
-		task, _, err := store.FindTask(projectName, taskID)
+		// Assign all tasks with a single save
+		assigned := make([]models.Task, 0, len(taskIDs))
+		err = store.WithTx(projectName, func() error {
+			for _, taskID := range taskIDs {
+				task, _, err := store.FindTask(projectName, taskID)
+				if err != nil {
+					return fmt.Errorf("task not found: %w", err)
+				}
+				if err := store.AssignTaskToSprint(projectName, sprintName, taskID); err != nil {
+					return fmt.Errorf("failed to assign task %s: %w", taskID, err)
+				}
+				assigned = append(assigned, *task)
+			}
+			return nil
+		})
 		if err != nil {
-			ui.PrintError("Task not found: %v", err)
-			return
+			return fail("%v", err)
 		}
 
-		// Assign task
-		if err := store.AssignTaskToSprint(projectName, sprintName, taskID); err != nil {
-			ui.PrintError("Failed to assign task: %v", err)
-			return
+		if len(assigned) == 1 {
+			ui.PrintSuccess("Task assigned to sprint")
+		} else {
+			ui.PrintSuccess("%d tasks assigned to sprint", len(assigned))
 		}
-
-		ui.PrintSuccess("Task assigned to sprint")
 		ui.Cyan.Printf("  Sprint: %s\n", sprintName)
-		ui.Yellow.Printf("  Task:   [%s] %s\n", taskID, task.Title)
+		for _, task := range assigned {
+			ui.Yellow.Printf("  Task:   [%s] %s\n", task.ID, task.Title)
+		}
 		ui.Blue.Printf("  Period: %s → %s\n",
 			ui.FormatDate(sprint.StartDate),
 			ui.FormatDate(sprint.EndDate))
+		return nil
 	},
 }
 
@@ -189,7 +230,7 @@ var sprintReportCmd = &cobra.Command{
 	Short: "Generate sprint report",
 	Long:  "Show detailed sprint progress and metrics",
 	Args:  cobra.ExactArgs(2),
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		projectName := args[0]
 		sprintName := args[1]
 
@@ -197,14 +238,17 @@ var sprintReportCmd = &cobra.Command{
 
 		project, err := store.LoadProject(projectName)
 		if err != nil {
-			ui.PrintError("Project not found: %s", projectName)
-			return
+			return notFound("Project not found: %s", projectName)
 		}
 
 		sprint, err := store.GetSprint(projectName, sprintName)
 		if err != nil {
-			ui.PrintError("Sprint not found: %v", err)
-			return
+			return notFound("Sprint not found: %v", err)
+		}
+
+		if jsonOutput {
+			printJSON(newSprintView(store, projectName, *sprint, time.Now().Format("2006-01-02")))
+			return nil
 		}
 
 		// Use the beautiful UI function
@@ -232,7 +276,7 @@ var sprintReportCmd = &cobra.Command{
 			done := 0
 			for _, taskID := range sprint.TaskIDs {
 				task, _, err := store.FindTask(projectName, taskID)
-				if err == nil && task.Status == models.StatusDone {
+				if err == nil && task.Status.IsDone() {
 					done++
 				}
 			}
@@ -260,6 +304,53 @@ var sprintReportCmd = &cobra.Command{
 				ui.Green.Println("✅ On track!")
 			}
 		}
+		return nil
+	},
+}
+
+var sprintCloseCmd = &cobra.Command{
+	Use:   "close <project> <sprint_name>",
+	Short: "Close a sprint",
+	Long: `Mark a sprint as finished, also before its end date, and post its results
+to the webhooks that take sprint-closed events (see 'qix notify').`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		projectName := args[0]
+		sprintName := args[1]
+
+		store := storage.Get()
+
+		sprint, err := store.GetSprint(projectName, sprintName)
+		if err != nil {
+			return notFound("Sprint not found: %v", err)
+		}
+		if sprint.ClosedAt != nil {
+			return fail("Sprint '%s' is already closed", sprintName)
+		}
+
+		now := time.Now()
+		err = store.UpdateProject(projectName, func(p *models.Project) error {
+			for i := range p.Sprints {
+				if p.Sprints[i].Name == sprintName {
+					p.Sprints[i].ClosedAt = &now
+					return nil
+				}
+			}
+			return notFound("sprint not found")
+		})
+		if err != nil {
+			return fail("Failed to close sprint: %v", err)
+		}
+
+		project, err := store.LoadProject(projectName)
+		if err != nil {
+			return fail("Failed to load project: %v", err)
+		}
+		info := events.NewSprintInfo(project, *sprint)
+		ui.PrintSuccess("Sprint '%s' closed", sprintName)
+		ui.Green.Printf("  Tasks done: %d / %d\n", info.Done, info.Tasks)
+		ui.Blue.Printf("  Logged:     %s\n", ui.FormatHours(info.Hours))
+		return nil
 	},
 }
 
@@ -267,7 +358,7 @@ var sprintRemoveCmd = &cobra.Command{
 	Use:   "remove <project> <sprint_name>",
 	Short: "Remove a sprint",
 	Args:  cobra.ExactArgs(2),
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		projectName := args[0]
 		sprintName := args[1]
 
@@ -276,24 +367,18 @@ var sprintRemoveCmd = &cobra.Command{
 		// Verify sprint exists
 		sprint, err := store.GetSprint(projectName, sprintName)
 		if err != nil {
-			ui.PrintError("Sprint not found: %v", err)
-			return
+			return notFound("Sprint not found: %v", err)
 		}
 
 		// Confirmation
-		force, _ := cmd.Flags().GetBool("force")
+		force := skipConfirm(cmd)
 
 		if !force {
 			fmt.Printf("⚠️  Delete sprint '%s' (%d tasks assigned)?\n",
 				sprintName, len(sprint.TaskIDs))
-			fmt.Print("Type 'yes' to confirm: ")
-
-			var confirm string
-			fmt.Scanln(&confirm)
-
-			if confirm != "yes" {
+			if !ui.ConfirmTyped("yes") {
 				ui.PrintInfo("Deletion cancelled")
-				return
+				return nil
 			}
 		}
 
@@ -305,16 +390,16 @@ var sprintRemoveCmd = &cobra.Command{
 					return nil
 				}
 			}
-			return fmt.Errorf("sprint not found")
+			return notFound("sprint not found")
 		})
 
 		if err != nil {
-			ui.PrintError("Failed to remove sprint: %v", err)
-			return
+			return fail("Failed to remove sprint: %v", err)
 		}
 
 		ui.PrintSuccess("Sprint '%s' removed", sprintName)
 		ui.Dim.Printf("  Note: Tasks were not deleted, only unassigned from sprint\n")
+		return nil
 	},
 }
 
@@ -322,7 +407,7 @@ var sprintUnassignCmd = &cobra.Command{
 	Use:   "unassign <project> <sprint_name> <task_id>",
 	Short: "Unassign a task from a sprint",
 	Args:  cobra.ExactArgs(3),
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		projectName := args[0]
 		sprintName := args[1]
 		taskID := args[2]
@@ -338,25 +423,54 @@ var sprintUnassignCmd = &cobra.Command{
 							p.Sprints[i].TaskIDs = append(
 								p.Sprints[i].TaskIDs[:j],
 								p.Sprints[i].TaskIDs[j+1:]...)
+							if p.Sprints[i].RemovedAt == nil {
+								p.Sprints[i].RemovedAt = make(map[string]time.Time)
+							}
+							p.Sprints[i].RemovedAt[taskID] = time.Now()
 							return nil
 						}
 					}
 					return fmt.Errorf("task not assigned to this sprint")
 				}
 			}
-			return fmt.Errorf("sprint not found")
+			return notFound("sprint not found")
 		})
 
 		if err != nil {
-			ui.PrintError("Failed to unassign task: %v", err)
-			return
+			return fail("Failed to unassign task: %v", err)
 		}
 
 		ui.PrintSuccess("Task [%s] unassigned from sprint '%s'", taskID, sprintName)
+		return nil
 	},
 }
 
 // Helper function
+// sprintView is a sprint as listed by --json
+type sprintView struct {
+	models.Sprint
+	State string `json:"state"` // upcoming, active or completed
+	Done  int    `json:"done"`  // Assigned tasks that are done
+}
+
+func newSprintView(store *storage.Storage, projectName string, sprint models.Sprint, today string) sprintView {
+	view := sprintView{Sprint: sprint, State: "active"}
+	if sprint.ClosedAt != nil || today > sprint.EndDate {
+		view.State = "completed"
+	} else if today < sprint.StartDate {
+		view.State = "upcoming"
+	}
+	if view.TaskIDs == nil {
+		view.TaskIDs = []string{}
+	}
+	for _, taskID := range sprint.TaskIDs {
+		if task, _, err := store.FindTask(projectName, taskID); err == nil && task.Status.IsDone() {
+			view.Done++
+		}
+	}
+	return view
+}
+
 func printSprintSummary(sprint models.Sprint, project *models.Project, store *storage.Storage) {
 	ui.BoldCyan.Printf("\n• %s\n", sprint.Name)
 	ui.Blue.Printf("  %s → %s",
@@ -367,7 +481,9 @@ func printSprintSummary(sprint models.Sprint, project *models.Project, store *st
 	today := time.Now().Format("2006-01-02")
 	end, _ := time.Parse("2006-01-02", sprint.EndDate)
 
-	if today < sprint.StartDate {
+	if sprint.ClosedAt != nil {
+		ui.Green.Printf(" (closed %s)\n", ui.FormatDate(sprint.ClosedAt.Format("2006-01-02")))
+	} else if today < sprint.StartDate {
 		start, _ := time.Parse("2006-01-02", sprint.StartDate)
 		daysUntil := int(start.Sub(time.Now()).Hours() / 24)
 		ui.Cyan.Printf(" (starts in %d days)\n", daysUntil)
@@ -386,7 +502,7 @@ func printSprintSummary(sprint models.Sprint, project *models.Project, store *st
 		done := 0
 		for _, taskID := range sprint.TaskIDs {
 			task, _, err := store.FindTask(project.Name, taskID)
-			if err == nil && task.Status == models.StatusDone {
+			if err == nil && task.Status.IsDone() {
 				done++
 			}
 		}
@@ -401,6 +517,9 @@ func printSprintSummary(sprint models.Sprint, project *models.Project, store *st
 }
 
 func init() {
+	// sprint create flags
+	sprintCreateCmd.Flags().Float64("capacity", 0, "Hours each assignee can work during the sprint")
+
 	// sprint remove flags
 	sprintRemoveCmd.Flags().BoolP("force", "f", false, "Skip confirmation")
 
@@ -408,14 +527,22 @@ func init() {
 	sprintListCmd.ValidArgsFunction = projectArgCompletion
 	sprintAssignCmd.ValidArgsFunction = sprintProjectSprintTaskArgCompletion
 	sprintReportCmd.ValidArgsFunction = sprintProjectSprintArgCompletion
+	watchable(sprintReportCmd)
+	sprintCloseCmd.ValidArgsFunction = sprintProjectSprintArgCompletion
 	sprintRemoveCmd.ValidArgsFunction = sprintProjectSprintArgCompletion
 	sprintUnassignCmd.ValidArgsFunction = sprintProjectSprintTaskArgCompletion
 
+	noJSON(sprintAssignCmd, sprintCloseCmd, sprintRemoveCmd, sprintUnassignCmd)
+
 	// Add subcommands
 	sprintCmd.AddCommand(sprintCreateCmd)
 	sprintCmd.AddCommand(sprintListCmd)
 	sprintCmd.AddCommand(sprintAssignCmd)
 	sprintCmd.AddCommand(sprintReportCmd)
+	sprintCmd.AddCommand(sprintCloseCmd)
 	sprintCmd.AddCommand(sprintRemoveCmd)
 	sprintCmd.AddCommand(sprintUnassignCmd)
+
+	usePager(true, sprintListCmd, sprintReportCmd)
+	supportDryRun(sprintCreateCmd, sprintAssignCmd, sprintCloseCmd, sprintRemoveCmd, sprintUnassignCmd)
 }
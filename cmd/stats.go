@@ -0,0 +1,178 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mrbooshehri/qix-go/internal/config"
+	"github.com/mrbooshehri/qix-go/internal/storage"
+	"github.com/mrbooshehri/qix-go/internal/ui"
+	"github.com/mrbooshehri/qix-go/pkg/qix/models"
+)
+
+// usageOrders are the orders 'stats usage --by' sorts commands in
+var usageOrders = []string{"total", "runs", "average", "max"}
+
+var statsCmd = &cobra.Command{
+	Use:   "stats",
+	Short: "Statistics about how qix is used",
+}
+
+var statsUsageCmd = &cobra.Command{
+	Use:   "usage",
+	Short: "Show how often you run each command and how long it takes",
+	Long: `Show how often each command was run, how often it failed and how long it
+took, so you can see where your time in qix goes.
+
+Nothing is recorded until you turn it on with
+'qix config set usage_stats true'. The counts stay in ~/.qix/usage.json and
+are never sent anywhere; 'qix stats usage clear' removes them.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		by, _ := cmd.Flags().GetString("by")
+		limit, _ := cmd.Flags().GetInt("limit")
+
+		less, ok := usageOrder(by)
+		if !ok {
+			return invalid("Invalid order '%s' (must be %s)", by, strings.Join(usageOrders, ", "))
+		}
+
+		stats, err := storage.Get().LoadUsage()
+		if err != nil {
+			return fail("%v", err)
+		}
+
+		commands := make([]string, 0, len(stats.Commands))
+		for command := range stats.Commands {
+			commands = append(commands, command)
+		}
+		sort.Slice(commands, func(i, j int) bool {
+			a, b := stats.Commands[commands[i]], stats.Commands[commands[j]]
+			if less(a, b) != less(b, a) {
+				return less(b, a)
+			}
+			return commands[i] < commands[j]
+		})
+		if limit > 0 && len(commands) > limit {
+			commands = commands[:limit]
+		}
+
+		if jsonOutput {
+			printJSON(stats)
+			return nil
+		}
+
+		ui.PrintHeader("📈 Command Usage")
+		if len(stats.Commands) == 0 {
+			if config.Get().UsageStats {
+				ui.PrintEmptyState("No commands recorded yet", "")
+			} else {
+				ui.PrintEmptyState("Usage statistics are off", "Turn them on with 'qix config set usage_stats true'; they stay on this machine")
+			}
+			return nil
+		}
+
+		runs, failures, totalMs := 0, 0, 0.0
+		for _, usage := range stats.Commands {
+			runs += usage.Runs
+			failures += usage.Failures
+			totalMs += usage.TotalMs
+		}
+
+		table := ui.NewTableBuilder("Command", "Runs", "Failed", "Total", "Average", "Max", "Share", "Last run")
+		for _, command := range commands {
+			usage := stats.Commands[command]
+			share := 0.0
+			if totalMs > 0 {
+				share = usage.TotalMs / totalMs * 100
+			}
+			table.Row(
+				command,
+				fmt.Sprint(usage.Runs),
+				fmt.Sprint(usage.Failures),
+				formatMs(usage.TotalMs),
+				formatMs(usage.TotalMs/float64(usage.Runs)),
+				formatMs(usage.MaxMs),
+				ui.FormatPercentage(share),
+				ui.FormatAgo(usage.LastRun),
+			)
+		}
+		table.PrintSimple()
+
+		fmt.Println()
+		ui.Dim.Println(fmt.Sprintf("%d runs (%d failed), %s in all since %s", runs, failures, formatMs(totalMs), ui.FormatDateTime(stats.Since.Local())))
+		if !config.Get().UsageStats {
+			ui.Dim.Println("Recording is off; turn it back on with 'qix config set usage_stats true'")
+		}
+		return nil
+	},
+}
+
+var statsUsageClearCmd = &cobra.Command{
+	Use:   "clear",
+	Short: "Remove the usage statistics",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		force, _ := cmd.Flags().GetBool("force")
+		if !force && !ui.Confirm("Remove all usage statistics?") {
+			ui.PrintInfo("Cancelled")
+			return nil
+		}
+
+		if err := storage.Get().ClearUsage(); err != nil {
+			return fail("Failed to remove usage statistics: %v", err)
+		}
+		ui.PrintSuccess("Usage statistics removed")
+		return nil
+	},
+}
+
+// usageOrder returns whether one command's usage sorts before another's in
+// the order named by, least first
+func usageOrder(by string) (func(a, b *models.CommandUsage) bool, bool) {
+	switch by {
+	case "total":
+		return func(a, b *models.CommandUsage) bool { return a.TotalMs < b.TotalMs }, true
+	case "runs":
+		return func(a, b *models.CommandUsage) bool { return a.Runs < b.Runs }, true
+	case "average":
+		return func(a, b *models.CommandUsage) bool {
+			return a.TotalMs/float64(a.Runs) < b.TotalMs/float64(b.Runs)
+		}, true
+	case "max":
+		return func(a, b *models.CommandUsage) bool { return a.MaxMs < b.MaxMs }, true
+	}
+	return nil, false
+}
+
+// formatMs formats milliseconds as "850ms", "2.4s" or "3m12s"
+func formatMs(ms float64) string {
+	switch {
+	case ms < 1000:
+		return fmt.Sprintf("%.0fms", ms)
+	case ms < 60000:
+		return fmt.Sprintf("%.1fs", ms/1000)
+	default:
+		seconds := int(ms / 1000)
+		return fmt.Sprintf("%dm%02ds", seconds/60, seconds%60)
+	}
+}
+
+func init() {
+	statsUsageCmd.Flags().String("by", "total", "Sort by total, runs, average or max time")
+	statsUsageCmd.Flags().IntP("limit", "n", 0, "Show only the first n commands")
+	statsUsageCmd.RegisterFlagCompletionFunc("by", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return usageOrders, cobra.ShellCompDirectiveNoFileComp
+	})
+	statsUsageClearCmd.Flags().BoolP("force", "f", false, "Skip confirmation")
+
+	noJSON(statsUsageClearCmd)
+	statsUsageCmd.AddCommand(statsUsageClearCmd)
+	statsCmd.AddCommand(statsUsageCmd)
+	rootCmd.AddCommand(statsCmd)
+	usePager(true, statsUsageCmd)
+	usePager(false, statsUsageClearCmd)
+}
@@ -0,0 +1,231 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/mrbooshehri/qix-go/internal/config"
+	"github.com/mrbooshehri/qix-go/internal/gitsync"
+	"github.com/mrbooshehri/qix-go/internal/logging"
+	"github.com/mrbooshehri/qix-go/internal/storage"
+	"github.com/mrbooshehri/qix-go/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var syncCmd = &cobra.Command{
+	Use:   "sync",
+	Short: "Synchronize data between machines",
+}
+
+var syncGitCmd = &cobra.Command{
+	Use:   "git",
+	Short: "Version the data directory with git and sync it through a git remote",
+	Long: `Keep the data directory in a git repository. Once initialized, qix commits
+after every command that changes data, so 'git log' and 'git diff' in the data
+directory show the full history of your projects.
+
+Push and pull sync with any git remote (a private repository on a git host, or
+a bare repository on a shared drive). When both machines changed the same
+project, qix merges it task by task: changes to different tasks are combined,
+time entries and journal lines from both sides are kept, and a task changed on
+both sides keeps the most recently updated version.
+
+The config file, backups, locks and logs are never committed. Set
+git_autocommit = false to commit only when you push or pull.`,
+}
+
+var syncGitInitCmd = &cobra.Command{
+	Use:   "init [remote_url]",
+	Short: "Make the data directory a git repository",
+	Long: `Make the data directory a git repository and commit the current data. With a
+remote URL, it becomes the origin used by push and pull; running init again on
+an existing repository just changes the remote.
+
+Examples:
+  qix sync git init
+  qix sync git init git@github.com:me/qix-data.git`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg := config.Get()
+		if cfg.StorageBackend != "json" {
+			return fail("Git sync needs the json storage backend (current: %s)", cfg.StorageBackend)
+		}
+
+		repo, err := gitsync.Open(cfg.QixDir)
+		if err != nil {
+			return fail("%v", err)
+		}
+
+		existed := repo.IsRepo()
+		remoteURL := ""
+		if len(args) > 0 {
+			remoteURL = args[0]
+		}
+		if err := repo.Init(remoteURL); err != nil {
+			return fail("Failed to initialize repository: %v", err)
+		}
+
+		if existed {
+			ui.PrintSuccess("Data directory is already a git repository")
+		} else {
+			ui.PrintSuccess("Initialized git repository in %s", cfg.QixDir)
+		}
+		if remoteURL != "" {
+			ui.PrintInfo("Remote: %s", remoteURL)
+			ui.Dim.Println("Run 'qix sync git push' to upload your data, or 'qix sync git pull' to merge data already there")
+		}
+		return nil
+	},
+}
+
+var syncGitPushCmd = &cobra.Command{
+	Use:   "push",
+	Short: "Commit pending changes and push them to the remote",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		repo, err := openSyncRepo()
+		if err != nil {
+			return fail("%v", err)
+		}
+
+		remoteURL, _ := repo.Remote()
+		if err := repo.Push(); err != nil {
+			ui.PrintError("Push failed: %v", err)
+			if strings.Contains(err.Error(), "rejected") {
+				ui.Dim.Println("The remote has changes you don't have yet; run 'qix sync git pull' first")
+			}
+			return nil
+		}
+		ui.PrintSuccess("Pushed to %s", remoteURL)
+		return nil
+	},
+}
+
+var syncGitPullCmd = &cobra.Command{
+	Use:   "pull",
+	Short: "Fetch changes from the remote and merge them",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		store := storage.Get()
+
+		repo, err := openSyncRepo()
+		if err != nil {
+			return fail("%v", err)
+		}
+		if err := store.FlushAll(); err != nil {
+			return fail("Failed to save pending changes: %v", err)
+		}
+
+		kept := make([]string, 0)
+		resolve := func(rel string, base, ours, theirs []byte) ([]byte, error) {
+			merged, err := storage.MergeDataFile(rel, base, ours, theirs)
+			if errors.Is(err, storage.ErrNotMergeable) {
+				// Derived files are rebuilt below; other state stays as this machine has it
+				if !isDerivedDataFile(rel) {
+					kept = append(kept, rel)
+				}
+				return ours, nil
+			}
+			return merged, err
+		}
+
+		result, err := repo.Pull(resolve, func() error { return repairAfterMerge(store) })
+		if err != nil {
+			return fail("Pull failed: %v", err)
+		}
+		if result.UpToDate {
+			ui.PrintSuccess("Already up to date")
+			return nil
+		}
+
+		// A clean merge can still bring in projects the local index doesn't know about
+		if err := repairAfterMerge(store); err != nil {
+			ui.PrintWarning("Failed to rebuild index: %v", err)
+		}
+
+		ui.PrintSuccess("Pulled changes from the remote")
+		for _, rel := range result.Resolved {
+			if !isDerivedDataFile(rel) && !containsPath(kept, rel) {
+				ui.Dim.Printf("  Merged %s\n", rel)
+			}
+		}
+		for _, rel := range kept {
+			ui.PrintWarning("Changed on both machines, kept the local version: %s", rel)
+		}
+		return nil
+	},
+}
+
+// openSyncRepo returns the git repository of the data directory, failing if it hasn't been initialized
+func openSyncRepo() (*gitsync.Repo, error) {
+	repo, err := gitsync.Open(config.Get().QixDir)
+	if err != nil {
+		return nil, err
+	}
+	if !repo.IsRepo() {
+		return nil, gitsync.ErrNotRepo
+	}
+	return repo, nil
+}
+
+// isDerivedDataFile reports whether a data file is rebuilt from others after a merge
+func isDerivedDataFile(rel string) bool {
+	return rel == "index.json" || path.Ext(rel) == ".sha256"
+}
+
+// containsPath reports whether paths contains rel
+func containsPath(paths []string, rel string) bool {
+	for _, p := range paths {
+		if p == rel {
+			return true
+		}
+	}
+	return false
+}
+
+// repairAfterMerge records fresh checksums for merged projects and rebuilds the task index
+func repairAfterMerge(store *storage.Storage) error {
+	projects, err := store.ListProjects()
+	if err != nil {
+		return err
+	}
+	for _, name := range projects {
+		var checksumErr *storage.ChecksumError
+		if err := store.VerifyProject(name); errors.As(err, &checksumErr) {
+			if err := store.RehashProject(name); err != nil {
+				return fmt.Errorf("failed to record checksum for %s: %w", name, err)
+			}
+		}
+		store.InvalidateCache(name)
+	}
+	return rebuildIndexWithProgress(store)
+}
+
+// autoCommitData commits the data directory after a command if it is a git repository
+func autoCommitData(cmd *cobra.Command, args []string) {
+	cfg := config.Get()
+	if !cfg.GitAutoCommit {
+		return
+	}
+
+	repo, err := gitsync.Open(cfg.QixDir)
+	if err != nil || !repo.IsRepo() {
+		return
+	}
+
+	message := strings.TrimSpace(cmd.CommandPath() + " " + strings.Join(args, " "))
+	if _, err := repo.Commit(message); err != nil {
+		logging.Warnf("Auto-commit failed: %v", err)
+	}
+}
+
+func init() {
+	syncGitCmd.AddCommand(syncGitInitCmd)
+	syncGitCmd.AddCommand(syncGitPushCmd)
+	syncGitCmd.AddCommand(syncGitPullCmd)
+	syncCmd.AddCommand(syncGitCmd)
+	noJSON(syncCmd)
+	rootCmd.AddCommand(syncCmd)
+}
@@ -1,16 +1,17 @@
 package cmd
 
 import (
-	"bufio"
+	"errors"
 	"fmt"
-	"os"
+	"slices"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
 
-	"github.com/mrbooshehri/qix-go/internal/models"
 	"github.com/mrbooshehri/qix-go/internal/storage"
 	"github.com/mrbooshehri/qix-go/internal/ui"
+	"github.com/mrbooshehri/qix-go/pkg/qix/models"
 	"github.com/spf13/cobra"
 )
 
@@ -24,7 +25,7 @@ var taskCreateCmd = &cobra.Command{
 	Use:   "create <project[/module]> <title>",
 	Short: "Create a new task",
 	Args:  cobra.MinimumNArgs(2),
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		path := args[0]
 		title := strings.Join(args[1:], " ")
 
@@ -38,40 +39,37 @@ var taskCreateCmd = &cobra.Command{
 		estimated, _ := cmd.Flags().GetFloat64("estimated")
 		jiraIssue, _ := cmd.Flags().GetString("jira-issue")
 		tags, _ := cmd.Flags().GetStringSlice("tags")
+		due, _ := cmd.Flags().GetString("due")
+		assignee, _ := cmd.Flags().GetString("assignee")
 		interactive, _ := cmd.Flags().GetBool("interactive")
 
+		// Validate due date
+		if due != "" {
+			normalized, err := ui.NormalizeDate(due)
+			if err != nil {
+				return invalid("Invalid due date format. Use: %s", ui.DateFormats())
+			}
+			due = normalized
+		}
+
 		// Validate status
 		taskStatus := models.StatusTodo
 		if status != "" {
-			switch status {
-			case "todo":
-				taskStatus = models.StatusTodo
-			case "doing":
-				taskStatus = models.StatusDoing
-			case "done":
-				taskStatus = models.StatusDone
-			case "blocked":
-				taskStatus = models.StatusBlocked
-			default:
-				ui.PrintError("Invalid status. Use: todo, doing, done, blocked")
-				return
+			parsed, err := models.ParseStatus(status)
+			if err != nil {
+				return invalid("Invalid status. Use: %s", strings.Join(models.StatusNames(), ", "))
 			}
+			taskStatus = parsed
 		}
 
 		// Validate priority
 		taskPriority := models.PriorityMedium
 		if priority != "" {
-			switch priority {
-			case "low":
-				taskPriority = models.PriorityLow
-			case "medium":
-				taskPriority = models.PriorityMedium
-			case "high":
-				taskPriority = models.PriorityHigh
-			default:
-				ui.PrintError("Invalid priority. Use: low, medium, high")
-				return
+			parsed, err := models.ParsePriority(priority)
+			if err != nil {
+				return invalid("Invalid priority. Use: %s", strings.Join(models.PriorityNames(), ", "))
 			}
+			taskPriority = parsed
 		}
 
 		// Create task
@@ -83,28 +81,33 @@ var taskCreateCmd = &cobra.Command{
 			EstimatedHours: estimated,
 			Tags:           tags,
 			JiraIssue:      strings.TrimSpace(jiraIssue),
+			DueDate:        due,
+			Assignee:       strings.TrimSpace(assignee),
 		}
 
 		if interactive {
-			if err := runInteractiveTaskCreate(&task); err != nil {
-				ui.PrintError("Failed to gather task details: %v", err)
-				return
+			if err := runInteractiveTaskCreate(projectName, &task); err != nil {
+				return fail("Failed to gather task details: %v", err)
 			}
 		}
 
-		if task.ID == "" {
-			task.ID = storage.GenerateTaskID()
+		id, err := qixClient().AddTask(projectName, moduleName, task)
+		if err != nil {
+			return fail("Failed to create task: %v", err)
 		}
+		task.ID = id
 
-		store := storage.Get()
-
-		if err := store.AddTask(projectName, moduleName, task); err != nil {
-			ui.PrintError("Failed to create task: %v", err)
-			return
+		if jsonOutput {
+			printJSON(newTaskView(projectName, moduleName, task))
+			return nil
+		}
+		if porcelain {
+			ui.PrintRecord(task.ID)
+			return nil
 		}
 
 		ui.PrintSuccess("Task created with ID: %s", task.ID)
-		ui.Dim.Printf("  Title: %s\n", title)
+		ui.Dim.Printf("  Title: %s\n", task.Title)
 
 		if moduleName != "" {
 			ui.Dim.Printf("  Location: %s/%s\n", projectName, moduleName)
@@ -112,14 +115,21 @@ var taskCreateCmd = &cobra.Command{
 			ui.Dim.Printf("  Location: %s (project level)\n", projectName)
 		}
 
-		ui.Dim.Printf("  Status: %s | Priority: %s\n", taskStatus, taskPriority)
+		ui.Dim.Printf("  Status: %s | Priority: %s\n", task.Status, task.Priority)
 
-		if estimated > 0 {
-			ui.Dim.Printf("  Estimated: %s\n", ui.FormatHours(estimated))
+		if task.EstimatedHours > 0 {
+			ui.Dim.Printf("  Estimated: %s\n", ui.FormatHours(task.EstimatedHours))
+		}
+		if task.JiraIssue != "" {
+			ui.Dim.Printf("  Jira: %s\n", task.JiraIssue)
+		}
+		if due != "" {
+			ui.Dim.Printf("  Due: %s\n", ui.FormatDate(due))
 		}
-		if jiraIssue != "" {
-			ui.Dim.Printf("  Jira: %s\n", jiraIssue)
+		if task.Assignee != "" {
+			ui.Dim.Printf("  Assignee: %s\n", task.Assignee)
 		}
+		return nil
 	},
 }
 
@@ -127,7 +137,7 @@ var taskListCmd = &cobra.Command{
 	Use:   "list <project[/module]>",
 	Short: "List tasks",
 	Args:  cobra.ExactArgs(1),
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		path := args[0]
 		projectName, moduleName := parsePath(path)
 
@@ -138,30 +148,28 @@ var taskListCmd = &cobra.Command{
 
 		project, err := store.LoadProject(projectName)
 		if err != nil {
-			ui.PrintError("Project not found: %s", projectName)
-			return
+			return notFound("Project not found: %s", projectName)
 		}
 
 		var tasks []models.Task
+		var title string
 
 		if moduleName != "" {
 			// List tasks in specific module
 			moduleTasks, err := store.ListTasksInModule(projectName, moduleName)
 			if err != nil {
-				ui.PrintError("Module not found: %v", err)
-				return
+				return notFound("Module not found: %v", err)
 			}
 			tasks = moduleTasks
-
-			ui.PrintHeader(fmt.Sprintf("📋 Tasks in %s/%s", projectName, moduleName))
+			title = fmt.Sprintf("📋 Tasks in %s/%s", projectName, moduleName)
 		} else if all {
 			// List all tasks recursively
 			tasks = project.GetAllTasks()
-			ui.PrintHeader(fmt.Sprintf("📋 All Tasks in %s", projectName))
+			title = fmt.Sprintf("📋 All Tasks in %s", projectName)
 		} else {
 			// List project-level tasks only
 			tasks = project.Tasks
-			ui.PrintHeader(fmt.Sprintf("📋 Project-Level Tasks in %s", projectName))
+			title = fmt.Sprintf("📋 Project-Level Tasks in %s", projectName)
 		}
 
 		// Filter by status if specified
@@ -175,13 +183,32 @@ var taskListCmd = &cobra.Command{
 			tasks = filtered
 		}
 
+		if jsonOutput {
+			modules := taskModules(project)
+			views := make([]taskView, 0, len(tasks))
+			for _, task := range tasks {
+				views = append(views, newTaskView(projectName, modules[task.ID], task))
+			}
+			printJSON(views)
+			return nil
+		}
+		if porcelain {
+			modules := taskModules(project)
+			for _, task := range tasks {
+				ui.PrintRecord(taskRecord(newTaskView(projectName, modules[task.ID], task))...)
+			}
+			return nil
+		}
+
+		ui.PrintHeader(title)
+
 		if len(tasks) == 0 {
 			msg := fmt.Sprintf("No tasks found in %s", path)
 			if status != "" {
 				msg = fmt.Sprintf("No %s tasks found in %s", status, path)
 			}
 			ui.PrintEmptyState(msg, fmt.Sprintf("Create one with: qix task create %s <title>", path))
-			return
+			return nil
 		}
 
 		// Group by status
@@ -190,13 +217,24 @@ var taskListCmd = &cobra.Command{
 			byStatus[task.Status] = append(byStatus[task.Status], task)
 		}
 
-		// Print by status
-		statusOrder := []models.TaskStatus{
-			models.StatusDoing,
-			models.StatusTodo,
-			models.StatusBlocked,
-			models.StatusDone,
+		// Print by status: work in progress first, finished work last, and
+		// statuses no longer in the config at the end
+		statusOrder := []models.TaskStatus{models.StatusDoing}
+		for _, done := range []bool{false, true} {
+			for _, def := range models.Statuses() {
+				if def.Done == done && def.Name != models.StatusDoing {
+					statusOrder = append(statusOrder, def.Name)
+				}
+			}
 		}
+		unknown := make([]models.TaskStatus, 0)
+		for st := range byStatus {
+			if _, ok := models.LookupStatus(st); !ok {
+				unknown = append(unknown, st)
+			}
+		}
+		sort.Slice(unknown, func(i, j int) bool { return unknown[i] < unknown[j] })
+		statusOrder = append(statusOrder, unknown...)
 
 		for _, st := range statusOrder {
 			if len(byStatus[st]) == 0 {
@@ -216,6 +254,7 @@ var taskListCmd = &cobra.Command{
 		}
 
 		fmt.Println()
+		return nil
 	},
 }
 
@@ -223,36 +262,58 @@ var taskShowCmd = &cobra.Command{
 	Use:   "show <project> <task_id>",
 	Short: "Show task details",
 	Args:  cobra.ExactArgs(2),
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		projectName := args[0]
 		taskID := args[1]
 
 		store := storage.Get()
 
-		task, location, err := store.FindTask(projectName, taskID)
+		task, moduleName, err := qixClient().Task(projectName, taskID)
 		if err != nil {
-			ui.PrintError("Task not found: %v", err)
-			return
+			return notFound("Task not found: %v", err)
 		}
 
-		ui.PrintTaskDetailed(*task, formatTaskLocation(projectName, location))
-
-		// Show parent task if exists
+		details := taskDetails{
+			taskView: newTaskView(projectName, moduleName, task),
+			Children: make([]taskRef, 0),
+			Blocking: make([]taskRef, 0),
+		}
 		if task.ParentID != "" {
-			parentTask, _, err := store.FindTask(projectName, task.ParentID)
-			if err == nil {
-				fmt.Println()
-				ui.BoldBlue.Println("👨‍👩‍👧 Parent Task:")
-				ui.Magenta.Printf("   [%s] %s\n", parentTask.ID, parentTask.Title)
+			if parentTask, _, err := store.FindTask(projectName, task.ParentID); err == nil {
+				parent := newTaskRef(*parentTask)
+				details.Parent = &parent
 			}
 		}
+		if children, err := store.GetChildTasks(projectName, taskID); err == nil {
+			for _, child := range children {
+				details.Children = append(details.Children, newTaskRef(child))
+			}
+		}
+		if dependents, err := store.GetDependentTasks(projectName, taskID); err == nil {
+			for _, dep := range dependents {
+				details.Blocking = append(details.Blocking, newTaskRef(dep))
+			}
+		}
+
+		if jsonOutput {
+			printJSON(details)
+			return nil
+		}
+
+		ui.PrintTaskDetailed(task, formatTaskLocation(projectName, moduleName))
+
+		// Show parent task if exists
+		if details.Parent != nil {
+			fmt.Println()
+			ui.BoldBlue.Println("👨‍👩‍👧 Parent Task:")
+			ui.Magenta.Printf("   [%s] %s\n", details.Parent.ID, details.Parent.Title)
+		}
 
 		// Show child tasks
-		children, err := store.GetChildTasks(projectName, taskID)
-		if err == nil && len(children) > 0 {
+		if len(details.Children) > 0 {
 			fmt.Println()
 			ui.BoldBlue.Println("👶 Child Tasks:")
-			for _, child := range children {
+			for _, child := range details.Children {
 				statusColor := ui.GetStatusColor(child.Status)
 				statusColor.Printf("   %s [%s] %s [%s]\n",
 					ui.GetStatusIcon(child.Status),
@@ -263,68 +324,76 @@ var taskShowCmd = &cobra.Command{
 		}
 
 		// Show dependent tasks
-		dependents, err := store.GetDependentTasks(projectName, taskID)
-		if err == nil && len(dependents) > 0 {
+		if len(details.Blocking) > 0 {
 			fmt.Println()
 			ui.BoldBlue.Println("🔒 Blocking Tasks:")
-			for _, dep := range dependents {
+			for _, dep := range details.Blocking {
 				ui.Red.Printf("   🔒 [%s] %s\n", dep.ID, dep.Title)
 			}
 		}
+		return nil
 	},
 }
 
+// taskDetails is a task with the tasks related to it
+type taskDetails struct {
+	taskView
+	Parent   *taskRef  `json:"parent,omitempty"`
+	Children []taskRef `json:"children"`
+	Blocking []taskRef `json:"blocking"` // Tasks that depend on this one
+}
+
 var taskUpdateCmd = &cobra.Command{
-	Use:   "update <project> <task_id> <status>",
+	Use:   "update <project> <task_id>... <status>",
 	Short: "Update task status",
-	Args:  cobra.ExactArgs(3),
-	Run: func(cmd *cobra.Command, args []string) {
+	Long: `Update the status of one or more tasks.
+
+Several task IDs may be given; they are updated together and the project is
+saved once. If any task can't be updated, none are.`,
+	Args: cobra.MinimumNArgs(3),
+	RunE: func(cmd *cobra.Command, args []string) error {
 		projectName := args[0]
-		taskID := args[1]
-		statusStr := args[2]
+		taskIDs := args[1 : len(args)-1]
+		statusStr := args[len(args)-1]
 
 		// Validate status
-		var status models.TaskStatus
-		switch statusStr {
-		case "todo":
-			status = models.StatusTodo
-		case "doing":
-			status = models.StatusDoing
-		case "done":
-			status = models.StatusDone
-		case "blocked":
-			status = models.StatusBlocked
-		default:
-			ui.PrintError("Invalid status. Use: todo, doing, done, blocked")
-			return
-		}
-
-		store := storage.Get()
-
-		// Get task first to show before/after
-		task, _, err := store.FindTask(projectName, taskID)
+		status, err := models.ParseStatus(statusStr)
 		if err != nil {
-			ui.PrintError("Task not found: %v", err)
-			return
+			return invalid("Invalid status. Use: %s", strings.Join(models.StatusNames(), ", "))
 		}
 
-		oldStatus := task.Status
+		client := qixClient()
 
-		if err := store.UpdateTaskStatus(projectName, taskID, status); err != nil {
-			ui.PrintError("Failed to update task: %v", err)
-			return
+		// Keep each task's old status to show before/after
+		updated := make([]models.Task, 0, len(taskIDs))
+		for _, taskID := range taskIDs {
+			task, _, err := client.Task(projectName, taskID)
+			if err != nil {
+				return notFound("Task not found: %v", err)
+			}
+			updated = append(updated, task)
+		}
+		if err := client.SetStatuses(projectName, taskIDs, status); err != nil {
+			return fail("%v", err)
 		}
 
-		ui.PrintSuccess("Task status updated")
-		ui.Cyan.Printf("  [%s] %s\n", taskID, task.Title)
+		if len(updated) == 1 {
+			ui.PrintSuccess("Task status updated")
+		} else {
+			ui.PrintSuccess("%d tasks updated", len(updated))
+		}
 
-		oldColor := ui.GetStatusColor(oldStatus)
 		newColor := ui.GetStatusColor(status)
-
-		fmt.Print("  ")
-		oldColor.Printf("%s %s", ui.GetStatusIcon(oldStatus), oldStatus)
-		fmt.Print(" → ")
-		newColor.Printf("%s %s\n", ui.GetStatusIcon(status), status)
+		for _, task := range updated {
+			oldColor := ui.GetStatusColor(task.Status)
+
+			ui.Cyan.Printf("  [%s] %s\n", task.ID, task.Title)
+			fmt.Print("  ")
+			oldColor.Printf("%s %s", ui.GetStatusIcon(task.Status), task.Status)
+			fmt.Print(" → ")
+			newColor.Printf("%s %s\n", ui.GetStatusIcon(status), status)
+		}
+		return nil
 	},
 }
 
@@ -332,7 +401,7 @@ var taskEditCmd = &cobra.Command{
 	Use:   "edit <project> <task_id>",
 	Short: "Edit task details",
 	Args:  cobra.ExactArgs(2),
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		projectName := args[0]
 		taskID := args[1]
 
@@ -343,17 +412,27 @@ var taskEditCmd = &cobra.Command{
 		estimated, _ := cmd.Flags().GetFloat64("estimated")
 		jiraIssue, _ := cmd.Flags().GetString("jira-issue")
 		jiraIssueChanged := cmd.Flags().Changed("jira-issue")
+		due, _ := cmd.Flags().GetString("due")
+		dueChanged := cmd.Flags().Changed("due")
+		assignee, _ := cmd.Flags().GetString("assignee")
+		assigneeChanged := cmd.Flags().Changed("assignee")
 
-		if title == "" && description == "" && status == "" && priority == "" && estimated == 0 && !jiraIssueChanged {
-			if err := runInteractiveTaskEdit(projectName, taskID); err != nil {
-				ui.PrintError("Failed to update task: %v", err)
+		if dueChanged && due != "" {
+			normalized, err := ui.NormalizeDate(due)
+			if err != nil {
+				return invalid("Invalid due date format. Use: %s", ui.DateFormats())
 			}
-			return
+			due = normalized
 		}
 
-		store := storage.Get()
+		if title == "" && description == "" && status == "" && priority == "" && estimated == 0 && !jiraIssueChanged && !dueChanged && !assigneeChanged {
+			if err := runInteractiveTaskEdit(projectName, taskID); err != nil {
+				return fail("Failed to update task: %v", err)
+			}
+			return nil
+		}
 
-		err := store.UpdateTask(projectName, taskID, func(t *models.Task) error {
+		err := qixClient().UpdateTask(projectName, taskID, func(t *models.Task) error {
 			if title != "" {
 				t.Title = title
 			}
@@ -361,30 +440,18 @@ var taskEditCmd = &cobra.Command{
 				t.Description = description
 			}
 			if status != "" {
-				switch status {
-				case "todo":
-					t.Status = models.StatusTodo
-				case "doing":
-					t.Status = models.StatusDoing
-				case "done":
-					t.Status = models.StatusDone
-				case "blocked":
-					t.Status = models.StatusBlocked
-				default:
-					return fmt.Errorf("invalid status: %s", status)
+				parsed, err := models.ParseStatus(status)
+				if err != nil {
+					return err
 				}
+				t.Status = parsed
 			}
 			if priority != "" {
-				switch priority {
-				case "low":
-					t.Priority = models.PriorityLow
-				case "medium":
-					t.Priority = models.PriorityMedium
-				case "high":
-					t.Priority = models.PriorityHigh
-				default:
-					return fmt.Errorf("invalid priority: %s", priority)
+				parsed, err := models.ParsePriority(priority)
+				if err != nil {
+					return err
 				}
+				t.Priority = parsed
 			}
 			if estimated > 0 {
 				t.EstimatedHours = estimated
@@ -392,15 +459,21 @@ var taskEditCmd = &cobra.Command{
 			if jiraIssueChanged {
 				t.JiraIssue = strings.TrimSpace(jiraIssue)
 			}
+			if dueChanged {
+				t.DueDate = due
+			}
+			if assigneeChanged {
+				t.Assignee = strings.TrimSpace(assignee)
+			}
 			return nil
 		})
 
 		if err != nil {
-			ui.PrintError("Failed to update task: %v", err)
-			return
+			return fail("Failed to update task: %v", err)
 		}
 
 		ui.PrintSuccess("Task updated: %s", taskID)
+		return nil
 	},
 }
 
@@ -408,41 +481,36 @@ var taskRemoveCmd = &cobra.Command{
 	Use:   "remove <project> <task_id>",
 	Short: "Remove a task",
 	Args:  cobra.ExactArgs(2),
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		projectName := args[0]
 		taskID := args[1]
 
-		store := storage.Get()
+		client := qixClient()
 
 		// Get task details first
-		task, _, err := store.FindTask(projectName, taskID)
+		task, _, err := client.Task(projectName, taskID)
 		if err != nil {
-			ui.PrintError("Task not found: %v", err)
-			return
+			return notFound("Task not found: %v", err)
 		}
 
 		// Confirmation
-		force, _ := cmd.Flags().GetBool("force")
+		force := skipConfirm(cmd)
 
 		if !force {
 			fmt.Printf("⚠️  Delete task '%s' [%s]?\n", task.Title, taskID)
-			fmt.Print("Type 'yes' to confirm: ")
-
-			var confirm string
-			fmt.Scanln(&confirm)
-
-			if confirm != "yes" {
+			if !ui.ConfirmTyped("yes") {
 				ui.PrintInfo("Deletion cancelled")
-				return
+				return nil
 			}
 		}
 
-		if err := store.RemoveTask(projectName, taskID); err != nil {
-			ui.PrintError("Failed to remove task: %v", err)
-			return
+		if err := client.RemoveTask(projectName, taskID); err != nil {
+			return fail("Failed to remove task: %v", err)
 		}
 
 		ui.PrintSuccess("Task removed: [%s] %s", taskID, task.Title)
+		ui.Dim.Println("Moved to trash; use 'qix trash list' to restore it")
+		return nil
 	},
 }
 
@@ -451,7 +519,7 @@ var taskLinkCmd = &cobra.Command{
 	Short: "Link a task as child of another",
 	Long:  "Create a parent-child relationship between tasks",
 	Args:  cobra.ExactArgs(3),
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		projectName := args[0]
 		childID := args[1]
 		parentID := args[2]
@@ -461,24 +529,22 @@ var taskLinkCmd = &cobra.Command{
 		// Get task details
 		childTask, _, err := store.FindTask(projectName, childID)
 		if err != nil {
-			ui.PrintError("Child task not found: %v", err)
-			return
+			return notFound("Child task not found: %v", err)
 		}
 
 		parentTask, _, err := store.FindTask(projectName, parentID)
 		if err != nil {
-			ui.PrintError("Parent task not found: %v", err)
-			return
+			return notFound("Parent task not found: %v", err)
 		}
 
 		if err := store.LinkTaskAsChild(projectName, childID, parentID); err != nil {
-			ui.PrintError("Failed to link tasks: %v", err)
-			return
+			return fail("Failed to link tasks: %v", err)
 		}
 
 		ui.PrintSuccess("Task linked successfully")
 		ui.Cyan.Printf("  Child:  [%s] %s\n", childID, childTask.Title)
 		ui.Magenta.Printf("  Parent: [%s] %s\n", parentID, parentTask.Title)
+		return nil
 	},
 }
 
@@ -487,7 +553,7 @@ var taskDependCmd = &cobra.Command{
 	Short: "Add a task dependency",
 	Long:  "Make a task depend on another (task_id will be blocked until depends_on_id is done)",
 	Args:  cobra.ExactArgs(3),
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		projectName := args[0]
 		taskID := args[1]
 		dependsOnID := args[2]
@@ -497,19 +563,16 @@ var taskDependCmd = &cobra.Command{
 		// Get task details
 		task, _, err := store.FindTask(projectName, taskID)
 		if err != nil {
-			ui.PrintError("Task not found: %v", err)
-			return
+			return notFound("Task not found: %v", err)
 		}
 
 		depTask, _, err := store.FindTask(projectName, dependsOnID)
 		if err != nil {
-			ui.PrintError("Dependency task not found: %v", err)
-			return
+			return notFound("Dependency task not found: %v", err)
 		}
 
 		if err := store.AddTaskDependency(projectName, taskID, dependsOnID); err != nil {
-			ui.PrintError("Failed to add dependency: %v", err)
-			return
+			return fail("Failed to add dependency: %v", err)
 		}
 
 		ui.PrintSuccess("Dependency added")
@@ -517,9 +580,10 @@ var taskDependCmd = &cobra.Command{
 		ui.Cyan.Print("  ↓ depends on\n")
 		ui.Green.Printf("  [%s] %s\n", dependsOnID, depTask.Title)
 
-		if depTask.Status != models.StatusDone {
+		if !depTask.Status.IsDone() {
 			ui.PrintWarning("Note: [%s] is not done yet (%s)", dependsOnID, depTask.Status)
 		}
+		return nil
 	},
 }
 
@@ -530,7 +594,7 @@ var taskRecurCmd = &cobra.Command{
 
 Patterns:
   daily                    - Every day
-  weekly:<day>             - Every week (monday, tuesday, etc.)
+  weekly[:<day>]           - Every week (monday, tuesday, etc.; week_start by default)
   monthly:<day>            - Every month (1-31)
   interval:<days>          - Every N days
 
@@ -540,7 +604,7 @@ Examples:
   qix task recur myproject task789 monthly:15
   qix task recur myproject taskabc interval:3`,
 	Args: cobra.ExactArgs(3),
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		projectName := args[0]
 		taskID := args[1]
 		pattern := args[2]
@@ -548,8 +612,7 @@ Examples:
 		// Parse pattern
 		recurrence, err := parseRecurrencePattern(pattern)
 		if err != nil {
-			ui.PrintError("Invalid pattern: %v", err)
-			return
+			return invalid("Invalid pattern: %v", err)
 		}
 
 		store := storage.Get()
@@ -557,19 +620,18 @@ Examples:
 		// Get task
 		task, _, err := store.FindTask(projectName, taskID)
 		if err != nil {
-			ui.PrintError("Task not found: %v", err)
-			return
+			return notFound("Task not found: %v", err)
 		}
 
 		if err := store.SetTaskRecurrence(projectName, taskID, *recurrence); err != nil {
-			ui.PrintError("Failed to set recurrence: %v", err)
-			return
+			return fail("Failed to set recurrence: %v", err)
 		}
 
 		ui.PrintSuccess("Recurring schedule set")
 		ui.Cyan.Printf("  Task: [%s] %s\n", taskID, task.Title)
 		ui.Yellow.Printf("  Pattern: %s\n", pattern)
 		ui.Green.Printf("  Next due: %s\n", ui.FormatDate(recurrence.NextDue))
+		return nil
 	},
 }
 
@@ -577,18 +639,18 @@ var taskUnrecurCmd = &cobra.Command{
 	Use:   "unrecur <project> <task_id>",
 	Short: "Remove recurrence from task",
 	Args:  cobra.ExactArgs(2),
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		projectName := args[0]
 		taskID := args[1]
 
 		store := storage.Get()
 
 		if err := store.RemoveTaskRecurrence(projectName, taskID); err != nil {
-			ui.PrintError("Failed to remove recurrence: %v", err)
-			return
+			return fail("Failed to remove recurrence: %v", err)
 		}
 
 		ui.PrintSuccess("Recurrence removed from task: %s", taskID)
+		return nil
 	},
 }
 
@@ -596,7 +658,7 @@ var taskDueCmd = &cobra.Command{
 	Use:   "due [project]",
 	Short: "Show recurring tasks due today",
 	Args:  cobra.MaximumNArgs(1),
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		today := time.Now().Format("2006-01-02")
 
 		store := storage.Get()
@@ -609,9 +671,32 @@ var taskDueCmd = &cobra.Command{
 		} else {
 			projects, err = store.ListProjects()
 			if err != nil {
-				ui.PrintError("Failed to list projects: %v", err)
-				return
+				return fail("Failed to list projects: %v", err)
+			}
+		}
+
+		due, err := store.GetAllRecurringTasksDue(cmd.Context(), projects, today)
+		if err != nil {
+			return fail("Failed to load projects: %v", err)
+		}
+
+		if jsonOutput {
+			views := make([]taskView, 0)
+			for _, projectName := range projects {
+				if len(due[projectName]) == 0 {
+					continue
+				}
+				project, err := store.LoadProject(projectName)
+				if err != nil {
+					return notFound("Project not found: %s", projectName)
+				}
+				modules := taskModules(project)
+				for _, task := range due[projectName] {
+					views = append(views, newTaskView(projectName, modules[task.ID], task))
+				}
 			}
+			printJSON(views)
+			return nil
 		}
 
 		ui.PrintHeader(fmt.Sprintf("🔔 Tasks Due Today - %s", ui.FormatDate(today)))
@@ -619,10 +704,7 @@ var taskDueCmd = &cobra.Command{
 		found := false
 
 		for _, projectName := range projects {
-			tasks, err := store.GetRecurringTasksDue(projectName, today)
-			if err != nil {
-				continue
-			}
+			tasks := due[projectName]
 
 			if len(tasks) > 0 {
 				found = true
@@ -646,6 +728,7 @@ var taskDueCmd = &cobra.Command{
 		if !found {
 			ui.PrintEmptyState("No recurring tasks due today", "")
 		}
+		return nil
 	},
 }
 
@@ -654,7 +737,7 @@ var taskCompleteCmd = &cobra.Command{
 	Short: "Complete a recurring task",
 	Long:  "Mark a recurring task as done and schedule the next occurrence",
 	Args:  cobra.ExactArgs(2),
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		projectName := args[0]
 		taskID := args[1]
 
@@ -663,20 +746,18 @@ var taskCompleteCmd = &cobra.Command{
 		// Get task
 		task, _, err := store.FindTask(projectName, taskID)
 		if err != nil {
-			ui.PrintError("Task not found: %v", err)
-			return
+			return notFound("Task not found: %v", err)
 		}
 
 		// Check if recurring
 		if !task.IsRecurring() {
 			// Just update status
 			if err := store.UpdateTaskStatus(projectName, taskID, models.StatusDone); err != nil {
-				ui.PrintError("Failed to complete task: %v", err)
-				return
+				return fail("Failed to complete task: %v", err)
 			}
 
 			ui.PrintSuccess("Task completed: [%s] %s", taskID, task.Title)
-			return
+			return nil
 		}
 
 		// Handle recurring task
@@ -690,20 +771,21 @@ var taskCompleteCmd = &cobra.Command{
 			t.Status = models.StatusDone
 			if t.Recurrence != nil {
 				t.Recurrence.LastCompleted = today
+				t.Recurrence.Completions = append(t.Recurrence.Completions, today)
 				t.Recurrence.NextDue = nextDue
 			}
 			return nil
 		})
 
 		if err != nil {
-			ui.PrintError("Failed to complete task: %v", err)
-			return
+			return fail("Failed to complete task: %v", err)
 		}
 
 		ui.PrintSuccess("Recurring task completed")
 		ui.Cyan.Printf("  Task: [%s] %s\n", taskID, task.Title)
 		ui.Green.Printf("  Completed: %s\n", ui.FormatDate(today))
 		ui.Yellow.Printf("  Next due: %s\n", ui.FormatDate(nextDue))
+		return nil
 	},
 }
 
@@ -724,29 +806,26 @@ func taskDueCompletion(cmd *cobra.Command, args []string, toComplete string) ([]
 }
 
 func runInteractiveTaskEdit(projectName, taskID string) error {
-	store := storage.Get()
-	task, _, err := store.FindTask(projectName, taskID)
+	client := qixClient()
+	task, _, err := client.Task(projectName, taskID)
 	if err != nil {
 		return err
 	}
 
-	reader := bufio.NewReader(os.Stdin)
 	fmt.Println()
 	ui.PrintHeader("Interactive Task Editor")
 	fmt.Printf("Editing [%s] %s\n", task.ID, task.Title)
 	fmt.Println("Press Enter to keep the current value. Type '-' to clear Jira Issue.")
 	fmt.Println()
 
-	edited := *task
-
-	edited.Title = promptWithDefault(reader, "Title", task.Title)
-	edited.Description = promptWithDefault(reader, "Description", task.Description)
-	edited.Status = promptStatus(reader, task.Status)
-	edited.Priority = promptPriority(reader, task.Priority)
-	edited.EstimatedHours = promptEstimated(reader, task.EstimatedHours)
-	edited.JiraIssue = promptJira(reader, task.JiraIssue)
+	edited := task
+	if err := promptTaskFields(&edited, []taskField{
+		fieldTitle, fieldDescription, fieldStatus, fieldPriority, fieldEstimated, fieldJira,
+	}, nil); err != nil {
+		return err
+	}
 
-	if err := store.UpdateTask(projectName, taskID, func(t *models.Task) error {
+	if err := client.UpdateTask(projectName, taskID, func(t *models.Task) error {
 		t.Title = edited.Title
 		t.Description = edited.Description
 		t.Status = edited.Status
@@ -762,131 +841,142 @@ func runInteractiveTaskEdit(projectName, taskID string) error {
 	return nil
 }
 
-func runInteractiveTaskCreate(task *models.Task) error {
-	reader := bufio.NewReader(os.Stdin)
+func runInteractiveTaskCreate(projectName string, task *models.Task) error {
 	fmt.Println()
 	ui.PrintHeader("Interactive Task Creator")
 	fmt.Println("Provide values for the following fields (press Enter to keep defaults).")
 	fmt.Println()
 
-	task.Description = promptWithDefault(reader, "Description", task.Description)
-	task.EstimatedHours = promptEstimated(reader, task.EstimatedHours)
-	task.Status = promptStatus(reader, task.Status)
-	task.Priority = promptPriority(reader, task.Priority)
-	task.Tags = promptTags(reader, task.Tags)
-	task.JiraIssue = promptJira(reader, task.JiraIssue)
+	return promptTaskFields(task, []taskField{
+		fieldDescription, fieldEstimated, fieldStatus, fieldPriority, fieldTags, fieldJira,
+	}, projectTags(projectName))
+}
+
+// taskField is a task field asked for by the interactive editor and creator
+type taskField int
+
+const (
+	fieldTitle taskField = iota
+	fieldDescription
+	fieldStatus
+	fieldPriority
+	fieldEstimated
+	fieldTags
+	fieldJira
+)
 
+// promptTaskFields asks for each field in turn, offering knownTags to pick tags from
+func promptTaskFields(task *models.Task, fields []taskField, knownTags []string) error {
+	for _, field := range fields {
+		var err error
+		switch field {
+		case fieldTitle:
+			task.Title, err = ui.Input("Title", task.Title, nil)
+		case fieldDescription:
+			task.Description, err = ui.Input("Description", task.Description, nil)
+		case fieldStatus:
+			task.Status, err = promptStatus(task.Status)
+		case fieldPriority:
+			task.Priority, err = promptPriority(task.Priority)
+		case fieldEstimated:
+			task.EstimatedHours, err = promptEstimated(task.EstimatedHours)
+		case fieldTags:
+			task.Tags, err = promptTags(task.Tags, knownTags)
+		case fieldJira:
+			task.JiraIssue, err = promptJira(task.JiraIssue)
+		}
+		if err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
-func promptWithDefault(reader *bufio.Reader, label, current string) string {
-	display := current
-	if display == "" {
-		display = "<empty>"
-	}
-	fmt.Printf("%s [%s]: ", label, display)
-	input, _ := reader.ReadString('\n')
-	value := strings.TrimSpace(input)
-	if value == "" {
-		return current
-	}
-	return value
+func promptStatus(current models.TaskStatus) (models.TaskStatus, error) {
+	value, err := ui.Select("Status", models.StatusNames(), string(current))
+	return models.TaskStatus(value), err
+}
+
+func promptPriority(current models.Priority) (models.Priority, error) {
+	value, err := ui.Select("Priority", models.PriorityNames(), string(current))
+	return models.Priority(value), err
 }
 
-func promptStatus(reader *bufio.Reader, current models.TaskStatus) models.TaskStatus {
-	for {
-		fmt.Printf("Status [%s] (todo/doing/done/blocked): ", current)
-		input, _ := reader.ReadString('\n')
-		value := strings.TrimSpace(strings.ToLower(input))
-		if value == "" {
-			return current
-		}
-		switch value {
-		case "todo":
-			return models.StatusTodo
-		case "doing":
-			return models.StatusDoing
-		case "done":
-			return models.StatusDone
-		case "blocked":
-			return models.StatusBlocked
-		default:
-			fmt.Println("Invalid status. Use todo, doing, done, or blocked.")
+func promptEstimated(current float64) (float64, error) {
+	value, err := ui.Input("Estimated Hours", strconv.FormatFloat(current, 'f', 2, 64), func(value string) error {
+		if val, err := strconv.ParseFloat(value, 64); err != nil || val < 0 {
+			return errors.New("Enter a positive number (e.g., 1.5).")
 		}
+		return nil
+	})
+	if err != nil {
+		return current, err
 	}
+	return strconv.ParseFloat(value, 64)
 }
 
-func promptPriority(reader *bufio.Reader, current models.Priority) models.Priority {
-	for {
-		fmt.Printf("Priority [%s] (low/medium/high): ", current)
-		input, _ := reader.ReadString('\n')
-		value := strings.TrimSpace(strings.ToLower(input))
-		if value == "" {
-			return current
-		}
-		switch value {
-		case "low":
-			return models.PriorityLow
-		case "medium":
-			return models.PriorityMedium
-		case "high":
-			return models.PriorityHigh
-		default:
-			fmt.Println("Invalid priority. Use low, medium, or high.")
-		}
+func promptJira(current string) (string, error) {
+	value, err := ui.Input("Jira Issue ID (use '-' to clear)", current, nil)
+	if value == "-" {
+		value = ""
 	}
+	return value, err
 }
 
-func promptEstimated(reader *bufio.Reader, current float64) float64 {
-	for {
-		fmt.Printf("Estimated Hours [%.2f]: ", current)
-		input, _ := reader.ReadString('\n')
-		value := strings.TrimSpace(input)
-		if value == "" {
-			return current
+// promptTags picks tags from those already used in the project, then asks for
+// new ones
+func promptTags(current, known []string) ([]string, error) {
+	if len(known) == 0 {
+		value, err := ui.Input("Tags (comma-separated)", strings.Join(current, ", "), nil)
+		if err != nil {
+			return current, err
 		}
-		val, err := strconv.ParseFloat(value, 64)
-		if err != nil || val < 0 {
-			fmt.Println("Enter a positive number (e.g., 1.5).")
-			continue
+		return splitTags(value), nil
+	}
+
+	options := append([]string(nil), known...)
+	for _, tag := range current {
+		if !slices.Contains(options, tag) {
+			options = append(options, tag)
 		}
-		return val
 	}
-}
+	tags, err := ui.MultiSelect("Tags", options, current)
+	if err != nil {
+		return current, err
+	}
 
-func promptJira(reader *bufio.Reader, current string) string {
-	display := current
-	if display == "" {
-		display = "<none>"
+	value, err := ui.Input("New tags (comma-separated)", "", nil)
+	if err != nil {
+		return tags, err
 	}
-	for {
-		fmt.Printf("Jira Issue ID [%s] (use '-' to clear): ", display)
-		input, _ := reader.ReadString('\n')
-		value := strings.TrimSpace(input)
-		switch value {
-		case "":
-			return current
-		case "-":
-			return ""
-		default:
-			return value
+	for _, tag := range splitTags(value) {
+		if !slices.Contains(tags, tag) {
+			tags = append(tags, tag)
 		}
 	}
+	return tags, nil
 }
 
-func promptTags(reader *bufio.Reader, current []string) []string {
-	display := "<none>"
-	if len(current) > 0 {
-		display = strings.Join(current, ", ")
+// projectTags returns the tags used by the tasks of a project, sorted
+func projectTags(projectName string) []string {
+	project, err := storage.Get().LoadProject(projectName)
+	if err != nil {
+		return nil
 	}
-
-	fmt.Printf("Tags (comma-separated) [%s]: ", display)
-	input, _ := reader.ReadString('\n')
-	value := strings.TrimSpace(input)
-	if value == "" {
-		return current
+	var tags []string
+	for _, task := range project.GetAllTasks() {
+		for _, tag := range task.Tags {
+			if !slices.Contains(tags, tag) {
+				tags = append(tags, tag)
+			}
+		}
 	}
+	sort.Strings(tags)
+	return tags
+}
 
+// splitTags splits a comma separated list of tags, dropping empty ones
+func splitTags(value string) []string {
 	parts := strings.Split(value, ",")
 	result := make([]string, 0, len(parts))
 	for _, part := range parts {
@@ -907,11 +997,18 @@ func parsePath(path string) (project, module string) {
 	return
 }
 
-func formatTaskLocation(projectName, location string) string {
+// locationModule returns the module of a task location from FindTask, or "" for project level
+func locationModule(location string) string {
 	if location == "project" {
+		return ""
+	}
+	return strings.TrimPrefix(location, "module:")
+}
+
+func formatTaskLocation(projectName, moduleName string) string {
+	if moduleName == "" {
 		return fmt.Sprintf("%s (project level)", projectName)
 	}
-	moduleName := strings.TrimPrefix(location, "module:")
 	return fmt.Sprintf("%s/%s", projectName, moduleName)
 }
 
@@ -931,7 +1028,8 @@ func parseRecurrencePattern(pattern string) (*models.Recurrence, error) {
 	case "weekly":
 		rType = models.RecurWeekly
 		if recValue == "" {
-			return nil, fmt.Errorf("weekly pattern requires day (e.g., weekly:monday)")
+			// Plain "weekly" recurs on the day weeks start on
+			recValue = strings.ToLower(ui.WeekStart().String())
 		}
 	case "monthly":
 		rType = models.RecurMonthly
@@ -1016,21 +1114,30 @@ func calculateNextOccurrence(recType models.RecurrenceType, value string) string
 func init() {
 	// task create flags
 	taskCreateCmd.Flags().StringP("description", "d", "", "Task description")
-	taskCreateCmd.Flags().StringP("status", "s", "todo", "Task status (todo/doing/done/blocked)")
-	taskCreateCmd.Flags().StringP("priority", "p", "medium", "Task priority (low/medium/high)")
+	taskCreateCmd.Flags().StringP("status", "s", "todo", "Task status (todo/doing/done/blocked, or one set in the config)")
+	taskCreateCmd.Flags().StringP("priority", "p", "medium", "Task priority (low/medium/high, or one set in the config)")
 	taskCreateCmd.Flags().Float64P("estimated", "e", 0, "Estimated hours")
 	taskCreateCmd.Flags().StringSliceP("tags", "t", []string{}, "Task tags")
 	taskCreateCmd.Flags().String("jira-issue", "", "Jira issue ID")
+	taskCreateCmd.Flags().String("due", "", "Due date (YYYY-MM-DD or the configured date_format)")
+	taskCreateCmd.Flags().String("assignee", "", "Person responsible for the task")
 	taskCreateCmd.Flags().BoolP("interactive", "i", false, "Interactive mode to enter task details")
 	taskCreateCmd.ValidArgsFunction = taskPathCompletion
+	taskCreateCmd.RegisterFlagCompletionFunc("status", completeStatuses)
+	taskCreateCmd.RegisterFlagCompletionFunc("priority", completePriorities)
+	taskCreateCmd.RegisterFlagCompletionFunc("tags", completeTags)
+	taskCreateCmd.RegisterFlagCompletionFunc("due", completeDates)
 
 	// task list flags
 	taskListCmd.Flags().BoolP("all", "a", false, "Show all tasks recursively")
 	taskListCmd.Flags().StringP("status", "s", "", "Filter by status")
+	taskListCmd.RegisterFlagCompletionFunc("status", completeStatuses)
 	taskListCmd.ValidArgsFunction = taskPathCompletion
+	listingFlags("task", taskListCmd)
+	watchable(taskListCmd)
 
 	taskShowCmd.ValidArgsFunction = projectTaskArgCompletion
-	taskUpdateCmd.ValidArgsFunction = projectTaskArgCompletion
+	taskUpdateCmd.ValidArgsFunction = taskUpdateArgCompletion
 	taskEditCmd.ValidArgsFunction = projectTaskArgCompletion
 	taskRemoveCmd.ValidArgsFunction = projectTaskArgCompletion
 	taskRecurCmd.ValidArgsFunction = projectTaskArgCompletion
@@ -1045,12 +1152,19 @@ func init() {
 	taskEditCmd.Flags().StringP("description", "d", "", "New description")
 	taskEditCmd.Flags().StringP("status", "s", "", "New status")
 	taskEditCmd.Flags().StringP("priority", "p", "", "New priority")
+	taskEditCmd.RegisterFlagCompletionFunc("status", completeStatuses)
+	taskEditCmd.RegisterFlagCompletionFunc("priority", completePriorities)
 	taskEditCmd.Flags().Float64P("estimated", "e", 0, "New estimated hours")
 	taskEditCmd.Flags().String("jira-issue", "", "Set Jira issue ID (use empty string to clear)")
+	taskEditCmd.Flags().String("due", "", "Set due date YYYY-MM-DD (use empty string to clear)")
+	taskEditCmd.Flags().String("assignee", "", "Set assignee (use empty string to clear)")
+	taskEditCmd.RegisterFlagCompletionFunc("due", completeDates)
 
 	// task remove flags
 	taskRemoveCmd.Flags().BoolP("force", "f", false, "Skip confirmation")
 
+	noJSON(taskUpdateCmd, taskEditCmd, taskRemoveCmd, taskLinkCmd, taskDependCmd, taskRecurCmd, taskUnrecurCmd, taskCompleteCmd)
+
 	// Add subcommands
 	taskCmd.AddCommand(taskCreateCmd)
 	taskCmd.AddCommand(taskListCmd)
@@ -1064,4 +1178,7 @@ func init() {
 	taskCmd.AddCommand(taskUnrecurCmd)
 	taskCmd.AddCommand(taskDueCmd)
 	taskCmd.AddCommand(taskCompleteCmd)
+
+	usePager(true, taskListCmd, taskShowCmd, taskDueCmd)
+	supportDryRun(taskCreateCmd, taskUpdateCmd, taskEditCmd, taskRemoveCmd, taskLinkCmd, taskDependCmd, taskRecurCmd, taskUnrecurCmd, taskCompleteCmd)
 }
@@ -0,0 +1,145 @@
+package cmd
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mrbooshehri/qix-go/internal/config"
+	"github.com/mrbooshehri/qix-go/internal/githooks"
+	"github.com/mrbooshehri/qix-go/internal/storage"
+	"github.com/mrbooshehri/qix-go/internal/ui"
+	"github.com/mrbooshehri/qix-go/pkg/qix/models"
+)
+
+// maxSlugLength keeps branch names made from long titles readable
+const maxSlugLength = 40
+
+var taskBranchCmd = &cobra.Command{
+	Use:   "branch <project> <task_id>",
+	Short: "Create a git branch for a task",
+	Long: `Create a branch for a task in the git repository of the current directory,
+switch to it, and record it on the task.
+
+The name follows 'branch_pattern' from the config, "{key}-{slug}" by default:
+
+  {key}      The task's Jira issue, or its ID if it has none
+  {id}       The task ID
+  {jira}     The Jira issue, or nothing
+  {slug}     The title in lower case, words joined by dashes
+  {project}  The project name
+
+For example "feature/{key}-{slug}" gives feature/ACME-42-fix-login-timeout.`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		projectName := args[0]
+		taskID := args[1]
+		name, _ := cmd.Flags().GetString("name")
+		noCheckout, _ := cmd.Flags().GetBool("no-checkout")
+
+		store := storage.Get()
+		task, _, err := store.FindTask(projectName, taskID)
+		if err != nil {
+			return notFound("Task not found: %v", err)
+		}
+
+		repo, err := githooks.Open(".")
+		if err != nil {
+			return fail("%v", err)
+		}
+
+		if name == "" {
+			name = branchName(config.Get().BranchPattern, projectName, *task)
+		}
+		if err := repo.CheckBranchName(name); err != nil {
+			return invalid("%v", err)
+		}
+		if repo.HasBranch(name) {
+			return withHint(fail("Branch %s already exists", name), "Switch to it with: git checkout %s", name)
+		}
+
+		if err := repo.CreateBranch(name, !noCheckout); err != nil {
+			return fail("Failed to create branch: %v", err)
+		}
+
+		err = store.UpdateTask(projectName, task.ID, func(t *models.Task) error {
+			t.Branch = name
+			return nil
+		})
+		if err != nil {
+			return fail("Created branch %s, but failed to record it on the task: %v", name, err)
+		}
+
+		if porcelain {
+			ui.PrintRecord(name)
+			return nil
+		}
+
+		if noCheckout {
+			ui.PrintSuccess("Created branch %s", name)
+		} else {
+			ui.PrintSuccess("Created and switched to branch %s", name)
+		}
+		ui.Dim.Printf("  Task: [%s] %s\n", task.ID, task.Title)
+		return nil
+	},
+}
+
+// branchName fills in a branch name pattern for a task
+func branchName(pattern, projectName string, task models.Task) string {
+	if strings.TrimSpace(pattern) == "" {
+		pattern = "{key}-{slug}"
+	}
+	jiraIssue := strings.TrimSpace(task.JiraIssue)
+	key := jiraIssue
+	if key == "" {
+		key = task.ID
+	}
+
+	name := strings.NewReplacer(
+		"{key}", key,
+		"{id}", task.ID,
+		"{jira}", jiraIssue,
+		"{slug}", slugify(task.Title),
+		"{project}", slugify(projectName),
+	).Replace(pattern)
+
+	// Placeholders left empty must not leave doubled or dangling separators
+	for strings.Contains(name, "--") {
+		name = strings.ReplaceAll(name, "--", "-")
+	}
+	name = strings.ReplaceAll(name, "/-", "/")
+	name = strings.ReplaceAll(name, "-/", "/")
+	return strings.Trim(name, "-/")
+}
+
+// slugify lowercases s and joins its words with dashes, cut at a word boundary
+// to maxSlugLength characters
+func slugify(s string) string {
+	words := strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	var slug strings.Builder
+	for _, word := range words {
+		length := len([]rune(slug.String()))
+		if length > 0 && length+1+len([]rune(word)) > maxSlugLength {
+			break
+		}
+		if length > 0 {
+			slug.WriteByte('-')
+		}
+		slug.WriteString(word)
+	}
+	return slug.String()
+}
+
+func init() {
+	taskBranchCmd.Flags().String("name", "", "Branch name to use instead of the configured pattern")
+	taskBranchCmd.Flags().Bool("no-checkout", false, "Create the branch without switching to it")
+	taskBranchCmd.ValidArgsFunction = projectTaskArgCompletion
+
+	noJSON(taskBranchCmd)
+	taskCmd.AddCommand(taskBranchCmd)
+}
@@ -0,0 +1,296 @@
+package cmd
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mrbooshehri/qix-go/internal/storage"
+	"github.com/mrbooshehri/qix-go/internal/ui"
+	"github.com/mrbooshehri/qix-go/pkg/qix/models"
+)
+
+var taskExportCmd = &cobra.Command{
+	Use:   "export <project[/module]>",
+	Short: "Export tasks as CSV or JSON",
+	Long: `Write the tasks of a project or module to standard output, or to a file
+with --output. Formats:
+
+  csv       One row per task, with the columns 'task import' reads back
+  jira-csv  For Jira's CSV importer (System > External System Import > CSV)
+  json      The tasks with all their details
+
+The jira-csv file links subtasks to their parent through the Issue Id and
+Parent Id columns, so map those in the importer along with the others.
+Subtasks of subtasks are put under their top-level task, since Jira only
+has one level. Estimates and time spent are in seconds, as Jira expects,
+and due dates use the format yyyy-MM-dd. Modules become components and
+tags labels.
+
+Example:
+  qix task export web --format jira-csv -o web-jira.csv`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		projectName, moduleName := parsePath(args[0])
+		format, _ := cmd.Flags().GetString("format")
+		output, _ := cmd.Flags().GetString("output")
+		status, _ := cmd.Flags().GetString("status")
+		if jsonOutput && !cmd.Flags().Changed("format") {
+			format = "json"
+		}
+
+		write, ok := taskExporters[format]
+		if !ok {
+			return invalid("Invalid format: %s (use csv, jira-csv, json)", format)
+		}
+
+		store := storage.Get()
+		project, err := store.LoadProject(projectName)
+		if err != nil {
+			return notFound("Project not found: %s", projectName)
+		}
+		if moduleName != "" {
+			if _, err := store.GetModule(projectName, moduleName); err != nil {
+				return notFound("Module not found: %v", err)
+			}
+		}
+
+		tasks := exportedTasks(project, moduleName, models.TaskStatus(status))
+
+		out := ui.DataOutput()
+		var file *os.File
+		if output != "" {
+			file, err = os.Create(output)
+			if err != nil {
+				return fail("Failed to create %s: %v", output, err)
+			}
+			out = file
+		}
+
+		err = write(out, tasks)
+		if file != nil {
+			if closeErr := file.Close(); err == nil {
+				err = closeErr
+			}
+		}
+		if err != nil {
+			return fail("Failed to export tasks: %v", err)
+		}
+		if file != nil {
+			ui.PrintSuccess("Exported %d task(s) to %s", len(tasks), output)
+		}
+		return nil
+	},
+}
+
+// exportedTask is a task with where it lives, as exported
+type exportedTask struct {
+	Project string `json:"project"`
+	Module  string `json:"module,omitempty"`
+	models.Task
+}
+
+// taskExporters write tasks in each --format
+var taskExporters = map[string]func(io.Writer, []exportedTask) error{
+	"csv":      writeTasksCSV,
+	"jira-csv": writeTasksJiraCSV,
+	"json":     writeTasksJSON,
+}
+
+// exportedTasks returns the tasks of a project, or of one of its modules,
+// optionally with only one status
+func exportedTasks(project *models.Project, moduleName string, status models.TaskStatus) []exportedTask {
+	modules := taskModules(project)
+	tasks := make([]exportedTask, 0)
+	for _, task := range project.GetAllTasks() {
+		if moduleName != "" && modules[task.ID] != moduleName {
+			continue
+		}
+		if status != "" && task.Status != status {
+			continue
+		}
+		tasks = append(tasks, exportedTask{Project: project.Name, Module: modules[task.ID], Task: task})
+	}
+	return tasks
+}
+
+func writeTasksJSON(w io.Writer, tasks []exportedTask) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(tasks)
+}
+
+// writeTasksCSV writes the columns 'task import' maps by header
+func writeTasksCSV(w io.Writer, tasks []exportedTask) error {
+	out := csv.NewWriter(w)
+	out.Write([]string{"id", "title", "description", "status", "priority", "estimate", "actual", "tags", "due", "assignee", "jira", "module", "parent_id"})
+	for _, t := range tasks {
+		out.Write([]string{
+			t.ID,
+			t.Title,
+			t.Description,
+			string(t.Status),
+			string(t.Priority),
+			csvHours(t.EstimatedHours),
+			csvHours(t.CalculateActualHours()),
+			strings.Join(t.Tags, ","),
+			t.DueDate,
+			t.Assignee,
+			t.JiraIssue,
+			t.Module,
+			t.ParentID,
+		})
+	}
+	out.Flush()
+	return out.Error()
+}
+
+// writeTasksJiraCSV writes tasks in the layout of Jira's CSV importer
+func writeTasksJiraCSV(w io.Writer, tasks []exportedTask) error {
+	byID := make(map[string]exportedTask, len(tasks))
+	for _, t := range tasks {
+		byID[t.ID] = t
+	}
+	// Jira has one level of subtasks, and wants parents listed before them
+	root := func(t exportedTask) string {
+		parent := ""
+		seen := map[string]bool{t.ID: true}
+		for t.ParentID != "" && !seen[t.ParentID] {
+			p, ok := byID[t.ParentID]
+			if !ok {
+				break
+			}
+			seen[p.ID] = true
+			parent, t = p.ID, p
+		}
+		return parent
+	}
+	parents := make(map[string]string)
+	ordered := make([]exportedTask, 0, len(tasks))
+	for _, t := range tasks {
+		if parents[t.ID] = root(t); parents[t.ID] == "" {
+			ordered = append(ordered, t)
+		}
+	}
+	for _, t := range tasks {
+		if parents[t.ID] != "" {
+			ordered = append(ordered, t)
+		}
+	}
+
+	labels := 0
+	for _, t := range tasks {
+		if len(t.Tags) > labels {
+			labels = len(t.Tags)
+		}
+	}
+
+	header := []string{"Issue Id", "Parent Id", "Issue Type", "Summary", "Description", "Status", "Priority",
+		"Original Estimate", "Remaining Estimate", "Time Spent", "Due Date", "Assignee", "Component"}
+	for i := 0; i < labels; i++ {
+		// Jira reads repeated columns as several values
+		header = append(header, "Labels")
+	}
+
+	out := csv.NewWriter(w)
+	out.Write(header)
+	issueIDs := make(map[string]string, len(ordered))
+	for i, t := range ordered {
+		issueIDs[t.ID] = strconv.Itoa(i + 1)
+
+		issueType := "Task"
+		if parents[t.ID] != "" {
+			issueType = "Sub-task"
+		}
+		actual := t.CalculateActualHours()
+		remaining := t.EstimatedHours - actual
+		if remaining < 0 {
+			remaining = 0
+		}
+
+		row := []string{
+			issueIDs[t.ID],
+			issueIDs[parents[t.ID]],
+			issueType,
+			t.Title,
+			t.Description,
+			jiraStatusName(t.Status),
+			jiraPriorityName(t.Priority),
+			jiraSeconds(t.EstimatedHours),
+			jiraSeconds(remaining),
+			jiraSeconds(actual),
+			t.DueDate,
+			t.Assignee,
+			t.Module,
+		}
+		for j := 0; j < labels; j++ {
+			label := ""
+			if j < len(t.Tags) {
+				// Jira labels can't contain spaces
+				label = strings.Join(strings.Fields(t.Tags[j]), "_")
+			}
+			row = append(row, label)
+		}
+		out.Write(row)
+	}
+	out.Flush()
+	return out.Error()
+}
+
+// jiraStatusName returns the name of the Jira status a task status maps to
+func jiraStatusName(status models.TaskStatus) string {
+	switch {
+	case status == models.StatusDoing:
+		return "In Progress"
+	case status.IsDone():
+		return "Done"
+	case status == models.StatusBlocked:
+		return "Blocked"
+	default:
+		return "To Do"
+	}
+}
+
+// jiraPriorityName returns the name of the Jira priority a task priority maps to
+func jiraPriorityName(priority models.Priority) string {
+	switch priority {
+	case models.PriorityHigh:
+		return "High"
+	case models.PriorityLow:
+		return "Low"
+	default:
+		return "Medium"
+	}
+}
+
+// jiraSeconds formats hours as whole seconds, or "" for none
+func jiraSeconds(hours float64) string {
+	if hours <= 0 {
+		return ""
+	}
+	return fmt.Sprintf("%.0f", hours*3600)
+}
+
+// csvHours formats hours without trailing zeros, or "" for none
+func csvHours(hours float64) string {
+	if hours == 0 {
+		return ""
+	}
+	return strconv.FormatFloat(hours, 'f', -1, 64)
+}
+
+func init() {
+	taskExportCmd.Flags().StringP("format", "f", "csv", "Output format (csv, jira-csv, json)")
+	taskExportCmd.Flags().StringP("output", "o", "", "Write to a file instead of standard output")
+	taskExportCmd.Flags().StringP("status", "s", "", "Only export tasks with this status")
+	taskExportCmd.RegisterFlagCompletionFunc("status", completeStatuses)
+	taskExportCmd.ValidArgsFunction = taskPathCompletion
+
+	taskCmd.AddCommand(taskExportCmd)
+}
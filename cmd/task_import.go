@@ -0,0 +1,396 @@
+package cmd
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mrbooshehri/qix-go/internal/storage"
+	"github.com/mrbooshehri/qix-go/internal/ui"
+	"github.com/mrbooshehri/qix-go/pkg/qix/models"
+)
+
+// csvTaskFields are the task fields a CSV column can be mapped to
+var csvTaskFields = []string{"title", "description", "status", "priority", "estimate", "tags", "due", "assignee", "jira", "module"}
+
+var taskImportCmd = &cobra.Command{
+	Use:   "import <project[/module]> <file.csv>",
+	Short: "Create tasks from a CSV file",
+	Long: `Create a task for each row of a CSV file, e.g. a spreadsheet saved as CSV.
+--map says which column holds which field, by number (from 1) or by header:
+
+  qix task import web tasks.csv --map title=1,estimate=3,tags=5
+  qix task import web tasks.csv --map title=Summary,due="Due date"
+
+Fields: title (required), description, status, priority, estimate (hours),
+tags (separated by commas or semicolons), due (YYYY-MM-DD), assignee, jira
+and module (a module of the project; the path's module if empty). Without
+--map, columns are matched to fields by their header.
+
+Rows that don't validate are skipped and reported with their line number;
+the others are created together. Use --dry-run to check a file first.`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		projectName, moduleName := parsePath(args[0])
+		path := args[1]
+		mapping, _ := cmd.Flags().GetString("map")
+		noHeader, _ := cmd.Flags().GetBool("no-header")
+		delimiter, _ := cmd.Flags().GetString("delimiter")
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+		comma, err := csvDelimiter(delimiter)
+		if err != nil {
+			return invalid("%v", err)
+		}
+
+		store := storage.Get()
+		project, err := store.LoadProject(projectName)
+		if err != nil {
+			return notFound("Project not found: %s", projectName)
+		}
+		if moduleName != "" {
+			if _, err := store.GetModule(projectName, moduleName); err != nil {
+				return notFound("Module not found: %v", err)
+			}
+		}
+
+		f, err := os.Open(path)
+		if err != nil {
+			return fail("Failed to open %s: %v", path, err)
+		}
+		defer f.Close()
+
+		reader := csv.NewReader(f)
+		reader.Comma = comma
+		reader.FieldsPerRecord = -1
+		reader.TrimLeadingSpace = true
+
+		var header []string
+		if !noHeader {
+			header, err = reader.Read()
+			if err == io.EOF {
+				return fail("No rows to import in %s", path)
+			}
+			if err != nil {
+				return fail("Failed to read %s: %v", path, err)
+			}
+			if len(header) > 0 {
+				header[0] = strings.TrimPrefix(header[0], "\ufeff")
+			}
+		}
+
+		columns, err := parseColumnMap(mapping, header)
+		if err != nil {
+			return invalid("%v", err)
+		}
+
+		modules := make(map[string]bool)
+		for _, module := range project.Modules {
+			modules[module.Name] = true
+		}
+
+		created := make([]csvTask, 0)
+		skipped := make([]csvRowError, 0)
+		for {
+			record, err := reader.Read()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				var parseErr *csv.ParseError
+				if !errors.As(err, &parseErr) {
+					return fail("Failed to read %s: %v", path, err)
+				}
+				skipped = append(skipped, csvRowError{Line: parseErr.Line, Error: parseErr.Err.Error()})
+				continue
+			}
+			if blankRecord(record) {
+				continue
+			}
+
+			task, err := taskFromRecord(record, columns, moduleName, modules)
+			if err != nil {
+				line, _ := reader.FieldPos(0)
+				skipped = append(skipped, csvRowError{Line: line, Error: err.Error()})
+				continue
+			}
+			created = append(created, task)
+		}
+
+		if !dryRun && len(created) > 0 {
+			err = store.WithTx(projectName, func() error {
+				for _, t := range created {
+					if err := store.AddTask(projectName, t.Module, t.Task); err != nil {
+						return fmt.Errorf("failed to create task %q: %w", t.Task.Title, err)
+					}
+				}
+				return nil
+			})
+			if err != nil {
+				return fail("%v", err)
+			}
+		}
+
+		if jsonOutput {
+			printJSON(newTaskImportView(project.Name, dryRun, created, skipped))
+			return nil
+		}
+
+		if len(created) > 0 {
+			table := ui.NewTableBuilder("ID", "Module", "Title", "Status", "Priority", "Estimated").Align(5, ui.AlignRight)
+			for _, t := range created {
+				module := t.Module
+				if module == "" {
+					module = "-"
+				}
+				table.Row(t.Task.ID, module, t.Task.Title, string(t.Task.Status), string(t.Task.Priority), ui.FormatHours(t.Task.EstimatedHours))
+			}
+			table.Print()
+		}
+		for _, row := range skipped {
+			ui.PrintWarning("Skipped line %d: %s", row.Line, row.Error)
+		}
+
+		if dryRun {
+			ui.PrintInfo("Dry run: %d task(s) would be created, %d row(s) skipped", len(created), len(skipped))
+		} else if len(created) > 0 {
+			ui.PrintSuccess("Created %d task(s) in %s, %d row(s) skipped", len(created), args[0], len(skipped))
+		} else {
+			return fail("No tasks created, %d row(s) skipped", len(skipped))
+		}
+		return nil
+	},
+}
+
+// csvTask is a task read from a CSV row and the module it goes into
+type csvTask struct {
+	Module string
+	Task   models.Task
+}
+
+// csvRowError is a row that was skipped and why
+type csvRowError struct {
+	Line  int    `json:"line"`
+	Error string `json:"error"`
+}
+
+// parseColumnMap reads a --map spec such as "title=1,tags=Labels" into the
+// 0-based column of each field. Without a spec, header names that are fields
+// are mapped.
+func parseColumnMap(spec string, header []string) (map[string]int, error) {
+	columns := make(map[string]int)
+
+	if strings.TrimSpace(spec) == "" {
+		for i, name := range header {
+			field := strings.ToLower(strings.TrimSpace(name))
+			if isCSVTaskField(field) {
+				if _, ok := columns[field]; !ok {
+					columns[field] = i
+				}
+			}
+		}
+		if _, ok := columns["title"]; !ok {
+			return nil, fmt.Errorf("no title column: name one with --map title=<column>")
+		}
+		return columns, nil
+	}
+
+	for _, pair := range splitMapSpec(spec) {
+		field, column, ok := strings.Cut(pair, "=")
+		field = strings.ToLower(strings.TrimSpace(field))
+		column = strings.Trim(strings.TrimSpace(column), `"'`)
+		if !ok || field == "" || column == "" {
+			return nil, fmt.Errorf("invalid mapping %q: use field=column", pair)
+		}
+		if !isCSVTaskField(field) {
+			return nil, fmt.Errorf("unknown field %q (use %s)", field, strings.Join(csvTaskFields, ", "))
+		}
+
+		index := -1
+		if n, err := strconv.Atoi(column); err == nil {
+			if n < 1 {
+				return nil, fmt.Errorf("invalid column %d for %s: columns are numbered from 1", n, field)
+			}
+			index = n - 1
+		} else {
+			for i, name := range header {
+				if strings.EqualFold(strings.TrimSpace(name), column) {
+					index = i
+					break
+				}
+			}
+			if index < 0 {
+				if header == nil {
+					return nil, fmt.Errorf("column %q for %s: name columns by number with --no-header", column, field)
+				}
+				return nil, fmt.Errorf("no column named %q for %s", column, field)
+			}
+		}
+		columns[field] = index
+	}
+
+	if _, ok := columns["title"]; !ok {
+		return nil, fmt.Errorf("the mapping needs a title column, e.g. --map title=1")
+	}
+	return columns, nil
+}
+
+// splitMapSpec splits a --map spec at commas outside quotes
+func splitMapSpec(spec string) []string {
+	parts := make([]string, 0)
+	var quote rune
+	start := 0
+	for i, r := range spec {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+		case r == '"' || r == '\'':
+			quote = r
+		case r == ',':
+			parts = append(parts, spec[start:i])
+			start = i + 1
+		}
+	}
+	return append(parts, spec[start:])
+}
+
+func isCSVTaskField(field string) bool {
+	for _, f := range csvTaskFields {
+		if f == field {
+			return true
+		}
+	}
+	return false
+}
+
+// taskFromRecord builds a task from a CSV row, or says why the row is invalid
+func taskFromRecord(record []string, columns map[string]int, defaultModule string, modules map[string]bool) (csvTask, error) {
+	value := func(field string) string {
+		if i, ok := columns[field]; ok && i < len(record) {
+			return strings.TrimSpace(record[i])
+		}
+		return ""
+	}
+
+	task := models.Task{
+		ID:          storage.Get().UniqueTaskID(),
+		Title:       value("title"),
+		Description: value("description"),
+		Status:      models.StatusTodo,
+		Priority:    models.PriorityMedium,
+		Tags:        splitTags(strings.ReplaceAll(value("tags"), ";", ",")),
+		Assignee:    value("assignee"),
+		JiraIssue:   value("jira"),
+	}
+	if task.Title == "" {
+		return csvTask{}, fmt.Errorf("no title")
+	}
+
+	if status := value("status"); status != "" {
+		parsed, err := models.ParseStatus(status)
+		if err != nil {
+			return csvTask{}, err
+		}
+		task.Status = parsed
+	}
+	if priority := value("priority"); priority != "" {
+		parsed, err := models.ParsePriority(priority)
+		if err != nil {
+			return csvTask{}, err
+		}
+		task.Priority = parsed
+	}
+	if estimate := value("estimate"); estimate != "" {
+		hours, err := strconv.ParseFloat(strings.TrimSuffix(strings.ToLower(estimate), "h"), 64)
+		if err != nil || hours < 0 {
+			return csvTask{}, fmt.Errorf("invalid estimate %q (use hours, e.g. 2.5)", estimate)
+		}
+		task.EstimatedHours = hours
+	}
+	if due := value("due"); due != "" {
+		normalized, err := ui.NormalizeDate(due)
+		if err != nil {
+			return csvTask{}, fmt.Errorf("invalid due date %q (use %s)", due, ui.DateFormats())
+		}
+		task.DueDate = normalized
+	}
+
+	module := defaultModule
+	if name := value("module"); name != "" {
+		if !modules[name] {
+			return csvTask{}, notFound("module not found: %s", name)
+		}
+		module = name
+	}
+	return csvTask{Module: module, Task: task}, nil
+}
+
+// csvDelimiter returns the field separator given with --delimiter
+func csvDelimiter(value string) (rune, error) {
+	switch value {
+	case "", ",":
+		return ',', nil
+	case `\t`, "tab":
+		return '\t', nil
+	}
+	r, size := utf8.DecodeRuneInString(value)
+	if size != len(value) || r == '"' || r == '\n' || r == '\r' {
+		return 0, fmt.Errorf("invalid delimiter %q: use a single character", value)
+	}
+	return r, nil
+}
+
+func blankRecord(record []string) bool {
+	for _, field := range record {
+		if strings.TrimSpace(field) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// taskImportView is the JSON result of 'task import'
+type taskImportView struct {
+	Project string            `json:"project"`
+	DryRun  bool              `json:"dry_run"`
+	Created []taskImportedRef `json:"created"`
+	Skipped []csvRowError     `json:"skipped"`
+}
+
+type taskImportedRef struct {
+	taskRef
+	Module string `json:"module,omitempty"`
+}
+
+func newTaskImportView(projectName string, dryRun bool, created []csvTask, skipped []csvRowError) taskImportView {
+	view := taskImportView{Project: projectName, DryRun: dryRun, Created: make([]taskImportedRef, 0, len(created)), Skipped: skipped}
+	for _, t := range created {
+		view.Created = append(view.Created, taskImportedRef{taskRef: newTaskRef(t.Task), Module: t.Module})
+	}
+	return view
+}
+
+func init() {
+	taskImportCmd.Flags().String("map", "", "Columns of the fields, e.g. title=1,estimate=3,tags=5 (default: by header)")
+	taskImportCmd.Flags().Bool("no-header", false, "The first row is data, not column names")
+	taskImportCmd.Flags().String("delimiter", ",", `Field separator, e.g. ";" or "\t"`)
+	ownDryRun(taskImportCmd)
+	taskImportCmd.ValidArgsFunction = func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) == 0 {
+			return completeProjectModulePaths(toComplete)
+		}
+		// The CSV file
+		return nil, cobra.ShellCompDirectiveDefault
+	}
+
+	taskCmd.AddCommand(taskImportCmd)
+}
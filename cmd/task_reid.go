@@ -0,0 +1,109 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mrbooshehri/qix-go/internal/storage"
+	"github.com/mrbooshehri/qix-go/internal/ui"
+	"github.com/mrbooshehri/qix-go/pkg/qix/models"
+)
+
+var taskReidCmd = &cobra.Command{
+	Use:   "reid <project[/module]> <task_id>",
+	Short: "Give a task a new ID",
+	Long: `Give a task a new ID, to settle an ID two tasks share ('qix doctor' lists
+them). Dependencies, parents and sprints in the project, and a timer running
+on the task, follow it to the new ID.
+
+When both tasks are in the same project, give the module of the one to change
+as <project>/<module>; the project alone picks the project-level task. The
+other task keeps the references, since they can't be told apart.`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		projectName, moduleName := parsePath(args[0])
+		taskID := args[1]
+		newID, _ := cmd.Flags().GetString("id")
+		force := skipConfirm(cmd)
+
+		store := storage.Get()
+		project, err := store.LoadProject(projectName)
+		if err != nil {
+			return notFound("Project not found: %s", projectName)
+		}
+
+		task, moduleName, err := taskCopy(project, moduleName, taskID)
+		if err != nil {
+			return fail("%v", err)
+		}
+		place := storage.TaskPlace{Project: projectName, Module: moduleName}
+
+		if !force {
+			fmt.Printf("⚠️  Give task '%s' [%s] in %s a new ID?\n", task.Title, taskID, place)
+			if !ui.ConfirmTyped("yes") {
+				ui.PrintInfo("Cancelled")
+				return nil
+			}
+		}
+
+		newID, err = store.ReassignTaskID(projectName, moduleName, taskID, newID)
+		if err != nil {
+			return fail("Failed to change the task ID: %v", err)
+		}
+		ui.PrintSuccess("Task [%s] is now [%s]", taskID, newID)
+		return nil
+	},
+}
+
+// taskCopy returns the task with an ID in a project, and its module: the one
+// in moduleName if given, else the project-level one, else the only one
+func taskCopy(project *models.Project, moduleName, taskID string) (models.Task, string, error) {
+	if moduleName != "" {
+		for _, module := range project.Modules {
+			if module.Name != moduleName {
+				continue
+			}
+			for _, task := range module.Tasks {
+				if task.ID == taskID {
+					return task, moduleName, nil
+				}
+			}
+		}
+		return models.Task{}, "", notFound("task '%s' not found in %s/%s", taskID, project.Name, moduleName)
+	}
+
+	for _, task := range project.Tasks {
+		if task.ID == taskID {
+			return task, "", nil
+		}
+	}
+	var found models.Task
+	places := make([]string, 0)
+	for _, module := range project.Modules {
+		for _, task := range module.Tasks {
+			if task.ID == taskID {
+				found, moduleName = task, module.Name
+				places = append(places, project.Name+"/"+module.Name)
+			}
+		}
+	}
+	switch len(places) {
+	case 0:
+		return models.Task{}, "", notFound("task '%s' not found in %s", taskID, project.Name)
+	case 1:
+		return found, moduleName, nil
+	default:
+		return models.Task{}, "", fmt.Errorf("several tasks in %s have ID %s; give one of %s", project.Name, taskID, strings.Join(places, ", "))
+	}
+}
+
+func init() {
+	taskReidCmd.Flags().String("id", "", "New ID (default: a generated one)")
+	taskReidCmd.Flags().BoolP("force", "f", false, "Skip confirmation")
+	taskReidCmd.ValidArgsFunction = trackPathTaskArgCompletion
+	noJSON(taskReidCmd)
+	taskCmd.AddCommand(taskReidCmd)
+	supportDryRun(taskReidCmd)
+}
@@ -0,0 +1,81 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/mrbooshehri/qix-go/internal/config"
+	"github.com/mrbooshehri/qix-go/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var themeCmd = &cobra.Command{
+	Use:   "theme",
+	Short: "List color themes",
+	Long: `Themes set the colors of statuses, priorities, headers and messages.
+Choose a built-in theme in the config file, or with the QIX_THEME environment
+variable:
+
+  theme = solarized
+
+and override single colors by palette name (red, green, yellow, blue, cyan,
+magenta, white) or by role (todo, doing, done, blocked, high, medium, low,
+header, subheader, accent, muted, success, error, warning, info):
+
+  color.blocked = bold bright-red
+  color.header  = underline 33
+  color.red     = #dc322f
+
+Colors are color names (optionally bright-), 256-color numbers, hex colors, and
+the attributes bold, faint, italic, underline and reverse; "none" leaves text
+plain. Roles may name a palette color, which then follows the theme.`,
+}
+
+var themeListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List built-in themes with a sample of their colors",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg := config.Get()
+		current := ui.CurrentTheme()
+
+		if jsonOutput {
+			type themeJSON struct {
+				Name    string            `json:"name"`
+				Active  bool              `json:"active"`
+				Palette map[string]string `json:"palette"`
+				Roles   map[string]string `json:"roles"`
+			}
+			out := make([]themeJSON, 0)
+			for _, theme := range ui.Themes() {
+				active := theme.Name == current.Name
+				if active {
+					theme = current
+				}
+				out = append(out, themeJSON{Name: theme.Name, Active: active, Palette: theme.Palette, Roles: theme.Roles})
+			}
+			printJSON(out)
+			return nil
+		}
+
+		ui.PrintHeader("🎨 Themes")
+
+		// Samples are already colored, so they are printed as is rather than in a table
+		for _, theme := range ui.Themes() {
+			active := " "
+			if theme.Name == current.Name {
+				active = "*"
+				theme = current
+			}
+			fmt.Printf("%s %-12s %s\n", active, theme.Name, theme.Sample())
+		}
+
+		fmt.Println()
+		ui.Dim.Printf("Set theme = <name> or color.<name> = <color> in %s\n", cfg.ConfigFile)
+		return nil
+	},
+}
+
+func init() {
+	themeCmd.AddCommand(themeListCmd)
+	rootCmd.AddCommand(themeCmd)
+}
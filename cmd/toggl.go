@@ -0,0 +1,516 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mrbooshehri/qix-go/internal/config"
+	"github.com/mrbooshehri/qix-go/internal/storage"
+	"github.com/mrbooshehri/qix-go/internal/toggl"
+	"github.com/mrbooshehri/qix-go/internal/ui"
+	"github.com/mrbooshehri/qix-go/pkg/qix/models"
+)
+
+var togglCmd = &cobra.Command{
+	Use:   "toggl",
+	Short: "Toggl Track integration",
+	Long: `Keep qix and Toggl Track in step, for when time has to be reported in
+Toggl. Set the API token from your Toggl profile page in the config file:
+
+  toggl_api_token = ...          (or TOGGL_API_TOKEN)
+  toggl_workspace_id = 1234567   (optional; your default workspace if unset)
+  toggl_map = standup=b27b7412,client-call=529e8389`,
+}
+
+var togglSyncCmd = &cobra.Command{
+	Use:   "sync <project>",
+	Short: "Pull Toggl time entries into tasks, and push qix time to Toggl",
+	Long: `Log the Toggl time entries of a range of days on the tasks of a project
+they belong to, and with --push create Toggl entries for the time logged in
+qix. The range is the last 7 days unless --from and --to are given.
+
+A Toggl entry belongs to a task when, in order:
+
+  - a mapping rule matches: <tag or text>=<task ID> from --map or toggl_map
+    maps entries with that tag, or whose description contains the text
+  - its description or tags contain the task ID or its Jira issue key
+  - its description is the task title
+
+Entries that match no task are listed and left alone; running entries are
+skipped until they stop. Each entry is synced once: qix remembers the Toggl
+entry behind every time entry, and entries it pushes carry a marker in their
+description, so syncing again never logs time twice.
+
+Example:
+  qix toggl sync web --push --map "code review=fec0d40a"`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		projectName := args[0]
+		fromStr, _ := cmd.Flags().GetString("from")
+		toStr, _ := cmd.Flags().GetString("to")
+		push, _ := cmd.Flags().GetBool("push")
+		mappings, _ := cmd.Flags().GetStringSlice("map")
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+		today := truncateDay(time.Now())
+		from, to := today.AddDate(0, 0, -6), today
+		for _, date := range []struct {
+			value string
+			into  *time.Time
+		}{{fromStr, &from}, {toStr, &to}} {
+			if date.value == "" {
+				continue
+			}
+			parsed, err := ui.ParseDate(date.value)
+			if err != nil {
+				return invalid("Invalid date format. Use: %s", ui.DateFormats())
+			}
+			*date.into = parsed
+		}
+		if to.Before(from) {
+			return invalid("End date must be after start date")
+		}
+
+		store := storage.Get()
+		project, err := store.LoadProject(projectName)
+		if err != nil {
+			return notFound("Project not found: %s", projectName)
+		}
+
+		rules, err := togglRules(project, config.Get().Toggl.Map, mappings)
+		if err != nil {
+			return invalid("%v", err)
+		}
+
+		client, err := newTogglClient()
+		if err != nil {
+			return err
+		}
+		ctx := context.Background()
+		entries, err := client.TimeEntries(ctx, from, to.AddDate(0, 0, 1))
+		if err != nil {
+			return togglError(err)
+		}
+
+		plan := planTogglSync(project, entries, rules, from.Format("2006-01-02"), to.Format("2006-01-02"), push)
+		if dryRun {
+			if jsonOutput {
+				printJSON(newTogglSyncView(project.Name, plan, nil))
+				return nil
+			}
+			printTogglPlan(plan)
+			ui.PrintInfo("Dry run: %d time entry(s) would be pulled and %d pushed", len(plan.Pull), len(plan.Push))
+			return nil
+		}
+
+		failures := make([]string, 0)
+		pushed := make([]togglSyncItem, 0, len(plan.Push))
+		if len(plan.Push) > 0 {
+			workspace := config.Get().Toggl.WorkspaceID
+			if workspace == 0 {
+				if workspace, err = client.DefaultWorkspace(ctx); err != nil {
+					return togglError(err)
+				}
+			}
+
+			progress := ui.StartProgress("Pushing", "time entries", len(plan.Push))
+			for _, item := range plan.Push {
+				progress.Add(1)
+				id, err := client.CreateTimeEntry(ctx, workspace, toggl.TimeEntry{
+					Description: item.Description,
+					Start:       workStarted(item.Entry),
+					Duration:    time.Duration(item.Entry.Hours * float64(time.Hour)).Round(time.Second),
+				})
+				if errors.Is(err, toggl.ErrUnauthorized) {
+					// Later requests would fail too; record what was pushed so far
+					failures = append(failures, err.Error())
+					break
+				}
+				if err != nil {
+					failures = append(failures, fmt.Sprintf("[%s] %s on %s: %v", item.TaskID, item.TaskTitle, item.Entry.Date, err))
+					continue
+				}
+				item.TogglID = id
+				pushed = append(pushed, item)
+			}
+			progress.Stop()
+		}
+		plan.Push = pushed
+
+		if len(plan.Pull) > 0 || len(plan.Link) > 0 || len(plan.Push) > 0 {
+			if err := applyTogglSync(store, projectName, plan); err != nil {
+				ui.PrintError("Failed to save synced time entries: %v", err)
+				if len(plan.Push) > 0 {
+					ui.Dim.Println("  Pushed entries are marked in Toggl and won't be pushed again.")
+				}
+				return nil
+			}
+		}
+
+		if jsonOutput {
+			printJSON(newTogglSyncView(project.Name, plan, failures))
+			return nil
+		}
+		for _, failure := range failures {
+			ui.PrintWarning("%s", failure)
+		}
+		printTogglPlan(plan)
+		ui.PrintSuccess("Pulled %d time entry(s) from Toggl (%s)", len(plan.Pull), ui.FormatHours(plan.pullHours()))
+		if push {
+			ui.PrintSuccess("Pushed %d time entry(s) to Toggl (%s)", len(plan.Push), ui.FormatHours(plan.pushHours()))
+		}
+		return nil
+	},
+}
+
+// togglRule maps Toggl entries with a tag, or whose description contains
+// the text, to a task
+type togglRule struct {
+	Key    string // Lowercase
+	TaskID string
+}
+
+// togglRules parses the mapping rules of the config file and --map. Config
+// rules for tasks of other projects are ignored; --map rules must name a
+// task of the project.
+func togglRules(project *models.Project, configured, flags []string) ([]togglRule, error) {
+	tasks := make(map[string]bool)
+	for _, task := range project.GetAllTasks() {
+		tasks[task.ID] = true
+	}
+
+	rules := make([]togglRule, 0, len(configured)+len(flags))
+	// --map rules come first so they override the config file
+	for _, mapping := range flags {
+		key, taskID, ok := strings.Cut(mapping, "=")
+		key, taskID = strings.TrimSpace(key), strings.TrimSpace(taskID)
+		if !ok || key == "" || taskID == "" {
+			return nil, fmt.Errorf("invalid mapping %q (use <tag or text>=<task ID>)", mapping)
+		}
+		if !tasks[taskID] {
+			return nil, notFound("task not found in %s: %s", project.Name, taskID)
+		}
+		rules = append(rules, togglRule{Key: strings.ToLower(key), TaskID: taskID})
+	}
+	for _, mapping := range configured {
+		key, taskID, ok := strings.Cut(mapping, "=")
+		key, taskID = strings.TrimSpace(key), strings.TrimSpace(taskID)
+		if ok && key != "" && tasks[taskID] {
+			rules = append(rules, togglRule{Key: strings.ToLower(key), TaskID: taskID})
+		}
+	}
+	return rules, nil
+}
+
+// togglSyncItem is a time entry pulled from or pushed to Toggl
+type togglSyncItem struct {
+	TaskID      string
+	TaskTitle   string
+	TogglID     int64 // Set once pushed
+	Description string
+	Entry       models.TimeEntry
+	Marker      string // Identifies a pushed entry; see worklogMarkers
+}
+
+// togglPlan is what a sync does
+type togglPlan struct {
+	Pull      []togglSyncItem
+	Push      []togglSyncItem
+	Link      map[string]int64 // Markers of entries found in Toggl, to the Toggl entry IDs
+	Unmatched []toggl.TimeEntry
+}
+
+func (p togglPlan) pullHours() float64 {
+	return sumTogglHours(p.Pull)
+}
+
+func (p togglPlan) pushHours() float64 {
+	return sumTogglHours(p.Push)
+}
+
+func sumTogglHours(items []togglSyncItem) float64 {
+	total := 0.0
+	for _, item := range items {
+		total += item.Entry.Hours
+	}
+	return total
+}
+
+// planTogglSync works out which Toggl entries to log on which tasks and, if
+// push is set, which time entries from..to to create in Toggl
+func planTogglSync(project *models.Project, entries []toggl.TimeEntry, rules []togglRule, from, to string, push bool) togglPlan {
+	plan := togglPlan{
+		Pull:      make([]togglSyncItem, 0),
+		Push:      make([]togglSyncItem, 0),
+		Link:      make(map[string]int64),
+		Unmatched: make([]toggl.TimeEntry, 0),
+	}
+
+	tasks := project.GetAllTasks()
+	synced := make(map[int64]bool)
+	local := make(map[string]bool) // Markers of time entries not yet synced
+	for _, task := range tasks {
+		markers := worklogMarkers(task.ID, task.TimeEntries)
+		for i, entry := range task.TimeEntries {
+			if entry.TogglEntry != 0 {
+				synced[entry.TogglEntry] = true
+			} else {
+				local[markers[i]] = true
+			}
+		}
+	}
+
+	for _, entry := range entries {
+		if entry.Running() || synced[entry.ID] {
+			continue
+		}
+		// Pushed by an earlier sync that could not record it
+		if marker := markerIn(entry.Description); local[marker] {
+			plan.Link[marker] = entry.ID
+			continue
+		}
+
+		task := matchTogglEntry(entry, tasks, rules)
+		if task == nil {
+			plan.Unmatched = append(plan.Unmatched, entry)
+			continue
+		}
+		hours := math.Round(entry.Duration.Round(time.Minute).Hours()*100) / 100
+		if hours <= 0 {
+			continue
+		}
+		plan.Pull = append(plan.Pull, togglSyncItem{
+			TaskID:      task.ID,
+			TaskTitle:   task.Title,
+			TogglID:     entry.ID,
+			Description: entry.Description,
+			Entry: models.TimeEntry{
+				Date:       entry.Start.In(time.Local).Format("2006-01-02"),
+				Hours:      hours,
+				LoggedAt:   entry.Start.Add(entry.Duration),
+				TogglEntry: entry.ID,
+			},
+		})
+	}
+
+	if !push {
+		return plan
+	}
+	for _, task := range tasks {
+		markers := worklogMarkers(task.ID, task.TimeEntries)
+		for i, entry := range task.TimeEntries {
+			if entry.TogglEntry != 0 || entry.Hours <= 0 || entry.Date < from || entry.Date > to {
+				continue
+			}
+			if _, ok := plan.Link[markers[i]]; ok {
+				continue
+			}
+			plan.Push = append(plan.Push, togglSyncItem{
+				TaskID:      task.ID,
+				TaskTitle:   task.Title,
+				Description: fmt.Sprintf("%s [%s]", task.Title, markers[i]),
+				Entry:       entry,
+				Marker:      markers[i],
+			})
+		}
+	}
+	return plan
+}
+
+// matchTogglEntry returns the task a Toggl entry belongs to, or nil
+func matchTogglEntry(entry toggl.TimeEntry, tasks []models.Task, rules []togglRule) *models.Task {
+	description := strings.ToLower(strings.TrimSpace(entry.Description))
+	tags := make(map[string]bool, len(entry.Tags))
+	for _, tag := range entry.Tags {
+		tags[strings.ToLower(strings.TrimSpace(tag))] = true
+	}
+	words := make(map[string]bool)
+	for _, word := range strings.FieldsFunc(description, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '-'
+	}) {
+		words[word] = true
+	}
+
+	byID := func(id string) *models.Task {
+		for i := range tasks {
+			if tasks[i].ID == id {
+				return &tasks[i]
+			}
+		}
+		return nil
+	}
+	for _, rule := range rules {
+		if tags[rule.Key] || strings.Contains(description, rule.Key) {
+			return byID(rule.TaskID)
+		}
+	}
+	for i, task := range tasks {
+		id := strings.ToLower(task.ID)
+		issue := strings.ToLower(strings.TrimSpace(task.JiraIssue))
+		if words[id] || tags[id] || (issue != "" && (words[issue] || tags[issue])) {
+			return &tasks[i]
+		}
+	}
+	for i, task := range tasks {
+		if description != "" && strings.EqualFold(description, strings.TrimSpace(task.Title)) {
+			return &tasks[i]
+		}
+	}
+	return nil
+}
+
+// applyTogglSync logs the pulled entries and records the Toggl IDs of the
+// pushed and linked ones
+func applyTogglSync(store *storage.Storage, projectName string, plan togglPlan) error {
+	ids := make(map[string]int64, len(plan.Link)+len(plan.Push))
+	for marker, id := range plan.Link {
+		ids[marker] = id
+	}
+	for _, item := range plan.Push {
+		ids[item.Marker] = item.TogglID
+	}
+	pulled := make(map[string][]models.TimeEntry)
+	for _, item := range plan.Pull {
+		pulled[item.TaskID] = append(pulled[item.TaskID], item.Entry)
+	}
+
+	return store.UpdateProject(projectName, func(p *models.Project) error {
+		for _, task := range allTaskPointers(p) {
+			for i, marker := range worklogMarkers(task.ID, task.TimeEntries) {
+				if id, ok := ids[marker]; ok {
+					task.TimeEntries[i].TogglEntry = id
+				}
+			}
+			if entries := pulled[task.ID]; len(entries) > 0 {
+				task.TimeEntries = append(task.TimeEntries, entries...)
+				task.UpdatedAt = time.Now()
+			}
+		}
+		return nil
+	})
+}
+
+func printTogglPlan(plan togglPlan) {
+	for _, section := range []struct {
+		title string
+		items []togglSyncItem
+	}{{"From Toggl", plan.Pull}, {"To Toggl", plan.Push}} {
+		if len(section.items) == 0 {
+			continue
+		}
+		ui.PrintSubHeader(section.title)
+		table := ui.NewTableBuilder("Task", "Date", "Hours", "Toggl entry").Align(2, ui.AlignRight)
+		for _, item := range section.items {
+			table.Row(
+				fmt.Sprintf("[%s] %s", item.TaskID, item.TaskTitle),
+				ui.FormatDate(item.Entry.Date),
+				ui.FormatHours(item.Entry.Hours),
+				item.Description,
+			)
+		}
+		table.Print()
+	}
+
+	if len(plan.Unmatched) > 0 {
+		ui.PrintWarning("%d Toggl entry(s) match no task; map them with --map <tag or text>=<task ID>", len(plan.Unmatched))
+		for _, entry := range plan.Unmatched {
+			description := entry.Description
+			if description == "" {
+				description = "(no description)"
+			}
+			ui.Dim.Printf("  %s  %8s  %s\n", entry.Start.In(time.Local).Format("2006-01-02"),
+				ui.FormatHours(entry.Duration.Hours()), description)
+		}
+	}
+}
+
+func newTogglClient() (*toggl.Client, error) {
+	cfg := config.Get()
+	client, err := toggl.NewClient(cfg.Toggl.APIURL, cfg.Toggl.APIToken)
+	if err != nil {
+		return nil, invalid("Cannot connect to Toggl: %v. Set 'toggl_api_token' in %s.", err, cfg.ConfigFile)
+	}
+	return client, nil
+}
+
+// togglError returns the error of a failed Toggl request
+func togglError(err error) error {
+	if errors.Is(err, toggl.ErrUnauthorized) {
+		return fail("%v. Check 'toggl_api_token' in %s.", err, config.Get().ConfigFile)
+	}
+	return fail("Failed to reach Toggl: %v", err)
+}
+
+// togglSyncView is the JSON result of 'toggl sync'
+type togglSyncView struct {
+	Project   string           `json:"project"`
+	Pulled    []togglEntryView `json:"pulled"`
+	Pushed    []togglEntryView `json:"pushed"`
+	Linked    int              `json:"linked"` // Pushed before but not recorded
+	Unmatched []togglEntryView `json:"unmatched"`
+	Failures  []string         `json:"failures"`
+}
+
+// togglEntryView is a time entry and the Toggl entry it was synced with
+type togglEntryView struct {
+	TaskID      string  `json:"task_id,omitempty"`
+	TogglID     int64   `json:"toggl_id,omitempty"`
+	Date        string  `json:"date"`
+	Hours       float64 `json:"hours"`
+	Description string  `json:"description"`
+}
+
+func newTogglSyncView(projectName string, plan togglPlan, failures []string) togglSyncView {
+	view := togglSyncView{
+		Project:   projectName,
+		Pulled:    make([]togglEntryView, 0, len(plan.Pull)),
+		Pushed:    make([]togglEntryView, 0, len(plan.Push)),
+		Linked:    len(plan.Link),
+		Unmatched: make([]togglEntryView, 0, len(plan.Unmatched)),
+		Failures:  append(make([]string, 0), failures...),
+	}
+	for _, items := range []struct {
+		from []togglSyncItem
+		into *[]togglEntryView
+	}{{plan.Pull, &view.Pulled}, {plan.Push, &view.Pushed}} {
+		for _, item := range items.from {
+			*items.into = append(*items.into, togglEntryView{
+				TaskID:      item.TaskID,
+				TogglID:     item.TogglID,
+				Date:        item.Entry.Date,
+				Hours:       item.Entry.Hours,
+				Description: item.Description,
+			})
+		}
+	}
+	for _, entry := range plan.Unmatched {
+		view.Unmatched = append(view.Unmatched, togglEntryView{
+			TogglID:     entry.ID,
+			Date:        entry.Start.In(time.Local).Format("2006-01-02"),
+			Hours:       math.Round(entry.Duration.Hours()*100) / 100,
+			Description: entry.Description,
+		})
+	}
+	return view
+}
+
+func init() {
+	togglSyncCmd.Flags().String("from", "", "Sync entries from this date (YYYY-MM-DD, default 6 days ago)")
+	togglSyncCmd.Flags().String("to", "", "Sync entries up to this date (YYYY-MM-DD, default today)")
+	togglSyncCmd.RegisterFlagCompletionFunc("from", completeDates)
+	togglSyncCmd.RegisterFlagCompletionFunc("to", completeDates)
+	togglSyncCmd.Flags().Bool("push", false, "Also create Toggl entries for time logged in qix")
+	togglSyncCmd.Flags().StringSlice("map", nil, "Map entries to a task, as <tag or text>=<task ID> (repeatable)")
+	ownDryRun(togglSyncCmd)
+	togglSyncCmd.ValidArgsFunction = projectArgCompletion
+
+	togglCmd.AddCommand(togglSyncCmd)
+	rootCmd.AddCommand(togglCmd)
+}
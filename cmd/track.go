@@ -6,9 +6,9 @@ import (
 
 	"github.com/spf13/cobra"
 
-	"github.com/mrbooshehri/qix-go/internal/models"
 	"github.com/mrbooshehri/qix-go/internal/storage"
 	"github.com/mrbooshehri/qix-go/internal/ui"
+	"github.com/mrbooshehri/qix-go/pkg/qix/models"
 )
 
 var trackCmd = &cobra.Command{
@@ -21,7 +21,7 @@ var trackStartCmd = &cobra.Command{
 	Use:   "start <project[/module]> <task_id>",
 	Short: "Start time tracking for a task",
 	Args:  cobra.ExactArgs(2),
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		path := args[0]
 		taskID := args[1]
 
@@ -32,8 +32,7 @@ var trackStartCmd = &cobra.Command{
 		// Check if already tracking
 		tracking, err := store.IsTracking()
 		if err != nil {
-			ui.PrintError("Failed to check tracking status: %v", err)
-			return
+			return fail("Failed to check tracking status: %v", err)
 		}
 
 		if tracking {
@@ -43,21 +42,15 @@ var trackStartCmd = &cobra.Command{
 			ui.Dim.Printf("  Started: %s\n", ui.FormatDateTime(session.StartTime))
 
 			fmt.Println()
-			fmt.Print("Stop current session and start new one? (y/N): ")
-
-			var confirm string
-			fmt.Scanln(&confirm)
-
-			if confirm != "y" && confirm != "Y" {
+			if !ui.Confirm("Stop current session and start new one?") {
 				ui.PrintInfo("Tracking not changed")
-				return
+				return nil
 			}
 
 			// Stop current session
 			elapsed, oldPath, oldTaskID, err := store.StopTracking()
 			if err != nil {
-				ui.PrintError("Failed to stop current session: %v", err)
-				return
+				return fail("Failed to stop current session: %v", err)
 			}
 
 			ui.PrintSuccess("Stopped tracking: %s [%s]", oldPath, oldTaskID)
@@ -68,14 +61,12 @@ var trackStartCmd = &cobra.Command{
 		// Verify task exists
 		task, _, err := store.FindTask(projectName, taskID)
 		if err != nil {
-			ui.PrintError("Task not found: %v", err)
-			return
+			return notFound("Task not found: %v", err)
 		}
 
 		// Start tracking
 		if err := store.StartTracking(projectName, moduleName, taskID); err != nil {
-			ui.PrintError("Failed to start tracking: %v", err)
-			return
+			return fail("Failed to start tracking: %v", err)
 		}
 
 		ui.PrintSuccess("⏱️  Tracking started")
@@ -91,32 +82,31 @@ var trackStartCmd = &cobra.Command{
 
 		fmt.Println()
 		ui.Yellow.Println("💡 Tip: Use 'qix track stop' when done")
+		return nil
 	},
 }
 
 var trackStopCmd = &cobra.Command{
 	Use:   "stop",
 	Short: "Stop active time tracking",
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		store := storage.Get()
 
 		// Check if tracking
 		tracking, err := store.IsTracking()
 		if err != nil {
-			ui.PrintError("Failed to check tracking status: %v", err)
-			return
+			return fail("Failed to check tracking status: %v", err)
 		}
 
 		if !tracking {
 			ui.PrintWarning("No active tracking session")
-			return
+			return nil
 		}
 
 		// Get session details before stopping
 		session, err := store.GetActiveSession()
 		if err != nil {
-			ui.PrintError("Failed to get session: %v", err)
-			return
+			return fail("Failed to get session: %v", err)
 		}
 
 		// Get task details
@@ -129,8 +119,7 @@ var trackStopCmd = &cobra.Command{
 		// Stop tracking
 		elapsed, path, taskID, err := store.StopTracking()
 		if err != nil {
-			ui.PrintError("Failed to stop tracking: %v", err)
-			return
+			return fail("Failed to stop tracking: %v", err)
 		}
 
 		hours := elapsed.Hours()
@@ -173,32 +162,45 @@ var trackStopCmd = &cobra.Command{
 				ui.Cyan.Printf("    Total logged: %s\n", ui.FormatHours(newActual))
 			}
 		}
+		return nil
 	},
 }
 
 var trackStatusCmd = &cobra.Command{
 	Use:   "status",
 	Short: "Show current tracking status",
-	Run: func(cmd *cobra.Command, args []string) {
+	Long: `Show the active tracking session.
+
+With --porcelain, prints one record (project, module, task ID, start time,
+elapsed hours, title) and exits 1 when nothing is being tracked:
+
+  if qix --porcelain track status >/dev/null; then echo busy; fi`,
+	RunE: func(cmd *cobra.Command, args []string) error {
 		store := storage.Get()
 
 		tracking, err := store.IsTracking()
 		if err != nil {
-			ui.PrintError("Failed to check tracking status: %v", err)
-			return
+			return fail("Failed to check tracking status: %v", err)
 		}
 
 		if !tracking {
+			setPorcelainStatus(1)
+			if jsonOutput {
+				printJSON(trackingStatus{})
+				return nil
+			}
+			if porcelain {
+				return nil
+			}
 			ui.Blue.Println("🟢 No active tracking session")
 			fmt.Println()
 			ui.Dim.Println("Start tracking with: qix track start <project> <task_id>")
-			return
+			return nil
 		}
 
 		session, err := store.GetActiveSession()
 		if err != nil {
-			ui.PrintError("Failed to get session: %v", err)
-			return
+			return fail("Failed to get session: %v", err)
 		}
 
 		elapsed := time.Since(session.StartTime)
@@ -207,6 +209,36 @@ var trackStatusCmd = &cobra.Command{
 		projectName, moduleName := parsePath(session.Path)
 		task, _, err := store.FindTask(projectName, session.TaskID)
 
+		if jsonOutput {
+			status := trackingStatus{
+				Active:       true,
+				Project:      projectName,
+				Module:       moduleName,
+				TaskID:       session.TaskID,
+				StartedAt:    &session.StartTime,
+				ElapsedHours: elapsed.Hours(),
+			}
+			if task != nil {
+				status.Title = task.Title
+				status.Status = task.Status
+				status.Priority = task.Priority
+				status.EstimatedHours = task.EstimatedHours
+				status.LoggedHours = task.CalculateActualHours()
+			}
+			printJSON(status)
+			return nil
+		}
+
+		if porcelain {
+			title := ""
+			if task != nil {
+				title = task.Title
+			}
+			ui.PrintRecord(projectName, moduleName, session.TaskID,
+				session.StartTime.Format(time.RFC3339), ui.FormatRecordHours(elapsed.Hours()), title)
+			return nil
+		}
+
 		ui.PrintHeader("⏳ Active Tracking Session")
 
 		if task != nil {
@@ -257,6 +289,7 @@ var trackStatusCmd = &cobra.Command{
 
 		fmt.Println()
 		ui.Dim.Println("Stop tracking with: qix track stop")
+		return nil
 	},
 }
 
@@ -265,7 +298,7 @@ var trackLogCmd = &cobra.Command{
 	Short: "Manually log time to a task",
 	Long:  "Log time without starting/stopping a tracking session",
 	Args:  cobra.ExactArgs(3),
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		path := args[0]
 		taskID := args[1]
 		hoursStr := args[2]
@@ -273,13 +306,11 @@ var trackLogCmd = &cobra.Command{
 		// Parse hours
 		var hours float64
 		if _, err := fmt.Sscanf(hoursStr, "%f", &hours); err != nil {
-			ui.PrintError("Invalid hours format: %s", hoursStr)
-			return
+			return invalid("Invalid hours format: %s", hoursStr)
 		}
 
 		if hours <= 0 {
-			ui.PrintError("Hours must be positive")
-			return
+			return invalid("Hours must be positive")
 		}
 
 		projectName, _ := parsePath(path)
@@ -289,8 +320,7 @@ var trackLogCmd = &cobra.Command{
 		// Verify task exists
 		task, _, err := store.FindTask(projectName, taskID)
 		if err != nil {
-			ui.PrintError("Task not found: %v", err)
-			return
+			return notFound("Task not found: %v", err)
 		}
 
 		// Get date flag
@@ -298,11 +328,11 @@ var trackLogCmd = &cobra.Command{
 		if dateStr == "" {
 			dateStr = time.Now().Format("2006-01-02")
 		} else {
-			// Validate date format
-			if _, err := time.Parse("2006-01-02", dateStr); err != nil {
-				ui.PrintError("Invalid date format. Use: YYYY-MM-DD")
-				return
+			normalized, err := ui.NormalizeDate(dateStr)
+			if err != nil {
+				return invalid("Invalid date format. Use: %s", ui.DateFormats())
 			}
+			dateStr = normalized
 		}
 
 		// Log time
@@ -312,8 +342,7 @@ var trackLogCmd = &cobra.Command{
 		}
 
 		if err := store.AddTimeEntry(projectName, taskID, entry); err != nil {
-			ui.PrintError("Failed to log time: %v", err)
-			return
+			return fail("Failed to log time: %v", err)
 		}
 
 		ui.PrintSuccess("Time logged")
@@ -339,6 +368,7 @@ var trackLogCmd = &cobra.Command{
 		} else {
 			ui.Cyan.Printf("    Total: %s\n", ui.FormatHours(newActual))
 		}
+		return nil
 	},
 }
 
@@ -346,7 +376,7 @@ var trackSwitchCmd = &cobra.Command{
 	Use:   "switch <project[/module]> <task_id>",
 	Short: "Stop current tracking and start tracking a different task",
 	Args:  cobra.ExactArgs(2),
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		path := args[0]
 		taskID := args[1]
 
@@ -357,8 +387,7 @@ var trackSwitchCmd = &cobra.Command{
 		// Check if currently tracking
 		tracking, err := store.IsTracking()
 		if err != nil {
-			ui.PrintError("Failed to check tracking status: %v", err)
-			return
+			return fail("Failed to check tracking status: %v", err)
 		}
 
 		if tracking {
@@ -368,8 +397,7 @@ var trackSwitchCmd = &cobra.Command{
 			// Stop current
 			elapsed, oldPath, oldTaskID, err := store.StopTracking()
 			if err != nil {
-				ui.PrintError("Failed to stop current session: %v", err)
-				return
+				return fail("Failed to stop current session: %v", err)
 			}
 
 			ui.PrintSuccess("⏹️  Stopped: [%s] %s", oldTaskID, oldPath)
@@ -381,14 +409,12 @@ var trackSwitchCmd = &cobra.Command{
 		// Verify new task exists
 		task, _, err := store.FindTask(projectName, taskID)
 		if err != nil {
-			ui.PrintError("Task not found: %v", err)
-			return
+			return notFound("Task not found: %v", err)
 		}
 
 		// Start new session
 		if err := store.StartTracking(projectName, moduleName, taskID); err != nil {
-			ui.PrintError("Failed to start tracking: %v", err)
-			return
+			return fail("Failed to start tracking: %v", err)
 		}
 
 		ui.PrintSuccess("▶️  Started: [%s] %s", taskID, task.Title)
@@ -400,6 +426,7 @@ var trackSwitchCmd = &cobra.Command{
 		}
 
 		ui.Dim.Printf("  Time: %s\n", time.Now().Format("15:04:05"))
+		return nil
 	},
 }
 
@@ -408,65 +435,67 @@ var trackListCmd = &cobra.Command{
 	Short: "List time entries for a date",
 	Long:  "Show all time entries for a specific date (defaults to today)",
 	Args:  cobra.RangeArgs(1, 2),
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		projectName := args[0]
 
 		dateStr := time.Now().Format("2006-01-02")
 		if len(args) > 1 {
-			dateStr = args[1]
-			// Validate date
-			if _, err := time.Parse("2006-01-02", dateStr); err != nil {
-				ui.PrintError("Invalid date format. Use: YYYY-MM-DD")
-				return
+			normalized, err := ui.NormalizeDate(args[1])
+			if err != nil {
+				return invalid("Invalid date format. Use: %s", ui.DateFormats())
 			}
+			dateStr = normalized
 		}
 
 		store := storage.Get()
 
 		project, err := store.LoadProject(projectName)
 		if err != nil {
-			ui.PrintError("Project not found: %s", projectName)
-			return
+			return notFound("Project not found: %s", projectName)
 		}
 
-		ui.PrintHeader(fmt.Sprintf("⏱️  Time Entries: %s", ui.FormatDate(dateStr)))
-		ui.Dim.Printf("Project: %s\n\n", projectName)
-
-		totalHours := 0.0
-		entryCount := 0
-
-		// Check all tasks
+		day := timeOnDate{Project: projectName, Date: dateStr, Tasks: make([]loggedTask, 0)}
 		for _, task := range project.GetAllTasks() {
-			taskHours := 0.0
-
+			hours := 0.0
 			for _, entry := range task.TimeEntries {
 				if entry.Date == dateStr {
-					taskHours += entry.Hours
-					entryCount++
+					hours += entry.Hours
+					day.Entries++
 				}
 			}
+			if hours > 0 {
+				day.Tasks = append(day.Tasks, loggedTask{taskRef: newTaskRef(task), Hours: hours})
+				day.TotalHours += hours
+			}
+		}
 
-			if taskHours > 0 {
-				totalHours += taskHours
+		if jsonOutput {
+			printJSON(day)
+			return nil
+		}
 
-				statusColor := ui.GetStatusColor(task.Status)
-				statusColor.Printf("  %s [%s] %s\n",
-					ui.GetStatusIcon(task.Status), task.ID, task.Title)
-				ui.Cyan.Printf("    └─ %s\n", ui.FormatHours(taskHours))
-			}
+		ui.PrintHeader(fmt.Sprintf("⏱️  Time Entries: %s", ui.FormatDate(dateStr)))
+		ui.Dim.Printf("Project: %s\n\n", projectName)
+
+		for _, task := range day.Tasks {
+			statusColor := ui.GetStatusColor(task.Status)
+			statusColor.Printf("  %s [%s] %s\n",
+				ui.GetStatusIcon(task.Status), task.ID, task.Title)
+			ui.Cyan.Printf("    └─ %s\n", ui.FormatHours(task.Hours))
 		}
 
-		if entryCount == 0 {
+		if day.Entries == 0 {
 			ui.PrintEmptyState(
 				fmt.Sprintf("No time entries on %s", dateStr),
 				"Log time with: qix track log <project> <task_id> <hours>",
 			)
-			return
+			return nil
 		}
 
 		fmt.Println()
 		ui.PrintSeparator()
-		ui.BoldGreen.Printf("Total: %s (%d entries)\n", ui.FormatHours(totalHours), entryCount)
+		ui.BoldGreen.Printf("Total: %s (%d entries)\n", ui.FormatHours(day.TotalHours), day.Entries)
+		return nil
 	},
 }
 
@@ -475,14 +504,13 @@ var trackSummaryCmd = &cobra.Command{
 	Short: "Show time tracking summary",
 	Long:  "Show time tracking summary for the last N days (default: 7)",
 	Args:  cobra.RangeArgs(1, 2),
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		projectName := args[0]
 
 		days := 7
 		if len(args) > 1 {
 			if _, err := fmt.Sscanf(args[1], "%d", &days); err != nil || days <= 0 {
-				ui.PrintError("Invalid days: %s", args[1])
-				return
+				return invalid("Invalid days: %s", args[1])
 			}
 		}
 
@@ -490,12 +518,9 @@ var trackSummaryCmd = &cobra.Command{
 
 		project, err := store.LoadProject(projectName)
 		if err != nil {
-			ui.PrintError("Project not found: %s", projectName)
-			return
+			return notFound("Project not found: %s", projectName)
 		}
 
-		ui.PrintHeader(fmt.Sprintf("⏱️  Time Summary: %s (Last %d days)", projectName, days))
-
 		// Calculate date range
 		endDate := time.Now()
 		startDate := endDate.AddDate(0, 0, -days+1)
@@ -516,34 +541,41 @@ var trackSummaryCmd = &cobra.Command{
 			}
 		}
 
+		summary := timeSummary{Project: projectName, Days: days, Daily: make([]dateHours, 0, days)}
+		maxHours := 0.0
+		for current := startDate; current.Before(endDate.AddDate(0, 0, 1)); current = current.AddDate(0, 0, 1) {
+			dateStr := current.Format("2006-01-02")
+			hours := dailyTotals[dateStr]
+			summary.Daily = append(summary.Daily, dateHours{Date: dateStr, Hours: hours})
+			summary.TotalHours += hours
+			if hours > maxHours {
+				maxHours = hours
+			}
+		}
+		summary.AverageHours = summary.TotalHours / float64(days)
+
+		if jsonOutput {
+			printJSON(summary)
+			return nil
+		}
+
+		ui.PrintHeader(fmt.Sprintf("⏱️  Time Summary: %s (Last %d days)", projectName, days))
+
 		if len(dailyTotals) == 0 {
 			ui.PrintEmptyState("No time entries in this period", "")
-			return
+			return nil
 		}
 
 		// Create table
 		table := ui.NewTableBuilder("Date", "Hours", "Bar").
 			Align(1, ui.AlignRight)
 
-		grandTotal := 0.0
-		maxHours := 0.0
-
-		// Find max for scaling
-		for _, hours := range dailyTotals {
-			if hours > maxHours {
-				maxHours = hours
-			}
-			grandTotal += hours
-		}
-
 		// Add rows for each day
-		current := startDate
-		for current.Before(endDate.AddDate(0, 0, 1)) {
-			dateStr := current.Format("2006-01-02")
-			hours := dailyTotals[dateStr]
+		for _, day := range summary.Daily {
+			dateStr, hours := day.Date, day.Hours
 
 			// Create bar
-			barWidth := 20
+			barWidth := ui.ScaleWidth(20)
 			filled := 0
 			if maxHours > 0 {
 				filled = int((hours / maxHours) * float64(barWidth))
@@ -563,32 +595,76 @@ var trackSummaryCmd = &cobra.Command{
 				ui.FormatHours(hours),
 				bar,
 			)
-
-			current = current.AddDate(0, 0, 1)
 		}
 
 		table.PrintSimple()
 
 		fmt.Println()
-		ui.BoldGreen.Printf("Total: %s\n", ui.FormatHours(grandTotal))
-
-		if days > 0 {
-			avgPerDay := grandTotal / float64(days)
-			ui.Cyan.Printf("Average: %s/day\n", ui.FormatHours(avgPerDay))
-		}
+		ui.BoldGreen.Printf("Total: %s\n", ui.FormatHours(summary.TotalHours))
+		ui.Cyan.Printf("Average: %s/day\n", ui.FormatHours(summary.AverageHours))
+		return nil
 	},
 }
 
+// trackingStatus describes the active tracking session, if any
+type trackingStatus struct {
+	Active         bool              `json:"active"`
+	Project        string            `json:"project,omitempty"`
+	Module         string            `json:"module,omitempty"`
+	TaskID         string            `json:"task_id,omitempty"`
+	Title          string            `json:"title,omitempty"`
+	Status         models.TaskStatus `json:"status,omitempty"`
+	Priority       models.Priority   `json:"priority,omitempty"`
+	StartedAt      *time.Time        `json:"started_at,omitempty"`
+	ElapsedHours   float64           `json:"elapsed_hours,omitempty"`
+	EstimatedHours float64           `json:"estimated_hours,omitempty"`
+	LoggedHours    float64           `json:"logged_hours,omitempty"` // Before this session
+}
+
+// timeOnDate is the time logged to a project's tasks on one date
+type timeOnDate struct {
+	Project    string       `json:"project"`
+	Date       string       `json:"date"`
+	Tasks      []loggedTask `json:"tasks"`
+	Entries    int          `json:"entries"`
+	TotalHours float64      `json:"total_hours"`
+}
+
+// loggedTask is a task with the time logged to it on a date
+type loggedTask struct {
+	taskRef
+	Hours float64 `json:"hours"`
+}
+
+// timeSummary is the time logged to a project per day over a number of days
+type timeSummary struct {
+	Project      string      `json:"project"`
+	Days         int         `json:"days"`
+	Daily        []dateHours `json:"daily"`
+	TotalHours   float64     `json:"total_hours"`
+	AverageHours float64     `json:"average_hours"`
+}
+
+// dateHours is the time logged on one date
+type dateHours struct {
+	Date  string  `json:"date"`
+	Hours float64 `json:"hours"`
+}
+
 func init() {
 	// track log flags
 	trackLogCmd.Flags().StringP("date", "d", "", "Date for time entry (YYYY-MM-DD, defaults to today)")
 
 	trackStartCmd.ValidArgsFunction = trackPathTaskArgCompletion
 	trackLogCmd.ValidArgsFunction = trackPathTaskArgCompletion
+	trackLogCmd.RegisterFlagCompletionFunc("date", completeDates)
 	trackSwitchCmd.ValidArgsFunction = trackPathTaskArgCompletion
-	trackListCmd.ValidArgsFunction = projectArgCompletion
+	trackListCmd.ValidArgsFunction = dateArgCompletion(1, projectArgCompletion)
 	trackSummaryCmd.ValidArgsFunction = projectArgCompletion
 
+	noJSON(trackStartCmd, trackStopCmd, trackLogCmd, trackSwitchCmd)
+	supportDryRun(trackStartCmd, trackStopCmd, trackLogCmd, trackSwitchCmd)
+
 	// Add subcommands
 	trackCmd.AddCommand(trackStartCmd)
 	trackCmd.AddCommand(trackStopCmd)
@@ -597,4 +673,6 @@ func init() {
 	trackCmd.AddCommand(trackSwitchCmd)
 	trackCmd.AddCommand(trackListCmd)
 	trackCmd.AddCommand(trackSummaryCmd)
+
+	usePager(true, trackListCmd, trackSummaryCmd)
 }
@@ -0,0 +1,163 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/mrbooshehri/qix-go/internal/config"
+	"github.com/mrbooshehri/qix-go/internal/storage"
+	"github.com/mrbooshehri/qix-go/internal/ui"
+	"github.com/mrbooshehri/qix-go/pkg/qix/models"
+	"github.com/spf13/cobra"
+)
+
+var trashCmd = &cobra.Command{
+	Use:   "trash",
+	Short: "List, restore and purge deleted items",
+	Long: `Deleted tasks, modules and projects are kept in the trash so they can be
+restored. Items older than trash_retention_days (default 30) are purged
+automatically; set it to 0 to keep them until 'qix trash purge --all'.`,
+}
+
+var trashListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List deleted items",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		store := storage.Get()
+
+		items, err := store.ListTrash()
+		if err != nil {
+			return fail("Failed to read trash: %v", err)
+		}
+
+		retention := config.Get().TrashRetentionDays
+
+		if jsonOutput {
+			type trashJSON struct {
+				ID        string           `json:"id"`
+				Kind      models.TrashKind `json:"kind"`
+				Item      string           `json:"item"`
+				Project   string           `json:"project"`
+				Module    string           `json:"module,omitempty"`
+				DeletedAt time.Time        `json:"deleted_at"`
+				ExpiresAt *time.Time       `json:"expires_at,omitempty"`
+			}
+			out := make([]trashJSON, 0, len(items))
+			for _, item := range items {
+				view := trashJSON{ID: item.ID, Kind: item.Kind, Item: item.Label(), Project: item.Project, Module: item.Module, DeletedAt: item.DeletedAt}
+				if retention > 0 {
+					expires := item.DeletedAt.AddDate(0, 0, retention)
+					view.ExpiresAt = &expires
+				}
+				out = append(out, view)
+			}
+			printJSON(out)
+			return nil
+		}
+
+		ui.PrintHeader("🗑  Trash")
+
+		if len(items) == 0 {
+			ui.PrintEmptyState("Trash is empty", "Deleted tasks, modules and projects appear here")
+			return nil
+		}
+
+		table := ui.NewTableBuilder("ID", "Kind", "Item", "From", "Deleted", "Expires")
+		for _, item := range items {
+			from := item.Project
+			if item.Kind == models.TrashTask && item.Module != "" {
+				from += "/" + item.Module
+			}
+
+			expires := "never"
+			if retention > 0 {
+				expires = item.DeletedAt.AddDate(0, 0, retention).Format("2006-01-02")
+			}
+
+			table.Row(
+				item.ID,
+				string(item.Kind),
+				item.Label(),
+				from,
+				ui.FormatDateTime(item.DeletedAt.Local()),
+				expires,
+			)
+		}
+		table.PrintSimple()
+
+		fmt.Println()
+		ui.Dim.Println("Restore an item with 'qix trash restore <id>'")
+		return nil
+	},
+}
+
+var trashRestoreCmd = &cobra.Command{
+	Use:   "restore <id>",
+	Short: "Restore a deleted item",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		store := storage.Get()
+
+		item, err := store.RestoreTrash(args[0])
+		if err != nil {
+			return fail("Failed to restore: %v", err)
+		}
+
+		switch item.Kind {
+		case models.TrashTask:
+			where := item.Project
+			if item.Module != "" {
+				where += "/" + item.Module
+			}
+			ui.PrintSuccess("Task restored to %s: [%s] %s", where, item.Task.ID, item.Task.Title)
+		case models.TrashModule:
+			ui.PrintSuccess("Module '%s' restored to project '%s'", item.ModuleData.Name, item.Project)
+		case models.TrashProject:
+			ui.PrintSuccess("Project '%s' restored", item.Project)
+		}
+		return nil
+	},
+}
+
+var trashPurgeCmd = &cobra.Command{
+	Use:   "purge",
+	Short: "Permanently delete expired items",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		all, _ := cmd.Flags().GetBool("all")
+		force := skipConfirm(cmd)
+
+		if all && !force {
+			fmt.Println("⚠️  Permanently delete everything in the trash?")
+			if !ui.ConfirmTyped("yes") {
+				ui.PrintInfo("Purge cancelled")
+				return nil
+			}
+		}
+
+		store := storage.Get()
+
+		count, err := store.PurgeTrash(all)
+		if err != nil {
+			return fail("Failed to purge trash: %v", err)
+		}
+
+		ui.PrintSuccess("Purged %d item(s) from trash", count)
+		return nil
+	},
+}
+
+func init() {
+	trashPurgeCmd.Flags().Bool("all", false, "Delete all items, not just expired ones")
+	trashPurgeCmd.Flags().BoolP("force", "f", false, "Skip confirmation")
+
+	noJSON(trashRestoreCmd, trashPurgeCmd)
+	trashCmd.AddCommand(trashListCmd)
+	trashCmd.AddCommand(trashRestoreCmd)
+	trashCmd.AddCommand(trashPurgeCmd)
+	rootCmd.AddCommand(trashCmd)
+
+	usePager(true, trashListCmd)
+	supportDryRun(trashRestoreCmd, trashPurgeCmd)
+}
@@ -0,0 +1,178 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/mrbooshehri/qix-go/internal/storage"
+	"github.com/mrbooshehri/qix-go/internal/ui"
+	"github.com/mrbooshehri/qix-go/pkg/qix/models"
+	"github.com/spf13/cobra"
+)
+
+var treeCmd = &cobra.Command{
+	Use:   "tree <project[/module]>",
+	Short: "Show a project as a tree of modules, tasks and subtasks",
+	Long: `Show a project's modules, tasks and subtasks as a tree.
+
+Subtasks are shown under their parent task, wherever it is in the project.
+Every node with tasks under it shows how many of them are done.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		projectName, moduleName := parsePath(args[0])
+		hideDone, _ := cmd.Flags().GetBool("hide-done")
+
+		project, err := storage.Get().LoadProject(projectName)
+		if err != nil {
+			return notFound("Project not found: %s", projectName)
+		}
+
+		root, err := buildProjectTree(project, moduleName, hideDone)
+		if err != nil {
+			return fail("%v", err)
+		}
+
+		if jsonOutput {
+			printJSON(root)
+			return nil
+		}
+
+		fmt.Println(root.label())
+		ui.PrintTree(root.treeNodes(), "", true)
+		fmt.Println()
+		return nil
+	},
+}
+
+// treeNodeView is a project, module or task in the tree, with the number of
+// tasks under it and how many of them are done
+type treeNodeView struct {
+	Kind     string            `json:"kind"`
+	Name     string            `json:"name"`
+	ID       string            `json:"id,omitempty"`
+	Status   models.TaskStatus `json:"status,omitempty"`
+	Done     int               `json:"done"`
+	Total    int               `json:"total"`
+	Children []treeNodeView    `json:"children"`
+}
+
+// buildProjectTree arranges the tasks of a project, or of one of its modules,
+// under their modules and parent tasks
+func buildProjectTree(project *models.Project, moduleName string, hideDone bool) (treeNodeView, error) {
+	graph := buildTaskGraph(project)
+
+	// Subtasks are shown under their parent, which for a module must be in it too
+	inScope := func(parentID, childID string) bool {
+		return moduleName == "" || graph.Section[parentID] == graph.Section[childID]
+	}
+	isChild := make(map[string]bool)
+	for parentID, children := range graph.Children {
+		for _, id := range children {
+			if inScope(parentID, id) {
+				isChild[id] = true
+			}
+		}
+	}
+
+	// count adds a task node and the tasks under it to the progress of parent
+	count := func(parent *treeNodeView, node treeNodeView) {
+		parent.Done += node.Done
+		parent.Total += node.Total + 1
+		if node.Status.IsDone() {
+			parent.Done++
+		}
+	}
+
+	visited := make(map[string]bool)
+	var taskNode func(id string) (treeNodeView, bool)
+	taskNode = func(id string) (treeNodeView, bool) {
+		task := graph.Tasks[id]
+		node := treeNodeView{Kind: "task", Name: task.Title, ID: task.ID, Status: task.Status, Children: []treeNodeView{}}
+		visited[id] = true
+		for _, childID := range graph.Children[id] {
+			if visited[childID] || !inScope(id, childID) {
+				continue
+			}
+			child, shown := taskNode(childID)
+			count(&node, child)
+			if shown {
+				node.Children = append(node.Children, child)
+			}
+		}
+		return node, !hideDone || !task.Status.IsDone() || len(node.Children) > 0
+	}
+
+	// rootTasks adds the top level tasks of a section to parent
+	rootTasks := func(parent *treeNodeView, section string) {
+		for _, id := range graph.Order {
+			if graph.Section[id] != section || isChild[id] {
+				continue
+			}
+			node, shown := taskNode(id)
+			count(parent, node)
+			if shown {
+				parent.Children = append(parent.Children, node)
+			}
+		}
+	}
+
+	moduleNode := func(module models.Module) treeNodeView {
+		node := treeNodeView{Kind: "module", Name: module.Name, Children: []treeNodeView{}}
+		rootTasks(&node, module.Name)
+		return node
+	}
+
+	if moduleName != "" {
+		for _, module := range project.Modules {
+			if module.Name == moduleName {
+				return moduleNode(module), nil
+			}
+		}
+		return treeNodeView{}, notFound("module not found: %s/%s", project.Name, moduleName)
+	}
+
+	root := treeNodeView{Kind: "project", Name: project.Name, Children: []treeNodeView{}}
+	for _, module := range project.Modules {
+		node := moduleNode(module)
+		root.Children = append(root.Children, node)
+		root.Done += node.Done
+		root.Total += node.Total
+	}
+	rootTasks(&root, "Project")
+	return root, nil
+}
+
+// label describes the node on one line, with its progress if it has tasks under it
+func (n treeNodeView) label() string {
+	var label string
+	switch n.Kind {
+	case "project":
+		label = ui.BoldCyan.Sprint("📁 " + n.Name)
+	case "module":
+		label = ui.BoldBlue.Sprint("📦 " + n.Name)
+	default:
+		color := ui.GetStatusColor(n.Status)
+		label = fmt.Sprintf("%s %s %s", color.Sprint(ui.GetStatusIcon(n.Status)), ui.Dim.Sprintf("[%s]", n.ID), n.Name)
+	}
+
+	if n.Total > 0 {
+		label += ui.Dim.Sprintf("  %d/%d done (%s)", n.Done, n.Total,
+			ui.FormatPercentage(float64(n.Done)/float64(n.Total)*100))
+	}
+	return label
+}
+
+// treeNodes converts the node's children for ui.PrintTree
+func (n treeNodeView) treeNodes() []ui.TreeNode {
+	nodes := make([]ui.TreeNode, 0, len(n.Children))
+	for _, child := range n.Children {
+		nodes = append(nodes, ui.TreeNode{Label: child.label(), Children: child.treeNodes()})
+	}
+	return nodes
+}
+
+func init() {
+	treeCmd.Flags().Bool("hide-done", false, "Hide done tasks with no open subtasks")
+	treeCmd.ValidArgsFunction = taskPathCompletion
+	rootCmd.AddCommand(treeCmd)
+	usePager(true, treeCmd)
+}
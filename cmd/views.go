@@ -0,0 +1,201 @@
+package cmd
+
+import (
+	"time"
+
+	"github.com/mrbooshehri/qix-go/internal/ui"
+	"github.com/mrbooshehri/qix-go/pkg/qix/models"
+)
+
+// Views are the data a command shows, assembled separately from how it is
+// rendered: the terminal output prints them, and --json prints them as JSON.
+
+// taskView is a task with its location and computed fields
+type taskView struct {
+	models.Task
+	Project     string  `json:"project"`
+	Module      string  `json:"module,omitempty"`
+	ActualHours float64 `json:"actual_hours"`
+	Overdue     bool    `json:"overdue"`
+}
+
+// newTaskView assembles the view of a task in a project ("" module for project level)
+func newTaskView(projectName, moduleName string, task models.Task) taskView {
+	if task.Tags == nil {
+		task.Tags = []string{}
+	}
+	if task.Dependencies == nil {
+		task.Dependencies = []string{}
+	}
+	if task.TimeEntries == nil {
+		task.TimeEntries = []models.TimeEntry{}
+	}
+	return taskView{
+		Task:        task,
+		Project:     projectName,
+		Module:      moduleName,
+		ActualHours: task.CalculateActualHours(),
+		Overdue:     task.IsOverdue(time.Now().Format("2006-01-02")),
+	}
+}
+
+// taskRef identifies a related task
+type taskRef struct {
+	ID     string            `json:"id"`
+	Title  string            `json:"title"`
+	Status models.TaskStatus `json:"status"`
+}
+
+func newTaskRef(task models.Task) taskRef {
+	return taskRef{ID: task.ID, Title: task.Title, Status: task.Status}
+}
+
+// taskModules maps each task ID in a project to its module ("" for project level)
+func taskModules(project *models.Project) map[string]string {
+	modules := make(map[string]string)
+	for _, module := range project.Modules {
+		for _, task := range module.Tasks {
+			modules[task.ID] = module.Name
+		}
+	}
+	return modules
+}
+
+// projectSummary is the overview of a project shown in project listings
+type projectSummary struct {
+	Name           string                    `json:"name"`
+	Description    string                    `json:"description"`
+	Tags           []string                  `json:"tags"`
+	Deadline       string                    `json:"deadline,omitempty"`
+	Budget         float64                   `json:"budget,omitempty"`
+	Modules        int                       `json:"modules"`
+	Tasks          int                       `json:"tasks"`
+	Sprints        int                       `json:"sprints"`
+	StatusCounts   map[models.TaskStatus]int `json:"status_counts"`
+	EstimatedHours float64                   `json:"estimated_hours"`
+	ActualHours    float64                   `json:"actual_hours"`
+	Completion     float64                   `json:"completion"`
+}
+
+func newProjectSummary(project *models.Project) projectSummary {
+	counts := project.CountByStatus()
+	for _, def := range models.Statuses() {
+		counts[def.Name] += 0
+	}
+	tags := project.Tags
+	if tags == nil {
+		tags = []string{}
+	}
+
+	return projectSummary{
+		Name:           project.Name,
+		Description:    project.Description,
+		Tags:           tags,
+		Deadline:       project.Deadline,
+		Budget:         project.Budget,
+		Modules:        len(project.Modules),
+		Tasks:          len(project.GetAllTasks()),
+		Sprints:        len(project.Sprints),
+		StatusCounts:   counts,
+		EstimatedHours: project.CalculateTotalEstimated(),
+		ActualHours:    project.CalculateTotalActual(),
+		Completion:     project.GetCompletionPercentage(),
+	}
+}
+
+// moduleSummary is the overview of a module
+type moduleSummary struct {
+	Name           string   `json:"name"`
+	Description    string   `json:"description"`
+	Tags           []string `json:"tags"`
+	Tasks          int      `json:"tasks"`
+	Done           int      `json:"done"`
+	Completion     float64  `json:"completion"`
+	EstimatedHours float64  `json:"estimated_hours"`
+	ActualHours    float64  `json:"actual_hours"`
+}
+
+func newModuleSummary(module models.Module) moduleSummary {
+	summary := moduleSummary{
+		Name:        module.Name,
+		Description: module.Description,
+		Tags:        module.Tags,
+		Tasks:       len(module.Tasks),
+	}
+	if summary.Tags == nil {
+		summary.Tags = []string{}
+	}
+	for _, task := range module.Tasks {
+		if task.Status.IsDone() {
+			summary.Done++
+		}
+		summary.EstimatedHours += task.EstimatedHours
+		summary.ActualHours += task.CalculateActualHours()
+	}
+	if summary.Tasks > 0 {
+		summary.Completion = float64(summary.Done) / float64(summary.Tasks) * 100
+	}
+	return summary
+}
+
+// moduleDetails is everything 'module show' displays
+type moduleDetails struct {
+	moduleSummary
+	Project string     `json:"project"`
+	Tasks   []taskView `json:"tasks"`
+}
+
+func newModuleDetails(projectName string, module models.Module) moduleDetails {
+	details := moduleDetails{
+		moduleSummary: newModuleSummary(module),
+		Project:       projectName,
+		Tasks:         make([]taskView, 0, len(module.Tasks)),
+	}
+	for _, task := range module.Tasks {
+		details.Tasks = append(details.Tasks, newTaskView(projectName, module.Name, task))
+	}
+	return details
+}
+
+// projectDetails is everything 'project show' displays. Its lists replace the
+// summary's counts of the same name in JSON.
+type projectDetails struct {
+	projectSummary
+	Modules []moduleSummary `json:"modules"`
+	Tasks   []taskView      `json:"tasks"` // Project-level tasks
+	Sprints []models.Sprint `json:"sprints"`
+}
+
+func newProjectDetails(project *models.Project) projectDetails {
+	details := projectDetails{
+		projectSummary: newProjectSummary(project),
+		Modules:        make([]moduleSummary, 0, len(project.Modules)),
+		Tasks:          make([]taskView, 0, len(project.Tasks)),
+		Sprints:        project.Sprints,
+	}
+	for _, module := range project.Modules {
+		details.Modules = append(details.Modules, newModuleSummary(module))
+	}
+	for _, task := range project.Tasks {
+		details.Tasks = append(details.Tasks, newTaskView(project.Name, "", task))
+	}
+	if details.Sprints == nil {
+		details.Sprints = []models.Sprint{}
+	}
+	return details
+}
+
+// taskRecord is a task's fields for --porcelain: ID, status, priority, estimated
+// and actual hours, due date, project, module and title
+func taskRecord(v taskView) []string {
+	return []string{v.ID, string(v.Status), string(v.Priority),
+		ui.FormatRecordHours(v.EstimatedHours), ui.FormatRecordHours(v.ActualHours),
+		v.DueDate, v.Project, v.Module, v.Title}
+}
+
+// printJSON prints a command's result for --json
+func printJSON(v interface{}) {
+	if err := ui.PrintJSON(v); err != nil {
+		ui.PrintError("Failed to encode JSON: %v", err)
+	}
+}
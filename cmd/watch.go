@@ -0,0 +1,75 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/mrbooshehri/qix-go/internal/storage"
+	"github.com/mrbooshehri/qix-go/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+// defaultWatchInterval is how often --watch re-runs a command without an interval
+const defaultWatchInterval = "2s"
+
+// minWatchInterval keeps --watch from re-reading the data directory nonstop
+const minWatchInterval = 500 * time.Millisecond
+
+// watchable adds --watch to commands, which then re-run on an interval as a
+// live view until interrupted
+func watchable(cmds ...*cobra.Command) {
+	for _, cmd := range cmds {
+		cmd.Flags().String("watch", "", "Re-run every interval until Ctrl+C, e.g. --watch or --watch=30s (default "+defaultWatchInterval+")")
+		cmd.Flags().Lookup("watch").NoOptDefVal = defaultWatchInterval
+
+		run := cmd.RunE
+		cmd.RunE = func(cmd *cobra.Command, args []string) error {
+			if !cmd.Flags().Changed("watch") {
+				return run(cmd, args)
+			}
+
+			spec, _ := cmd.Flags().GetString("watch")
+			interval, err := parseWatchInterval(spec)
+			if err != nil {
+				return invalid("%v", err)
+			}
+
+			// Changes made elsewhere only show if projects are read again each
+			// time. A failed run is shown like the output and tried again.
+			err = ui.Watch(interval, func() {
+				storage.Get().ClearCache()
+				if err := run(cmd, args); err != nil {
+					printCommandError(err)
+				}
+			})
+			if err != nil {
+				return fail("%v", err)
+			}
+			return nil
+		}
+	}
+}
+
+// watching reports whether a command was asked to re-run with --watch
+func watching(cmd *cobra.Command) bool {
+	flag := cmd.Flags().Lookup("watch")
+	return flag != nil && flag.Changed
+}
+
+// parseWatchInterval reads a --watch interval: a duration such as 30s or 1m,
+// or a number of seconds
+func parseWatchInterval(spec string) (time.Duration, error) {
+	interval, err := time.ParseDuration(spec)
+	if err != nil {
+		seconds, convErr := strconv.ParseFloat(spec, 64)
+		if convErr != nil {
+			return 0, fmt.Errorf("invalid watch interval: %s", spec)
+		}
+		interval = time.Duration(seconds * float64(time.Second))
+	}
+	if interval < minWatchInterval {
+		return 0, fmt.Errorf("watch interval must be at least %s", minWatchInterval)
+	}
+	return interval, nil
+}
@@ -0,0 +1,351 @@
+// Package calsync writes events to remote calendars, over CalDAV or with the
+// Google Calendar API.
+package calsync
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/mrbooshehri/qix-go/internal/ics"
+)
+
+// ErrUnauthorized is returned when a calendar rejects the credentials
+var ErrUnauthorized = errors.New("the calendar rejected the credentials")
+
+// Calendar is a remote calendar events are written to
+type Calendar interface {
+	// Name identifies the calendar, such as its URL
+	Name() string
+
+	// Put creates an event, or replaces the one an earlier Put returned ref
+	// for, and returns the event's new ref
+	Put(ctx context.Context, ref string, event ics.Event) (string, error)
+
+	// Delete removes the event at ref. Events already gone are not an error.
+	Delete(ctx context.Context, ref string) error
+}
+
+// Hash returns a fingerprint of an event's content, to tell whether it
+// changed since it was last written
+func Hash(event ics.Event) string {
+	event.Stamp = time.Time{}
+	data, _ := json.Marshal(event)
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:16])
+}
+
+// CalDAV is a calendar collection on a CalDAV server, such as Nextcloud,
+// Radicale, Fastmail or iCloud. Each event is a resource in the collection.
+type CalDAV struct {
+	collection *url.URL
+	user       string
+	password   string
+	client     *http.Client
+}
+
+// NewCalDAV returns the calendar collection at rawURL
+func NewCalDAV(rawURL, user, password string) (*CalDAV, error) {
+	rawURL = strings.TrimSpace(rawURL)
+	if rawURL == "" {
+		return nil, errors.New("no CalDAV URL configured")
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Host == "" || (u.Scheme != "http" && u.Scheme != "https") {
+		return nil, fmt.Errorf("invalid CalDAV URL: %s", rawURL)
+	}
+	if !strings.HasSuffix(u.Path, "/") {
+		u.Path += "/"
+	}
+	return &CalDAV{
+		collection: u,
+		user:       user,
+		password:   password,
+		client:     &http.Client{Timeout: 30 * time.Second},
+	}, nil
+}
+
+// Name returns the collection URL
+func (c *CalDAV) Name() string {
+	return c.collection.String()
+}
+
+// Put writes an event to the resource at ref, or to a new one named after
+// its UID, and returns the resource URL
+func (c *CalDAV) Put(ctx context.Context, ref string, event ics.Event) (string, error) {
+	if ref == "" {
+		ref = c.collection.ResolveReference(&url.URL{Path: resourceName(event.UID)}).String()
+	}
+
+	// A stored calendar object holds one event and no METHOD
+	var body bytes.Buffer
+	calendar := ics.Calendar{Events: []ics.Event{event}}
+	calendar.WriteTo(&body)
+
+	resp, err := c.do(ctx, http.MethodPut, ref, "text/calendar; charset=utf-8", &body)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		return "", newAPIError("CalDAV server", resp)
+	}
+	return ref, nil
+}
+
+// Delete removes the resource at ref
+func (c *CalDAV) Delete(ctx context.Context, ref string) error {
+	resp, err := c.do(ctx, http.MethodDelete, ref, "", nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 && resp.StatusCode != http.StatusNotFound && resp.StatusCode != http.StatusGone {
+		return newAPIError("CalDAV server", resp)
+	}
+	return nil
+}
+
+func (c *CalDAV) do(ctx context.Context, method, target, contentType string, body io.Reader) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, target, body)
+	if err != nil {
+		return nil, err
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	if c.user != "" {
+		req.SetBasicAuth(c.user, c.password)
+	}
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden {
+		resp.Body.Close()
+		return nil, fmt.Errorf("%w (%s)", ErrUnauthorized, resp.Status)
+	}
+	return resp, nil
+}
+
+// resourceName returns the name of the resource holding an event: its UID,
+// kept to characters that are safe in any URL path
+func resourceName(uid string) string {
+	name := strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '-' || r == '_' || r == '.' {
+			return r
+		}
+		return '-'
+	}, uid)
+	return name + ".ics"
+}
+
+// GoogleAPIURL is the root of the Google Calendar API
+const GoogleAPIURL = "https://www.googleapis.com/calendar/v3"
+
+// Google is a Google calendar, written with the Calendar API. Refs are event IDs.
+type Google struct {
+	api        string
+	calendarID string
+	token      string
+	client     *http.Client
+}
+
+// NewGoogle returns the calendar with an ID, such as "primary", using an
+// OAuth access token with the calendar.events scope
+func NewGoogle(calendarID, token string) (*Google, error) {
+	calendarID = strings.TrimSpace(calendarID)
+	if calendarID == "" {
+		calendarID = "primary"
+	}
+	if strings.TrimSpace(token) == "" {
+		return nil, errors.New("no Google access token configured")
+	}
+	return &Google{
+		api:        GoogleAPIURL,
+		calendarID: calendarID,
+		token:      strings.TrimSpace(token),
+		client:     &http.Client{Timeout: 30 * time.Second},
+	}, nil
+}
+
+// Name returns the calendar ID
+func (g *Google) Name() string {
+	return "google:" + g.calendarID
+}
+
+// googleEvent is an event as the Calendar API reads and writes it
+type googleEvent struct {
+	ID                 string         `json:"id,omitempty"`
+	Status             string         `json:"status"`
+	Summary            string         `json:"summary"`
+	Description        string         `json:"description,omitempty"`
+	Start              googleTime     `json:"start"`
+	End                googleTime     `json:"end"`
+	Recurrence         []string       `json:"recurrence,omitempty"`
+	Transparency       string         `json:"transparency"`
+	ExtendedProperties googleExtended `json:"extendedProperties"`
+}
+
+type googleTime struct {
+	Date     string `json:"date,omitempty"`
+	DateTime string `json:"dateTime,omitempty"`
+}
+
+type googleExtended struct {
+	Private map[string]string `json:"private"`
+}
+
+func newGoogleEvent(event ics.Event) googleEvent {
+	g := googleEvent{
+		// Revives events deleted in the calendar since the last sync
+		Status:       "confirmed",
+		Summary:      event.Summary,
+		Description:  event.Description,
+		Transparency: "transparent",
+		ExtendedProperties: googleExtended{
+			Private: map[string]string{"qix_uid": event.UID},
+		},
+	}
+	if event.URL != "" {
+		g.Description = strings.TrimSpace(g.Description + "\n\n" + event.URL)
+	}
+	end := event.End
+	if end.Before(event.Start) {
+		end = event.Start
+	}
+	if event.Timed {
+		g.Start.DateTime = event.Start.Format(time.RFC3339)
+		g.End.DateTime = end.Format(time.RFC3339)
+		g.Transparency = "opaque"
+	} else {
+		// The end date of all-day events is exclusive
+		g.Start.Date = event.Start.Format("2006-01-02")
+		g.End.Date = end.AddDate(0, 0, 1).Format("2006-01-02")
+	}
+	if event.RRule != "" {
+		g.Recurrence = []string{"RRULE:" + event.RRule}
+	}
+	return g
+}
+
+// Put updates the event with ID ref, or creates one if ref is empty or the
+// event no longer exists, and returns its ID
+func (g *Google) Put(ctx context.Context, ref string, event ics.Event) (string, error) {
+	body := newGoogleEvent(event)
+	events := g.api + "/calendars/" + url.PathEscape(g.calendarID) + "/events"
+
+	var saved googleEvent
+	if ref != "" {
+		err := g.do(ctx, http.MethodPut, events+"/"+url.PathEscape(ref), body, &saved)
+		if err == nil {
+			return saved.ID, nil
+		}
+		var apiErr *APIError
+		if !errors.As(err, &apiErr) || (apiErr.Code != http.StatusNotFound && apiErr.Code != http.StatusGone) {
+			return "", err
+		}
+	}
+	if err := g.do(ctx, http.MethodPost, events, body, &saved); err != nil {
+		return "", err
+	}
+	return saved.ID, nil
+}
+
+// Delete removes the event with ID ref
+func (g *Google) Delete(ctx context.Context, ref string) error {
+	err := g.do(ctx, http.MethodDelete, g.api+"/calendars/"+url.PathEscape(g.calendarID)+"/events/"+url.PathEscape(ref), nil, nil)
+	var apiErr *APIError
+	if errors.As(err, &apiErr) && (apiErr.Code == http.StatusNotFound || apiErr.Code == http.StatusGone) {
+		return nil
+	}
+	return err
+}
+
+// do sends a request to the API, encoding body and decoding the response into out as JSON
+func (g *Google) do(ctx context.Context, method, target string, body, out interface{}) error {
+	var payload io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		payload = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, target, payload)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	req.Header.Set("Authorization", "Bearer "+g.token)
+
+	resp, err := g.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden:
+		return fmt.Errorf("%w (%s)", ErrUnauthorized, resp.Status)
+	case resp.StatusCode/100 != 2:
+		return newAPIError("Google Calendar", resp)
+	}
+	if out == nil {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("invalid response from Google Calendar: %w", err)
+	}
+	return nil
+}
+
+// APIError is a request a calendar refused
+type APIError struct {
+	Server  string
+	Code    int
+	Status  string
+	Message string
+}
+
+func (e *APIError) Error() string {
+	if e.Message == "" {
+		return e.Server + " returned " + e.Status
+	}
+	return fmt.Sprintf("%s returned %s: %s", e.Server, e.Status, e.Message)
+}
+
+// newAPIError reads the reason of an error response: the message of a Google
+// API error, or the start of the body
+func newAPIError(server string, resp *http.Response) *APIError {
+	data, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+
+	apiErr := &APIError{Server: server, Code: resp.StatusCode, Status: resp.Status}
+	var body struct {
+		Error struct {
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	if json.Unmarshal(data, &body) == nil && body.Error.Message != "" {
+		apiErr.Message = body.Error.Message
+		return apiErr
+	}
+	if len(data) > 512 {
+		data = data[:512]
+	}
+	apiErr.Message = strings.TrimSpace(string(data))
+	return apiErr
+}
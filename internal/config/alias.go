@@ -0,0 +1,43 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+// Aliases returns the command aliases defined in the config file, from keys of
+// the form alias.<name> = <command line>. Unlike the other settings they are
+// read straight from the file, since they are expanded before Init runs.
+func Aliases() (map[string]string, error) {
+	dir, err := Dir()
+	if err != nil {
+		return nil, err
+	}
+	path := filepath.Join(dir, "config")
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		return map[string]string{}, nil
+	}
+
+	v := viper.New()
+	v.SetConfigFile(path)
+	v.SetConfigType("properties")
+	if err := v.ReadInConfig(); err != nil {
+		return nil, err
+	}
+
+	aliases := make(map[string]string)
+	for name, line := range v.GetStringMapString("alias") {
+		line = strings.TrimSpace(line)
+		// Allow alias.t = "task create inbox" as well as the bare command line
+		if len(line) >= 2 && line[0] == '"' && line[len(line)-1] == '"' && !strings.Contains(line[1:len(line)-1], `"`) {
+			line = strings.TrimSpace(line[1 : len(line)-1])
+		}
+		if line != "" {
+			aliases[name] = line
+		}
+	}
+	return aliases, nil
+}
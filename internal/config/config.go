@@ -1,107 +1,626 @@
 package config
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
 
 	"github.com/spf13/viper"
 )
 
 // Config holds application configuration
 type Config struct {
-	QixDir              string
-	ProjectsDir         string
-	TrackFile           string
-	IndexFile           string
-	ConfigFile          string
-	BackupDir           string
-	DateFormat          string
-	DateTimeFormat      string
-	BackupRetentionDays int
-	ColorOutput         bool
-	JiraBaseURL         string
-	LogFile             string
-	LogLevel            string
+	QixDir               string
+	Profile              string
+	ProjectsDir          string
+	TrackFile            string
+	IndexFile            string
+	ScheduleFile         string
+	CalendarSyncFile     string
+	JournalFile          string
+	DatabaseFile         string
+	ConfigFile           string
+	LocalesDir           string // Translation files, <language>.json
+	UsageFile            string // Command usage statistics, kept if UsageStats is set
+	HooksDir             string // Executables run on events, named after them
+	HookTimeout          int    // Seconds a hook may run; unlimited if 0
+	BackupDir            string
+	TrashDir             string
+	DateFormat           string
+	DateTimeFormat       string
+	DateDisplay          string // absolute, relative ("in 3 days") or both
+	WeekStart            string // Day weeks start on in weekly reports, digests and recurrence
+	Language             string // Language of messages; empty to follow LC_ALL, LC_MESSAGES and LANG
+	BackupRetentionDays  int
+	TrashRetentionDays   int
+	ColorOutput          bool
+	ASCIIOutput          bool   // Plain ASCII in place of emoji, box drawing and block characters
+	Theme                string // Built-in color theme; colors can be overridden with ThemeColors
+	Pager                string // Command long output is shown through on a terminal
+	UsePager             bool
+	TaskFields           []string // Optional task fields listings show
+	ProjectFields        []string // Optional project fields listings show
+	CompactLists         bool     // One line per task or project in listings
+	JiraBaseURL          string
+	JiraAPIURL           string // REST API root; derived from JiraBaseURL if empty
+	JiraUser             string // Account for basic auth; empty to send JiraToken as a bearer token
+	JiraToken            string
+	JiraProject          string // Project key 'jira create' files issues in by default
+	JiraIssueType        string // Issue type 'jira create' files issues as
+	BranchPattern        string // Name of branches 'task branch' creates, with {key}, {id}, {jira}, {slug} and {project}
+	LogFile              string
+	LogLevel             string
+	LogFormat            string // text, or json for one JSON object per entry
+	LogMaxSizeKB         int    // Size the log is rotated at; never if 0
+	LogRotateDays        int    // Age the log is rotated at; never if 0
+	LogRetentionDays     int    // Days rotated logs are kept; forever if 0
+	Currency             string
+	StorageBackend       string
+	CacheMaxProjects     int
+	CompressThresholdKB  int
+	EncryptionPassphrase string
+	EncryptionKeyFile    string
+	BackupPassphrase     string
+	BackupInclude        []string // Patterns archived even if an exclude pattern matches
+	BackupExclude        []string // Patterns left out of backups
+	GitAutoCommit        bool     // Commit the data directory after each command once it is a git repo
+	UsageStats           bool     // Count the commands run and time them, in UsageFile
+	KPI                  KPIConfig
+	Remote               RemoteConfig
+	Mail                 MailConfig
+	Toggl                TogglConfig
+	Calendar             CalendarConfig
+
+	settings *viper.Viper // The settings read, for those not kept in fields
+}
+
+// RemoteConfig holds settings and credentials for remote backup targets
+type RemoteConfig struct {
+	S3Region       string
+	S3Endpoint     string
+	S3AccessKey    string
+	S3SecretKey    string
+	S3SessionToken string
+	WebDAVUser     string
+	WebDAVPassword string
+}
+
+// MailConfig holds how email, such as 'digest send' digests, is delivered
+type MailConfig struct {
+	SMTPHost     string
+	SMTPPort     int
+	SMTPUser     string
+	SMTPPassword string
+	From         string
+	To           []string
+	Command      string // Sendmail-compatible command used instead of SMTP, e.g. "sendmail -t"
+}
+
+// TogglConfig holds the Toggl Track account 'toggl sync' works with
+type TogglConfig struct {
+	APIToken    string
+	APIURL      string   // REST API root; the public Toggl API if empty
+	WorkspaceID int64    // Workspace time entries are pushed to; the account's default if 0
+	Map         []string // Rules mapping Toggl entries to tasks, as <tag or text>=<task ID>
+}
+
+// CalendarConfig holds the calendars 'calendar sync' writes to
+type CalendarConfig struct {
+	CalDAVURL          string // Calendar collection, e.g. https://dav.example.com/calendars/me/work/
+	CalDAVUser         string
+	CalDAVPassword     string
+	GoogleCalendarID   string // "primary" for the account's main calendar
+	GoogleToken        string // OAuth access token with the calendar.events scope
+	GoogleTokenCommand string // Prints an access token, e.g. "gcloud auth print-access-token"
+}
+
+// KPIConfig tunes the project health score. A weight of 0 disables that component.
+type KPIConfig struct {
+	CompletionWeight   float64
+	AccuracyWeight     float64
+	TrackingWeight     float64
+	ActiveWeight       float64
+	ActiveMin          float64 // Lower bound of the ideal in-progress percentage
+	ActiveMax          float64 // Upper bound of the ideal in-progress percentage
+	ExcellentThreshold float64
+	GoodThreshold      float64
+	AttentionThreshold float64
 }
 
 var globalConfig *Config
 
-// Init initializes the configuration
-func Init() error {
+// profileName selects a data-directory profile; see SetProfile
+var profileName string
+
+// SetProfile selects the profile Init uses, overriding QIX_PROFILE. Call it before Init.
+func SetProfile(name string) {
+	profileName = name
+}
+
+// Dir returns the directory of the config file: QIX_DIR, or ~/.qix
+func Dir() (string, error) {
+	if qixDir := os.Getenv("QIX_DIR"); qixDir != "" {
+		return qixDir, nil
+	}
 	homeDir, err := os.UserHomeDir()
 	if err != nil {
-		return err
+		return "", err
 	}
+	return filepath.Join(homeDir, ".qix"), nil
+}
 
-	// Get QIX_DIR from environment or use default
-	qixDir := os.Getenv("QIX_DIR")
-	if qixDir == "" {
-		qixDir = filepath.Join(homeDir, ".qix")
+// Init initializes the configuration
+func Init() error {
+	cfg, err := load(viper.GetViper(), firstNonEmpty(profileName, os.Getenv("QIX_PROFILE")))
+	if err != nil {
+		return err
 	}
+	globalConfig = cfg
+	return nil
+}
 
-	// Create directories
-	projectsDir := filepath.Join(qixDir, "projects")
-	backupDir := filepath.Join(qixDir, "backups")
+// Load reads the configuration into a Config of its own, leaving the global
+// one and its settings alone, for a data directory opened next to the CLI's.
+// An empty profile means $QIX_PROFILE.
+func Load(profile string) (*Config, error) {
+	return load(viper.New(), firstNonEmpty(profile, os.Getenv("QIX_PROFILE")))
+}
 
-	if err := os.MkdirAll(projectsDir, 0700); err != nil {
-		return err
+// load reads the config file into v and returns the configuration of a profile
+func load(v *viper.Viper, profile string) (*Config, error) {
+	qixDir, err := Dir()
+	if err != nil {
+		return nil, err
 	}
-	if err := os.MkdirAll(backupDir, 0700); err != nil {
-		return err
+
+	// The config file always lives in QIX_DIR, even when a profile keeps its data elsewhere
+	if err := os.MkdirAll(qixDir, 0700); err != nil {
+		return nil, err
 	}
 
 	// Set up viper for config file
 	configFile := filepath.Join(qixDir, "config")
-	viper.SetConfigFile(configFile)
-	viper.SetConfigType("properties")
+	v.SetConfigFile(configFile)
+	v.SetConfigType("properties")
 
 	// Set defaults
-	viper.SetDefault("date_format", "2006-01-02")
-	viper.SetDefault("datetime_format", "2006-01-02T15:04:05Z07:00")
-	viper.SetDefault("backup_retention_days", 30)
-	viper.SetDefault("color_output", true)
-	viper.SetDefault("jira_base_url", "")
-	viper.BindEnv("jira_base_url", "JIRA_BASE_URL")
-	viper.SetDefault("log_level", "info")
-	viper.BindEnv("log_level", "QIX_LOG_LEVEL")
-	viper.SetDefault("log_file", filepath.Join(qixDir, "qix.log"))
-	viper.BindEnv("log_file", "QIX_LOG_FILE")
-	viper.SetDefault("QIX_LOG_LEVEL", "info")
-	viper.SetDefault("QIX_LOG_FILE", filepath.Join(qixDir, "qix.log"))
+	v.SetDefault("date_format", "2006-01-02")
+	v.SetDefault("datetime_format", "2006-01-02 15:04:05")
+	v.SetDefault("date_display", "absolute")
+	v.SetDefault("week_start", "monday")
+	v.SetDefault("language", "")
+	v.SetDefault("backup_retention_days", 30)
+	v.SetDefault("trash_retention_days", 30)
+	v.SetDefault("color_output", true)
+	v.SetDefault("ascii_output", false)
+	v.SetDefault("theme", "default")
+	v.SetDefault("pager", firstNonEmpty(os.Getenv("PAGER"), "less"))
+	v.SetDefault("use_pager", true)
+	v.SetDefault("task_fields", "priority,assignee,status,time,due,tags")
+	v.SetDefault("project_fields", "description,counts,status,progress")
+	v.SetDefault("compact_lists", false)
+	v.SetDefault("jira_base_url", "")
+	v.SetDefault("jira_api_url", "")
+	v.SetDefault("jira_user", "")
+	v.SetDefault("jira_token", "")
+	v.SetDefault("jira_project", "")
+	v.SetDefault("jira_issue_type", "Task")
+	v.SetDefault("branch_pattern", "{key}-{slug}")
+	v.SetDefault("log_level", "info")
+	v.SetDefault("log_file", filepath.Join(qixDir, "qix.log"))
+	v.SetDefault("log_format", "text")
+	v.SetDefault("log_max_size_kb", 1024)
+	v.SetDefault("log_rotate_days", 7)
+	v.SetDefault("log_retention_days", 30)
+	v.SetDefault("currency", "USD")
+	v.SetDefault("storage_backend", "json")
+	v.SetDefault("cache_max_projects", 50)
+	v.SetDefault("compress_threshold_kb", 512)
+	v.SetDefault("encryption_passphrase", "")
+	v.SetDefault("encryption_key_file", "")
+	v.SetDefault("backup_passphrase", "")
+	v.SetDefault("backup_include", "")
+	v.SetDefault("backup_exclude", "*.log,*.tmp,*.lock,locks/")
+	v.SetDefault("git_autocommit", true)
+	v.SetDefault("usage_stats", false)
+	v.SetDefault("s3_region", "")
+	v.SetDefault("s3_endpoint", "")
+	v.SetDefault("s3_access_key", "")
+	v.SetDefault("s3_secret_key", "")
+	v.SetDefault("s3_session_token", "")
+	v.SetDefault("webdav_user", "")
+	v.SetDefault("webdav_password", "")
+	v.SetDefault("smtp_host", "")
+	v.SetDefault("smtp_port", 587)
+	v.SetDefault("smtp_user", "")
+	v.SetDefault("smtp_password", "")
+	v.SetDefault("mail_from", "")
+	v.SetDefault("mail_to", "")
+	v.SetDefault("mail_command", "")
+	v.SetDefault("hooks_dir", filepath.Join(qixDir, "hooks"))
+	v.SetDefault("hook_timeout", 30)
+	v.SetDefault("toggl_api_token", "")
+	v.SetDefault("toggl_api_url", "")
+	v.SetDefault("toggl_workspace_id", 0)
+	v.SetDefault("toggl_map", "")
+	v.SetDefault("caldav_url", "")
+	v.SetDefault("caldav_user", "")
+	v.SetDefault("caldav_password", "")
+	v.SetDefault("google_calendar_id", "primary")
+	v.SetDefault("google_token", "")
+	v.SetDefault("google_token_command", "")
+	v.SetDefault("kpi_weight_completion", 30)
+	v.SetDefault("kpi_weight_accuracy", 30)
+	v.SetDefault("kpi_weight_tracking", 20)
+	v.SetDefault("kpi_weight_active", 20)
+	v.SetDefault("kpi_active_min", 20)
+	v.SetDefault("kpi_active_max", 40)
+	v.SetDefault("kpi_threshold_excellent", 80)
+	v.SetDefault("kpi_threshold_good", 60)
+	v.SetDefault("kpi_threshold_attention", 40)
+	bindEnv(v)
 
 	// Try to read config file
-	if err := viper.ReadInConfig(); err != nil {
+	if err := v.ReadInConfig(); err != nil {
 		// Config file doesn't exist, create it with defaults
 		if _, ok := err.(viper.ConfigFileNotFoundError); ok {
-			viper.SafeWriteConfig()
+			v.SafeWriteConfig()
+		}
+	}
+
+	// A profile moves all data (but not the config file or log) to its own directory
+	profile = strings.ToLower(profile)
+	dataDir := qixDir
+	if profile != "" {
+		dataDir, err = profileDir(v, profile)
+		if err != nil {
+			return nil, err
 		}
 	}
 
-	globalConfig = &Config{
-		QixDir:              qixDir,
+	// Create directories
+	projectsDir := filepath.Join(dataDir, "projects")
+	backupDir := filepath.Join(dataDir, "backups")
+
+	if err := os.MkdirAll(projectsDir, 0700); err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(backupDir, 0700); err != nil {
+		return nil, err
+	}
+
+	return &Config{
+		QixDir:              dataDir,
+		Profile:             profile,
 		ProjectsDir:         projectsDir,
-		TrackFile:           filepath.Join(qixDir, "tracking.json"),
-		IndexFile:           filepath.Join(qixDir, "index.json"),
+		TrackFile:           filepath.Join(dataDir, "tracking.json"),
+		IndexFile:           filepath.Join(dataDir, "index.json"),
+		ScheduleFile:        filepath.Join(dataDir, "schedules.json"),
+		CalendarSyncFile:    filepath.Join(dataDir, "calendar-sync.json"),
+		JournalFile:         filepath.Join(dataDir, "journal.jsonl"),
+		DatabaseFile:        filepath.Join(dataDir, "qix.db"),
 		ConfigFile:          configFile,
+		LocalesDir:          filepath.Join(qixDir, "locales"),
+		UsageFile:           filepath.Join(qixDir, "usage.json"),
+		HooksDir:            v.GetString("hooks_dir"),
+		HookTimeout:         v.GetInt("hook_timeout"),
 		BackupDir:           backupDir,
-		DateFormat:          viper.GetString("date_format"),
-		DateTimeFormat:      viper.GetString("datetime_format"),
-		BackupRetentionDays: viper.GetInt("backup_retention_days"),
-		ColorOutput:         viper.GetBool("color_output"),
-		JiraBaseURL:         viper.GetString("jira_base_url"),
+		TrashDir:            filepath.Join(dataDir, "trash"),
+		DateFormat:          v.GetString("date_format"),
+		DateTimeFormat:      v.GetString("datetime_format"),
+		DateDisplay:         v.GetString("date_display"),
+		WeekStart:           v.GetString("week_start"),
+		Language:            v.GetString("language"),
+		BackupRetentionDays: v.GetInt("backup_retention_days"),
+		TrashRetentionDays:  v.GetInt("trash_retention_days"),
+		ColorOutput:         v.GetBool("color_output"),
+		ASCIIOutput:         v.GetBool("ascii_output"),
+		Theme:               v.GetString("theme"),
+		Pager:               v.GetString("pager"),
+		UsePager:            v.GetBool("use_pager"),
+		TaskFields:          splitList(v.GetString("task_fields")),
+		ProjectFields:       splitList(v.GetString("project_fields")),
+		CompactLists:        v.GetBool("compact_lists"),
+		JiraBaseURL:         v.GetString("jira_base_url"),
+		JiraAPIURL:          v.GetString("jira_api_url"),
+		JiraUser:            v.GetString("jira_user"),
+		JiraToken:           v.GetString("jira_token"),
+		JiraProject:         v.GetString("jira_project"),
+		JiraIssueType:       v.GetString("jira_issue_type"),
+		BranchPattern:       v.GetString("branch_pattern"),
 		LogFile: firstNonEmpty(
-			viper.GetString("QIX_LOG_FILE"),
-			viper.GetString("log_file"),
+			v.GetString("QIX_LOG_FILE"),
+			v.GetString("log_file"),
 			filepath.Join(qixDir, "qix.log"),
 		),
 		LogLevel: firstNonEmpty(
-			viper.GetString("QIX_LOG_LEVEL"),
-			viper.GetString("log_level"),
+			v.GetString("QIX_LOG_LEVEL"),
+			v.GetString("log_level"),
 			"info",
 		),
+		LogFormat: firstNonEmpty(
+			v.GetString("QIX_LOG_FORMAT"),
+			v.GetString("log_format"),
+			"text",
+		),
+		LogMaxSizeKB:         v.GetInt("log_max_size_kb"),
+		LogRotateDays:        v.GetInt("log_rotate_days"),
+		LogRetentionDays:     v.GetInt("log_retention_days"),
+		Currency:             v.GetString("currency"),
+		StorageBackend:       v.GetString("storage_backend"),
+		CacheMaxProjects:     v.GetInt("cache_max_projects"),
+		CompressThresholdKB:  v.GetInt("compress_threshold_kb"),
+		EncryptionPassphrase: v.GetString("encryption_passphrase"),
+		EncryptionKeyFile:    v.GetString("encryption_key_file"),
+		BackupPassphrase:     v.GetString("backup_passphrase"),
+		BackupInclude:        splitList(v.GetString("backup_include")),
+		BackupExclude:        splitList(v.GetString("backup_exclude")),
+		GitAutoCommit:        v.GetBool("git_autocommit"),
+		UsageStats:           v.GetBool("usage_stats"),
+		KPI: KPIConfig{
+			CompletionWeight:   v.GetFloat64("kpi_weight_completion"),
+			AccuracyWeight:     v.GetFloat64("kpi_weight_accuracy"),
+			TrackingWeight:     v.GetFloat64("kpi_weight_tracking"),
+			ActiveWeight:       v.GetFloat64("kpi_weight_active"),
+			ActiveMin:          v.GetFloat64("kpi_active_min"),
+			ActiveMax:          v.GetFloat64("kpi_active_max"),
+			ExcellentThreshold: v.GetFloat64("kpi_threshold_excellent"),
+			GoodThreshold:      v.GetFloat64("kpi_threshold_good"),
+			AttentionThreshold: v.GetFloat64("kpi_threshold_attention"),
+		},
+		Remote: RemoteConfig{
+			S3Region:       v.GetString("s3_region"),
+			S3Endpoint:     v.GetString("s3_endpoint"),
+			S3AccessKey:    v.GetString("s3_access_key"),
+			S3SecretKey:    v.GetString("s3_secret_key"),
+			S3SessionToken: v.GetString("s3_session_token"),
+			WebDAVUser:     v.GetString("webdav_user"),
+			WebDAVPassword: v.GetString("webdav_password"),
+		},
+		Mail: MailConfig{
+			SMTPHost:     v.GetString("smtp_host"),
+			SMTPPort:     v.GetInt("smtp_port"),
+			SMTPUser:     v.GetString("smtp_user"),
+			SMTPPassword: v.GetString("smtp_password"),
+			From:         v.GetString("mail_from"),
+			To:           splitList(v.GetString("mail_to")),
+			Command:      v.GetString("mail_command"),
+		},
+		Toggl: TogglConfig{
+			APIToken:    v.GetString("toggl_api_token"),
+			APIURL:      v.GetString("toggl_api_url"),
+			WorkspaceID: v.GetInt64("toggl_workspace_id"),
+			Map:         splitList(v.GetString("toggl_map")),
+		},
+		Calendar: CalendarConfig{
+			CalDAVURL:          v.GetString("caldav_url"),
+			CalDAVUser:         v.GetString("caldav_user"),
+			CalDAVPassword:     v.GetString("caldav_password"),
+			GoogleCalendarID:   v.GetString("google_calendar_id"),
+			GoogleToken:        v.GetString("google_token"),
+			GoogleTokenCommand: v.GetString("google_token_command"),
+		},
+		settings: v,
+	}, nil
+}
+
+// Profiles returns the data directory of each profile defined in the config file,
+// from keys of the form profile.<name> = <dir>
+func Profiles() map[string]string {
+	return profiles(viper.GetViper())
+}
+
+func profiles(v *viper.Viper) map[string]string {
+	profiles := make(map[string]string)
+	for name, dir := range v.GetStringMapString("profile") {
+		profiles[name] = dir
 	}
+	return profiles
+}
 
-	return nil
+// Remotes returns the backup targets defined in the config file, from keys of the
+// form remote.<name> = <url>
+func Remotes() map[string]string {
+	remotes := make(map[string]string)
+	for name, target := range viper.GetStringMapString("remote") {
+		remotes[name] = target
+	}
+	return remotes
+}
+
+// WebhookConfig is a webhook notifications are posted to
+type WebhookConfig struct {
+	Name   string
+	URL    string
+	Format string   // json, slack or discord; empty to guess from the URL
+	Events []string // Events to post; empty for all
+}
+
+// Webhooks returns the webhooks defined in the config file, from keys of the
+// form webhook.<name> = <url>, with webhook_format.<name> and
+// webhook_events.<name> to pick their format and events
+func Webhooks() []WebhookConfig {
+	return webhooks(viper.GetViper())
+}
+
+// Webhooks returns the webhooks defined in this configuration's config file
+func (c *Config) Webhooks() []WebhookConfig {
+	return webhooks(c.settings)
+}
+
+func webhooks(v *viper.Viper) []WebhookConfig {
+	formats := v.GetStringMapString("webhook_format")
+	events := v.GetStringMapString("webhook_events")
+
+	hooks := make([]WebhookConfig, 0)
+	for name, url := range v.GetStringMapString("webhook") {
+		if strings.TrimSpace(url) == "" {
+			continue
+		}
+		hooks = append(hooks, WebhookConfig{
+			Name:   name,
+			URL:    strings.TrimSpace(url),
+			Format: strings.TrimSpace(formats[name]),
+			Events: splitList(events[name]),
+		})
+	}
+	return hooks
+}
+
+// SubscriberConfig is a subscriber to the event bus
+type SubscriberConfig struct {
+	Name     string
+	Kind     string // webhook, hook, log or notify
+	Target   string // URL, script or file
+	Events   []string
+	Projects []string
+	Tags     []string
+	Statuses []string
+}
+
+// Subscribers returns the event bus subscribers defined in the config file,
+// from keys of the form subscriber.<name> = <kind> <target>, filtered with
+// subscriber_events.<name>, subscriber_projects.<name>, subscriber_tags.<name>
+// and subscriber_status.<name>
+func Subscribers() []SubscriberConfig {
+	return subscribers(viper.GetViper())
+}
+
+// Subscribers returns the event bus subscribers defined in this
+// configuration's config file
+func (c *Config) Subscribers() []SubscriberConfig {
+	return subscribers(c.settings)
+}
+
+func subscribers(v *viper.Viper) []SubscriberConfig {
+	events := v.GetStringMapString("subscriber_events")
+	projects := v.GetStringMapString("subscriber_projects")
+	tags := v.GetStringMapString("subscriber_tags")
+	statuses := v.GetStringMapString("subscriber_status")
+
+	subscribers := make([]SubscriberConfig, 0)
+	for name, value := range v.GetStringMapString("subscriber") {
+		kind, target, _ := strings.Cut(strings.TrimSpace(value), " ")
+		if kind == "" {
+			continue
+		}
+		subscribers = append(subscribers, SubscriberConfig{
+			Name:     name,
+			Kind:     strings.ToLower(kind),
+			Target:   expandHome(strings.TrimSpace(target)),
+			Events:   splitList(events[name]),
+			Projects: splitList(projects[name]),
+			Tags:     splitList(tags[name]),
+			Statuses: splitList(statuses[name]),
+		})
+	}
+	sort.Slice(subscribers, func(i, j int) bool { return subscribers[i].Name < subscribers[j].Name })
+	return subscribers
+}
+
+// CommandHookConfig is a shell command run before or after qix commands
+type CommandHookConfig struct {
+	Name        string
+	When        string   // before or after
+	Command     string   // Shell command line
+	Commands    []string // Commands it runs for, such as "task create"; all if empty
+	ChangedOnly bool     // Run after a command only if it changed data
+}
+
+// CommandHooks returns the command hooks defined in the config file, from keys
+// of the form command_hook.<name> = <before|after> <command>, limited to some
+// commands with command_hook_commands.<name> and, after commands, to those
+// that changed data with command_hook_changed.<name> = true
+func CommandHooks() []CommandHookConfig {
+	commands := viper.GetStringMapString("command_hook_commands")
+	changed := viper.GetStringMapString("command_hook_changed")
+
+	commandHooks := make([]CommandHookConfig, 0)
+	for name, value := range viper.GetStringMapString("command_hook") {
+		when, command, _ := strings.Cut(strings.TrimSpace(value), " ")
+		if when == "" {
+			continue
+		}
+		changedOnly, _ := strconv.ParseBool(strings.TrimSpace(changed[name]))
+		commandHooks = append(commandHooks, CommandHookConfig{
+			Name:        name,
+			When:        strings.ToLower(when),
+			Command:     strings.TrimSpace(command),
+			Commands:    splitList(commands[name]),
+			ChangedOnly: changedOnly,
+		})
+	}
+	sort.Slice(commandHooks, func(i, j int) bool { return commandHooks[i].Name < commandHooks[j].Name })
+	return commandHooks
+}
+
+// NotifyTemplates returns the notification messages overridden in the config
+// file, from keys of the form notify_template.<event> = <template>
+func NotifyTemplates() map[string]string {
+	return notifyTemplates(viper.GetViper())
+}
+
+// NotifyTemplates returns the notification messages overridden in this
+// configuration's config file
+func (c *Config) NotifyTemplates() map[string]string {
+	return notifyTemplates(c.settings)
+}
+
+func notifyTemplates(v *viper.Viper) map[string]string {
+	templates := make(map[string]string)
+	for event, text := range v.GetStringMapString("notify_template") {
+		templates[event] = text
+	}
+	return templates
+}
+
+// ThemeColors returns the theme colors overridden in the config file, from keys
+// of the form color.<name> = <color>
+func ThemeColors() map[string]string {
+	colors := make(map[string]string)
+	for name, spec := range viper.GetStringMapString("color") {
+		colors[name] = spec
+	}
+	return colors
+}
+
+// ProfileDir resolves the data directory of a profile, expanding a leading ~
+func ProfileDir(name string) (string, error) {
+	return profileDir(viper.GetViper(), name)
+}
+
+func profileDir(v *viper.Viper, name string) (string, error) {
+	dir, ok := profiles(v)[strings.ToLower(name)]
+	if !ok || dir == "" {
+		return "", fmt.Errorf("unknown profile '%s' (define it in the config file as profile.%s = <dir>)", name, name)
+	}
+	if dir == "~" || strings.HasPrefix(dir, "~/") {
+		homeDir, err := os.UserHomeDir()
+		if err != nil {
+			return "", err
+		}
+		dir = filepath.Join(homeDir, dir[1:])
+	}
+	return filepath.Abs(dir)
+}
+
+// WithDataDir returns a copy of the configuration that keeps its data under dir instead
+func (c *Config) WithDataDir(dir string) *Config {
+	copied := *c
+	copied.QixDir = dir
+	copied.ProjectsDir = filepath.Join(dir, "projects")
+	copied.TrackFile = filepath.Join(dir, "tracking.json")
+	copied.IndexFile = filepath.Join(dir, "index.json")
+	copied.ScheduleFile = filepath.Join(dir, "schedules.json")
+	copied.CalendarSyncFile = filepath.Join(dir, "calendar-sync.json")
+	copied.JournalFile = filepath.Join(dir, "journal.jsonl")
+	copied.DatabaseFile = filepath.Join(dir, "qix.db")
+	copied.BackupDir = filepath.Join(dir, "backups")
+	copied.TrashDir = filepath.Join(dir, "trash")
+	return &copied
 }
 
 // Get returns the global configuration
@@ -119,30 +638,66 @@ func (c *Config) GetProjectPath(projectName string) string {
 	return filepath.Join(c.ProjectsDir, projectName+".json")
 }
 
-// ProjectExists checks if a project file exists
+// ProjectExists checks if a project file exists, plain or gzip-compressed
 func (c *Config) ProjectExists(projectName string) bool {
-	_, err := os.Stat(c.GetProjectPath(projectName))
+	path := c.GetProjectPath(projectName)
+	if _, err := os.Stat(path); err == nil {
+		return true
+	}
+	_, err := os.Stat(path + ".gz")
 	return err == nil
 }
 
-// ListProjectFiles returns all project JSON files
+// ListProjectFiles returns the names of all projects, whether stored as .json or .json.gz
 func (c *Config) ListProjectFiles() ([]string, error) {
 	files, err := filepath.Glob(filepath.Join(c.ProjectsDir, "*.json"))
 	if err != nil {
 		return nil, err
 	}
+	compressed, err := filepath.Glob(filepath.Join(c.ProjectsDir, "*.json.gz"))
+	if err != nil {
+		return nil, err
+	}
 
-	// Extract just the project names (without path and .json extension)
-	projects := make([]string, 0, len(files))
-	for _, file := range files {
-		base := filepath.Base(file)
-		name := base[:len(base)-5] // Remove .json
-		projects = append(projects, name)
+	// Extract just the project names (without path and extension)
+	seen := make(map[string]bool)
+	projects := make([]string, 0, len(files)+len(compressed))
+	for _, file := range append(files, compressed...) {
+		base := strings.TrimSuffix(filepath.Base(file), ".gz")
+		name := strings.TrimSuffix(base, ".json")
+		if !seen[name] {
+			seen[name] = true
+			projects = append(projects, name)
+		}
 	}
+	sort.Strings(projects)
 
 	return projects, nil
 }
 
+// splitList splits a comma-separated setting, dropping empty items
+func splitList(value string) []string {
+	items := make([]string, 0)
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// expandHome expands a leading ~ in a path to the home directory
+func expandHome(path string) string {
+	if path != "~" && !strings.HasPrefix(path, "~/") {
+		return path
+	}
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return path
+	}
+	return filepath.Join(homeDir, path[1:])
+}
+
 func firstNonEmpty(values ...string) string {
 	for _, v := range values {
 		if v != "" {
@@ -0,0 +1,152 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+// SetValue writes key = value to the config file, replacing the line that
+// sets the key if there is one so the rest of the file, comments included,
+// stays as it is
+func SetValue(key, value string) error {
+	if strings.ContainsAny(value, "\r\n") {
+		return errors.New("values can't span lines")
+	}
+	line := key + " = " + escapeValue(value)
+
+	lines, err := readConfigLines()
+	if err != nil {
+		return err
+	}
+	replaced := false
+	kept := lines[:0]
+	for _, l := range lines {
+		if lineKey(l) == key {
+			// Only the first line counts; later ones set the key again
+			if !replaced {
+				kept = append(kept, line)
+				replaced = true
+			}
+			continue
+		}
+		kept = append(kept, l)
+	}
+	if !replaced {
+		kept = append(kept, line)
+	}
+	if err := writeConfigLines(kept); err != nil {
+		return err
+	}
+	viper.Set(key, value)
+	return nil
+}
+
+// UnsetValue removes the lines setting key from the config file, and reports
+// whether there were any
+func UnsetValue(key string) (bool, error) {
+	lines, err := readConfigLines()
+	if err != nil {
+		return false, err
+	}
+	kept := lines[:0]
+	for _, l := range lines {
+		if lineKey(l) != key {
+			kept = append(kept, l)
+		}
+	}
+	if len(kept) == len(lines) {
+		return false, nil
+	}
+	return true, writeConfigLines(kept)
+}
+
+// FileValues returns the keys set in the config file and their values
+func FileValues() (map[string]string, error) {
+	v := viper.New()
+	v.SetConfigFile(Get().ConfigFile)
+	v.SetConfigType("properties")
+	if err := v.ReadInConfig(); err != nil {
+		if _, statErr := os.Stat(Get().ConfigFile); os.IsNotExist(statErr) {
+			return map[string]string{}, nil
+		}
+		return nil, err
+	}
+	values := make(map[string]string)
+	for _, key := range v.AllKeys() {
+		values[key] = v.GetString(key)
+	}
+	return values, nil
+}
+
+// Source returns where the value of a key comes from: "env", "file" or
+// "default"
+func Source(key string, fileValues map[string]string) string {
+	if s, ok := FindSetting(key); ok && s.Env != "" {
+		if _, set := os.LookupEnv(s.Env); set {
+			return "env"
+		}
+	}
+	if _, ok := fileValues[strings.ToLower(key)]; ok {
+		return "file"
+	}
+	return "default"
+}
+
+func readConfigLines() ([]string, error) {
+	data, err := os.ReadFile(Get().ConfigFile)
+	if os.IsNotExist(err) {
+		return []string{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	text := strings.TrimRight(strings.ReplaceAll(string(data), "\r\n", "\n"), "\n")
+	if text == "" {
+		return []string{}, nil
+	}
+	return strings.Split(text, "\n"), nil
+}
+
+func writeConfigLines(lines []string) error {
+	path := Get().ConfigFile
+	data := strings.Join(lines, "\n")
+	if data != "" {
+		data += "\n"
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, []byte(data), 0600); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("failed to save %s: %w", path, err)
+	}
+	return nil
+}
+
+// lineKey returns the lowercased key a properties line sets, or "" for
+// comments and blank lines
+func lineKey(line string) string {
+	line = strings.TrimLeft(line, " \t\f")
+	if line == "" || line[0] == '#' || line[0] == '!' {
+		return ""
+	}
+	end := strings.IndexAny(line, "=: \t\f")
+	if end < 0 {
+		end = len(line)
+	}
+	return strings.ToLower(line[:end])
+}
+
+// escapeValue escapes the characters a properties value can't hold as is
+func escapeValue(value string) string {
+	value = strings.ReplaceAll(value, `\`, `\\`)
+	if strings.HasPrefix(value, " ") || strings.HasPrefix(value, "\t") {
+		value = `\` + value
+	}
+	return value
+}
@@ -0,0 +1,199 @@
+package config
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+// Kinds of setting values
+const (
+	KindString = "string"
+	KindInt    = "int"
+	KindFloat  = "float"
+	KindBool   = "bool"
+	KindList   = "list" // Comma-separated
+)
+
+// Setting describes a key of the config file
+type Setting struct {
+	Key         string   // Key, or prefix of keys like webhook.<name> when it ends in "."
+	Kind        string   // Kind of value
+	Description string   // What it sets
+	Env         string   // Environment variable that overrides it, if any
+	Secret      bool     // Hidden when listed
+	Values      []string // Values allowed; any if empty
+}
+
+// Named reports whether the setting is a family of keys such as webhook.<name>
+func (s Setting) Named() bool {
+	return strings.HasSuffix(s.Key, ".")
+}
+
+// Settings lists the keys of the config file
+var Settings = []Setting{
+	{Key: "date_format", Kind: KindString, Description: "Go layout dates are shown in, and accepted in besides YYYY-MM-DD"},
+	{Key: "datetime_format", Kind: KindString, Description: "Go layout timestamps are shown in"},
+	{Key: "date_display", Kind: KindString, Description: "Show dates as absolute, relative (\"in 3 days\") or both", Values: []string{"absolute", "relative", "both"}},
+	{Key: "week_start", Kind: KindString, Description: "Day weeks start on in weekly reports, digests and recurrence", Values: []string{"monday", "tuesday", "wednesday", "thursday", "friday", "saturday", "sunday"}},
+	{Key: "language", Kind: KindString, Env: "QIX_LANG", Description: "Language of messages; empty to follow LC_ALL, LC_MESSAGES and LANG"},
+	{Key: "backup_retention_days", Kind: KindInt, Description: "Days backups are kept by 'backup cleanup'"},
+	{Key: "trash_retention_days", Kind: KindInt, Description: "Days deleted items stay in the trash"},
+	{Key: "color_output", Kind: KindBool, Description: "Color the output"},
+	{Key: "ascii_output", Kind: KindBool, Env: "QIX_ASCII", Description: "Plain ASCII in place of emoji and box drawing"},
+	{Key: "theme", Kind: KindString, Env: "QIX_THEME", Description: "Color theme (see 'qix theme list')"},
+	{Key: "color.", Kind: KindString, Description: "Color of a palette color or role, overriding the theme"},
+	{Key: "pager", Kind: KindString, Env: "QIX_PAGER", Description: "Command long output is shown through"},
+	{Key: "use_pager", Kind: KindBool, Description: "Show long output through the pager on a terminal"},
+	{Key: "task_fields", Kind: KindList, Description: "Optional task fields listings show"},
+	{Key: "project_fields", Kind: KindList, Description: "Optional project fields listings show"},
+	{Key: "compact_lists", Kind: KindBool, Description: "One line per task or project in listings"},
+	{Key: "profile.", Kind: KindString, Description: "Data directory of a profile"},
+	{Key: "status.", Kind: KindString, Description: "Icon of a task status, defining it if it isn't built in"},
+	{Key: "status_ascii.", Kind: KindString, Description: "Icon of a status in ASCII output"},
+	{Key: "status_color.", Kind: KindString, Description: "Color of a status, or a palette color or role"},
+	{Key: "status_done.", Kind: KindBool, Description: "Whether tasks in a status are finished"},
+	{Key: "status_order", Kind: KindList, Description: "Statuses in the order they are listed"},
+	{Key: "priority.", Kind: KindString, Description: "Icon of a task priority, defining it if it isn't built in"},
+	{Key: "priority_ascii.", Kind: KindString, Description: "Icon of a priority in ASCII output"},
+	{Key: "priority_color.", Kind: KindString, Description: "Color of a priority, or a palette color or role"},
+	{Key: "priority_order", Kind: KindList, Description: "Priorities from lowest to highest"},
+	{Key: "jira_base_url", Kind: KindString, Env: "JIRA_BASE_URL", Description: "URL Jira issue links start with, e.g. https://example.atlassian.net/browse"},
+	{Key: "jira_api_url", Kind: KindString, Description: "Jira REST API root; derived from jira_base_url if empty"},
+	{Key: "jira_user", Kind: KindString, Env: "JIRA_USER", Description: "Jira account for basic auth; empty to send the token as a bearer token"},
+	{Key: "jira_token", Kind: KindString, Env: "JIRA_API_TOKEN", Secret: true, Description: "Jira API token"},
+	{Key: "jira_project", Kind: KindString, Description: "Jira project key 'jira create' files issues in"},
+	{Key: "jira_issue_type", Kind: KindString, Description: "Issue type 'jira create' files issues as"},
+	{Key: "branch_pattern", Kind: KindString, Description: "Name of branches 'task branch' creates, with {key}, {id}, {jira}, {slug} and {project}"},
+	{Key: "log_level", Kind: KindString, Env: "QIX_LOG_LEVEL", Description: "Log level", Values: []string{"debug", "info", "warn", "error"}},
+	{Key: "log_file", Kind: KindString, Env: "QIX_LOG_FILE", Description: "File the log is written to"},
+	{Key: "log_format", Kind: KindString, Env: "QIX_LOG_FORMAT", Description: "How log entries are written: text, or json for one JSON object per line", Values: []string{"text", "json"}},
+	{Key: "log_max_size_kb", Kind: KindInt, Description: "Size in KB the log is rotated at; 0 for no limit"},
+	{Key: "log_rotate_days", Kind: KindInt, Description: "Days a log collects entries before it is rotated; 0 for no limit"},
+	{Key: "log_retention_days", Kind: KindInt, Description: "Days rotated logs are kept; 0 to keep them"},
+	{Key: "currency", Kind: KindString, Description: "Currency of cost reports"},
+	{Key: "storage_backend", Kind: KindString, Description: "Where projects are stored", Values: []string{"json", "sqlite"}},
+	{Key: "cache_max_projects", Kind: KindInt, Description: "Projects kept in memory at once"},
+	{Key: "compress_threshold_kb", Kind: KindInt, Description: "Size in KB above which project files are compressed"},
+	{Key: "encryption_passphrase", Kind: KindString, Env: "QIX_PASSPHRASE", Secret: true, Description: "Passphrase data is encrypted with at rest"},
+	{Key: "encryption_key_file", Kind: KindString, Description: "File holding the key data is encrypted with at rest"},
+	{Key: "backup_passphrase", Kind: KindString, Env: "QIX_BACKUP_PASSPHRASE", Secret: true, Description: "Passphrase backups are encrypted with"},
+	{Key: "backup_include", Kind: KindList, Description: "Patterns archived even if an exclude pattern matches"},
+	{Key: "backup_exclude", Kind: KindList, Description: "Patterns left out of backups"},
+	{Key: "remote.", Kind: KindString, Description: "Backup target URL"},
+	{Key: "git_autocommit", Kind: KindBool, Description: "Commit the data directory after each command once it is a git repo"},
+	{Key: "usage_stats", Kind: KindBool, Description: "Count and time the commands you run, locally, for 'qix stats usage'"},
+	{Key: "s3_region", Kind: KindString, Env: "AWS_REGION", Description: "S3 region of backup remotes"},
+	{Key: "s3_endpoint", Kind: KindString, Description: "S3-compatible endpoint of backup remotes"},
+	{Key: "s3_access_key", Kind: KindString, Env: "AWS_ACCESS_KEY_ID", Description: "S3 access key ID"},
+	{Key: "s3_secret_key", Kind: KindString, Env: "AWS_SECRET_ACCESS_KEY", Secret: true, Description: "S3 secret access key"},
+	{Key: "s3_session_token", Kind: KindString, Env: "AWS_SESSION_TOKEN", Secret: true, Description: "S3 session token"},
+	{Key: "webdav_user", Kind: KindString, Description: "WebDAV backup remote user"},
+	{Key: "webdav_password", Kind: KindString, Env: "QIX_WEBDAV_PASSWORD", Secret: true, Description: "WebDAV backup remote password"},
+	{Key: "smtp_host", Kind: KindString, Description: "SMTP server digests are sent through"},
+	{Key: "smtp_port", Kind: KindInt, Description: "SMTP server port"},
+	{Key: "smtp_user", Kind: KindString, Description: "SMTP user"},
+	{Key: "smtp_password", Kind: KindString, Env: "QIX_SMTP_PASSWORD", Secret: true, Description: "SMTP password"},
+	{Key: "mail_from", Kind: KindString, Description: "Sender of digests"},
+	{Key: "mail_to", Kind: KindList, Description: "Recipients of digests"},
+	{Key: "mail_command", Kind: KindString, Description: "Sendmail-compatible command used instead of SMTP"},
+	{Key: "webhook.", Kind: KindString, Description: "Webhook URL notifications are posted to"},
+	{Key: "webhook_format.", Kind: KindString, Description: "Format of a webhook", Values: []string{"json", "slack", "discord"}},
+	{Key: "webhook_events.", Kind: KindList, Description: "Events a webhook gets; all if empty"},
+	{Key: "notify_template.", Kind: KindString, Description: "Message of a notification event"},
+	{Key: "alias.", Kind: KindString, Description: "Command line an alias runs, such as task create inbox"},
+	{Key: "hooks_dir", Kind: KindString, Description: "Directory of hook scripts"},
+	{Key: "hook_timeout", Kind: KindInt, Description: "Seconds a hook may run; unlimited if 0"},
+	{Key: "subscriber.", Kind: KindString, Description: "Event subscriber, as <webhook|hook|log|notify> <target>"},
+	{Key: "subscriber_events.", Kind: KindList, Description: "Events a subscriber gets; all if empty"},
+	{Key: "subscriber_projects.", Kind: KindList, Description: "Projects a subscriber gets events of"},
+	{Key: "subscriber_tags.", Kind: KindList, Description: "Task tags a subscriber gets events of"},
+	{Key: "subscriber_status.", Kind: KindList, Description: "Task statuses a subscriber gets events of"},
+	{Key: "command_hook.", Kind: KindString, Description: "Shell command run before or after qix commands, as <before|after> <command>"},
+	{Key: "command_hook_commands.", Kind: KindList, Description: "Commands a command hook runs for, such as task create; all if empty"},
+	{Key: "command_hook_changed.", Kind: KindBool, Description: "Run an after hook only when the command changed data"},
+	{Key: "toggl_api_token", Kind: KindString, Env: "TOGGL_API_TOKEN", Secret: true, Description: "Toggl Track API token"},
+	{Key: "toggl_api_url", Kind: KindString, Description: "Toggl API root; the public API if empty"},
+	{Key: "toggl_workspace_id", Kind: KindInt, Description: "Toggl workspace entries are pushed to; the default if 0"},
+	{Key: "toggl_map", Kind: KindList, Description: "Rules mapping Toggl entries to tasks, as <tag or text>=<task ID>"},
+	{Key: "caldav_url", Kind: KindString, Description: "CalDAV calendar collection 'calendar sync' writes to"},
+	{Key: "caldav_user", Kind: KindString, Description: "CalDAV user"},
+	{Key: "caldav_password", Kind: KindString, Env: "QIX_CALDAV_PASSWORD", Secret: true, Description: "CalDAV password"},
+	{Key: "google_calendar_id", Kind: KindString, Description: "Google calendar 'calendar sync' writes to"},
+	{Key: "google_token", Kind: KindString, Env: "GOOGLE_OAUTH_ACCESS_TOKEN", Secret: true, Description: "Google OAuth access token"},
+	{Key: "google_token_command", Kind: KindString, Description: "Command printing a Google access token"},
+	{Key: "kpi_weight_completion", Kind: KindFloat, Description: "Weight of completion in the health score"},
+	{Key: "kpi_weight_accuracy", Kind: KindFloat, Description: "Weight of estimate accuracy in the health score"},
+	{Key: "kpi_weight_tracking", Kind: KindFloat, Description: "Weight of time tracking in the health score"},
+	{Key: "kpi_weight_active", Kind: KindFloat, Description: "Weight of work in progress in the health score"},
+	{Key: "kpi_active_min", Kind: KindFloat, Description: "Lower bound of the ideal in-progress percentage"},
+	{Key: "kpi_active_max", Kind: KindFloat, Description: "Upper bound of the ideal in-progress percentage"},
+	{Key: "kpi_threshold_excellent", Kind: KindFloat, Description: "Health score rated excellent"},
+	{Key: "kpi_threshold_good", Kind: KindFloat, Description: "Health score rated good"},
+	{Key: "kpi_threshold_attention", Kind: KindFloat, Description: "Health score below which a project needs attention"},
+}
+
+// FindSetting returns the setting of a key, such as date_format or
+// webhook.team
+func FindSetting(key string) (Setting, bool) {
+	key = strings.ToLower(key)
+	for _, s := range Settings {
+		if s.Key == key || s.Named() && strings.HasPrefix(key, s.Key) && len(key) > len(s.Key) {
+			return s, true
+		}
+	}
+	return Setting{}, false
+}
+
+// Check returns an error if value can't be given to the setting
+func (s Setting) Check(value string) error {
+	value = strings.TrimSpace(value)
+	switch s.Kind {
+	case KindInt:
+		if _, err := strconv.Atoi(value); err != nil {
+			return fmt.Errorf("takes a whole number, not %q", value)
+		}
+	case KindFloat:
+		if _, err := strconv.ParseFloat(value, 64); err != nil {
+			return fmt.Errorf("takes a number, not %q", value)
+		}
+	case KindBool:
+		if _, err := strconv.ParseBool(value); err != nil {
+			return fmt.Errorf("takes true or false, not %q", value)
+		}
+	}
+	if len(s.Values) > 0 && value != "" {
+		for _, allowed := range s.Values {
+			if strings.EqualFold(value, allowed) {
+				return nil
+			}
+		}
+		return fmt.Errorf("takes one of %s, not %q", strings.Join(s.Values, ", "), value)
+	}
+	return nil
+}
+
+// Value returns the value a key has, from the environment, the config file or
+// its default
+func Value(key string) string {
+	value := viper.Get(key)
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// bindEnv lets the environment variables of settings override them
+func bindEnv(v *viper.Viper) {
+	for _, s := range Settings {
+		if s.Env != "" {
+			v.BindEnv(s.Key, s.Env)
+		}
+	}
+}
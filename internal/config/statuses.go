@@ -0,0 +1,201 @@
+package config
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/viper"
+
+	"github.com/mrbooshehri/qix-go/pkg/qix/models"
+)
+
+var statusNamePattern = regexp.MustCompile(`^[a-z][a-z0-9_-]*$`)
+
+// Statuses returns the statuses tasks can have: the built-in ones and those
+// defined in the config file with keys of the form status.<name> = <icon>,
+// with status_ascii.<name>, status_color.<name> and status_done.<name> to set
+// their ASCII icon, color and whether they finish a task. status_order lists
+// them in order; the others follow.
+func Statuses() ([]models.StatusDef, error) {
+	return statuses(viper.GetViper())
+}
+
+func statuses(v *viper.Viper) ([]models.StatusDef, error) {
+	defs := models.DefaultStatuses()
+	index := make(map[string]int)
+	for i, def := range defs {
+		index[string(def.Name)] = i
+	}
+
+	icons := v.GetStringMapString("status")
+	for _, name := range sortedNames(icons) {
+		if !statusNamePattern.MatchString(name) {
+			return nil, fmt.Errorf("status.%s: names are lowercase letters, digits, - and _", name)
+		}
+		if _, ok := index[name]; !ok {
+			index[name] = len(defs)
+			defs = append(defs, models.StatusDef{
+				Name:  models.TaskStatus(name),
+				Icon:  "🔹",
+				ASCII: "[" + name[:1] + "]",
+			})
+		}
+		if icon := strings.TrimSpace(icons[name]); icon != "" {
+			defs[index[name]].Icon = icon
+		}
+	}
+
+	for name, ascii := range v.GetStringMapString("status_ascii") {
+		i, ok := index[name]
+		if !ok {
+			return nil, fmt.Errorf("status_ascii.%s: no status %s (define it with status.%s)", name, name, name)
+		}
+		if ascii = strings.TrimSpace(ascii); ascii != "" {
+			defs[i].ASCII = ascii
+		}
+	}
+	for name, spec := range v.GetStringMapString("status_color") {
+		i, ok := index[name]
+		if !ok {
+			return nil, fmt.Errorf("status_color.%s: no status %s (define it with status.%s)", name, name, name)
+		}
+		defs[i].Color = strings.TrimSpace(spec)
+	}
+	for name, value := range v.GetStringMapString("status_done") {
+		i, ok := index[name]
+		if !ok {
+			return nil, fmt.Errorf("status_done.%s: no status %s (define it with status.%s)", name, name, name)
+		}
+		done, err := strconv.ParseBool(strings.TrimSpace(value))
+		if err != nil {
+			return nil, fmt.Errorf("status_done.%s takes true or false, not %q", name, value)
+		}
+		defs[i].Done = done
+	}
+
+	order, err := orderOf(v, "status_order", index)
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(defs, func(a, b int) bool {
+		return order(string(defs[a].Name)) < order(string(defs[b].Name))
+	})
+	return defs, nil
+}
+
+// Priorities returns the priorities tasks can have: the built-in ones and
+// those defined in the config file with keys of the form
+// priority.<name> = <icon>, with priority_ascii.<name> and
+// priority_color.<name>. priority_order lists them from lowest to highest; the
+// others follow.
+func Priorities() ([]models.PriorityDef, error) {
+	return priorities(viper.GetViper())
+}
+
+func priorities(v *viper.Viper) ([]models.PriorityDef, error) {
+	defs := models.DefaultPriorities()
+	index := make(map[string]int)
+	for i, def := range defs {
+		index[string(def.Name)] = i
+	}
+
+	icons := v.GetStringMapString("priority")
+	for _, name := range sortedNames(icons) {
+		if !statusNamePattern.MatchString(name) {
+			return nil, fmt.Errorf("priority.%s: names are lowercase letters, digits, - and _", name)
+		}
+		if _, ok := index[name]; !ok {
+			index[name] = len(defs)
+			defs = append(defs, models.PriorityDef{
+				Name:  models.Priority(name),
+				Icon:  "⚪",
+				ASCII: "(" + strings.ToUpper(name[:1]) + ")",
+			})
+		}
+		if icon := strings.TrimSpace(icons[name]); icon != "" {
+			defs[index[name]].Icon = icon
+		}
+	}
+
+	for name, ascii := range v.GetStringMapString("priority_ascii") {
+		i, ok := index[name]
+		if !ok {
+			return nil, fmt.Errorf("priority_ascii.%s: no priority %s (define it with priority.%s)", name, name, name)
+		}
+		if ascii = strings.TrimSpace(ascii); ascii != "" {
+			defs[i].ASCII = ascii
+		}
+	}
+	for name, spec := range v.GetStringMapString("priority_color") {
+		i, ok := index[name]
+		if !ok {
+			return nil, fmt.Errorf("priority_color.%s: no priority %s (define it with priority.%s)", name, name, name)
+		}
+		defs[i].Color = strings.TrimSpace(spec)
+	}
+
+	order, err := orderOf(v, "priority_order", index)
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(defs, func(a, b int) bool {
+		return order(string(defs[a].Name)) < order(string(defs[b].Name))
+	})
+	return defs, nil
+}
+
+// ApplyStatuses makes the statuses and priorities of the config file the
+// ones tasks can have. Nothing changes if they are invalid.
+func ApplyStatuses() error {
+	return applyStatuses(viper.GetViper())
+}
+
+// ApplyStatuses makes the statuses and priorities of this configuration's
+// config file the ones tasks can have. They are shared by the whole process.
+func (c *Config) ApplyStatuses() error {
+	return applyStatuses(c.settings)
+}
+
+func applyStatuses(v *viper.Viper) error {
+	statuses, err := statuses(v)
+	if err != nil {
+		return err
+	}
+	priorities, err := priorities(v)
+	if err != nil {
+		return err
+	}
+	models.SetStatuses(statuses)
+	models.SetPriorities(priorities)
+	return nil
+}
+
+// orderOf returns the position of each name in the list set by key, with
+// the names it doesn't list after those it does
+func orderOf(v *viper.Viper, key string, known map[string]int) (func(name string) int, error) {
+	positions := make(map[string]int)
+	for i, name := range splitList(strings.ToLower(v.GetString(key))) {
+		if _, ok := known[name]; !ok {
+			return nil, fmt.Errorf("%s: unknown name %s", key, name)
+		}
+		positions[name] = i
+	}
+	return func(name string) int {
+		if i, ok := positions[name]; ok {
+			return i
+		}
+		return len(positions)
+	}, nil
+}
+
+func sortedNames(values map[string]string) []string {
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,254 @@
+// qix dashboard: reads the JSON API of 'qix serve web' and redraws every
+// refresh interval. Read-only; changes are made with the qix CLI.
+"use strict";
+
+const REFRESH_MS = 30000;
+let STATUSES = ["todo", "doing", "done", "blocked"]; // From /api/statuses
+const token = new URLSearchParams(location.search).get("token") || "";
+
+let timer = null;      // Last /api/timer response
+let projects = [];     // Last /api/projects response
+
+function api(path, params) {
+  const query = new URLSearchParams(params || {});
+  if (token) query.set("token", token);
+  const qs = query.toString();
+  return fetch("api/" + path + (qs ? "?" + qs : "")).then((resp) => {
+    if (!resp.ok) {
+      return resp.text().then((text) => { throw new Error(text.trim() || resp.statusText); });
+    }
+    return resp.json();
+  });
+}
+
+// el builds an element; children are nodes or strings (set as text, never HTML)
+function el(tag, attrs, ...children) {
+  const node = document.createElement(tag);
+  for (const [key, value] of Object.entries(attrs || {})) {
+    if (value === undefined || value === null || value === false) continue;
+    if (key === "style") node.style.cssText = value;
+    else node.setAttribute(key, value);
+  }
+  for (const child of children.flat()) {
+    if (child === undefined || child === null || child === false) continue;
+    node.append(child instanceof Node ? child : String(child));
+  }
+  return node;
+}
+
+function svg(tag, attrs, ...children) {
+  const node = document.createElementNS("http://www.w3.org/2000/svg", tag);
+  for (const [key, value] of Object.entries(attrs || {})) node.setAttribute(key, value);
+  for (const child of children.flat()) {
+    node.append(child instanceof Node ? child : String(child));
+  }
+  return node;
+}
+
+const hours = (h) => (h || 0).toFixed(2) + "h";
+const pct = (p) => (p || 0).toFixed(1) + "%";
+const statusColor = (s) => "var(--" + s + ", var(--custom))";
+
+function duration(seconds) {
+  const h = Math.floor(seconds / 3600);
+  const m = Math.floor((seconds % 3600) / 60);
+  const s = Math.floor(seconds % 60);
+  return h + ":" + String(m).padStart(2, "0") + ":" + String(s).padStart(2, "0");
+}
+
+function statusBar(counts, total) {
+  return el("div", { class: "bar" },
+    STATUSES.filter((s) => counts[s] > 0).map((s) =>
+      el("span", { title: counts[s] + " " + s, style: `width:${counts[s] / total * 100}%;background:${statusColor(s)}` })));
+}
+
+function legend(items) {
+  return el("div", { class: "legend" },
+    items.map(([label, color]) => el("span", {}, el("i", { style: "background:" + color }), label)));
+}
+
+// Overview of all projects
+
+function renderOverview(main) {
+  if (projects.length === 0) {
+    main.append(el("div", { class: "panel" },
+      el("h2", {}, "No projects yet"),
+      el("p", { class: "muted" }, "Create one with: qix project create <name>")));
+    return;
+  }
+  main.append(el("div", { class: "grid" }, projects.map((p) =>
+    el("a", { class: "panel", href: "#/project/" + encodeURIComponent(p.name) },
+      el("h2", {}, p.name),
+      p.description ? el("div", { class: "muted" }, p.description) : null,
+      el("div", { class: "stats" },
+        STATUSES.map((s) => el("span", {}, el("b", { style: "color:" + statusColor(s) }, p.status_counts[s] || 0), s))),
+      p.tasks > 0 ? statusBar(p.status_counts, p.tasks) : null,
+      el("div", { class: "stats muted" },
+        el("span", {}, pct(p.completion) + " done"),
+        el("span", {}, hours(p.actual_hours) + " of " + hours(p.estimated_hours)),
+        p.deadline ? el("span", {}, "Deadline " + p.deadline) : null)))));
+}
+
+// One project: charts, board and sprints
+
+function renderProject(main, p) {
+  main.append(
+    el("h1", {}, p.name),
+    p.description ? el("div", { class: "muted" }, p.description) : null,
+    el("div", { class: "stats" },
+      el("span", {}, el("b", {}, p.tasks.length), "tasks"),
+      el("span", {}, el("b", {}, pct(p.completion)), "done"),
+      el("span", {}, el("b", {}, hours(p.estimated_hours)), "estimated"),
+      el("span", {}, el("b", {}, hours(p.actual_hours)), "logged"),
+      p.deadline ? el("span", {}, el("b", {}, p.deadline), "deadline") : null),
+    el("div", { class: "charts" },
+      el("div", { class: "panel" }, el("h2", {}, "Status"), donutChart(p.status_counts)),
+      el("div", { class: "panel" }, el("h2", {}, "Hours logged, last 14 days"), hoursChart(p.hours)),
+      el("div", { class: "panel" }, el("h2", {}, "Modules"), moduleChart(p.modules))),
+    board(p.tasks),
+    sprintTable(p.sprints));
+}
+
+function donutChart(counts) {
+  const total = STATUSES.reduce((sum, s) => sum + (counts[s] || 0), 0);
+  const r = 50, c = 2 * Math.PI * r;
+  const chart = svg("svg", { viewBox: "0 0 140 140", width: 140, height: 140 });
+  let offset = 0;
+  for (const s of STATUSES) {
+    const n = counts[s] || 0;
+    if (n === 0) continue;
+    const len = n / total * c;
+    chart.append(svg("circle", {
+      cx: 70, cy: 70, r, fill: "none", stroke: statusColor(s), "stroke-width": 22,
+      "stroke-dasharray": `${len} ${c - len}`, "stroke-dashoffset": -offset, transform: "rotate(-90 70 70)",
+    }, svg("title", {}, `${n} ${s}`)));
+    offset += len;
+  }
+  if (total === 0) {
+    chart.append(svg("circle", { cx: 70, cy: 70, r, fill: "none", stroke: "var(--border)", "stroke-width": 22 }));
+  }
+  chart.append(svg("text", { x: 70, y: 75, "text-anchor": "middle", style: "font-size:16px" }, total + " tasks"));
+  return el("div", {}, chart, legend(STATUSES.map((s) => [`${s} (${counts[s] || 0})`, statusColor(s)])));
+}
+
+function hoursChart(days) {
+  const width = 320, height = 140, pad = 18;
+  const max = Math.max(1, ...days.map((d) => d.hours));
+  const step = (width - pad) / days.length;
+  const chart = svg("svg", { viewBox: `0 0 ${width} ${height}`, width: "100%" });
+  days.forEach((d, i) => {
+    const h = d.hours / max * (height - 2 * pad);
+    const x = pad + i * step;
+    chart.append(svg("rect", {
+      x: x + 2, y: height - pad - h, width: step - 4, height: Math.max(h, 1),
+      rx: 2, fill: d.hours > 0 ? "var(--doing)" : "var(--border)",
+    }, svg("title", {}, `${d.date}: ${hours(d.hours)}`)));
+    if (i % 2 === 0) {
+      chart.append(svg("text", { x: x + step / 2, y: height - 4, "text-anchor": "middle" }, d.date.slice(5)));
+    }
+  });
+  chart.append(svg("text", { x: 0, y: pad }, max.toFixed(1) + "h"));
+  return chart;
+}
+
+function moduleChart(modules) {
+  if (modules.length === 0) return el("p", { class: "muted" }, "No modules");
+  return el("div", {}, modules.map((m) => el("div", { style: "margin-bottom:10px" },
+    el("div", { style: "display:flex;justify-content:space-between" },
+      el("span", {}, m.name), el("span", { class: "muted" }, `${m.done}/${m.tasks} · ${hours(m.actual_hours)} of ${hours(m.estimated_hours)}`)),
+    el("div", { class: "bar" }, el("span", { style: `width:${m.completion}%;background:var(--done)` })))));
+}
+
+function board(tasks) {
+  const tracking = timer && timer.active ? timer.task_id : "";
+  return el("div", { class: "board" }, STATUSES.map((s) => {
+    const column = tasks.filter((t) => t.status === s);
+    return el("div", { class: "column " + s },
+      el("h2", {}, el("span", {}, s), el("span", { class: "muted" }, column.length)),
+      column.map((t) => el("div", { class: `card ${t.priority}${t.id === tracking ? " tracking" : ""}` },
+        el("div", { class: "title" }, t.title),
+        el("div", { class: "meta" },
+          el("span", {}, t.id),
+          t.module ? el("span", {}, t.module) : null,
+          t.assignee ? el("span", {}, "@" + t.assignee) : null,
+          t.due_date ? el("span", { class: t.overdue ? "overdue" : "" }, "due " + t.due_date) : null,
+          t.estimated_hours || t.actual_hours ? el("span", {}, `${hours(t.actual_hours)}/${hours(t.estimated_hours)}`) : null,
+          t.jira_issue ? el("span", {}, t.jira_issue) : null,
+          (t.tags || []).map((tag) => el("span", { class: "tag" }, tag))))));
+  }));
+}
+
+function sprintTable(sprints) {
+  if (sprints.length === 0) return null;
+  return el("div", { class: "panel", style: "margin-top:16px" },
+    el("h2", {}, "Sprints"),
+    el("table", {},
+      el("tr", {}, el("th", {}, "Sprint"), el("th", {}, "Dates"), el("th", { class: "num" }, "Done"), el("th", {}, "Progress"), el("th", {}, "")),
+      sprints.map((s) => el("tr", {},
+        el("td", {}, s.name),
+        el("td", {}, `${s.start_date} – ${s.end_date}`),
+        el("td", { class: "num" }, `${s.done}/${s.tasks}`),
+        el("td", { style: "width:30%" }, el("div", { class: "bar" },
+          el("span", { style: `width:${s.tasks ? s.done / s.tasks * 100 : 0}%;background:var(--done)` }))),
+        el("td", { class: "muted" }, s.closed_at ? "closed " + s.closed_at.slice(0, 10) : (s.active ? "active" : ""))))));
+}
+
+// Timer
+
+function renderTimer() {
+  const node = document.getElementById("timer");
+  if (!timer || !timer.active) {
+    node.className = "timer idle";
+    node.textContent = "No timer running";
+    return;
+  }
+  const elapsed = (Date.now() - new Date(timer.started_at).getTime()) / 1000;
+  node.className = "timer";
+  node.textContent = `⏱ ${duration(elapsed)} · ${timer.title || timer.task_id} (${timer.project})`;
+}
+
+// Routing and refresh
+
+function route() {
+  const match = location.hash.match(/^#\/project\/(.+)$/);
+  return match ? decodeURIComponent(match[1]) : "";
+}
+
+function renderNav(current) {
+  const nav = document.getElementById("projects");
+  nav.replaceChildren(
+    el("a", { href: "#/", class: current === "" ? "active" : "" }, "Overview"),
+    projects.map((p) => el("a", { href: "#/project/" + encodeURIComponent(p.name), class: p.name === current ? "active" : "" }, p.name)));
+}
+
+async function refresh() {
+  const current = route();
+  const main = document.getElementById("main");
+  try {
+    const [list, active, statuses, project] = await Promise.all([
+      api("projects"),
+      api("timer"),
+      api("statuses"),
+      current ? api("project", { name: current }) : Promise.resolve(null),
+    ]);
+    projects = list;
+    timer = active;
+    STATUSES = statuses.map((s) => s.name);
+
+    const content = document.createDocumentFragment();
+    if (project) renderProject(content, project);
+    else renderOverview(content);
+    main.replaceChildren(content);
+    renderNav(current);
+    renderTimer();
+    document.getElementById("updated").textContent = "Updated " + new Date().toLocaleTimeString();
+  } catch (err) {
+    main.replaceChildren(el("div", { class: "panel error" }, "Failed to load: " + err.message));
+  }
+}
+
+if (token) document.getElementById("calendar").href = "qix.ics?token=" + encodeURIComponent(token);
+window.addEventListener("hashchange", refresh);
+setInterval(refresh, REFRESH_MS);
+setInterval(renderTimer, 1000);
+refresh();
@@ -0,0 +1,23 @@
+// Package dashboard holds the web dashboard 'serve web' ships: a single page
+// that reads the server's JSON API and shows projects, a kanban board, the
+// running timer and charts.
+package dashboard
+
+import (
+	"embed"
+	"io/fs"
+	"net/http"
+)
+
+//go:embed assets
+var assets embed.FS
+
+// Handler serves the dashboard's page, script and styles
+func Handler() http.Handler {
+	files, err := fs.Sub(assets, "assets")
+	if err != nil {
+		// The directory is embedded at build time
+		panic(err)
+	}
+	return http.FileServer(http.FS(files))
+}
@@ -0,0 +1,303 @@
+// Package encryption encrypts QIX data files at rest with AES-256-GCM.
+//
+// The key is derived from a passphrase (set directly or read from a key file) with
+// scrypt. Every encrypted file starts with a magic header followed by the scrypt salt
+// and the GCM nonce, so files written with different salts can still be read, and
+// plaintext files written before encryption was enabled keep working.
+package encryption
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"golang.org/x/crypto/scrypt"
+)
+
+const (
+	saltSize = 16
+	keySize  = 32
+
+	// scrypt parameters recommended for interactive use
+	scryptN = 1 << 15
+	scryptR = 8
+	scryptP = 1
+)
+
+// magic marks an encrypted file; the trailing digit is the format version
+var magic = []byte("QIXENC1\n")
+
+// linePrefix marks an encrypted line in line-oriented files such as the journal
+const linePrefix = "qixenc:"
+
+// ErrNoKey is returned when reading an encrypted file without a passphrase configured
+var ErrNoKey = errors.New("data is encrypted but no passphrase is configured (set encryption_passphrase, encryption_key_file or QIX_PASSPHRASE)")
+
+// Key seals and opens data with one passphrase. Derived keys are cached per salt, so
+// each salt costs one scrypt run.
+type Key struct {
+	mu         sync.Mutex
+	passphrase []byte
+	writeSalt  []byte            // Salt used for everything sealed with this key
+	derived    map[string][]byte // Derived keys by salt
+}
+
+// NewKey returns a key for a passphrase
+func NewKey(passphrase string) *Key {
+	return &Key{passphrase: []byte(passphrase), derived: make(map[string][]byte)}
+}
+
+var (
+	mu         sync.Mutex
+	defaultKey *Key // Key for data at rest, nil if encryption is disabled
+	source     string
+	dirKeys    = make(map[string]*Key) // Keys of data directories not using defaultKey
+)
+
+// Init sets the passphrase from the configuration. A key file takes precedence over a
+// passphrase; if neither is set, encryption is disabled.
+func Init(configPassphrase, keyFile string) error {
+	key, keySource, err := LoadKey(configPassphrase, keyFile)
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	defaultKey, source = key, keySource
+	return err
+}
+
+// LoadKey returns the key for a passphrase or key file, the key file taking precedence,
+// and where it comes from. The key is nil if neither is set.
+func LoadKey(passphrase, keyFile string) (*Key, string, error) {
+	switch {
+	case keyFile != "":
+		data, err := os.ReadFile(keyFile)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to read encryption key file: %w", err)
+		}
+		key := strings.TrimSpace(string(data))
+		if key == "" {
+			return nil, "", fmt.Errorf("encryption key file %s is empty", keyFile)
+		}
+		return NewKey(key), "key file " + keyFile, nil
+	case passphrase != "":
+		return NewKey(passphrase), "passphrase", nil
+	}
+	return nil, "", nil
+}
+
+// SetDirKey sets the key for the files under dir, in place of the one set by Init. A
+// nil key leaves them unencrypted.
+func SetDirKey(dir string, key *Key) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	dirKeys[filepath.Clean(dir)] = key
+}
+
+// KeyFor returns the key for a file: that of the innermost directory given to
+// SetDirKey holding it, else the one set by Init. It is nil if the file isn't encrypted.
+func KeyFor(path string) *Key {
+	mu.Lock()
+	defer mu.Unlock()
+
+	for dir := filepath.Clean(path); ; dir = filepath.Dir(dir) {
+		if key, ok := dirKeys[dir]; ok {
+			return key
+		}
+		if parent := filepath.Dir(dir); parent == dir {
+			return defaultKey
+		}
+	}
+}
+
+// current returns the key for data at rest, or nil if encryption is disabled
+func current() *Key {
+	mu.Lock()
+	defer mu.Unlock()
+
+	return defaultKey
+}
+
+// Enabled reports whether new data is written encrypted
+func Enabled() bool {
+	return current() != nil
+}
+
+// Source describes where the key comes from, or "" if encryption is disabled
+func Source() string {
+	mu.Lock()
+	defer mu.Unlock()
+
+	return source
+}
+
+// IsEncrypted reports whether data was produced by Encrypt
+func IsEncrypted(data []byte) bool {
+	return bytes.HasPrefix(data, magic)
+}
+
+// Encrypt seals data if encryption is enabled and returns it unchanged otherwise
+func Encrypt(data []byte) ([]byte, error) {
+	return current().Encrypt(data)
+}
+
+// Seal encrypts data with the configured passphrase
+func Seal(data []byte) ([]byte, error) {
+	key := current()
+	if key == nil {
+		return nil, ErrNoKey
+	}
+	return key.Seal(data)
+}
+
+// Decrypt opens data written by Encrypt. Plaintext data is returned unchanged.
+func Decrypt(data []byte) ([]byte, error) {
+	return current().Decrypt(data)
+}
+
+// Encrypt seals data with this key, or returns it unchanged if the key is nil
+func (k *Key) Encrypt(data []byte) ([]byte, error) {
+	if k == nil {
+		return data, nil
+	}
+	return k.Seal(data)
+}
+
+// Decrypt opens data sealed with this key. Plaintext data is returned unchanged;
+// encrypted data can't be opened with a nil key.
+func (k *Key) Decrypt(data []byte) ([]byte, error) {
+	if !IsEncrypted(data) {
+		return data, nil
+	}
+	if k == nil {
+		return nil, ErrNoKey
+	}
+	return k.Open(data)
+}
+
+// Seal encrypts data with this key
+func (k *Key) Seal(data []byte) ([]byte, error) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	if k.writeSalt == nil {
+		k.writeSalt = make([]byte, saltSize)
+		if _, err := rand.Read(k.writeSalt); err != nil {
+			return nil, err
+		}
+	}
+
+	gcm, err := k.cipherFor(k.writeSalt)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+
+	out := make([]byte, 0, len(magic)+saltSize+len(nonce)+len(data)+gcm.Overhead())
+	out = append(out, magic...)
+	out = append(out, k.writeSalt...)
+	out = append(out, nonce...)
+	return gcm.Seal(out, nonce, data, magic), nil
+}
+
+// Open decrypts data sealed with this key. Plaintext data is returned unchanged.
+func (k *Key) Open(data []byte) ([]byte, error) {
+	if !IsEncrypted(data) {
+		return data, nil
+	}
+
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	body := data[len(magic):]
+	if len(body) < saltSize {
+		return nil, fmt.Errorf("encrypted data is truncated")
+	}
+	salt, body := body[:saltSize], body[saltSize:]
+
+	gcm, err := k.cipherFor(salt)
+	if err != nil {
+		return nil, err
+	}
+	if len(body) < gcm.NonceSize() {
+		return nil, fmt.Errorf("encrypted data is truncated")
+	}
+	nonce, sealed := body[:gcm.NonceSize()], body[gcm.NonceSize():]
+
+	plain, err := gcm.Open(nil, nonce, sealed, magic)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt data (wrong passphrase or corrupted file)")
+	}
+	return plain, nil
+}
+
+// IsEncryptedLine reports whether a line (or the first line of data) was produced by EncryptLine
+func IsEncryptedLine(line []byte) bool {
+	return bytes.HasPrefix(line, []byte(linePrefix))
+}
+
+// EncryptLine seals one line of a line-oriented file as printable text, if encryption is enabled
+func EncryptLine(line []byte) ([]byte, error) {
+	return current().EncryptLine(line)
+}
+
+// DecryptLine opens a line written by EncryptLine. Plaintext lines are returned unchanged.
+func DecryptLine(line []byte) ([]byte, error) {
+	return current().DecryptLine(line)
+}
+
+// EncryptLine seals one line as printable text with this key, or returns it unchanged
+// if the key is nil
+func (k *Key) EncryptLine(line []byte) ([]byte, error) {
+	if k == nil {
+		return line, nil
+	}
+	sealed, err := k.Seal(line)
+	if err != nil {
+		return nil, err
+	}
+	return []byte(linePrefix + base64.StdEncoding.EncodeToString(sealed)), nil
+}
+
+// DecryptLine opens a line sealed by EncryptLine. Plaintext lines are returned unchanged.
+func (k *Key) DecryptLine(line []byte) ([]byte, error) {
+	if !IsEncryptedLine(line) {
+		return line, nil
+	}
+	sealed, err := base64.StdEncoding.DecodeString(string(line[len(linePrefix):]))
+	if err != nil {
+		return nil, err
+	}
+	return k.Decrypt(sealed)
+}
+
+// cipherFor returns the AES-GCM cipher for a salt, deriving its key on first use. Callers hold k.mu.
+func (k *Key) cipherFor(salt []byte) (cipher.AEAD, error) {
+	key, ok := k.derived[string(salt)]
+	if !ok {
+		var err error
+		key, err = scrypt.Key(k.passphrase, salt, scryptN, scryptR, scryptP, keySize)
+		if err != nil {
+			return nil, err
+		}
+		k.derived[string(salt)] = key
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,100 @@
+package events
+
+import (
+	"github.com/mrbooshehri/qix-go/internal/notify"
+	"github.com/mrbooshehri/qix-go/pkg/qix/models"
+)
+
+// NewTaskInfo describes a task of a project, in module if not empty, for the
+// events about it
+func NewTaskInfo(projectName, moduleName string, task models.Task) notify.TaskInfo {
+	return notify.TaskInfo{
+		ID:        task.ID,
+		Title:     task.Title,
+		Project:   projectName,
+		Module:    moduleName,
+		Status:    string(task.Status),
+		Priority:  string(task.Priority),
+		Assignee:  task.Assignee,
+		JiraIssue: task.JiraIssue,
+		Tags:      task.Tags,
+		Hours:     task.CalculateActualHours(),
+	}
+}
+
+// NewSprintInfo sums up a sprint of project: its tasks, those done, and the
+// hours logged on them during the sprint
+func NewSprintInfo(project *models.Project, sprint models.Sprint) notify.SprintInfo {
+	info := notify.SprintInfo{
+		Name:      sprint.Name,
+		StartDate: sprint.StartDate,
+		EndDate:   sprint.EndDate,
+		Tasks:     len(sprint.TaskIDs),
+	}
+	tasks := make(map[string]models.Task)
+	for _, task := range project.GetAllTasks() {
+		tasks[task.ID] = task
+	}
+	for _, taskID := range sprint.TaskIDs {
+		task, ok := tasks[taskID]
+		if !ok {
+			continue
+		}
+		if task.Status.IsDone() {
+			info.Done++
+		}
+		for _, entry := range task.TimeEntries {
+			if entry.Date >= sprint.StartDate && entry.Date <= sprint.EndDate {
+				info.Hours += entry.Hours
+			}
+		}
+	}
+	return info
+}
+
+// FromJournal returns the events of task changes recorded in the journal. A
+// status change is published as task-status, followed by task-started,
+// task-done or task-blocked for those statuses.
+func FromJournal(changes []models.JournalEntry) []Event {
+	evts := make([]Event, 0, len(changes))
+	for _, change := range changes {
+		task := change.After
+		if task == nil {
+			task = change.Before
+		}
+		if task == nil {
+			continue
+		}
+		info := NewTaskInfo(change.Project, change.Module, *task)
+		if change.Before != nil && change.After != nil && change.Before.Status != change.After.Status {
+			info.Previous = string(change.Before.Status)
+		}
+		event := Event{At: change.At, Project: change.Project, Task: &info}
+
+		switch change.Op {
+		case models.OpTaskCreated:
+			event.Type = TaskCreated
+		case models.OpTaskRemoved:
+			event.Type = TaskRemoved
+		case models.OpTimeLogged:
+			event.Type = TimeLogged
+		case models.OpStatusChanged:
+			event.Type = TaskStatus
+			evts = append(evts, event)
+			switch {
+			case task.Status == models.StatusDoing:
+				event.Type = TaskStarted
+			case task.Status.IsDone():
+				event.Type = TaskDone
+			case task.Status == models.StatusBlocked:
+				event.Type = TaskBlocked
+			default:
+				continue
+			}
+		default:
+			event.Type = TaskUpdated
+		}
+		evts = append(evts, event)
+	}
+	return evts
+}
@@ -0,0 +1,342 @@
+// Package events is the bus qix publishes its changes to. Subscribers such as
+// webhooks, hook scripts and log files get the events their filters take, so
+// every integration hears about changes the same way.
+package events
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mrbooshehri/qix-go/internal/notify"
+)
+
+// Event types. Those with a notification are named by the notify package.
+const (
+	ProjectCreated = "project-created"
+	ProjectRemoved = "project-removed"
+	ModuleCreated  = "module-created"
+	ModuleRemoved  = "module-removed"
+	TaskCreated    = "task-created"
+	TaskUpdated    = "task-updated" // Changed other than its status or time
+	TaskStatus     = "task-status"  // Any status change
+	TaskStarted    = "task-started" // Status changed to doing
+	TaskDone       = notify.TaskDone
+	TaskBlocked    = notify.TaskBlocked
+	TaskRemoved    = "task-removed"
+	TimeLogged     = "time-logged"
+	TrackStart     = "track-start"
+	TrackStop      = "track-stop"
+	SprintCreated  = "sprint-created"
+	SprintClosed   = notify.SprintClosed
+	SprintRemoved  = "sprint-removed"
+	DailySummary   = notify.DailySummary
+)
+
+// Types lists the events published on the bus
+var Types = []string{
+	ProjectCreated, ProjectRemoved, ModuleCreated, ModuleRemoved,
+	TaskCreated, TaskUpdated, TaskStatus, TaskStarted, TaskDone, TaskBlocked,
+	TaskRemoved, TimeLogged, TrackStart, TrackStop,
+	SprintCreated, SprintClosed, SprintRemoved, DailySummary,
+}
+
+// Known reports whether typ is an event published on the bus
+func Known(typ string) bool {
+	for _, t := range Types {
+		if t == typ {
+			return true
+		}
+	}
+	return false
+}
+
+// Event is a change made in qix. It is what subscribers get as JSON.
+type Event struct {
+	Type    string              `json:"event"`
+	At      time.Time           `json:"at"`
+	Project string              `json:"project,omitempty"`
+	Module  string              `json:"module,omitempty"` // Set for module events
+	Task    *notify.TaskInfo    `json:"task,omitempty"`
+	Sprint  *notify.SprintInfo  `json:"sprint,omitempty"`
+	Session *Session            `json:"session,omitempty"`
+	Summary *notify.SummaryInfo `json:"summary,omitempty"`
+}
+
+// Session is the time tracked in track-start and track-stop events
+type Session struct {
+	Path  string     `json:"path"` // project or project/module
+	Start time.Time  `json:"start"`
+	End   *time.Time `json:"end,omitempty"`   // Set when stopped
+	Hours float64    `json:"hours,omitempty"` // Logged when stopped
+}
+
+// Subscriber is somewhere events are delivered
+type Subscriber interface {
+	// Name identifies the subscriber in errors and listings
+	Name() string
+
+	// Deliver hands an event to the subscriber
+	Deliver(ctx context.Context, event Event) error
+}
+
+// Filter picks the events a subscriber gets. Each list that isn't empty must
+// match: Events by type, or by prefix when it ends in *, Projects by project,
+// and Tags and Statuses by the task the event is about.
+type Filter struct {
+	Events   []string
+	Projects []string
+	Tags     []string
+	Statuses []string
+}
+
+// Match reports whether the filter takes an event
+func (f Filter) Match(event Event) bool {
+	if len(f.Events) > 0 && !matchType(f.Events, event.Type) {
+		return false
+	}
+	if len(f.Projects) > 0 && !contains(f.Projects, event.Project) {
+		return false
+	}
+	if len(f.Statuses) > 0 && (event.Task == nil || !contains(f.Statuses, event.Task.Status)) {
+		return false
+	}
+	if len(f.Tags) > 0 {
+		if event.Task == nil {
+			return false
+		}
+		for _, tag := range event.Task.Tags {
+			if contains(f.Tags, tag) {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+// String describes the filter, such as "events=task-done project=web"
+func (f Filter) String() string {
+	parts := make([]string, 0, 4)
+	for _, part := range []struct {
+		name   string
+		values []string
+	}{{"events", f.Events}, {"projects", f.Projects}, {"tags", f.Tags}, {"status", f.Statuses}} {
+		if len(part.values) > 0 {
+			parts = append(parts, part.name+"="+strings.Join(part.values, ","))
+		}
+	}
+	if len(parts) == 0 {
+		return "all"
+	}
+	return strings.Join(parts, " ")
+}
+
+func matchType(patterns []string, typ string) bool {
+	for _, pattern := range patterns {
+		if pattern == "*" || pattern == typ || strings.HasSuffix(pattern, "*") && strings.HasPrefix(typ, strings.TrimSuffix(pattern, "*")) {
+			return true
+		}
+	}
+	return false
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// Subscription is a subscriber with the filter of the events it gets
+type Subscription struct {
+	Subscriber Subscriber
+	Filter     Filter
+}
+
+// Bus delivers published events to its subscribers
+type Bus struct {
+	subscriptions []Subscription
+}
+
+// Subscribe adds a subscriber that gets the events filter takes
+func (b *Bus) Subscribe(subscriber Subscriber, filter Filter) {
+	b.subscriptions = append(b.subscriptions, Subscription{Subscriber: subscriber, Filter: filter})
+}
+
+// Subscriptions returns the subscribers in the order they get events
+func (b *Bus) Subscriptions() []Subscription {
+	return b.subscriptions
+}
+
+// Publish delivers events in order to each subscriber that takes them, and
+// returns an error for each delivery that failed. A failing subscriber doesn't
+// keep the others from getting an event.
+func (b *Bus) Publish(ctx context.Context, events ...Event) []error {
+	errs := make([]error, 0)
+	for _, event := range events {
+		if event.At.IsZero() {
+			event.At = time.Now()
+		}
+		for _, sub := range b.subscriptions {
+			if !sub.Filter.Match(event) {
+				continue
+			}
+			if err := sub.Subscriber.Deliver(ctx, event); err != nil {
+				errs = append(errs, fmt.Errorf("%s: %s: %w", sub.Subscriber.Name(), event.Type, err))
+			}
+		}
+	}
+	return errs
+}
+
+// Webhook posts events as JSON to a URL
+type Webhook struct {
+	name   string
+	url    string
+	client *http.Client
+}
+
+// NewWebhook returns a subscriber posting events to url
+func NewWebhook(name, url string) (*Webhook, error) {
+	if !strings.HasPrefix(url, "http://") && !strings.HasPrefix(url, "https://") {
+		return nil, fmt.Errorf("not an http(s) URL: %s", url)
+	}
+	return &Webhook{name: name, url: url, client: &http.Client{Timeout: 10 * time.Second}}, nil
+}
+
+// Name returns the webhook's name
+func (w *Webhook) Name() string {
+	return w.name
+}
+
+// Deliver posts the event
+func (w *Webhook) Deliver(ctx context.Context, event Event) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.url, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "qix")
+	req.Header.Set("X-Qix-Event", event.Type)
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		if text := strings.TrimSpace(string(msg)); text != "" {
+			return fmt.Errorf("%s: %s", resp.Status, text)
+		}
+		return errors.New(resp.Status)
+	}
+	return nil
+}
+
+// Log appends events to a file, one JSON object per line
+type Log struct {
+	name string
+	path string
+	mu   sync.Mutex
+}
+
+// NewLog returns a subscriber appending events to the file at path
+func NewLog(name, path string) *Log {
+	return &Log{name: name, path: path}
+}
+
+// Name returns the log's name
+func (l *Log) Name() string {
+	return l.name
+}
+
+// Deliver appends the event to the file
+func (l *Log) Deliver(ctx context.Context, event Event) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	file, err := os.OpenFile(l.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	if _, err := file.Write(append(data, '\n')); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// Notifications sends the events that have a notification message, such as
+// task-done and daily-summary, to webhooks as Slack, Discord or JSON messages
+type Notifications struct {
+	name     string
+	notifier *notify.Notifier
+}
+
+// NewNotifications returns a subscriber sending notifications with notifier
+func NewNotifications(name string, notifier *notify.Notifier) *Notifications {
+	return &Notifications{name: name, notifier: notifier}
+}
+
+// Name returns the subscriber's name
+func (n *Notifications) Name() string {
+	return n.name
+}
+
+// Notifies reports whether events of a type have a notification message
+func Notifies(typ string) bool {
+	for _, event := range notify.Events {
+		if event == typ {
+			return true
+		}
+	}
+	return false
+}
+
+// Wants reports whether a webhook takes events of a type
+func (n *Notifications) Wants(typ string) bool {
+	if !Notifies(typ) {
+		return false
+	}
+	for _, hook := range n.notifier.Hooks() {
+		if hook.Wants(typ) {
+			return true
+		}
+	}
+	return false
+}
+
+// Deliver sends the event's notification, if it has one
+func (n *Notifications) Deliver(ctx context.Context, event Event) error {
+	if !Notifies(event.Type) {
+		return nil
+	}
+	return errors.Join(n.notifier.Send(ctx, notify.Event{
+		Type:    event.Type,
+		At:      event.At,
+		Project: event.Project,
+		Task:    event.Task,
+		Sprint:  event.Sprint,
+		Summary: event.Summary,
+	})...)
+}
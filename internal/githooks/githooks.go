@@ -0,0 +1,278 @@
+// Package githooks ties work in a code repository to qix tasks: it installs
+// hooks that add the tracked task to commit messages and record commits on the
+// task they name, and creates branches for tasks.
+package githooks
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// TaskTrailer is the commit message trailer naming the task a commit is for,
+// as <project>/<task_id>
+const TaskTrailer = "Qix-Task"
+
+// JiraTrailer is the commit message trailer naming the task's Jira issue
+const JiraTrailer = "Jira"
+
+// marker identifies hooks written by qix, so they can be replaced and removed
+const marker = "# Installed by qix"
+
+// Hooks are the hooks Install writes, by name
+var Hooks = []string{"prepare-commit-msg", "commit-msg", "post-commit"}
+
+// ErrHookExists is returned by Install when a hook not written by qix is in
+// the way
+var ErrHookExists = errors.New("hook exists and was not installed by qix")
+
+// Repo is a git working tree
+type Repo struct {
+	dir string
+}
+
+// Open returns the repository dir is in
+func Open(dir string) (*Repo, error) {
+	top, err := git(dir, "rev-parse", "--show-toplevel")
+	if err != nil {
+		return nil, fmt.Errorf("not a git repository: %s", dir)
+	}
+	return &Repo{dir: top}, nil
+}
+
+// Dir returns the root of the working tree
+func (r *Repo) Dir() string {
+	return r.dir
+}
+
+// HooksDir returns the directory git runs hooks from, which core.hooksPath may
+// move out of .git/hooks
+func (r *Repo) HooksDir() (string, error) {
+	dir, err := git(r.dir, "rev-parse", "--git-path", "hooks")
+	if err != nil {
+		return "", err
+	}
+	if !filepath.IsAbs(dir) {
+		dir = filepath.Join(r.dir, dir)
+	}
+	return dir, nil
+}
+
+// Install writes the hooks, calling qix at qixPath. A hook that qix did not
+// write is only replaced with force.
+func (r *Repo) Install(qixPath string, force bool) ([]string, error) {
+	dir, err := r.HooksDir()
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+
+	paths := make([]string, 0, len(Hooks))
+	for _, name := range Hooks {
+		path := filepath.Join(dir, name)
+		if !force && !ownHook(path) {
+			return paths, fmt.Errorf("%s: %w (use --force to replace it)", path, ErrHookExists)
+		}
+		if err := os.WriteFile(path, []byte(script(name, qixPath)), 0755); err != nil {
+			return paths, err
+		}
+		paths = append(paths, path)
+	}
+	return paths, nil
+}
+
+// Uninstall removes the hooks qix wrote, leaving others alone
+func (r *Repo) Uninstall() ([]string, error) {
+	dir, err := r.HooksDir()
+	if err != nil {
+		return nil, err
+	}
+
+	removed := make([]string, 0, len(Hooks))
+	for _, name := range Hooks {
+		path := filepath.Join(dir, name)
+		data, err := os.ReadFile(path)
+		if err != nil || !bytes.Contains(data, []byte(marker)) {
+			continue
+		}
+		if err := os.Remove(path); err != nil {
+			return removed, err
+		}
+		removed = append(removed, path)
+	}
+	return removed, nil
+}
+
+// ownHook reports whether the hook at path is missing or was written by qix
+func ownHook(path string) bool {
+	data, err := os.ReadFile(path)
+	return err != nil || bytes.Contains(data, []byte(marker))
+}
+
+// script returns a hook that hands its arguments to 'qix git hook'. It never
+// fails the commit: a missing qix or a qix error only skips the linking.
+func script(name, qixPath string) string {
+	return fmt.Sprintf(`#!/bin/sh
+%s; 'qix git uninstall-hooks' removes this hook
+QIX=%s
+[ -x "$QIX" ] || QIX=qix
+command -v "$QIX" >/dev/null 2>&1 || exit 0
+"$QIX" git hook %s "$@" || true
+`, marker, shellQuote(qixPath), name)
+}
+
+// shellQuote quotes s for a POSIX shell
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// AddTrailers adds "Key: value" trailers to the commit message in file,
+// keeping git's comment lines below them. Trailers already in the message are
+// kept as they are. In a message still to be written, a blank line is left
+// between the subject line and the trailers.
+func (r *Repo) AddTrailers(file string, trailers ...string) error {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return err
+	}
+	empty := len(r.messageLines(string(data))) == 0
+
+	args := []string{"interpret-trailers", "--in-place", "--if-exists", "doNothing"}
+	for _, trailer := range trailers {
+		args = append(args, "--trailer", trailer)
+	}
+	args = append(args, file)
+	if _, err := git(r.dir, args...); err != nil {
+		return err
+	}
+	if !empty {
+		return nil
+	}
+
+	data, err = os.ReadFile(file)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(file, append([]byte("\n"), data...), 0644)
+}
+
+// DropLoneTrailers removes the task and Jira trailers from the commit message
+// in file if nothing else was written, so git aborts the commit for an empty
+// message as it would without them
+func (r *Repo) DropLoneTrailers(file string) error {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return err
+	}
+	for _, line := range r.messageLines(string(data)) {
+		key, _, _ := strings.Cut(line, ":")
+		if key != TaskTrailer && key != JiraTrailer {
+			return nil
+		}
+	}
+
+	kept := make([]string, 0)
+	for _, line := range strings.Split(string(data), "\n") {
+		key, _, _ := strings.Cut(line, ":")
+		if key != TaskTrailer && key != JiraTrailer {
+			kept = append(kept, line)
+		}
+	}
+	return os.WriteFile(file, []byte(strings.Join(kept, "\n")), 0644)
+}
+
+// messageLines returns the non-blank lines of a commit message that are not
+// comments
+func (r *Repo) messageLines(msg string) []string {
+	comment := "#"
+	if char, err := git(r.dir, "config", "core.commentChar"); err == nil && char != "" && char != "auto" {
+		comment = char
+	}
+
+	lines := make([]string, 0)
+	for _, line := range strings.Split(msg, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, comment) {
+			continue
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// CheckBranchName returns an error if git does not accept name for a branch
+func (r *Repo) CheckBranchName(name string) error {
+	if _, err := git(r.dir, "check-ref-format", "--branch", name); err != nil {
+		return fmt.Errorf("not a valid branch name: %s", name)
+	}
+	return nil
+}
+
+// HasBranch reports whether a local branch called name exists
+func (r *Repo) HasBranch(name string) bool {
+	_, err := git(r.dir, "rev-parse", "--verify", "--quiet", "refs/heads/"+name)
+	return err == nil
+}
+
+// CreateBranch creates a branch from HEAD, switching to it if checkout is set
+func (r *Repo) CreateBranch(name string, checkout bool) error {
+	if checkout {
+		_, err := git(r.dir, "checkout", "-b", name)
+		return err
+	}
+	_, err := git(r.dir, "branch", name)
+	return err
+}
+
+// Commit is a commit with the trailers of its message
+type Commit struct {
+	Hash     string
+	Subject  string
+	At       time.Time
+	Trailers map[string]string
+}
+
+// Head returns the commit HEAD points at
+func (r *Repo) Head() (*Commit, error) {
+	out, err := git(r.dir, "log", "-1", "--format=%H%x00%s%x00%cI%x00%(trailers:only,unfold)")
+	if err != nil {
+		return nil, err
+	}
+
+	parts := strings.SplitN(out, "\x00", 4)
+	if len(parts) < 4 {
+		return nil, fmt.Errorf("unexpected git log output: %q", out)
+	}
+	commit := &Commit{Hash: parts[0], Subject: parts[1], Trailers: make(map[string]string)}
+	commit.At, _ = time.Parse(time.RFC3339, parts[2])
+	for _, line := range strings.Split(parts[3], "\n") {
+		if key, value, ok := strings.Cut(line, ":"); ok {
+			commit.Trailers[strings.TrimSpace(key)] = strings.TrimSpace(value)
+		}
+	}
+	return commit, nil
+}
+
+// git runs a git command in dir and returns its trimmed output
+func git(dir string, args ...string) (string, error) {
+	cmd := exec.Command("git", append([]string{"-C", dir}, args...)...)
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		msg := strings.TrimSpace(stderr.String())
+		if msg == "" {
+			msg = err.Error()
+		}
+		return "", fmt.Errorf("git %s: %s", args[0], msg)
+	}
+	return strings.TrimSpace(stdout.String()), nil
+}
@@ -0,0 +1,288 @@
+// Package gitsync keeps the data directory in a git repository, so every change is
+// versioned and machines can share data through an ordinary git remote. It drives the
+// system git binary, so credentials, SSH keys and helpers work as they do for the
+// user's own repositories.
+package gitsync
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// ErrNotRepo is returned when the data directory is not a git repository yet
+var ErrNotRepo = errors.New("data directory is not a git repository (run: qix sync git init)")
+
+// ErrNoRemote is returned by Push and Pull when the repository has no origin remote
+var ErrNoRemote = errors.New("no remote configured (run: qix sync git init <url>)")
+
+// gitignore keeps machine-local files and secrets out of the repository
+const gitignore = `# Managed by qix
+config
+backups/
+locks/
+usage.json
+*.log
+*.tmp
+*.lock
+qix.db*
+`
+
+// gitattributes turns off git's line-based merging of data files; qix merges them
+// itself, task by task, when both sides changed a file
+const gitattributes = `# Managed by qix
+*.json -merge
+*.jsonl -merge
+*.gz -merge
+*.sha256 -merge
+`
+
+// Resolver merges one conflicted file given the common ancestor's contents (nil if
+// the file was added on both sides) and both sides' contents
+type Resolver func(rel string, base, ours, theirs []byte) ([]byte, error)
+
+// PullResult describes what a pull did
+type PullResult struct {
+	UpToDate bool     // Nothing new on the remote
+	Resolved []string // Files changed on both sides and merged by the resolver
+}
+
+// Repo is a data directory managed as a git repository
+type Repo struct {
+	dir          string
+	identityArgs []string
+	identityOnce sync.Once
+}
+
+// Open returns the repository for a data directory
+func Open(dir string) (*Repo, error) {
+	if _, err := exec.LookPath("git"); err != nil {
+		return nil, fmt.Errorf("git is not installed or not in PATH")
+	}
+	return &Repo{dir: dir}, nil
+}
+
+// IsRepo reports whether the data directory has been initialized as a repository
+func (r *Repo) IsRepo() bool {
+	info, err := os.Stat(filepath.Join(r.dir, ".git"))
+	return err == nil && info.IsDir()
+}
+
+// Init makes the data directory a repository, commits the current data and, if
+// remoteURL is given, sets it as the origin remote. Running it on an existing
+// repository only updates the remote.
+func (r *Repo) Init(remoteURL string) error {
+	if !r.IsRepo() {
+		if _, err := r.git("init", "-q"); err != nil {
+			return err
+		}
+	}
+
+	for name, content := range map[string]string{".gitignore": gitignore, ".gitattributes": gitattributes} {
+		path := filepath.Join(r.dir, name)
+		if _, err := os.Stat(path); os.IsNotExist(err) {
+			if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+				return err
+			}
+		}
+	}
+
+	if remoteURL != "" {
+		if _, err := r.git("remote", "get-url", "origin"); err == nil {
+			_, err = r.git("remote", "set-url", "origin", remoteURL)
+			if err != nil {
+				return err
+			}
+		} else if _, err := r.git("remote", "add", "origin", remoteURL); err != nil {
+			return err
+		}
+	}
+
+	_, err := r.Commit("Initialize qix data")
+	return err
+}
+
+// Commit records all changes in the data directory, reporting whether there was anything to commit
+func (r *Repo) Commit(message string) (bool, error) {
+	if !r.IsRepo() {
+		return false, ErrNotRepo
+	}
+	if _, err := r.git("add", "-A"); err != nil {
+		return false, err
+	}
+	status, err := r.git("status", "--porcelain")
+	if err != nil {
+		return false, err
+	}
+	if strings.TrimSpace(status) == "" {
+		return false, nil
+	}
+	if _, err := r.git("commit", "-q", "-m", message); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// Remote returns the URL of the origin remote
+func (r *Repo) Remote() (string, error) {
+	if !r.IsRepo() {
+		return "", ErrNotRepo
+	}
+	url, err := r.git("remote", "get-url", "origin")
+	if err != nil {
+		return "", ErrNoRemote
+	}
+	return strings.TrimSpace(url), nil
+}
+
+// Push commits any pending changes and pushes the current branch to origin
+func (r *Repo) Push() error {
+	if _, err := r.Remote(); err != nil {
+		return err
+	}
+	if _, err := r.Commit("Local changes"); err != nil {
+		return err
+	}
+	_, err := r.git("push", "-q", "-u", "origin", "HEAD")
+	return err
+}
+
+// Pull commits any pending changes, fetches origin and merges its copy of the current
+// branch. Files changed on both sides are merged by resolve; afterwards fixup (if not
+// nil) runs before the merge is committed, to repair derived files such as indexes.
+// If any file can't be resolved, the merge is aborted and the data left as it was.
+func (r *Repo) Pull(resolve Resolver, fixup func() error) (*PullResult, error) {
+	if _, err := r.Remote(); err != nil {
+		return nil, err
+	}
+	if _, err := r.Commit("Local changes"); err != nil {
+		return nil, err
+	}
+	if _, err := r.git("fetch", "-q", "origin"); err != nil {
+		return nil, err
+	}
+
+	branch, err := r.git("rev-parse", "--abbrev-ref", "HEAD")
+	if err != nil {
+		return nil, err
+	}
+	upstream := "origin/" + strings.TrimSpace(branch)
+	if _, err := r.git("rev-parse", "--verify", "-q", upstream); err != nil {
+		// Nothing has been pushed to this branch yet
+		return &PullResult{UpToDate: true}, nil
+	}
+
+	head, _ := r.git("rev-parse", "HEAD")
+	_, mergeErr := r.git("merge", "-q", "--no-edit", "--allow-unrelated-histories", upstream)
+	if mergeErr == nil {
+		after, _ := r.git("rev-parse", "HEAD")
+		return &PullResult{UpToDate: head == after}, nil
+	}
+
+	conflicted, err := r.git("diff", "--name-only", "-z", "--diff-filter=U")
+	if err != nil || conflicted == "" {
+		r.git("merge", "--abort")
+		return nil, mergeErr
+	}
+
+	result := &PullResult{}
+	abort := func(err error) (*PullResult, error) {
+		r.git("merge", "--abort")
+		return nil, err
+	}
+
+	for _, rel := range strings.Split(strings.TrimRight(conflicted, "\x00"), "\x00") {
+		base := r.stage(1, rel)
+		ours := r.stage(2, rel)
+		theirs := r.stage(3, rel)
+
+		var merged []byte
+		switch {
+		case ours == nil:
+			// Deleted here, changed there: keep their changes
+			merged = theirs
+		case theirs == nil:
+			merged = ours
+		default:
+			merged, err = resolve(rel, base, ours, theirs)
+			if err != nil {
+				return abort(fmt.Errorf("cannot merge %s: %w", rel, err))
+			}
+			result.Resolved = append(result.Resolved, rel)
+		}
+
+		if err := os.WriteFile(filepath.Join(r.dir, filepath.FromSlash(rel)), merged, 0600); err != nil {
+			return abort(err)
+		}
+		if _, err := r.git("add", "--", rel); err != nil {
+			return abort(err)
+		}
+	}
+
+	if fixup != nil {
+		if err := fixup(); err != nil {
+			return abort(err)
+		}
+	}
+	if _, err := r.git("add", "-A"); err != nil {
+		return abort(err)
+	}
+	if _, err := r.git("commit", "-q", "--no-edit"); err != nil {
+		return abort(err)
+	}
+	return result, nil
+}
+
+// stage returns a conflicted file's contents at a merge stage (1 base, 2 ours,
+// 3 theirs), or nil if the file doesn't exist at that stage
+func (r *Repo) stage(n int, rel string) []byte {
+	data, err := r.gitBytes("show", fmt.Sprintf(":%d:%s", n, rel))
+	if err != nil {
+		return nil
+	}
+	return data
+}
+
+// git runs a git command in the data directory and returns its output
+func (r *Repo) git(args ...string) (string, error) {
+	out, err := r.gitBytes(args...)
+	return string(out), err
+}
+
+// gitBytes runs a git command in the data directory and returns its raw output
+func (r *Repo) gitBytes(args ...string) ([]byte, error) {
+	cmdArgs := append([]string{"-C", r.dir}, r.identity()...)
+	cmd := exec.Command("git", append(cmdArgs, args...)...)
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		msg := strings.TrimSpace(stderr.String())
+		if msg == "" {
+			msg = strings.TrimSpace(stdout.String())
+		}
+		if msg == "" {
+			msg = err.Error()
+		}
+		return nil, fmt.Errorf("git %s: %s", args[0], msg)
+	}
+	return stdout.Bytes(), nil
+}
+
+// identity supplies a committer identity when git has none configured, so
+// automatic commits work on machines where git was never set up
+func (r *Repo) identity() []string {
+	r.identityOnce.Do(func() {
+		out, err := exec.Command("git", "-C", r.dir, "config", "user.email").Output()
+		if err != nil || strings.TrimSpace(string(out)) == "" {
+			r.identityArgs = []string{"-c", "user.name=qix", "-c", "user.email=qix@localhost"}
+		}
+	})
+	return r.identityArgs
+}
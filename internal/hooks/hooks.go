@@ -0,0 +1,178 @@
+// Package hooks runs the user's executables in the hooks directory when qix
+// events happen, such as a task being done or a timer stopping, so any
+// automation can be wired to them.
+package hooks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mrbooshehri/qix-go/internal/events"
+)
+
+// EnvHook is set to the event in the environment of hooks. qix commands run
+// with it set don't run hooks, so hooks can't trigger each other in a loop.
+const EnvHook = "QIX_HOOK"
+
+// Runner runs the hooks in a directory
+type Runner struct {
+	Dir     string
+	Timeout time.Duration // How long a hook may run; unlimited if 0
+	Output  io.Writer     // Where hook output goes
+}
+
+// Scripts returns the hooks of an event: the executable <dir>/<event>, then
+// the executables in <dir>/<event>.d in name order
+func (r Runner) Scripts(event string) []string {
+	scripts := make([]string, 0)
+	if path := filepath.Join(r.Dir, event); executable(path) {
+		scripts = append(scripts, path)
+	}
+
+	entries, err := os.ReadDir(filepath.Join(r.Dir, event+".d"))
+	if err != nil {
+		return scripts
+	}
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		// Leave out hidden files and editor backups
+		if name := entry.Name(); !strings.HasPrefix(name, ".") && !strings.HasSuffix(name, "~") {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if path := filepath.Join(r.Dir, event+".d", name); executable(path) {
+			scripts = append(scripts, path)
+		}
+	}
+	return scripts
+}
+
+// executable reports whether path is a file that can be run
+func executable(path string) bool {
+	info, err := os.Stat(path)
+	if err != nil || !info.Mode().IsRegular() {
+		return false
+	}
+	return runtime.GOOS == "windows" || info.Mode().Perm()&0111 != 0
+}
+
+// Name returns the hooks directory
+func (r Runner) Name() string {
+	return "hooks"
+}
+
+// Deliver runs the hooks of an event, so the directory can subscribe to the
+// event bus
+func (r Runner) Deliver(ctx context.Context, event events.Event) error {
+	return errors.Join(r.Run(ctx, event)...)
+}
+
+// Run runs the hooks of an event one after another, and returns an error for
+// each hook that failed
+func (r Runner) Run(ctx context.Context, event events.Event) []error {
+	scripts := r.Scripts(event.Type)
+	if len(scripts) == 0 {
+		return nil
+	}
+	data, env, err := input(event)
+	if err != nil {
+		return []error{err}
+	}
+
+	errs := make([]error, 0)
+	for _, script := range scripts {
+		if err := run(ctx, []string{script}, r.Dir, r.Timeout, data, env, r.Output); err != nil {
+			errs = append(errs, fmt.Errorf("hook %s: %w", script, err))
+		}
+	}
+	return errs
+}
+
+// Script is one executable run on every event it subscribes to
+type Script struct {
+	Path    string
+	Timeout time.Duration // How long it may run; unlimited if 0
+	Output  io.Writer     // Where its output goes
+}
+
+// Name returns the script's path
+func (s Script) Name() string {
+	return s.Path
+}
+
+// Deliver runs the script with the event
+func (s Script) Deliver(ctx context.Context, event events.Event) error {
+	data, env, err := input(event)
+	if err != nil {
+		return err
+	}
+	return run(ctx, []string{s.Path}, filepath.Dir(s.Path), s.Timeout, data, env, s.Output)
+}
+
+// Shell runs a command line with the shell, sh or cmd on Windows, in dir with
+// env as its environment
+func Shell(ctx context.Context, line, dir string, timeout time.Duration, env []string, output io.Writer) error {
+	argv := []string{"sh", "-c", line}
+	if runtime.GOOS == "windows" {
+		argv = []string{"cmd", "/C", line}
+	}
+	return run(ctx, argv, dir, timeout, nil, env, output)
+}
+
+// input returns what a hook gets for an event: the event as JSON on standard
+// input, and its type, project and task in QIX_EVENT, QIX_PROJECT, QIX_TASK_ID
+// and QIX_TASK_TITLE
+func input(event events.Event) ([]byte, []string, error) {
+	if event.At.IsZero() {
+		event.At = time.Now()
+	}
+	data, err := json.Marshal(event)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	env := append(os.Environ(),
+		EnvHook+"="+event.Type,
+		"QIX_EVENT="+event.Type,
+		"QIX_PROJECT="+event.Project,
+	)
+	if event.Task != nil {
+		env = append(env, "QIX_TASK_ID="+event.Task.ID, "QIX_TASK_TITLE="+event.Task.Title)
+	}
+	return data, env, nil
+}
+
+// run runs argv in dir with input on standard input, for up to timeout if it
+// isn't 0
+func run(ctx context.Context, argv []string, dir string, timeout time.Duration, input []byte, env []string, output io.Writer) error {
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	cmd := exec.CommandContext(ctx, argv[0], argv[1:]...)
+	cmd.Dir = dir
+	cmd.Env = env
+	cmd.Stdin = bytes.NewReader(input)
+	cmd.Stdout = output
+	cmd.Stderr = output
+	err := cmd.Run()
+	if ctx.Err() == context.DeadlineExceeded {
+		return fmt.Errorf("timed out after %s", timeout)
+	}
+	return err
+}
@@ -0,0 +1,696 @@
+package i18n
+
+// german is the German catalog
+var german = map[string]string{
+	// Months and weekdays
+	"January": "Januar", "February": "Februar", "March": "März", "April": "April",
+	"May": "Mai", "June": "Juni", "July": "Juli", "August": "August",
+	"September": "September", "October": "Oktober", "November": "November", "December": "Dezember",
+	"Jan": "Jan", "Feb": "Feb", "Mar": "Mär", "Apr": "Apr", "Jun": "Jun", "Jul": "Jul",
+	"Aug": "Aug", "Sep": "Sep", "Oct": "Okt", "Nov": "Nov", "Dec": "Dez",
+	"Monday": "Montag", "Tuesday": "Dienstag", "Wednesday": "Mittwoch", "Thursday": "Donnerstag",
+	"Friday": "Freitag", "Saturday": "Samstag", "Sunday": "Sonntag",
+	"Mon": "Mo", "Tue": "Di", "Wed": "Mi", "Thu": "Do", "Fri": "Fr", "Sat": "Sa", "Sun": "So",
+
+	// Relative dates
+	"just now": "gerade eben", "today": "heute", "yesterday": "gestern", "tomorrow": "morgen",
+	"1 minute ago": "vor 1 Minute", "%d minutes ago": "vor %d Minuten", "in 1 minute": "in 1 Minute", "in %d minutes": "in %d Minuten",
+	"1 hour ago": "vor 1 Stunde", "%d hours ago": "vor %d Stunden", "in 1 hour": "in 1 Stunde", "in %d hours": "in %d Stunden",
+	"1 day ago": "vor 1 Tag", "%d days ago": "vor %d Tagen", "in 1 day": "in 1 Tag", "in %d days": "in %d Tagen",
+	"1 week ago": "vor 1 Woche", "%d weeks ago": "vor %d Wochen", "in 1 week": "in 1 Woche", "in %d weeks": "in %d Wochen",
+	"1 month ago": "vor 1 Monat", "%d months ago": "vor %d Monaten", "in 1 month": "in 1 Monat", "in %d months": "in %d Monaten",
+	"1 year ago": "vor 1 Jahr", "%d years ago": "vor %d Jahren", "in 1 year": "in 1 Jahr", "in %d years": "in %d Jahren",
+
+	// Prompts
+	"Type '%s' to confirm:": "Zum Bestätigen '%s' eingeben:",
+	"(y/N):":                "(j/N):",
+	"y":                     "j",
+	"yes":                   "ja",
+	"↑/↓ choose, enter accept, esc cancel":           "↑/↓ wählen, Enter übernehmen, Esc abbrechen",
+	"↑/↓ move, space tick, enter accept, esc cancel": "↑/↓ bewegen, Leertaste ankreuzen, Enter übernehmen, Esc abbrechen",
+	"Choose one of: %s":                              "Eine Auswahl treffen: %s",
+	"Unknown choice '%s'. Choose from: %s":           "Unbekannte Auswahl '%s'. Möglich sind: %s",
+
+	// Watch mode
+	"Every %s, updated %s. Ctrl+C to quit.": "Alle %s, aktualisiert %s. Strg+C beendet.",
+
+	// Board
+	"←/→ move  enter drop  esc put back":                              "←/→ verschieben  Enter ablegen  Esc zurücklegen",
+	"←/→ column  ↑/↓ task  enter pick up  < > move  r reload  q quit": "←/→ Spalte  ↑/↓ Aufgabe  Enter aufnehmen  < > verschieben  r neu laden  q beenden",
+	"Failed to move %s: %v":                                           "%s konnte nicht verschoben werden: %v",
+	"Moved %s to %s":                                                  "%s nach %s verschoben",
+	"Reloaded":                                                        "Neu geladen",
+
+	// Tasks and projects
+	"%s   ⏱️  Est: %s | Act: %s":        "%s   ⏱️  Gesch.: %s | Ist: %s",
+	" | +%s over":                       " | +%s darüber",
+	" | %s under":                       " | %s darunter",
+	"%s   📅 Overdue: %s\n":              "%s   📅 Überfällig: %s\n",
+	"%s   📅 Due: %s\n":                  "%s   📅 Fällig: %s\n",
+	"Details":                           "Details",
+	"⏱️  Time Tracking":                 "⏱️  Zeiterfassung",
+	"📅 Time Entries":                    "📅 Zeiteinträge",
+	"🔁 Recurrence":                      "🔁 Wiederholung",
+	"🔗 Dependencies":                    "🔗 Abhängigkeiten",
+	"👨‍👩‍👧 Hierarchy":                   "👨‍👩‍👧 Hierarchie",
+	"Parent: %s":                        "Übergeordnet: %s",
+	"🏷️  Tags":                          "🏷️  Tags",
+	"🔀 Commits":                         "🔀 Commits",
+	"Timestamps":                        "Zeitstempel",
+	"Created: %s":                       "Erstellt:     %s",
+	"Updated: %s":                       "Geändert:     %s",
+	"📍 Location":                        "📍 Ort",
+	"ID:          %s":                   "ID:           %s",
+	"Status:      %s %s":                "Status:       %s %s",
+	"Priority:    %s %s":                "Priorität:    %s %s",
+	"Assignee:    %s":                   "Zuständig:    %s",
+	"Due:         %s":                   "Fällig:       %s",
+	"Jira Issue:  %s":                   "Jira-Issue:   %s",
+	"Jira Title:  %s":                   "Jira-Titel:   %s",
+	"Jira Status: %s":                   "Jira-Status:  %s",
+	"Jira Update: %s":                   "Jira-Stand:   %s",
+	"differs from task":                 "weicht von der Aufgabe ab",
+	"Branch:      %s":                   "Branch:       %s",
+	"Description: %s":                   "Beschreibung: %s",
+	"Estimated:  %s":                    "Geschätzt:   %s",
+	"Actual:     %s":                    "Tatsächlich: %s",
+	"Variance:   %s":                    "Abweichung:  %s",
+	"+%s (%.1f%% over)":                 "+%s (%.1f%% darüber)",
+	"%s (%.1f%% under)":                 "%s (%.1f%% darunter)",
+	"Variance:   On target":             "Abweichung:  Im Plan",
+	"Pattern:    %s":                    "Muster:       %s",
+	"Pattern:    %s (%s)":               "Muster:       %s (%s)",
+	"Next Due:   %s":                    "Nächste:      %s",
+	"Last Done:  %s":                    "Zuletzt:      %s",
+	"→ Depends on: %s":                  "→ Hängt ab von: %s",
+	"📊 Tasks: %d total\n":               "📊 Aufgaben: %d insgesamt\n",
+	"⏱️  Time:":                         "⏱️  Zeit:",
+	"   Estimated: %s\n":                "   Geschätzt:   %s\n",
+	"   Actual:    %s\n":                "   Tatsächlich: %s\n",
+	"   Variance:  +%s (%.1f%% over)\n": "   Abweichung:  +%s (%.1f%% darüber)\n",
+	"   Variance:  %s (%.1f%% under)\n": "   Abweichung:  %s (%.1f%% darunter)\n",
+	"📈 Completion: ":                    "📈 Fortschritt: ",
+	"📦 Modules: %d\n":                   "📦 Module: %d\n",
+	"🏃 Sprints: %d\n":                   "🏃 Sprints: %d\n",
+	"   Tasks: %d\n":                    "   Aufgaben: %d\n",
+	"   Progress: ":                     "   Fortschritt: ",
+
+	// Table headers
+	"Accuracy":      "Genauigkeit",
+	"Actual":        "Tatsächlich",
+	"Age":           "Alter",
+	"Alias":         "Alias",
+	"Average":       "Durchschnitt",
+	"Backup":        "Sicherung",
+	"Bar":           "Balken",
+	"Blocked":       "Blockiert",
+	"Blocked Tasks": "Blockierte Aufgaben",
+	"Change":        "Änderung",
+	"Command":       "Befehl",
+	"Command hook":  "Befehls-Hook",
+	"Commands":      "Befehle",
+	"Completed":     "Erledigt",
+	"Count":         "Anzahl",
+	"Cron":          "Cron",
+	"Date":          "Datum",
+	"Dates":         "Daten",
+	"Day":           "Tag",
+	"Days":          "Tage",
+	"Deleted":       "Gelöscht",
+	"Directory":     "Verzeichnis",
+	"Doing":         "In Arbeit",
+	"Done":          "Erledigt",
+	"Due":           "Fällig",
+	"End":           "Ende",
+	"Estimated":     "Geschätzt",
+	"Events":        "Ereignisse",
+	"Expires":       "Läuft ab",
+	"Failed":        "Fehlgeschlagen",
+	"Format":        "Format",
+	"From":          "Von",
+	"Holds Up":      "Hält auf",
+	"Host":          "Host",
+	"Hours Held":    "Stunden blockiert",
+	"Hours":         "Stunden",
+	"ID":            "ID",
+	"Item":          "Eintrag",
+	"Jira":          "Jira",
+	"Jira status":   "Jira-Status",
+	"Kind":          "Art",
+	"Last Run":      "Letzter Lauf",
+	"Last run":      "Letzter Lauf",
+	"Max":           "Max",
+	"Metric":        "Kennzahl",
+	"Metrics won":   "Gewonnene Kennzahlen",
+	"Name":          "Name",
+	"Next Run":      "Nächster Lauf",
+	"Operation":     "Vorgang",
+	"Per op":        "Pro Vorgang",
+	"Percentage":    "Anteil",
+	"Priority":      "Priorität",
+	"Profile":       "Profil",
+	"Project":       "Projekt",
+	"Projects":      "Projekte",
+	"Rank":          "Rang",
+	"Rate":          "Satz",
+	"Runs":          "Läufe",
+	"Scenario":      "Szenario",
+	"Segment":       "Abschnitt",
+	"Share":         "Anteil",
+	"Size":          "Größe",
+	"Start":         "Beginn",
+	"Started":       "Begonnen",
+	"State":         "Zustand",
+	"Status":        "Status",
+	"Target":        "Ziel",
+	"Task":          "Aufgabe",
+	"Tasks":         "Aufgaben",
+	"Todo":          "Offen",
+	"Total":         "Gesamt",
+	"Type":          "Typ",
+	"Value":         "Wert",
+	"Variance":      "Abweichung",
+	"Velocity":      "Geschwindigkeit",
+	"Week":          "Woche",
+	"When":          "Wann",
+	"Who":           "Wer",
+
+	// Messages
+	"%d Toggl entry(s) match no task; map them with --map <tag or text>=<task ID>":    "%d Toggl-Einträge passen zu keiner Aufgabe; mit --map <Tag oder Text>=<Aufgaben-ID> zuordnen",
+	"%d data file(s) use an older schema (run: qix migrate)":                          "%d Datendatei(en) verwenden ein älteres Schema (ausführen: qix migrate)",
+	"%d file(s) need migration (dry run, nothing changed)":                            "%d Datei(en) müssen migriert werden (Probelauf, nichts geändert)",
+	"%d file(s) use an older schema and will be upgraded when loaded":                 "%d Datei(en) verwenden ein älteres Schema und werden beim Laden aktualisiert",
+	"%d issue(s) and %d warning(s) found":                                             "%d Problem(e) und %d Warnung(en) gefunden",
+	"%d journaled change(s) were never confirmed as saved (run: qix journal recover)": "%d protokollierte Änderung(en) wurden nie als gespeichert bestätigt (ausführen: qix journal recover)",
+	"%d open task(s) have no estimate and are not included":                           "%d offene Aufgabe(n) ohne Schätzung sind nicht enthalten",
+	"%d tasks assigned to sprint":                                                     "%d Aufgaben dem Sprint zugewiesen",
+	"%d task(s) disagree with Jira":                                                   "%d Aufgabe(n) weichen von Jira ab",
+	"%d tasks updated":                                                                "%d Aufgaben aktualisiert",
+	"%d warning(s) found (non-critical)":                                              "%d Warnung(en) gefunden (unkritisch)",
+	"%s %s has schema %d, newer than this qix supports (%d)":                          "%s %s hat Schema %d, neuer als von diesem qix unterstützt (%d)",
+	"%s is not set in %s":                                                             "%s ist in %s nicht gesetzt",
+	"%s is set in the environment, which takes precedence":                            "%s ist in der Umgebung gesetzt und hat Vorrang",
+	"%s is up to date (%d notes)":                                                     "%s ist aktuell (%d Notizen)",
+	"%v. Check 'jira_user' and 'jira_token' in %s.":                                   "%v. 'jira_user' und 'jira_token' in %s prüfen.",
+	"%v. Check 'toggl_api_token' in %s.":                                              "%v. 'toggl_api_token' in %s prüfen.",
+	"%v. Check the calendar settings in %s.":                                          "%v. Kalendereinstellungen in %s prüfen.",
+	"'%s' has no JSON output":                                                         "'%s' hat keine JSON-Ausgabe",
+	"'%s' has no dry run":                                                             "'%s' hat keinen Probelauf",
+	"--days must be at least 1":                                                       "--days muss mindestens 1 sein",
+	"--last must be at least 1":                                                       "--last muss mindestens 1 sein",
+	"--project needs an export of one project; this one has %d":                       "--project braucht den Export eines einzelnen Projekts; dieser enthält %d",
+	"--projects and --tasks must be at least 1":                                       "--projects und --tasks müssen mindestens 1 sein",
+	"--sprint requires a project":                                                     "--sprint erfordert ein Projekt",
+	"--weeks must be at least 1":                                                      "--weeks muss mindestens 1 sein",
+	"A query is required: --jql \"project = KEY\"":                                    "Eine Abfrage ist erforderlich: --jql \"project = KEY\"",
+	"A schedule is required: --cron \"<min> <hour> <dom> <month> <dow>\"":             "Ein Zeitplan ist erforderlich: --cron \"<min> <hour> <dom> <month> <dow>\"",
+	"Add alias.<name> = <command line> to %s":                                         "Füge alias.<name> = <Befehlszeile> zu %s hinzu",
+	"Add one to the config file: webhook.<name> = <url>":                              "In der Konfigurationsdatei anlegen: webhook.<name> = <url>",
+	"Add one with: qix report schedule add \"<report>\" --cron \"<expr>\"":            "Anlegen mit: qix report schedule add \"<report>\" --cron \"<expr>\"",
+	"All %d data file(s) are up to date":                                              "Alle %d Datendatei(en) sind aktuell",
+	"All archives are readable":                                                       "Alle Archive sind lesbar",
+	"All checks passed! Your QIX installation is healthy. ✨":                          "Alle Prüfungen bestanden! Ihre QIX-Installation ist in Ordnung. ✨",
+	"Already tracking task: %s":                                                       "Zeiterfassung läuft bereits für Aufgabe: %s",
+	"Already up to date":                                                              "Bereits aktuell",
+	"Backup can be restored, with %d warning(s)":                                      "Sicherung kann wiederhergestellt werden, mit %d Warnung(en)",
+	"Backup created":                                                                  "Sicherung erstellt",
+	"Backup created: %s":                                                              "Sicherung erstellt: %s",
+	"Backup exported":                                                                 "Sicherung exportiert",
+	"Backup file not found: %s":                                                       "Sicherungsdatei nicht gefunden: %s",
+	"Backup has no task index; it will be rebuilt after restoring":                    "Die Sicherung hat keinen Aufgabenindex; er wird nach der Wiederherstellung neu aufgebaut",
+	"Backup is intact and can be restored":                                            "Die Sicherung ist intakt und kann wiederhergestellt werden",
+	"Backup restored successfully":                                                    "Sicherung erfolgreich wiederhergestellt",
+	"Backup uploaded to %s":                                                           "Sicherung hochgeladen nach %s",
+	"Benchmark failed: %v":                                                            "Benchmark fehlgeschlagen: %v",
+	"Branch %s already exists":                                                        "Branch %s existiert bereits",
+	"Budget cleared for '%s'":                                                         "Budget für '%s' entfernt",
+	"Budget for '%s' set to %s":                                                       "Budget für '%s' auf %s gesetzt",
+	"Built-in commands win over aliases of the same name; never used: %s":             "Eingebaute Befehle haben Vorrang vor gleichnamigen Aliasen; nie verwendet: %s",
+	"Cached projects: %v (limit %d)":                                                  "Zwischengespeicherte Projekte: %v (Grenze %d)",
+	"Cached projects: %v (unlimited)":                                                 "Zwischengespeicherte Projekte: %v (unbegrenzt)",
+	"Cancelled":                                                                       "Abgebrochen",
+	"Cannot connect to Jira: %v. Set 'jira_base_url' and 'jira_token' in %s.":         "Keine Verbindung zu Jira: %v. 'jira_base_url' und 'jira_token' in %s setzen.",
+	"Cannot connect to Toggl: %v. Set 'toggl_api_token' in %s.":                       "Keine Verbindung zu Toggl: %v. 'toggl_api_token' in %s setzen.",
+	"Capacity cannot be negative":                                                     "Die Kapazität darf nicht negativ sein",
+	"Changed on both machines, kept the local version: %s":                            "Auf beiden Rechnern geändert, lokale Version behalten: %s",
+	"Changes to tasks are journaled as they are made":                                 "Änderungen an Aufgaben werden beim Vornehmen protokolliert",
+	"Checked %d pending journal entries":                                              "%d ausstehende Protokolleinträge geprüft",
+	"Checksum mismatch: %s":                                                           "Prüfsumme stimmt nicht: %s",
+	"Checksum mismatch: %s (corrupted, or edited outside qix; if intended, run: qix doctor --rehash)": "Prüfsumme stimmt nicht: %s (beschädigt oder außerhalb von qix bearbeitet; falls beabsichtigt, ausführen: qix doctor --rehash)",
+	"Child task not found: %v":                                               "Unteraufgabe nicht gefunden: %v",
+	"Cleaning up old backups (retention: %d days)...":                        "Alte Sicherungen werden aufgeräumt (Aufbewahrung: %d Tage)...",
+	"Command hook %s failed: %v":                                             "Befehls-Hook %s fehlgeschlagen: %v",
+	"Corrupted project: %s (%v)":                                             "Beschädigtes Projekt: %s (%v)",
+	"Could not load task details":                                            "Aufgabendetails konnten nicht geladen werden",
+	"Create one with: qix backup create":                                     "Erstellen mit: qix backup create",
+	"Create one with: qix project create <name>":                             "Erstellen mit: qix project create <name>",
+	"Created %d task(s) in %s, %d row(s) skipped":                            "%d Aufgabe(n) in %s angelegt, %d Zeile(n) übersprungen",
+	"Created %s, but failed to link the task to it: %v":                      "%s angelegt, aber die Aufgabe konnte nicht verknüpft werden: %v",
+	"Created and switched to branch %s":                                      "Branch %s angelegt und gewechselt",
+	"Created branch %s":                                                      "Branch %s angelegt",
+	"Created branch %s, but failed to record it on the task: %v":             "Branch %s angelegt, aber nicht bei der Aufgabe vermerkt: %v",
+	"Created Jira issue %s":                                                  "Jira-Issue %s angelegt",
+	"Creating backup...":                                                     "Sicherung wird erstellt...",
+	"Creating incremental backup...":                                         "Inkrementelle Sicherung wird erstellt...",
+	"Creating safety backup of current data...":                              "Sicherheitskopie der aktuellen Daten wird erstellt...",
+	"Data directory is already a git repository":                             "Das Datenverzeichnis ist bereits ein Git-Repository",
+	"Deadline cleared for '%s'":                                              "Frist für '%s' entfernt",
+	"Deadline for '%s' set to %s":                                            "Frist für '%s' auf %s gesetzt",
+	"Decrypted %d file(s)":                                                   "%d Datei(en) entschlüsselt",
+	"Deleted tasks, modules and projects appear here":                        "Gelöschte Aufgaben, Module und Projekte erscheinen hier",
+	"Deletion cancelled":                                                     "Löschen abgebrochen",
+	"Dependency added":                                                       "Abhängigkeit hinzugefügt",
+	"Dependency task not found: %v":                                          "Abhängige Aufgabe nicht gefunden: %v",
+	"Directory exists: %s":                                                   "Verzeichnis vorhanden: %s",
+	"Directory missing: %s":                                                  "Verzeichnis fehlt: %s",
+	"Dirty projects:  %v":                                                    "Geänderte Projekte:  %v",
+	"Downloading from %s...":                                                 "Herunterladen von %s...",
+	"Dry run: %d event(s) would be created, %d updated and %d deleted in %s": "Probelauf: %d Termine würden erstellt, %d aktualisiert und %d gelöscht in %s",
+	"Dry run: %d task(s) would be created, %d row(s) skipped":                "Probelauf: %d Aufgabe(n) würden angelegt, %d Zeile(n) übersprungen",
+	"Dry run: %d time entry(s) would be pulled and %d pushed":                "Probelauf: %d Zeiteinträge würden geholt und %d übertragen",
+	"Dry run: nothing was restored":                                          "Probelauf: nichts wurde wiederhergestellt",
+	"Dry run: nothing was saved":                                             "Probelauf: nichts wurde gespeichert",
+	"Dry run: nothing would change":                                          "Probelauf: nichts würde sich ändern",
+	"Dry run: project %s would be created with %d module(s) and %d task(s)":  "Probelauf: Projekt %s würde mit %d Modul(en) und %d Aufgabe(n) angelegt",
+	"Editor failed: %v":                                                      "Editor fehlgeschlagen: %v",
+	"Encrypted %d file(s)":                                                   "%d Datei(en) verschlüsselt",
+	"Encryption disabled":                                                    "Verschlüsselung deaktiviert",
+	"Encryption enabled (key from %s)":                                       "Verschlüsselung aktiviert (Schlüssel aus %s)",
+	"Dry run: %d task(s) would be created":                                   "Probelauf: %d Aufgabe(n) würden angelegt",
+	"Dry run: %d time entry(s) would be pushed":                              "Probelauf: %d Zeiteintrag/-einträge würden übertragen",
+	"End date must be after start date":                                      "Das Enddatum muss nach dem Startdatum liegen",
+	"Evictions:       %v":                                                    "Verdrängungen:   %v",
+	"Exported %d task(s) to %s":                                              "%d Aufgabe(n) nach %s exportiert",
+	"Exported %s to %s: %d written, %d removed, %d unchanged":                "%s nach %s exportiert: %d geschrieben, %d entfernt, %d unverändert",
+	"Exporting backup...":                                                    "Sicherung wird exportiert...",
+	"Failed after rewriting %d file(s): %v":                                  "Fehlgeschlagen nach dem Umschreiben von %d Datei(en): %v",
+	"Failed to add dependency: %v":                                           "Abhängigkeit konnte nicht hinzugefügt werden: %v",
+	"Failed to change the task ID: %v":                                       "Die Aufgaben-ID konnte nicht geändert werden: %v",
+	"Failed to check task IDs: %v":                                           "Aufgaben-IDs konnten nicht geprüft werden: %v",
+	"Failed to check tracking status: %v":                                    "Status der Zeiterfassung konnte nicht geprüft werden: %v",
+	"Failed to cleanup backups: %v":                                          "Sicherungen konnten nicht aufgeräumt werden: %v",
+	"Failed to cleanup old backups: %v":                                      "Alte Sicherungen konnten nicht aufgeräumt werden: %v",
+	"Failed to complete task: %v":                                            "Aufgabe konnte nicht abgeschlossen werden: %v",
+	"Failed to copy report to clipboard: %v":                                 "Bericht konnte nicht in die Zwischenablage kopiert werden: %v",
+	"Failed to create %s: %v":                                                "%s konnte nicht erstellt werden: %v",
+	"Failed to create Jira issue: %v":                                        "Jira-Issue konnte nicht angelegt werden: %v",
+	"Failed to close sprint: %v":                                             "Sprint konnte nicht abgeschlossen werden: %v",
+	"Failed to create backup, nothing migrated: %v":                          "Sicherung konnte nicht erstellt werden, nichts migriert: %v",
+	"Failed to create backup: %v":                                            "Sicherung konnte nicht erstellt werden: %v",
+	"Failed to create benchmark directory: %v":                               "Benchmark-Verzeichnis konnte nicht erstellt werden: %v",
+	"Failed to create branch: %v":                                            "Branch konnte nicht angelegt werden: %v",
+	"Failed to create module: %v":                                            "Modul konnte nicht erstellt werden: %v",
+	"Failed to create project: %v":                                           "Projekt konnte nicht erstellt werden: %v",
+	"Failed to create safety backup: %v":                                     "Sicherheitskopie konnte nicht erstellt werden: %v",
+	"Failed to create sprint: %v":                                            "Sprint konnte nicht erstellt werden: %v",
+	"Failed to create task: %v":                                              "Aufgabe konnte nicht erstellt werden: %v",
+	"Failed to delete project: %v":                                           "Projekt konnte nicht gelöscht werden: %v",
+	"Failed to encode JSON: %v":                                              "JSON konnte nicht erzeugt werden: %v",
+	"Failed to export %s: %v":                                                "Export von %s fehlgeschlagen: %v",
+	"Failed to export backup: %v":                                            "Sicherung konnte nicht exportiert werden: %v",
+	"Failed to export tasks: %v":                                             "Aufgaben konnten nicht exportiert werden: %v",
+	"Failed to follow %s: %v":                                                "Verfolgen von %s fehlgeschlagen: %v",
+	"Failed to gather task details: %v":                                      "Aufgabendetails konnten nicht erfasst werden: %v",
+	"Failed to get backup info: %v":                                          "Sicherungsinformationen konnten nicht gelesen werden: %v",
+	"Failed to get session: %v":                                              "Sitzung konnte nicht gelesen werden: %v",
+	"Failed to get time entries: %v":                                         "Zeiteinträge konnten nicht gelesen werden: %v",
+	"Failed to import %s: %v":                                                "%s konnte nicht importiert werden: %v",
+	"Failed to initialize repository: %v":                                    "Repository konnte nicht angelegt werden: %v",
+	"Failed to install hooks: %v":                                            "Hooks konnten nicht installiert werden: %v",
+	"Failed to link tasks: %v":                                               "Aufgaben konnten nicht verknüpft werden: %v",
+	"Failed to list backups: %v":                                             "Sicherungen konnten nicht aufgelistet werden: %v",
+	"Failed to list projects: %v":                                            "Projekte konnten nicht aufgelistet werden: %v",
+	"Failed to list remote backups: %v":                                      "Entfernte Sicherungen konnten nicht aufgelistet werden: %v",
+	"Failed to load backup projects: %v":                                     "Projekte der Sicherung konnten nicht geladen werden: %v",
+	"Failed to load project %s: %v":                                          "Projekt %s konnte nicht geladen werden: %v",
+	"Failed to load projects: %v":                                            "Projekte konnten nicht geladen werden: %v",
+	"Failed to listen on %s: %v":                                             "Lauschen auf %s fehlgeschlagen: %v",
+	"Failed to locate qix executable: %v":                                    "Das qix-Programm wurde nicht gefunden: %v",
+	"Failed to log time: %v":                                                 "Zeit konnte nicht erfasst werden: %v",
+	"Failed to migrate %s %s: %v":                                            "%s %s konnte nicht migriert werden: %v",
+	"Failed to move current project to trash: %v":                            "Aktuelles Projekt konnte nicht in den Papierkorb verschoben werden: %v",
+	"Failed to open %s: %v":                                                  "%s konnte nicht geöffnet werden: %v",
+	"Failed to open Jira issue: %v":                                          "Jira-Issue konnte nicht geöffnet werden: %v",
+	"Failed to purge trash: %v":                                              "Papierkorb konnte nicht geleert werden: %v",
+	"Failed to reach Toggl: %v":                                              "Toggl nicht erreichbar: %v",
+	"Failed to read %s: %v":                                                  "%s konnte nicht gelesen werden: %v",
+	"Failed to read aliases: %v":                                             "Aliase konnten nicht gelesen werden: %v",
+	"Failed to read backup: %v":                                              "Sicherung konnte nicht gelesen werden: %v",
+	"Failed to read data files: %v":                                          "Datendateien konnten nicht gelesen werden: %v",
+	"Failed to read journal: %v":                                             "Protokoll konnte nicht gelesen werden: %v",
+	"Failed to read the keyboard: %v":                                        "Tastatur konnte nicht gelesen werden: %v",
+	"Failed to read trash: %v":                                               "Papierkorb konnte nicht gelesen werden: %v",
+	"Failed to rebuild index: %v":                                            "Index konnte nicht neu aufgebaut werden: %v",
+	"Failed to record pushed worklogs: %v":                                   "Übertragene Worklogs konnten nicht vermerkt werden: %v",
+	"Failed to record schedule runs: %v":                                     "Läufe des Zeitplans konnten nicht gespeichert werden: %v",
+	"Failed to remove hooks: %v":                                             "Hooks konnten nicht entfernt werden: %v",
+	"Failed to remove module: %v":                                            "Modul konnte nicht entfernt werden: %v",
+	"Failed to remove recurrence: %v":                                        "Wiederholung konnte nicht entfernt werden: %v",
+	"Failed to remove schedule: %v":                                          "Zeitplan konnte nicht entfernt werden: %v",
+	"Failed to remove sprint: %v":                                            "Sprint konnte nicht entfernt werden: %v",
+	"Failed to remove task: %v":                                              "Aufgabe konnte nicht entfernt werden: %v",
+	"Failed to remove usage statistics: %v":                                  "Entfernen der Nutzungsstatistiken fehlgeschlagen: %v",
+	"Failed to restore backup: %v":                                           "Sicherung konnte nicht wiederhergestellt werden: %v",
+	"Failed to restore project: %v":                                          "Projekt konnte nicht wiederhergestellt werden: %v",
+	"Failed to restore: %v":                                                  "Wiederherstellen fehlgeschlagen: %v",
+	"Failed to save %s: %v":                                                  "%s konnte nicht gespeichert werden: %v",
+	"Failed to save Jira details: %v":                                        "Jira-Angaben konnten nicht gespeichert werden: %v",
+	"Failed to save all changes: %v":                                         "Nicht alle Änderungen konnten gespeichert werden: %v",
+	"Failed to save calendar sync state: %v":                                 "Kalender-Synchronisationsstand konnte nicht gespeichert werden: %v",
+	"Failed to save pending changes: %v":                                     "Ausstehende Änderungen konnten nicht gespeichert werden: %v",
+	"Failed to save schedule: %v":                                            "Zeitplan konnte nicht gespeichert werden: %v",
+	"Failed to save synced time entries: %v":                                 "Synchronisierte Zeiteinträge konnten nicht gespeichert werden: %v",
+	"Failed to send the digest: %v":                                          "Senden der Zusammenfassung fehlgeschlagen: %v",
+	"Failed to set recurrence: %v":                                           "Wiederholung konnte nicht gesetzt werden: %v",
+	"Failed to start tracking: %v":                                           "Zeiterfassung konnte nicht gestartet werden: %v",
+	"Failed to stop current session: %v":                                     "Laufende Sitzung konnte nicht beendet werden: %v",
+	"Failed to stop tracking: %v":                                            "Zeiterfassung konnte nicht beendet werden: %v",
+	"Failed to unassign task: %v":                                            "Zuweisung der Aufgabe konnte nicht aufgehoben werden: %v",
+	"Failed to update module: %v":                                            "Modul konnte nicht aktualisiert werden: %v",
+	"Failed to update project: %v":                                           "Projekt konnte nicht aktualisiert werden: %v",
+	"Failed to update task: %v":                                              "Aufgabe konnte nicht aktualisiert werden: %v",
+	"Failed to upload backup: %v":                                            "Sicherung konnte nicht hochgeladen werden: %v",
+	"Failed to write man pages: %v":                                          "Schreiben der Man-Pages fehlgeschlagen: %v",
+	"Failed to write markdown pages: %v":                                     "Schreiben der Markdown-Seiten fehlgeschlagen: %v",
+	"Failed to write report: %v":                                             "Bericht konnte nicht geschrieben werden: %v",
+	"Fixed %d time entry problem(s)":                                         "%d Problem(e) mit Zeiteinträgen behoben",
+	"Found %d issue(s) and %d warning(s); restoring this backup is not safe": "%d Problem(e) und %d Warnung(en) gefunden; diese Sicherung wiederherzustellen ist nicht sicher",
+	"Found %d project(s)":                                                    "%d Projekt(e) gefunden",
+	"From Toggl":                                                             "Von Toggl",
+	"Git sync needs the json storage backend (current: %s)":                  "Git-Synchronisierung benötigt den json-Speicher (aktuell: %s)",
+	"Hits / misses:   %v / %v (%.1f%% hit rate)":                             "Treffer / Fehler: %v / %v (%.1f%% Trefferquote)",
+	"Hours must be positive":                                                 "Die Stunden müssen positiv sein",
+	"Ignoring aliases: %v":                                                   "Aliase werden ignoriert: %v",
+	"Ignoring command hook %s: it must start with before or after":           "Befehls-Hook %s wird ignoriert: er muss mit before oder after beginnen",
+	"Ignoring custom statuses: %v":                                           "Eigene Status werden ignoriert: %v",
+	"Ignoring language: %v":                                                  "Sprache wird ignoriert: %v",
+	"Ignoring theme: %v":                                                     "Farbschema wird ignoriert: %v",
+	"Ignoring week_start: %v":                                                "week_start wird ignoriert: %v",
+	"Import under another name with --project <name>":                        "Unter anderem Namen importieren mit --project <name>",
+	"Imported %s into project %s: %d module(s), %d task(s)":                  "%s in Projekt %s importiert: %d Modul(e), %d Aufgabe(n)",
+	"Incremental backup; chain of %d archive(s) from %s":                     "Inkrementelle Sicherung; Kette aus %d Archiv(en) ab %s",
+	"Index contains %v task(s)":                                              "Der Index enthält %v Aufgabe(n)",
+	"Index error: %v":                                                        "Indexfehler: %v",
+	"Index inconsistencies found (repaired by rebuilding the index after restoring):": "Unstimmigkeiten im Index gefunden (werden durch Neuaufbau nach der Wiederherstellung behoben):",
+	"Index inconsistencies found:":                 "Unstimmigkeiten im Index gefunden:",
+	"Index is consistent":                          "Der Index ist stimmig",
+	"Index is consistent with the projects":        "Der Index stimmt mit den Projekten überein",
+	"Index is up to date":                          "Der Index ist aktuell",
+	"Index validation failed: %v":                  "Indexprüfung fehlgeschlagen: %v",
+	"Initialized git repository in %s":             "Git-Repository angelegt in %s",
+	"Installed git hooks in %s":                    "Git-Hooks installiert in %s",
+	"Interactive Task Creator":                     "Aufgabe interaktiv anlegen",
+	"Interactive Task Editor":                      "Aufgabe interaktiv bearbeiten",
+	"Imported %d issue(s) into %s":                 "%d Issue(s) in %s importiert",
+	"Invalid SOURCE_DATE_EPOCH: %v":                "Ungültiges SOURCE_DATE_EPOCH: %v",
+	"Invalid budget: %s":                           "Ungültiges Budget: %s",
+	"Invalid command: %v":                          "Ungültiger Befehl: %v",
+	"Invalid cron expression: %v":                  "Ungültiger Cron-Ausdruck: %v",
+	"Invalid date format. Use: %s":                 "Ungültiges Datumsformat. Verwenden: %s",
+	"Invalid days: %s":                             "Ungültige Tage: %s",
+	"Invalid due date format. Use: %s":             "Ungültiges Fälligkeitsdatum. Verwenden: %s",
+	"Invalid editor command: %s":                   "Ungültiger Editor-Befehl: %s",
+	"Invalid end date format. Use: %s":             "Ungültiges Enddatum. Verwenden: %s",
+	"Invalid format. Use: ascii, dot, mermaid":     "Ungültiges Format. Verwenden: ascii, dot, mermaid",
+	"Invalid format. Use: ascii, mermaid":          "Ungültiges Format. Verwenden: ascii, mermaid",
+	"Invalid format: %s (use csv, jira-csv, json)": "Ungültiges Format: %s (csv, jira-csv, json verwenden)",
+	"Invalid hours format: %s":                     "Ungültige Stundenangabe: %s",
+	"Invalid month format. Use: YYYY-MM":           "Ungültiges Monatsformat. Verwenden: JJJJ-MM",
+	"Invalid order '%s' (must be %s)":              "Ungültige Reihenfolge '%s' (erlaubt: %s)",
+	"Invalid path format. Use: <project>/<module>": "Ungültiger Pfad. Verwenden: <project>/<module>",
+	"Invalid pattern: %v":                          "Ungültiges Muster: %v",
+	"Invalid period: %s (use daily or weekly)":     "Ungültiger Zeitraum: %s (daily oder weekly verwenden)",
+	"Invalid priority. Use: %s":                    "Ungültige Priorität. Verwenden: %s",
+	"Invalid project name: %s":                     "Ungültiger Projektname: %s",
+	"Invalid rate: %s":                             "Ungültiger Satz: %s",
+	"Invalid start date format. Use: %s":           "Ungültiges Startdatum. Verwenden: %s",
+	"Invalid status. Use: %s":                      "Ungültiger Status. Verwenden: %s",
+	"Invalid week: %v":                             "Ungültige Woche: %v",
+	"Jira base URL not configured. Set 'jira_base_url' in %s or export JIRA_BASE_URL.": "Jira-Basis-URL nicht eingerichtet. 'jira_base_url' in %s setzen oder JIRA_BASE_URL exportieren.",
+	"Jira doesn't take an estimate here (%s); creating the issue without it":           "Jira nimmt hier keine Schätzung an (%s); das Issue wird ohne angelegt",
+	"Jira search failed: %v":                                                   "Jira-Suche fehlgeschlagen: %v",
+	"Link it with: qix task edit %s %s --jira-issue %s":                        "Verknüpfen mit: qix task edit %s %s --jira-issue %s",
+	"Log time with: qix track log <project> <task_id> <hours>":                 "Zeit erfassen mit: qix track log <project> <task_id> <hours>",
+	"Make a task recurring with: qix task recur <project> <task_id> <pattern>": "Aufgabe wiederkehrend machen mit: qix task recur <project> <task_id> <pattern>",
+	"Migrated %d file(s)":                                                      "%d Datei(en) migriert",
+	"Migrated %d of %d file(s)":                                                "%d von %d Datei(en) migriert",
+	"Module '%s' created in project '%s'":                                      "Modul '%s' in Projekt '%s' erstellt",
+	"Module '%s' removed from project '%s'":                                    "Modul '%s' aus Projekt '%s' entfernt",
+	"Module '%s' restored to project '%s'":                                     "Modul '%s' in Projekt '%s' wiederhergestellt",
+	"Module '%s' updated":                                                      "Modul '%s' aktualisiert",
+	"Module not found: %v":                                                     "Modul nicht gefunden: %v",
+	"Module renamed: %s → %s":                                                  "Modul umbenannt: %s → %s",
+	"No active tracking session":                                               "Keine laufende Zeiterfassung",
+	"No aliases configured":                                                    "Keine Aliase eingerichtet",
+	"No backups found":                                                         "Keine Sicherungen gefunden",
+	"No blocked tasks":                                                         "Keine blockierten Aufgaben",
+	"No commands recorded yet":                                                 "Noch keine Befehle erfasst",
+	"No differences; the backup matches your current data":                     "Keine Unterschiede; die Sicherung entspricht den aktuellen Daten",
+	"No estimated work completed in the last %d weeks; cannot forecast":        "In den letzten %d Wochen wurde keine geschätzte Arbeit erledigt; keine Prognose möglich",
+	"No estimated work remaining":                                              "Keine geschätzte Arbeit übrig",
+	"No Jira project to create the issue in. Pass --jira-project or set 'jira_project' in %s.": "Kein Jira-Projekt für das Issue. --jira-project angeben oder 'jira_project' in %s setzen.",
+	"No hooks for %s in %s": "Keine Hooks für %s in %s",
+	"No journal entries":    "Keine Protokolleinträge",
+	"No matching tasks":     "Keine passenden Aufgaben",
+	"No new issues to import (%d found, %d already linked)":                            "Keine neuen Issues zu importieren (%d gefunden, %d bereits verknüpft)",
+	"No old backups to remove":                                                         "Keine alten Sicherungen zu entfernen",
+	"No orphaned references found":                                                     "Keine verwaisten Verweise gefunden",
+	"No passphrase configured; set encryption_passphrase or encryption_key_file first": "Keine Passphrase eingerichtet; zuerst encryption_passphrase oder encryption_key_file setzen",
+	"No pending journal entries":                                                       "Keine ausstehenden Protokolleinträge",
+	"No profiles configured":                                                           "Keine Profile eingerichtet",
+	"No projects found":                                                                "Keine Projekte gefunden",
+	"No qix hooks installed in %s":                                                     "Keine qix-Hooks in %s installiert",
+	"No rates configured. Set one with: qix project rate %s <rate>":                    "Keine Sätze eingerichtet. Setzen mit: qix project rate %s <rate>",
+	"No recurring tasks":                                                               "Keine wiederkehrenden Aufgaben",
+	"No recurring tasks due today":                                                     "Heute sind keine wiederkehrenden Aufgaben fällig",
+	"No rows to import in %s":                                                          "Keine Zeilen zum Importieren in %s",
+	"No scheduled reports":                                                             "Keine geplanten Berichte",
+	"No scheduled reports due":                                                         "Keine geplanten Berichte fällig",
+	"No settings match %s":                                                             "Keine Einstellungen passen zu %s",
+	"No started sprints":                                                               "Keine begonnenen Sprints",
+	"No subscribers get %s events":                                                     "Keine Abonnenten erhalten %s-Ereignisse",
+	"No tasks assigned to this sprint":                                                 "Diesem Sprint sind keine Aufgaben zugewiesen",
+	"No tasks created, %d row(s) skipped":                                              "Keine Aufgaben angelegt, %d Zeile(n) übersprungen",
+	"No tasks to report":                                                               "Keine Aufgaben für den Bericht",
+	"No time entries to push":                                                          "Keine Zeiteinträge zu übertragen",
+	"No time entries found in this period":                                             "Keine Zeiteinträge in diesem Zeitraum gefunden",
+	"No time entries in this period":                                                   "Keine Zeiteinträge in diesem Zeitraum",
+	"No time entry problems found":                                                     "Keine Probleme mit Zeiteinträgen gefunden",
+	"No webhooks configured":                                                           "Keine Webhooks eingerichtet",
+	"Not a report command: %s":                                                         "Kein Berichtsbefehl: %s",
+	"Note: [%s] is not done yet (%s)":                                                  "Hinweis: [%s] ist noch nicht erledigt (%s)",
+	"Nothing overdue or due soon":                                                      "Nichts überfällig oder bald fällig",
+	"Nothing to import":                                                                "Nichts zu importieren",
+	"Opening Jira issue: %s":                                                           "Jira-Issue wird geöffnet: %s",
+	"Orphaned %s in %s:":                                                               "Verwaiste %s in %s:",
+	"Parent task not found: %v":                                                        "Übergeordnete Aufgabe nicht gefunden: %v",
+	"Print the end of the log, and with --follow what is logged next":                  "Das Ende des Logs ausgeben, mit --follow auch neue Einträge",
+	"Print the log":                                                                    "Das Log ausgeben",
+	"Project %s was created but is incomplete: %v":                                     "Projekt %s wurde angelegt, ist aber unvollständig: %v",
+	"Project '%s' budget: %s":                                                          "Budget von Projekt '%s': %s",
+	"Project '%s' created":                                                             "Projekt '%s' erstellt",
+	"Project '%s' deleted":                                                             "Projekt '%s' gelöscht",
+	"Project '%s' has no budget":                                                       "Projekt '%s' hat kein Budget",
+	"Project '%s' has no deadline":                                                     "Projekt '%s' hat keine Frist",
+	"Project '%s' is due %s":                                                           "Projekt '%s' ist fällig am %s",
+	"Project '%s' is not in %s":                                                        "Projekt '%s' ist nicht in %s",
+	"Project '%s' restored":                                                            "Projekt '%s' wiederhergestellt",
+	"Project '%s' restored (%d file(s))":                                               "Projekt '%s' wiederhergestellt (%d Datei(en))",
+	"Project already exists: %s":                                                       "Projekt existiert bereits: %s",
+	"Project not found: %s":                                                            "Projekt nicht gefunden: %s",
+	"Project not found: %v":                                                            "Projekt nicht gefunden: %v",
+	"Projects in this backup: %s":                                                      "Projekte in dieser Sicherung: %s",
+	"Published %s to %d subscriber(s)":                                                 "%s an %d Abonnent(en) veröffentlicht",
+	"Pull failed: %v":                                                                  "Pull fehlgeschlagen: %v",
+	"Pulled %d time entry(s) from Toggl (%s)":                                          "%d Zeiteinträge von Toggl geholt (%s)",
+	"Pulled changes from the remote":                                                   "Änderungen vom Remote geholt",
+	"Purge cancelled":                                                                  "Leeren abgebrochen",
+	"Purged %d item(s) from trash":                                                     "%d Eintrag/Einträge aus dem Papierkorb gelöscht",
+	"Push failed: %v":                                                                  "Push fehlgeschlagen: %v",
+	"Pushed %d time entry(s) to Toggl (%s)":                                            "%d Zeiteinträge an Toggl übertragen (%s)",
+	"Pushed %d worklog(s) to Jira (%s)":                                                "%d Worklog(s) an Jira übertragen (%s)",
+	"Pushed to %s":                                                                     "Nach %s übertragen",
+	"QIX Doctor - System Health Check":                                                 "QIX Doctor - Systemprüfung",
+	"QIX - Quick Insight X":                                                            "QIX - Quick Insight X",
+	"Ran %d hook(s) for %s":                                                            "%d Hook(s) für %s ausgeführt",
+	"Remove all usage statistics?":                                                     "Alle Nutzungsstatistiken entfernen?",
+	"Rewritten in Go for 100x performance improvement!":                                "In Go neu geschrieben, 100-mal schneller!",
+	"QIX directory permissions secure (700)":                                           "Berechtigungen des QIX-Verzeichnisses sicher (700)",
+	"QIX directory permissions: %o (recommended: 700)":                                 "Berechtigungen des QIX-Verzeichnisses: %o (empfohlen: 700)",
+	"Rate cleared for %s in '%s'":                                                      "Satz für %s in '%s' entfernt",
+	"Rate for %s in '%s' set to %s/h":                                                  "Satz für %s in '%s' auf %s/h gesetzt",
+	"Recorded new checksum: %s":                                                        "Neue Prüfsumme gespeichert: %s",
+	"Recovery stopped: %v":                                                             "Wiederherstellung abgebrochen: %v",
+	"Recurrence removed from task: %s":                                                 "Wiederholung von Aufgabe entfernt: %s",
+	"Recurring schedule set":                                                           "Wiederholung festgelegt",
+	"Recurring task completed":                                                         "Wiederkehrende Aufgabe erledigt",
+	"Remote: %s":                                                                       "Remote: %s",
+	"Removed %d old backup(s)":                                                         "%d alte Sicherung(en) entfernt",
+	"Removed git hooks from %s":                                                        "Git-Hooks entfernt aus %s",
+	"Report copied to clipboard":                                                       "Bericht in die Zwischenablage kopiert",
+	"Report scheduled with ID: %s":                                                     "Bericht geplant mit ID: %s",
+	"Report written to %s":                                                             "Bericht geschrieben nach %s",
+	"Restore cancelled":                                                                "Wiederherstellen abgebrochen",
+	"Restoring from backup...":                                                         "Wiederherstellung aus Sicherung...",
+	"Running [%s] qix report %s":                                                       "Ausführen [%s] qix report %s",
+	"Safety backup created: %s":                                                        "Sicherheitskopie erstellt: %s",
+	"Saved %s":                                                                         "%s gespeichert",
+	"Schedule removed: %s":                                                             "Zeitplan entfernt: %s",
+	"Scheduled report %s failed: %v":                                                   "Geplanter Bericht %s fehlgeschlagen: %v",
+	"Search failed: %v":                                                                "Suche fehlgeschlagen: %v",
+	"Sent a test message to %s":                                                        "Testnachricht an %s gesendet",
+	"Sent the %s digest to %s":                                                         "Zusammenfassung (%s) an %s gesendet",
+	"Sent the summary of %s":                                                           "Zusammenfassung für %s gesendet",
+	"Server stopped: %v":                                                               "Server angehalten: %v",
+	"Serving the calendar feed at %s":                                                  "Kalender-Feed unter %s bereitgestellt",
+	"Serving the dashboard at %s":                                                      "Dashboard unter %s bereitgestellt",
+	"Set %s = %s":                                                                      "%s = %s gesetzt",
+	"Show the qix log":                                                                 "Das qix-Log anzeigen",
+	"Skipped line %d: %s":                                                              "Zeile %d übersprungen: %s",
+	"Skipping %s: %v":                                                                  "%s wird übersprungen: %v",
+	"Skipping %s: invalid cron expression: %v":                                         "%s wird übersprungen: ungültiger Cron-Ausdruck: %v",
+	"Some changes may not be saved: %v":                                                "Einige Änderungen wurden möglicherweise nicht gespeichert: %v",
+	"Specify at least --name or --description":                                         "Mindestens --name oder --description angeben",
+	"Sprint '%s' closed":                                                               "Sprint '%s' abgeschlossen",
+	"Sprint '%s' created":                                                              "Sprint '%s' erstellt",
+	"Sprint '%s' is already closed":                                                    "Sprint '%s' ist bereits abgeschlossen",
+	"Sprint '%s' removed":                                                              "Sprint '%s' entfernt",
+	"Sprint Tasks":                                                                     "Sprint-Aufgaben",
+	"Sprint not found: %v":                                                             "Sprint nicht gefunden: %v",
+	"Start tracking with: qix track start <project> <task_id>":                         "Zeiterfassung starten mit: qix track start <project> <task_id>",
+	"Stopped tracking: %s [%s]":                                                        "Zeiterfassung beendet: %s [%s]",
+	"Switch to it with: git checkout %s":                                               "Wechseln mit: git checkout %s",
+	"Synced %d of %d Jira issue(s)":                                                    "%d von %d Jira-Issue(s) abgeglichen",
+	"Synced %s: %d created, %d updated, %d deleted, %d unchanged":                      "%s synchronisiert: %d erstellt, %d aktualisiert, %d gelöscht, %d unverändert",
+	"Task ID %s is shared by: %s":                                                      "Die Aufgaben-ID %s wird geteilt von: %s",
+	"Task IDs are unique":                                                              "Aufgaben-IDs sind eindeutig",
+	"Task [%s] is already linked to %s":                                                "Aufgabe [%s] ist bereits mit %s verknüpft",
+	"Task [%s] has no Jira issue linked. Use 'qix task edit %s %s --jira-issue <ID>' to set one.": "Aufgabe [%s] ist mit keinem Jira-Issue verknüpft. Mit 'qix task edit %s %s --jira-issue <ID>' festlegen.",
+	"Task [%s] is now [%s]":                                        "Aufgabe [%s] heißt jetzt [%s]",
+	"Task [%s] unassigned from sprint '%s'":                        "Aufgabe [%s] aus Sprint '%s' entfernt",
+	"Task assigned to sprint":                                      "Aufgabe dem Sprint zugewiesen",
+	"Task completed: [%s] %s":                                      "Aufgabe erledigt: [%s] %s",
+	"Task created with ID: %s":                                     "Aufgabe erstellt mit ID: %s",
+	"Task index unusable (%v); it will be rebuilt after restoring": "Aufgabenindex unbrauchbar (%v); er wird nach der Wiederherstellung neu aufgebaut",
+	"Task linked successfully":                                     "Aufgabe erfolgreich verknüpft",
+	"Task not found: %v":                                           "Aufgabe nicht gefunden: %v",
+	"Task removed: [%s] %s":                                        "Aufgabe entfernt: [%s] %s",
+	"Task restored to %s: [%s] %s":                                 "Aufgabe wiederhergestellt in %s: [%s] %s",
+	"Task status updated":                                          "Aufgabenstatus aktualisiert",
+	"Task updated: %s":                                             "Aufgabe aktualisiert: %s",
+	"The config file can't be read: %v":                            "Die Konfigurationsdatei kann nicht gelesen werden: %v",
+	"The local backup was kept: %s":                                "Die lokale Sicherung wurde behalten: %s",
+	"The log is empty":                                             "Das Log ist leer",
+	"The previous version is in the trash; use 'qix trash list' to restore it": "Die vorherige Version liegt im Papierkorb; mit 'qix trash list' wiederherstellen",
+	"They are marked in Jira and won't be pushed again.":                       "Sie sind in Jira markiert und werden nicht erneut übertragen.",
+	"Time logged":                "Zeit erfasst",
+	"To Toggl":                   "An Toggl",
+	"Tracking data is valid":     "Die Daten der Zeiterfassung sind gültig",
+	"Tracking not changed":       "Zeiterfassung nicht geändert",
+	"Trash is empty":             "Der Papierkorb ist leer",
+	"Try fewer or shorter words": "Weniger oder kürzere Wörter versuchen",
+	"Turn them on with 'qix config set usage_stats true'; they stay on this machine": "Mit 'qix config set usage_stats true' einschalten; sie bleiben auf diesem Rechner",
+	"Unknown event: %s (use %s)":                                   "Unbekanntes Ereignis: %s (verwende %s)",
+	"Unknown hook: %s":                                             "Unbekannter Hook: %s",
+	"Unknown setting: %s":                                          "Unbekannte Einstellung: %s",
+	"Unknown setting: %s (see 'qix config list')":                  "Unbekannte Einstellung: %s (siehe 'qix config list')",
+	"Unknown setting: %s (see 'qix config list', or pass --force)": "Unbekannte Einstellung: %s (siehe 'qix config list' oder --force angeben)",
+	"Unknown webhook: %s":                                          "Unbekannter Webhook: %s",
+	"Unreadable journal: %v":                                       "Protokoll nicht lesbar: %v",
+	"Unreadable project: %s (%v)":                                  "Projekt nicht lesbar: %s (%v)",
+	"Unreadable report schedules: %v":                              "Berichtszeitpläne nicht lesbar: %v",
+	"Unreadable time entries: %v":                                  "Zeiteinträge nicht lesbar: %v",
+	"Unreadable tracking data: %v":                                 "Daten der Zeiterfassung nicht lesbar: %v",
+	"Unset %s":                                                     "%s entfernt",
+	"Upload one with: qix backup create --remote <target>":         "Hochladen mit: qix backup create --remote <target>",
+	"Uploading to %s...":                                           "Hochladen nach %s...",
+	"Usage statistics are off":                                     "Nutzungsstatistiken sind aus",
+	"Usage statistics removed":                                     "Nutzungsstatistiken entfernt",
+	"Use either a date or --from/--to, not both":                   "Entweder ein Datum oder --from/--to angeben, nicht beides",
+	"Valid: %s":                     "Gültig: %s",
+	"Wrote %d man pages to %s":      "%d Man-Pages nach %s geschrieben",
+	"Wrote %d markdown pages to %s": "%d Markdown-Seiten nach %s geschrieben",
+	"Your data may be partly restored. Bring it back as it was with: qix backup restore %s": "Ihre Daten sind möglicherweise teilweise wiederhergestellt. Den vorherigen Stand zurückholen mit: qix backup restore %s",
+	"commit-msg needs the message file":                                                     "commit-msg benötigt die Nachrichtendatei",
+	"prepare-commit-msg needs the message file":                                             "prepare-commit-msg benötigt die Nachrichtendatei",
+	"qix: could not add the tracked task to the commit message: %v":                         "qix: die erfasste Aufgabe konnte nicht in die Commit-Nachricht eingefügt werden: %v",
+	"qix: could not check the commit message: %v":                                           "qix: die Commit-Nachricht konnte nicht geprüft werden: %v",
+	"qix: could not link commit %s to task [%s]: %v":                                        "qix: Commit %s konnte nicht mit Aufgabe [%s] verknüpft werden: %v",
+	"qix: could not link commit %s: %v":                                                     "qix: Commit %s konnte nicht verknüpft werden: %v",
+	"qix: could not read the commit: %v":                                                    "qix: der Commit konnte nicht gelesen werden: %v",
+	"restore-project needs the json storage backend; use 'qix backup restore' instead":      "restore-project benötigt den json-Speicher; stattdessen 'qix backup restore' verwenden",
+
+	// Headers
+	"Files":                            "Dateien",
+	"Task changes":                     "Aufgabenänderungen",
+	"⏰ Scheduled Reports":              "⏰ Geplante Berichte",
+	"⏱  Storage Benchmark":             "⏱  Speicher-Benchmark",
+	"⏱️  Checking time entries...":     "⏱️  Zeiteinträge werden geprüft...",
+	"⏱️  Hours per Day":                "⏱️  Stunden pro Tag",
+	"⏱️  Most Time-Intensive Tasks":    "⏱️  Zeitintensivste Aufgaben",
+	"⏱️  Time Analysis":                "⏱️  Zeitanalyse",
+	"⏱️  Top Time-Consuming Tasks":     "⏱️  Aufgaben mit dem meisten Zeitaufwand",
+	"⏱️  Tracking started":             "⏱️  Zeiterfassung gestartet",
+	"⏳ Active Tracking Session":        "⏳ Laufende Zeiterfassung",
+	"⏹️  Stopped: [%s] %s":             "⏹️  Beendet: [%s] %s",
+	"⏹️  Tracking stopped":             "⏹️  Zeiterfassung beendet",
+	"▶️  Started: [%s] %s":             "▶️  Gestartet: [%s] %s",
+	"☁️  Remote Backups":               "☁️  Entfernte Sicherungen",
+	"⚡ Efficiency":                     "⚡ Effizienz",
+	"✅ Completed":                      "✅ Erledigt",
+	"🎨 Themes":                         "🎨 Farbschemata",
+	"🎯 Estimation Accuracy":            "🎯 Schätzgenauigkeit",
+	"🎯 Estimation Accuracy Breakdown":  "🎯 Schätzgenauigkeit im Detail",
+	"🎯 Priority Breakdown":             "🎯 Nach Priorität",
+	"🎯 Root Causes":                    "🎯 Ursachen",
+	"🏃  Sprints":                       "🏃  Sprints",
+	"🏆 Ranking":                        "🏆 Rangliste",
+	"👤 Profiles":                       "👤 Profile",
+	"👥 By Person":                      "👥 Nach Person",
+	"👨‍👩‍👧 Task Hierarchy":             "👨‍👩‍👧 Aufgabenhierarchie",
+	"💚 Project Health Score":           "💚 Projektgesundheit",
+	"💾 Cache statistics...":            "💾 Cache-Statistik...",
+	"📁 Checking directories...":        "📁 Verzeichnisse werden geprüft...",
+	"📁 Per-Project Totals":             "📁 Summen pro Projekt",
+	"📁 Projects":                       "📁 Projekte",
+	"📄 Checking projects...":           "📄 Projekte werden geprüft...",
+	"📄 Validating project files...":    "📄 Projektdateien werden geprüft...",
+	"📅 Projected Completion":           "📅 Voraussichtlicher Abschluss",
+	"📅 Upcoming":                       "📅 Demnächst",
+	"📆 Weekly Rollup":                  "📆 Wochenübersicht",
+	"📇 Checking task index...":         "📇 Aufgabenindex wird geprüft...",
+	"📈 Activity Breakdown":             "📈 Aktivität im Detail",
+	"📈 Command Usage":                  "📈 Befehlsnutzung",
+	"📈 Completion":                     "📈 Fortschritt",
+	"📈 Completion Comparison":          "📈 Fortschritt im Vergleich",
+	"📈 Task Distribution":              "📈 Aufgabenverteilung",
+	"📉 Burndown Analysis":              "📉 Burndown-Analyse",
+	"📊 Additional Metrics":             "📊 Weitere Kennzahlen",
+	"📊 Compared to Previous Week":      "📊 Im Vergleich zur Vorwoche",
+	"📊 Comparison":                     "📊 Vergleich",
+	"📊 Estimation Accuracy":            "📊 Schätzgenauigkeit",
+	"📊 Month-over-Month":               "📊 Im Monatsvergleich",
+	"📋 Summary":                        "📋 Zusammenfassung",
+	"📋 Tasks":                          "📋 Aufgaben",
+	"📒 Budget":                         "📒 Budget",
+	"📓 Journal":                        "📓 Protokoll",
+	"📦 Available Backups":              "📦 Verfügbare Sicherungen",
+	"📦 Checking archives...":           "📦 Archive werden geprüft...",
+	"📦 Module Breakdown":               "📦 Nach Modul",
+	"📦 Project-Level Tasks":            "📦 Aufgaben auf Projektebene",
+	"🔀 Backup vs Current Data":         "🔀 Sicherung und aktuelle Daten",
+	"🔄 Active":                         "🔄 Aktiv",
+	"🔐 Encryption":                     "🔐 Verschlüsselung",
+	"🔒 Checking permissions...":        "🔒 Berechtigungen werden geprüft...",
+	"🔗 Aliases":                        "🔗 Aliase",
+	"🔗 Chains":                         "🔗 Ketten",
+	"🔗 Checking task relationships...": "🔗 Beziehungen der Aufgaben werden geprüft...",
+	"🔗 Task Dependencies":              "🔗 Abhängigkeiten der Aufgaben",
+	"🔧 Schema Migration":               "🔧 Schema-Migration",
+	"🗂  Checking other data...":        "🗂  Weitere Daten werden geprüft...",
+	"🗑  Trash":                         "🗑  Papierkorb",
+	"🗒️  Project Tasks":                "🗒️  Projektaufgaben",
+	"🚀 Velocity (Last 7 days)":         "🚀 Geschwindigkeit (letzte 7 Tage)",
+	"🧩 By Module":                      "🧩 Nach Modul",
+	"🧩 Modules":                        "🧩 Module",
+}
@@ -0,0 +1,38 @@
+package i18n
+
+import (
+	"strings"
+	"time"
+)
+
+// Placeholders for month and weekday names while a layout is formatted. They
+// hold no characters time.Format would read as part of the layout.
+const (
+	longMonth    = "\x01"
+	shortMonth   = "\x02"
+	longWeekday  = "\x03"
+	shortWeekday = "\x04"
+)
+
+// FormatTime formats t like time.Format, with the month and weekday names of
+// the current language
+func FormatTime(t time.Time, layout string) string {
+	if catalog == nil {
+		return t.Format(layout)
+	}
+
+	// Longer names first, so "January" is not read as "Jan" and "uary"
+	layout = strings.NewReplacer(
+		"January", longMonth,
+		"Jan", shortMonth,
+		"Monday", longWeekday,
+		"Mon", shortWeekday,
+	).Replace(layout)
+
+	return strings.NewReplacer(
+		longMonth, T(t.Month().String()),
+		shortMonth, T(t.Month().String()[:3]),
+		longWeekday, T(t.Weekday().String()),
+		shortWeekday, T(t.Weekday().String()[:3]),
+	).Replace(t.Format(layout))
+}
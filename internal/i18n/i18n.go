@@ -0,0 +1,190 @@
+// Package i18n translates the messages qix prints. Messages are looked up by
+// their English text, format verbs included, in the catalog of the current
+// language; messages without a translation are printed in English.
+package i18n
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// builtinCatalogs are the translations shipped with qix, by language
+var builtinCatalogs = map[string]map[string]string{
+	"de": german,
+}
+
+var (
+	// language is the current language; "en" when messages are not translated
+	language = "en"
+
+	// catalog maps English messages to the current language
+	catalog map[string]string
+)
+
+// SetLanguage selects the language of messages, such as "de" or "de_DE.UTF-8".
+// An empty language is taken from the LC_ALL, LC_MESSAGES and LANG environment
+// variables. Translations in <dir>/<language>.json, a JSON object from English
+// messages to translated ones, add to and override the built-in ones.
+//
+// An unknown language is an error only if it was asked for, rather than taken
+// from the environment; messages stay in English either way.
+func SetLanguage(lang, dir string) error {
+	explicit := lang != ""
+	if !explicit {
+		lang = environmentLanguage()
+	}
+	language, catalog = "en", nil
+
+	candidates := languageCandidates(lang)
+	if len(candidates) == 0 {
+		return nil
+	}
+
+	merged := make(map[string]string)
+	found := false
+	for i := len(candidates) - 1; i >= 0; i-- {
+		name := candidates[i]
+		if builtin, ok := builtinCatalogs[name]; ok {
+			for msg, translation := range builtin {
+				merged[msg] = translation
+			}
+			found = true
+		}
+		if dir == "" {
+			continue
+		}
+		entries, err := readCatalogFile(catalogFile(dir, name))
+		if err != nil {
+			return err
+		}
+		if entries != nil {
+			for msg, translation := range entries {
+				merged[msg] = translation
+			}
+			found = true
+		}
+	}
+
+	if !found {
+		if explicit && candidates[len(candidates)-1] != "en" {
+			return fmt.Errorf("no translations for language '%s' (available: %s)", lang, strings.Join(Languages(dir), ", "))
+		}
+		return nil
+	}
+	language, catalog = candidates[0], merged
+	return nil
+}
+
+// Language returns the current language, such as "de" or "de_at"
+func Language() string {
+	return language
+}
+
+// Languages returns the languages there are translations for: the built-in
+// ones and those in dir, plus English
+func Languages(dir string) []string {
+	names := []string{"en"}
+	for name := range builtinCatalogs {
+		names = append(names, name)
+	}
+	if dir != "" {
+		files, _ := filepath.Glob(filepath.Join(dir, "*.json"))
+		for _, file := range files {
+			name := strings.ToLower(strings.TrimSuffix(filepath.Base(file), ".json"))
+			if !contains(names, name) {
+				names = append(names, name)
+			}
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// T returns the translation of an English message, or the message itself if
+// it has none
+func T(msg string) string {
+	if translation, ok := catalog[msg]; ok && translation != "" {
+		return translation
+	}
+	return msg
+}
+
+// Sprintf formats the translation of an English format string
+func Sprintf(format string, args ...interface{}) string {
+	return fmt.Sprintf(T(format), args...)
+}
+
+// environmentLanguage returns the language of the locale environment variables
+func environmentLanguage() string {
+	for _, name := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		if value := os.Getenv(name); value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+// languageCandidates returns the catalog names to look for a locale under,
+// most specific first: "de_AT.UTF-8" gives de_at and de. The C and POSIX
+// locales give none.
+func languageCandidates(locale string) []string {
+	// Drop the encoding and modifier: de_AT.UTF-8@euro
+	if i := strings.IndexAny(locale, ".@"); i >= 0 {
+		locale = locale[:i]
+	}
+	locale = strings.ToLower(strings.ReplaceAll(strings.TrimSpace(locale), "-", "_"))
+	if locale == "" || locale == "c" || locale == "posix" {
+		return nil
+	}
+
+	candidates := []string{locale}
+	if base, _, ok := strings.Cut(locale, "_"); ok {
+		candidates = append(candidates, base)
+	}
+	return candidates
+}
+
+// catalogFile returns the translation file for a language in dir, whatever the
+// case of its name (pt_BR.json for pt_br), or "" if there is none
+func catalogFile(dir, name string) string {
+	files, _ := filepath.Glob(filepath.Join(dir, "*.json"))
+	for _, file := range files {
+		if strings.EqualFold(strings.TrimSuffix(filepath.Base(file), ".json"), name) {
+			return file
+		}
+	}
+	return ""
+}
+
+// readCatalogFile reads a translation file, returning nil if there is none
+func readCatalogFile(path string) (map[string]string, error) {
+	if path == "" {
+		return nil, nil
+	}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make(map[string]string)
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("invalid translations in %s: %w", path, err)
+	}
+	return entries, nil
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,147 @@
+// Package ics writes iCalendar (RFC 5545) feeds of all-day events, such as
+// sprints and due dates, that calendar apps can subscribe to, and of timed
+// events such as logged work.
+package ics
+
+import (
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Event is an all-day event spanning Start..End (inclusive), or with Timed
+// an event from Start until End
+type Event struct {
+	UID         string
+	Summary     string
+	Description string
+	Start       time.Time
+	End         time.Time // Same as Start for a one-day event
+	RRule       string    // Recurrence rule, e.g. FREQ=WEEKLY;BYDAY=FR; empty for none
+	Categories  []string
+	URL         string
+	Stamp       time.Time // When the event last changed
+	Timed       bool
+}
+
+// Calendar is a named set of events
+type Calendar struct {
+	Name   string
+	Method string // iTIP method, such as PUBLISH for feeds; none if empty
+	Events []Event
+}
+
+// WriteTo writes the calendar in iCalendar format
+func (c *Calendar) WriteTo(w io.Writer) (int64, error) {
+	var b strings.Builder
+	line := func(s string) {
+		b.WriteString(fold(s))
+		b.WriteString("\r\n")
+	}
+
+	line("BEGIN:VCALENDAR")
+	line("VERSION:2.0")
+	line("PRODID:-//qix//qix calendar//EN")
+	line("CALSCALE:GREGORIAN")
+	if c.Method != "" {
+		line("METHOD:" + c.Method)
+	}
+	if c.Name != "" {
+		line("X-WR-CALNAME:" + escape(c.Name))
+	}
+	for _, e := range c.Events {
+		stamp := e.Stamp
+		if stamp.IsZero() {
+			stamp = time.Now()
+		}
+		end := e.End
+		if end.Before(e.Start) {
+			end = e.Start
+		}
+
+		line("BEGIN:VEVENT")
+		line("UID:" + escape(e.UID))
+		line("DTSTAMP:" + stamp.UTC().Format("20060102T150405Z"))
+		if e.Timed {
+			line("DTSTART:" + e.Start.UTC().Format("20060102T150405Z"))
+			line("DTEND:" + end.UTC().Format("20060102T150405Z"))
+		} else {
+			line("DTSTART;VALUE=DATE:" + e.Start.Format("20060102"))
+			// DTEND is exclusive for all-day events
+			line("DTEND;VALUE=DATE:" + end.AddDate(0, 0, 1).Format("20060102"))
+		}
+		if e.RRule != "" {
+			line("RRULE:" + e.RRule)
+		}
+		line("SUMMARY:" + escape(e.Summary))
+		if e.Description != "" {
+			line("DESCRIPTION:" + escape(e.Description))
+		}
+		if len(e.Categories) > 0 {
+			categories := make([]string, len(e.Categories))
+			for i, category := range e.Categories {
+				categories[i] = escape(category)
+			}
+			line("CATEGORIES:" + strings.Join(categories, ","))
+		}
+		if e.URL != "" {
+			line("URL:" + e.URL)
+		}
+		if e.Timed {
+			line("TRANSP:OPAQUE")
+		} else {
+			line("TRANSP:TRANSPARENT")
+		}
+		line("END:VEVENT")
+	}
+	line("END:VCALENDAR")
+
+	n, err := io.WriteString(w, b.String())
+	return int64(n), err
+}
+
+// escape escapes text for an iCalendar value
+func escape(s string) string {
+	return strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+	).Replace(s)
+}
+
+// fold splits a content line into lines of at most 75 octets, continued with
+// a leading space, without splitting UTF-8 characters
+func fold(s string) string {
+	const limit = 75
+	if len(s) <= limit {
+		return s
+	}
+
+	var b strings.Builder
+	width := limit
+	for len(s) > width {
+		cut := width
+		for cut > 0 && !utf8.RuneStart(s[cut]) {
+			cut--
+		}
+		b.WriteString(s[:cut])
+		b.WriteString("\r\n ")
+		s = s[cut:]
+		width = limit - 1 // The leading space counts
+	}
+	b.WriteString(s)
+	return b.String()
+}
+
+// Weekday returns the iCalendar code of a weekday name such as "friday", or
+// "" if it is not one
+func Weekday(name string) string {
+	codes := map[string]string{
+		"monday": "MO", "tuesday": "TU", "wednesday": "WE", "thursday": "TH",
+		"friday": "FR", "saturday": "SA", "sunday": "SU",
+	}
+	return codes[strings.ToLower(strings.TrimSpace(name))]
+}
@@ -0,0 +1,131 @@
+// Package importer reads exports of other task managers (Todoist, Trello) and
+// turns them into plans of the qix projects, modules and tasks to create.
+package importer
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/mrbooshehri/qix-go/pkg/qix/models"
+)
+
+// Options tune how an export is read
+type Options struct {
+	NewID    func() string // Generates task IDs, so subtasks can name their parent
+	Archived bool          // Also import archived boards, lists, projects and cards
+}
+
+// Plan is what an import creates
+type Plan struct {
+	Source   string // What was read, e.g. "Trello board"
+	Projects []ProjectPlan
+}
+
+// ProjectPlan is a project to create and what goes into it
+type ProjectPlan struct {
+	Name        string
+	From        string // Name of the board or project it comes from
+	Description string
+	Modules     []ModulePlan
+	Tasks       []TaskPlan
+	Skipped     int // Archived cards and tasks left out
+}
+
+// ModulePlan is a module to create
+type ModulePlan struct {
+	Name string
+	From string // Name of the list or section it comes from
+}
+
+// TaskPlan is a task to create, in a module or at project level if Module is ""
+type TaskPlan struct {
+	Module string
+	Task   models.Task
+}
+
+// Tags returns the tags the project's tasks use, in the order first used
+func (p ProjectPlan) Tags() []string {
+	seen := make(map[string]bool)
+	tags := make([]string, 0)
+	for _, t := range p.Tasks {
+		for _, tag := range t.Task.Tags {
+			if !seen[tag] {
+				seen[tag] = true
+				tags = append(tags, tag)
+			}
+		}
+	}
+	return tags
+}
+
+// TaskCount returns how many tasks go into a module
+func (p ProjectPlan) TaskCount(module string) int {
+	count := 0
+	for _, t := range p.Tasks {
+		if t.Module == module {
+			count++
+		}
+	}
+	return count
+}
+
+// dropMissingParents unlinks subtasks whose parent is not imported with them
+func (p *ProjectPlan) dropMissingParents() {
+	ids := make(map[string]bool)
+	for _, t := range p.Tasks {
+		ids[t.Task.ID] = true
+	}
+	for i := range p.Tasks {
+		if !ids[p.Tasks[i].Task.ParentID] {
+			p.Tasks[i].Task.ParentID = ""
+		}
+	}
+}
+
+// Name turns a board, list or project name into a qix name: lower case words
+// joined by dashes, so it can be typed on the command line
+func Name(s string) string {
+	words := strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	return strings.Join(words, "-")
+}
+
+// uniqueName returns name, or name-2, name-3, ... if it is taken
+func uniqueName(name string, taken map[string]bool) string {
+	if name == "" {
+		name = "untitled"
+	}
+	unique := name
+	for i := 2; taken[unique]; i++ {
+		unique = name + "-" + strconv.Itoa(i)
+	}
+	taken[unique] = true
+	return unique
+}
+
+// statusForList guesses a task status from the name of the list or section it
+// is in, so boards laid out as To do / Doing / Done keep their state
+func statusForList(name string) models.TaskStatus {
+	name = strings.ToLower(name)
+	switch {
+	case strings.Contains(name, "block"):
+		return models.StatusBlocked
+	case strings.Contains(name, "done"), strings.Contains(name, "complete"), strings.Contains(name, "finished"):
+		return models.StatusDone
+	case strings.Contains(name, "doing"), strings.Contains(name, "progress"), strings.Contains(name, "review"):
+		return models.StatusDoing
+	default:
+		return models.StatusTodo
+	}
+}
+
+// dueDate returns the YYYY-MM-DD date at the start of a date or timestamp
+func dueDate(s string) string {
+	s = strings.TrimSpace(s)
+	if len(s) < 10 || s[4] != '-' || s[7] != '-' {
+		return ""
+	}
+	return s[:10]
+}
@@ -0,0 +1,313 @@
+package importer
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/mrbooshehri/qix-go/pkg/qix/models"
+)
+
+// todoistID is an ID that older exports write as a number and newer ones as a string
+type todoistID string
+
+func (id *todoistID) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*id = ""
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		*id = todoistID(s)
+		return nil
+	}
+	var n json.Number
+	if err := json.Unmarshal(data, &n); err != nil {
+		return err
+	}
+	*id = todoistID(n.String())
+	return nil
+}
+
+// todoistData is the part of a Todoist sync API dump qix reads
+type todoistData struct {
+	Projects []struct {
+		ID         todoistID `json:"id"`
+		Name       string    `json:"name"`
+		IsArchived bool      `json:"is_archived"`
+		IsDeleted  bool      `json:"is_deleted"`
+		ChildOrder int       `json:"child_order"`
+	} `json:"projects"`
+	Sections []struct {
+		ID           todoistID `json:"id"`
+		Name         string    `json:"name"`
+		ProjectID    todoistID `json:"project_id"`
+		SectionOrder int       `json:"section_order"`
+		IsArchived   bool      `json:"is_archived"`
+		IsDeleted    bool      `json:"is_deleted"`
+	} `json:"sections"`
+	Items []struct {
+		ID          todoistID `json:"id"`
+		Content     string    `json:"content"`
+		Description string    `json:"description"`
+		ProjectID   todoistID `json:"project_id"`
+		SectionID   todoistID `json:"section_id"`
+		ParentID    todoistID `json:"parent_id"`
+		Labels      []string  `json:"labels"`
+		Priority    int       `json:"priority"` // 4 is p1, the highest
+		Checked     bool      `json:"checked"`
+		IsDeleted   bool      `json:"is_deleted"`
+		ChildOrder  int       `json:"child_order"`
+		Responsible todoistID `json:"responsible_uid"`
+		Due         *struct {
+			Date        string `json:"date"`
+			String      string `json:"string"`
+			IsRecurring bool   `json:"is_recurring"`
+		} `json:"due"`
+	} `json:"items"`
+	Collaborators []struct {
+		ID       todoistID `json:"id"`
+		FullName string    `json:"full_name"`
+	} `json:"collaborators"`
+}
+
+// Todoist reads a Todoist export: either a project exported as a CSV template,
+// which becomes a project called name, or a JSON dump of the sync API, where
+// each Todoist project becomes a qix project. Sections become modules, labels
+// tags and subtasks keep their parent.
+func Todoist(r io.Reader, name string, opts Options) (*Plan, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	data = bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '{' {
+		return todoistJSON(trimmed, opts)
+	}
+	return todoistCSV(data, name, opts)
+}
+
+func todoistJSON(data []byte, opts Options) (*Plan, error) {
+	var dump todoistData
+	if err := json.Unmarshal(data, &dump); err != nil {
+		return nil, fmt.Errorf("not a Todoist export: %w", err)
+	}
+	if dump.Projects == nil || dump.Items == nil {
+		return nil, fmt.Errorf("not a Todoist export: no projects or items")
+	}
+
+	collaborators := make(map[todoistID]string)
+	for _, c := range dump.Collaborators {
+		collaborators[c.ID] = c.FullName
+	}
+	sort.SliceStable(dump.Projects, func(i, j int) bool { return dump.Projects[i].ChildOrder < dump.Projects[j].ChildOrder })
+	sort.SliceStable(dump.Sections, func(i, j int) bool { return dump.Sections[i].SectionOrder < dump.Sections[j].SectionOrder })
+	sort.SliceStable(dump.Items, func(i, j int) bool { return dump.Items[i].ChildOrder < dump.Items[j].ChildOrder })
+
+	// Give every item that is kept an ID first, so subtasks can point to
+	// parents listed after them
+	ids := make(map[todoistID]string)
+	for _, item := range dump.Items {
+		if item.IsDeleted {
+			continue
+		}
+		ids[item.ID] = opts.NewID()
+	}
+
+	plan := &Plan{Source: "Todoist project", Projects: make([]ProjectPlan, 0)}
+	projectNames := make(map[string]bool)
+	for _, p := range dump.Projects {
+		if p.IsDeleted || (p.IsArchived && !opts.Archived) {
+			continue
+		}
+		project := ProjectPlan{
+			Name:    uniqueName(Name(p.Name), projectNames),
+			From:    p.Name,
+			Modules: make([]ModulePlan, 0),
+			Tasks:   make([]TaskPlan, 0),
+		}
+
+		modules := make(map[todoistID]string)
+		moduleNames := make(map[string]bool)
+		for _, section := range dump.Sections {
+			if section.ProjectID != p.ID || section.IsDeleted || (section.IsArchived && !opts.Archived) {
+				continue
+			}
+			modules[section.ID] = uniqueName(Name(section.Name), moduleNames)
+			project.Modules = append(project.Modules, ModulePlan{Name: modules[section.ID], From: section.Name})
+		}
+
+		for _, item := range dump.Items {
+			if item.ProjectID != p.ID || item.IsDeleted {
+				continue
+			}
+			module, ok := modules[item.SectionID]
+			if !ok && item.SectionID != "" {
+				// Left in an archived section
+				project.Skipped++
+				continue
+			}
+
+			task := models.Task{
+				ID:          ids[item.ID],
+				Title:       strings.TrimSpace(item.Content),
+				Description: strings.TrimSpace(item.Description),
+				Status:      models.StatusTodo,
+				Priority:    todoistPriority(5 - item.Priority),
+				Tags:        make([]string, 0),
+				ParentID:    ids[item.ParentID],
+				Assignee:    collaborators[item.Responsible],
+			}
+			if item.Checked {
+				task.Status = models.StatusDone
+			}
+			for _, label := range item.Labels {
+				if tag := Name(label); tag != "" {
+					task.Tags = append(task.Tags, tag)
+				}
+			}
+			if item.Due != nil {
+				task.DueDate = dueDate(item.Due.Date)
+				if item.Due.IsRecurring && item.Due.String != "" {
+					task.Description = joinParagraphs(task.Description, "Repeats: "+item.Due.String)
+				}
+			}
+			project.Tasks = append(project.Tasks, TaskPlan{Module: module, Task: task})
+		}
+
+		project.dropMissingParents()
+		plan.Projects = append(plan.Projects, project)
+	}
+	return plan, nil
+}
+
+// todoistLabel matches the @labels written in a task's content
+var todoistLabel = regexp.MustCompile(`(^|\s)@([^\s@]+)`)
+
+func todoistCSV(data []byte, name string, opts Options) (*Plan, error) {
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.FieldsPerRecord = -1
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("not a Todoist export: %w", err)
+	}
+	if len(records) == 0 {
+		return nil, fmt.Errorf("not a Todoist export: the file is empty")
+	}
+
+	columns := make(map[string]int)
+	for i, header := range records[0] {
+		columns[strings.ToUpper(strings.TrimSpace(header))] = i
+	}
+	for _, required := range []string{"TYPE", "CONTENT"} {
+		if _, ok := columns[required]; !ok {
+			return nil, fmt.Errorf("not a Todoist export: no %s column", required)
+		}
+	}
+	field := func(record []string, column string) string {
+		if i, ok := columns[column]; ok && i < len(record) {
+			return strings.TrimSpace(record[i])
+		}
+		return ""
+	}
+
+	project := ProjectPlan{
+		Name:    uniqueName(Name(name), map[string]bool{}),
+		From:    name,
+		Modules: make([]ModulePlan, 0),
+		Tasks:   make([]TaskPlan, 0),
+	}
+	moduleNames := make(map[string]bool)
+	module := ""
+	parents := make([]string, 0) // IDs of the latest task at each indent level
+
+	for _, record := range records[1:] {
+		content := field(record, "CONTENT")
+		switch strings.ToLower(field(record, "TYPE")) {
+		case "section":
+			module = uniqueName(Name(content), moduleNames)
+			project.Modules = append(project.Modules, ModulePlan{Name: module, From: content})
+			parents = parents[:0]
+
+		case "task":
+			task := models.Task{
+				ID:          opts.NewID(),
+				Status:      models.StatusTodo,
+				Description: field(record, "DESCRIPTION"),
+				Tags:        make([]string, 0),
+				Priority:    models.PriorityMedium,
+			}
+			if p, err := strconv.Atoi(field(record, "PRIORITY")); err == nil {
+				task.Priority = todoistPriority(p)
+			}
+			for _, match := range todoistLabel.FindAllStringSubmatch(content, -1) {
+				if tag := Name(match[2]); tag != "" {
+					task.Tags = append(task.Tags, tag)
+				}
+			}
+			task.Title = strings.Join(strings.Fields(todoistLabel.ReplaceAllString(content, " ")), " ")
+			if responsible := field(record, "RESPONSIBLE"); responsible != "" {
+				// Written as "Full Name (id)"
+				if i := strings.LastIndex(responsible, " ("); i > 0 {
+					responsible = responsible[:i]
+				}
+				task.Assignee = responsible
+			}
+			if date := field(record, "DATE"); date != "" {
+				task.DueDate = dueDate(date)
+				if task.DueDate == "" {
+					// A date in words, e.g. "every monday"
+					task.Description = joinParagraphs(task.Description, "Due: "+date)
+				}
+			}
+
+			indent, err := strconv.Atoi(field(record, "INDENT"))
+			if err != nil || indent < 1 {
+				indent = 1
+			}
+			if indent > len(parents)+1 {
+				indent = len(parents) + 1
+			}
+			if indent > 1 {
+				task.ParentID = parents[indent-2]
+			}
+			parents = append(parents[:indent-1], task.ID)
+
+			project.Tasks = append(project.Tasks, TaskPlan{Module: module, Task: task})
+
+		case "note":
+			if n := len(project.Tasks); n > 0 && content != "" {
+				last := &project.Tasks[n-1].Task
+				last.Description = joinParagraphs(last.Description, content)
+			}
+		}
+	}
+
+	return &Plan{Source: "Todoist project", Projects: []ProjectPlan{project}}, nil
+}
+
+// todoistPriority maps Todoist's p1..p4 to a qix priority. p4 is Todoist's
+// default, so it stays medium.
+func todoistPriority(p int) models.Priority {
+	switch p {
+	case 1:
+		return models.PriorityHigh
+	case 3:
+		return models.PriorityLow
+	default:
+		return models.PriorityMedium
+	}
+}
+
+func joinParagraphs(a, b string) string {
+	if a == "" {
+		return b
+	}
+	return a + "\n\n" + b
+}
@@ -0,0 +1,168 @@
+package importer
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/mrbooshehri/qix-go/pkg/qix/models"
+)
+
+// trelloBoard is the part of a Trello board JSON export qix reads
+type trelloBoard struct {
+	Name  string `json:"name"`
+	Desc  string `json:"desc"`
+	Lists []struct {
+		ID     string  `json:"id"`
+		Name   string  `json:"name"`
+		Closed bool    `json:"closed"`
+		Pos    float64 `json:"pos"`
+	} `json:"lists"`
+	Cards []struct {
+		ID          string   `json:"id"`
+		Name        string   `json:"name"`
+		Desc        string   `json:"desc"`
+		IDList      string   `json:"idList"`
+		Closed      bool     `json:"closed"`
+		Due         string   `json:"due"`
+		DueComplete bool     `json:"dueComplete"`
+		IDLabels    []string `json:"idLabels"`
+		IDMembers   []string `json:"idMembers"`
+		Pos         float64  `json:"pos"`
+		ShortURL    string   `json:"shortUrl"`
+	} `json:"cards"`
+	Labels []struct {
+		ID    string `json:"id"`
+		Name  string `json:"name"`
+		Color string `json:"color"`
+	} `json:"labels"`
+	Members []struct {
+		ID       string `json:"id"`
+		FullName string `json:"fullName"`
+		Username string `json:"username"`
+	} `json:"members"`
+	Checklists []struct {
+		IDCard     string  `json:"idCard"`
+		Name       string  `json:"name"`
+		Pos        float64 `json:"pos"`
+		CheckItems []struct {
+			Name  string  `json:"name"`
+			State string  `json:"state"`
+			Pos   float64 `json:"pos"`
+		} `json:"checkItems"`
+	} `json:"checklists"`
+}
+
+// Trello reads a board exported from Trello as JSON (Menu > Print, export and
+// share > Export as JSON). The board becomes a project, its lists modules and
+// its cards tasks; labels become tags and checklists go into the description.
+func Trello(r io.Reader, opts Options) (*Plan, error) {
+	var board trelloBoard
+	if err := json.NewDecoder(r).Decode(&board); err != nil {
+		return nil, fmt.Errorf("not a Trello board export: %w", err)
+	}
+	if board.Name == "" || board.Lists == nil {
+		return nil, fmt.Errorf("not a Trello board export: no board name or lists")
+	}
+
+	project := ProjectPlan{
+		Name:        uniqueName(Name(board.Name), map[string]bool{}),
+		From:        board.Name,
+		Description: strings.TrimSpace(board.Desc),
+		Modules:     make([]ModulePlan, 0),
+		Tasks:       make([]TaskPlan, 0),
+	}
+
+	labels := make(map[string]string)
+	for _, label := range board.Labels {
+		name := Name(label.Name)
+		if name == "" {
+			name = Name(label.Color)
+		}
+		labels[label.ID] = name
+	}
+	members := make(map[string]string)
+	for _, member := range board.Members {
+		members[member.ID] = member.FullName
+		if member.FullName == "" {
+			members[member.ID] = member.Username
+		}
+	}
+	checklists := make(map[string][]string)
+	sort.SliceStable(board.Checklists, func(i, j int) bool { return board.Checklists[i].Pos < board.Checklists[j].Pos })
+	for _, checklist := range board.Checklists {
+		lines := []string{checklist.Name + ":"}
+		sort.SliceStable(checklist.CheckItems, func(i, j int) bool { return checklist.CheckItems[i].Pos < checklist.CheckItems[j].Pos })
+		for _, item := range checklist.CheckItems {
+			mark := " "
+			if item.State == "complete" {
+				mark = "x"
+			}
+			lines = append(lines, fmt.Sprintf("- [%s] %s", mark, item.Name))
+		}
+		checklists[checklist.IDCard] = append(checklists[checklist.IDCard], strings.Join(lines, "\n"))
+	}
+
+	sort.SliceStable(board.Lists, func(i, j int) bool { return board.Lists[i].Pos < board.Lists[j].Pos })
+	sort.SliceStable(board.Cards, func(i, j int) bool { return board.Cards[i].Pos < board.Cards[j].Pos })
+
+	taken := make(map[string]bool)
+	for _, list := range board.Lists {
+		if list.Closed && !opts.Archived {
+			for _, card := range board.Cards {
+				if card.IDList == list.ID {
+					project.Skipped++
+				}
+			}
+			continue
+		}
+		module := uniqueName(Name(list.Name), taken)
+		project.Modules = append(project.Modules, ModulePlan{Name: module, From: list.Name})
+
+		for _, card := range board.Cards {
+			if card.IDList != list.ID {
+				continue
+			}
+			if card.Closed && !opts.Archived {
+				project.Skipped++
+				continue
+			}
+
+			task := models.Task{
+				ID:       opts.NewID(),
+				Title:    strings.TrimSpace(card.Name),
+				Status:   statusForList(list.Name),
+				Priority: models.PriorityMedium,
+				Tags:     make([]string, 0),
+				DueDate:  dueDate(card.Due),
+			}
+			if card.DueComplete {
+				task.Status = models.StatusDone
+			}
+			for _, id := range card.IDLabels {
+				if tag := labels[id]; tag != "" {
+					task.Tags = append(task.Tags, tag)
+				}
+			}
+			if len(card.IDMembers) > 0 {
+				task.Assignee = members[card.IDMembers[0]]
+			}
+
+			parts := make([]string, 0)
+			if desc := strings.TrimSpace(card.Desc); desc != "" {
+				parts = append(parts, desc)
+			}
+			parts = append(parts, checklists[card.ID]...)
+			if card.ShortURL != "" {
+				parts = append(parts, "Trello: "+card.ShortURL)
+			}
+			task.Description = strings.Join(parts, "\n\n")
+
+			project.Tasks = append(project.Tasks, TaskPlan{Module: module, Task: task})
+		}
+	}
+
+	return &Plan{Source: "Trello board", Projects: []ProjectPlan{project}}, nil
+}
@@ -0,0 +1,398 @@
+// Package jira reads issues from the Jira REST API.
+package jira
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrNotFound is returned for issues that do not exist or cannot be seen with
+// the configured credentials
+var ErrNotFound = errors.New("issue not found")
+
+// ErrUnauthorized is returned when Jira rejects the configured credentials
+var ErrUnauthorized = errors.New("Jira rejected the credentials")
+
+// timeLayout is how Jira writes times, with an offset without a colon:
+// 2024-05-02T10:04:11.000+0000
+const timeLayout = "2006-01-02T15:04:05.000-0700"
+
+// Options locate a Jira instance and the credentials to read it with
+type Options struct {
+	BaseURL string // Site or browse URL, e.g. https://example.atlassian.net/browse
+	APIURL  string // REST API root; derived from BaseURL if empty
+	User    string // Account email (Jira Cloud) or user name, for basic auth with Token
+	Token   string // API token, or a personal access token used alone (Jira Data Center)
+}
+
+// issueFields are the fields requested for issues
+const issueFields = "summary,description,status,assignee,priority,labels,duedate,timeoriginalestimate,timeestimate,updated"
+
+// Issue is the part of a Jira issue qix keeps
+type Issue struct {
+	Key               string
+	Summary           string
+	Description       string
+	Status            string
+	StatusCategory    string // new, indeterminate or done
+	Assignee          string // Display name; empty if unassigned
+	Priority          string // Name, such as High; empty if the project has no priorities
+	Labels            []string
+	DueDate           string // YYYY-MM-DD; empty if not set
+	OriginalEstimate  time.Duration
+	RemainingEstimate time.Duration
+	Updated           time.Time
+}
+
+// Client reads issues from one Jira instance
+type Client struct {
+	api    string
+	user   string
+	token  string
+	client *http.Client
+}
+
+// NewClient returns a client for the Jira instance in opts
+func NewClient(opts Options) (*Client, error) {
+	api := strings.TrimSpace(opts.APIURL)
+	if api == "" {
+		// The browse URL qix opens issues at is under the site root
+		api = strings.TrimSuffix(strings.TrimRight(strings.TrimSpace(opts.BaseURL), "/"), "/browse")
+	}
+	api = strings.TrimRight(api, "/")
+	if api == "" {
+		return nil, errors.New("no Jira URL configured")
+	}
+	u, err := url.Parse(api)
+	if err != nil || u.Host == "" || (u.Scheme != "http" && u.Scheme != "https") {
+		return nil, fmt.Errorf("invalid Jira URL: %s", api)
+	}
+	if strings.TrimSpace(opts.Token) == "" {
+		return nil, errors.New("no Jira API token configured")
+	}
+
+	return &Client{
+		api:    api,
+		user:   strings.TrimSpace(opts.User),
+		token:  strings.TrimSpace(opts.Token),
+		client: &http.Client{Timeout: 30 * time.Second},
+	}, nil
+}
+
+// issueResponse is an issue as the REST API returns it
+type issueResponse struct {
+	Key    string `json:"key"`
+	Fields struct {
+		Summary     string `json:"summary"`
+		Description string `json:"description"`
+		Status      struct {
+			Name           string `json:"name"`
+			StatusCategory struct {
+				Key string `json:"key"`
+			} `json:"statusCategory"`
+		} `json:"status"`
+		Assignee *struct {
+			DisplayName string `json:"displayName"`
+		} `json:"assignee"`
+		Priority *struct {
+			Name string `json:"name"`
+		} `json:"priority"`
+		Labels               []string `json:"labels"`
+		DueDate              string   `json:"duedate"`
+		TimeOriginalEstimate int64    `json:"timeoriginalestimate"`
+		TimeEstimate         int64    `json:"timeestimate"`
+		Updated              string   `json:"updated"`
+	} `json:"fields"`
+}
+
+func (r issueResponse) issue() Issue {
+	issue := Issue{
+		Key:               r.Key,
+		Summary:           r.Fields.Summary,
+		Description:       r.Fields.Description,
+		Status:            r.Fields.Status.Name,
+		StatusCategory:    r.Fields.Status.StatusCategory.Key,
+		Labels:            r.Fields.Labels,
+		DueDate:           r.Fields.DueDate,
+		OriginalEstimate:  time.Duration(r.Fields.TimeOriginalEstimate) * time.Second,
+		RemainingEstimate: time.Duration(r.Fields.TimeEstimate) * time.Second,
+	}
+	if r.Fields.Assignee != nil {
+		issue.Assignee = r.Fields.Assignee.DisplayName
+	}
+	if r.Fields.Priority != nil {
+		issue.Priority = r.Fields.Priority.Name
+	}
+	if updated, err := time.Parse(timeLayout, r.Fields.Updated); err == nil {
+		issue.Updated = updated
+	}
+	return issue
+}
+
+// Issue reads an issue by key, such as PROJ-123
+func (c *Client) Issue(ctx context.Context, key string) (*Issue, error) {
+	var data issueResponse
+	path := "/rest/api/2/issue/" + url.PathEscape(key) + "?fields=" + issueFields
+	if err := c.do(ctx, http.MethodGet, path, nil, &data); err != nil {
+		return nil, err
+	}
+	issue := data.issue()
+	return &issue, nil
+}
+
+// Search returns the issues a JQL query finds, in the order it sorts them
+func (c *Client) Search(ctx context.Context, jql string) ([]Issue, error) {
+	issues, err := c.searchJQL(ctx, jql)
+	if errors.Is(err, ErrNotFound) {
+		// Jira Data Center, which only has the older paginated search
+		return c.searchPaged(ctx, jql)
+	}
+	return issues, err
+}
+
+// searchJQL searches with /search/jql, which pages with tokens (Jira Cloud)
+func (c *Client) searchJQL(ctx context.Context, jql string) ([]Issue, error) {
+	issues := make([]Issue, 0)
+	token := ""
+	for {
+		query := url.Values{"jql": {jql}, "fields": {issueFields}, "maxResults": {"100"}}
+		if token != "" {
+			query.Set("nextPageToken", token)
+		}
+		var page struct {
+			Issues        []issueResponse `json:"issues"`
+			NextPageToken string          `json:"nextPageToken"`
+			IsLast        bool            `json:"isLast"`
+		}
+		if err := c.do(ctx, http.MethodGet, "/rest/api/2/search/jql?"+query.Encode(), nil, &page); err != nil {
+			return nil, err
+		}
+		for _, issue := range page.Issues {
+			issues = append(issues, issue.issue())
+		}
+		if page.IsLast || page.NextPageToken == "" || len(page.Issues) == 0 {
+			return issues, nil
+		}
+		token = page.NextPageToken
+	}
+}
+
+// searchPaged searches with /search, which pages by offset
+func (c *Client) searchPaged(ctx context.Context, jql string) ([]Issue, error) {
+	issues := make([]Issue, 0)
+	for {
+		query := url.Values{"jql": {jql}, "fields": {issueFields}, "maxResults": {"100"}, "startAt": {strconv.Itoa(len(issues))}}
+		var page struct {
+			Total  int             `json:"total"`
+			Issues []issueResponse `json:"issues"`
+		}
+		if err := c.do(ctx, http.MethodGet, "/rest/api/2/search?"+query.Encode(), nil, &page); err != nil {
+			return nil, err
+		}
+		for _, issue := range page.Issues {
+			issues = append(issues, issue.issue())
+		}
+		if len(page.Issues) == 0 || len(issues) >= page.Total {
+			return issues, nil
+		}
+	}
+}
+
+// NewIssue is an issue to create
+type NewIssue struct {
+	Project     string // Project key, such as PROJ
+	Type        string // Issue type name, such as Task
+	Summary     string
+	Description string
+	Estimate    time.Duration // Original estimate; none if 0
+}
+
+// CreateIssue creates an issue and returns its key
+func (c *Client) CreateIssue(ctx context.Context, issue NewIssue) (string, error) {
+	fields := map[string]interface{}{
+		"project":   map[string]string{"key": issue.Project},
+		"issuetype": map[string]string{"name": issue.Type},
+		"summary":   issue.Summary,
+	}
+	if issue.Description != "" {
+		fields["description"] = issue.Description
+	}
+	if issue.Estimate > 0 {
+		fields["timetracking"] = map[string]string{
+			"originalEstimate": fmt.Sprintf("%dm", int64(issue.Estimate.Round(time.Minute)/time.Minute)),
+		}
+	}
+
+	var created struct {
+		Key string `json:"key"`
+	}
+	if err := c.do(ctx, http.MethodPost, "/rest/api/2/issue", map[string]interface{}{"fields": fields}, &created); err != nil {
+		return "", err
+	}
+	return created.Key, nil
+}
+
+// Worklog is time logged on an issue
+type Worklog struct {
+	ID      string
+	Comment string
+	Started time.Time
+	Spent   time.Duration
+}
+
+// worklogJSON is a worklog as the REST API reads and writes it
+type worklogJSON struct {
+	ID               string `json:"id,omitempty"`
+	Comment          string `json:"comment"`
+	Started          string `json:"started"`
+	TimeSpentSeconds int64  `json:"timeSpentSeconds"`
+}
+
+// Worklogs returns all worklogs of an issue
+func (c *Client) Worklogs(ctx context.Context, key string) ([]Worklog, error) {
+	worklogs := make([]Worklog, 0)
+	for {
+		var page struct {
+			StartAt  int           `json:"startAt"`
+			Total    int           `json:"total"`
+			Worklogs []worklogJSON `json:"worklogs"`
+		}
+		path := fmt.Sprintf("/rest/api/2/issue/%s/worklog?startAt=%d", url.PathEscape(key), len(worklogs))
+		if err := c.do(ctx, http.MethodGet, path, nil, &page); err != nil {
+			return nil, err
+		}
+		for _, w := range page.Worklogs {
+			worklogs = append(worklogs, w.worklog())
+		}
+		if len(page.Worklogs) == 0 || len(worklogs) >= page.Total {
+			return worklogs, nil
+		}
+	}
+}
+
+// AddWorklog logs time on an issue and returns the new worklog's ID
+func (c *Client) AddWorklog(ctx context.Context, key string, w Worklog) (string, error) {
+	body := worklogJSON{
+		Comment:          w.Comment,
+		Started:          w.Started.Format(timeLayout),
+		TimeSpentSeconds: int64(w.Spent / time.Second),
+	}
+	var created worklogJSON
+	path := "/rest/api/2/issue/" + url.PathEscape(key) + "/worklog?adjustEstimate=leave"
+	if err := c.do(ctx, http.MethodPost, path, body, &created); err != nil {
+		return "", err
+	}
+	return created.ID, nil
+}
+
+func (w worklogJSON) worklog() Worklog {
+	worklog := Worklog{ID: w.ID, Comment: w.Comment, Spent: time.Duration(w.TimeSpentSeconds) * time.Second}
+	if started, err := time.Parse(timeLayout, w.Started); err == nil {
+		worklog.Started = started
+	}
+	return worklog
+}
+
+// do sends a request to the REST API, encoding body and decoding the response into out as JSON
+func (c *Client) do(ctx context.Context, method, path string, body, out interface{}) error {
+	var payload io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		payload = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.api+path, payload)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.user != "" {
+		req.SetBasicAuth(c.user, c.token)
+	} else {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return ErrNotFound
+	case resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden:
+		return fmt.Errorf("%w (%s)", ErrUnauthorized, resp.Status)
+	case resp.StatusCode/100 != 2:
+		return newAPIError(resp)
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("invalid response from Jira: %w", err)
+	}
+	return nil
+}
+
+// APIError is a request Jira refused, with its reasons
+type APIError struct {
+	Status   string
+	Messages []string
+	Fields   map[string]string // Problems with particular fields, by field ID
+}
+
+func (e *APIError) Error() string {
+	messages := append([]string{}, e.Messages...)
+	fields := make([]string, 0, len(e.Fields))
+	for field := range e.Fields {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	for _, field := range fields {
+		messages = append(messages, field+": "+e.Fields[field])
+	}
+	if len(messages) == 0 {
+		return "Jira returned " + e.Status
+	}
+	return fmt.Sprintf("Jira returned %s: %s", e.Status, strings.Join(messages, "; "))
+}
+
+// newAPIError reads the reasons of a Jira error response, or the start of the
+// body if it is not one
+func newAPIError(resp *http.Response) *APIError {
+	data, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+
+	apiErr := &APIError{Status: resp.Status}
+	var body struct {
+		ErrorMessages []string          `json:"errorMessages"`
+		Errors        map[string]string `json:"errors"`
+	}
+	if json.Unmarshal(data, &body) == nil && (len(body.ErrorMessages) > 0 || len(body.Errors) > 0) {
+		apiErr.Messages = body.ErrorMessages
+		apiErr.Fields = body.Errors
+		return apiErr
+	}
+
+	if len(data) > 512 {
+		data = data[:512]
+	}
+	if text := strings.TrimSpace(string(data)); text != "" {
+		apiErr.Messages = []string{text}
+	}
+	return apiErr
+}
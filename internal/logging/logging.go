@@ -1,13 +1,17 @@
 package logging
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
 	"io"
 	"log"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 	"sync"
+	"time"
 )
 
 type Level int
@@ -19,18 +23,34 @@ const (
 	LevelError
 )
 
+// Log formats
+const (
+	FormatText = "text"
+	FormatJSON = "json" // One JSON object per line
+)
+
+// Fields are the structured data of an entry, such as the command, project
+// or task it is about
+type Fields map[string]interface{}
+
 var (
 	initOnce sync.Once
 	initErr  error
 
 	logger   *log.Logger
+	logFile  *rotatingFile
 	levelMu  sync.RWMutex
 	logLevel = LevelInfo
+
+	// format, context and verbose are guarded by levelMu too
+	format  = FormatText
+	context Fields
+	verbose *log.Logger
 )
 
-// Init sets up the logger output. Safe to call multiple times; only the first
-// call performs initialization.
-func Init(logPath string) error {
+// Init sets up the logger output, rotating the log file as set by rotation.
+// Safe to call multiple times; only the first call performs initialization.
+func Init(logPath string, rotation Rotation) error {
 	initOnce.Do(func() {
 		var writer io.Writer = os.Stderr
 
@@ -40,11 +60,12 @@ func Init(logPath string) error {
 				return
 			}
 
-			file, err := os.OpenFile(logPath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+			file, err := openRotatingFile(logPath, rotation)
 			if err != nil {
 				initErr = err
 				return
 			}
+			logFile = file
 			writer = file
 		}
 
@@ -54,6 +75,15 @@ func Init(logPath string) error {
 	return initErr
 }
 
+// Close closes the log file. Entries logged afterwards are dropped.
+func Close() error {
+	if logFile == nil {
+		return nil
+	}
+	logger = nil
+	return logFile.Close()
+}
+
 // SetLevel updates the global log level (debug, info, warn, error).
 func SetLevel(value string) {
 	levelMu.Lock()
@@ -61,6 +91,80 @@ func SetLevel(value string) {
 	logLevel = parseLevel(value)
 }
 
+// ParseLevel returns the level named value (debug, info, warn, error), info
+// if it names none
+func ParseLevel(value string) Level {
+	return parseLevel(value)
+}
+
+// EntryLevel returns the level of a line of the log, in either format, and
+// whether the line starts an entry
+func EntryLevel(line string) (Level, bool) {
+	if strings.HasPrefix(line, "{") {
+		var entry struct {
+			Level string `json:"level"`
+		}
+		if json.Unmarshal([]byte(line), &entry) != nil || entry.Level == "" {
+			return LevelInfo, false
+		}
+		return parseLevel(entry.Level), true
+	}
+	start := strings.Index(line, " [")
+	if start < 0 {
+		return LevelInfo, false
+	}
+	end := strings.Index(line[start:], "] ")
+	if end < 0 {
+		return LevelInfo, false
+	}
+	switch line[start+2 : start+end] {
+	case "DEBUG":
+		return LevelDebug, true
+	case "INFO":
+		return LevelInfo, true
+	case "WARN":
+		return LevelWarn, true
+	case "ERROR":
+		return LevelError, true
+	}
+	return LevelInfo, false
+}
+
+// SetFormat sets how entries are written: text or json
+func SetFormat(value string) error {
+	switch strings.ToLower(value) {
+	case "", FormatText:
+		value = FormatText
+	case FormatJSON:
+		value = FormatJSON
+	default:
+		return fmt.Errorf("unknown log format %q (use text or json)", value)
+	}
+	levelMu.Lock()
+	defer levelMu.Unlock()
+	format = value
+	return nil
+}
+
+// SetVerbose also writes every entry, whatever the log level, to w as text,
+// for --verbose. A nil w turns that off.
+func SetVerbose(w io.Writer) {
+	levelMu.Lock()
+	defer levelMu.Unlock()
+	verbose = nil
+	if w != nil {
+		verbose = log.New(w, "", log.Ltime|log.Lmicroseconds)
+	}
+}
+
+// SetContext sets fields every entry carries in JSON format, such as the
+// command being run
+func SetContext(fields Fields) {
+	levelMu.Lock()
+	defer levelMu.Unlock()
+	context = fields
+}
+
 func parseLevel(value string) Level {
 	switch strings.ToLower(value) {
 	case "debug":
@@ -75,29 +179,119 @@ func parseLevel(value string) Level {
 }
 
 func Debugf(format string, args ...interface{}) {
-	logf(LevelDebug, "DEBUG", format, args...)
+	logf(LevelDebug, format, args...)
 }
 
 func Infof(format string, args ...interface{}) {
-	logf(LevelInfo, "INFO", format, args...)
+	logf(LevelInfo, format, args...)
 }
 
 func Warnf(format string, args ...interface{}) {
-	logf(LevelWarn, "WARN", format, args...)
+	logf(LevelWarn, format, args...)
 }
 
 func Errorf(format string, args ...interface{}) {
-	logf(LevelError, "ERROR", format, args...)
+	logf(LevelError, format, args...)
+}
+
+// Log writes an entry with fields. In text format the fields follow the
+// message as key=value pairs.
+func Log(entryLevel Level, msg string, fields Fields) {
+	write(entryLevel, msg, fields)
+}
+
+func logf(entryLevel Level, format string, args ...interface{}) {
+	write(entryLevel, fmt.Sprintf(format, args...), nil)
 }
 
-func logf(entryLevel Level, prefix, format string, args ...interface{}) {
+func write(entryLevel Level, msg string, fields Fields) {
 	levelMu.RLock()
-	current := logLevel
+	current, entryFormat, entryContext, verboseLogger := logLevel, format, context, verbose
 	levelMu.RUnlock()
 
+	if verboseLogger != nil {
+		verboseLogger.Print(textEntry(entryLevel, msg, fields))
+	}
 	if entryLevel < current || logger == nil {
 		return
 	}
 
-	logger.Printf("[%s] %s", prefix, fmt.Sprintf(format, args...))
+	if entryFormat == FormatJSON {
+		logger.Writer().Write(jsonEntry(time.Now(), entryLevel, msg, entryContext, fields))
+		return
+	}
+	logger.Print(textEntry(entryLevel, msg, fields))
+}
+
+// textEntry returns an entry in text format, without its time: the level,
+// the message and the fields as key=value pairs
+func textEntry(entryLevel Level, msg string, fields Fields) string {
+	for _, key := range sortedKeys(fields) {
+		value := fmt.Sprint(fields[key])
+		if strings.ContainsAny(value, " \t\"=") {
+			value = fmt.Sprintf("%q", value)
+		}
+		msg += fmt.Sprintf(" %s=%s", key, value)
+	}
+	return fmt.Sprintf("[%s] %s", levelNames[entryLevel], msg)
+}
+
+// levelNames are the names entries give their level in text format
+var levelNames = map[Level]string{
+	LevelDebug: "DEBUG",
+	LevelInfo:  "INFO",
+	LevelWarn:  "WARN",
+	LevelError: "ERROR",
+}
+
+// jsonEntry returns an entry as a line holding a JSON object: its time, level
+// and message, then the context and fields by name
+func jsonEntry(at time.Time, entryLevel Level, msg string, context, fields Fields) []byte {
+	all := make(Fields, len(context)+len(fields))
+	for key, value := range context {
+		all[key] = value
+	}
+	for key, value := range fields {
+		all[key] = value
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString(`{"time":`)
+	writeJSON(&buf, at.Format(time.RFC3339Nano))
+	buf.WriteString(`,"level":`)
+	writeJSON(&buf, strings.ToLower(levelNames[entryLevel]))
+	buf.WriteString(`,"msg":`)
+	writeJSON(&buf, msg)
+	for _, key := range sortedKeys(all) {
+		if key == "time" || key == "level" || key == "msg" {
+			continue
+		}
+		value := all[key]
+		if err, ok := value.(error); ok {
+			value = err.Error()
+		}
+		buf.WriteByte(',')
+		writeJSON(&buf, key)
+		buf.WriteByte(':')
+		writeJSON(&buf, value)
+	}
+	buf.WriteString("}\n")
+	return buf.Bytes()
+}
+
+func writeJSON(buf *bytes.Buffer, value interface{}) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		data, _ = json.Marshal(fmt.Sprint(value))
+	}
+	buf.Write(data)
+}
+
+func sortedKeys(fields Fields) []string {
+	keys := make([]string, 0, len(fields))
+	for key := range fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
 }
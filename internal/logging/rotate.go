@@ -0,0 +1,187 @@
+package logging
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Rotation sets when the log file is rotated and how long rotated logs are
+// kept. Zero values turn each limit off.
+type Rotation struct {
+	MaxSize   int64         // Bytes the log may grow to
+	MaxAge    time.Duration // How long one file collects entries
+	Retention time.Duration // How long rotated logs are kept
+}
+
+// rotatedLayout is the time stamp in rotated log names, qix-20261016-153000.log
+const rotatedLayout = "20060102-150405"
+
+// entryLayout is the time stamp at the start of each entry in text format, as
+// written with log.LstdFlags|log.Lmicroseconds
+const entryLayout = "2006/01/02 15:04:05"
+
+// rotatingFile is a log file that moves itself aside once it is too large or
+// too old, and removes rotated logs past their retention
+type rotatingFile struct {
+	mu       sync.Mutex
+	path     string
+	rotation Rotation
+	file     *os.File
+	size     int64
+	started  time.Time // When the first entry in the file was written
+}
+
+func openRotatingFile(path string, rotation Rotation) (*rotatingFile, error) {
+	f := &rotatingFile{path: path, rotation: rotation}
+	if err := f.open(); err != nil {
+		return nil, err
+	}
+	if f.due(time.Now()) {
+		if err := f.rotate(time.Now()); err != nil {
+			return nil, err
+		}
+	}
+	f.removeExpired(time.Now())
+	return f, nil
+}
+
+func (f *rotatingFile) open() error {
+	file, err := os.OpenFile(f.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return err
+	}
+	f.file, f.size, f.started = file, info.Size(), firstEntryTime(f.path)
+	return nil
+}
+
+// Write appends p to the log, rotating it first if it is due
+func (f *rotatingFile) Write(p []byte) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	now := time.Now()
+	if f.due(now) {
+		// Keep logging to the current file if it can't be moved aside
+		_ = f.rotate(now)
+	}
+	if f.started.IsZero() {
+		f.started = now
+	}
+	n, err := f.file.Write(p)
+	f.size += int64(n)
+	return n, err
+}
+
+// Close closes the log file
+func (f *rotatingFile) Close() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.file.Close()
+}
+
+// due reports whether the file has outgrown its size or age
+func (f *rotatingFile) due(now time.Time) bool {
+	if f.size == 0 {
+		return false
+	}
+	if f.rotation.MaxSize > 0 && f.size >= f.rotation.MaxSize {
+		return true
+	}
+	return f.rotation.MaxAge > 0 && !f.started.IsZero() && now.Sub(f.started) >= f.rotation.MaxAge
+}
+
+// rotate renames the log after the time it was rotated and starts a new one
+func (f *rotatingFile) rotate(now time.Time) error {
+	if err := f.file.Close(); err != nil {
+		return err
+	}
+	ext := filepath.Ext(f.path)
+	rotated := strings.TrimSuffix(f.path, ext) + "-" + now.Format(rotatedLayout) + ext
+	if err := os.Rename(f.path, rotated); err != nil {
+		// Reopen so entries aren't lost
+		if openErr := f.open(); openErr != nil {
+			return openErr
+		}
+		return err
+	}
+	if err := f.open(); err != nil {
+		return err
+	}
+	f.removeExpired(now)
+	return nil
+}
+
+// removeExpired removes rotated logs older than the retention
+func (f *rotatingFile) removeExpired(now time.Time) {
+	if f.rotation.Retention <= 0 {
+		return
+	}
+	for _, path := range RotatedFiles(f.path) {
+		if info, err := os.Stat(path); err == nil && now.Sub(info.ModTime()) > f.rotation.Retention {
+			os.Remove(path)
+		}
+	}
+}
+
+// RotatedFiles returns the rotated logs of the log at path, oldest first
+func RotatedFiles(path string) []string {
+	ext := filepath.Ext(path)
+	matches, err := filepath.Glob(strings.TrimSuffix(path, ext) + "-*" + ext)
+	if err != nil {
+		return nil
+	}
+	rotated := make([]string, 0, len(matches))
+	prefix := strings.TrimSuffix(filepath.Base(path), ext) + "-"
+	for _, match := range matches {
+		stamp := strings.TrimSuffix(strings.TrimPrefix(filepath.Base(match), prefix), ext)
+		if _, err := time.Parse(rotatedLayout, stamp); err == nil {
+			rotated = append(rotated, match)
+		}
+	}
+	// The time stamps sort in time order
+	sort.Strings(rotated)
+	return rotated
+}
+
+// firstEntryTime returns when the first entry of a log was written, or the
+// zero time if it has none
+func firstEntryTime(path string) time.Time {
+	file, err := os.Open(path)
+	if err != nil {
+		return time.Time{}
+	}
+	defer file.Close()
+
+	line, err := bufio.NewReader(file).ReadString('\n')
+	if err != nil {
+		return time.Time{}
+	}
+	if strings.HasPrefix(line, "{") {
+		var entry struct {
+			Time time.Time `json:"time"`
+		}
+		if json.Unmarshal([]byte(line), &entry) != nil {
+			return time.Time{}
+		}
+		return entry.Time
+	}
+	if len(line) < len(entryLayout) {
+		return time.Time{}
+	}
+	t, err := time.ParseInLocation(entryLayout, line[:len(entryLayout)], time.Local)
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
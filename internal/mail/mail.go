@@ -0,0 +1,198 @@
+// Package mail sends plain-text email over SMTP or through a local
+// sendmail-compatible command.
+package mail
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/tls"
+	"encoding/hex"
+	"fmt"
+	"mime"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Message is a plain-text email
+type Message struct {
+	From    string
+	To      []string
+	Subject string
+	Body    string
+	Date    time.Time // Now if zero
+}
+
+// Check returns an error if the message has no valid sender or recipients
+func (m Message) Check() error {
+	if m.From == "" {
+		return fmt.Errorf("no sender address")
+	}
+	if _, err := mail.ParseAddress(m.From); err != nil {
+		return fmt.Errorf("invalid sender address %q: %w", m.From, err)
+	}
+	if len(m.To) == 0 {
+		return fmt.Errorf("no recipient addresses")
+	}
+	for _, to := range m.To {
+		if _, err := mail.ParseAddress(to); err != nil {
+			return fmt.Errorf("invalid recipient address %q: %w", to, err)
+		}
+	}
+	return nil
+}
+
+// Bytes returns the message in RFC 5322 format, with the body quoted-printable
+// encoded so any text survives transport
+func (m Message) Bytes() []byte {
+	date := m.Date
+	if date.IsZero() {
+		date = time.Now()
+	}
+
+	var out bytes.Buffer
+	header := func(name, value string) {
+		fmt.Fprintf(&out, "%s: %s\r\n", name, value)
+	}
+	header("From", m.From)
+	header("To", strings.Join(m.To, ", "))
+	header("Subject", mime.QEncoding.Encode("utf-8", m.Subject))
+	header("Date", date.Format(time.RFC1123Z))
+	header("Message-ID", messageID(m.From))
+	header("MIME-Version", "1.0")
+	header("Content-Type", "text/plain; charset=utf-8")
+	header("Content-Transfer-Encoding", "quoted-printable")
+	out.WriteString("\r\n")
+
+	body := quotedprintable.NewWriter(&out)
+	body.Write([]byte(strings.ReplaceAll(m.Body, "\n", "\r\n")))
+	body.Close()
+	return out.Bytes()
+}
+
+// messageID returns a unique Message-ID in the sender's domain
+func messageID(from string) string {
+	domain := "localhost"
+	if addr, err := mail.ParseAddress(from); err == nil {
+		if at := strings.LastIndex(addr.Address, "@"); at >= 0 {
+			domain = addr.Address[at+1:]
+		}
+	}
+	random := make([]byte, 8)
+	rand.Read(random)
+	return fmt.Sprintf("<%d.%s@%s>", time.Now().Unix(), hex.EncodeToString(random), domain)
+}
+
+// Sender delivers messages
+type Sender interface {
+	Send(m Message) error
+}
+
+// SMTP sends through an SMTP server. Port 465 uses implicit TLS; on other
+// ports STARTTLS is used when the server offers it.
+type SMTP struct {
+	Host     string
+	Port     int
+	Username string // Authenticate with PLAIN if set
+	Password string
+	Timeout  time.Duration // 30 seconds if zero
+}
+
+// Send delivers a message to its recipients
+func (s SMTP) Send(m Message) error {
+	if err := m.Check(); err != nil {
+		return err
+	}
+	timeout := s.Timeout
+	if timeout == 0 {
+		timeout = 30 * time.Second
+	}
+	addr := net.JoinHostPort(s.Host, strconv.Itoa(s.Port))
+	tlsConfig := &tls.Config{ServerName: s.Host}
+
+	var conn net.Conn
+	var err error
+	dialer := &net.Dialer{Timeout: timeout}
+	if s.Port == 465 {
+		conn, err = tls.DialWithDialer(dialer, "tcp", addr, tlsConfig)
+	} else {
+		conn, err = dialer.Dial("tcp", addr)
+	}
+	if err != nil {
+		return err
+	}
+	conn.SetDeadline(time.Now().Add(timeout))
+
+	client, err := smtp.NewClient(conn, s.Host)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	defer client.Close()
+
+	if ok, _ := client.Extension("STARTTLS"); ok && s.Port != 465 {
+		if err := client.StartTLS(tlsConfig); err != nil {
+			return fmt.Errorf("STARTTLS: %w", err)
+		}
+	}
+	if s.Username != "" {
+		if err := client.Auth(smtp.PlainAuth("", s.Username, s.Password, s.Host)); err != nil {
+			return fmt.Errorf("authentication failed: %w", err)
+		}
+	}
+
+	from, _ := mail.ParseAddress(m.From)
+	if err := client.Mail(from.Address); err != nil {
+		return err
+	}
+	for _, to := range m.To {
+		addr, _ := mail.ParseAddress(to)
+		if err := client.Rcpt(addr.Address); err != nil {
+			return fmt.Errorf("recipient %s: %w", addr.Address, err)
+		}
+	}
+	data, err := client.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := data.Write(m.Bytes()); err != nil {
+		return err
+	}
+	if err := data.Close(); err != nil {
+		return err
+	}
+	return client.Quit()
+}
+
+// Command pipes messages to a sendmail-compatible command, such as
+// "sendmail -t" or "msmtp -t", that reads the recipients from the headers
+type Command struct {
+	Args []string
+}
+
+// Send delivers a message to its recipients
+func (c Command) Send(m Message) error {
+	if err := m.Check(); err != nil {
+		return err
+	}
+	if len(c.Args) == 0 {
+		return fmt.Errorf("no mail command")
+	}
+
+	cmd := exec.Command(c.Args[0], c.Args[1:]...)
+	cmd.Stdin = bytes.NewReader(m.Bytes())
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return fmt.Errorf("%s: %w: %s", c.Args[0], err, msg)
+		}
+		return fmt.Errorf("%s: %w", c.Args[0], err)
+	}
+	return nil
+}
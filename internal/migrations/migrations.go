@@ -0,0 +1,176 @@
+// Package migrations upgrades QIX data files written by older versions of the schema.
+//
+// Every data file carries a schema_version. Files without one are version 0.
+// Each Migration moves one kind of document forward by a single version, and
+// Upgrade applies them in order until the document reaches Latest.
+package migrations
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Kind identifies a type of data file
+type Kind string
+
+const (
+	KindProject  Kind = "project"
+	KindTracking Kind = "tracking"
+	KindIndex    Kind = "index"
+)
+
+// VersionKey is the JSON field holding a document's schema version
+const VersionKey = "schema_version"
+
+// Migration upgrades a document of one kind from version From to From+1
+type Migration struct {
+	Kind        Kind
+	From        int
+	Description string
+	Apply       func(doc map[string]interface{}) (map[string]interface{}, error)
+}
+
+// registry lists all migrations; add new ones at the end
+var registry = []Migration{
+	{
+		Kind:        KindProject,
+		From:        0,
+		Description: "add schema version and replace null lists with empty ones",
+		Apply:       migrateProjectV0,
+	},
+	{
+		Kind:        KindProject,
+		From:        1,
+		Description: "move time entries into monthly files next to the project (applied on save)",
+		Apply:       migrateProjectV1,
+	},
+	{
+		Kind:        KindTracking,
+		From:        0,
+		Description: "add schema version and replace null session history",
+		Apply:       migrateTrackingV0,
+	},
+	{
+		Kind:        KindIndex,
+		From:        0,
+		Description: "move task locations under a versioned \"tasks\" key",
+		Apply:       migrateIndexV0,
+	},
+}
+
+// Latest returns the current schema version for a kind of file
+func Latest(kind Kind) int {
+	latest := 0
+	for _, m := range registry {
+		if m.Kind == kind && m.From+1 > latest {
+			latest = m.From + 1
+		}
+	}
+	return latest
+}
+
+// Version returns the schema version recorded in a JSON document, or 0 if it has none
+func Version(data []byte) (int, error) {
+	var doc map[string]interface{}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return 0, err
+	}
+	return versionOf(doc), nil
+}
+
+// Pending returns the migrations needed to bring a document of the given version up to date
+func Pending(kind Kind, version int) []Migration {
+	pending := make([]Migration, 0)
+	for v := version; v < Latest(kind); v++ {
+		for _, m := range registry {
+			if m.Kind == kind && m.From == v {
+				pending = append(pending, m)
+			}
+		}
+	}
+	return pending
+}
+
+// Upgrade migrates a JSON document to the latest schema version.
+// It returns the upgraded document and the version it started at; data is returned unchanged if already current.
+func Upgrade(kind Kind, data []byte) ([]byte, int, error) {
+	var doc map[string]interface{}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, 0, err
+	}
+
+	from := versionOf(doc)
+	latest := Latest(kind)
+	if from > latest {
+		return nil, from, fmt.Errorf("%s file has schema version %d but this qix only supports up to %d; upgrade qix", kind, from, latest)
+	}
+	if from == latest {
+		return data, from, nil
+	}
+
+	for _, m := range Pending(kind, from) {
+		upgraded, err := m.Apply(doc)
+		if err != nil {
+			return nil, from, fmt.Errorf("%s migration %d->%d failed: %w", kind, m.From, m.From+1, err)
+		}
+		doc = upgraded
+		doc[VersionKey] = m.From + 1
+	}
+
+	upgraded, err := json.Marshal(doc)
+	if err != nil {
+		return nil, from, err
+	}
+	return upgraded, from, nil
+}
+
+// versionOf reads the schema version from a decoded document
+func versionOf(doc map[string]interface{}) int {
+	if v, ok := doc[VersionKey].(float64); ok {
+		return int(v)
+	}
+	return 0
+}
+
+// emptyListIfNull replaces a missing or null list field with an empty one
+func emptyListIfNull(doc map[string]interface{}, key string) {
+	if doc[key] == nil {
+		doc[key] = []interface{}{}
+	}
+}
+
+func migrateProjectV0(doc map[string]interface{}) (map[string]interface{}, error) {
+	for _, key := range []string{"tags", "modules", "tasks", "sprints"} {
+		emptyListIfNull(doc, key)
+	}
+
+	modules, ok := doc["modules"].([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("modules is not a list")
+	}
+	for _, m := range modules {
+		module, ok := m.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("module is not an object")
+		}
+		emptyListIfNull(module, "tags")
+		emptyListIfNull(module, "tasks")
+	}
+
+	return doc, nil
+}
+
+func migrateProjectV1(doc map[string]interface{}) (map[string]interface{}, error) {
+	// Inline entries are still read; the storage layer moves them out when the project is saved
+	return doc, nil
+}
+
+func migrateTrackingV0(doc map[string]interface{}) (map[string]interface{}, error) {
+	emptyListIfNull(doc, "sessions")
+	return doc, nil
+}
+
+func migrateIndexV0(doc map[string]interface{}) (map[string]interface{}, error) {
+	// Version 0 indexes were a bare map of task ID to location
+	return map[string]interface{}{"tasks": doc}, nil
+}
@@ -0,0 +1,309 @@
+// Package notify posts messages about qix events to webhooks: plain JSON
+// endpoints, or Slack and Discord incoming webhooks.
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// Events notifications are sent for, named like those of the event bus
+const (
+	TaskDone     = "task-done"
+	TaskBlocked  = "task-blocked"
+	SprintClosed = "sprint-closed"
+	DailySummary = "daily-summary"
+	Test         = "test" // Sent by 'notify test' only
+)
+
+// Events lists the events a webhook can subscribe to
+var Events = []string{TaskDone, TaskBlocked, SprintClosed, DailySummary}
+
+// oldNames are the names events had before they were named like those of the
+// event bus, still accepted in the config file
+var oldNames = map[string]string{
+	"task_completed": TaskDone,
+	"task_blocked":   TaskBlocked,
+	"sprint_closed":  SprintClosed,
+	"daily_summary":  DailySummary,
+}
+
+// EventName returns the name of an event given by its current or old name
+func EventName(event string) string {
+	if name, ok := oldNames[event]; ok {
+		return name
+	}
+	return event
+}
+
+// Webhook formats
+const (
+	FormatJSON    = "json"
+	FormatSlack   = "slack"
+	FormatDiscord = "discord"
+)
+
+// DefaultTemplates are the messages of each event unless the config overrides
+// them. They are text/template templates executed with the Event.
+var DefaultTemplates = map[string]string{
+	TaskDone:     `✅ [{{.Project}}] {{.Task.Title}} ({{.Task.ID}}) is done{{if .Task.Hours}} after {{hours .Task.Hours}}{{end}}`,
+	TaskBlocked:  `⛔ [{{.Project}}] {{.Task.Title}} ({{.Task.ID}}) is blocked`,
+	SprintClosed: `🏁 [{{.Project}}] Sprint {{.Sprint.Name}} closed: {{.Sprint.Done}}/{{.Sprint.Tasks}} tasks done, {{hours .Sprint.Hours}} logged`,
+	DailySummary: `📊 {{.Summary.Date}}: {{hours .Summary.Hours}} logged, {{len .Summary.Completed}} task(s) done` +
+		`{{range .Summary.Worked}}` + "\n" + `• [{{.Project}}] {{.Title}}: {{hours .Hours}}{{if eq .Status "done"}} ✅{{end}}{{end}}`,
+	Test: `👋 qix notifications reach this webhook`,
+}
+
+// Event is something that happened in qix. It is the payload of JSON webhooks,
+// with Text set to the rendered message.
+type Event struct {
+	Type    string       `json:"event"`
+	At      time.Time    `json:"at"`
+	Text    string       `json:"text"`
+	Project string       `json:"project,omitempty"`
+	Task    *TaskInfo    `json:"task,omitempty"`
+	Sprint  *SprintInfo  `json:"sprint,omitempty"`
+	Summary *SummaryInfo `json:"summary,omitempty"`
+}
+
+// TaskInfo is the task an event is about
+type TaskInfo struct {
+	ID        string   `json:"id"`
+	Title     string   `json:"title"`
+	Project   string   `json:"project"`
+	Module    string   `json:"module,omitempty"`
+	Status    string   `json:"status"`
+	Previous  string   `json:"previous_status,omitempty"`
+	Priority  string   `json:"priority,omitempty"`
+	Assignee  string   `json:"assignee,omitempty"`
+	JiraIssue string   `json:"jira_issue,omitempty"`
+	Tags      []string `json:"tags,omitempty"`
+	Hours     float64  `json:"hours"` // Logged in total, or on the day in a summary
+}
+
+// SprintInfo is the sprint a sprint-closed event is about
+type SprintInfo struct {
+	Name      string  `json:"name"`
+	StartDate string  `json:"start_date"`
+	EndDate   string  `json:"end_date"`
+	Tasks     int     `json:"tasks"`
+	Done      int     `json:"done"`
+	Hours     float64 `json:"hours"` // Logged on the sprint's tasks during the sprint
+}
+
+// SummaryInfo is a day's work, for daily-summary events
+type SummaryInfo struct {
+	Date      string     `json:"date"`
+	Hours     float64    `json:"hours"`
+	Worked    []TaskInfo `json:"worked"`    // Tasks time was logged on
+	Completed []TaskInfo `json:"completed"` // Tasks that were done that day
+}
+
+// Webhook is a URL events are posted to
+type Webhook struct {
+	Name   string
+	URL    string
+	Format string   // json, slack or discord; guessed from the URL if empty
+	Events []string // Events to post; all if empty
+}
+
+// Wants reports whether the webhook takes an event
+func (w Webhook) Wants(event string) bool {
+	if event == Test || len(w.Events) == 0 {
+		return true
+	}
+	for _, e := range w.Events {
+		if EventName(e) == event {
+			return true
+		}
+	}
+	return false
+}
+
+// Kind returns the webhook's format, guessing it from the URL if not set
+func (w Webhook) Kind() string {
+	if w.Format != "" {
+		return strings.ToLower(w.Format)
+	}
+	switch {
+	case strings.Contains(w.URL, "hooks.slack.com"):
+		return FormatSlack
+	case strings.Contains(w.URL, "discord.com/api/webhooks"), strings.Contains(w.URL, "discordapp.com/api/webhooks"):
+		return FormatDiscord
+	default:
+		return FormatJSON
+	}
+}
+
+// Check returns an error if the webhook cannot be posted to
+func (w Webhook) Check() error {
+	if !strings.HasPrefix(w.URL, "http://") && !strings.HasPrefix(w.URL, "https://") {
+		return fmt.Errorf("webhook %s: not an http(s) URL: %s", w.Name, w.URL)
+	}
+	switch w.Kind() {
+	case FormatJSON, FormatSlack, FormatDiscord:
+	default:
+		return fmt.Errorf("webhook %s: unknown format %q (use json, slack or discord)", w.Name, w.Format)
+	}
+	for _, event := range w.Events {
+		if !knownEvent(EventName(event)) {
+			return fmt.Errorf("webhook %s: unknown event %q (use %s)", w.Name, event, strings.Join(Events, ", "))
+		}
+	}
+	return nil
+}
+
+func knownEvent(event string) bool {
+	for _, e := range Events {
+		if e == event {
+			return true
+		}
+	}
+	return false
+}
+
+// Notifier posts events to a set of webhooks
+type Notifier struct {
+	hooks     []Webhook
+	templates map[string]string
+	client    *http.Client
+}
+
+// New returns a notifier for hooks. templates override DefaultTemplates by
+// event, given by its current or old name.
+func New(hooks []Webhook, templates map[string]string) *Notifier {
+	sorted := append([]Webhook(nil), hooks...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Name < sorted[j].Name })
+	named := make(map[string]string, len(templates))
+	for event, text := range templates {
+		if _, old := oldNames[event]; old {
+			if _, ok := templates[EventName(event)]; ok {
+				continue
+			}
+		}
+		named[EventName(event)] = text
+	}
+	return &Notifier{
+		hooks:     sorted,
+		templates: named,
+		client:    &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// Enabled reports whether any webhook is configured
+func (n *Notifier) Enabled() bool {
+	return len(n.hooks) > 0
+}
+
+// Hooks returns the configured webhooks by name
+func (n *Notifier) Hooks() []Webhook {
+	return n.hooks
+}
+
+// Render returns an event's message from its template
+func (n *Notifier) Render(event Event) (string, error) {
+	text, ok := n.templates[event.Type]
+	if !ok || strings.TrimSpace(text) == "" {
+		text = DefaultTemplates[event.Type]
+	}
+	tmpl, err := template.New(event.Type).Funcs(template.FuncMap{
+		"hours": func(h float64) string { return fmt.Sprintf("%.2fh", h) },
+	}).Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("template for %s: %w", event.Type, err)
+	}
+
+	var out bytes.Buffer
+	if err := tmpl.Execute(&out, event); err != nil {
+		return "", fmt.Errorf("template for %s: %w", event.Type, err)
+	}
+	return out.String(), nil
+}
+
+// Send posts an event to every webhook that wants it, returning an error for
+// each one it could not be delivered to
+func (n *Notifier) Send(ctx context.Context, event Event) []error {
+	if event.At.IsZero() {
+		event.At = time.Now()
+	}
+	text, err := n.Render(event)
+	if err != nil {
+		return []error{err}
+	}
+	event.Text = text
+
+	errs := make([]error, 0)
+	for _, hook := range n.hooks {
+		if !hook.Wants(event.Type) {
+			continue
+		}
+		if err := n.post(ctx, hook, event); err != nil {
+			errs = append(errs, fmt.Errorf("webhook %s: %w", hook.Name, err))
+		}
+	}
+	return errs
+}
+
+// SendTo posts an event to one webhook, whether or not it subscribes to it
+func (n *Notifier) SendTo(ctx context.Context, hook Webhook, event Event) error {
+	if event.At.IsZero() {
+		event.At = time.Now()
+	}
+	text, err := n.Render(event)
+	if err != nil {
+		return err
+	}
+	event.Text = text
+	return n.post(ctx, hook, event)
+}
+
+// post sends an event in the webhook's format
+func (n *Notifier) post(ctx context.Context, hook Webhook, event Event) error {
+	if err := hook.Check(); err != nil {
+		return err
+	}
+
+	var body interface{}
+	switch hook.Kind() {
+	case FormatSlack:
+		body = map[string]string{"text": event.Text}
+	case FormatDiscord:
+		body = map[string]string{"content": event.Text}
+	default:
+		body = event
+	}
+	data, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, hook.URL, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "qix")
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode/100 != 2 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		if text := strings.TrimSpace(string(msg)); text != "" {
+			return fmt.Errorf("%s: %s", resp.Status, text)
+		}
+		return fmt.Errorf("%s", resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,84 @@
+// Package remote stores backup archives on remote targets: S3-compatible object
+// storage, WebDAV servers and SFTP hosts.
+package remote
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+)
+
+// Object is a file stored on a remote target
+type Object struct {
+	Name    string
+	Size    int64
+	ModTime time.Time
+}
+
+// Target is a remote location that backups can be uploaded to and downloaded from.
+// Names are plain file names inside the target's directory.
+type Target interface {
+	Upload(ctx context.Context, name string, data []byte) error
+	Download(ctx context.Context, name string) ([]byte, error)
+	List(ctx context.Context) ([]Object, error)
+	String() string
+}
+
+// Options holds the settings and credentials used to reach remote targets
+type Options struct {
+	S3Region       string
+	S3Endpoint     string // For S3-compatible services; empty means AWS
+	S3AccessKey    string
+	S3SecretKey    string
+	S3SessionToken string
+	WebDAVUser     string
+	WebDAVPassword string
+}
+
+// Open returns the target for a URL:
+//
+//	s3://bucket/prefix
+//	webdav://host/path (HTTPS) or webdav+http://host/path
+//	sftp://[user@]host[:port]/path
+func Open(rawURL string, opts Options) (Target, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid remote URL '%s': %w", rawURL, err)
+	}
+	if u.Host == "" {
+		return nil, fmt.Errorf("invalid remote URL '%s': missing host or bucket", rawURL)
+	}
+	dir := strings.Trim(u.Path, "/")
+
+	switch u.Scheme {
+	case "s3":
+		return newS3Target(u.Host, dir, opts)
+	case "webdav", "webdav+https":
+		return newWebDAVTarget("https", u, dir, opts), nil
+	case "webdav+http":
+		return newWebDAVTarget("http", u, dir, opts), nil
+	case "sftp":
+		return newSFTPTarget(u, dir), nil
+	default:
+		return nil, fmt.Errorf("unsupported remote '%s' (use s3://, webdav://, webdav+http:// or sftp://)", u.Scheme)
+	}
+}
+
+// joinKey joins a directory and a file name into a slash-separated key
+func joinKey(dir, name string) string {
+	if dir == "" {
+		return name
+	}
+	return path.Join(dir, name)
+}
+
+// checkName rejects names that would escape the target's directory
+func checkName(name string) error {
+	if name == "" || strings.ContainsAny(name, "/\\") || name == "." || name == ".." {
+		return fmt.Errorf("invalid remote file name '%s'", name)
+	}
+	return nil
+}
@@ -0,0 +1,159 @@
+package remote
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// sftpTarget stores files on an SFTP host through the system sftp client, so keys,
+// agents and ~/.ssh/config work as they do for the user's own sessions
+type sftpTarget struct {
+	host string // [user@]host
+	port string
+	dir  string
+}
+
+func newSFTPTarget(u *url.URL, dir string) *sftpTarget {
+	host := u.Hostname()
+	if u.User != nil {
+		host = u.User.Username() + "@" + host
+	}
+	return &sftpTarget{host: host, port: u.Port(), dir: dir}
+}
+
+func (t *sftpTarget) String() string {
+	s := "sftp://" + t.host
+	if t.port != "" {
+		s += ":" + t.port
+	}
+	return s + "/" + t.dir
+}
+
+// Upload copies a file to the remote directory, creating the directory if needed
+func (t *sftpTarget) Upload(ctx context.Context, name string, data []byte) error {
+	if err := checkName(name); err != nil {
+		return err
+	}
+
+	tmpDir, err := os.MkdirTemp("", "qix-sftp-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tmpDir)
+
+	local := filepath.Join(tmpDir, name)
+	if err := os.WriteFile(local, data, 0600); err != nil {
+		return err
+	}
+
+	// A leading '-' lets the batch continue when the directory already exists
+	batch := ""
+	if t.dir != "" {
+		batch += "-mkdir " + sftpQuote(t.dir) + "\n"
+	}
+	batch += "put " + sftpQuote(local) + " " + sftpQuote(joinKey(t.dir, name)) + "\n"
+
+	_, err = t.run(ctx, batch)
+	return err
+}
+
+// Download copies a file from the remote directory
+func (t *sftpTarget) Download(ctx context.Context, name string) ([]byte, error) {
+	if err := checkName(name); err != nil {
+		return nil, err
+	}
+
+	tmpDir, err := os.MkdirTemp("", "qix-sftp-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(tmpDir)
+
+	local := filepath.Join(tmpDir, name)
+	if _, err := t.run(ctx, "get "+sftpQuote(joinKey(t.dir, name))+" "+sftpQuote(local)+"\n"); err != nil {
+		return nil, err
+	}
+	return os.ReadFile(local)
+}
+
+// List returns the files in the remote directory, parsed from 'ls -l' output
+func (t *sftpTarget) List(ctx context.Context) ([]Object, error) {
+	dir := t.dir
+	if dir == "" {
+		dir = "."
+	}
+	out, err := t.run(ctx, "ls -l "+sftpQuote(dir)+"\n")
+	if err != nil {
+		return nil, err
+	}
+
+	objects := make([]Object, 0)
+	for _, line := range strings.Split(out, "\n") {
+		fields := strings.Fields(line)
+		// -rw-r--r--  1 user group  1234 Oct 16 08:30 name
+		if len(fields) < 9 || !strings.HasPrefix(fields[0], "-") {
+			continue
+		}
+		size, err := strconv.ParseInt(fields[4], 10, 64)
+		if err != nil {
+			continue
+		}
+		objects = append(objects, Object{
+			Name:    filepath.Base(strings.Join(fields[8:], " ")),
+			Size:    size,
+			ModTime: parseLsTime(fields[5], fields[6], fields[7]),
+		})
+	}
+	return objects, nil
+}
+
+// run executes sftp commands in batch mode and returns the output
+func (t *sftpTarget) run(ctx context.Context, batch string) (string, error) {
+	if _, err := exec.LookPath("sftp"); err != nil {
+		return "", fmt.Errorf("sftp client not found; install OpenSSH to use sftp:// remotes")
+	}
+
+	args := []string{"-b", "-", "-o", "BatchMode=yes"}
+	if t.port != "" {
+		args = append(args, "-P", t.port)
+	}
+	args = append(args, t.host)
+
+	cmd := exec.CommandContext(ctx, "sftp", args...)
+	cmd.Stdin = strings.NewReader(batch)
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return "", fmt.Errorf("sftp failed: %v %s", err, strings.TrimSpace(string(out)))
+	}
+	return string(out), nil
+}
+
+// sftpQuote quotes a path for an sftp batch command
+func sftpQuote(s string) string {
+	return `"` + strings.ReplaceAll(s, `"`, `\"`) + `"`
+}
+
+// parseLsTime parses the date columns of 'ls -l': "Oct 16 08:30" for recent files, "Oct 16 2025" otherwise
+func parseLsTime(month, day, clock string) time.Time {
+	if strings.Contains(clock, ":") {
+		now := time.Now()
+		t, err := time.ParseInLocation("Jan 2 15:04 2006", fmt.Sprintf("%s %s %s %d", month, day, clock, now.Year()), time.Local)
+		if err != nil {
+			return time.Time{}
+		}
+		// Times without a year are within the last six months
+		if t.After(now.Add(24 * time.Hour)) {
+			t = t.AddDate(-1, 0, 0)
+		}
+		return t
+	}
+	t, _ := time.ParseInLocation("Jan 2 2006", fmt.Sprintf("%s %s %s", month, day, clock), time.Local)
+	return t
+}
@@ -0,0 +1,172 @@
+package remote
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+)
+
+// webdavTarget stores files in a WebDAV collection
+type webdavTarget struct {
+	base     url.URL // Collection URL, ending in a slash
+	user     string
+	password string
+	client   *http.Client
+}
+
+func newWebDAVTarget(scheme string, u *url.URL, dir string, opts Options) *webdavTarget {
+	target := &webdavTarget{
+		base:     url.URL{Scheme: scheme, Host: u.Host, Path: "/" + dir + "/"},
+		user:     opts.WebDAVUser,
+		password: opts.WebDAVPassword,
+		client:   &http.Client{Timeout: 10 * time.Minute},
+	}
+	if dir == "" {
+		target.base.Path = "/"
+	}
+	// Credentials in the URL take precedence over configured ones
+	if u.User != nil {
+		target.user = u.User.Username()
+		if password, ok := u.User.Password(); ok {
+			target.password = password
+		}
+	}
+	return target
+}
+
+func (t *webdavTarget) String() string {
+	return t.base.String()
+}
+
+// Upload puts a file into the collection, creating the collection if needed
+func (t *webdavTarget) Upload(ctx context.Context, name string, data []byte) error {
+	if err := checkName(name); err != nil {
+		return err
+	}
+
+	// MKCOL fails harmlessly with 405 when the collection already exists
+	if resp, err := t.do(ctx, "MKCOL", t.base.String(), nil, nil); err == nil {
+		resp.Body.Close()
+	}
+
+	resp, err := t.do(ctx, http.MethodPut, t.fileURL(name), bytes.NewReader(data), nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("WebDAV upload failed: %s", resp.Status)
+	}
+	return nil
+}
+
+// Download gets a file from the collection
+func (t *webdavTarget) Download(ctx context.Context, name string) ([]byte, error) {
+	if err := checkName(name); err != nil {
+		return nil, err
+	}
+
+	resp, err := t.do(ctx, http.MethodGet, t.fileURL(name), nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("%s not found on %s", name, t)
+	}
+	if resp.StatusCode/100 != 2 {
+		return nil, fmt.Errorf("WebDAV download failed: %s", resp.Status)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+// webdavMultistatus is the response to a PROPFIND request
+type webdavMultistatus struct {
+	Responses []struct {
+		Href     string `xml:"href"`
+		Propstat []struct {
+			Prop struct {
+				ContentLength int64  `xml:"getcontentlength"`
+				LastModified  string `xml:"getlastmodified"`
+				ResourceType  struct {
+					Collection *struct{} `xml:"collection"`
+				} `xml:"resourcetype"`
+			} `xml:"prop"`
+		} `xml:"propstat"`
+	} `xml:"response"`
+}
+
+const webdavPropfind = `<?xml version="1.0" encoding="utf-8"?>
+<propfind xmlns="DAV:"><prop><getcontentlength/><getlastmodified/><resourcetype/></prop></propfind>`
+
+// List returns the files directly inside the collection
+func (t *webdavTarget) List(ctx context.Context) ([]Object, error) {
+	resp, err := t.do(ctx, "PROPFIND", t.base.String(), strings.NewReader(webdavPropfind), map[string]string{
+		"Depth":        "1",
+		"Content-Type": "application/xml",
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return []Object{}, nil
+	}
+	if resp.StatusCode != http.StatusMultiStatus {
+		return nil, fmt.Errorf("WebDAV listing failed: %s", resp.Status)
+	}
+
+	var result webdavMultistatus
+	if err := xml.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("invalid WebDAV listing: %w", err)
+	}
+
+	objects := make([]Object, 0, len(result.Responses))
+	for _, item := range result.Responses {
+		if len(item.Propstat) == 0 || item.Propstat[0].Prop.ResourceType.Collection != nil {
+			continue
+		}
+
+		href, err := url.PathUnescape(item.Href)
+		if err != nil {
+			href = item.Href
+		}
+		prop := item.Propstat[0].Prop
+		modTime, _ := http.ParseTime(prop.LastModified)
+
+		objects = append(objects, Object{Name: path.Base(href), Size: prop.ContentLength, ModTime: modTime})
+	}
+	return objects, nil
+}
+
+// fileURL returns the URL of a file in the collection
+func (t *webdavTarget) fileURL(name string) string {
+	u := t.base
+	u.Path += name
+	return u.String()
+}
+
+// do sends an authenticated request
+func (t *webdavTarget) do(ctx context.Context, method, target string, body io.Reader, headers map[string]string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, target, body)
+	if err != nil {
+		return nil, err
+	}
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+	if t.user != "" {
+		req.SetBasicAuth(t.user, t.password)
+	}
+	return t.client.Do(req)
+}
@@ -0,0 +1,136 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/mrbooshehri/qix-go/internal/config"
+	"github.com/mrbooshehri/qix-go/internal/migrations"
+	"github.com/mrbooshehri/qix-go/pkg/qix/models"
+)
+
+// Backend persists whole projects. Storage layers caching and indexing on top.
+type Backend interface {
+	Name() string
+	LoadProject(name string) (*models.Project, error)
+	ReadProjectData(name string) ([]byte, error) // Raw JSON, before schema migrations
+	SaveProject(name string, project *models.Project) error
+	DeleteProject(name string) error
+	ListProjects() ([]string, error)
+	ProjectExists(name string) bool
+	ProjectModTime(name string) (time.Time, error)
+}
+
+// TimeEntryQuerier is implemented by backends that can find time entries without loading every project
+type TimeEntryQuerier interface {
+	TimeEntriesInRange(ctx context.Context, startDate, endDate string) (map[string][]models.TimeEntry, error)
+}
+
+// openBackend returns the backend selected by the storage_backend setting
+func openBackend(cfg *config.Config) (Backend, error) {
+	switch cfg.StorageBackend {
+	case "", "json":
+		return &jsonBackend{config: cfg}, nil
+	case "sqlite":
+		return openSQLiteBackend(cfg)
+	default:
+		return nil, fmt.Errorf("unknown storage backend: %s (use: json, sqlite)", cfg.StorageBackend)
+	}
+}
+
+// jsonBackend stores each project in its own JSON file, with time entries in monthly sidecar files
+type jsonBackend struct {
+	config *config.Config
+
+	mu          sync.Mutex
+	monthHashes map[string]string // "<project>/<month>" -> hash of the month file last read or written
+}
+
+func (b *jsonBackend) Name() string {
+	return "json"
+}
+
+func (b *jsonBackend) LoadProject(name string) (*models.Project, error) {
+	data, err := readDataFile(b.config.GetProjectPath(name))
+	if err != nil {
+		return nil, err
+	}
+	if err := b.verifyChecksum(name, data); err != nil {
+		return nil, err
+	}
+
+	var project models.Project
+	if err := decodeVersioned(migrations.KindProject, data, &project); err != nil {
+		return nil, err
+	}
+
+	byTask, _, err := b.loadTimeEntries(name, "", "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to load time entries: %w", err)
+	}
+	mergeTimeEntries(&project, byTask)
+
+	return &project, nil
+}
+
+func (b *jsonBackend) ReadProjectData(name string) ([]byte, error) {
+	return readDataFile(b.config.GetProjectPath(name))
+}
+
+// SaveProject writes the project, compressed once it grows past the configured threshold, and its checksum.
+// Time entries are written to their month files first, so a crash can only leave them duplicated inline.
+func (b *jsonBackend) SaveProject(name string, project *models.Project) error {
+	stripped, months := splitTimeEntries(project)
+	if err := b.saveTimeEntries(name, months); err != nil {
+		return fmt.Errorf("failed to save time entries: %w", err)
+	}
+
+	data, err := json.MarshalIndent(stripped, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := writeCompressibleFile(b.config.GetProjectPath(name), data, b.config.CompressThresholdKB*1024); err != nil {
+		return err
+	}
+	return b.writeChecksum(name, data)
+}
+
+func (b *jsonBackend) DeleteProject(name string) error {
+	path := b.config.GetProjectPath(name)
+	for _, p := range []string{path, path + compressedSuffix, path + checksumSuffix} {
+		if skipRemove(p) {
+			continue
+		}
+		if err := os.Remove(p); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	if skipRemove(b.timeDir(name)) {
+		return nil
+	}
+	return os.RemoveAll(b.timeDir(name))
+}
+
+func (b *jsonBackend) ListProjects() ([]string, error) {
+	return b.config.ListProjectFiles()
+}
+
+func (b *jsonBackend) ProjectExists(name string) bool {
+	return b.config.ProjectExists(name)
+}
+
+func (b *jsonBackend) ProjectModTime(name string) (time.Time, error) {
+	path, err := dataFilePath(b.config.GetProjectPath(name))
+	if err != nil {
+		return time.Time{}, err
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return info.ModTime(), nil
+}
@@ -0,0 +1,29 @@
+package storage
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/mrbooshehri/qix-go/pkg/qix/models"
+)
+
+// LoadCalendarSync loads the record of events 'calendar sync' created
+func (s *Storage) LoadCalendarSync() (*models.CalendarSync, error) {
+	state := &models.CalendarSync{Calendars: make(map[string]map[string]models.SyncedEvent)}
+	if _, err := os.Stat(s.config.CalendarSyncFile); os.IsNotExist(err) {
+		return state, nil
+	}
+
+	if err := readJSONFile(s.config.CalendarSyncFile, state); err != nil {
+		return nil, fmt.Errorf("failed to load calendar sync state: %w", err)
+	}
+	if state.Calendars == nil {
+		state.Calendars = make(map[string]map[string]models.SyncedEvent)
+	}
+	return state, nil
+}
+
+// SaveCalendarSync saves the record of events 'calendar sync' created
+func (s *Storage) SaveCalendarSync(state *models.CalendarSync) error {
+	return writeJSONFile(s.config.CalendarSyncFile, state)
+}
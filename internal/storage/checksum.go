@@ -0,0 +1,113 @@
+package storage
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Each JSON project file has a sidecar <project>.json.sha256 holding the SHA-256 of its
+// uncompressed contents. Loading verifies it, so a truncated write, disk corruption or a
+// hand edit is reported as such rather than as whatever error the JSON decoder hits.
+// Files without a sidecar (written by older versions) are accepted and get one on save.
+
+// checksumSuffix is appended to a project file's path to name its checksum file
+const checksumSuffix = ".sha256"
+
+// ChecksumError reports a project file whose contents don't match its recorded checksum
+type ChecksumError struct {
+	Project  string
+	Expected string
+	Actual   string
+}
+
+func (e *ChecksumError) Error() string {
+	return fmt.Sprintf("project '%s' failed checksum verification (expected %.12s, got %.12s): the file was corrupted or edited outside qix", e.Project, e.Expected, e.Actual)
+}
+
+// ChecksumVerifier is implemented by backends that record checksums of their data files
+type ChecksumVerifier interface {
+	VerifyProject(name string) error
+	RehashProject(name string) error
+}
+
+// contentHash returns the hex SHA-256 of data
+func contentHash(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// checksumPath returns the checksum file for a project
+func (b *jsonBackend) checksumPath(name string) string {
+	return b.config.GetProjectPath(name) + checksumSuffix
+}
+
+// writeChecksum records the checksum of a project file's contents
+func (b *jsonBackend) writeChecksum(name string, data []byte) error {
+	return writeFileAtomic(b.checksumPath(name), []byte(contentHash(data)+"\n"))
+}
+
+// verifyChecksum checks data read from a project file against its recorded checksum
+func (b *jsonBackend) verifyChecksum(name string, data []byte) error {
+	recorded, err := readDataFile(b.checksumPath(name))
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read checksum: %w", err)
+	}
+
+	expected := strings.TrimSpace(string(recorded))
+	if actual := contentHash(data); actual != expected {
+		return &ChecksumError{Project: name, Expected: expected, Actual: actual}
+	}
+	return nil
+}
+
+// VerifyProject checks a project file against its checksum without decoding it
+func (b *jsonBackend) VerifyProject(name string) error {
+	data, err := readDataFile(b.config.GetProjectPath(name))
+	if err != nil {
+		return err
+	}
+	return b.verifyChecksum(name, data)
+}
+
+// RehashProject accepts a project file's current contents by recording a new checksum.
+// The file must still be valid JSON.
+func (b *jsonBackend) RehashProject(name string) error {
+	data, err := readDataFile(b.config.GetProjectPath(name))
+	if err != nil {
+		return err
+	}
+	if !json.Valid(data) {
+		return fmt.Errorf("project '%s' is not valid JSON; restore it from a backup instead", name)
+	}
+	return b.writeChecksum(name, data)
+}
+
+// VerifyProject checks a project's data file against its recorded checksum.
+// Backends that don't record checksums always pass.
+func (s *Storage) VerifyProject(projectName string) error {
+	verifier, ok := s.backend.(ChecksumVerifier)
+	if !ok {
+		return nil
+	}
+	return verifier.VerifyProject(projectName)
+}
+
+// RehashProject records a new checksum for a project's data file, accepting changes made outside qix
+func (s *Storage) RehashProject(projectName string) error {
+	verifier, ok := s.backend.(ChecksumVerifier)
+	if !ok {
+		return fmt.Errorf("the %s backend does not record checksums", s.backend.Name())
+	}
+
+	s.InvalidateCache(projectName)
+	return s.withLock("project-"+projectName, func() error {
+		return verifier.RehashProject(projectName)
+	})
+}
@@ -0,0 +1,157 @@
+package storage
+
+import (
+	"os"
+	"slices"
+	"sort"
+	"sync"
+	"sync/atomic"
+)
+
+// dryRun is set when nothing may be written; writes are recorded instead
+var dryRun atomic.Bool
+
+// dryRunLog holds the writes a dry run skipped, one per file
+var dryRunLog struct {
+	mu      sync.Mutex
+	changes []FileChange
+}
+
+// dryRunProjects are the projects a dry run saved (true) or deleted (false),
+// which the backend still has as they were
+var dryRunProjects struct {
+	mu     sync.Mutex
+	exists map[string]bool
+}
+
+// FileChange is a file a dry run would have written or removed
+type FileChange struct {
+	Path     string
+	Removed  bool // The file would be removed, else written
+	Appended bool // Size bytes would be appended rather than the file replaced
+	Size     int  // Bytes written; for a database, the size of the project saved
+}
+
+// SetDryRun sets whether this process runs dry: nothing in the data directory
+// is written or removed, and the writes are recorded for DryRunChanges
+// instead. The cache keeps what would have been saved, so the command sees
+// its own changes.
+func SetDryRun(enabled bool) {
+	dryRun.Store(enabled)
+}
+
+// DryRun reports whether this process runs dry
+func DryRun() bool {
+	return dryRun.Load()
+}
+
+// DryRunChanges returns the files a dry run would have written or removed, in
+// the order they were first touched
+func DryRunChanges() []FileChange {
+	dryRunLog.mu.Lock()
+	defer dryRunLog.mu.Unlock()
+	return append([]FileChange(nil), dryRunLog.changes...)
+}
+
+// recordChange records a skipped write, replacing any earlier one to the same
+// file; appends add up
+func recordChange(change FileChange) {
+	dryRunLog.mu.Lock()
+	defer dryRunLog.mu.Unlock()
+	for i, earlier := range dryRunLog.changes {
+		if earlier.Path != change.Path {
+			continue
+		}
+		if change.Appended && earlier.Appended {
+			change.Size += earlier.Size
+		}
+		dryRunLog.changes[i] = change
+		return
+	}
+	dryRunLog.changes = append(dryRunLog.changes, change)
+}
+
+// skipWrite reports whether writing size bytes to path is skipped for a dry
+// run, recording the write if so
+func skipWrite(path string, size int) bool {
+	if !dryRun.Load() {
+		return false
+	}
+	recordChange(FileChange{Path: path, Size: size})
+	return true
+}
+
+// skipAppend is skipWrite for appending to a file
+func skipAppend(path string, size int) bool {
+	if !dryRun.Load() {
+		return false
+	}
+	recordChange(FileChange{Path: path, Appended: true, Size: size})
+	return true
+}
+
+// skipRemove reports whether removing path is skipped for a dry run,
+// recording the removal if so and the file exists
+func skipRemove(path string) bool {
+	if !dryRun.Load() {
+		return false
+	}
+	if _, err := os.Stat(path); err == nil {
+		recordChange(FileChange{Path: path, Removed: true})
+	}
+	return true
+}
+
+// recordProject records that a dry run saved or deleted a project
+func recordProject(name string, exists bool) {
+	if !dryRun.Load() {
+		return
+	}
+	dryRunProjects.mu.Lock()
+	defer dryRunProjects.mu.Unlock()
+	if dryRunProjects.exists == nil {
+		dryRunProjects.exists = make(map[string]bool)
+	}
+	dryRunProjects.exists[name] = exists
+}
+
+// dryRunProjectExists reports whether a project exists after what a dry run
+// saved or deleted, and whether it did either
+func dryRunProjectExists(name string) (exists, ok bool) {
+	dryRunProjects.mu.Lock()
+	defer dryRunProjects.mu.Unlock()
+	exists, ok = dryRunProjects.exists[name]
+	return exists, ok
+}
+
+// withDryRunProjects adds the projects a dry run saved to names, the
+// projects in the backend, and leaves out those it deleted
+func withDryRunProjects(names []string) []string {
+	dryRunProjects.mu.Lock()
+	defer dryRunProjects.mu.Unlock()
+	if len(dryRunProjects.exists) == 0 {
+		return names
+	}
+	result := make([]string, 0, len(names)+len(dryRunProjects.exists))
+	for _, name := range names {
+		if exists, ok := dryRunProjects.exists[name]; !ok || exists {
+			result = append(result, name)
+		}
+	}
+	for name, exists := range dryRunProjects.exists {
+		if exists && !slices.Contains(names, name) {
+			result = append(result, name)
+		}
+	}
+	sort.Strings(result)
+	return result
+}
+
+// mkdirAll creates a directory like os.MkdirAll, except in a dry run, whose
+// files aren't written anyway
+func mkdirAll(dir string) error {
+	if dryRun.Load() {
+		return nil
+	}
+	return os.MkdirAll(dir, 0700)
+}
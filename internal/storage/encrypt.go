@@ -0,0 +1,189 @@
+package storage
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/mrbooshehri/qix-go/internal/encryption"
+)
+
+// EncryptionStatus counts data files by whether they are encrypted
+type EncryptionStatus struct {
+	Encrypted int
+	Plain     int
+}
+
+// dataFile is a file holding user data, with the lock that guards it
+type dataFile struct {
+	path string
+	lock string
+}
+
+// dataFiles lists every file holding user data: projects with their checksums and
+// time entries, tracking, schedules, the index, the journal and the trash
+func (s *Storage) dataFiles() ([]dataFile, error) {
+	files := make([]dataFile, 0)
+	add := func(lock string, patterns ...string) error {
+		for _, pattern := range patterns {
+			matches, err := filepath.Glob(pattern)
+			if err != nil {
+				return err
+			}
+			for _, path := range matches {
+				files = append(files, dataFile{path: path, lock: lock})
+			}
+		}
+		return nil
+	}
+
+	names, err := s.ListProjects()
+	if err != nil {
+		return nil, err
+	}
+	for _, name := range names {
+		path := s.config.GetProjectPath(name)
+		if err := add("project-"+name, path, path+compressedSuffix, path+checksumSuffix,
+			filepath.Join(s.config.ProjectsDir, name+".time", "*.json")); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := add("tracking", s.config.TrackFile); err != nil {
+		return nil, err
+	}
+	if err := add("schedules", s.config.ScheduleFile); err != nil {
+		return nil, err
+	}
+	if err := add("calendar-sync", s.config.CalendarSyncFile); err != nil {
+		return nil, err
+	}
+	if err := add("index", s.config.IndexFile); err != nil {
+		return nil, err
+	}
+	if err := add("journal", s.config.JournalFile); err != nil {
+		return nil, err
+	}
+	if err := add("trash", filepath.Join(s.config.TrashDir, "*.json")); err != nil {
+		return nil, err
+	}
+
+	return files, nil
+}
+
+// isJournal reports whether a data file is the line-oriented journal
+func (s *Storage) isJournal(path string) bool {
+	return path == s.config.JournalFile
+}
+
+// GetEncryptionStatus counts encrypted and plaintext data files
+func (s *Storage) GetEncryptionStatus() (EncryptionStatus, error) {
+	status := EncryptionStatus{}
+
+	files, err := s.dataFiles()
+	if err != nil {
+		return status, err
+	}
+	for _, file := range files {
+		data, err := os.ReadFile(file.path)
+		if err != nil {
+			return status, err
+		}
+
+		encrypted := encryption.IsEncrypted(data)
+		if s.isJournal(file.path) {
+			encrypted = encryption.IsEncryptedLine(data)
+		}
+		if encrypted {
+			status.Encrypted++
+		} else if len(data) > 0 {
+			status.Plain++
+		}
+	}
+
+	return status, nil
+}
+
+// RewriteDataFiles rewrites every data file encrypted, or decrypted if encrypt is false,
+// and returns the number of files written. A passphrase must be configured either way.
+func (s *Storage) RewriteDataFiles(encrypt bool) (int, error) {
+	key := encryption.KeyFor(s.config.QixDir)
+	if key == nil {
+		return 0, fmt.Errorf("no encryption passphrase is configured (set encryption_passphrase, encryption_key_file or QIX_PASSPHRASE)")
+	}
+
+	files, err := s.dataFiles()
+	if err != nil {
+		return 0, err
+	}
+
+	written := 0
+	for _, file := range files {
+		err := s.withLock(file.lock, func() error {
+			data, err := os.ReadFile(file.path)
+			if err != nil {
+				return err
+			}
+
+			if !s.isJournal(file.path) && encryption.IsEncrypted(data) == encrypt {
+				return nil
+			}
+
+			var out []byte
+			if s.isJournal(file.path) {
+				out, err = rewriteJournalLines(data, key, encrypt)
+			} else {
+				out, err = key.Decrypt(data)
+				if err == nil && encrypt {
+					out, err = key.Seal(out)
+				}
+			}
+			if err != nil {
+				return err
+			}
+
+			if bytes.Equal(out, data) {
+				return nil
+			}
+			written++
+			return writeRawFileAtomic(file.path, out)
+		})
+		if err != nil {
+			return written, fmt.Errorf("%s: %w", filepath.Base(file.path), err)
+		}
+	}
+
+	// Cached data is unchanged, but drop it so nothing is saved over the rewritten files
+	s.ClearCache()
+	return written, nil
+}
+
+// rewriteJournalLines encrypts or decrypts each line of the journal with key
+func rewriteJournalLines(data []byte, key *encryption.Key, encrypt bool) ([]byte, error) {
+	var out bytes.Buffer
+	for _, line := range strings.Split(strings.TrimRight(string(data), "\n"), "\n") {
+		if line == "" {
+			continue
+		}
+		if encrypt && encryption.IsEncryptedLine([]byte(line)) {
+			out.WriteString(line + "\n")
+			continue
+		}
+
+		plain, err := key.DecryptLine([]byte(line))
+		if err != nil {
+			return nil, err
+		}
+		if encrypt {
+			if plain, err = key.EncryptLine(plain); err != nil {
+				return nil, err
+			}
+		}
+
+		out.Write(plain)
+		out.WriteByte('\n')
+	}
+	return out.Bytes(), nil
+}
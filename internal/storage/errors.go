@@ -0,0 +1,50 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrNotFound is matched by the errors of lookups that find nothing, such
+	// as an unknown task ID or module
+	ErrNotFound = errors.New("not found")
+
+	// ErrStorage is matched by the errors of data files that can't be locked
+	// or written
+	ErrStorage = errors.New("storage failure")
+
+	// ErrInvalidName is matched by the errors of project names that can't
+	// be stored, such as those naming a path outside the data directory
+	ErrInvalidName = errors.New("invalid name")
+)
+
+// kindError is an error that also matches one of the sentinels above, keeping
+// its own message
+type kindError struct {
+	err  error
+	kind error
+}
+
+func (e *kindError) Error() string {
+	return e.err.Error()
+}
+
+func (e *kindError) Unwrap() []error {
+	return []error{e.err, e.kind}
+}
+
+// notFoundf returns an error formatted like fmt.Errorf that matches ErrNotFound
+func notFoundf(format string, args ...interface{}) error {
+	return &kindError{err: fmt.Errorf(format, args...), kind: ErrNotFound}
+}
+
+// storageErrorf returns an error formatted like fmt.Errorf that matches ErrStorage
+func storageErrorf(format string, args ...interface{}) error {
+	return &kindError{err: fmt.Errorf(format, args...), kind: ErrStorage}
+}
+
+// invalidNamef returns an error formatted like fmt.Errorf that matches ErrInvalidName
+func invalidNamef(format string, args ...interface{}) error {
+	return &kindError{err: fmt.Errorf(format, args...), kind: ErrInvalidName}
+}
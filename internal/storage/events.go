@@ -0,0 +1,163 @@
+package storage
+
+import (
+	"context"
+	"sort"
+
+	"github.com/mrbooshehri/qix-go/internal/events"
+	"github.com/mrbooshehri/qix-go/internal/notify"
+	"github.com/mrbooshehri/qix-go/pkg/qix/models"
+)
+
+// projectOutline is what events are published about in a project as it was
+// last loaded or saved: its modules and its sprints, by name
+type projectOutline struct {
+	modules map[string]bool
+	sprints map[string]models.Sprint
+	created bool // The project didn't exist before
+}
+
+func outlineOf(project *models.Project) projectOutline {
+	outline := projectOutline{
+		modules: make(map[string]bool, len(project.Modules)),
+		sprints: make(map[string]models.Sprint, len(project.Sprints)),
+	}
+	for _, module := range project.Modules {
+		outline.modules[module.Name] = true
+	}
+	for _, sprint := range project.Sprints {
+		outline.sprints[sprint.Name] = sprint
+	}
+	return outline
+}
+
+// SetBus makes the storage publish the changes it saves to bus: task changes,
+// projects, modules and sprints created or removed, sprints closed, and time
+// tracked. They are delivered by PublishEvents.
+func (s *Storage) SetBus(bus *events.Bus) {
+	s.changesMu.Lock()
+	defer s.changesMu.Unlock()
+	s.bus = bus
+	s.outlines = make(map[string]projectOutline)
+}
+
+// PublishEvents delivers the events of the changes saved since it was last
+// called, and returns how many there were and an error for each delivery that
+// failed. Call it once no lock is held, since hook scripts may run qix.
+func (s *Storage) PublishEvents(ctx context.Context) (int, []error) {
+	s.changesMu.Lock()
+	bus, pending := s.bus, s.pending
+	s.pending = nil
+	s.changesMu.Unlock()
+
+	if bus == nil || len(pending) == 0 {
+		return 0, nil
+	}
+	return len(pending), bus.Publish(ctx, pending...)
+}
+
+// queueEvents adds events to those PublishEvents delivers. Nothing is queued
+// in a dry run, which changes nothing.
+func (s *Storage) queueEvents(evts ...events.Event) {
+	if len(evts) == 0 || DryRun() {
+		return
+	}
+	s.changesMu.Lock()
+	defer s.changesMu.Unlock()
+	if s.bus != nil {
+		s.pending = append(s.pending, evts...)
+	}
+}
+
+// rememberOutline records the outline of a project as loaded
+func (s *Storage) rememberOutline(projectName string, project *models.Project) {
+	s.changesMu.Lock()
+	defer s.changesMu.Unlock()
+	if s.bus != nil {
+		s.outlines[projectName] = outlineOf(project)
+	}
+}
+
+// forgetOutline drops the outline of a removed project
+func (s *Storage) forgetOutline(projectName string) {
+	s.changesMu.Lock()
+	defer s.changesMu.Unlock()
+	delete(s.outlines, projectName)
+}
+
+// previousOutline returns the outline of a project about to be saved, empty
+// for a new one. It is nil if no events are published, or if the project
+// exists but wasn't loaded, so there is nothing to compare with.
+func (s *Storage) previousOutline(projectName string) *projectOutline {
+	s.changesMu.Lock()
+	outline, known := s.outlines[projectName]
+	publishing := s.bus != nil
+	s.changesMu.Unlock()
+
+	switch {
+	case !publishing:
+		return nil
+	case known:
+		return &outline
+	case !s.ProjectExists(projectName):
+		return &projectOutline{created: true}
+	default:
+		return nil
+	}
+}
+
+// outlineSaved queues the events of what changed in a saved project since
+// previous, its previous outline if known, and remembers its new outline
+func (s *Storage) outlineSaved(projectName string, project *models.Project, previous *projectOutline) {
+	s.rememberOutline(projectName, project)
+	if previous == nil {
+		return
+	}
+
+	evts := make([]events.Event, 0)
+	if previous.created {
+		evts = append(evts, events.Event{Type: events.ProjectCreated, Project: projectName})
+	}
+	current := outlineOf(project)
+	for _, module := range project.Modules {
+		if !previous.modules[module.Name] {
+			evts = append(evts, events.Event{Type: events.ModuleCreated, Project: projectName, Module: module.Name})
+		}
+	}
+	for _, name := range removedNames(previous.modules, current.modules) {
+		evts = append(evts, events.Event{Type: events.ModuleRemoved, Project: projectName, Module: name})
+	}
+
+	for _, sprint := range project.Sprints {
+		before, existed := previous.sprints[sprint.Name]
+		typ := ""
+		switch {
+		case !existed:
+			typ = events.SprintCreated
+		case before.ClosedAt == nil && sprint.ClosedAt != nil:
+			typ = events.SprintClosed
+		default:
+			continue
+		}
+		info := events.NewSprintInfo(project, sprint)
+		evts = append(evts, events.Event{Type: typ, Project: projectName, Sprint: &info})
+	}
+	for _, name := range removedNames(previous.sprints, current.sprints) {
+		sprint := previous.sprints[name]
+		info := notify.SprintInfo{Name: sprint.Name, StartDate: sprint.StartDate, EndDate: sprint.EndDate, Tasks: len(sprint.TaskIDs)}
+		evts = append(evts, events.Event{Type: events.SprintRemoved, Project: projectName, Sprint: &info})
+	}
+	s.queueEvents(evts...)
+}
+
+// removedNames returns the names in before that aren't in after, sorted
+func removedNames[T any](before, after map[string]T) []string {
+	names := make([]string, 0)
+	for name := range before {
+		if _, ok := after[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
@@ -1,37 +1,165 @@
 package storage
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"os"
-	"path/filepath"
+	"sort"
 
-	"github.com/mrbooshehri/qix-go/internal/models"
+	"github.com/mrbooshehri/qix-go/internal/logging"
+	"github.com/mrbooshehri/qix-go/internal/migrations"
+	"github.com/mrbooshehri/qix-go/pkg/qix/models"
 )
 
+// indexDocument is the on-disk layout of the task index
+type indexDocument struct {
+	SchemaVersion int              `json:"schema_version"`
+	Checksum      string           `json:"checksum,omitempty"` // SHA-256 of Tasks; absent in older indexes
+	Tasks         models.TaskIndex `json:"tasks"`
+}
+
+// indexChecksum hashes the task map; encoding/json sorts map keys, so the result is stable
+func indexChecksum(tasks models.TaskIndex) (string, error) {
+	data, err := json.Marshal(tasks)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// readIndexFile reads the index from disk and verifies its checksum
+func (s *Storage) readIndexFile() (models.TaskIndex, error) {
+	var doc indexDocument
+	if err := readVersionedFile(s.config.IndexFile, migrations.KindIndex, &doc); err != nil {
+		return nil, err
+	}
+	
+	if doc.Checksum != "" {
+		sum, err := indexChecksum(doc.Tasks)
+		if err != nil {
+			return nil, err
+		}
+		if sum != doc.Checksum {
+			return nil, fmt.Errorf("index checksum mismatch")
+		}
+	}
+	
+	if doc.Tasks == nil {
+		doc.Tasks = make(models.TaskIndex)
+	}
+	return doc.Tasks, nil
+}
+
+// writeIndexFile writes the index with its checksum. Callers hold the index file lock.
+func (s *Storage) writeIndexFile(tasks models.TaskIndex) error {
+	sum, err := indexChecksum(tasks)
+	if err != nil {
+		return err
+	}
+	
+	return writeJSONFile(s.config.IndexFile, indexDocument{
+		SchemaVersion: migrations.Latest(migrations.KindIndex),
+		Checksum:      sum,
+		Tasks:         tasks,
+	})
+}
+
 // LoadIndex loads the task index from disk
 func (s *Storage) LoadIndex() error {
 	s.cache.mu.Lock()
 	defer s.cache.mu.Unlock()
 	
-	if err := readJSONFile(s.config.IndexFile, &s.cache.index); err != nil {
-		// Index doesn't exist or is corrupted
+	s.cache.search = nil
+	s.cache.indexDirty = make(map[string]bool)
+	
+	index, err := s.readIndexFile()
+	if err != nil {
+		// Index doesn't exist or is corrupted; rebuild on next access
 		s.cache.index = make(models.TaskIndex)
+		s.cache.indexInvalid = true
 		return err
 	}
 	
+	s.cache.index = index
+	s.cache.indexInvalid = false
 	return nil
 }
 
-// SaveIndex saves the task index to disk
+// SaveIndex writes the whole in-memory index to disk
 func (s *Storage) SaveIndex() error {
+	return s.withLock("index", func() error {
+		s.cache.mu.Lock()
+		defer s.cache.mu.Unlock()
+		
+		if err := s.writeIndexFile(s.cache.index); err != nil {
+			return err
+		}
+		s.cache.indexDirty = make(map[string]bool)
+		s.cache.indexInvalid = false
+		return nil
+	})
+}
+
+// FlushIndex persists index entries for projects changed by this process.
+// Entries are merged into the file on disk so changes saved by other processes are kept.
+func (s *Storage) FlushIndex() error {
 	s.cache.mu.RLock()
-	defer s.cache.mu.RUnlock()
+	dirty := len(s.cache.indexDirty)
+	invalid := s.cache.indexInvalid
+	s.cache.mu.RUnlock()
+	
+	if dirty == 0 {
+		return nil
+	}
+	if invalid {
+		return s.RebuildIndex()
+	}
 	
-	return writeJSONFile(s.config.IndexFile, s.cache.index)
+	return s.withLock("index", func() error {
+		merged, err := s.readIndexFile()
+		if err != nil {
+			if !os.IsNotExist(err) {
+				logging.Warnf("Rewriting unreadable index: %v", err)
+			}
+			merged = make(models.TaskIndex)
+		}
+		
+		s.cache.mu.Lock()
+		defer s.cache.mu.Unlock()
+		
+		for taskID, loc := range merged {
+			if s.cache.indexDirty[loc.Project] {
+				delete(merged, taskID)
+			}
+		}
+		for taskID, loc := range s.cache.index {
+			if s.cache.indexDirty[loc.Project] {
+				merged[taskID] = loc
+			}
+		}
+		
+		if err := s.writeIndexFile(merged); err != nil {
+			return err
+		}
+		
+		s.cache.index = merged
+		s.cache.search = nil
+		s.cache.indexDirty = make(map[string]bool)
+		return nil
+	})
 }
 
 // RebuildIndex rebuilds the entire task index from all projects
 func (s *Storage) RebuildIndex() error {
+	return s.RebuildIndexProgress(nil)
+}
+
+// RebuildIndexProgress rebuilds the task index, calling report (if not nil) with
+// the number of projects indexed so far and the number in all
+func (s *Storage) RebuildIndexProgress(report func(done, total int)) error {
 	newIndex := make(models.TaskIndex)
 	
 	projects, err := s.ListProjects()
@@ -39,7 +167,11 @@ func (s *Storage) RebuildIndex() error {
 		return fmt.Errorf("failed to list projects: %w", err)
 	}
 	
-	for _, projectName := range projects {
+	for i, projectName := range projects {
+		if report != nil {
+			report(i, len(projects))
+		}
+		
 		project, err := s.LoadProject(projectName)
 		if err != nil {
 			// Skip corrupted projects
@@ -48,26 +180,25 @@ func (s *Storage) RebuildIndex() error {
 		
 		// Index project-level tasks
 		for _, task := range project.Tasks {
-			newIndex[task.ID] = models.TaskLocation{
-				Project:  projectName,
-				Location: "project",
-			}
+			newIndex[task.ID] = newTaskLocation(projectName, "", task)
 		}
 		
 		// Index module tasks
 		for _, module := range project.Modules {
 			for _, task := range module.Tasks {
-				newIndex[task.ID] = models.TaskLocation{
-					Project:  projectName,
-					Location: fmt.Sprintf("module:%s", module.Name),
-				}
+				newIndex[task.ID] = newTaskLocation(projectName, module.Name, task)
 			}
 		}
 	}
+	if report != nil {
+		report(len(projects), len(projects))
+	}
 	
 	// Update cache
 	s.cache.mu.Lock()
 	s.cache.index = newIndex
+	s.cache.search = nil
+	s.cache.indexInvalid = false
 	s.cache.mu.Unlock()
 	
 	// Save to disk
@@ -88,43 +219,89 @@ func (s *Storage) indexProject(projectName string, project *models.Project) erro
 	
 	// Add project-level tasks
 	for _, task := range project.Tasks {
-		s.cache.index[task.ID] = models.TaskLocation{
-			Project:  projectName,
-			Location: "project",
-		}
+		s.cache.index[task.ID] = newTaskLocation(projectName, "", task)
 	}
 	
 	// Add module tasks
 	for _, module := range project.Modules {
 		for _, task := range module.Tasks {
-			s.cache.index[task.ID] = models.TaskLocation{
-				Project:  projectName,
-				Location: fmt.Sprintf("module:%s", module.Name),
-			}
+			s.cache.index[task.ID] = newTaskLocation(projectName, module.Name, task)
 		}
 	}
+	s.cache.search = nil
 	
-	// Save index asynchronously (don't block on disk I/O)
-	go s.SaveIndex()
+	// Written by FlushIndex, merged with entries other processes saved
+	s.cache.indexDirty[projectName] = true
 	
 	return nil
 }
 
-// LookupTask uses the index for fast task lookup
+// LookupTask uses the index for fast task lookup.
+// On a miss it searches the projects themselves and re-indexes the one holding the task.
 func (s *Storage) LookupTask(taskID string) (string, string, error) {
+	s.cache.mu.RLock()
+	loc, exists := s.cache.index[taskID]
+	s.cache.mu.RUnlock()
+	
+	if exists {
+		return loc.Project, loc.Location, nil
+	}
+	
+	projects, err := s.ListProjects()
+	if err != nil {
+		return "", "", err
+	}
+	for _, projectName := range projects {
+		project, err := s.loadFreshProject(projectName)
+		if err != nil {
+			continue
+		}
+		if task, moduleName := findTaskIn(project, taskID); task != nil {
+			s.healIndex(projectName, project, taskID)
+			return projectName, newTaskLocation(projectName, moduleName, *task).Location, nil
+		}
+	}
+	
+	return "", "", notFoundf("task '%s' not found in index", taskID)
+}
+
+// indexedProject returns the project the index places a task in, if any
+func (s *Storage) indexedProject(taskID string) (string, bool) {
 	s.cache.mu.RLock()
 	defer s.cache.mu.RUnlock()
 	
 	loc, exists := s.cache.index[taskID]
-	if !exists {
-		return "", "", fmt.Errorf("task '%s' not found in index", taskID)
+	return loc.Project, exists
+}
+
+// healIndex re-indexes a project whose index entries disagreed with its data
+func (s *Storage) healIndex(projectName string, project *models.Project, taskID string) {
+	logging.Infof("Index out of date for task %s; re-indexing project %s", taskID, projectName)
+	s.indexProject(projectName, project)
+}
+
+// loadFreshProject loads a project, reloading it first if another process saved it since it was cached
+func (s *Storage) loadFreshProject(projectName string) (*models.Project, error) {
+	if !s.IsDirty(projectName) && s.isCacheStale(projectName) {
+		s.InvalidateCache(projectName)
 	}
-	
-	return loc.Project, loc.Location, nil
+	return s.LoadProject(projectName)
 }
 
 // IsIndexStale checks if the index needs rebuilding
 func (s *Storage) IsIndexStale() (bool, error) {
+	s.cache.mu.RLock()
+	invalid := s.cache.indexInvalid
+	dirty := make(map[string]bool, len(s.cache.indexDirty))
+	for name := range s.cache.indexDirty {
+		dirty[name] = true
+	}
+	s.cache.mu.RUnlock()
+	
+	if invalid {
+		return true, nil
+	}
+	
 	indexInfo, err := os.Stat(s.config.IndexFile)
 	if err != nil {
 		if os.IsNotExist(err) {
@@ -142,13 +319,17 @@ func (s *Storage) IsIndexStale() (bool, error) {
 	}
 	
 	for _, projectName := range projects {
-		path := s.config.GetProjectPath(projectName)
-		info, err := os.Stat(path)
+		// Entries for projects saved by this process are current in memory
+		if dirty[projectName] {
+			continue
+		}
+		
+		modTime, err := s.backend.ProjectModTime(projectName)
 		if err != nil {
 			continue
 		}
 		
-		if info.ModTime().After(indexModTime) {
+		if modTime.After(indexModTime) {
 			return true, nil
 		}
 	}
@@ -283,6 +464,60 @@ func (s *Storage) ValidateIndex() ([]string, error) {
 	return errors, nil
 }
 
+// VerifyIndexFile compares the index file on disk with the tasks stored in each project,
+// without repairing either, and returns the inconsistencies found. Projects that fail to
+// load are skipped; report those separately.
+func (s *Storage) VerifyIndexFile() ([]string, error) {
+	index, err := s.readIndexFile()
+	if err != nil {
+		return nil, err
+	}
+	
+	projects, err := s.backend.ListProjects()
+	if err != nil {
+		return nil, err
+	}
+	
+	actual := make(map[string]models.TaskLocation)
+	unreadable := make(map[string]bool)
+	for _, name := range projects {
+		project, err := s.backend.LoadProject(name)
+		if err != nil {
+			unreadable[name] = true
+			continue
+		}
+		for _, task := range project.Tasks {
+			actual[task.ID] = models.TaskLocation{Project: name, Location: "project"}
+		}
+		for _, module := range project.Modules {
+			for _, task := range module.Tasks {
+				actual[task.ID] = models.TaskLocation{Project: name, Location: "module:" + module.Name}
+			}
+		}
+	}
+	
+	problems := make([]string, 0)
+	for taskID, loc := range index {
+		found, ok := actual[taskID]
+		switch {
+		case unreadable[loc.Project]:
+		case !ok:
+			problems = append(problems, fmt.Sprintf("Index references task %s in %s but task not found", taskID, loc.Project))
+		case found.Project != loc.Project || found.Location != loc.Location:
+			problems = append(problems, fmt.Sprintf("Index places task %s in %s (%s) but it is in %s (%s)",
+				taskID, loc.Project, loc.Location, found.Project, found.Location))
+		}
+	}
+	for taskID, found := range actual {
+		if _, ok := index[taskID]; !ok {
+			problems = append(problems, fmt.Sprintf("Task %s in project %s not indexed", taskID, found.Project))
+		}
+	}
+	sort.Strings(problems)
+	
+	return problems, nil
+}
+
 // CompactIndex removes entries for deleted projects
 func (s *Storage) CompactIndex() error {
 	projects, err := s.ListProjects()
@@ -296,19 +531,23 @@ func (s *Storage) CompactIndex() error {
 	}
 	
 	s.cache.mu.Lock()
-	defer s.cache.mu.Unlock()
-	
 	// Remove entries for non-existent projects
 	for taskID, loc := range s.cache.index {
 		if !projectSet[loc.Project] {
 			delete(s.cache.index, taskID)
 		}
 	}
+	s.cache.search = nil
+	s.cache.mu.Unlock()
 	
 	return s.SaveIndex()
 }
 
-// GetProjectPath is a helper to get the full path for a project file
-func (s *Storage) GetProjectPath(projectName string) string {
-	return filepath.Join(s.config.ProjectsDir, projectName+".json")
+// GetProjectPath is a helper to get the full path for a project file. It
+// fails for names that would point outside the projects directory.
+func (s *Storage) GetProjectPath(projectName string) (string, error) {
+	if err := ValidateProjectName(projectName); err != nil {
+		return "", err
+	}
+	return s.config.GetProjectPath(projectName), nil
 }
\ No newline at end of file
@@ -0,0 +1,374 @@
+package storage
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/mrbooshehri/qix-go/internal/encryption"
+	"github.com/mrbooshehri/qix-go/internal/events"
+	"github.com/mrbooshehri/qix-go/internal/logging"
+	"github.com/mrbooshehri/qix-go/pkg/qix/models"
+)
+
+// maxJournalLine bounds a single journal line; tasks with long time histories can be large
+const maxJournalLine = 16 * 1024 * 1024
+
+// writeJournal appends a mutation to the journal before it is applied and returns its ID
+func (s *Storage) writeJournal(op models.JournalOp, projectName, moduleName string, before, after *models.Task) (string, error) {
+	entry := models.JournalEntry{
+		ID:      GenerateTaskID() + GenerateTaskID(),
+		At:      time.Now(),
+		Op:      op,
+		Project: projectName,
+		Module:  moduleName,
+		Before:  before,
+		After:   after,
+	}
+	if after != nil {
+		entry.TaskID = after.ID
+	} else if before != nil {
+		entry.TaskID = before.ID
+	}
+
+	if err := s.appendJournal(entry); err != nil {
+		return "", fmt.Errorf("failed to write journal: %w", err)
+	}
+	// before is the stored task, which the change overwrites once applied
+	if before != nil {
+		previous := *before
+		entry.Before = &previous
+	}
+	s.changesMu.Lock()
+	s.unsaved[entry.ID] = entry
+	s.changesMu.Unlock()
+	return entry.ID, nil
+}
+
+// commitJournal records that a journal entry has been applied
+func (s *Storage) commitJournal(id string) {
+	s.changesMu.Lock()
+	change, ok := s.unsaved[id]
+	if ok {
+		s.changes = append(s.changes, change)
+		delete(s.unsaved, id)
+	}
+	s.changesMu.Unlock()
+	if ok {
+		s.queueEvents(events.FromJournal([]models.JournalEntry{change})...)
+	}
+
+	entry := models.JournalEntry{ID: GenerateTaskID() + GenerateTaskID(), At: time.Now(), Op: models.OpCommit, Ref: id}
+	if err := s.appendJournal(entry); err != nil {
+		// The change is saved; recovery will find it already applied
+		logging.Warnf("Failed to commit journal entry %s: %v", id, err)
+	}
+}
+
+// abortJournal records that a journaled change failed and was not applied
+func (s *Storage) abortJournal(id string) {
+	s.changesMu.Lock()
+	delete(s.unsaved, id)
+	s.changesMu.Unlock()
+
+	entry := models.JournalEntry{ID: GenerateTaskID() + GenerateTaskID(), At: time.Now(), Op: models.OpAbort, Ref: id}
+	if err := s.appendJournal(entry); err != nil {
+		logging.Warnf("Failed to abort journal entry %s: %v", id, err)
+	}
+}
+
+// finishJournal commits or aborts a journal entry depending on whether the change was saved.
+// Inside a transaction, a successful change is committed along with the transaction.
+func (s *Storage) finishJournal(projectName, id string, err error) error {
+	if id == "" {
+		return err
+	}
+	if err != nil {
+		s.abortJournal(id)
+		return err
+	}
+	if tx := s.activeTx(projectName); tx != nil {
+		tx.journal = append(tx.journal, id)
+		return nil
+	}
+	s.commitJournal(id)
+	return nil
+}
+
+// Changes returns the task changes saved since the storage was opened,
+// oldest first, and forgets them
+func (s *Storage) Changes() []models.JournalEntry {
+	s.changesMu.Lock()
+	defer s.changesMu.Unlock()
+
+	changes := s.changes
+	s.changes = nil
+	return changes
+}
+
+// appendJournal writes one line to the journal file
+func (s *Storage) appendJournal(entry models.JournalEntry) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	if data, err = encryption.KeyFor(s.config.JournalFile).EncryptLine(data); err != nil {
+		return err
+	}
+
+	if skipAppend(s.config.JournalFile, len(data)+1) {
+		return nil
+	}
+	return s.withLock("journal", func() error {
+		file, err := os.OpenFile(s.config.JournalFile, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+		if err != nil {
+			return err
+		}
+		if _, err := file.Write(append(data, '\n')); err != nil {
+			file.Close()
+			return err
+		}
+		if err := file.Sync(); err != nil {
+			file.Close()
+			return err
+		}
+		return file.Close()
+	})
+}
+
+// JournalRecord is a journal entry together with whether it was applied
+type JournalRecord struct {
+	models.JournalEntry
+	Committed bool
+	aborted   bool
+}
+
+// ReadJournal returns all mutations in the journal, oldest first
+func (s *Storage) ReadJournal() ([]JournalRecord, error) {
+	file, err := os.Open(s.config.JournalFile)
+	if os.IsNotExist(err) {
+		return []JournalRecord{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	records := make([]JournalRecord, 0)
+	positions := make(map[string]int)
+
+	key := encryption.KeyFor(s.config.JournalFile)
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), maxJournalLine)
+	line := 0
+	for scanner.Scan() {
+		line++
+		var entry models.JournalEntry
+		data, err := key.DecryptLine(scanner.Bytes())
+		if err == nil {
+			err = json.Unmarshal(data, &entry)
+		}
+		if err != nil {
+			// A torn final line is expected after a crash mid-write
+			logging.Warnf("Skipping unreadable journal line %d: %v", line, err)
+			continue
+		}
+
+		switch entry.Op {
+		case models.OpCommit:
+			if i, ok := positions[entry.Ref]; ok {
+				records[i].Committed = true
+			}
+		case models.OpAbort:
+			if i, ok := positions[entry.Ref]; ok {
+				records[i].aborted = true
+			}
+		default:
+			positions[entry.ID] = len(records)
+			records = append(records, JournalRecord{JournalEntry: entry})
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	applied := make([]JournalRecord, 0, len(records))
+	for _, record := range records {
+		if !record.aborted {
+			applied = append(applied, record)
+		}
+	}
+	return applied, nil
+}
+
+// PendingJournal returns mutations that were journaled but never committed
+func (s *Storage) PendingJournal() ([]JournalRecord, error) {
+	records, err := s.ReadJournal()
+	if err != nil {
+		return nil, err
+	}
+
+	pending := make([]JournalRecord, 0)
+	for _, record := range records {
+		if !record.Committed {
+			pending = append(pending, record)
+		}
+	}
+	return pending, nil
+}
+
+// RecoverJournal re-applies uncommitted mutations that did not reach the project file.
+// It returns how many entries had to be replayed.
+func (s *Storage) RecoverJournal() (int, error) {
+	records, err := s.ReadJournal()
+	if err != nil {
+		return 0, err
+	}
+
+	// A committed change supersedes any earlier pending one for the same task
+	lastCommitted := make(map[string]int)
+	for i, record := range records {
+		if record.Committed {
+			lastCommitted[record.Project+"/"+record.TaskID] = i
+		}
+	}
+
+	replayed := 0
+	for i, record := range records {
+		if record.Committed {
+			continue
+		}
+		if last, ok := lastCommitted[record.Project+"/"+record.TaskID]; ok && last > i {
+			s.commitJournal(record.ID)
+			continue
+		}
+		if !s.ProjectExists(record.Project) {
+			logging.Warnf("Dropping journal entry %s: project %s no longer exists", record.ID, record.Project)
+			s.commitJournal(record.ID)
+			continue
+		}
+
+		applied := false
+		err := s.UpdateProject(record.Project, func(p *models.Project) error {
+			var err error
+			applied, err = replayJournalEntry(p, record.JournalEntry)
+			return err
+		})
+		if err != nil {
+			return replayed, fmt.Errorf("failed to replay journal entry %s: %w", record.ID, err)
+		}
+		if applied {
+			replayed++
+		}
+		s.commitJournal(record.ID)
+	}
+
+	return replayed, nil
+}
+
+// replayJournalEntry applies an entry to a project unless the project already reflects it
+func replayJournalEntry(p *models.Project, entry models.JournalEntry) (bool, error) {
+	task, _ := findTaskIn(p, entry.TaskID)
+
+	switch entry.Op {
+	case models.OpTaskCreated:
+		if task != nil || entry.After == nil {
+			return false, nil
+		}
+		return true, insertTask(p, entry.Module, *entry.After)
+	case models.OpTaskRemoved:
+		if task == nil {
+			return false, nil
+		}
+		deleteTask(p, entry.TaskID)
+		return true, nil
+	default:
+		if task == nil || entry.After == nil || !task.UpdatedAt.Before(entry.After.UpdatedAt) {
+			return false, nil
+		}
+		*task = *entry.After
+		return true, nil
+	}
+}
+
+// findTaskIn returns the task with the given ID and its module name ("" for project-level tasks)
+func findTaskIn(p *models.Project, taskID string) (*models.Task, string) {
+	for i := range p.Tasks {
+		if p.Tasks[i].ID == taskID {
+			return &p.Tasks[i], ""
+		}
+	}
+
+	for i := range p.Modules {
+		for j := range p.Modules[i].Tasks {
+			if p.Modules[i].Tasks[j].ID == taskID {
+				return &p.Modules[i].Tasks[j], p.Modules[i].Name
+			}
+		}
+	}
+
+	return nil, ""
+}
+
+// insertTask appends a task to the project or one of its modules
+func insertTask(p *models.Project, moduleName string, task models.Task) error {
+	if moduleName == "" {
+		p.Tasks = append(p.Tasks, task)
+		return nil
+	}
+
+	for i := range p.Modules {
+		if p.Modules[i].Name == moduleName {
+			p.Modules[i].Tasks = append(p.Modules[i].Tasks, task)
+			return nil
+		}
+	}
+	return notFoundf("module '%s' not found", moduleName)
+}
+
+// deleteTask removes a task from the project or its module, reporting whether it was found
+func deleteTask(p *models.Project, taskID string) bool {
+	for i := range p.Tasks {
+		if p.Tasks[i].ID == taskID {
+			p.Tasks = append(p.Tasks[:i], p.Tasks[i+1:]...)
+			return true
+		}
+	}
+
+	for i := range p.Modules {
+		for j := range p.Modules[i].Tasks {
+			if p.Modules[i].Tasks[j].ID == taskID {
+				tasks := p.Modules[i].Tasks
+				p.Modules[i].Tasks = append(tasks[:j], tasks[j+1:]...)
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// cloneTask returns a deep copy of a task so an updater can't mutate the journaled original
+func cloneTask(task models.Task) (models.Task, error) {
+	var clone models.Task
+	data, err := json.Marshal(task)
+	if err != nil {
+		return clone, err
+	}
+	err = json.Unmarshal(data, &clone)
+	return clone, err
+}
+
+// journalOpFor classifies an update by what changed
+func journalOpFor(before, after models.Task) models.JournalOp {
+	switch {
+	case before.Status != after.Status:
+		return models.OpStatusChanged
+	case len(after.TimeEntries) > len(before.TimeEntries):
+		return models.OpTimeLogged
+	default:
+		return models.OpTaskUpdated
+	}
+}
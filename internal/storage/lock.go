@@ -0,0 +1,71 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/mrbooshehri/qix-go/internal/logging"
+)
+
+const (
+	lockTimeout    = 10 * time.Second
+	lockRetryStart = 10 * time.Millisecond
+	lockRetryMax   = 500 * time.Millisecond
+)
+
+// errLocked is returned by tryLock when another process holds the lock
+var errLocked = errors.New("lock is held by another process")
+
+// fileLock is an advisory cross-process lock backed by a file in the locks directory
+type fileLock struct {
+	file *os.File
+	path string
+}
+
+// acquireLock takes an exclusive lock on name, retrying with exponential backoff until lockTimeout
+func (s *Storage) acquireLock(name string) (*fileLock, error) {
+	dir := filepath.Join(s.config.QixDir, "locks")
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, fmt.Errorf("failed to create lock directory: %w", err)
+	}
+	path := filepath.Join(dir, name+".lock")
+
+	delay := lockRetryStart
+	start := time.Now()
+	deadline := start.Add(lockTimeout)
+	for {
+		lock, err := tryLock(path)
+		if err == nil {
+			if delay > lockRetryStart {
+				logging.Debugf("Waited %s for the %s lock", time.Since(start).Round(time.Millisecond), name)
+			}
+			return lock, nil
+		}
+		if !errors.Is(err, errLocked) {
+			return nil, storageErrorf("failed to lock %s: %w", name, err)
+		}
+		if time.Now().After(deadline) {
+			return nil, storageErrorf("timed out waiting for %s (is another qix command running?)", name)
+		}
+
+		time.Sleep(delay)
+		delay *= 2
+		if delay > lockRetryMax {
+			delay = lockRetryMax
+		}
+	}
+}
+
+// withLock runs fn while holding the named lock
+func (s *Storage) withLock(name string, fn func() error) error {
+	lock, err := s.acquireLock(name)
+	if err != nil {
+		return err
+	}
+	defer lock.release()
+
+	return fn()
+}
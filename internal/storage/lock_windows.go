@@ -0,0 +1,33 @@
+//go:build windows
+
+package storage
+
+import (
+	"os"
+	"time"
+)
+
+// lockStale is how old a lock file must be before it is assumed abandoned by a crashed process
+const lockStale = 2 * time.Minute
+
+// tryLock creates path exclusively; the file's existence is the lock
+func tryLock(path string) (*fileLock, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_RDWR, 0600)
+	if err == nil {
+		return &fileLock{file: file, path: path}, nil
+	}
+	if !os.IsExist(err) {
+		return nil, err
+	}
+
+	if info, statErr := os.Stat(path); statErr == nil && time.Since(info.ModTime()) > lockStale {
+		os.Remove(path)
+	}
+	return nil, errLocked
+}
+
+// release closes and removes the lock file
+func (l *fileLock) release() {
+	l.file.Close()
+	os.Remove(l.path)
+}
@@ -0,0 +1,324 @@
+package storage
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/mrbooshehri/qix-go/internal/encryption"
+	"github.com/mrbooshehri/qix-go/internal/migrations"
+	"github.com/mrbooshehri/qix-go/pkg/qix/models"
+)
+
+// Data files changed on two machines are merged item by item rather than line by line:
+// tasks, modules and sprints by ID or name, time entries and journal lines as sets.
+// When both sides changed the same task, the more recently updated version wins; for
+// anything else that both sides changed, our version wins.
+
+// ErrNotMergeable is returned by MergeDataFile for files it has no merge strategy for
+var ErrNotMergeable = errors.New("no merge strategy for this file")
+
+// MergeDataFile three-way merges a data file. rel is its slash-separated path relative
+// to the data directory; base is the common ancestor's contents (nil if the file was
+// added on both sides). Files are decrypted and decompressed as needed, and the result
+// is encoded the way the file would be written.
+func MergeDataFile(rel string, base, ours, theirs []byte) ([]byte, error) {
+	switch {
+	case rel == "journal.jsonl":
+		return mergeLines(base, ours, theirs), nil
+	case path.Dir(rel) == "projects" && (strings.HasSuffix(rel, ".json") || strings.HasSuffix(rel, ".json.gz")):
+		return mergeEncoded(rel, base, ours, theirs, mergeProjectData)
+	case strings.HasSuffix(path.Dir(rel), ".time") && (strings.HasSuffix(rel, ".json") || strings.HasSuffix(rel, ".json.gz")):
+		return mergeEncoded(rel, base, ours, theirs, mergeMonthData)
+	default:
+		return nil, ErrNotMergeable
+	}
+}
+
+// mergeEncoded decodes the three versions of a file, merges them and encodes the result
+func mergeEncoded(rel string, base, ours, theirs []byte, merge func(base, ours, theirs []byte) ([]byte, error)) ([]byte, error) {
+	compressed := strings.HasSuffix(rel, compressedSuffix)
+
+	decoded := make([][]byte, 3)
+	for i, data := range [][]byte{base, ours, theirs} {
+		if data == nil {
+			continue
+		}
+		plain, err := encryption.Decrypt(data)
+		if err != nil {
+			return nil, err
+		}
+		if compressed {
+			reader, err := gzip.NewReader(bytes.NewReader(plain))
+			if err != nil {
+				return nil, err
+			}
+			plain, err = io.ReadAll(reader)
+			reader.Close()
+			if err != nil {
+				return nil, err
+			}
+		}
+		decoded[i] = plain
+	}
+
+	merged, err := merge(decoded[0], decoded[1], decoded[2])
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", rel, err)
+	}
+
+	if compressed {
+		var buf bytes.Buffer
+		writer := gzip.NewWriter(&buf)
+		if _, err := writer.Write(merged); err != nil {
+			return nil, err
+		}
+		if err := writer.Close(); err != nil {
+			return nil, err
+		}
+		merged = buf.Bytes()
+	}
+	return encryption.Encrypt(merged)
+}
+
+// placedTask is a task with the module it sits in ("" for project level)
+type placedTask struct {
+	Module string      `json:"module"`
+	Task   models.Task `json:"task"`
+}
+
+// mergeProjectData merges three versions of a project file
+func mergeProjectData(baseData, oursData, theirsData []byte) ([]byte, error) {
+	decode := func(data []byte) (*models.Project, error) {
+		project := &models.Project{}
+		if data == nil {
+			return project, nil
+		}
+		if err := decodeVersioned(migrations.KindProject, data, project); err != nil {
+			return nil, err
+		}
+		return project, nil
+	}
+
+	base, err := decode(baseData)
+	if err != nil {
+		return nil, err
+	}
+	ours, err := decode(oursData)
+	if err != nil {
+		return nil, err
+	}
+	theirs, err := decode(theirsData)
+	if err != nil {
+		return nil, err
+	}
+
+	// Project settings as a whole: take theirs only if we left them alone
+	merged := *ours
+	if sameJSON(projectSettings(ours), projectSettings(base)) {
+		merged = *theirs
+	}
+
+	merged.Modules = mergeKeyed(moduleHeaders(base), moduleHeaders(ours), moduleHeaders(theirs),
+		func(m models.Module) string { return m.Name }, nil)
+	merged.Sprints = mergeKeyed(base.Sprints, ours.Sprints, theirs.Sprints,
+		func(s models.Sprint) string { return s.Name }, nil)
+
+	tasks := mergeKeyed(placeTasks(base), placeTasks(ours), placeTasks(theirs),
+		func(t placedTask) string { return t.Task.ID },
+		func(a, b placedTask) bool { return a.Task.UpdatedAt.After(b.Task.UpdatedAt) })
+
+	moduleIndex := make(map[string]int, len(merged.Modules))
+	for i := range merged.Modules {
+		moduleIndex[merged.Modules[i].Name] = i
+	}
+	merged.Tasks = make([]models.Task, 0)
+	for _, placed := range tasks {
+		if i, ok := moduleIndex[placed.Module]; ok && placed.Module != "" {
+			merged.Modules[i].Tasks = append(merged.Modules[i].Tasks, placed.Task)
+		} else {
+			// Its module was deleted on the other side; keep the task at project level
+			merged.Tasks = append(merged.Tasks, placed.Task)
+		}
+	}
+
+	return json.MarshalIndent(&merged, "", "  ")
+}
+
+// projectSettings returns a project without its modules, tasks and sprints
+func projectSettings(project *models.Project) models.Project {
+	settings := *project
+	settings.Modules, settings.Tasks, settings.Sprints = nil, nil, nil
+	return settings
+}
+
+// moduleHeaders returns a project's modules without their tasks
+func moduleHeaders(project *models.Project) []models.Module {
+	modules := make([]models.Module, len(project.Modules))
+	for i, module := range project.Modules {
+		module.Tasks = make([]models.Task, 0)
+		modules[i] = module
+	}
+	return modules
+}
+
+// placeTasks lists a project's tasks with the module each sits in
+func placeTasks(project *models.Project) []placedTask {
+	tasks := make([]placedTask, 0, len(project.Tasks))
+	for _, task := range project.Tasks {
+		tasks = append(tasks, placedTask{Task: task})
+	}
+	for _, module := range project.Modules {
+		for _, task := range module.Tasks {
+			tasks = append(tasks, placedTask{Module: module.Name, Task: task})
+		}
+	}
+	return tasks
+}
+
+// mergeKeyed three-way merges lists of items identified by key. An item changed on one
+// side takes that side's version; an item deleted on one side and untouched on the other
+// is dropped; an item changed on both sides takes the newer one if newer is given, or
+// ours otherwise. Order follows ours, with items only in theirs appended.
+func mergeKeyed[T any](base, ours, theirs []T, key func(T) string, newer func(a, b T) bool) []T {
+	index := func(items []T) map[string]T {
+		m := make(map[string]T, len(items))
+		for _, item := range items {
+			m[key(item)] = item
+		}
+		return m
+	}
+	baseByKey, oursByKey, theirsByKey := index(base), index(ours), index(theirs)
+
+	order := make([]string, 0, len(ours)+len(theirs))
+	for _, item := range ours {
+		order = append(order, key(item))
+	}
+	for _, item := range theirs {
+		if _, ok := oursByKey[key(item)]; !ok {
+			order = append(order, key(item))
+		}
+	}
+
+	merged := make([]T, 0, len(order))
+	for _, k := range order {
+		b, inBase := baseByKey[k]
+		o, inOurs := oursByKey[k]
+		t, inTheirs := theirsByKey[k]
+
+		switch {
+		case inOurs && inTheirs:
+			switch {
+			case sameJSON(o, t), inBase && sameJSON(t, b):
+				merged = append(merged, o)
+			case inBase && sameJSON(o, b):
+				merged = append(merged, t)
+			case newer != nil && newer(t, o):
+				merged = append(merged, t)
+			default:
+				merged = append(merged, o)
+			}
+		case inOurs:
+			// Deleted on their side: keep it only if we changed it since
+			if !inBase || !sameJSON(o, b) {
+				merged = append(merged, o)
+			}
+		case inTheirs:
+			if !inBase || !sameJSON(t, b) {
+				merged = append(merged, t)
+			}
+		}
+	}
+	return merged
+}
+
+// mergeMonthData merges three versions of a month of time entries as sets per task
+func mergeMonthData(baseData, oursData, theirsData []byte) ([]byte, error) {
+	decode := func(data []byte) (monthEntries, error) {
+		entries := make(monthEntries)
+		if data == nil {
+			return entries, nil
+		}
+		if err := json.Unmarshal(data, &entries); err != nil {
+			return nil, err
+		}
+		return entries, nil
+	}
+
+	base, err := decode(baseData)
+	if err != nil {
+		return nil, err
+	}
+	ours, err := decode(oursData)
+	if err != nil {
+		return nil, err
+	}
+	theirs, err := decode(theirsData)
+	if err != nil {
+		return nil, err
+	}
+
+	entryKey := func(e models.TimeEntry) string {
+		return fmt.Sprintf("%s|%g|%s", e.Date, e.Hours, e.LoggedAt.UTC().Format("2006-01-02T15:04:05.999999999"))
+	}
+
+	merged := make(monthEntries)
+	for _, entries := range []monthEntries{ours, theirs} {
+		for taskID := range entries {
+			if _, done := merged[taskID]; done {
+				continue
+			}
+			result := mergeKeyed(base[taskID], ours[taskID], theirs[taskID], entryKey, nil)
+			sort.SliceStable(result, func(i, j int) bool {
+				return result[i].LoggedAt.Before(result[j].LoggedAt)
+			})
+			if len(result) > 0 {
+				merged[taskID] = result
+			} else {
+				merged[taskID] = nil
+			}
+		}
+	}
+	for taskID, entries := range merged {
+		if entries == nil {
+			delete(merged, taskID)
+		}
+	}
+
+	return json.MarshalIndent(merged, "", "  ")
+}
+
+// mergeLines merges line-oriented files such as the journal as sets of lines
+func mergeLines(base, ours, theirs []byte) []byte {
+	split := func(data []byte) []string {
+		lines := make([]string, 0)
+		for _, line := range strings.Split(string(data), "\n") {
+			if line != "" {
+				lines = append(lines, line)
+			}
+		}
+		return lines
+	}
+
+	identity := func(line string) string { return line }
+	merged := mergeKeyed(split(base), split(ours), split(theirs), identity, nil)
+
+	var out bytes.Buffer
+	for _, line := range merged {
+		out.WriteString(line + "\n")
+	}
+	return out.Bytes()
+}
+
+// sameJSON reports whether two values encode to the same JSON
+func sameJSON(a, b interface{}) bool {
+	aData, aErr := json.Marshal(a)
+	bData, bErr := json.Marshal(b)
+	return aErr == nil && bErr == nil && bytes.Equal(aData, bData)
+}
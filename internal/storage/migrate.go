@@ -0,0 +1,92 @@
+package storage
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/mrbooshehri/qix-go/internal/migrations"
+)
+
+// SchemaFile describes the schema version of one data file
+type SchemaFile struct {
+	Kind    migrations.Kind
+	Name    string // Project name, or the file name for tracking and index data
+	Version int
+	Latest  int
+	Err     error // Set if the file could not be read
+}
+
+// NeedsMigration reports whether the file is readable and older than the current schema
+func (f SchemaFile) NeedsMigration() bool {
+	return f.Err == nil && f.Version < f.Latest
+}
+
+// SchemaStatus reports the schema version of every data file
+func (s *Storage) SchemaStatus() ([]SchemaFile, error) {
+	files := make([]SchemaFile, 0)
+
+	projects, err := s.ListProjects()
+	if err != nil {
+		return nil, err
+	}
+	for _, name := range projects {
+		file := SchemaFile{Kind: migrations.KindProject, Name: name, Latest: migrations.Latest(migrations.KindProject)}
+		data, err := s.backend.ReadProjectData(name)
+		if err == nil {
+			file.Version, err = migrations.Version(data)
+		}
+		file.Err = err
+		files = append(files, file)
+	}
+
+	for _, f := range []struct {
+		kind migrations.Kind
+		path string
+	}{
+		{migrations.KindTracking, s.config.TrackFile},
+		{migrations.KindIndex, s.config.IndexFile},
+	} {
+		data, err := readDataFile(f.path)
+		if os.IsNotExist(err) {
+			continue
+		}
+		file := SchemaFile{Kind: f.kind, Name: filepath.Base(f.path), Latest: migrations.Latest(f.kind)}
+		if err == nil {
+			file.Version, err = migrations.Version(data)
+		}
+		file.Err = err
+		files = append(files, file)
+	}
+
+	return files, nil
+}
+
+// Migrate upgrades a data file to the current schema and writes it back
+func (s *Storage) Migrate(file SchemaFile) error {
+	switch file.Kind {
+	case migrations.KindProject:
+		return s.withLock("project-"+file.Name, func() error {
+			if !s.IsDirty(file.Name) {
+				s.InvalidateCache(file.Name)
+			}
+			project, err := s.LoadProject(file.Name)
+			if err != nil {
+				return err
+			}
+			return s.SaveProject(file.Name, project)
+		})
+	case migrations.KindTracking:
+		return s.withLock("tracking", func() error {
+			data, err := s.LoadTrackingData()
+			if err != nil {
+				return err
+			}
+			return s.SaveTrackingData(data)
+		})
+	default:
+		if err := s.LoadIndex(); err != nil {
+			return s.RebuildIndex()
+		}
+		return s.SaveIndex()
+	}
+}
@@ -0,0 +1,92 @@
+package storage
+
+import (
+	"context"
+	"sync"
+
+	"github.com/mrbooshehri/qix-go/internal/logging"
+	"github.com/mrbooshehri/qix-go/pkg/qix/models"
+)
+
+// parallelWorkers caps how many projects are read at once
+const parallelWorkers = 8
+
+// forEachParallel calls fn for every index in [0, n) on a bounded pool of goroutines.
+// It stops handing out work once ctx is cancelled or fn fails, and returns the first error.
+func forEachParallel(ctx context.Context, n int, fn func(ctx context.Context, i int) error) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		wg       sync.WaitGroup
+		once     sync.Once
+		firstErr error
+	)
+	jobs := make(chan int)
+
+	for w := 0; w < min(parallelWorkers, n); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				if err := fn(ctx, i); err != nil {
+					once.Do(func() {
+						firstErr = err
+						cancel()
+					})
+				}
+			}
+		}()
+	}
+
+feed:
+	for i := 0; i < n; i++ {
+		select {
+		case jobs <- i:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(jobs)
+	wg.Wait()
+
+	if firstErr != nil {
+		return firstErr
+	}
+	return ctx.Err()
+}
+
+// LoadProjects loads projects concurrently and returns them in the order named.
+// Projects that fail to load are skipped, as in GetAllProjects.
+func (s *Storage) LoadProjects(ctx context.Context, names []string) ([]*models.Project, error) {
+	loaded := make([]*models.Project, len(names))
+	err := forEachParallel(ctx, len(names), func(ctx context.Context, i int) error {
+		project, err := s.LoadProject(names[i])
+		if err != nil {
+			logging.Warnf("Skipping project %s: %v", names[i], err)
+			return nil
+		}
+		loaded[i] = project
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	projects := make([]*models.Project, 0, len(names))
+	for _, project := range loaded {
+		if project != nil {
+			projects = append(projects, project)
+		}
+	}
+	return projects, nil
+}
+
+// GetAllProjectsContext loads all projects concurrently, stopping early if ctx is cancelled
+func (s *Storage) GetAllProjectsContext(ctx context.Context) ([]*models.Project, error) {
+	names, err := s.ListProjects()
+	if err != nil {
+		return nil, err
+	}
+	return s.LoadProjects(ctx, names)
+}
@@ -1,49 +1,80 @@
 package storage
 
 import (
+	"context"
 	"fmt"
+	"strings"
 	"time"
 
-	"github.com/mrbooshehri/qix-go/internal/models"
+	"github.com/mrbooshehri/qix-go/internal/logging"
+	"github.com/mrbooshehri/qix-go/internal/migrations"
+	"github.com/mrbooshehri/qix-go/pkg/qix/models"
 )
 
+// ValidateProjectName returns an error if a project can't be named name: it
+// is empty, or holds a path separator or "..", so its file would be outside
+// the projects directory
+func ValidateProjectName(name string) error {
+	if strings.TrimSpace(name) == "" {
+		return invalidNamef("project name is empty")
+	}
+	if strings.ContainsAny(name, `/\`) || strings.Contains(name, "..") || strings.ContainsRune(name, 0) {
+		return invalidNamef("invalid project name '%s': it may not contain /, \\ or ..", name)
+	}
+	return nil
+}
+
 // LoadProject loads a project from disk (with caching)
 func (s *Storage) LoadProject(projectName string) (*models.Project, error) {
+	if err := ValidateProjectName(projectName); err != nil {
+		return nil, err
+	}
 	// Check cache first
 	if project, exists := s.GetFromCache(projectName); exists {
+		logging.Debugf("Cache hit for project %s", projectName)
 		return project, nil
 	}
 	
-	// Load from disk
-	path := s.config.GetProjectPath(projectName)
-	
-	var project models.Project
-	if err := readJSONFile(path, &project); err != nil {
+	// Load from the backend
+	start := time.Now()
+	project, err := s.backend.LoadProject(projectName)
+	if err != nil {
 		return nil, fmt.Errorf("failed to load project: %w", err)
 	}
+	logging.Debugf("Cache miss for project %s, loaded from %s in %s", projectName, s.backend.Name(), time.Since(start).Round(time.Microsecond))
 	
 	// Cache it
-	s.PutInCache(projectName, &project)
+	s.PutInCache(projectName, project)
+	s.rememberOutline(projectName, project)
 	
-	return &project, nil
+	return project, nil
 }
 
 // SaveProject saves a project to disk
 func (s *Storage) SaveProject(projectName string, project *models.Project) error {
+	if err := ValidateProjectName(projectName); err != nil {
+		return err
+	}
+	project.SchemaVersion = migrations.Latest(migrations.KindProject)
+	
 	// Validate JSON before writing
 	if err := validateJSON(project); err != nil {
 		return fmt.Errorf("invalid project data: %w", err)
 	}
 	
-	path := s.config.GetProjectPath(projectName)
-	
-	if err := writeJSONFile(path, project); err != nil {
+	previous := s.previousOutline(projectName)
+	start := time.Now()
+	if err := s.backend.SaveProject(projectName, project); err != nil {
 		return fmt.Errorf("failed to save project: %w", err)
 	}
+	modified.Store(true)
+	recordProject(projectName, true)
+	logging.Debugf("Saved project %s to %s in %s", projectName, s.backend.Name(), time.Since(start).Round(time.Microsecond))
 	
 	// Update cache
 	s.PutInCache(projectName, project)
 	s.ClearDirty(projectName)
+	s.outlineSaved(projectName, project, previous)
 	
 	// Update index
 	return s.indexProject(projectName, project)
@@ -51,6 +82,15 @@ func (s *Storage) SaveProject(projectName string, project *models.Project) error
 
 // CreateProject creates a new project
 func (s *Storage) CreateProject(name, description string, tags []string) (*models.Project, error) {
+	if err := ValidateProjectName(name); err != nil {
+		return nil, err
+	}
+	lock, err := s.acquireLock("project-" + name)
+	if err != nil {
+		return nil, err
+	}
+	defer lock.release()
+	
 	if s.ProjectExists(name) {
 		return nil, fmt.Errorf("project '%s' already exists", name)
 	}
@@ -73,17 +113,41 @@ func (s *Storage) CreateProject(name, description string, tags []string) (*model
 }
 
 // UpdateProject updates an existing project
+// The read-modify-write cycle holds the project lock so concurrent qix processes don't lose updates.
+// Inside a transaction the change is kept in memory until the transaction commits.
 func (s *Storage) UpdateProject(projectName string, updater func(*models.Project) error) error {
-	project, err := s.LoadProject(projectName)
-	if err != nil {
+	if err := ValidateProjectName(projectName); err != nil {
 		return err
 	}
-	
-	if err := updater(project); err != nil {
-		return err
+	if s.activeTx(projectName) != nil {
+		project, err := s.LoadProject(projectName)
+		if err != nil {
+			return err
+		}
+		if err := updater(project); err != nil {
+			return err
+		}
+		s.MarkDirty(projectName)
+		return s.indexProject(projectName, project)
 	}
 	
-	return s.SaveProject(projectName, project)
+	return s.withLock("project-"+projectName, func() error {
+		// Reload if another process saved the project after we cached it
+		if !s.IsDirty(projectName) && s.isCacheStale(projectName) {
+			s.InvalidateCache(projectName)
+		}
+		
+		project, err := s.LoadProject(projectName)
+		if err != nil {
+			return err
+		}
+		
+		if err := updater(project); err != nil {
+			return err
+		}
+		
+		return s.SaveProject(projectName, project)
+	})
 }
 
 // AddModule adds a module to a project
@@ -103,18 +167,34 @@ func (s *Storage) AddModule(projectName string, module models.Module) error {
 	})
 }
 
-// RemoveModule removes a module from a project
+// RemoveModule moves a module and its tasks to the trash and removes it from a project
 func (s *Storage) RemoveModule(projectName, moduleName string) error {
-	return s.UpdateProject(projectName, func(p *models.Project) error {
+	trashID := ""
+	err := s.UpdateProject(projectName, func(p *models.Project) error {
 		for i, m := range p.Modules {
 			if m.Name == moduleName {
+				var err error
+				trashID, err = s.moveToTrash(models.TrashItem{
+					Kind:       models.TrashModule,
+					Project:    projectName,
+					Module:     moduleName,
+					ModuleData: &m,
+				})
+				if err != nil {
+					return err
+				}
+				
 				// Remove module
 				p.Modules = append(p.Modules[:i], p.Modules[i+1:]...)
 				return nil
 			}
 		}
-		return fmt.Errorf("module '%s' not found", moduleName)
+		return notFoundf("module '%s' not found", moduleName)
 	})
+	if err != nil {
+		s.discardTrash(trashID)
+	}
+	return err
 }
 
 // GetModule retrieves a specific module
@@ -130,7 +210,7 @@ func (s *Storage) GetModule(projectName, moduleName string) (*models.Module, err
 		}
 	}
 	
-	return nil, fmt.Errorf("module '%s' not found", moduleName)
+	return nil, notFoundf("module '%s' not found", moduleName)
 }
 
 // UpdateModule updates a specific module
@@ -141,7 +221,7 @@ func (s *Storage) UpdateModule(projectName, moduleName string, updater func(*mod
 				return updater(&p.Modules[i])
 			}
 		}
-		return fmt.Errorf("module '%s' not found", moduleName)
+		return notFoundf("module '%s' not found", moduleName)
 	})
 }
 
@@ -175,7 +255,7 @@ func (s *Storage) GetSprint(projectName, sprintName string) (*models.Sprint, err
 		}
 	}
 	
-	return nil, fmt.Errorf("sprint '%s' not found", sprintName)
+	return nil, notFoundf("sprint '%s' not found", sprintName)
 }
 
 // AssignTaskToSprint assigns a task ID to a sprint
@@ -190,31 +270,21 @@ func (s *Storage) AssignTaskToSprint(projectName, sprintName, taskID string) err
 					}
 				}
 				p.Sprints[i].TaskIDs = append(p.Sprints[i].TaskIDs, taskID)
+				if p.Sprints[i].AddedAt == nil {
+					p.Sprints[i].AddedAt = make(map[string]time.Time)
+				}
+				p.Sprints[i].AddedAt[taskID] = time.Now()
+				delete(p.Sprints[i].RemovedAt, taskID)
 				return nil
 			}
 		}
-		return fmt.Errorf("sprint '%s' not found", sprintName)
+		return notFoundf("sprint '%s' not found", sprintName)
 	})
 }
 
 // GetAllProjects loads all projects (useful for reports)
 func (s *Storage) GetAllProjects() ([]*models.Project, error) {
-	names, err := s.ListProjects()
-	if err != nil {
-		return nil, err
-	}
-	
-	projects := make([]*models.Project, 0, len(names))
-	for _, name := range names {
-		project, err := s.LoadProject(name)
-		if err != nil {
-			// Skip corrupted projects but log
-			continue
-		}
-		projects = append(projects, project)
-	}
-	
-	return projects, nil
+	return s.GetAllProjectsContext(context.Background())
 }
 
 // GetProjectStats returns statistics for a project
@@ -231,7 +301,7 @@ func (s *Storage) GetProjectStats(projectName string) (map[string]interface{}, e
 		"total_tasks":       len(allTasks),
 		"todo":              counts[models.StatusTodo],
 		"doing":             counts[models.StatusDoing],
-		"done":              counts[models.StatusDone],
+		"done":              models.CountDone(counts),
 		"blocked":           counts[models.StatusBlocked],
 		"total_estimated":   project.CalculateTotalEstimated(),
 		"total_actual":      project.CalculateTotalActual(),
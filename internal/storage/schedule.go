@@ -0,0 +1,44 @@
+package storage
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/mrbooshehri/qix-go/pkg/qix/models"
+)
+
+// LoadSchedules loads the scheduled report definitions
+func (s *Storage) LoadSchedules() ([]models.ReportSchedule, error) {
+	if _, err := os.Stat(s.config.ScheduleFile); os.IsNotExist(err) {
+		return make([]models.ReportSchedule, 0), nil
+	}
+
+	var schedules []models.ReportSchedule
+	if err := readJSONFile(s.config.ScheduleFile, &schedules); err != nil {
+		return nil, fmt.Errorf("failed to load schedules: %w", err)
+	}
+
+	return schedules, nil
+}
+
+// SaveSchedules saves the scheduled report definitions
+func (s *Storage) SaveSchedules(schedules []models.ReportSchedule) error {
+	return writeJSONFile(s.config.ScheduleFile, schedules)
+}
+
+// UpdateSchedules loads, modifies and saves the scheduled report definitions
+func (s *Storage) UpdateSchedules(updater func([]models.ReportSchedule) ([]models.ReportSchedule, error)) error {
+	return s.withLock("schedules", func() error {
+		schedules, err := s.LoadSchedules()
+		if err != nil {
+			return err
+		}
+
+		schedules, err = updater(schedules)
+		if err != nil {
+			return err
+		}
+
+		return s.SaveSchedules(schedules)
+	})
+}
@@ -0,0 +1,194 @@
+package storage
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/mrbooshehri/qix-go/pkg/qix/models"
+)
+
+// Field weights for search terms; a title match counts more than a description match
+const (
+	weightTitle       = 3
+	weightTag         = 2
+	weightJira        = 2
+	weightModule      = 1
+	weightDescription = 1
+)
+
+// stopWords are too common to be worth indexing
+var stopWords = map[string]bool{
+	"a": true, "an": true, "and": true, "are": true, "as": true, "at": true, "be": true,
+	"by": true, "for": true, "from": true, "in": true, "is": true, "it": true, "of": true,
+	"on": true, "or": true, "the": true, "to": true, "with": true,
+}
+
+// searchPosting is one task containing a term
+type searchPosting struct {
+	TaskID string
+	Weight int
+}
+
+// SearchResult is a task matching a search query
+type SearchResult struct {
+	TaskID   string
+	Project  string
+	Location string
+	Title    string
+	Status   models.TaskStatus
+	Score    float64
+}
+
+// newTaskLocation builds the index entry for a task, including its search terms
+func newTaskLocation(projectName, moduleName string, task models.Task) models.TaskLocation {
+	location := "project"
+	if moduleName != "" {
+		location = fmt.Sprintf("module:%s", moduleName)
+	}
+
+	terms := make(map[string]int)
+	add := func(text string, weight int) {
+		for _, token := range tokenize(text) {
+			terms[token] += weight
+		}
+	}
+	add(task.Title, weightTitle)
+	add(task.Description, weightDescription)
+	add(task.JiraIssue, weightJira)
+	add(moduleName, weightModule)
+	for _, tag := range task.Tags {
+		add(tag, weightTag)
+	}
+
+	return models.TaskLocation{
+		Project:  projectName,
+		Location: location,
+		Title:    task.Title,
+		Status:   task.Status,
+		Terms:    terms,
+	}
+}
+
+// tokenize lowercases text and splits it into words, dropping stop words and single characters
+func tokenize(text string) []string {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	tokens := make([]string, 0, len(words))
+	for _, word := range words {
+		if len(word) < 2 || stopWords[word] {
+			continue
+		}
+		tokens = append(tokens, word)
+	}
+	return tokens
+}
+
+// Search returns tasks matching every word of the query, best matches first.
+// Words match whole terms or, at half weight, the start of longer terms.
+func (s *Storage) Search(query string) ([]SearchResult, error) {
+	words := tokenize(query)
+	if len(words) == 0 {
+		return nil, fmt.Errorf("search query has no searchable words")
+	}
+
+	if err := s.ensureSearchIndex(); err != nil {
+		return nil, err
+	}
+
+	s.cache.mu.RLock()
+	defer s.cache.mu.RUnlock()
+
+	scores := make(map[string]float64)
+	for i, word := range words {
+		wordScores := make(map[string]float64)
+		for term, postings := range s.cache.search {
+			factor := 0.0
+			switch {
+			case term == word:
+				factor = 1
+			case strings.HasPrefix(term, word):
+				factor = 0.5
+			default:
+				continue
+			}
+			for _, posting := range postings {
+				wordScores[posting.TaskID] += factor * float64(posting.Weight)
+			}
+		}
+
+		// Keep only tasks that matched every word so far
+		if i == 0 {
+			scores = wordScores
+			continue
+		}
+		for taskID := range scores {
+			if score, ok := wordScores[taskID]; ok {
+				scores[taskID] += score
+			} else {
+				delete(scores, taskID)
+			}
+		}
+	}
+
+	results := make([]SearchResult, 0, len(scores))
+	for taskID, score := range scores {
+		loc := s.cache.index[taskID]
+		results = append(results, SearchResult{
+			TaskID:   taskID,
+			Project:  loc.Project,
+			Location: loc.Location,
+			Title:    loc.Title,
+			Status:   loc.Status,
+			Score:    score,
+		})
+	}
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Score != results[j].Score {
+			return results[i].Score > results[j].Score
+		}
+		return results[i].TaskID < results[j].TaskID
+	})
+
+	return results, nil
+}
+
+// ensureSearchIndex refreshes the task index if needed and builds the in-memory inverted index
+func (s *Storage) ensureSearchIndex() error {
+	if err := s.EnsureIndexFresh(); err != nil {
+		return err
+	}
+
+	// Indexes written before search support have no terms
+	s.cache.mu.RLock()
+	outdated := false
+	for _, loc := range s.cache.index {
+		if loc.Terms == nil {
+			outdated = true
+			break
+		}
+	}
+	s.cache.mu.RUnlock()
+	if outdated {
+		if err := s.RebuildIndex(); err != nil {
+			return err
+		}
+	}
+
+	s.cache.mu.Lock()
+	defer s.cache.mu.Unlock()
+	if s.cache.search != nil {
+		return nil
+	}
+
+	s.cache.search = make(map[string][]searchPosting)
+	for taskID, loc := range s.cache.index {
+		for term, weight := range loc.Terms {
+			s.cache.search[term] = append(s.cache.search[term], searchPosting{TaskID: taskID, Weight: weight})
+		}
+	}
+	return nil
+}
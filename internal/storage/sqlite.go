@@ -0,0 +1,299 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mrbooshehri/qix-go/internal/config"
+	"github.com/mrbooshehri/qix-go/internal/logging"
+	"github.com/mrbooshehri/qix-go/internal/migrations"
+	"github.com/mrbooshehri/qix-go/pkg/qix/models"
+)
+
+// sqliteDriver is the database/sql driver name registered by the sqlite build tag
+const sqliteDriver = "sqlite3"
+
+// sqliteSchema stores each project as a JSON document, with tasks and time
+// entries denormalized into tables for queries that span projects
+const sqliteSchema = `
+CREATE TABLE IF NOT EXISTS projects (
+	name       TEXT PRIMARY KEY,
+	data       TEXT NOT NULL,
+	updated_at TEXT NOT NULL
+);
+CREATE TABLE IF NOT EXISTS tasks (
+	project     TEXT NOT NULL,
+	id          TEXT NOT NULL,
+	module      TEXT NOT NULL,
+	title       TEXT NOT NULL,
+	description TEXT NOT NULL,
+	status      TEXT NOT NULL,
+	tags        TEXT NOT NULL,
+	PRIMARY KEY (project, id)
+);
+CREATE TABLE IF NOT EXISTS time_entries (
+	project   TEXT NOT NULL,
+	task_id   TEXT NOT NULL,
+	date      TEXT NOT NULL,
+	hours     REAL NOT NULL,
+	logged_at TEXT NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_time_entries_date ON time_entries (date);
+CREATE INDEX IF NOT EXISTS idx_tasks_status ON tasks (status);
+CREATE TABLE IF NOT EXISTS meta (
+	key   TEXT PRIMARY KEY,
+	value TEXT NOT NULL
+);`
+
+// sqliteBackend stores all projects in a single SQLite database
+type sqliteBackend struct {
+	db   *sql.DB
+	path string // The database file
+}
+
+// openSQLiteBackend opens the database, creating the schema and importing JSON projects on first use
+func openSQLiteBackend(cfg *config.Config) (*sqliteBackend, error) {
+	registered := false
+	for _, driver := range sql.Drivers() {
+		if driver == sqliteDriver {
+			registered = true
+			break
+		}
+	}
+	if !registered {
+		return nil, fmt.Errorf("this build of qix does not include SQLite support (rebuild with -tags sqlite)")
+	}
+
+	db, err := sql.Open(sqliteDriver, cfg.DatabaseFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+	// SQLite allows a single writer; serialize access through one connection
+	db.SetMaxOpenConns(1)
+
+	if _, err := db.Exec(sqliteSchema); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to create database schema: %w", err)
+	}
+
+	backend := &sqliteBackend{db: db, path: cfg.DatabaseFile}
+	if err := backend.migrateFromJSON(cfg); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to import JSON projects: %w", err)
+	}
+
+	return backend, nil
+}
+
+// migrateFromJSON imports existing JSON project files once. The files are left in place.
+func (b *sqliteBackend) migrateFromJSON(cfg *config.Config) error {
+	var done string
+	err := b.db.QueryRow(`SELECT value FROM meta WHERE key = 'json_imported'`).Scan(&done)
+	if err == nil {
+		return nil
+	}
+	if err != sql.ErrNoRows {
+		return err
+	}
+
+	source := &jsonBackend{config: cfg}
+	names, err := source.ListProjects()
+	if err != nil {
+		return err
+	}
+
+	for _, name := range names {
+		project, err := source.LoadProject(name)
+		if err != nil {
+			logging.Warnf("Skipping project %s during SQLite import: %v", name, err)
+			continue
+		}
+		if err := b.SaveProject(name, project); err != nil {
+			return fmt.Errorf("project %s: %w", name, err)
+		}
+	}
+	if dryRun.Load() {
+		// The projects weren't saved; import them again next time
+		return nil
+	}
+	logging.Infof("Imported %d JSON projects into %s", len(names), cfg.DatabaseFile)
+
+	_, err = b.db.Exec(`INSERT INTO meta (key, value) VALUES ('json_imported', ?)`, time.Now().Format(time.RFC3339))
+	return err
+}
+
+func (b *sqliteBackend) Name() string {
+	return "sqlite"
+}
+
+func (b *sqliteBackend) LoadProject(name string) (*models.Project, error) {
+	data, err := b.ReadProjectData(name)
+	if err != nil {
+		return nil, err
+	}
+
+	var project models.Project
+	if err := decodeVersioned(migrations.KindProject, data, &project); err != nil {
+		return nil, err
+	}
+	return &project, nil
+}
+
+func (b *sqliteBackend) ReadProjectData(name string) ([]byte, error) {
+	var data string
+	err := b.db.QueryRow(`SELECT data FROM projects WHERE name = ?`, name).Scan(&data)
+	if err == sql.ErrNoRows {
+		return nil, notFoundf("project '%s' not found", name)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return []byte(data), nil
+}
+
+func (b *sqliteBackend) SaveProject(name string, project *models.Project) error {
+	data, err := json.Marshal(project)
+	if err != nil {
+		return err
+	}
+	if skipWrite(b.path, len(data)) {
+		return nil
+	}
+
+	tx, err := b.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	now := time.Now().Format(time.RFC3339Nano)
+	if _, err := tx.Exec(
+		`INSERT INTO projects (name, data, updated_at) VALUES (?, ?, ?)
+		 ON CONFLICT (name) DO UPDATE SET data = excluded.data, updated_at = excluded.updated_at`,
+		name, string(data), now,
+	); err != nil {
+		return err
+	}
+
+	if _, err := tx.Exec(`DELETE FROM tasks WHERE project = ?`, name); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(`DELETE FROM time_entries WHERE project = ?`, name); err != nil {
+		return err
+	}
+
+	insert := func(module string, tasks []models.Task) error {
+		for _, task := range tasks {
+			if _, err := tx.Exec(
+				`INSERT INTO tasks (project, id, module, title, description, status, tags) VALUES (?, ?, ?, ?, ?, ?, ?)`,
+				name, task.ID, module, task.Title, task.Description, string(task.Status), strings.Join(task.Tags, ","),
+			); err != nil {
+				return err
+			}
+			for _, entry := range task.TimeEntries {
+				if _, err := tx.Exec(
+					`INSERT INTO time_entries (project, task_id, date, hours, logged_at) VALUES (?, ?, ?, ?, ?)`,
+					name, task.ID, entry.Date, entry.Hours, entry.LoggedAt.Format(time.RFC3339Nano),
+				); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	}
+
+	if err := insert("", project.Tasks); err != nil {
+		return err
+	}
+	for _, module := range project.Modules {
+		if err := insert(module.Name, module.Tasks); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (b *sqliteBackend) DeleteProject(name string) error {
+	if skipWrite(b.path, 0) {
+		return nil
+	}
+
+	tx, err := b.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for _, table := range []string{"projects", "tasks", "time_entries"} {
+		column := "project"
+		if table == "projects" {
+			column = "name"
+		}
+		if _, err := tx.Exec(fmt.Sprintf(`DELETE FROM %s WHERE %s = ?`, table, column), name); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (b *sqliteBackend) ListProjects() ([]string, error) {
+	rows, err := b.db.Query(`SELECT name FROM projects ORDER BY name`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	names := make([]string, 0)
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, err
+		}
+		names = append(names, name)
+	}
+	return names, rows.Err()
+}
+
+func (b *sqliteBackend) ProjectExists(name string) bool {
+	var exists int
+	err := b.db.QueryRow(`SELECT 1 FROM projects WHERE name = ?`, name).Scan(&exists)
+	return err == nil
+}
+
+func (b *sqliteBackend) ProjectModTime(name string) (time.Time, error) {
+	var updated string
+	if err := b.db.QueryRow(`SELECT updated_at FROM projects WHERE name = ?`, name).Scan(&updated); err != nil {
+		return time.Time{}, err
+	}
+	return time.Parse(time.RFC3339Nano, updated)
+}
+
+// TimeEntriesInRange returns time entries logged between startDate and endDate, grouped by project
+func (b *sqliteBackend) TimeEntriesInRange(ctx context.Context, startDate, endDate string) (map[string][]models.TimeEntry, error) {
+	rows, err := b.db.QueryContext(ctx,
+		`SELECT project, date, hours, logged_at FROM time_entries WHERE date >= ? AND date <= ? ORDER BY project, date`,
+		startDate, endDate,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	entriesByProject := make(map[string][]models.TimeEntry)
+	for rows.Next() {
+		var project, loggedAt string
+		var entry models.TimeEntry
+		if err := rows.Scan(&project, &entry.Date, &entry.Hours, &loggedAt); err != nil {
+			return nil, err
+		}
+		entry.LoggedAt, _ = time.Parse(time.RFC3339Nano, loggedAt)
+		entriesByProject[project] = append(entriesByProject[project], entry)
+	}
+	return entriesByProject, rows.Err()
+}
@@ -0,0 +1,7 @@
+//go:build sqlite
+
+package storage
+
+// Registers the "sqlite3" database/sql driver, which needs cgo. Build with
+// -tags sqlite.
+import _ "github.com/mattn/go-sqlite3"
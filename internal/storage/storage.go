@@ -1,52 +1,121 @@
 package storage
 
 import (
+	"bytes"
+	"compress/gzip"
+	"container/list"
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"strings"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/mrbooshehri/qix-go/internal/config"
-	"github.com/mrbooshehri/qix-go/internal/models"
+	"github.com/mrbooshehri/qix-go/internal/encryption"
+	"github.com/mrbooshehri/qix-go/internal/events"
+	"github.com/mrbooshehri/qix-go/internal/logging"
+	"github.com/mrbooshehri/qix-go/internal/migrations"
+	"github.com/mrbooshehri/qix-go/pkg/qix/models"
 )
 
 // Storage handles all data persistence operations
 type Storage struct {
-	config *config.Config
-	cache  *Cache
+	config  *config.Config
+	backend Backend
+	cache   *Cache
+	
+	txMu sync.Mutex
+	txs  map[string]*Tx // Open transactions by project
+	
+	changesMu sync.Mutex
+	unsaved   map[string]models.JournalEntry // Task changes journaled but not yet committed, by journal ID
+	changes   []models.JournalEntry          // Task changes saved by this process
+	bus       *events.Bus                    // Where saved changes are published; nil if they aren't
+	pending   []events.Event                 // Events of saved changes not yet published
+	outlines  map[string]projectOutline      // Projects as loaded or saved, to tell what changed
 }
 
 // Cache stores frequently accessed data in memory
 type Cache struct {
 	mu       sync.RWMutex
 	projects map[string]*models.Project
+	loaded   map[string]time.Time // When each cached project was read or written
 	index    models.TaskIndex
+	search   map[string][]searchPosting // Inverted index built from index terms on first search
+	taskMaps map[string]*taskMap        // Task positions in cached projects, built on first FindTask
 	dirty    map[string]bool // Tracks which projects need saving
+	
+	indexDirty   map[string]bool // Projects whose index entries changed since the last index save
+	indexInvalid bool            // The index file was missing or failed verification
+	
+	// LRU bookkeeping; maxProjects <= 0 means unbounded
+	lru         *list.List // Project names, most recently used first
+	lruItems    map[string]*list.Element
+	maxProjects int
+	hits        int
+	misses      int
+	evictions   int
 }
 
 var globalStorage *Storage
 
+// modified is set once this process writes data
+var modified atomic.Bool
+
 // Init initializes the global storage instance
 func Init() error {
-	cfg := config.Get()
+	store, err := Open(config.Get())
+	if err != nil {
+		return err
+	}
+	
+	globalStorage = store
+	
+	// Items expire from the trash whether or not anything else is deleted
+	if _, err := store.PurgeTrash(false); err != nil {
+		logging.Warnf("Failed to purge trash: %v", err)
+	}
+	return nil
+}
+
+// Open creates a storage instance over the data directories in cfg
+func Open(cfg *config.Config) (*Storage, error) {
+	backend, err := openBackend(cfg)
+	if err != nil {
+		return nil, err
+	}
 	
-	globalStorage = &Storage{
-		config: cfg,
+	store := &Storage{
+		config:  cfg,
+		backend: backend,
+		txs:     make(map[string]*Tx),
+		unsaved: make(map[string]models.JournalEntry),
 		cache: &Cache{
 			projects: make(map[string]*models.Project),
+			loaded:   make(map[string]time.Time),
 			index:    make(models.TaskIndex),
 			dirty:    make(map[string]bool),
+			taskMaps: make(map[string]*taskMap),
+			
+			indexDirty: make(map[string]bool),
+			
+			lru:         list.New(),
+			lruItems:    make(map[string]*list.Element),
+			maxProjects: cfg.CacheMaxProjects,
 		},
 	}
 	
 	// Load index on startup
-	if err := globalStorage.LoadIndex(); err != nil {
-		// Index doesn't exist or is corrupted, will rebuild on first access
-		globalStorage.cache.index = make(models.TaskIndex)
+	if err := store.LoadIndex(); err != nil && !os.IsNotExist(err) {
+		// Corrupted or failed verification; rebuilt on first access
+		logging.Warnf("Task index unusable, will rebuild: %v", err)
 	}
 	
-	return nil
+	return store, nil
 }
 
 // Get returns the global storage instance
@@ -59,9 +128,75 @@ func Get() *Storage {
 	return globalStorage
 }
 
+// Dir returns the data directory the storage reads and writes
+func (s *Storage) Dir() string {
+	return s.config.QixDir
+}
+
+// Modified reports whether this process has written any data, such as a
+// project or the tracking file
+func (s *Storage) Modified() bool {
+	return modified.Load()
+}
+
+// compressedSuffix is appended to the path of gzip-compressed data files
+const compressedSuffix = ".gz"
+
+// dataFilePath returns whichever of path and its compressed form exists, preferring the newer.
+// Both can exist briefly if qix stopped while switching a file between formats.
+func dataFilePath(path string) (string, error) {
+	plain, plainErr := os.Stat(path)
+	packed, packedErr := os.Stat(path + compressedSuffix)
+	
+	switch {
+	case plainErr == nil && packedErr == nil:
+		if packed.ModTime().After(plain.ModTime()) {
+			return path + compressedSuffix, nil
+		}
+		return path, nil
+	case plainErr == nil:
+		return path, nil
+	case packedErr == nil:
+		return path + compressedSuffix, nil
+	default:
+		return "", plainErr
+	}
+}
+
+// readDataFile reads a data file, decrypting it if it was encrypted and decompressing it if it was stored as .gz
+func readDataFile(path string) ([]byte, error) {
+	actual, err := dataFilePath(path)
+	if err != nil {
+		return nil, err
+	}
+	
+	data, err := os.ReadFile(actual)
+	if err != nil {
+		return nil, err
+	}
+	logging.Debugf("Read %s (%d bytes)", actual, len(data))
+	
+	data, err = encryption.KeyFor(actual).Decrypt(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", actual, err)
+	}
+	
+	if !strings.HasSuffix(actual, compressedSuffix) {
+		return data, nil
+	}
+	
+	reader, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", actual, err)
+	}
+	defer reader.Close()
+	
+	return io.ReadAll(reader)
+}
+
 // readJSONFile reads and unmarshals a JSON file
 func readJSONFile(path string, v interface{}) error {
-	data, err := os.ReadFile(path)
+	data, err := readDataFile(path)
 	if err != nil {
 		return err
 	}
@@ -69,14 +204,92 @@ func readJSONFile(path string, v interface{}) error {
 	return json.Unmarshal(data, v)
 }
 
+// readVersionedFile reads a JSON data file, upgrading it to the current schema first
+func readVersionedFile(path string, kind migrations.Kind, v interface{}) error {
+	data, err := readDataFile(path)
+	if err != nil {
+		return err
+	}
+	
+	return decodeVersioned(kind, data, v)
+}
+
+// decodeVersioned upgrades a JSON document to the current schema and unmarshals it.
+// The upgrade happens in memory; the file is rewritten on its next save.
+func decodeVersioned(kind migrations.Kind, data []byte, v interface{}) error {
+	upgraded, from, err := migrations.Upgrade(kind, data)
+	if err != nil {
+		return err
+	}
+	if from != migrations.Latest(kind) {
+		logging.Debugf("Upgraded %s data from schema %d to %d", kind, from, migrations.Latest(kind))
+	}
+	
+	return json.Unmarshal(upgraded, v)
+}
+
 // writeJSONFile marshals and writes a JSON file atomically
 func writeJSONFile(path string, v interface{}) error {
+	return writeCompressibleJSONFile(path, v, 0)
+}
+
+// writeCompressibleJSONFile writes a JSON file, gzip-compressing it to path.gz when it is larger
+// than threshold bytes (0 never compresses). The copy in the other format is removed.
+func writeCompressibleJSONFile(path string, v interface{}, threshold int) error {
 	// Marshal with indentation for readability
 	data, err := json.MarshalIndent(v, "", "  ")
 	if err != nil {
 		return err
 	}
 	
+	return writeCompressibleFile(path, data, threshold)
+}
+
+// writeCompressibleFile writes data to path, or gzip-compressed to path.gz when it is larger than threshold bytes
+func writeCompressibleFile(path string, data []byte, threshold int) error {
+	target, stale := path, path+compressedSuffix
+	if threshold > 0 && len(data) > threshold {
+		var buf bytes.Buffer
+		writer := gzip.NewWriter(&buf)
+		if _, err := writer.Write(data); err != nil {
+			return err
+		}
+		if err := writer.Close(); err != nil {
+			return err
+		}
+		data = buf.Bytes()
+		target, stale = stale, target
+	}
+	
+	if err := writeFileAtomic(target, data); err != nil {
+		return err
+	}
+	
+	if skipRemove(stale) {
+		return nil
+	}
+	if err := os.Remove(stale); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// writeFileAtomic writes data to a temp file and renames it into place, encrypting it if encryption is enabled
+func writeFileAtomic(path string, data []byte) error {
+	data, err := encryption.KeyFor(path).Encrypt(data)
+	if err != nil {
+		return fmt.Errorf("failed to encrypt %s: %w", filepath.Base(path), err)
+	}
+	
+	return writeRawFileAtomic(path, data)
+}
+
+// writeRawFileAtomic writes data exactly as given to a temp file and renames it into place
+func writeRawFileAtomic(path string, data []byte) error {
+	if skipWrite(path, len(data)) {
+		return nil
+	}
+	
 	// Write to temp file first
 	tempPath := path + ".tmp"
 	if err := os.WriteFile(tempPath, data, 0600); err != nil {
@@ -88,6 +301,8 @@ func writeJSONFile(path string, v interface{}) error {
 		os.Remove(tempPath) // Cleanup on failure
 		return err
 	}
+	logging.Debugf("Wrote %s (%d bytes)", path, len(data))
+	modified.Store(true)
 	
 	return nil
 }
@@ -100,19 +315,74 @@ func validateJSON(v interface{}) error {
 
 // GetFromCache retrieves a project from cache
 func (s *Storage) GetFromCache(projectName string) (*models.Project, bool) {
-	s.cache.mu.RLock()
-	defer s.cache.mu.RUnlock()
+	s.cache.mu.Lock()
+	defer s.cache.mu.Unlock()
 	
 	project, exists := s.cache.projects[projectName]
-	return project, exists
+	if !exists {
+		s.cache.misses++
+		return nil, false
+	}
+	
+	s.cache.hits++
+	if elem, ok := s.cache.lruItems[projectName]; ok {
+		s.cache.lru.MoveToFront(elem)
+	}
+	return project, true
 }
 
-// PutInCache stores a project in cache
+// PutInCache stores a project in cache, evicting the least recently used ones beyond the limit
 func (s *Storage) PutInCache(projectName string, project *models.Project) {
 	s.cache.mu.Lock()
 	defer s.cache.mu.Unlock()
 	
 	s.cache.projects[projectName] = project
+	s.cache.loaded[projectName] = time.Now()
+	
+	if elem, ok := s.cache.lruItems[projectName]; ok {
+		s.cache.lru.MoveToFront(elem)
+	} else {
+		s.cache.lruItems[projectName] = s.cache.lru.PushFront(projectName)
+	}
+	
+	s.evictLocked()
+}
+
+// evictLocked drops least recently used projects until the cache fits. Dirty projects are kept
+// so unsaved changes aren't lost. Callers hold the cache lock.
+func (s *Storage) evictLocked() {
+	if s.cache.maxProjects <= 0 {
+		return
+	}
+	
+	elem := s.cache.lru.Back()
+	for len(s.cache.projects) > s.cache.maxProjects && elem != nil {
+		prev := elem.Prev()
+		name := elem.Value.(string)
+		if !s.cache.dirty[name] {
+			s.cache.lru.Remove(elem)
+			delete(s.cache.lruItems, name)
+			delete(s.cache.projects, name)
+			delete(s.cache.loaded, name)
+			delete(s.cache.taskMaps, name)
+			s.cache.evictions++
+			logging.Debugf("Evicted project %s from the cache", name)
+		}
+		elem = prev
+	}
+}
+
+// isCacheStale reports whether another process has written a cached project since it was loaded
+func (s *Storage) isCacheStale(projectName string) bool {
+	s.cache.mu.RLock()
+	loaded, exists := s.cache.loaded[projectName]
+	s.cache.mu.RUnlock()
+	if !exists {
+		return false
+	}
+
+	modTime, err := s.backend.ProjectModTime(projectName)
+	return err == nil && modTime.After(loaded)
 }
 
 // MarkDirty marks a project as needing to be saved
@@ -145,10 +415,16 @@ func (s *Storage) InvalidateCache(projectName string) {
 	defer s.cache.mu.Unlock()
 	
 	delete(s.cache.projects, projectName)
+	delete(s.cache.loaded, projectName)
 	delete(s.cache.dirty, projectName)
+	delete(s.cache.taskMaps, projectName)
+	if elem, ok := s.cache.lruItems[projectName]; ok {
+		s.cache.lru.Remove(elem)
+		delete(s.cache.lruItems, projectName)
+	}
 }
 
-// FlushAll saves all dirty projects to disk
+// FlushAll saves all dirty projects and pending index changes to disk
 func (s *Storage) FlushAll() error {
 	s.cache.mu.Lock()
 	dirtyProjects := make([]string, 0, len(s.cache.dirty))
@@ -165,6 +441,10 @@ func (s *Storage) FlushAll() error {
 		}
 	}
 	
+	if err := s.FlushIndex(); err != nil {
+		return fmt.Errorf("failed to save index: %w", err)
+	}
+	
 	return nil
 }
 
@@ -174,7 +454,11 @@ func (s *Storage) ClearCache() {
 	defer s.cache.mu.Unlock()
 	
 	s.cache.projects = make(map[string]*models.Project)
+	s.cache.loaded = make(map[string]time.Time)
 	s.cache.dirty = make(map[string]bool)
+	s.cache.taskMaps = make(map[string]*taskMap)
+	s.cache.lru.Init()
+	s.cache.lruItems = make(map[string]*list.Element)
 }
 
 // GetCacheStats returns statistics about cache usage
@@ -182,34 +466,80 @@ func (s *Storage) GetCacheStats() map[string]interface{} {
 	s.cache.mu.RLock()
 	defer s.cache.mu.RUnlock()
 	
+	hitRate := 0.0
+	if lookups := s.cache.hits + s.cache.misses; lookups > 0 {
+		hitRate = float64(s.cache.hits) / float64(lookups) * 100
+	}
+	
 	return map[string]interface{}{
 		"cached_projects": len(s.cache.projects),
+		"max_projects":    s.cache.maxProjects,
 		"dirty_projects":  len(s.cache.dirty),
 		"index_entries":   len(s.cache.index),
+		"hits":            s.cache.hits,
+		"misses":          s.cache.misses,
+		"hit_rate":        hitRate,
+		"evictions":       s.cache.evictions,
+		"backend":         s.backend.Name(),
 	}
 }
 
 // ListProjects returns all project names
 func (s *Storage) ListProjects() ([]string, error) {
-	return s.config.ListProjectFiles()
+	names, err := s.backend.ListProjects()
+	if err != nil {
+		return nil, err
+	}
+	return withDryRunProjects(names), nil
 }
 
-// ProjectExists checks if a project exists
+// ProjectExists checks if a project exists. No project has a name that
+// ValidateProjectName rejects.
 func (s *Storage) ProjectExists(projectName string) bool {
-	return s.config.ProjectExists(projectName)
+	if ValidateProjectName(projectName) != nil {
+		return false
+	}
+	if exists, ok := dryRunProjectExists(projectName); ok {
+		return exists
+	}
+	return s.backend.ProjectExists(projectName)
 }
 
-// DeleteProject removes a project file and clears cache
+// DeleteProject moves a project to the trash, removes it from the backend and clears cache
 func (s *Storage) DeleteProject(projectName string) error {
-	path := s.config.GetProjectPath(projectName)
-	
+	if err := ValidateProjectName(projectName); err != nil {
+		return err
+	}
 	// Remove from cache first
 	s.InvalidateCache(projectName)
 	
-	// Delete file
-	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
-		return fmt.Errorf("failed to delete project file: %w", err)
+	err := s.withLock("project-"+projectName, func() error {
+		project, err := s.backend.LoadProject(projectName)
+		if err != nil {
+			return err
+		}
+		
+		trashID, err := s.moveToTrash(models.TrashItem{
+			Kind:        models.TrashProject,
+			Project:     projectName,
+			ProjectData: project,
+		})
+		if err != nil {
+			return err
+		}
+		
+		if err := s.backend.DeleteProject(projectName); err != nil {
+			s.discardTrash(trashID)
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete project: %w", err)
 	}
+	recordProject(projectName, false)
+	s.forgetOutline(projectName)
+	s.queueEvents(events.Event{Type: events.ProjectRemoved, Project: projectName})
 	
 	// Rebuild index
 	return s.RebuildIndex()
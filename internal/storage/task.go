@@ -1,53 +1,57 @@
 package storage
 
 import (
+	"context"
 	"crypto/rand"
 	"encoding/hex"
 	"fmt"
 	"time"
 
-	"github.com/mrbooshehri/qix-go/internal/models"
+	"github.com/mrbooshehri/qix-go/pkg/qix/models"
 )
 
-// GenerateTaskID generates a unique 8-character hex ID
+// GenerateTaskID generates a random 8-character hex ID; UniqueTaskID also
+// checks that no task has it
 func GenerateTaskID() string {
 	bytes := make([]byte, 4)
 	rand.Read(bytes)
 	return hex.EncodeToString(bytes)
 }
 
-// FindTask locates a task and returns it with its location
+// FindTask locates a task and returns it with its location.
+// If the task isn't found, the project is reloaded in case another process changed it,
+// and its index entries are rebuilt if they were out of date.
 func (s *Storage) FindTask(projectName, taskID string) (*models.Task, string, error) {
 	project, err := s.LoadProject(projectName)
 	if err != nil {
 		return nil, "", err
 	}
 	
-	// Check project-level tasks
-	for i := range project.Tasks {
-		if project.Tasks[i].ID == taskID {
-			return &project.Tasks[i], "project", nil
-		}
+	if task, location, found := s.findTaskFast(projectName, project, taskID); found {
+		return task, location, nil
 	}
 	
-	// Check module tasks
-	for i := range project.Modules {
-		for j := range project.Modules[i].Tasks {
-			if project.Modules[i].Tasks[j].ID == taskID {
-				location := fmt.Sprintf("module:%s", project.Modules[i].Name)
-				return &project.Modules[i].Tasks[j], location, nil
-			}
+	if fresh, err := s.loadFreshProject(projectName); err == nil && fresh != project {
+		if task, location, found := s.findTaskFast(projectName, fresh, taskID); found {
+			s.healIndex(projectName, fresh, taskID)
+			return task, location, nil
 		}
+		project = fresh
 	}
 	
-	return nil, "", fmt.Errorf("task '%s' not found", taskID)
+	// Drop an index entry that still places the task here
+	if indexed, ok := s.indexedProject(taskID); ok && indexed == projectName {
+		s.healIndex(projectName, project, taskID)
+	}
+	
+	return nil, "", notFoundf("task '%s' not found", taskID)
 }
 
 // AddTask adds a task to a project or module
 func (s *Storage) AddTask(projectName, moduleName string, task models.Task) error {
 	// Generate ID if not provided
 	if task.ID == "" {
-		task.ID = GenerateTaskID()
+		task.ID = s.UniqueTaskID()
 	}
 	
 	// Set timestamps
@@ -73,84 +77,88 @@ func (s *Storage) AddTask(projectName, moduleName string, task models.Task) erro
 	if task.Priority == "" {
 		task.Priority = models.PriorityMedium
 	}
+	task.RecordStatus(task.Status, now)
 	
-	return s.UpdateProject(projectName, func(p *models.Project) error {
-		if moduleName == "" {
-			// Add to project-level tasks
-			p.Tasks = append(p.Tasks, task)
-		} else {
-			// Add to module tasks
-			found := false
-			for i := range p.Modules {
-				if p.Modules[i].Name == moduleName {
-					p.Modules[i].Tasks = append(p.Modules[i].Tasks, task)
-					found = true
-					break
-				}
-			}
-			if !found {
-				return fmt.Errorf("module '%s' not found", moduleName)
-			}
+	journalID := ""
+	err := s.UpdateProject(projectName, func(p *models.Project) error {
+		var err error
+		journalID, err = s.writeJournal(models.OpTaskCreated, projectName, moduleName, nil, &task)
+		if err != nil {
+			return err
 		}
-		return nil
+		return insertTask(p, moduleName, task)
 	})
+	
+	return s.finishJournal(projectName, journalID, err)
 }
 
 // UpdateTask updates a task by ID
+// The change is made on a copy and journaled before it replaces the stored task.
 func (s *Storage) UpdateTask(projectName, taskID string, updater func(*models.Task) error) error {
-	return s.UpdateProject(projectName, func(p *models.Project) error {
-		// Try project-level tasks
-		for i := range p.Tasks {
-			if p.Tasks[i].ID == taskID {
-				if err := updater(&p.Tasks[i]); err != nil {
-					return err
-				}
-				p.Tasks[i].UpdatedAt = time.Now()
-				return nil
-			}
+	journalID := ""
+	err := s.UpdateProject(projectName, func(p *models.Project) error {
+		task, moduleName := findTaskIn(p, taskID)
+		if task == nil {
+			return notFoundf("task '%s' not found", taskID)
 		}
 		
-		// Try module tasks
-		for i := range p.Modules {
-			for j := range p.Modules[i].Tasks {
-				if p.Modules[i].Tasks[j].ID == taskID {
-					if err := updater(&p.Modules[i].Tasks[j]); err != nil {
-						return err
-					}
-					p.Modules[i].Tasks[j].UpdatedAt = time.Now()
-					return nil
-				}
-			}
+		updated, err := cloneTask(*task)
+		if err != nil {
+			return err
+		}
+		if err := updater(&updated); err != nil {
+			return err
 		}
+		now := time.Now()
+		updated.RecordStatusChange(task.Status, now)
+		updated.UpdatedAt = now
 		
-		return fmt.Errorf("task '%s' not found", taskID)
+		journalID, err = s.writeJournal(journalOpFor(*task, updated), projectName, moduleName, task, &updated)
+		if err != nil {
+			return err
+		}
+		
+		*task = updated
+		return nil
 	})
+	
+	return s.finishJournal(projectName, journalID, err)
 }
 
-// RemoveTask removes a task by ID
+// RemoveTask moves a task to the trash and removes it from its project
 func (s *Storage) RemoveTask(projectName, taskID string) error {
-	return s.UpdateProject(projectName, func(p *models.Project) error {
-		// Try project-level tasks
-		for i := range p.Tasks {
-			if p.Tasks[i].ID == taskID {
-				p.Tasks = append(p.Tasks[:i], p.Tasks[i+1:]...)
-				return nil
-			}
+	journalID := ""
+	trashID := ""
+	err := s.UpdateProject(projectName, func(p *models.Project) error {
+		task, moduleName := findTaskIn(p, taskID)
+		if task == nil {
+			return notFoundf("task '%s' not found", taskID)
 		}
 		
-		// Try module tasks
-		for i := range p.Modules {
-			for j := range p.Modules[i].Tasks {
-				if p.Modules[i].Tasks[j].ID == taskID {
-					tasks := p.Modules[i].Tasks
-					p.Modules[i].Tasks = append(tasks[:j], tasks[j+1:]...)
-					return nil
-				}
-			}
+		var err error
+		trashID, err = s.moveToTrash(models.TrashItem{
+			Kind:    models.TrashTask,
+			Project: projectName,
+			Module:  moduleName,
+			Task:    task,
+		})
+		if err != nil {
+			return err
 		}
 		
-		return fmt.Errorf("task '%s' not found", taskID)
+		journalID, err = s.writeJournal(models.OpTaskRemoved, projectName, moduleName, task, nil)
+		if err != nil {
+			return err
+		}
+		
+		deleteTask(p, taskID)
+		return nil
 	})
+	if err != nil {
+		s.discardTrash(trashID)
+	}
+	
+	return s.finishJournal(projectName, journalID, err)
 }
 
 // UpdateTaskStatus updates a task's status
@@ -253,14 +261,35 @@ func (s *Storage) GetRecurringTasksDue(projectName, date string) ([]models.Task,
 		return nil, err
 	}
 	
+	return recurringTasksDue(project, date), nil
+}
+
+// GetAllRecurringTasksDue loads the named projects concurrently and returns their due recurring tasks.
+// Projects with nothing due are left out.
+func (s *Storage) GetAllRecurringTasksDue(ctx context.Context, projectNames []string, date string) (map[string][]models.Task, error) {
+	projects, err := s.LoadProjects(ctx, projectNames)
+	if err != nil {
+		return nil, err
+	}
+	
+	due := make(map[string][]models.Task)
+	for _, project := range projects {
+		if tasks := recurringTasksDue(project, date); len(tasks) > 0 {
+			due[project.Name] = tasks
+		}
+	}
+	return due, nil
+}
+
+// recurringTasksDue returns a project's recurring tasks due on or before date
+func recurringTasksDue(project *models.Project, date string) []models.Task {
 	tasks := make([]models.Task, 0)
 	for _, task := range project.GetAllTasks() {
 		if task.IsRecurring() && task.Recurrence.NextDue <= date {
 			tasks = append(tasks, task)
 		}
 	}
-	
-	return tasks, nil
+	return tasks
 }
 
 // GetChildTasks returns all tasks that have the given task as parent
@@ -313,5 +342,5 @@ func (s *Storage) ListTasksInModule(projectName, moduleName string) ([]models.Ta
 		}
 	}
 	
-	return nil, fmt.Errorf("module '%s' not found", moduleName)
+	return nil, notFoundf("module '%s' not found", moduleName)
 }
\ No newline at end of file
@@ -0,0 +1,196 @@
+package storage
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mrbooshehri/qix-go/pkg/qix/models"
+)
+
+// TaskPlace is where one of the tasks sharing an ID is stored
+type TaskPlace struct {
+	Project string `json:"project"`
+	Module  string `json:"module,omitempty"` // Empty for project-level tasks
+	Title   string `json:"title"`
+}
+
+// String returns the place as project or project/module
+func (p TaskPlace) String() string {
+	if p.Module == "" {
+		return p.Project
+	}
+	return p.Project + "/" + p.Module
+}
+
+// UniqueTaskID returns a new task ID no indexed task has
+func (s *Storage) UniqueTaskID() string {
+	for {
+		id := GenerateTaskID()
+		if _, taken := s.indexedProject(id); !taken {
+			return id
+		}
+	}
+}
+
+// DuplicateTaskIDs returns the task IDs held by more than one task, within a
+// project or across projects, and where each of those tasks is. Projects that
+// fail to load are skipped; report those separately.
+func (s *Storage) DuplicateTaskIDs() (map[string][]TaskPlace, error) {
+	projects, err := s.ListProjects()
+	if err != nil {
+		return nil, err
+	}
+
+	places := make(map[string][]TaskPlace)
+	for _, projectName := range projects {
+		project, err := s.LoadProject(projectName)
+		if err != nil {
+			continue
+		}
+		for _, task := range project.Tasks {
+			places[task.ID] = append(places[task.ID], TaskPlace{Project: projectName, Title: task.Title})
+		}
+		for _, module := range project.Modules {
+			for _, task := range module.Tasks {
+				places[task.ID] = append(places[task.ID], TaskPlace{Project: projectName, Module: module.Name, Title: task.Title})
+			}
+		}
+	}
+
+	for id, list := range places {
+		if len(list) < 2 {
+			delete(places, id)
+		}
+	}
+	return places, nil
+}
+
+// ReassignTaskID gives the task with oldID in a project, at project level if
+// moduleName is empty or in that module otherwise, a new ID: newID, or a
+// generated one if it's empty. The task's dependencies, children, sprints and
+// running timer follow it, unless another task in the project keeps the old
+// ID, since they can't tell the two apart.
+func (s *Storage) ReassignTaskID(projectName, moduleName, oldID, newID string) (string, error) {
+	if newID == "" {
+		newID = s.UniqueTaskID()
+	} else if strings.ContainsAny(newID, "/ \t") {
+		return "", fmt.Errorf("invalid task ID %q", newID)
+	} else if project, taken := s.indexedProject(newID); taken {
+		return "", fmt.Errorf("task ID %s is already used in %s", newID, project)
+	}
+
+	followed := false
+	err := s.UpdateProject(projectName, func(p *models.Project) error {
+		task := taskAt(p, moduleName, oldID)
+		if task == nil {
+			place := TaskPlace{Project: projectName, Module: moduleName}
+			return notFoundf("task '%s' not found in %s", oldID, place)
+		}
+		if t, _ := findTaskIn(p, newID); t != nil {
+			return fmt.Errorf("task ID %s is already used in %s", newID, projectName)
+		}
+		task.ID = newID
+
+		if t, _ := findTaskIn(p, oldID); t != nil {
+			// The other task keeps the references
+			return nil
+		}
+		followed = true
+		renameTaskReferences(p, oldID, newID)
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+
+	if followed {
+		if err := s.renameTrackedTask(projectName, oldID, newID); err != nil {
+			return newID, err
+		}
+	}
+	// The index holds one place per ID, so the other copy may have been left
+	// out of it
+	return newID, s.RebuildIndex()
+}
+
+// taskAt returns the task with an ID at project level or in a module
+func taskAt(p *models.Project, moduleName, taskID string) *models.Task {
+	if moduleName == "" {
+		for i := range p.Tasks {
+			if p.Tasks[i].ID == taskID {
+				return &p.Tasks[i]
+			}
+		}
+		return nil
+	}
+	for i := range p.Modules {
+		if p.Modules[i].Name != moduleName {
+			continue
+		}
+		for j := range p.Modules[i].Tasks {
+			if p.Modules[i].Tasks[j].ID == taskID {
+				return &p.Modules[i].Tasks[j]
+			}
+		}
+	}
+	return nil
+}
+
+// renameTaskReferences points a project's dependencies, parents and sprints
+// at a task's new ID
+func renameTaskReferences(p *models.Project, oldID, newID string) {
+	rename := func(task *models.Task) {
+		if task.ParentID == oldID {
+			task.ParentID = newID
+		}
+		for i, dep := range task.Dependencies {
+			if dep == oldID {
+				task.Dependencies[i] = newID
+			}
+		}
+	}
+	for i := range p.Tasks {
+		rename(&p.Tasks[i])
+	}
+	for i := range p.Modules {
+		for j := range p.Modules[i].Tasks {
+			rename(&p.Modules[i].Tasks[j])
+		}
+	}
+
+	for i := range p.Sprints {
+		sprint := &p.Sprints[i]
+		for j, id := range sprint.TaskIDs {
+			if id == oldID {
+				sprint.TaskIDs[j] = newID
+			}
+		}
+		for _, times := range []map[string]time.Time{sprint.AddedAt, sprint.RemovedAt} {
+			if at, ok := times[oldID]; ok {
+				times[newID] = at
+				delete(times, oldID)
+			}
+		}
+	}
+}
+
+// renameTrackedTask points the running timer at a task's new ID
+func (s *Storage) renameTrackedTask(projectName, oldID, newID string) error {
+	lock, err := s.acquireLock("tracking")
+	if err != nil {
+		return err
+	}
+	defer lock.release()
+
+	data, err := s.LoadTrackingData()
+	if err != nil {
+		return err
+	}
+	session := data.ActiveSession
+	if session == nil || session.TaskID != oldID || strings.SplitN(session.Path, "/", 2)[0] != projectName {
+		return nil
+	}
+	session.TaskID = newID
+	return s.SaveTrackingData(data)
+}
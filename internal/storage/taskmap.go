@@ -0,0 +1,98 @@
+package storage
+
+import (
+	"fmt"
+
+	"github.com/mrbooshehri/qix-go/pkg/qix/models"
+)
+
+// taskPos is where a task sits in a project; module is -1 for project-level tasks
+type taskPos struct {
+	module int
+	index  int
+}
+
+// taskMap maps task IDs to their positions in one cached project, so FindTask is O(1).
+// Positions shift as tasks are added and removed, so every hit is checked against the
+// project and the map is rebuilt when it no longer matches.
+type taskMap struct {
+	project *models.Project // The cached copy the positions refer to
+	count   int             // Number of tasks when the map was built
+	pos     map[string]taskPos
+}
+
+// buildTaskMap records the position of every task in a project
+func buildTaskMap(project *models.Project) *taskMap {
+	m := &taskMap{project: project, pos: make(map[string]taskPos)}
+	for i, task := range project.Tasks {
+		m.pos[task.ID] = taskPos{module: -1, index: i}
+	}
+	for i, module := range project.Modules {
+		for j, task := range module.Tasks {
+			m.pos[task.ID] = taskPos{module: i, index: j}
+		}
+	}
+	m.count = countTasks(project)
+	return m
+}
+
+// countTasks returns the number of tasks in a project without visiting each one
+func countTasks(project *models.Project) int {
+	count := len(project.Tasks)
+	for _, module := range project.Modules {
+		count += len(module.Tasks)
+	}
+	return count
+}
+
+// get returns the task at its recorded position if it is still there
+func (m *taskMap) get(taskID string) (*models.Task, string, bool) {
+	pos, exists := m.pos[taskID]
+	if !exists {
+		return nil, "", false
+	}
+
+	p := m.project
+	if pos.module < 0 {
+		if pos.index < len(p.Tasks) && p.Tasks[pos.index].ID == taskID {
+			return &p.Tasks[pos.index], "project", true
+		}
+		return nil, "", false
+	}
+
+	if pos.module < len(p.Modules) {
+		module := &p.Modules[pos.module]
+		if pos.index < len(module.Tasks) && module.Tasks[pos.index].ID == taskID {
+			return &module.Tasks[pos.index], fmt.Sprintf("module:%s", module.Name), true
+		}
+	}
+	return nil, "", false
+}
+
+// taskMapFor returns the task map for a cached project, building it if missing, stale or rebuild is set
+func (s *Storage) taskMapFor(projectName string, project *models.Project, rebuild bool) *taskMap {
+	s.cache.mu.Lock()
+	defer s.cache.mu.Unlock()
+
+	m := s.cache.taskMaps[projectName]
+	if rebuild || m == nil || m.project != project {
+		m = buildTaskMap(project)
+		s.cache.taskMaps[projectName] = m
+	}
+	return m
+}
+
+// findTaskFast looks a task up through the project's task map.
+// A miss rebuilds the map once if the project changed size or the index places the task here.
+func (s *Storage) findTaskFast(projectName string, project *models.Project, taskID string) (*models.Task, string, bool) {
+	m := s.taskMapFor(projectName, project, false)
+	if task, location, found := m.get(taskID); found {
+		return task, location, true
+	}
+
+	indexed, ok := s.indexedProject(taskID)
+	if _, recorded := m.pos[taskID]; recorded || (ok && indexed == projectName) || m.count != countTasks(project) {
+		return s.taskMapFor(projectName, project, true).get(taskID)
+	}
+	return nil, "", false
+}
@@ -0,0 +1,272 @@
+package storage
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/mrbooshehri/qix-go/pkg/qix/models"
+)
+
+// The JSON backend keeps time entries out of the project file, in one sidecar file per
+// month: projects/<name>.time/<YYYY-MM>.json, mapping task IDs to that month's entries.
+// Saving a project only rewrites the months that changed, and date-range queries read
+// only the months they cover.
+
+// monthEntries maps task IDs to their time entries for one month
+type monthEntries map[string][]models.TimeEntry
+
+// timeDir returns the sidecar directory holding a project's time entries
+func (b *jsonBackend) timeDir(name string) string {
+	return filepath.Join(b.config.ProjectsDir, name+".time")
+}
+
+// entryMonth returns the YYYY-MM file an entry belongs in
+func entryMonth(entry models.TimeEntry) string {
+	if len(entry.Date) >= 7 {
+		return entry.Date[:7]
+	}
+	return "undated"
+}
+
+// splitTimeEntries returns a copy of the project without time entries, and the entries grouped by month
+func splitTimeEntries(project *models.Project) (*models.Project, map[string]monthEntries) {
+	months := make(map[string]monthEntries)
+
+	strip := func(tasks []models.Task) []models.Task {
+		stripped := make([]models.Task, len(tasks))
+		for i, task := range tasks {
+			for _, entry := range task.TimeEntries {
+				month := entryMonth(entry)
+				if months[month] == nil {
+					months[month] = make(monthEntries)
+				}
+				months[month][task.ID] = append(months[month][task.ID], entry)
+			}
+			task.TimeEntries = make([]models.TimeEntry, 0)
+			stripped[i] = task
+		}
+		return stripped
+	}
+
+	copied := *project
+	copied.Tasks = strip(project.Tasks)
+	copied.Modules = make([]models.Module, len(project.Modules))
+	for i, module := range project.Modules {
+		module.Tasks = strip(module.Tasks)
+		copied.Modules[i] = module
+	}
+
+	return &copied, months
+}
+
+// saveTimeEntries writes the month files that changed and removes months that no longer have entries
+func (b *jsonBackend) saveTimeEntries(name string, months map[string]monthEntries) error {
+	dir := b.timeDir(name)
+	if err := mkdirAll(dir); err != nil {
+		return err
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.monthHashes == nil {
+		b.monthHashes = make(map[string]string)
+	}
+
+	for month, entries := range months {
+		data, err := json.Marshal(entries)
+		if err != nil {
+			return err
+		}
+		sum := sha256.Sum256(data)
+		hash := hex.EncodeToString(sum[:])
+
+		key := name + "/" + month
+		if b.monthHashes[key] == hash {
+			continue
+		}
+		if err := writeJSONFile(filepath.Join(dir, month+".json"), entries); err != nil {
+			return err
+		}
+		b.monthHashes[key] = hash
+	}
+
+	files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return err
+	}
+	for _, file := range files {
+		month := strings.TrimSuffix(filepath.Base(file), ".json")
+		if _, ok := months[month]; !ok {
+			if skipRemove(file) {
+				continue
+			}
+			if err := os.Remove(file); err != nil && !os.IsNotExist(err) {
+				return err
+			}
+			delete(b.monthHashes, name+"/"+month)
+		}
+	}
+
+	return nil
+}
+
+// loadTimeEntries reads the month files between fromMonth and toMonth (inclusive; empty means unbounded).
+// ok is false if the project has no sidecar directory because it was never saved in this layout.
+func (b *jsonBackend) loadTimeEntries(name, fromMonth, toMonth string) (map[string][]models.TimeEntry, bool, error) {
+	dir := b.timeDir(name)
+	if _, err := os.Stat(dir); os.IsNotExist(err) {
+		return nil, false, nil
+	}
+
+	files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, true, err
+	}
+	sort.Strings(files)
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.monthHashes == nil {
+		b.monthHashes = make(map[string]string)
+	}
+
+	byTask := make(map[string][]models.TimeEntry)
+	for _, file := range files {
+		month := strings.TrimSuffix(filepath.Base(file), ".json")
+		if (fromMonth != "" && month < fromMonth) || (toMonth != "" && month > toMonth) {
+			continue
+		}
+
+		data, err := readDataFile(file)
+		if err != nil {
+			return nil, true, err
+		}
+		var entries monthEntries
+		if err := json.Unmarshal(data, &entries); err != nil {
+			return nil, true, err
+		}
+
+		// Remember what is on disk so an unchanged month isn't rewritten on save
+		if fromMonth == "" && toMonth == "" {
+			canonical, err := json.Marshal(entries)
+			if err == nil {
+				sum := sha256.Sum256(canonical)
+				b.monthHashes[name+"/"+month] = hex.EncodeToString(sum[:])
+			}
+		}
+
+		for taskID, list := range entries {
+			byTask[taskID] = append(byTask[taskID], list...)
+		}
+	}
+
+	return byTask, true, nil
+}
+
+// mergeTimeEntries adds sidecar entries to a project's tasks, skipping any already inline.
+// Inline entries remain in files written before entries were split out.
+func mergeTimeEntries(project *models.Project, byTask map[string][]models.TimeEntry) {
+	key := func(entry models.TimeEntry) string {
+		data, _ := json.Marshal(entry)
+		return string(data)
+	}
+
+	merge := func(task *models.Task) {
+		extra := byTask[task.ID]
+		if len(extra) == 0 {
+			return
+		}
+
+		seen := make(map[string]bool, len(task.TimeEntries))
+		for _, entry := range task.TimeEntries {
+			seen[key(entry)] = true
+		}
+		for _, entry := range extra {
+			if !seen[key(entry)] {
+				task.TimeEntries = append(task.TimeEntries, entry)
+			}
+		}
+	}
+
+	for i := range project.Tasks {
+		merge(&project.Tasks[i])
+	}
+	for i := range project.Modules {
+		for j := range project.Modules[i].Tasks {
+			merge(&project.Modules[i].Tasks[j])
+		}
+	}
+}
+
+// TimeEntriesInRange reads only the month files covering the range, loading whole
+// projects only for those still storing entries inline. Projects are read concurrently.
+func (b *jsonBackend) TimeEntriesInRange(ctx context.Context, startDate, endDate string) (map[string][]models.TimeEntry, error) {
+	names, err := b.ListProjects()
+	if err != nil {
+		return nil, err
+	}
+
+	inRange := func(entry models.TimeEntry) bool {
+		return entry.Date >= startDate && entry.Date <= endDate
+	}
+
+	found := make([][]models.TimeEntry, len(names))
+	err = forEachParallel(ctx, len(names), func(ctx context.Context, i int) error {
+		name := names[i]
+		byTask, ok, err := b.loadTimeEntries(name, entryMonth(models.TimeEntry{Date: startDate}), entryMonth(models.TimeEntry{Date: endDate}))
+		if err != nil {
+			return err
+		}
+
+		entries := make([]models.TimeEntry, 0)
+		if ok {
+			for _, list := range byTask {
+				for _, entry := range list {
+					if inRange(entry) {
+						entries = append(entries, entry)
+					}
+				}
+			}
+		} else {
+			project, err := b.LoadProject(name)
+			if err != nil {
+				return nil
+			}
+			for _, task := range project.GetAllTasks() {
+				for _, entry := range task.TimeEntries {
+					if inRange(entry) {
+						entries = append(entries, entry)
+					}
+				}
+			}
+		}
+
+		if len(entries) > 0 {
+			sort.SliceStable(entries, func(i, j int) bool {
+				if entries[i].Date != entries[j].Date {
+					return entries[i].Date < entries[j].Date
+				}
+				return entries[i].LoggedAt.Before(entries[j].LoggedAt)
+			})
+			found[i] = entries
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	entriesByProject := make(map[string][]models.TimeEntry)
+	for i, entries := range found {
+		if entries != nil {
+			entriesByProject[names[i]] = entries
+		}
+	}
+	return entriesByProject, nil
+}
@@ -1,12 +1,15 @@
 package storage
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"strings"
 	"time"
 
-	"github.com/mrbooshehri/qix-go/internal/models"
+	"github.com/mrbooshehri/qix-go/internal/events"
+	"github.com/mrbooshehri/qix-go/internal/migrations"
+	"github.com/mrbooshehri/qix-go/pkg/qix/models"
 )
 
 // LoadTrackingData loads the tracking session data
@@ -21,7 +24,7 @@ func (s *Storage) LoadTrackingData() (*models.TrackingData, error) {
 	}
 
 	var data models.TrackingData
-	if err := readJSONFile(s.config.TrackFile, &data); err != nil {
+	if err := readVersionedFile(s.config.TrackFile, migrations.KindTracking, &data); err != nil {
 		return nil, fmt.Errorf("failed to load tracking data: %w", err)
 	}
 
@@ -30,6 +33,7 @@ func (s *Storage) LoadTrackingData() (*models.TrackingData, error) {
 
 // SaveTrackingData saves the tracking session data
 func (s *Storage) SaveTrackingData(data *models.TrackingData) error {
+	data.SchemaVersion = migrations.Latest(migrations.KindTracking)
 	return writeJSONFile(s.config.TrackFile, data)
 }
 
@@ -40,6 +44,12 @@ func (s *Storage) StartTracking(projectName, moduleName, taskID string) error {
 		return fmt.Errorf("task not found: %w", err)
 	}
 
+	lock, err := s.acquireLock("tracking")
+	if err != nil {
+		return err
+	}
+	defer lock.release()
+
 	data, err := s.LoadTrackingData()
 	if err != nil {
 		return err
@@ -63,11 +73,21 @@ func (s *Storage) StartTracking(projectName, moduleName, taskID string) error {
 		StartTime: time.Now(),
 	}
 
-	return s.SaveTrackingData(data)
+	if err := s.SaveTrackingData(data); err != nil {
+		return err
+	}
+	s.queueEvents(s.trackEvent(events.TrackStart, *data.ActiveSession, nil))
+	return nil
 }
 
 // StopTracking stops the current tracking session and logs time
 func (s *Storage) StopTracking() (time.Duration, string, string, error) {
+	lock, err := s.acquireLock("tracking")
+	if err != nil {
+		return 0, "", "", err
+	}
+	defer lock.release()
+
 	data, err := s.LoadTrackingData()
 	if err != nil {
 		return 0, "", "", err
@@ -106,10 +126,56 @@ func (s *Storage) StopTracking() (time.Duration, string, string, error) {
 	if err := s.SaveTrackingData(data); err != nil {
 		return 0, "", "", err
 	}
+	end := session.StartTime.Add(elapsed)
+	s.queueEvents(s.trackEvent(events.TrackStop, *session, &end))
 
 	return elapsed, path, taskID, nil
 }
 
+// trackEvent returns the event of a tracking session starting, or stopping at
+// end once its time is logged
+func (s *Storage) trackEvent(typ string, session models.TrackingSession, end *time.Time) events.Event {
+	projectName, _, _ := strings.Cut(session.Path, "/")
+	event := events.Event{
+		Type:    typ,
+		Project: projectName,
+		Session: &events.Session{Path: session.Path, Start: session.StartTime, End: end},
+	}
+	if end != nil {
+		event.At = *end
+		event.Session.Hours = end.Sub(session.StartTime).Hours()
+	}
+	if task, location, err := s.FindTask(projectName, session.TaskID); err == nil {
+		moduleName := ""
+		if name, ok := strings.CutPrefix(location, "module:"); ok {
+			moduleName = name
+		}
+		info := events.NewTaskInfo(projectName, moduleName, *task)
+		event.Task = &info
+	}
+	return event
+}
+
+// DiscardTracking ends the current tracking session without logging its time
+func (s *Storage) DiscardTracking() error {
+	lock, err := s.acquireLock("tracking")
+	if err != nil {
+		return err
+	}
+	defer lock.release()
+
+	data, err := s.LoadTrackingData()
+	if err != nil {
+		return err
+	}
+	if data.ActiveSession == nil {
+		return nil
+	}
+
+	data.ActiveSession = nil
+	return s.SaveTrackingData(data)
+}
+
 // GetActiveSession returns the current active session if any
 func (s *Storage) GetActiveSession() (*models.TrackingSession, error) {
 	data, err := s.LoadTrackingData()
@@ -164,7 +230,16 @@ func (s *Storage) SwitchTracking(projectName, moduleName, taskID string) error {
 
 // GetTimeEntriesForDate returns all time entries for a specific date
 func (s *Storage) GetTimeEntriesForDate(date string) (map[string][]models.TimeEntry, error) {
-	projects, err := s.GetAllProjects()
+	return s.GetAllTimeEntriesInRange(context.Background(), date, date)
+}
+
+// GetAllTimeEntriesInRange returns time entries from every project between two dates, grouped by project
+func (s *Storage) GetAllTimeEntriesInRange(ctx context.Context, startDate, endDate string) (map[string][]models.TimeEntry, error) {
+	if querier, ok := s.backend.(TimeEntryQuerier); ok {
+		return querier.TimeEntriesInRange(ctx, startDate, endDate)
+	}
+
+	projects, err := s.GetAllProjectsContext(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -176,7 +251,7 @@ func (s *Storage) GetTimeEntriesForDate(date string) (map[string][]models.TimeEn
 
 		for _, task := range project.GetAllTasks() {
 			for _, entry := range task.TimeEntries {
-				if entry.Date == date {
+				if entry.Date >= startDate && entry.Date <= endDate {
 					entries = append(entries, entry)
 				}
 			}
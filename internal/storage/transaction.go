@@ -0,0 +1,128 @@
+package storage
+
+import (
+	"fmt"
+)
+
+// Tx batches changes to one project so the file is written once, on Commit.
+// While a transaction is open, updates to its project are applied in memory only.
+type Tx struct {
+	store   *Storage
+	project string
+	lock    *fileLock
+	journal []string // Journal entries to commit or abort with the transaction
+	done    bool
+}
+
+// Begin starts a transaction on a project, holding its lock until Commit or Rollback
+func (s *Storage) Begin(projectName string) (*Tx, error) {
+	if err := ValidateProjectName(projectName); err != nil {
+		return nil, err
+	}
+	s.txMu.Lock()
+	if _, open := s.txs[projectName]; open {
+		s.txMu.Unlock()
+		return nil, fmt.Errorf("a transaction is already open on project '%s'", projectName)
+	}
+	s.txMu.Unlock()
+
+	lock, err := s.acquireLock("project-" + projectName)
+	if err != nil {
+		return nil, err
+	}
+
+	// Start from the latest saved state
+	if !s.IsDirty(projectName) && s.isCacheStale(projectName) {
+		s.InvalidateCache(projectName)
+	}
+	if _, err := s.LoadProject(projectName); err != nil {
+		lock.release()
+		return nil, err
+	}
+
+	tx := &Tx{store: s, project: projectName, lock: lock}
+	s.txMu.Lock()
+	s.txs[projectName] = tx
+	s.txMu.Unlock()
+
+	return tx, nil
+}
+
+// Commit writes all changes made in the transaction and releases the project lock
+func (tx *Tx) Commit() error {
+	if tx.done {
+		return fmt.Errorf("transaction already finished")
+	}
+	defer tx.finish()
+
+	s := tx.store
+	project, err := s.LoadProject(tx.project)
+	if err == nil {
+		err = s.SaveProject(tx.project, project)
+	}
+	if err != nil {
+		for _, id := range tx.journal {
+			s.abortJournal(id)
+		}
+		s.InvalidateCache(tx.project)
+		return err
+	}
+
+	for _, id := range tx.journal {
+		s.commitJournal(id)
+	}
+	return nil
+}
+
+// Rollback discards all changes made in the transaction and releases the project lock.
+// It does nothing if the transaction was already committed, so it is safe to defer.
+func (tx *Tx) Rollback() {
+	if tx.done {
+		return
+	}
+	defer tx.finish()
+
+	s := tx.store
+	for _, id := range tx.journal {
+		s.abortJournal(id)
+	}
+	s.InvalidateCache(tx.project)
+
+	// Drop index entries for the discarded changes
+	if project, err := s.LoadProject(tx.project); err == nil {
+		s.indexProject(tx.project, project)
+	}
+}
+
+// finish closes the transaction and releases its lock
+func (tx *Tx) finish() {
+	tx.done = true
+
+	tx.store.txMu.Lock()
+	delete(tx.store.txs, tx.project)
+	tx.store.txMu.Unlock()
+
+	tx.lock.release()
+}
+
+// WithTx runs fn in a transaction on a project, committing if it succeeds and rolling back otherwise
+func (s *Storage) WithTx(projectName string, fn func() error) error {
+	tx, err := s.Begin(projectName)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := fn(); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// activeTx returns the open transaction on a project, if any
+func (s *Storage) activeTx(projectName string) *Tx {
+	s.txMu.Lock()
+	defer s.txMu.Unlock()
+
+	return s.txs[projectName]
+}
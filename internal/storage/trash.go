@@ -0,0 +1,214 @@
+package storage
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mrbooshehri/qix-go/internal/logging"
+	"github.com/mrbooshehri/qix-go/pkg/qix/models"
+)
+
+// trashPath returns the file holding a trash item
+func (s *Storage) trashPath(id string) string {
+	return filepath.Join(s.config.TrashDir, id+".json")
+}
+
+// moveToTrash saves a copy of something about to be deleted and returns its trash ID
+func (s *Storage) moveToTrash(item models.TrashItem) (string, error) {
+	if err := mkdirAll(s.config.TrashDir); err != nil {
+		return "", fmt.Errorf("failed to create trash: %w", err)
+	}
+
+	item.ID = GenerateTaskID()
+	item.DeletedAt = time.Now()
+	if err := writeJSONFile(s.trashPath(item.ID), item); err != nil {
+		return "", fmt.Errorf("failed to move to trash: %w", err)
+	}
+
+	// Opportunistically drop expired items
+	if _, err := s.PurgeTrash(false); err != nil {
+		logging.Warnf("Failed to purge trash: %v", err)
+	}
+
+	return item.ID, nil
+}
+
+// discardTrash removes a trash item written for a deletion that then failed
+func (s *Storage) discardTrash(id string) {
+	if id == "" {
+		return
+	}
+	if skipRemove(s.trashPath(id)) {
+		return
+	}
+	if err := os.Remove(s.trashPath(id)); err != nil && !os.IsNotExist(err) {
+		logging.Warnf("Failed to remove trash item %s: %v", id, err)
+	}
+}
+
+// ListTrash returns trashed items, most recently deleted first
+func (s *Storage) ListTrash() ([]models.TrashItem, error) {
+	files, err := filepath.Glob(filepath.Join(s.config.TrashDir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]models.TrashItem, 0, len(files))
+	for _, file := range files {
+		var item models.TrashItem
+		if err := readJSONFile(file, &item); err != nil {
+			logging.Warnf("Skipping unreadable trash item %s: %v", filepath.Base(file), err)
+			continue
+		}
+		items = append(items, item)
+	}
+
+	sort.Slice(items, func(i, j int) bool {
+		return items[i].DeletedAt.After(items[j].DeletedAt)
+	})
+	return items, nil
+}
+
+// GetTrashItem loads a trash item by ID or unique ID prefix
+func (s *Storage) GetTrashItem(id string) (*models.TrashItem, error) {
+	items, err := s.ListTrash()
+	if err != nil {
+		return nil, err
+	}
+
+	var match *models.TrashItem
+	for i := range items {
+		if strings.HasPrefix(items[i].ID, id) {
+			if match != nil {
+				return nil, fmt.Errorf("trash ID '%s' is ambiguous", id)
+			}
+			match = &items[i]
+		}
+	}
+	if match == nil {
+		return nil, notFoundf("trash item '%s' not found", id)
+	}
+	return match, nil
+}
+
+// RestoreTrash puts a trashed item back where it was deleted from and removes it from the trash.
+// A task whose module no longer exists is restored at project level.
+func (s *Storage) RestoreTrash(id string) (*models.TrashItem, error) {
+	item, err := s.GetTrashItem(id)
+	if err != nil {
+		return nil, err
+	}
+
+	switch item.Kind {
+	case models.TrashTask:
+		err = s.restoreTask(item)
+	case models.TrashModule:
+		err = s.UpdateProject(item.Project, func(p *models.Project) error {
+			for _, m := range p.Modules {
+				if m.Name == item.ModuleData.Name {
+					return fmt.Errorf("module '%s' already exists", m.Name)
+				}
+			}
+			p.Modules = append(p.Modules, *item.ModuleData)
+			return nil
+		})
+	case models.TrashProject:
+		err = s.withLock("project-"+item.Project, func() error {
+			if s.ProjectExists(item.Project) {
+				return fmt.Errorf("project '%s' already exists", item.Project)
+			}
+			return s.SaveProject(item.Project, item.ProjectData)
+		})
+	default:
+		err = fmt.Errorf("unknown trash item kind: %s", item.Kind)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	s.discardTrash(item.ID)
+	return item, nil
+}
+
+// restoreTask re-adds a trashed task, falling back to project level if its module is gone
+func (s *Storage) restoreTask(item *models.TrashItem) error {
+	journalID := ""
+	err := s.UpdateProject(item.Project, func(p *models.Project) error {
+		if existing, _ := findTaskIn(p, item.Task.ID); existing != nil {
+			return fmt.Errorf("task '%s' already exists", item.Task.ID)
+		}
+
+		moduleName := item.Module
+		if moduleName != "" {
+			if _, err := findModuleIn(p, moduleName); err != nil {
+				item.Module = ""
+				moduleName = ""
+			}
+		}
+
+		var err error
+		journalID, err = s.writeJournal(models.OpTaskCreated, item.Project, moduleName, nil, item.Task)
+		if err != nil {
+			return err
+		}
+		return insertTask(p, moduleName, *item.Task)
+	})
+
+	return s.finishJournal(item.Project, journalID, err)
+}
+
+// findModuleIn returns the index of a module in a project
+func findModuleIn(p *models.Project, moduleName string) (int, error) {
+	for i := range p.Modules {
+		if p.Modules[i].Name == moduleName {
+			return i, nil
+		}
+	}
+	return -1, notFoundf("module '%s' not found", moduleName)
+}
+
+// PurgeTrash permanently deletes trash items older than the retention period, or all items if all is set
+func (s *Storage) PurgeTrash(all bool) (int, error) {
+	files, err := filepath.Glob(filepath.Join(s.config.TrashDir, "*.json"))
+	if err != nil {
+		return 0, err
+	}
+
+	retention := time.Duration(s.config.TrashRetentionDays) * 24 * time.Hour
+	if !all && retention <= 0 {
+		return 0, nil // Keep forever
+	}
+	cutoff := time.Now().Add(-retention)
+
+	purged := 0
+	for _, file := range files {
+		if !all && !trashExpired(file, cutoff) {
+			continue
+		}
+		if skipRemove(file) {
+			purged++
+			continue
+		}
+		if err := os.Remove(file); err != nil && !os.IsNotExist(err) {
+			return purged, err
+		}
+		purged++
+	}
+
+	return purged, nil
+}
+
+// trashExpired reports whether a trash item was deleted before cutoff, going
+// by the time its file was written if it can't be read
+func trashExpired(file string, cutoff time.Time) bool {
+	var item models.TrashItem
+	if err := readJSONFile(file, &item); err == nil && !item.DeletedAt.IsZero() {
+		return item.DeletedAt.Before(cutoff)
+	}
+	info, err := os.Stat(file)
+	return err == nil && info.ModTime().Before(cutoff)
+}
@@ -0,0 +1,70 @@
+package storage
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/mrbooshehri/qix-go/pkg/qix/models"
+)
+
+// LoadUsage loads the command usage statistics
+func (s *Storage) LoadUsage() (*models.UsageStats, error) {
+	stats := &models.UsageStats{Commands: make(map[string]*models.CommandUsage)}
+	if _, err := os.Stat(s.config.UsageFile); os.IsNotExist(err) {
+		return stats, nil
+	}
+
+	if err := readJSONFile(s.config.UsageFile, stats); err != nil {
+		return nil, fmt.Errorf("failed to load usage statistics: %w", err)
+	}
+	if stats.Commands == nil {
+		stats.Commands = make(map[string]*models.CommandUsage)
+	}
+	return stats, nil
+}
+
+// RecordUsage counts a run of a command that took duration
+func (s *Storage) RecordUsage(command string, duration time.Duration, failed bool) error {
+	return s.withLock("usage", func() error {
+		stats, err := s.LoadUsage()
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		if stats.Since.IsZero() {
+			stats.Since = now
+		}
+		usage, ok := stats.Commands[command]
+		if !ok {
+			usage = &models.CommandUsage{}
+			stats.Commands[command] = usage
+		}
+		ms := float64(duration) / float64(time.Millisecond)
+		usage.Runs++
+		if failed {
+			usage.Failures++
+		}
+		usage.TotalMs += ms
+		if ms > usage.MaxMs {
+			usage.MaxMs = ms
+		}
+		usage.LastRun = now
+
+		return writeJSONFile(s.config.UsageFile, stats)
+	})
+}
+
+// ClearUsage removes the command usage statistics
+func (s *Storage) ClearUsage() error {
+	return s.withLock("usage", func() error {
+		if skipRemove(s.config.UsageFile) {
+			return nil
+		}
+		if err := os.Remove(s.config.UsageFile); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		return nil
+	})
+}
@@ -0,0 +1,120 @@
+// Package subscribers sets up the event bus of a configuration: the webhooks
+// of 'qix notify', the hooks directory, and the subscribers of the config
+// file. The CLI and the Go API publish to the same subscribers this way.
+package subscribers
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/mrbooshehri/qix-go/internal/config"
+	"github.com/mrbooshehri/qix-go/internal/events"
+	"github.com/mrbooshehri/qix-go/internal/hooks"
+	"github.com/mrbooshehri/qix-go/internal/notify"
+)
+
+// Named is a subscriber defined in the config file, known by the name it was
+// given there
+type Named struct {
+	events.Subscriber
+	name, kind, target string
+}
+
+// Name returns the subscriber's name in the config file
+func (s Named) Name() string {
+	return s.name
+}
+
+// Kind returns the subscriber's kind: webhook, hook, log or notify
+func (s Named) Kind() string {
+	return s.kind
+}
+
+// Target returns the URL, script or file events are delivered to
+func (s Named) Target() string {
+	return s.target
+}
+
+// Notifier returns a notifier for the webhooks in cfg's config file
+func Notifier(cfg *config.Config) *notify.Notifier {
+	webhooks := make([]notify.Webhook, 0)
+	for _, hook := range cfg.Webhooks() {
+		webhooks = append(webhooks, notify.Webhook{Name: hook.Name, URL: hook.URL, Format: hook.Format, Events: hook.Events})
+	}
+	return notify.New(webhooks, cfg.NotifyTemplates())
+}
+
+// HookRunner returns the runner of the hooks directory of cfg, with the hooks'
+// output going to output
+func HookRunner(cfg *config.Config, output io.Writer) hooks.Runner {
+	return hooks.Runner{Dir: cfg.HooksDir, Timeout: time.Duration(cfg.HookTimeout) * time.Second, Output: output}
+}
+
+// Bus returns the bus with the built-in subscribers and those in cfg's config
+// file, and an error for each subscriber that couldn't be set up. Hook output
+// goes to output. Within a hook, hook scripts aren't subscribed so they can't
+// loop.
+func Bus(cfg *config.Config, output io.Writer) (*events.Bus, []error) {
+	bus := &events.Bus{}
+	inHook := os.Getenv(hooks.EnvHook) != ""
+	runner := HookRunner(cfg, output)
+
+	if notifier := Notifier(cfg); notifier.Enabled() {
+		bus.Subscribe(events.NewNotifications("webhooks", notifier), events.Filter{})
+	}
+	if !inHook {
+		bus.Subscribe(runner, events.Filter{})
+	}
+
+	errs := make([]error, 0)
+	for _, sub := range cfg.Subscribers() {
+		if sub.Target == "" {
+			errs = append(errs, fmt.Errorf("subscriber %s: no target after %q", sub.Name, sub.Kind))
+			continue
+		}
+
+		var subscriber events.Subscriber
+		switch sub.Kind {
+		case "webhook":
+			webhook, err := events.NewWebhook(sub.Name, sub.Target)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("subscriber %s: %w", sub.Name, err))
+				continue
+			}
+			subscriber = webhook
+		case "log":
+			subscriber = events.NewLog(sub.Name, sub.Target)
+		case "hook":
+			if inHook {
+				continue
+			}
+			subscriber = hooks.Script{Path: sub.Target, Timeout: runner.Timeout, Output: output}
+		case "notify":
+			webhook := notify.Webhook{Name: sub.Name, URL: sub.Target}
+			if err := webhook.Check(); err != nil {
+				errs = append(errs, fmt.Errorf("subscriber %s: %w", sub.Name, err))
+				continue
+			}
+			subscriber = events.NewNotifications(sub.Name, notify.New([]notify.Webhook{webhook}, cfg.NotifyTemplates()))
+		default:
+			errs = append(errs, fmt.Errorf("subscriber %s: unknown kind %q (use webhook, hook, log or notify)", sub.Name, sub.Kind))
+			continue
+		}
+		for _, event := range sub.Events {
+			if !events.Known(event) && !strings.HasSuffix(event, "*") {
+				errs = append(errs, fmt.Errorf("subscriber %s: unknown event %q", sub.Name, event))
+			}
+		}
+
+		bus.Subscribe(Named{Subscriber: subscriber, name: sub.Name, kind: sub.Kind, target: sub.Target}, events.Filter{
+			Events:   sub.Events,
+			Projects: sub.Projects,
+			Tags:     sub.Tags,
+			Statuses: sub.Statuses,
+		})
+	}
+	return bus, errs
+}
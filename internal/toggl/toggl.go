@@ -0,0 +1,215 @@
+// Package toggl reads and creates time entries with the Toggl Track API.
+package toggl
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// DefaultAPIURL is the root of the Toggl Track API
+const DefaultAPIURL = "https://api.track.toggl.com/api/v9"
+
+// ErrUnauthorized is returned when Toggl rejects the API token
+var ErrUnauthorized = errors.New("Toggl rejected the API token")
+
+// TimeEntry is the part of a Toggl time entry qix uses
+type TimeEntry struct {
+	ID          int64
+	WorkspaceID int64
+	Description string
+	Tags        []string
+	Start       time.Time
+	Duration    time.Duration // Negative while the entry is running
+}
+
+// Running reports whether the entry's timer is still running
+func (e TimeEntry) Running() bool {
+	return e.Duration < 0
+}
+
+// timeEntryJSON is a time entry as the API reads and writes it
+type timeEntryJSON struct {
+	ID          int64    `json:"id,omitempty"`
+	WorkspaceID int64    `json:"workspace_id"`
+	Description string   `json:"description"`
+	Tags        []string `json:"tags"`
+	Start       string   `json:"start"`
+	Duration    int64    `json:"duration"`
+	CreatedWith string   `json:"created_with,omitempty"`
+}
+
+func (e timeEntryJSON) entry() TimeEntry {
+	entry := TimeEntry{
+		ID:          e.ID,
+		WorkspaceID: e.WorkspaceID,
+		Description: e.Description,
+		Tags:        e.Tags,
+		Duration:    time.Duration(e.Duration) * time.Second,
+	}
+	if start, err := time.Parse(time.RFC3339, e.Start); err == nil {
+		entry.Start = start
+	}
+	return entry
+}
+
+// Client works with the time entries of one Toggl account
+type Client struct {
+	api    string
+	token  string
+	client *http.Client
+}
+
+// NewClient returns a client for the account of an API token. api is the
+// API root; DefaultAPIURL if empty.
+func NewClient(api, token string) (*Client, error) {
+	api = strings.TrimRight(strings.TrimSpace(api), "/")
+	if api == "" {
+		api = DefaultAPIURL
+	}
+	u, err := url.Parse(api)
+	if err != nil || u.Host == "" || (u.Scheme != "http" && u.Scheme != "https") {
+		return nil, fmt.Errorf("invalid Toggl API URL: %s", api)
+	}
+	if strings.TrimSpace(token) == "" {
+		return nil, errors.New("no Toggl API token configured")
+	}
+
+	return &Client{
+		api:    api,
+		token:  strings.TrimSpace(token),
+		client: &http.Client{Timeout: 30 * time.Second},
+	}, nil
+}
+
+// DefaultWorkspace returns the ID of the account's default workspace
+func (c *Client) DefaultWorkspace(ctx context.Context) (int64, error) {
+	var me struct {
+		DefaultWorkspaceID int64 `json:"default_workspace_id"`
+	}
+	if err := c.do(ctx, http.MethodGet, "/me", nil, &me); err != nil {
+		return 0, err
+	}
+	return me.DefaultWorkspaceID, nil
+}
+
+// TimeEntries returns the account's time entries started from start until
+// before end, oldest first
+func (c *Client) TimeEntries(ctx context.Context, start, end time.Time) ([]TimeEntry, error) {
+	query := url.Values{
+		"start_date": {start.UTC().Format(time.RFC3339)},
+		"end_date":   {end.UTC().Format(time.RFC3339)},
+	}
+	var data []timeEntryJSON
+	if err := c.do(ctx, http.MethodGet, "/me/time_entries?"+query.Encode(), nil, &data); err != nil {
+		return nil, err
+	}
+
+	// The API returns the newest first
+	entries := make([]TimeEntry, 0, len(data))
+	for i := len(data) - 1; i >= 0; i-- {
+		entries = append(entries, data[i].entry())
+	}
+	return entries, nil
+}
+
+// CreateTimeEntry adds a stopped time entry to a workspace and returns its ID
+func (c *Client) CreateTimeEntry(ctx context.Context, workspaceID int64, entry TimeEntry) (int64, error) {
+	tags := entry.Tags
+	if tags == nil {
+		tags = make([]string, 0)
+	}
+	body := timeEntryJSON{
+		WorkspaceID: workspaceID,
+		Description: entry.Description,
+		Tags:        tags,
+		Start:       entry.Start.UTC().Format(time.RFC3339),
+		Duration:    int64(entry.Duration / time.Second),
+		CreatedWith: "qix",
+	}
+	var created timeEntryJSON
+	path := fmt.Sprintf("/workspaces/%d/time_entries", workspaceID)
+	if err := c.do(ctx, http.MethodPost, path, body, &created); err != nil {
+		return 0, err
+	}
+	return created.ID, nil
+}
+
+// do sends a request to the API, encoding body and decoding the response into out as JSON
+func (c *Client) do(ctx context.Context, method, path string, body, out interface{}) error {
+	var payload io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		payload = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.api+path, payload)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	// Toggl takes the token as the user name, with a fixed password
+	req.SetBasicAuth(c.token, "api_token")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden:
+		return fmt.Errorf("%w (%s)", ErrUnauthorized, resp.Status)
+	case resp.StatusCode/100 != 2:
+		return newAPIError(resp)
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("invalid response from Toggl: %w", err)
+	}
+	return nil
+}
+
+// APIError is a request Toggl refused
+type APIError struct {
+	Status  string
+	Message string
+}
+
+func (e *APIError) Error() string {
+	if e.Message == "" {
+		return "Toggl returned " + e.Status
+	}
+	return fmt.Sprintf("Toggl returned %s: %s", e.Status, e.Message)
+}
+
+// newAPIError reads the reason of an error response, which Toggl sends as a
+// JSON string or plain text
+func newAPIError(resp *http.Response) *APIError {
+	data, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+
+	apiErr := &APIError{Status: resp.Status}
+	var message string
+	if json.Unmarshal(data, &message) == nil {
+		apiErr.Message = message
+		return apiErr
+	}
+	if len(data) > 512 {
+		data = data[:512]
+	}
+	apiErr.Message = strings.TrimSpace(string(data))
+	return apiErr
+}
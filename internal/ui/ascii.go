@@ -0,0 +1,219 @@
+package ui
+
+import (
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/fatih/color"
+)
+
+var (
+	asciiMode bool
+
+	// asciiPipes carry standard output and error through ToASCII while ASCII output is enabled
+	asciiPipes []*asciiPipe
+)
+
+// asciiGlyphs maps the symbols qix draws with to plain ASCII. Symbols missing
+// here that fall in asciiRanges are dropped along with the spaces after them,
+// so "📆 Weekly Report" becomes "Weekly Report".
+var asciiGlyphs = map[rune]string{
+	'✓': "+", '✔': "+", '✗': "x", '✘': "x", '⚠': "!", 'ℹ': "i",
+	'•': "*", '·': ".", '…': "...", '→': "->", '←': "<-", '↔': "<->",
+	'═': "=", '─': "-", '━': "-", '│': "|", '║': "|", '┃': "|",
+	'█': "#", '▓': "#", '▒': "+", '░': ".",
+	'▁': "_", '▂': "_", '▃': ".", '▄': "-", '▅': "=", '▆': "+", '▇': "*",
+	'▏': "#", '▎': "#", '▍': "#", '▌': "#", '▋': "#", '▊': "#", '▉': "#",
+	'■': "#", '□': ".", '▪': "#", '●': "O", '○': "o", '∘': ":",
+	'◔': "o", '◑': "o", '◕': "O", '◐': "0", '◉': "@", '⬤': "@",
+	'⟦': "[", '⟧': "]",
+}
+
+// asciiRanges are the blocks of emoji and drawing characters replaced in ASCII
+// output; letters and other text in any script are left alone
+var asciiRanges = []struct {
+	lo, hi rune
+	repl   string // Replacement for symbols not in asciiGlyphs; "" drops them
+}{
+	{0x2190, 0x21FF, ""},  // Arrows
+	{0x2300, 0x23FF, ""},  // Miscellaneous technical (⏱ ⏳ ⏹)
+	{0x2500, 0x257F, "+"}, // Box drawing: corners and joints
+	{0x2580, 0x259F, "#"}, // Block elements
+	{0x25A0, 0x25FF, ""},  // Geometric shapes (▶)
+	{0x2600, 0x27BF, ""},  // Miscellaneous symbols and dingbats (✨ ⚡)
+	{0x2800, 0x28FF, "*"}, // Braille spinner frames
+	{0x2B00, 0x2BFF, ""},  // Miscellaneous symbols and arrows (⭕)
+	{0x1F000, 0x1FAFF, ""},
+}
+
+// EnableASCII replaces emoji, box drawing and block characters with plain
+// ASCII in everything printed to standard output and standard error, for
+// terminals, fonts and logs that can't show them. Call FlushOutput before exiting.
+func EnableASCII() {
+	if asciiMode {
+		return
+	}
+	asciiMode = true
+
+	if pipe, err := newASCIIPipe(os.Stdout); err == nil {
+		os.Stdout = pipe.w
+		color.Output = pipe.w
+		asciiPipes = append(asciiPipes, pipe)
+	}
+	if pipe, err := newASCIIPipe(os.Stderr); err == nil {
+		os.Stderr = pipe.w
+		color.Error = pipe.w
+		asciiPipes = append(asciiPipes, pipe)
+	}
+}
+
+// ASCIIEnabled reports whether ASCII output is enabled
+func ASCIIEnabled() bool {
+	return asciiMode
+}
+
+// FlushOutput writes out anything still passing through the ASCII filter or
+// the pager, waits for the pager to quit, and restores the original standard
+// output and error
+func FlushOutput() {
+	for _, pipe := range asciiPipes {
+		pipe.close()
+	}
+	asciiPipes = nil
+	closePager()
+}
+
+// ToASCII replaces the symbols qix draws with by ASCII equivalents and drops emoji
+func ToASCII(s string) string {
+	var b strings.Builder
+	dropped := false
+	for _, r := range s {
+		switch {
+		case r == 0xFE0F || r == 0x200D || (r >= 0x1F3FB && r <= 0x1F3FF):
+			// Emoji presentation selector, joiner and skin tones
+			continue
+		case r == ' ' && dropped:
+			continue
+		}
+		dropped = false
+
+		if r < 0x80 {
+			b.WriteRune(r)
+			continue
+		}
+		if repl, ok := asciiGlyphs[r]; ok {
+			b.WriteString(repl)
+			continue
+		}
+
+		replaced := false
+		for _, rng := range asciiRanges {
+			if r >= rng.lo && r <= rng.hi {
+				b.WriteString(rng.repl)
+				dropped = rng.repl == ""
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// asciiCells converts table cells when ASCII output is enabled, before their
+// widths are measured
+func asciiCells(cells []string) []string {
+	if !asciiMode {
+		return cells
+	}
+	converted := make([]string, len(cells))
+	for i, cell := range cells {
+		converted[i] = ToASCII(cell)
+	}
+	return converted
+}
+
+// asciiPipe copies everything written to w through ToASCII to the original file
+type asciiPipe struct {
+	orig *os.File
+	w    *os.File
+	done sync.WaitGroup
+}
+
+func newASCIIPipe(orig *os.File) (*asciiPipe, error) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		return nil, err
+	}
+
+	pipe := &asciiPipe{orig: orig, w: w}
+	pipe.done.Add(1)
+	go func() {
+		defer pipe.done.Done()
+		defer r.Close()
+		copyASCII(orig, r)
+	}()
+	return pipe, nil
+}
+
+// close flushes the pipe and points whatever still uses it back at the original file
+func (p *asciiPipe) close() {
+	p.w.Close()
+	p.done.Wait()
+
+	if os.Stdout == p.w {
+		os.Stdout = p.orig
+	}
+	if os.Stderr == p.w {
+		os.Stderr = p.orig
+	}
+	if color.Output == io.Writer(p.w) {
+		color.Output = p.orig
+	}
+	if color.Error == io.Writer(p.w) {
+		color.Error = p.orig
+	}
+}
+
+// copyASCII copies src to dst through ToASCII, never splitting a character
+// across reads
+func copyASCII(dst io.Writer, src io.Reader) {
+	buf := make([]byte, 32*1024)
+	pending := 0
+	for {
+		n, err := src.Read(buf[pending:])
+		n += pending
+
+		end := n
+		if n > 0 {
+			if start := lastRuneStart(buf, n); !utf8.FullRune(buf[start:n]) {
+				end = start
+			}
+		}
+		if end > 0 {
+			io.WriteString(dst, ToASCII(string(buf[:end])))
+		}
+		pending = copy(buf, buf[end:n])
+
+		if err != nil {
+			if pending > 0 {
+				io.WriteString(dst, ToASCII(string(buf[:pending])))
+			}
+			return
+		}
+	}
+}
+
+// lastRuneStart returns the index of the first byte of the last character in buf[:end]
+func lastRuneStart(buf []byte, end int) int {
+	start := end - 1
+	for start > 0 && !utf8.RuneStart(buf[start]) {
+		start--
+	}
+	return start
+}
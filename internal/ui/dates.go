@@ -0,0 +1,103 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mrbooshehri/qix-go/internal/config"
+)
+
+// DateLayout is the layout dates are stored in
+const DateLayout = "2006-01-02"
+
+// weekStart is the day weeks start on in weekly reports, digests and
+// recurrence
+var weekStart = time.Monday
+
+// layoutNames spell out the parts of a Go layout the way people write them
+var layoutNames = strings.NewReplacer(
+	"2006", "YYYY", "January", "MMMM", "Jan", "MMM", "Monday", "DDDD", "Mon", "DDD",
+	"01", "MM", "02", "DD", "_2", "DD", "06", "YY", "1", "M", "2", "D",
+)
+
+// SetWeekStart selects the day weeks start on, such as monday or sunday
+func SetWeekStart(day string) error {
+	if day == "" {
+		weekStart = time.Monday
+		return nil
+	}
+	for d := time.Sunday; d <= time.Saturday; d++ {
+		if strings.EqualFold(day, d.String()) {
+			weekStart = d
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown week start '%s' (use a day name such as monday or sunday)", day)
+}
+
+// WeekStart returns the day weeks start on
+func WeekStart() time.Weekday {
+	return weekStart
+}
+
+// StartOfWeek returns midnight on the first day of the week t falls in
+func StartOfWeek(t time.Time) time.Time {
+	offset := (int(t.Weekday()) - int(weekStart) + 7) % 7
+	return time.Date(t.Year(), t.Month(), t.Day()-offset, 0, 0, 0, 0, t.Location())
+}
+
+// WeekNumber returns the ISO year and number of the week starting on start:
+// that of its fourth day, so weeks starting on Sunday get the number of the
+// ISO week they mostly overlap
+func WeekNumber(start time.Time) (int, int) {
+	return start.AddDate(0, 0, 3).ISOWeek()
+}
+
+// Weekdays returns the days of the week from the one weeks start on
+func Weekdays() []time.Weekday {
+	days := make([]time.Weekday, 7)
+	for i := range days {
+		days[i] = (weekStart + time.Weekday(i)) % 7
+	}
+	return days
+}
+
+// ParseDate parses a date given by the user: YYYY-MM-DD, or a date in the
+// configured date format. Dates without a year fall in the current one.
+func ParseDate(value string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if t, err := time.ParseInLocation(DateLayout, value, time.Local); err == nil {
+		return t, nil
+	}
+	layout := config.Get().DateFormat
+	if layout != "" && layout != DateLayout {
+		if t, err := time.ParseInLocation(layout, value, time.Local); err == nil {
+			if t.Year() == 0 {
+				t = t.AddDate(time.Now().Year(), 0, 0)
+			}
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid date %q (use %s)", value, DateFormats())
+}
+
+// NormalizeDate parses a date given by the user, as ParseDate does, and
+// returns it in the layout dates are stored in
+func NormalizeDate(value string) (string, error) {
+	t, err := ParseDate(value)
+	if err != nil {
+		return "", err
+	}
+	return t.Format(DateLayout), nil
+}
+
+// DateFormats describes the formats ParseDate accepts, such as
+// "YYYY-MM-DD or DD.MM.YYYY"
+func DateFormats() string {
+	layout := config.Get().DateFormat
+	if layout == "" || layout == DateLayout {
+		return "YYYY-MM-DD"
+	}
+	return "YYYY-MM-DD or " + layoutNames.Replace(layout)
+}
@@ -0,0 +1,43 @@
+package ui
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+
+	"github.com/fatih/color"
+)
+
+// jsonOut receives JSON documents while JSON output is enabled; nil otherwise
+var jsonOut io.Writer
+
+// EnableJSON switches to machine-readable output: PrintJSON writes to standard
+// output, and everything printed for people (headers, tables, messages) goes to
+// standard error without colors, so standard output is always valid JSON.
+func EnableJSON() {
+	jsonOut = os.Stdout
+	os.Stdout = os.Stderr
+	color.Output = os.Stderr
+	color.NoColor = true
+}
+
+// JSONEnabled reports whether JSON output is enabled
+func JSONEnabled() bool {
+	return jsonOut != nil
+}
+
+// DataOutput returns where command output meant for the reader goes: standard
+// output, or the original standard output when JSON output is enabled
+func DataOutput() io.Writer {
+	if jsonOut != nil {
+		return jsonOut
+	}
+	return os.Stdout
+}
+
+// PrintJSON writes a value as an indented JSON document
+func PrintJSON(v interface{}) error {
+	encoder := json.NewEncoder(DataOutput())
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(v)
+}
@@ -0,0 +1,119 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+)
+
+// TaskFields are the optional parts of a task in listings, in the order shown;
+// the status icon, ID and title are always shown
+var TaskFields = []string{"priority", "assignee", "status", "time", "due", "tags"}
+
+// ProjectFields are the optional parts of a project in listings; the name is
+// always shown
+var ProjectFields = []string{"description", "counts", "status", "progress"}
+
+var (
+	// taskFields and projectFields are the fields listings show, by name
+	taskFields    = fieldSet(TaskFields)
+	projectFields = fieldSet(ProjectFields)
+
+	// compactLists puts each task or project of a listing on one line
+	compactLists bool
+)
+
+// SetTaskFields selects the task fields listings show
+func SetTaskFields(fields []string) error {
+	set, err := validFields("task", fields, TaskFields)
+	if err != nil {
+		return err
+	}
+	taskFields = set
+	return nil
+}
+
+// SetProjectFields selects the project fields listings show
+func SetProjectFields(fields []string) error {
+	set, err := validFields("project", fields, ProjectFields)
+	if err != nil {
+		return err
+	}
+	projectFields = set
+	return nil
+}
+
+// SetCompactLists puts each task or project of a listing on one line
+func SetCompactLists(compact bool) {
+	compactLists = compact
+}
+
+// ShowTaskField reports whether listings show a task field
+func ShowTaskField(name string) bool {
+	return taskFields[name]
+}
+
+// ShowProjectField reports whether listings show a project field
+func ShowProjectField(name string) bool {
+	return projectFields[name]
+}
+
+// CompactLists reports whether listings show one line per task or project
+func CompactLists() bool {
+	return compactLists
+}
+
+// ParseFields reads a comma-separated list of fields. A list of only +field
+// and -field entries adds to and removes from current; any other list
+// replaces it, and an empty one selects no fields.
+func ParseFields(spec string, current []string) []string {
+	var entries []string
+	relative := true
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.ToLower(strings.TrimSpace(entry))
+		if entry == "" {
+			continue
+		}
+		if entry[0] != '+' && entry[0] != '-' {
+			relative = false
+		}
+		entries = append(entries, entry)
+	}
+
+	fields := make([]string, 0, len(current)+len(entries))
+	if relative && len(entries) > 0 {
+		fields = append(fields, current...)
+	}
+	for _, entry := range entries {
+		name := strings.TrimLeft(entry, "+-")
+		at := indexOf(fields, name)
+		switch {
+		case entry[0] == '-':
+			if at >= 0 {
+				fields = append(fields[:at], fields[at+1:]...)
+			}
+		case at < 0:
+			fields = append(fields, name)
+		}
+	}
+	return fields
+}
+
+// validFields checks fields against the known ones and returns them as a set
+func validFields(kind string, fields, known []string) (map[string]bool, error) {
+	set := make(map[string]bool, len(fields))
+	for _, field := range fields {
+		if indexOf(known, field) < 0 {
+			return nil, fmt.Errorf("unknown %s field '%s' (use: %s)", kind, field, strings.Join(known, ", "))
+		}
+		set[field] = true
+	}
+	return set, nil
+}
+
+func fieldSet(fields []string) map[string]bool {
+	set := make(map[string]bool, len(fields))
+	for _, field := range fields {
+		set[field] = true
+	}
+	return set
+}
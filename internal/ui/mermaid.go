@@ -0,0 +1,184 @@
+package ui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// MermaidFlowchart is a Mermaid flowchart: nodes, optionally grouped in
+// subgraphs, joined by edges and styled by class
+type MermaidFlowchart struct {
+	Direction string // LR, TD, ...; LR if empty
+	Subgraphs []MermaidSubgraph
+	Nodes     []MermaidNode // Nodes outside any subgraph
+	Edges     []MermaidEdge
+	Classes   []MermaidClass
+}
+
+// MermaidSubgraph is a labelled group of flowchart nodes
+type MermaidSubgraph struct {
+	ID    string
+	Label string
+	Nodes []MermaidNode
+}
+
+// MermaidNode is a flowchart node, styled with Class if it is set
+type MermaidNode struct {
+	ID    string
+	Label string
+	Class string
+}
+
+// MermaidEdge is an arrow between flowchart nodes, dotted for weaker links
+type MermaidEdge struct {
+	From   string
+	To     string
+	Dotted bool
+}
+
+// MermaidClass is a node style, such as "fill:#c8e6c9"
+type MermaidClass struct {
+	Name  string
+	Style string
+}
+
+// String returns the flowchart's Mermaid definition
+func (f MermaidFlowchart) String() string {
+	var b strings.Builder
+
+	direction := f.Direction
+	if direction == "" {
+		direction = "LR"
+	}
+	fmt.Fprintf(&b, "flowchart %s\n", direction)
+
+	for _, subgraph := range f.Subgraphs {
+		fmt.Fprintf(&b, "    subgraph %s [\"%s\"]\n", subgraph.ID, MermaidLabel(subgraph.Label))
+		for _, node := range subgraph.Nodes {
+			b.WriteString("        " + node.definition() + "\n")
+		}
+		b.WriteString("    end\n")
+	}
+	for _, node := range f.Nodes {
+		b.WriteString("    " + node.definition() + "\n")
+	}
+
+	for _, edge := range f.Edges {
+		arrow := "-->"
+		if edge.Dotted {
+			arrow = "-.->"
+		}
+		fmt.Fprintf(&b, "    %s %s %s\n", edge.From, arrow, edge.To)
+	}
+	for _, class := range f.Classes {
+		fmt.Fprintf(&b, "    classDef %s %s\n", class.Name, class.Style)
+	}
+
+	return b.String()
+}
+
+// definition returns the node's flowchart statement
+func (n MermaidNode) definition() string {
+	definition := fmt.Sprintf("%s[\"%s\"]", n.ID, MermaidLabel(n.Label))
+	if n.Class != "" {
+		definition += ":::" + n.Class
+	}
+	return definition
+}
+
+// MermaidGantt is a Mermaid gantt chart of tasks in sections
+type MermaidGantt struct {
+	Title    string
+	Sections []MermaidGanttSection
+}
+
+// MermaidGanttSection is a named group of gantt tasks
+type MermaidGanttSection struct {
+	Name  string
+	Tasks []MermaidGanttTask
+}
+
+// MermaidGanttTask is a bar of a gantt chart from Start up to, but not
+// including, End. Tags are Mermaid's done, active, crit and milestone.
+type MermaidGanttTask struct {
+	Name  string
+	ID    string
+	Tags  []string
+	Start time.Time
+	End   time.Time
+}
+
+// String returns the gantt chart's Mermaid definition
+func (g MermaidGantt) String() string {
+	var b strings.Builder
+
+	b.WriteString("gantt\n")
+	if g.Title != "" {
+		fmt.Fprintf(&b, "    title %s\n", MermaidText(g.Title))
+	}
+	b.WriteString("    dateFormat YYYY-MM-DD\n")
+
+	for _, section := range g.Sections {
+		fmt.Fprintf(&b, "    section %s\n", MermaidText(section.Name))
+		for _, task := range section.Tasks {
+			fields := append([]string{}, task.Tags...)
+			if task.ID != "" {
+				fields = append(fields, task.ID)
+			}
+			fields = append(fields, task.Start.Format("2006-01-02"), task.End.Format("2006-01-02"))
+			fmt.Fprintf(&b, "    %s :%s\n", MermaidText(task.Name), strings.Join(fields, ", "))
+		}
+	}
+
+	return b.String()
+}
+
+// MermaidPie is a Mermaid pie chart
+type MermaidPie struct {
+	Title    string
+	ShowData bool // Show the values next to the legend
+	Slices   []MermaidSlice
+}
+
+// MermaidSlice is a slice of a pie chart
+type MermaidSlice struct {
+	Label string
+	Value float64
+}
+
+// String returns the pie chart's Mermaid definition. Slices with no value
+// are left out, as Mermaid draws them as empty labels.
+func (p MermaidPie) String() string {
+	var b strings.Builder
+
+	b.WriteString("pie")
+	if p.ShowData {
+		b.WriteString(" showData")
+	}
+	b.WriteString("\n")
+	if p.Title != "" {
+		fmt.Fprintf(&b, "    title %s\n", MermaidText(p.Title))
+	}
+	for _, slice := range p.Slices {
+		if slice.Value <= 0 {
+			continue
+		}
+		fmt.Fprintf(&b, "    \"%s\" : %s\n", MermaidLabel(slice.Label), strconv.FormatFloat(slice.Value, 'f', -1, 64))
+	}
+
+	return b.String()
+}
+
+// MermaidLabel escapes text for use inside a quoted Mermaid label
+func MermaidLabel(s string) string {
+	return strings.NewReplacer(`"`, "#quot;", "\r\n", " ", "\n", " ", "\r", " ").Replace(s)
+}
+
+// MermaidText strips characters that break unquoted Mermaid statements, such
+// as titles and gantt task names
+func MermaidText(s string) string {
+	replacer := strings.NewReplacer(":", " ", "#", "", ";", ",", "\r\n", " ", "\n", " ", "\r", " ")
+	return strings.TrimSpace(replacer.Replace(s))
+}
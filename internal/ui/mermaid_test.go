@@ -0,0 +1,195 @@
+package ui
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMermaidFlowchart(t *testing.T) {
+	tests := []struct {
+		name  string
+		chart MermaidFlowchart
+		want  string
+	}{
+		{
+			name:  "empty",
+			chart: MermaidFlowchart{},
+			want:  "flowchart LR\n",
+		},
+		{
+			name: "nodes and edges",
+			chart: MermaidFlowchart{
+				Direction: "TD",
+				Nodes: []MermaidNode{
+					{ID: "t1", Label: "Write \"docs\"", Class: "done"},
+					{ID: "t2", Label: "Ship"},
+				},
+				Edges: []MermaidEdge{
+					{From: "t1", To: "t2"},
+					{From: "t2", To: "t1", Dotted: true},
+				},
+				Classes: []MermaidClass{{Name: "done", Style: "fill:#c8e6c9"}},
+			},
+			want: "flowchart TD\n" +
+				"    t1[\"Write #quot;docs#quot;\"]:::done\n" +
+				"    t2[\"Ship\"]\n" +
+				"    t1 --> t2\n" +
+				"    t2 -.-> t1\n" +
+				"    classDef done fill:#c8e6c9\n",
+		},
+		{
+			name: "subgraphs",
+			chart: MermaidFlowchart{
+				Subgraphs: []MermaidSubgraph{{
+					ID:    "api",
+					Label: "API [v2]",
+					Nodes: []MermaidNode{{ID: "t1", Label: "Login\nendpoint"}},
+				}},
+				Nodes: []MermaidNode{{ID: "t2", Label: "Loose"}},
+			},
+			want: "flowchart LR\n" +
+				"    subgraph api [\"API [v2]\"]\n" +
+				"        t1[\"Login endpoint\"]\n" +
+				"    end\n" +
+				"    t2[\"Loose\"]\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.chart.String(); got != tt.want {
+				t.Errorf("String() =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMermaidGantt(t *testing.T) {
+	day := func(d int) time.Time {
+		return time.Date(2026, time.March, d, 0, 0, 0, 0, time.Local)
+	}
+
+	tests := []struct {
+		name  string
+		chart MermaidGantt
+		want  string
+	}{
+		{
+			name:  "empty",
+			chart: MermaidGantt{},
+			want:  "gantt\n    dateFormat YYYY-MM-DD\n",
+		},
+		{
+			name: "sections and tasks",
+			chart: MermaidGantt{
+				Title: "Plan: Q1 #1",
+				Sections: []MermaidGanttSection{
+					{Name: "api; backend", Tasks: []MermaidGanttTask{
+						{Name: "Login", ID: "t1", Tags: []string{"done"}, Start: day(2), End: day(5)},
+						{Name: "Rate limit\nlogin", Tags: []string{"active", "crit"}, Start: day(5), End: day(9)},
+					}},
+					{Name: "web", Tasks: []MermaidGanttTask{
+						{Name: "Release", ID: "t3", Tags: []string{"milestone"}, Start: day(29), End: day(29)},
+					}},
+				},
+			},
+			want: "gantt\n" +
+				"    title Plan  Q1 1\n" +
+				"    dateFormat YYYY-MM-DD\n" +
+				"    section api, backend\n" +
+				"    Login :done, t1, 2026-03-02, 2026-03-05\n" +
+				"    Rate limit login :active, crit, 2026-03-05, 2026-03-09\n" +
+				"    section web\n" +
+				"    Release :milestone, t3, 2026-03-29, 2026-03-29\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.chart.String(); got != tt.want {
+				t.Errorf("String() =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMermaidPie(t *testing.T) {
+	tests := []struct {
+		name  string
+		chart MermaidPie
+		want  string
+	}{
+		{
+			name:  "empty",
+			chart: MermaidPie{},
+			want:  "pie\n",
+		},
+		{
+			name: "slices",
+			chart: MermaidPie{
+				Title:    "Hours: March",
+				ShowData: true,
+				Slices: []MermaidSlice{
+					{Label: "api", Value: 12.5},
+					{Label: "none", Value: 0},
+					{Label: "\"web\"", Value: 3},
+				},
+			},
+			want: "pie showData\n" +
+				"    title Hours  March\n" +
+				"    \"api\" : 12.5\n" +
+				"    \"#quot;web#quot;\" : 3\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.chart.String(); got != tt.want {
+				t.Errorf("String() =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMermaidLabel(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"plain", "plain"},
+		{`say "hi"`, "say #quot;hi#quot;"},
+		{"[draft] (v2) {x}", "[draft] (v2) {x}"},
+		{"two\nlines", "two lines"},
+		{"windows\r\nlines", "windows lines"},
+		{"a: b; #c", "a: b; #c"},
+	}
+
+	for _, tt := range tests {
+		if got := MermaidLabel(tt.in); got != tt.want {
+			t.Errorf("MermaidLabel(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestMermaidText(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"plain", "plain"},
+		{`say "hi"`, `say "hi"`},
+		{"[draft] (v2)", "[draft] (v2)"},
+		{"two\nlines", "two lines"},
+		{"windows\r\nlines", "windows lines"},
+		{"a: b", "a  b"},
+		{"issue #12", "issue 12"},
+		{"one; two", "one, two"},
+		{"  padded:\n", "padded"},
+	}
+
+	for _, tt := range tests {
+		if got := MermaidText(tt.in); got != tt.want {
+			t.Errorf("MermaidText(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
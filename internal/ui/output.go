@@ -2,95 +2,150 @@ package ui
 
 import (
 	"fmt"
+	"os"
 	"strings"
 	"time"
 
 	"github.com/fatih/color"
 	"github.com/mrbooshehri/qix-go/internal/config"
-	"github.com/mrbooshehri/qix-go/internal/models"
+	"github.com/mrbooshehri/qix-go/internal/i18n"
+	"github.com/mrbooshehri/qix-go/pkg/qix/models"
 )
 
+// Colors are set by the theme; see SetTheme
 var (
 	// Color definitions
-	Red     = color.New(color.FgRed)
-	Green   = color.New(color.FgGreen)
-	Yellow  = color.New(color.FgYellow)
-	Blue    = color.New(color.FgBlue)
-	Cyan    = color.New(color.FgCyan)
-	Magenta = color.New(color.FgMagenta)
-	White   = color.New(color.FgWhite)
+	Red     *color.Color
+	Green   *color.Color
+	Yellow  *color.Color
+	Blue    *color.Color
+	Cyan    *color.Color
+	Magenta *color.Color
+	White   *color.Color
 
 	// Bold variants
-	BoldRed     = color.New(color.FgRed, color.Bold)
-	BoldGreen   = color.New(color.FgGreen, color.Bold)
-	BoldYellow  = color.New(color.FgYellow, color.Bold)
-	BoldBlue    = color.New(color.FgBlue, color.Bold)
-	BoldCyan    = color.New(color.FgCyan, color.Bold)
-	BoldMagenta = color.New(color.FgMagenta, color.Bold)
+	BoldRed     *color.Color
+	BoldGreen   *color.Color
+	BoldYellow  *color.Color
+	BoldBlue    *color.Color
+	BoldCyan    *color.Color
+	BoldMagenta *color.Color
 
 	// Dim
-	Dim = color.New(color.Faint)
+	Dim *color.Color
 )
 
 // Init initializes the UI system
 func Init() {
 	cfg := config.Get()
-	color.NoColor = !cfg.ColorOutput
+	color.NoColor = !colorEnabled(cfg.ColorOutput)
+	terminalWidth = detectTerminalWidth()
+}
+
+// colorEnabled reports whether output is colored: as configured, unless
+// NO_COLOR is set or standard output is not a terminal. FORCE_COLOR keeps
+// colors when output is piped, e.g. into less -R.
+func colorEnabled(configured bool) bool {
+	if !configured || os.Getenv("NO_COLOR") != "" {
+		return false
+	}
+	if force := os.Getenv("FORCE_COLOR"); force != "" && force != "0" {
+		return true
+	}
+	return isTerminal(os.Stdout)
 }
 
 // PrintSuccess prints a success message
 func PrintSuccess(format string, args ...interface{}) {
-	Green.Printf("✓ "+format+"\n", args...)
+	if porcelain {
+		return
+	}
+	successColor.Printf("✓ "+i18n.T(format)+"\n", args...)
 }
 
-// PrintError prints an error message
+// PrintError prints an error message and marks the command as failed
 func PrintError(format string, args ...interface{}) {
-	Red.Printf("✗ "+format+"\n", args...)
+	errorsPrinted = true
+	lastError = fmt.Sprintf(format, args...)
+	if porcelain {
+		errorColor.Fprintf(os.Stderr, "✗ "+i18n.T(format)+"\n", args...)
+	} else {
+		errorColor.Printf("✗ "+i18n.T(format)+"\n", args...)
+	}
+	if verbose {
+		printErrorChains(args)
+	}
 }
 
 // PrintWarning prints a warning message
 func PrintWarning(format string, args ...interface{}) {
-	Yellow.Printf("⚠ "+format+"\n", args...)
+	if porcelain {
+		warningColor.Fprintf(os.Stderr, "⚠ "+i18n.T(format)+"\n", args...)
+		return
+	}
+	warningColor.Printf("⚠ "+i18n.T(format)+"\n", args...)
 }
 
 // PrintInfo prints an info message
 func PrintInfo(format string, args ...interface{}) {
-	Blue.Printf("ℹ "+format+"\n", args...)
+	if porcelain {
+		return
+	}
+	infoColor.Printf("ℹ "+i18n.T(format)+"\n", args...)
 }
 
 // PrintHeader prints a section header
 func PrintHeader(text string) {
-	BoldCyan.Println("\n" + text)
-	BoldCyan.Println(strings.Repeat("═", len(text)))
+	text = i18n.T(text)
+	if asciiMode {
+		text = ToASCII(text)
+	}
+	headerColor.Println("\n" + text)
+	headerColor.Println(strings.Repeat("═", DisplayWidth(text)))
 }
 
 // PrintSubHeader prints a subsection header
 func PrintSubHeader(text string) {
-	BoldBlue.Println("\n" + text)
+	subHeaderColor.Println("\n" + i18n.T(text))
 }
 
 // PrintBox prints text in a bordered box
 func PrintBox(title string, lines []string) {
-	width := len(title) + 4
+	if asciiMode {
+		title = ToASCII(title)
+		lines = asciiCells(lines)
+	}
+	width := DisplayWidth(title) + 4
 	for _, line := range lines {
-		if len(line) > width-4 {
-			width = len(line) + 4
+		if DisplayWidth(line) > width-4 {
+			width = DisplayWidth(line) + 4
 		}
 	}
 
-	Cyan.Println("╔" + strings.Repeat("═", width-2) + "╗")
-	Cyan.Print("║ ")
-	BoldCyan.Print(title)
-	Cyan.Println(strings.Repeat(" ", width-len(title)-3) + "║")
-	Cyan.Println("╠" + strings.Repeat("═", width-2) + "╣")
+	// Shorten what doesn't fit on the terminal
+	if terminalWidth > 0 && width > terminalWidth {
+		width = terminalWidth
+		title = TruncateWidth(title, width-4)
+		fitted := make([]string, len(lines))
+		for i, line := range lines {
+			fitted[i] = TruncateWidth(line, width-4)
+		}
+		lines = fitted
+	}
+
+	accentColor.Println("╔" + strings.Repeat("═", width-2) + "╗")
+	accentColor.Print("║ ")
+	headerColor.Print(title)
+	accentColor.Println(strings.Repeat(" ", width-DisplayWidth(title)-3) + "║")
+	accentColor.Println("╠" + strings.Repeat("═", width-2) + "╣")
 
 	for _, line := range lines {
-		Cyan.Print("║ ")
+		accentColor.Print("║ ")
 		fmt.Print(line)
-		Cyan.Println(strings.Repeat(" ", width-len(line)-3) + "║")
+		accentColor.Println(strings.Repeat(" ", width-DisplayWidth(line)-3) + "║")
 	}
 
-	Cyan.Println("╚" + strings.Repeat("═", width-2) + "╝")
+	accentColor.Println("╚" + strings.Repeat("═", width-2) + "╝")
 }
 
 // FormatDuration formats a duration in human-readable format
@@ -112,123 +167,210 @@ func FormatPercentage(pct float64) string {
 	return fmt.Sprintf("%.1f%%", pct)
 }
 
-// FormatDate formats a date string
+// FormatMoney formats an amount in the configured currency
+func FormatMoney(amount float64) string {
+	return fmt.Sprintf("%.2f %s", amount, config.Get().Currency)
+}
+
+// FormatDate formats a YYYY-MM-DD date in the configured date format, with
+// month names in the language of messages, or relative to today as selected
+// with SetDateDisplay
 func FormatDate(dateStr string) string {
-	t, err := time.Parse("2006-01-02", dateStr)
+	t, err := time.Parse(DateLayout, dateStr)
 	if err != nil {
 		return dateStr
 	}
-	return t.Format("Jan 02, 2006")
+	layout := config.Get().DateFormat
+	if layout == "" {
+		layout = DateLayout
+	}
+	return displayDate(i18n.FormatTime(t, layout), relativeDay(t))
 }
 
-// FormatDateTime formats a datetime string
+// FormatDateTime formats a point in time in the configured datetime format,
+// or relative to now, as selected with SetDateDisplay
 func FormatDateTime(t time.Time) string {
-	return t.Format("2006-01-02 15:04:05")
+	layout := config.Get().DateTimeFormat
+	if layout == "" {
+		layout = "2006-01-02 15:04:05"
+	}
+	return FormatTime(t, layout)
+}
+
+// FormatTime formats a point in time in layout, or relative to now, as
+// selected with SetDateDisplay
+func FormatTime(t time.Time, layout string) string {
+	return displayDate(t.Format(layout), FormatAgo(t))
 }
 
 // GetStatusIcon returns an icon for a task status
 func GetStatusIcon(status models.TaskStatus) string {
-	switch status {
-	case models.StatusTodo:
-		return "⭕"
-	case models.StatusDoing:
-		return "🔄"
-	case models.StatusDone:
-		return "✅"
-	case models.StatusBlocked:
-		return "🚫"
-	default:
+	def, ok := models.LookupStatus(status)
+	switch {
+	case !ok && asciiMode:
+		return "[?]"
+	case !ok:
 		return "❓"
+	case asciiMode:
+		return def.ASCII
 	}
+	return def.Icon
 }
 
 // GetStatusColor returns the color for a task status
 func GetStatusColor(status models.TaskStatus) *color.Color {
-	switch status {
-	case models.StatusTodo:
-		return Yellow
-	case models.StatusDoing:
-		return Cyan
-	case models.StatusDone:
-		return Green
-	case models.StatusBlocked:
-		return Red
-	default:
-		return White
+	if c, ok := statusColors[status]; ok {
+		return c
 	}
+	return White
+}
+
+// StatusLabel returns the name of a status as a label, such as "Todo"
+func StatusLabel(status models.TaskStatus) string {
+	return capitalize(string(status))
+}
+
+// PriorityLabel returns the name of a priority as a label, such as "High"
+func PriorityLabel(priority models.Priority) string {
+	return capitalize(string(priority))
+}
+
+func capitalize(name string) string {
+	if name == "" {
+		return name
+	}
+	return strings.ToUpper(name[:1]) + name[1:]
 }
 
 // GetPriorityIcon returns an icon for a priority level
 func GetPriorityIcon(priority models.Priority) string {
-	switch priority {
-	case models.PriorityHigh:
-		return "🔴"
-	case models.PriorityMedium:
-		return "🟡"
-	case models.PriorityLow:
-		return "🟢"
-	default:
+	def, ok := models.LookupPriority(priority)
+	switch {
+	case !ok && asciiMode:
+		return "(-)"
+	case !ok:
 		return "⚪"
+	case asciiMode:
+		return def.ASCII
 	}
+	return def.Icon
 }
 
 // GetPriorityColor returns the color for a priority level
 func GetPriorityColor(priority models.Priority) *color.Color {
-	switch priority {
-	case models.PriorityHigh:
-		return Red
-	case models.PriorityMedium:
-		return Yellow
-	case models.PriorityLow:
-		return Green
-	default:
-		return White
+	if c, ok := priorityColors[priority]; ok {
+		return c
 	}
+	return White
 }
 
-// PrintTask prints a task in a formatted way
+// PrintTask prints a task in a listing, with the fields selected with
+// SetTaskFields: on one line in compact listings, with details below otherwise
 func PrintTask(task models.Task, indent string) {
 	statusColor := GetStatusColor(task.Status)
 	statusIcon := GetStatusIcon(task.Status)
-
-	// Task line
-	statusColor.Printf("%s%s [%s] %s", indent, statusIcon, task.ID, task.Title)
-
-	// Priority badge
-	if task.Priority != "" {
-		priorityColor := GetPriorityColor(task.Priority)
-		priorityColor.Printf(" [%s]", task.Priority)
+	badges := taskBadges(task)
+
+	// Task line, with the title shortened to keep it on one line of the terminal
+	title := task.Title
+	if terminalWidth > 0 {
+		rest := fmt.Sprintf("%s%s [%s] ", indent, statusIcon, task.ID)
+		for _, badge := range badges {
+			rest += badge.text
+		}
+		if available := terminalWidth - DisplayWidth(rest); available >= minTitleWidth {
+			title = TruncateWidth(title, available)
+		}
+	}
+	statusColor.Printf("%s%s [%s] %s", indent, statusIcon, task.ID, title)
+	for _, badge := range badges {
+		badge.color.Print(badge.text)
 	}
+	fmt.Println()
 
-	// Status badge
-	statusColor.Printf(" [%s]\n", task.Status)
+	if compactLists {
+		return
+	}
 
 	// Time info
-	if task.EstimatedHours > 0 {
+	if ShowTaskField("time") && task.EstimatedHours > 0 {
 		actual := task.CalculateActualHours()
 		variance := actual - task.EstimatedHours
 
-		fmt.Printf("%s   ⏱️  Est: %s | Act: %s",
+		fmt.Print(i18n.Sprintf("%s   ⏱️  Est: %s | Act: %s",
 			indent,
 			FormatHours(task.EstimatedHours),
-			FormatHours(actual))
+			FormatHours(actual)))
 
 		if variance != 0 {
 			if variance > 0 {
-				Red.Printf(" | +%s over", FormatHours(variance))
+				Red.Print(i18n.Sprintf(" | +%s over", FormatHours(variance)))
 			} else {
-				Green.Printf(" | %s under", FormatHours(-variance))
+				Green.Print(i18n.Sprintf(" | %s under", FormatHours(-variance)))
 			}
 		}
 		fmt.Println()
 	}
 
+	// Due date
+	if ShowTaskField("due") && task.DueDate != "" && !task.Status.IsDone() {
+		if task.IsOverdue(time.Now().Format("2006-01-02")) {
+			Red.Print(i18n.Sprintf("%s   📅 Overdue: %s\n", indent, FormatDate(task.DueDate)))
+		} else {
+			Dim.Print(i18n.Sprintf("%s   📅 Due: %s\n", indent, FormatDate(task.DueDate)))
+		}
+	}
+
 	// Tags
-	if len(task.Tags) > 0 {
+	if ShowTaskField("tags") && len(task.Tags) > 0 {
 		Dim.Printf("%s   🏷️  %s\n", indent, strings.Join(task.Tags, ", "))
 	}
 }
 
+// badge is a colored part of a task's line after its title
+type badge struct {
+	text  string
+	color *color.Color
+}
+
+// taskBadges returns the parts of a task's line after its title: priority,
+// assignee and status, and in compact listings time, due date and tags too
+func taskBadges(task models.Task) []badge {
+	var badges []badge
+	if ShowTaskField("priority") && task.Priority != "" {
+		badges = append(badges, badge{fmt.Sprintf(" [%s]", task.Priority), GetPriorityColor(task.Priority)})
+	}
+	if ShowTaskField("assignee") && task.Assignee != "" {
+		badges = append(badges, badge{" @" + task.Assignee, Magenta})
+	}
+	if ShowTaskField("status") {
+		badges = append(badges, badge{fmt.Sprintf(" [%s]", task.Status), GetStatusColor(task.Status)})
+	}
+	if !compactLists {
+		return badges
+	}
+
+	if ShowTaskField("time") && task.EstimatedHours > 0 {
+		actual := task.CalculateActualHours()
+		timeColor := Dim
+		if actual > task.EstimatedHours {
+			timeColor = Red
+		}
+		badges = append(badges, badge{fmt.Sprintf("  ⏱️  %s/%s", FormatHours(actual), FormatHours(task.EstimatedHours)), timeColor})
+	}
+	if ShowTaskField("due") && task.DueDate != "" && !task.Status.IsDone() {
+		dueColor := Dim
+		if task.IsOverdue(time.Now().Format("2006-01-02")) {
+			dueColor = Red
+		}
+		badges = append(badges, badge{"  📅 " + FormatDate(task.DueDate), dueColor})
+	}
+	if ShowTaskField("tags") && len(task.Tags) > 0 {
+		badges = append(badges, badge{"  🏷️  " + strings.Join(task.Tags, ", "), Dim})
+	}
+	return badges
+}
+
 // PrintTaskDetailed prints a task with full details
 func PrintTaskDetailed(task models.Task, location string) {
 	sections := []sectionBlock{
@@ -249,16 +391,20 @@ func PrintTaskDetailed(task models.Task, location string) {
 	}
 
 	if task.ParentID != "" {
-		sections = append(sections, newSectionBlock("👨‍👩‍👧 Hierarchy", []string{fmt.Sprintf("Parent: %s", task.ParentID)}))
+		sections = append(sections, newSectionBlock("👨‍👩‍👧 Hierarchy", []string{i18n.Sprintf("Parent: %s", task.ParentID)}))
 	}
 
 	if len(task.Tags) > 0 {
 		sections = append(sections, newSectionBlock("🏷️  Tags", []string{strings.Join(task.Tags, ", ")}))
 	}
 
+	if len(task.Commits) > 0 {
+		sections = append(sections, newSectionBlock("🔀 Commits", formatCommits(task.Commits)))
+	}
+
 	sections = append(sections, newSectionBlock("Timestamps", []string{
-		fmt.Sprintf("Created: %s", FormatDateTime(task.CreatedAt)),
-		fmt.Sprintf("Updated: %s", FormatDateTime(task.UpdatedAt)),
+		i18n.Sprintf("Created: %s", FormatDateTime(task.CreatedAt)),
+		i18n.Sprintf("Updated: %s", FormatDateTime(task.UpdatedAt)),
 	}))
 
 	if location != "" {
@@ -282,11 +428,11 @@ func PrintProjectSummary(project *models.Project) {
 	counts := project.CountByStatus()
 	total := len(project.GetAllTasks())
 
-	fmt.Printf("📊 Tasks: %d total\n", total)
-	Yellow.Printf("   ⭕ Todo:    %d\n", counts[models.StatusTodo])
-	Cyan.Printf("   🔄 Doing:   %d\n", counts[models.StatusDoing])
-	Green.Printf("   ✅ Done:    %d\n", counts[models.StatusDone])
-	Red.Printf("   🚫 Blocked: %d\n", counts[models.StatusBlocked])
+	fmt.Print(i18n.Sprintf("📊 Tasks: %d total\n", total))
+	for _, def := range models.Statuses() {
+		label := i18n.T(StatusLabel(def.Name)) + ":"
+		GetStatusColor(def.Name).Printf("   %s %-10s %d\n", GetStatusIcon(def.Name), label, counts[def.Name])
+	}
 
 	fmt.Println()
 
@@ -294,20 +440,20 @@ func PrintProjectSummary(project *models.Project) {
 	estimated := project.CalculateTotalEstimated()
 	actual := project.CalculateTotalActual()
 
-	fmt.Println("⏱️  Time:")
-	fmt.Printf("   Estimated: %s\n", FormatHours(estimated))
-	fmt.Printf("   Actual:    %s\n", FormatHours(actual))
+	fmt.Println(i18n.T("⏱️  Time:"))
+	fmt.Print(i18n.Sprintf("   Estimated: %s\n", FormatHours(estimated)))
+	fmt.Print(i18n.Sprintf("   Actual:    %s\n", FormatHours(actual)))
 
 	if estimated > 0 {
 		variance := actual - estimated
 		if variance > 0 {
-			Red.Printf("   Variance:  +%s (%.1f%% over)\n",
+			Red.Print(i18n.Sprintf("   Variance:  +%s (%.1f%% over)\n",
 				FormatHours(variance),
-				(variance/estimated)*100)
+				(variance/estimated)*100))
 		} else if variance < 0 {
-			Green.Printf("   Variance:  %s (%.1f%% under)\n",
+			Green.Print(i18n.Sprintf("   Variance:  %s (%.1f%% under)\n",
 				FormatHours(variance),
-				(-variance/estimated)*100)
+				(-variance/estimated)*100))
 		}
 	}
 
@@ -315,13 +461,13 @@ func PrintProjectSummary(project *models.Project) {
 
 	// Completion
 	completion := project.GetCompletionPercentage()
-	fmt.Print("📈 Completion: ")
+	fmt.Print(i18n.T("📈 Completion: "))
 	PrintProgressBar(completion, 40)
 	fmt.Printf(" %s\n", FormatPercentage(completion))
 
 	fmt.Println()
-	fmt.Printf("📦 Modules: %d\n", len(project.Modules))
-	fmt.Printf("🏃 Sprints: %d\n", len(project.Sprints))
+	fmt.Print(i18n.Sprintf("📦 Modules: %d\n", len(project.Modules)))
+	fmt.Print(i18n.Sprintf("🏃 Sprints: %d\n", len(project.Sprints)))
 }
 
 // PrintModuleSummary prints a module summary
@@ -332,7 +478,7 @@ func PrintModuleSummary(module *models.Module) {
 		Dim.Println("   " + module.Description)
 	}
 
-	fmt.Printf("   Tasks: %d\n", len(module.Tasks))
+	fmt.Print(i18n.Sprintf("   Tasks: %d\n", len(module.Tasks)))
 
 	// Count by status
 	statusCounts := make(map[models.TaskStatus]int)
@@ -341,9 +487,9 @@ func PrintModuleSummary(module *models.Module) {
 	}
 
 	if len(module.Tasks) > 0 {
-		done := statusCounts[models.StatusDone]
+		done := models.CountDone(statusCounts)
 		completion := float64(done) / float64(len(module.Tasks)) * 100
-		fmt.Print("   Progress: ")
+		fmt.Print(i18n.T("   Progress: "))
 		PrintProgressBar(completion, 30)
 		fmt.Printf(" %s\n", FormatPercentage(completion))
 	}
@@ -354,46 +500,86 @@ func buildTaskDetailsSection(task models.Task) []string {
 	priorityColor := GetPriorityColor(task.Priority)
 
 	lines := []string{
-		fmt.Sprintf("ID:          %s", BoldCyan.Sprint(task.ID)),
-		fmt.Sprintf("Status:      %s %s",
+		i18n.Sprintf("ID:          %s", BoldCyan.Sprint(task.ID)),
+		i18n.Sprintf("Status:      %s %s",
 			statusColor.Sprint(GetStatusIcon(task.Status)),
 			statusColor.Sprint(task.Status)),
-		fmt.Sprintf("Priority:    %s %s",
+		i18n.Sprintf("Priority:    %s %s",
 			priorityColor.Sprint(GetPriorityIcon(task.Priority)),
 			priorityColor.Sprint(task.Priority)),
 	}
 
+	if task.Assignee != "" {
+		lines = append(lines, i18n.Sprintf("Assignee:    %s", Magenta.Sprint(task.Assignee)))
+	}
+
+	if task.DueDate != "" {
+		dueColor := White
+		if task.IsOverdue(time.Now().Format("2006-01-02")) {
+			dueColor = Red
+		}
+		lines = append(lines, i18n.Sprintf("Due:         %s", dueColor.Sprint(FormatDate(task.DueDate))))
+	}
+
 	if task.JiraIssue != "" {
-		lines = append(lines, fmt.Sprintf("Jira Issue:  %s", BoldBlue.Sprint(task.JiraIssue)))
+		lines = append(lines, i18n.Sprintf("Jira Issue:  %s", BoldBlue.Sprint(task.JiraIssue)))
+		if info := task.SyncedJira(); info != nil {
+			lines = append(lines, buildJiraLines(task, info)...)
+		}
+	}
+
+	if task.Branch != "" {
+		lines = append(lines, i18n.Sprintf("Branch:      %s", Yellow.Sprint(task.Branch)))
 	}
 
 	if task.Description != "" {
-		lines = append(lines, fmt.Sprintf("Description: %s", White.Sprint(task.Description)))
+		lines = append(lines, i18n.Sprintf("Description: %s", White.Sprint(task.Description)))
+	}
+
+	return lines
+}
+
+// buildJiraLines describes a task's Jira issue as of the last 'jira sync'
+func buildJiraLines(task models.Task, info *models.JiraInfo) []string {
+	lines := []string{i18n.Sprintf("Jira Title:  %s", White.Sprint(info.Summary))}
+
+	status := info.Status
+	if info.Assignee != "" {
+		status += ", " + info.Assignee
+	}
+	statusColor := Cyan
+	if task.JiraMismatch() {
+		statusColor = Yellow
+		status += " ⚠ " + i18n.T("differs from task")
 	}
+	lines = append(lines, i18n.Sprintf("Jira Status: %s", statusColor.Sprint(status)))
 
+	if !info.Updated.IsZero() {
+		lines = append(lines, i18n.Sprintf("Jira Update: %s", Dim.Sprint(FormatDateTime(info.Updated.Local()))))
+	}
 	return lines
 }
 
 func buildTaskTimeSection(task models.Task) []string {
 	lines := []string{
-		fmt.Sprintf("Estimated:  %s", Cyan.Sprint(FormatHours(task.EstimatedHours))),
+		i18n.Sprintf("Estimated:  %s", Cyan.Sprint(FormatHours(task.EstimatedHours))),
 	}
 
 	actual := task.CalculateActualHours()
-	lines = append(lines, fmt.Sprintf("Actual:     %s", Cyan.Sprint(FormatHours(actual))))
+	lines = append(lines, i18n.Sprintf("Actual:     %s", Cyan.Sprint(FormatHours(actual))))
 
 	if task.EstimatedHours > 0 {
 		variance := task.GetVariance()
 		varPct := task.GetVariancePercentage()
 
 		if variance > 0 {
-			lines = append(lines, fmt.Sprintf("Variance:   %s",
-				Red.Sprintf("+%s (%.1f%% over)", FormatHours(variance), varPct)))
+			lines = append(lines, i18n.Sprintf("Variance:   %s",
+				Red.Sprint(i18n.Sprintf("+%s (%.1f%% over)", FormatHours(variance), varPct))))
 		} else if variance < 0 {
-			lines = append(lines, fmt.Sprintf("Variance:   %s",
-				Green.Sprintf("%s (%.1f%% under)", FormatHours(variance), -varPct)))
+			lines = append(lines, i18n.Sprintf("Variance:   %s",
+				Green.Sprint(i18n.Sprintf("%s (%.1f%% under)", FormatHours(variance), -varPct))))
 		} else {
-			lines = append(lines, Green.Sprint("Variance:   On target"))
+			lines = append(lines, Green.Sprint(i18n.T("Variance:   On target")))
 		}
 	}
 
@@ -406,7 +592,7 @@ type sectionBlock struct {
 }
 
 func newSectionBlock(title string, content []string) sectionBlock {
-	return sectionBlock{title: title, content: content}
+	return sectionBlock{title: i18n.T(title), content: content}
 }
 
 func formatTimeEntries(entries []models.TimeEntry) []string {
@@ -419,16 +605,31 @@ func formatTimeEntries(entries []models.TimeEntry) []string {
 	return lines
 }
 
+func formatCommits(commits []models.CommitRef) []string {
+	lines := make([]string, 0, len(commits))
+	for _, commit := range commits {
+		hash := commit.Hash
+		if len(hash) > 7 {
+			hash = hash[:7]
+		}
+		lines = append(lines, fmt.Sprintf("%s %s %s",
+			Yellow.Sprint(hash),
+			TruncateWidth(commit.Subject, 50),
+			Dim.Sprintf("(%s, %s)", commit.Repo, FormatTime(commit.At.Local(), "2006-01-02"))))
+	}
+	return lines
+}
+
 func formatRecurrence(rec *models.Recurrence) []string {
 	lines := []string{
-		fmt.Sprintf("Pattern:    %s", Magenta.Sprint(rec.Type)),
-		fmt.Sprintf("Next Due:   %s", Yellow.Sprint(FormatDate(rec.NextDue))),
+		i18n.Sprintf("Pattern:    %s", Magenta.Sprint(rec.Type)),
+		i18n.Sprintf("Next Due:   %s", Yellow.Sprint(FormatDate(rec.NextDue))),
 	}
 	if rec.Value != "" {
-		lines[0] = fmt.Sprintf("Pattern:    %s (%s)", Magenta.Sprint(rec.Type), White.Sprint(rec.Value))
+		lines[0] = i18n.Sprintf("Pattern:    %s (%s)", Magenta.Sprint(rec.Type), White.Sprint(rec.Value))
 	}
 	if rec.LastCompleted != "" {
-		lines = append(lines, fmt.Sprintf("Last Done:  %s", Yellow.Sprint(FormatDate(rec.LastCompleted))))
+		lines = append(lines, i18n.Sprintf("Last Done:  %s", Yellow.Sprint(FormatDate(rec.LastCompleted))))
 	}
 	return lines
 }
@@ -436,19 +637,22 @@ func formatRecurrence(rec *models.Recurrence) []string {
 func formatDependencies(ids []string) []string {
 	lines := make([]string, len(ids))
 	for i, dep := range ids {
-		lines[i] = fmt.Sprintf("→ Depends on: %s", Yellow.Sprint(dep))
+		lines[i] = i18n.Sprintf("→ Depends on: %s", Yellow.Sprint(dep))
 	}
 	return lines
 }
 
 func printSectionedBox(title string, sections []sectionBlock) {
 	width := calculateSectionWidth(title, sections)
+	if terminalWidth > 0 && width > terminalWidth {
+		width = terminalWidth
+	}
 	separator := strings.Repeat("═", width)
 
-	BoldBlue.Println(title)
+	subHeaderColor.Println(title)
 	Dim.Println(separator)
 	for i, section := range sections {
-		BoldBlue.Println(section.title)
+		subHeaderColor.Println(section.title)
 		for _, line := range section.content {
 			fmt.Printf("  %s\n", line)
 		}
@@ -461,14 +665,14 @@ func printSectionedBox(title string, sections []sectionBlock) {
 }
 
 func calculateSectionWidth(title string, sections []sectionBlock) int {
-	width := len(title)
+	width := DisplayWidth(title)
 	for _, section := range sections {
-		if len(section.title) > width {
-			width = len(section.title)
+		if DisplayWidth(section.title) > width {
+			width = DisplayWidth(section.title)
 		}
 		for _, line := range section.content {
-			if len(line) > width {
-				width = len(line)
+			if DisplayWidth(line) > width {
+				width = DisplayWidth(line)
 			}
 		}
 	}
@@ -484,15 +688,15 @@ func PrintList(items []string, bullet string) {
 
 // PrintSeparator prints a horizontal line
 func PrintSeparator() {
-	Dim.Println(strings.Repeat("─", 80))
+	Dim.Println(strings.Repeat("─", layoutWidth()))
 }
 
 // PrintEmptyState prints a message when no data exists
 func PrintEmptyState(message string, suggestion string) {
 	fmt.Println()
-	Yellow.Println("ℹ️  " + message)
+	Yellow.Println("ℹ️  " + i18n.T(message))
 	if suggestion != "" {
-		Dim.Println("   💡 " + suggestion)
+		Dim.Println("   💡 " + i18n.T(suggestion))
 	}
 	fmt.Println()
 }
@@ -0,0 +1,98 @@
+package ui
+
+import (
+	"os"
+	"os/exec"
+	"os/signal"
+	"runtime"
+	"strings"
+
+	"github.com/fatih/color"
+)
+
+var (
+	// pager shows standard output while it is being paged; nil otherwise
+	pager *exec.Cmd
+
+	// pagerInput feeds the pager, in place of standard output
+	pagerInput *os.File
+)
+
+// StartPager sends standard output through a pager such as less, when standard
+// output is a terminal. Unless LESS is already set, less is run with -FRX, so it
+// quits at once when the output fits on the screen, keeps colors, and leaves the
+// output on the screen; it is also told the output is UTF-8, whatever the
+// locale. Call FlushOutput before exiting to wait for the pager.
+func StartPager(command string) {
+	command = strings.TrimSpace(command)
+	if pager != nil || command == "" || command == "cat" || !isTerminal(os.Stdout) {
+		return
+	}
+
+	r, w, err := os.Pipe()
+	if err != nil {
+		return
+	}
+
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		fields := strings.Fields(command)
+		cmd = exec.Command(fields[0], fields[1:]...)
+	} else {
+		cmd = exec.Command("sh", "-c", command)
+	}
+	cmd.Stdin = r
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	cmd.Env = os.Environ()
+	if _, ok := os.LookupEnv("LESS"); !ok {
+		cmd.Env = append(cmd.Env, "LESS=FRX")
+	}
+	if _, ok := os.LookupEnv("LESSCHARSET"); !ok {
+		cmd.Env = append(cmd.Env, "LESSCHARSET=utf-8")
+	}
+	if _, ok := os.LookupEnv("LV"); !ok {
+		cmd.Env = append(cmd.Env, "LV=-c")
+	}
+
+	if err := cmd.Start(); err != nil {
+		r.Close()
+		w.Close()
+		return
+	}
+	r.Close()
+
+	// Ctrl-C belongs to the pager while it runs, as with git
+	signal.Ignore(os.Interrupt)
+
+	pager = cmd
+	pagerInput = w
+	os.Stdout = w
+	color.Output = w
+}
+
+// closePager lets the pager read the end of the output and waits for it to quit
+func closePager() {
+	if pager == nil {
+		return
+	}
+
+	terminal := pager.Stdout.(*os.File)
+	pagerInput.Close()
+	pager.Wait()
+
+	if os.Stdout == pagerInput {
+		os.Stdout = terminal
+	}
+	if color.Output == pagerInput {
+		color.Output = terminal
+	}
+	pager = nil
+	pagerInput = nil
+}
+
+// isTerminal reports whether f is a terminal rather than a file or pipe
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
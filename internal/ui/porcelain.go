@@ -0,0 +1,59 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/fatih/color"
+)
+
+var (
+	porcelain     bool
+	errorsPrinted bool
+	lastError     string
+)
+
+// EnablePorcelain switches to output for scripts: commands that support it print
+// tab-separated records instead of tables, colors are off, success and info
+// messages are suppressed, and warnings and errors go to standard error.
+func EnablePorcelain() {
+	porcelain = true
+	color.NoColor = true
+}
+
+// Porcelain reports whether porcelain output is enabled
+func Porcelain() bool {
+	return porcelain
+}
+
+// ErrorsPrinted reports whether PrintError was called, so the process can exit
+// with a failure status
+func ErrorsPrinted() bool {
+	return errorsPrinted
+}
+
+// LastError returns the last message PrintError printed, untranslated, or ""
+// if there was none
+func LastError() string {
+	return lastError
+}
+
+// PrintRecord prints one porcelain record: fields separated by tabs, on one line.
+// Empty fields are printed as "-" so that shells splitting on whitespace keep
+// the columns aligned; tabs and newlines inside fields become spaces.
+func PrintRecord(fields ...string) {
+	cleaned := make([]string, len(fields))
+	for i, field := range fields {
+		field = strings.NewReplacer("\t", " ", "\r", " ", "\n", " ").Replace(field)
+		if field == "" {
+			field = "-"
+		}
+		cleaned[i] = field
+	}
+	fmt.Fprintln(DataOutput(), strings.Join(cleaned, "\t"))
+}
+
+// FormatRecordHours formats hours for a porcelain record
+func FormatRecordHours(hours float64) string {
+	return fmt.Sprintf("%.2f", hours)
+}
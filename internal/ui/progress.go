@@ -52,6 +52,7 @@ func PrintProgressBar(percentage float64, width int) {
 
 // PrintProgressBarWithStyle prints a progress bar with custom style
 func PrintProgressBarWithStyle(percentage float64, width int, style ProgressBarStyle) {
+	width = ScaleWidth(width)
 	if percentage < 0 {
 		percentage = 0
 	}
@@ -185,14 +186,14 @@ func PrintChart(data map[string]float64, width int, showValues bool) {
 		if value > maxValue {
 			maxValue = value
 		}
-		if len(label) > maxLabelLen {
-			maxLabelLen = len(label)
+		if DisplayWidth(label) > maxLabelLen {
+			maxLabelLen = DisplayWidth(label)
 		}
 	}
 	
+	width = ScaleWidth(width)
 	for label, value := range data {
-		paddedLabel := label + strings.Repeat(" ", maxLabelLen-len(label))
-		fmt.Printf("%s: ", paddedLabel)
+		fmt.Printf("%s: ", PadRight(label, maxLabelLen))
 		
 		barWidth := int((value / maxValue) * float64(width))
 		
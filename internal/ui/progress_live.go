@@ -0,0 +1,100 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+// progressInterval is how often a running progress line is redrawn
+const progressInterval = 120 * time.Millisecond
+
+// Progress shows how far a long operation has got on a line redrawn in place:
+// a loading bar once the total is known, a spinner until then, and the time
+// taken so far. Nothing is drawn unless output goes to a terminal, so it can be
+// used unconditionally.
+type Progress struct {
+	label string
+	unit  string
+	start time.Time
+
+	mu    sync.Mutex
+	done  int
+	total int
+	frame int
+
+	stop    chan struct{}
+	stopped sync.WaitGroup
+}
+
+// StartProgress starts showing the progress of an operation counting units
+// (such as "files") up to total, or 0 if the total is not known yet. Call Stop
+// before printing anything else.
+func StartProgress(label, unit string, total int) *Progress {
+	p := &Progress{label: label, unit: unit, total: total, start: time.Now()}
+	if !isTerminal(os.Stdout) || terminalWidth == 0 || jsonOut != nil || porcelain || pager != nil {
+		return p
+	}
+
+	p.stop = make(chan struct{})
+	p.stopped.Add(1)
+	go func() {
+		defer p.stopped.Done()
+		ticker := time.NewTicker(progressInterval)
+		defer ticker.Stop()
+		for {
+			p.draw()
+			select {
+			case <-ticker.C:
+			case <-p.stop:
+				return
+			}
+		}
+	}()
+	return p
+}
+
+// Add counts n more units done
+func (p *Progress) Add(n int) {
+	p.mu.Lock()
+	p.done += n
+	p.mu.Unlock()
+}
+
+// Set sets the units done and the total; it suits callbacks reporting both
+func (p *Progress) Set(done, total int) {
+	p.mu.Lock()
+	p.done, p.total = done, total
+	p.mu.Unlock()
+}
+
+// Stop erases the progress line and returns the time the operation took
+func (p *Progress) Stop() time.Duration {
+	if p.stop != nil {
+		close(p.stop)
+		p.stopped.Wait()
+		p.stop = nil
+		fmt.Print("\r\x1b[K")
+	}
+	return time.Since(p.start)
+}
+
+// draw redraws the progress line
+func (p *Progress) draw() {
+	p.mu.Lock()
+	done, total := p.done, p.total
+	p.frame++
+	frame := p.frame
+	p.mu.Unlock()
+
+	fmt.Print("\r")
+	count := fmt.Sprintf("%d %s", done, p.unit)
+	if total > 0 {
+		PrintProgressBar(float64(done)/float64(total)*100, 20)
+		count = fmt.Sprintf("%d/%d %s", done, total, p.unit)
+	} else {
+		PrintSpinner(frame)
+	}
+	fmt.Printf(" %s  %s  %s\x1b[K", p.label, count, Dim.Sprint(FormatDuration(time.Since(p.start))))
+}
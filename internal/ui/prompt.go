@@ -0,0 +1,387 @@
+package ui
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/mrbooshehri/qix-go/internal/i18n"
+)
+
+// ErrCancelled is returned by prompts the user backed out of
+var ErrCancelled = errors.New("cancelled")
+
+// maxSelectRows is the number of options a select list shows at once
+const maxSelectRows = 10
+
+// Prompter asks the user questions. Commands ask through the package functions
+// (Input, Select, ...), which use the prompter set with SetPrompter; tests can
+// set one that answers from a script.
+type Prompter interface {
+	// Input asks for a line of text. An empty answer keeps current; answers
+	// validate rejects are asked again.
+	Input(label, current string, validate func(string) error) (string, error)
+
+	// Select asks for one of options, starting from current
+	Select(label string, options []string, current string) (string, error)
+
+	// MultiSelect asks for any number of options, starting from selected
+	MultiSelect(label string, options, selected []string) ([]string, error)
+
+	// Confirm asks a yes or no question; the answer is no unless the user
+	// types y, or expected if it is not empty
+	Confirm(question, expected string) (bool, error)
+}
+
+var prompter Prompter = &terminalPrompter{}
+
+// SetPrompter replaces the prompter and returns the previous one
+func SetPrompter(p Prompter) Prompter {
+	previous := prompter
+	prompter = p
+	return previous
+}
+
+// Input asks for a line of text, keeping current if the answer is empty
+func Input(label, current string, validate func(string) error) (string, error) {
+	return prompter.Input(label, current, validate)
+}
+
+// Select asks for one of options, keeping current if none is chosen
+func Select(label string, options []string, current string) (string, error) {
+	return prompter.Select(label, options, current)
+}
+
+// MultiSelect asks for any number of options
+func MultiSelect(label string, options, selected []string) ([]string, error) {
+	return prompter.MultiSelect(label, options, selected)
+}
+
+// Confirm asks a yes or no question, answered no by default
+func Confirm(question string) bool {
+	ok, _ := prompter.Confirm(question, "")
+	return ok
+}
+
+// ConfirmTyped asks the user to type expected to go ahead
+func ConfirmTyped(expected string) bool {
+	ok, _ := prompter.Confirm(i18n.Sprintf("Type '%s' to confirm:", expected), expected)
+	return ok
+}
+
+// Interactive reports whether the user can be asked through the terminal:
+// standard input and output are terminals, and output is not JSON or porcelain.
+// Otherwise prompts read typed answers, such as ones piped in, and screens are
+// not available.
+func Interactive() bool {
+	return isTerminal(os.Stdin) && isTerminal(os.Stdout) && terminalWidth > 0 && jsonOut == nil && !porcelain
+}
+
+// promptOutput returns where questions are asked: standard output on a
+// terminal, and standard error when output is redirected, so questions do not
+// end up in the file or pipe
+func promptOutput() io.Writer {
+	if isTerminal(os.Stdout) {
+		return os.Stdout
+	}
+	return os.Stderr
+}
+
+// stdin reads standard input for prompts and screens. It is shared, so answers
+// piped in ahead of the questions are not lost between prompts.
+var stdin *bufio.Reader
+
+func stdinReader() *bufio.Reader {
+	if stdin == nil {
+		stdin = bufio.NewReader(os.Stdin)
+	}
+	return stdin
+}
+
+// terminalPrompter asks on the terminal: lists are chosen from with the arrow
+// keys when standard input and output are terminals, and typed in otherwise.
+type terminalPrompter struct{}
+
+func (p *terminalPrompter) Input(label, current string, validate func(string) error) (string, error) {
+	for {
+		answer, err := p.readLine(label + promptDefault(current) + ":")
+		if err != nil {
+			return current, err
+		}
+		if answer == "" {
+			return current, nil
+		}
+		if validate != nil {
+			if err := validate(answer); err != nil {
+				PrintWarning("%v", err)
+				continue
+			}
+		}
+		return answer, nil
+	}
+}
+
+func (p *terminalPrompter) Select(label string, options []string, current string) (string, error) {
+	if len(options) == 0 {
+		return current, nil
+	}
+	if restore, ok := p.raw(); ok {
+		defer restore()
+		cursor := indexOf(options, current)
+		chosen, err := p.list(label, options, max(cursor, 0), nil)
+		if err != nil {
+			return current, err
+		}
+		p.answer(label, options[chosen])
+		return options[chosen], nil
+	}
+
+	for {
+		answer, err := p.readLine(fmt.Sprintf("%s%s (%s):", label, promptDefault(current), strings.Join(options, "/")))
+		if err != nil {
+			return current, err
+		}
+		if answer == "" {
+			return current, nil
+		}
+		if option, ok := matchOption(options, answer); ok {
+			return option, nil
+		}
+		PrintWarning("Choose one of: %s", strings.Join(options, ", "))
+	}
+}
+
+func (p *terminalPrompter) MultiSelect(label string, options, selected []string) ([]string, error) {
+	if len(options) == 0 {
+		return selected, nil
+	}
+	if restore, ok := p.raw(); ok {
+		defer restore()
+		checked := make([]bool, len(options))
+		for i, option := range options {
+			checked[i] = indexOf(selected, option) >= 0
+		}
+		if _, err := p.list(label, options, 0, checked); err != nil {
+			return selected, err
+		}
+		result := make([]string, 0, len(options))
+		for i, option := range options {
+			if checked[i] {
+				result = append(result, option)
+			}
+		}
+		p.answer(label, strings.Join(result, ", "))
+		return result, nil
+	}
+
+	for {
+		answer, err := p.readLine(fmt.Sprintf("%s%s (comma-separated: %s, '-' for none):",
+			label, promptDefault(strings.Join(selected, ", ")), strings.Join(options, "/")))
+		if err != nil {
+			return selected, err
+		}
+		switch answer {
+		case "":
+			return selected, nil
+		case "-":
+			return []string{}, nil
+		}
+
+		result := make([]string, 0)
+		valid := true
+		for _, part := range strings.Split(answer, ",") {
+			if strings.TrimSpace(part) == "" {
+				continue
+			}
+			option, ok := matchOption(options, part)
+			if !ok {
+				PrintWarning("Unknown choice '%s'. Choose from: %s", strings.TrimSpace(part), strings.Join(options, ", "))
+				valid = false
+				break
+			}
+			if indexOf(result, option) < 0 {
+				result = append(result, option)
+			}
+		}
+		if valid {
+			return result, nil
+		}
+	}
+}
+
+func (p *terminalPrompter) Confirm(question, expected string) (bool, error) {
+	if expected == "" {
+		question += " " + i18n.T("(y/N):")
+	}
+	answer, err := p.readLine(question)
+	if err != nil {
+		return false, err
+	}
+	if expected == "" {
+		for _, yes := range []string{"y", "yes", i18n.T("y"), i18n.T("yes")} {
+			if strings.EqualFold(answer, yes) {
+				return true, nil
+			}
+		}
+		return false, nil
+	}
+	return answer == expected, nil
+}
+
+// readLine asks a question and reads the answer. At the end of the input the
+// answer is empty, as if the user had pressed enter.
+func (p *terminalPrompter) readLine(question string) (string, error) {
+	out := promptOutput()
+	fmt.Fprint(out, question+" ")
+	line, err := stdinReader().ReadString('\n')
+	if err == io.EOF {
+		if line == "" {
+			// Finish the question's line, which the user never did
+			fmt.Fprintln(out)
+		}
+		err = nil
+	}
+	return strings.TrimSpace(line), err
+}
+
+// raw switches the terminal to raw mode for choosing from a list, if the
+// session is interactive
+func (p *terminalPrompter) raw() (func(), bool) {
+	if !Interactive() {
+		return nil, false
+	}
+	restore, err := makeRaw(os.Stdin)
+	if err != nil {
+		return nil, false
+	}
+	// Hide the cursor while the list is shown
+	fmt.Print("\x1b[?25l")
+	return func() {
+		fmt.Print("\x1b[?25h")
+		restore()
+	}, true
+}
+
+// list lets the user move through options with the arrow keys and returns the
+// one chosen with enter. With checked, space ticks options instead.
+func (p *terminalPrompter) list(label string, options []string, cursor int, checked []bool) (int, error) {
+	rows := min(len(options), maxSelectRows)
+	offset := 0
+	hint := i18n.T("↑/↓ choose, enter accept, esc cancel")
+	if checked != nil {
+		hint = i18n.T("↑/↓ move, space tick, enter accept, esc cancel")
+	}
+
+	drawn := false
+	for {
+		if cursor < offset {
+			offset = cursor
+		} else if cursor >= offset+rows {
+			offset = cursor - rows + 1
+		}
+
+		var b strings.Builder
+		if drawn {
+			// Back to the label, to draw the list over itself
+			fmt.Fprintf(&b, "\r\x1b[%dA", rows)
+		}
+		fmt.Fprintf(&b, "\r%s %s\x1b[K\n", label+":", Dim.Sprint(hint))
+		for i := offset; i < offset+rows; i++ {
+			line := "  "
+			if i == cursor {
+				line = accentColor.Sprint("> ")
+			}
+			if checked != nil {
+				if checked[i] {
+					line += "[x] "
+				} else {
+					line += "[ ] "
+				}
+			}
+			text := TruncateWidth(options[i], layoutWidth()-DisplayWidth(line))
+			if i == cursor {
+				text = accentColor.Sprint(text)
+			}
+			b.WriteString("\r" + line + text + "\x1b[K")
+			if i < offset+rows-1 {
+				b.WriteString("\n")
+			}
+		}
+		fmt.Print(b.String())
+		drawn = true
+
+		key, err := readKey(stdinReader())
+		if err != nil {
+			p.clearList(rows)
+			return cursor, err
+		}
+
+		switch {
+		case key.Key == KeyUp, key.Key == KeyRune && key.Rune == 'k':
+			cursor = max(cursor-1, 0)
+		case key.Key == KeyDown, key.Key == KeyRune && key.Rune == 'j':
+			cursor = min(cursor+1, len(options)-1)
+		case key.Key == KeySpace && checked != nil:
+			checked[cursor] = !checked[cursor]
+		case key.Key == KeyEnter, key.Key == KeySpace:
+			p.clearList(rows)
+			return cursor, nil
+		case key.Key == KeyEscape, key.Key == KeyInterrupt:
+			p.clearList(rows)
+			return cursor, ErrCancelled
+		case key.Key == KeyRune && key.Rune >= '1' && key.Rune <= '9':
+			if n := int(key.Rune - '1'); n < len(options) {
+				cursor = n
+			}
+		}
+	}
+}
+
+// clearList erases a list drawn by list, leaving the cursor where it began
+func (p *terminalPrompter) clearList(rows int) {
+	fmt.Printf("\r\x1b[%dA\x1b[J", rows)
+}
+
+// answer shows the choice made in place of the list
+func (p *terminalPrompter) answer(label, value string) {
+	if value == "" {
+		value = "<none>"
+	}
+	fmt.Printf("%s: %s\r\n", label, accentColor.Sprint(value))
+}
+
+// promptDefault shows the value kept by an empty answer
+func promptDefault(current string) string {
+	if current == "" {
+		return ""
+	}
+	return " [" + current + "]"
+}
+
+// matchOption finds the option an answer names, by name or by number from 1
+func matchOption(options []string, answer string) (string, bool) {
+	answer = strings.TrimSpace(answer)
+	for _, option := range options {
+		if strings.EqualFold(option, answer) {
+			return option, true
+		}
+	}
+	if n, err := strconv.Atoi(answer); err == nil && n >= 1 && n <= len(options) {
+		return options[n-1], true
+	}
+	return "", false
+}
+
+// indexOf returns the position of value in values, or -1
+func indexOf(values []string, value string) int {
+	for i, v := range values {
+		if v == value {
+			return i
+		}
+	}
+	return -1
+}
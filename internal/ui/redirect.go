@@ -0,0 +1,38 @@
+package ui
+
+import (
+	"os"
+
+	"github.com/fatih/color"
+)
+
+// RedirectOutput sends all printed output to f with colors disabled and laid out
+// without regard to the terminal's width; with JSON
+// output enabled, only the JSON is redirected. The returned function restores the
+// previous destination.
+func RedirectOutput(f *os.File) func() {
+	if jsonOut != nil {
+		prevJSON := jsonOut
+		jsonOut = f
+		return func() {
+			jsonOut = prevJSON
+		}
+	}
+
+	prevStdout := os.Stdout
+	prevOutput := color.Output
+	prevNoColor := color.NoColor
+	prevWidth := terminalWidth
+
+	os.Stdout = f
+	color.Output = f
+	color.NoColor = true
+	terminalWidth = 0
+
+	return func() {
+		os.Stdout = prevStdout
+		color.Output = prevOutput
+		color.NoColor = prevNoColor
+		terminalWidth = prevWidth
+	}
+}
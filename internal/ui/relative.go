@@ -0,0 +1,131 @@
+package ui
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/mrbooshehri/qix-go/internal/i18n"
+)
+
+// Date display modes accepted by SetDateDisplay
+const (
+	DatesAbsolute = "absolute"
+	DatesRelative = "relative"
+	DatesBoth     = "both"
+)
+
+// dateDisplay is how dates and times are shown to people
+var dateDisplay = DatesAbsolute
+
+// relativeUnits are the units relative times are counted in: the first one
+// the time is less than below of
+var relativeUnits = []struct {
+	below, size        time.Duration
+	past, pastMany     string
+	future, futureMany string
+}{
+	{time.Hour, time.Minute, "1 minute ago", "%d minutes ago", "in 1 minute", "in %d minutes"},
+	{24 * time.Hour, time.Hour, "1 hour ago", "%d hours ago", "in 1 hour", "in %d hours"},
+	{7 * 24 * time.Hour, 24 * time.Hour, "1 day ago", "%d days ago", "in 1 day", "in %d days"},
+	{30 * 24 * time.Hour, 7 * 24 * time.Hour, "1 week ago", "%d weeks ago", "in 1 week", "in %d weeks"},
+	{365 * 24 * time.Hour, 30 * 24 * time.Hour, "1 month ago", "%d months ago", "in 1 month", "in %d months"},
+	{math.MaxInt64, 365 * 24 * time.Hour, "1 year ago", "%d years ago", "in 1 year", "in %d years"},
+}
+
+// SetDateDisplay selects how dates and times are shown: absolute ("Oct 19,
+// 2026"), relative ("in 3 days") or both. JSON, porcelain and CSV output
+// always show them absolute.
+func SetDateDisplay(mode string) error {
+	switch strings.ToLower(mode) {
+	case "", DatesAbsolute:
+		dateDisplay = DatesAbsolute
+	case DatesRelative:
+		dateDisplay = DatesRelative
+	case DatesBoth:
+		dateDisplay = DatesBoth
+	default:
+		return fmt.Errorf("unknown date display '%s' (use: absolute, relative, both)", mode)
+	}
+	return nil
+}
+
+// DateDisplay returns how dates and times are shown, absolute for output read
+// by programs
+func DateDisplay() string {
+	if jsonOut != nil || porcelain || tableFormat == TableFormatCSV {
+		return DatesAbsolute
+	}
+	return dateDisplay
+}
+
+// FormatAgo describes how long ago or from now t is: "just now", "5 minutes
+// ago", "in 3 days"
+func FormatAgo(t time.Time) string {
+	return relativePhrase(time.Since(t))
+}
+
+// relativeDay describes a day relative to today: "today", "yesterday", "in
+// 3 days"
+func relativeDay(day time.Time) string {
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+	day = time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, time.Local)
+
+	// Rounded, as days around a daylight saving change are not 24 hours long
+	switch days := int(math.Round(day.Sub(today).Hours() / 24)); days {
+	case 0:
+		return i18n.T("today")
+	case -1:
+		return i18n.T("yesterday")
+	case 1:
+		return i18n.T("tomorrow")
+	default:
+		return relativePhrase(-time.Duration(days) * 24 * time.Hour)
+	}
+}
+
+// relativePhrase describes an age, in the future if it is negative
+func relativePhrase(age time.Duration) string {
+	future := age < 0
+	if future {
+		age = -age
+	}
+	if age < time.Minute {
+		return i18n.T("just now")
+	}
+
+	unit := relativeUnits[len(relativeUnits)-1]
+	for _, u := range relativeUnits {
+		if age < u.below {
+			unit = u
+			break
+		}
+	}
+
+	n := int(age / unit.size)
+	switch {
+	case future && n == 1:
+		return i18n.T(unit.future)
+	case future:
+		return i18n.Sprintf(unit.futureMany, n)
+	case n == 1:
+		return i18n.T(unit.past)
+	default:
+		return i18n.Sprintf(unit.pastMany, n)
+	}
+}
+
+// displayDate combines the absolute and relative forms of a date as selected
+// with SetDateDisplay
+func displayDate(absolute, relative string) string {
+	switch DateDisplay() {
+	case DatesRelative:
+		return relative
+	case DatesBoth:
+		return fmt.Sprintf("%s (%s)", absolute, relative)
+	default:
+		return absolute
+	}
+}
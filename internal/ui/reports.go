@@ -5,7 +5,7 @@ import (
 	"time"
 
 	"github.com/fatih/color"
-	"github.com/mrbooshehri/qix-go/internal/models"
+	"github.com/mrbooshehri/qix-go/pkg/qix/models"
 )
 
 // PrintDailyReport prints a formatted daily time report
@@ -50,11 +50,13 @@ func PrintProjectReport(project *models.Project, startDate, endDate string) {
 	
 	table := NewTableBuilder("Metric", "Value").
 		Row("Total Tasks", fmt.Sprintf("%d", len(project.GetAllTasks()))).
-		Row("Completed", fmt.Sprintf("%d", counts[models.StatusDone])).
-		Row("In Progress", fmt.Sprintf("%d", counts[models.StatusDoing])).
-		Row("Todo", fmt.Sprintf("%d", counts[models.StatusTodo])).
-		Row("Blocked", fmt.Sprintf("%d", counts[models.StatusBlocked])).
+		Row("Completed", fmt.Sprintf("%d", models.CountDone(counts))).
 		Align(1, AlignRight)
+	for _, def := range models.Statuses() {
+		if !def.Done {
+			table.Row(StatusLabel(def.Name), fmt.Sprintf("%d", counts[def.Name]))
+		}
+	}
 	
 	table.PrintSimple()
 	fmt.Println()
@@ -96,7 +98,7 @@ func PrintProjectReport(project *models.Project, startDate, endDate string) {
 		for _, module := range project.Modules {
 			moduleDone := 0
 			for _, task := range module.Tasks {
-				if task.Status == models.StatusDone {
+				if task.Status.IsDone() {
 					moduleDone++
 				}
 			}
@@ -173,7 +175,7 @@ func PrintKPIReport(project *models.Project) {
 	completedLastWeek := 0
 	
 	for _, task := range allTasks {
-		if task.Status == models.StatusDone && task.UpdatedAt.Format("2006-01-02") >= weekAgo {
+		if task.Status.IsDone() && task.UpdatedAt.Format("2006-01-02") >= weekAgo {
 			completedLastWeek++
 		}
 	}
@@ -189,11 +191,9 @@ func PrintKPIReport(project *models.Project) {
 	
 	total := len(allTasks)
 	if total > 0 {
-		distribution := map[string]float64{
-			"Todo":    float64(counts[models.StatusTodo]) / float64(total) * 100,
-			"Doing":   float64(counts[models.StatusDoing]) / float64(total) * 100,
-			"Done":    float64(counts[models.StatusDone]) / float64(total) * 100,
-			"Blocked": float64(counts[models.StatusBlocked]) / float64(total) * 100,
+		distribution := make(map[string]float64)
+		for _, def := range models.Statuses() {
+			distribution[StatusLabel(def.Name)] = float64(counts[def.Name]) / float64(total) * 100
 		}
 		
 		PrintChart(distribution, 40, true)
@@ -205,7 +205,7 @@ func PrintKPIReport(project *models.Project) {
 	
 	priorityCounts := make(map[models.Priority]int)
 	for _, task := range allTasks {
-		if task.Status != models.StatusDone {
+		if !task.Status.IsDone() {
 			priorityCounts[task.Priority]++
 		}
 	}
@@ -214,28 +214,19 @@ func PrintKPIReport(project *models.Project) {
 		Align(1, AlignRight).
 		Align(2, AlignRight)
 	
-	active := len(allTasks) - counts[models.StatusDone]
+	active := len(allTasks) - models.CountDone(counts)
 	if active > 0 {
-		if count := priorityCounts[models.PriorityHigh]; count > 0 {
-			pct := float64(count) / float64(active) * 100
-			table.ColoredRow(
-				[]string{"High", fmt.Sprintf("%d", count), FormatPercentage(pct)},
-				[]*color.Color{Red, Red, Red},
-			)
-		}
-		if count := priorityCounts[models.PriorityMedium]; count > 0 {
-			pct := float64(count) / float64(active) * 100
-			table.ColoredRow(
-				[]string{"Medium", fmt.Sprintf("%d", count), FormatPercentage(pct)},
-				[]*color.Color{Yellow, Yellow, Yellow},
-			)
-		}
-		if count := priorityCounts[models.PriorityLow]; count > 0 {
-			pct := float64(count) / float64(active) * 100
-			table.ColoredRow(
-				[]string{"Low", fmt.Sprintf("%d", count), FormatPercentage(pct)},
-				[]*color.Color{Green, Green, Green},
-			)
+		// Highest first
+		defs := models.Priorities()
+		for i := len(defs) - 1; i >= 0; i-- {
+			if count := priorityCounts[defs[i].Name]; count > 0 {
+				pct := float64(count) / float64(active) * 100
+				c := GetPriorityColor(defs[i].Name)
+				table.ColoredRow(
+					[]string{PriorityLabel(defs[i].Name), fmt.Sprintf("%d", count), FormatPercentage(pct)},
+					[]*color.Color{c, c, c},
+				)
+			}
 		}
 	}
 	
@@ -249,7 +240,7 @@ func PrintKPIReport(project *models.Project) {
 	doneActual := 0.0
 	
 	for _, task := range allTasks {
-		if task.Status == models.StatusDone && task.EstimatedHours > 0 {
+		if task.Status.IsDone() && task.EstimatedHours > 0 {
 			doneEstimated += task.EstimatedHours
 			doneActual += task.CalculateActualHours()
 		}
@@ -283,11 +274,11 @@ func PrintWBSReport(project *models.Project) {
 	// Overall progress
 	completion := project.GetCompletionPercentage()
 	total := len(project.GetAllTasks())
-	done := project.CountByStatus()[models.StatusDone]
+	done := models.CountDone(project.CountByStatus())
 	
 	fmt.Printf("Overall Progress: %.1f%% (%d/%d tasks)\n", completion, done, total)
 	PrintProgressBar(completion, 60)
-	fmt.Println("\n")
+	fmt.Print("\n\n")
 	
 	// Project-level tasks
 	if len(project.Tasks) > 0 {
@@ -311,7 +302,7 @@ func PrintWBSReport(project *models.Project) {
 			// Module progress
 			moduleDone := 0
 			for _, task := range module.Tasks {
-				if task.Status == models.StatusDone {
+				if task.Status.IsDone() {
 					moduleDone++
 				}
 			}
@@ -381,17 +372,16 @@ func PrintSprintReport(project *models.Project, sprint *models.Sprint) {
 		totalAct += task.CalculateActualHours()
 	}
 	
-	done := statusCounts[models.StatusDone]
+	done := models.CountDone(statusCounts)
 	completion := float64(done) / float64(len(sprintTasks)) * 100
 	
 	// Print summary
 	table := NewTableBuilder("Metric", "Value").
-		Row("Total Tasks", fmt.Sprintf("%d", len(sprintTasks))).
-		Row("✅ Done", fmt.Sprintf("%d", done)).
-		Row("🔄 Doing", fmt.Sprintf("%d", statusCounts[models.StatusDoing])).
-		Row("⭕ Todo", fmt.Sprintf("%d", statusCounts[models.StatusTodo])).
-		Row("🚫 Blocked", fmt.Sprintf("%d", statusCounts[models.StatusBlocked])).
-		Row("", "").
+		Row("Total Tasks", fmt.Sprintf("%d", len(sprintTasks)))
+	for _, def := range models.Statuses() {
+		table.Row(GetStatusIcon(def.Name)+" "+StatusLabel(def.Name), fmt.Sprintf("%d", statusCounts[def.Name]))
+	}
+	table.Row("", "").
 		Row("Estimated", FormatHours(totalEst)).
 		Row("Actual", FormatHours(totalAct)).
 		Align(1, AlignRight)
@@ -0,0 +1,159 @@
+package ui
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Key is a key pressed on an interactive screen
+type Key int
+
+const (
+	KeyRune Key = iota // A printable character; see KeyPress.Rune
+	KeyUp
+	KeyDown
+	KeyLeft
+	KeyRight
+	KeyEnter
+	KeySpace
+	KeyEscape
+	KeyInterrupt // Ctrl-C
+	KeyUnknown
+)
+
+// KeyPress is a key read from the terminal
+type KeyPress struct {
+	Key  Key
+	Rune rune
+}
+
+// Screen takes over the terminal for an interactive view: key presses are read
+// one at a time without echo, and each frame replaces the previous one on the
+// terminal's alternate screen. Close restores the terminal.
+type Screen struct {
+	in      *bufio.Reader
+	restore func()
+}
+
+// OpenScreen starts an interactive screen. It fails if standard input or output
+// is not a terminal.
+func OpenScreen() (*Screen, error) {
+	if !Interactive() {
+		return nil, errors.New("not running in a terminal")
+	}
+
+	restore, err := makeRaw(os.Stdin)
+	if err != nil {
+		return nil, err
+	}
+
+	// Alternate screen, cursor hidden
+	fmt.Print("\x1b[?1049h\x1b[?25l")
+	return &Screen{in: stdinReader(), restore: restore}, nil
+}
+
+// Close shows the cursor, leaves the alternate screen and restores the terminal
+func (s *Screen) Close() {
+	fmt.Print("\x1b[?25h\x1b[?1049l")
+	s.restore()
+}
+
+// Size returns the terminal's current width and height
+func (s *Screen) Size() (cols, rows int) {
+	cols, rows = terminalSize(os.Stdin)
+	if cols == 0 {
+		cols = layoutWidth()
+	}
+	if rows == 0 {
+		rows = 24
+	}
+	return cols, rows
+}
+
+// Draw replaces the screen with lines, which should fit the terminal
+func (s *Screen) Draw(lines []string) {
+	var b strings.Builder
+	b.WriteString("\x1b[H")
+	for i, line := range lines {
+		if i > 0 {
+			b.WriteString("\r\n")
+		}
+		b.WriteString(line)
+		b.WriteString("\x1b[K")
+	}
+	b.WriteString("\x1b[J")
+	fmt.Print(b.String())
+}
+
+// ReadKey waits for a key press
+func (s *Screen) ReadKey() (KeyPress, error) {
+	return readKey(s.in)
+}
+
+// readKey reads a key press from a terminal in raw mode
+func readKey(in *bufio.Reader) (KeyPress, error) {
+	r, _, err := in.ReadRune()
+	if err != nil {
+		return KeyPress{}, err
+	}
+
+	switch r {
+	case '\r', '\n':
+		return KeyPress{Key: KeyEnter}, nil
+	case ' ':
+		return KeyPress{Key: KeySpace}, nil
+	case 3:
+		return KeyPress{Key: KeyInterrupt}, nil
+	case 0x1b:
+		// A lone escape, unless the rest of a sequence arrived with it
+		if in.Buffered() == 0 {
+			return KeyPress{Key: KeyEscape}, nil
+		}
+		return readEscape(in)
+	}
+	if r < 0x20 || r == 0x7f {
+		return KeyPress{Key: KeyUnknown}, nil
+	}
+	return KeyPress{Key: KeyRune, Rune: r}, nil
+}
+
+// readEscape reads the rest of an escape sequence such as an arrow key
+func readEscape(in *bufio.Reader) (KeyPress, error) {
+	introducer, err := in.ReadByte()
+	if err != nil {
+		return KeyPress{}, err
+	}
+	if introducer != '[' && introducer != 'O' {
+		return KeyPress{Key: KeyUnknown}, nil
+	}
+
+	// Parameters, then the final byte, e.g. "1;2C"
+	for {
+		b, err := in.ReadByte()
+		if err != nil {
+			return KeyPress{}, err
+		}
+		if b >= 0x40 && b <= 0x7e {
+			switch b {
+			case 'A':
+				return KeyPress{Key: KeyUp}, nil
+			case 'B':
+				return KeyPress{Key: KeyDown}, nil
+			case 'C':
+				return KeyPress{Key: KeyRight}, nil
+			case 'D':
+				return KeyPress{Key: KeyLeft}, nil
+			}
+			return KeyPress{Key: KeyUnknown}, nil
+		}
+	}
+}
+
+// Reverse returns text drawn in reverse video, for highlighting a selection.
+// It is drawn even without colors.
+func Reverse(text string) string {
+	return "\x1b[7m" + text + "\x1b[27m"
+}
@@ -5,6 +5,7 @@ import (
 	"strings"
 
 	"github.com/fatih/color"
+	"github.com/mrbooshehri/qix-go/internal/i18n"
 )
 
 // Table represents a formatted table
@@ -24,10 +25,14 @@ const (
 	AlignCenter
 )
 
-// NewTable creates a new table
+// NewTable creates a new table, with headers in the language of messages
 func NewTable(headers []string) *Table {
+	translated := make([]string, len(headers))
+	for i, header := range headers {
+		translated[i] = i18n.T(header)
+	}
 	return &Table{
-		Headers: headers,
+		Headers: asciiCells(translated),
 		Rows:    make([][]string, 0),
 		Colors:  make([][]*color.Color, 0),
 		Align:   make([]Alignment, len(headers)),
@@ -36,12 +41,12 @@ func NewTable(headers []string) *Table {
 
 // AddRow adds a row to the table
 func (t *Table) AddRow(cells ...string) {
-	t.Rows = append(t.Rows, cells)
+	t.Rows = append(t.Rows, asciiCells(cells))
 }
 
 // AddColoredRow adds a row with specific colors
 func (t *Table) AddColoredRow(cells []string, colors []*color.Color) {
-	t.Rows = append(t.Rows, cells)
+	t.Rows = append(t.Rows, asciiCells(cells))
 	t.Colors = append(t.Colors, colors)
 }
 
@@ -52,14 +57,17 @@ func (t *Table) SetColumnAlignment(col int, align Alignment) {
 	}
 }
 
-// Print prints the table to stdout
+// Print prints the table to stdout, in the selected table format
 func (t *Table) Print() {
 	if len(t.Headers) == 0 {
 		return
 	}
+	if t.printFormatted() {
+		return
+	}
 	
-	// Calculate column widths
-	widths := t.calculateColumnWidths()
+	// Calculate column widths; each column has a border and a space either side
+	widths := t.calculateColumnWidths(3*len(t.Headers) + 1)
 	
 	// Print top border
 	t.printBorder(widths, "┌", "┬", "┐")
@@ -88,12 +96,15 @@ func (t *Table) PrintSimple() {
 	if len(t.Headers) == 0 {
 		return
 	}
+	if t.printFormatted() {
+		return
+	}
 	
-	widths := t.calculateColumnWidths()
+	widths := t.calculateColumnWidths(2 * (len(t.Headers) - 1))
 	
 	// Print headers
 	for i, header := range t.Headers {
-		BoldCyan.Print(t.padCell(header, widths[i], t.Align[i]))
+		headerColor.Print(t.padCell(header, widths[i], t.Align[i]))
 		if i < len(t.Headers)-1 {
 			fmt.Print("  ")
 		}
@@ -136,12 +147,15 @@ func (t *Table) PrintCompact() {
 	if len(t.Headers) == 0 {
 		return
 	}
+	if t.printFormatted() {
+		return
+	}
 	
-	widths := t.calculateColumnWidths()
+	widths := t.calculateColumnWidths(len(t.Headers) - 1)
 	
 	// Print headers
 	for i, header := range t.Headers {
-		BoldCyan.Print(t.padCell(header, widths[i], t.Align[i]))
+		headerColor.Print(t.padCell(header, widths[i], t.Align[i]))
 		if i < len(t.Headers)-1 {
 			fmt.Print(" ")
 		}
@@ -169,20 +183,22 @@ func (t *Table) PrintCompact() {
 	}
 }
 
-// calculateColumnWidths calculates the width of each column
-func (t *Table) calculateColumnWidths() []int {
+// calculateColumnWidths calculates the width of each column, narrowing the
+// widest columns if the table and the given space between columns would not
+// fit on the terminal
+func (t *Table) calculateColumnWidths(spacing int) []int {
 	widths := make([]int, len(t.Headers))
 	
 	// Start with header widths
 	for i, header := range t.Headers {
-		widths[i] = len(header)
+		widths[i] = DisplayWidth(header)
 	}
 	
 	// Check row widths
 	for _, row := range t.Rows {
 		for i, cell := range row {
 			if i < len(widths) {
-				cellLen := len(stripAnsiCodes(cell))
+				cellLen := DisplayWidth(cell)
 				if cellLen > widths[i] {
 					widths[i] = cellLen
 				}
@@ -190,6 +206,27 @@ func (t *Table) calculateColumnWidths() []int {
 		}
 	}
 	
+	if terminalWidth == 0 {
+		return widths
+	}
+	total := spacing
+	for _, width := range widths {
+		total += width
+	}
+	for total > terminalWidth {
+		widest := 0
+		for i, width := range widths {
+			if width > widths[widest] {
+				widest = i
+			}
+		}
+		if widths[widest] <= minColumnWidth {
+			break
+		}
+		widths[widest]--
+		total--
+	}
+	
 	return widths
 }
 
@@ -214,7 +251,7 @@ func (t *Table) printRow(cells []string, widths []int, isHeader bool, colors []*
 			padded := t.padCell(cell, widths[i], t.Align[i])
 			
 			if isHeader {
-				BoldCyan.Print(padded)
+				headerColor.Print(padded)
 			} else if colors != nil && i < len(colors) {
 				colors[i].Print(padded)
 			} else {
@@ -229,9 +266,12 @@ func (t *Table) printRow(cells []string, widths []int, isHeader bool, colors []*
 
 // padCell pads a cell to the specified width with alignment
 func (t *Table) padCell(cell string, width int, align Alignment) string {
-	cellLen := len(stripAnsiCodes(cell))
+	cellLen := DisplayWidth(cell)
 	
-	if cellLen >= width {
+	if cellLen > width {
+		return TruncateWidth(cell, width)
+	}
+	if cellLen == width {
 		return cell
 	}
 	
@@ -249,25 +289,18 @@ func (t *Table) padCell(cell string, width int, align Alignment) string {
 	}
 }
 
-// stripAnsiCodes removes ANSI color codes for length calculation
-func stripAnsiCodes(s string) string {
-	// Simple implementation - in production you'd use a regex
-	// For now, assume no color codes in the string itself
-	return s
-}
-
 // PrintKeyValue prints a key-value table
 func PrintKeyValue(pairs map[string]string) {
 	maxKeyLen := 0
 	for key := range pairs {
-		if len(key) > maxKeyLen {
-			maxKeyLen = len(key)
+		if DisplayWidth(key) > maxKeyLen {
+			maxKeyLen = DisplayWidth(key)
 		}
 	}
 	
 	for key, value := range pairs {
 		BoldBlue.Print(key)
-		fmt.Print(strings.Repeat(" ", maxKeyLen-len(key)+2))
+		fmt.Print(strings.Repeat(" ", maxKeyLen-DisplayWidth(key)+2))
 		fmt.Println(value)
 	}
 }
@@ -281,16 +314,15 @@ func PrintColumns(items []string, columns int) {
 	// Calculate column width
 	maxWidth := 0
 	for _, item := range items {
-		if len(item) > maxWidth {
-			maxWidth = len(item)
+		if DisplayWidth(item) > maxWidth {
+			maxWidth = DisplayWidth(item)
 		}
 	}
 	columnWidth := maxWidth + 2
 	
 	// Print in columns
 	for i, item := range items {
-		padded := item + strings.Repeat(" ", columnWidth-len(item))
-		fmt.Print(padded)
+		fmt.Print(PadRight(item, columnWidth))
 		
 		if (i+1)%columns == 0 {
 			fmt.Println()
@@ -316,17 +348,13 @@ func PrintGrid(items []string, columns int, cellWidth int) {
 		}
 		
 		for j := i; j < end; j++ {
-			cell := items[j]
-			if len(cell) > cellWidth {
-				cell = cell[:cellWidth-3] + "..."
-			}
+			cell := TruncateWidth(items[j], cellWidth)
 			
-			padded := cell + strings.Repeat(" ", cellWidth-len(cell))
-			Cyan.Print("│ ")
-			fmt.Print(padded)
+			accentColor.Print("│ ")
+			fmt.Print(PadRight(cell, cellWidth))
 			fmt.Print(" ")
 		}
-		Cyan.Println("│")
+		accentColor.Println("│")
 	}
 }
 
@@ -0,0 +1,149 @@
+package ui
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/fatih/color"
+)
+
+// Table formats accepted by SetTableFormat
+const (
+	TableFormatText     = "text"
+	TableFormatMarkdown = "markdown"
+	TableFormatCSV      = "csv"
+)
+
+var (
+	// tableFormat is how tables are printed; TableFormatText draws them for the terminal
+	tableFormat = TableFormatText
+
+	// tableOut receives CSV tables while CSV output is enabled; nil otherwise
+	tableOut io.Writer
+
+	// tablesPrinted counts the tables printed, to separate them in CSV output
+	tablesPrinted int
+)
+
+// SetTableFormat selects how tables are printed: text, markdown (GitHub
+// flavored) or csv. Like JSON output, CSV sends everything but the tables to
+// standard error without colors, so standard output can be saved as a file.
+func SetTableFormat(format string) error {
+	switch strings.ToLower(format) {
+	case "", TableFormatText:
+		tableFormat = TableFormatText
+	case TableFormatMarkdown, "md":
+		tableFormat = TableFormatMarkdown
+	case TableFormatCSV:
+		tableFormat = TableFormatCSV
+		if jsonOut == nil {
+			tableOut = os.Stdout
+			os.Stdout = os.Stderr
+			color.Output = os.Stderr
+			color.NoColor = true
+		}
+	default:
+		return fmt.Errorf("unknown table format '%s' (use: text, markdown, csv)", format)
+	}
+	return nil
+}
+
+// TableFormat returns how tables are printed
+func TableFormat() string {
+	return tableFormat
+}
+
+// printFormatted prints the table in the selected export format, reporting
+// whether it did; tables are drawn for the terminal otherwise
+func (t *Table) printFormatted() bool {
+	switch tableFormat {
+	case TableFormatMarkdown:
+		t.PrintMarkdown()
+	case TableFormatCSV:
+		t.PrintCSV()
+	default:
+		return false
+	}
+	return true
+}
+
+// PrintMarkdown prints the table as a GitHub flavored Markdown table, with the
+// column alignment and without colors
+func (t *Table) PrintMarkdown() {
+	if len(t.Headers) == 0 {
+		return
+	}
+
+	out := os.Stdout
+	fmt.Fprintln(out, markdownRow(t.Headers))
+
+	separators := make([]string, len(t.Headers))
+	for i := range separators {
+		switch t.Align[i] {
+		case AlignRight:
+			separators[i] = "---:"
+		case AlignCenter:
+			separators[i] = ":---:"
+		default:
+			separators[i] = "---"
+		}
+	}
+	fmt.Fprintln(out, "| "+strings.Join(separators, " | ")+" |")
+
+	for _, row := range t.Rows {
+		fmt.Fprintln(out, markdownRow(t.fitRow(row)))
+	}
+	fmt.Fprintln(out)
+}
+
+// PrintCSV prints the table as CSV with a header row. Tables after the first
+// are separated from the one before by an empty line.
+func (t *Table) PrintCSV() {
+	if len(t.Headers) == 0 {
+		return
+	}
+
+	var out io.Writer = os.Stdout
+	if tableOut != nil {
+		out = tableOut
+	}
+	if tablesPrinted > 0 {
+		fmt.Fprintln(out)
+	}
+	tablesPrinted++
+
+	writer := csv.NewWriter(out)
+	writer.Write(plainCells(t.Headers))
+	for _, row := range t.Rows {
+		writer.Write(plainCells(t.fitRow(row)))
+	}
+	writer.Flush()
+}
+
+// fitRow pads or cuts a row to the number of columns
+func (t *Table) fitRow(row []string) []string {
+	cells := make([]string, len(t.Headers))
+	copy(cells, row)
+	return cells
+}
+
+// markdownRow formats cells as a Markdown table row, escaping pipes
+func markdownRow(cells []string) string {
+	escaped := plainCells(cells)
+	for i, cell := range escaped {
+		escaped[i] = strings.ReplaceAll(cell, "|", `\|`)
+	}
+	return "| " + strings.Join(escaped, " | ") + " |"
+}
+
+// plainCells returns cells without color codes, each on one line
+func plainCells(cells []string) []string {
+	plain := make([]string, len(cells))
+	for i, cell := range cells {
+		plain[i] = strings.NewReplacer("\r\n", " ", "\n", " ").Replace(stripAnsiCodes(cell))
+	}
+	return plain
+}
@@ -0,0 +1,70 @@
+package ui
+
+import (
+	"os"
+	"strconv"
+)
+
+const (
+	// designWidth is the terminal width fixed layout sizes were chosen for
+	designWidth = 80
+
+	// maxLayoutWidth caps how far layouts grow on wide terminals
+	maxLayoutWidth = 120
+
+	// minBarWidth keeps bars readable on narrow terminals
+	minBarWidth = 5
+
+	// minTitleWidth and minColumnWidth are as far as titles and table columns
+	// are shortened to fit the terminal
+	minTitleWidth  = 12
+	minColumnWidth = 6
+)
+
+// terminalWidth is the number of columns output can use, or 0 when it is not
+// going to a terminal (and COLUMNS is unset), in which case nothing is fitted
+var terminalWidth int
+
+// detectTerminalWidth measures standard output, falling back to COLUMNS
+func detectTerminalWidth() int {
+	if isTerminal(os.Stdout) {
+		if width, _ := terminalSize(os.Stdout); width > 0 {
+			return width
+		}
+	}
+	if width, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && width > 0 {
+		return width
+	}
+	return 0
+}
+
+// TerminalWidth returns the number of columns of the terminal output goes to,
+// or 0 if output is not going to a terminal
+func TerminalWidth() int {
+	return terminalWidth
+}
+
+// layoutWidth returns the width full-width elements such as separators span
+func layoutWidth() int {
+	switch {
+	case terminalWidth == 0:
+		return designWidth
+	case terminalWidth > maxLayoutWidth:
+		return maxLayoutWidth
+	default:
+		return terminalWidth
+	}
+}
+
+// ScaleWidth scales a width chosen for an 80-column terminal, such as a bar's,
+// to the terminal in use
+func ScaleWidth(width int) int {
+	if terminalWidth == 0 {
+		return width
+	}
+	scaled := width * layoutWidth() / designWidth
+	if scaled < minBarWidth {
+		scaled = minBarWidth
+	}
+	return scaled
+}
@@ -0,0 +1,22 @@
+//go:build !windows
+
+package ui
+
+import (
+	"os"
+	"syscall"
+	"unsafe"
+)
+
+// terminalSize returns the width and height of the terminal f is attached to,
+// or zeros
+func terminalSize(f *os.File) (cols, rows int) {
+	var size struct {
+		rows, cols, xpixel, ypixel uint16
+	}
+	_, _, errno := syscall.Syscall(syscall.SYS_IOCTL, f.Fd(), uintptr(syscall.TIOCGWINSZ), uintptr(unsafe.Pointer(&size)))
+	if errno != 0 {
+		return 0, 0
+	}
+	return int(size.cols), int(size.rows)
+}
@@ -0,0 +1,316 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/mrbooshehri/qix-go/pkg/qix/models"
+)
+
+// Theme is a named set of colors. The palette sets the colors commands draw with
+// by name (Red, Cyan, ...); the roles set the colors of statuses, priorities,
+// headers and messages, and may refer to palette colors by name.
+//
+// Colors are written as words: a color name (red, bright-red), a 256-color
+// number (0-255), a hex color (#268bd2), or the attributes bold, faint, italic,
+// underline and reverse; "none" is the terminal's default.
+type Theme struct {
+	Name    string
+	Palette map[string]string
+	Roles   map[string]string
+}
+
+// Palette colors, in the order they are listed
+var paletteNames = []string{"red", "green", "yellow", "blue", "cyan", "magenta", "white"}
+
+// Role colors, in the order they are listed
+var roleNames = []string{
+	"todo", "doing", "done", "blocked",
+	"high", "medium", "low",
+	"header", "subheader", "accent", "muted",
+	"success", "error", "warning", "info",
+}
+
+// defaultRoles are the roles of the built-in themes that only change the palette
+var defaultRoles = map[string]string{
+	"todo": "yellow", "doing": "cyan", "done": "green", "blocked": "red",
+	"high": "red", "medium": "yellow", "low": "green",
+	"header": "bold cyan", "subheader": "bold blue", "accent": "cyan", "muted": "faint",
+	"success": "green", "error": "red", "warning": "yellow", "info": "blue",
+}
+
+var builtinThemes = []Theme{
+	{
+		Name: "default",
+		Palette: map[string]string{
+			"red": "red", "green": "green", "yellow": "yellow", "blue": "blue",
+			"cyan": "cyan", "magenta": "magenta", "white": "white",
+		},
+		Roles: defaultRoles,
+	},
+	{
+		Name: "solarized",
+		Palette: map[string]string{
+			"red": "160", "green": "64", "yellow": "136", "blue": "33",
+			"cyan": "37", "magenta": "125", "white": "244",
+		},
+		Roles: mergeColors(defaultRoles, map[string]string{
+			"header": "bold blue", "subheader": "bold cyan", "accent": "blue", "muted": "240",
+		}),
+	},
+	{
+		Name: "monochrome",
+		Palette: map[string]string{
+			"red": "none", "green": "none", "yellow": "none", "blue": "none",
+			"cyan": "none", "magenta": "none", "white": "none",
+		},
+		Roles: map[string]string{
+			"todo": "none", "doing": "bold", "done": "faint", "blocked": "bold underline",
+			"high": "bold", "medium": "none", "low": "faint",
+			"header": "bold underline", "subheader": "bold", "accent": "none", "muted": "faint",
+			"success": "none", "error": "bold", "warning": "bold", "info": "none",
+		},
+	},
+}
+
+var (
+	currentTheme Theme
+
+	// Role colors of the current theme
+	statusColors   map[models.TaskStatus]*color.Color
+	priorityColors map[models.Priority]*color.Color
+	headerColor    *color.Color
+	subHeaderColor *color.Color
+	accentColor    *color.Color
+	successColor   *color.Color
+	errorColor     *color.Color
+	warningColor   *color.Color
+	infoColor      *color.Color
+)
+
+func init() {
+	if err := SetTheme("default", nil); err != nil {
+		panic(err)
+	}
+}
+
+// Themes returns the built-in themes, sorted by name
+func Themes() []Theme {
+	themes := append([]Theme(nil), builtinThemes...)
+	sort.Slice(themes, func(i, j int) bool { return themes[i].Name < themes[j].Name })
+	return themes
+}
+
+// CurrentTheme returns the theme in use, including any overridden colors
+func CurrentTheme() Theme {
+	return currentTheme
+}
+
+// SetTheme switches to a built-in theme, with colors overridden by palette or
+// role name. Nothing changes if the theme or a color is unknown.
+func SetTheme(name string, overrides map[string]string) error {
+	var theme Theme
+	found := false
+	for _, builtin := range builtinThemes {
+		if builtin.Name == strings.ToLower(name) {
+			theme, found = builtin, true
+			break
+		}
+	}
+	if !found {
+		names := make([]string, 0, len(builtinThemes))
+		for _, builtin := range Themes() {
+			names = append(names, builtin.Name)
+		}
+		return fmt.Errorf("unknown theme '%s' (available: %s)", name, strings.Join(names, ", "))
+	}
+
+	theme.Palette = mergeColors(theme.Palette, nil)
+	theme.Roles = mergeColors(theme.Roles, nil)
+	for key, spec := range overrides {
+		key = strings.ToLower(key)
+		switch {
+		case theme.Palette[key] != "":
+			theme.Palette[key] = spec
+		case theme.Roles[key] != "":
+			theme.Roles[key] = spec
+		default:
+			return fmt.Errorf("unknown color 'color.%s' (see 'qix theme --help' for the names)", key)
+		}
+	}
+
+	colors, err := theme.colors()
+	if err != nil {
+		return err
+	}
+	statuses := make(map[models.TaskStatus]*color.Color)
+	for _, def := range models.Statuses() {
+		if statuses[def.Name], err = theme.pick(colors, string(def.Name), def.Color); err != nil {
+			return fmt.Errorf("status %s: %v", def.Name, err)
+		}
+	}
+	priorities := make(map[models.Priority]*color.Color)
+	for _, def := range models.Priorities() {
+		if priorities[def.Name], err = theme.pick(colors, string(def.Name), def.Color); err != nil {
+			return fmt.Errorf("priority %s: %v", def.Name, err)
+		}
+	}
+
+	Red, Green, Yellow = colors["red"], colors["green"], colors["yellow"]
+	Blue, Cyan, Magenta, White = colors["blue"], colors["cyan"], colors["magenta"], colors["white"]
+	BoldRed = bold(theme.Palette["red"])
+	BoldGreen = bold(theme.Palette["green"])
+	BoldYellow = bold(theme.Palette["yellow"])
+	BoldBlue = bold(theme.Palette["blue"])
+	BoldCyan = bold(theme.Palette["cyan"])
+	BoldMagenta = bold(theme.Palette["magenta"])
+	Dim = colors["muted"]
+
+	statusColors, priorityColors = statuses, priorities
+	headerColor = colors["header"]
+	subHeaderColor = colors["subheader"]
+	accentColor = colors["accent"]
+	successColor = colors["success"]
+	errorColor = colors["error"]
+	warningColor = colors["warning"]
+	infoColor = colors["info"]
+
+	currentTheme = theme
+	return nil
+}
+
+// Sample returns the theme's role names, each drawn in its own color
+func (t Theme) Sample() string {
+	colors, err := t.colors()
+	if err != nil {
+		return err.Error()
+	}
+	words := make([]string, 0, len(roleNames))
+	for _, name := range roleNames {
+		words = append(words, colors[name].Sprint(name))
+	}
+	return strings.Join(words, " ")
+}
+
+// pick returns the color of a status or priority: spec, which may name a
+// palette color or role, or else the role named after it
+func (t Theme) pick(colors map[string]*color.Color, name, spec string) (*color.Color, error) {
+	if spec == "" {
+		if c, ok := colors[name]; ok {
+			return c, nil
+		}
+		return colors["white"], nil
+	}
+	if c, ok := colors[strings.ToLower(spec)]; ok {
+		return c, nil
+	}
+	attrs, err := parseColor(spec, t.Palette)
+	if err != nil {
+		return nil, err
+	}
+	return color.New(attrs...), nil
+}
+
+// colors parses every palette and role color of the theme
+func (t Theme) colors() (map[string]*color.Color, error) {
+	colors := make(map[string]*color.Color, len(t.Palette)+len(t.Roles))
+	for name, spec := range t.Palette {
+		attrs, err := parseColor(spec, nil)
+		if err != nil {
+			return nil, fmt.Errorf("color.%s: %v", name, err)
+		}
+		colors[name] = color.New(attrs...)
+	}
+	for name, spec := range t.Roles {
+		attrs, err := parseColor(spec, t.Palette)
+		if err != nil {
+			return nil, fmt.Errorf("color.%s: %v", name, err)
+		}
+		colors[name] = color.New(attrs...)
+	}
+	return colors, nil
+}
+
+var colorAttributes = map[string]color.Attribute{
+	"bold":      color.Bold,
+	"faint":     color.Faint,
+	"dim":       color.Faint,
+	"italic":    color.Italic,
+	"underline": color.Underline,
+	"reverse":   color.ReverseVideo,
+}
+
+var colorNames = []string{"black", "red", "green", "yellow", "blue", "magenta", "cyan", "white"}
+
+// parseColor turns a color written as words into terminal attributes. Words that
+// name a palette color stand for that color.
+func parseColor(spec string, palette map[string]string) ([]color.Attribute, error) {
+	attrs := make([]color.Attribute, 0)
+	for _, word := range strings.Fields(strings.ToLower(spec)) {
+		if paletteSpec, ok := palette[word]; ok {
+			paletteAttrs, err := parseColor(paletteSpec, nil)
+			if err != nil {
+				return nil, err
+			}
+			attrs = append(attrs, paletteAttrs...)
+			continue
+		}
+		if attr, ok := colorAttributes[word]; ok {
+			attrs = append(attrs, attr)
+			continue
+		}
+		if word == "none" {
+			continue
+		}
+		if fg, ok := namedColor(word); ok {
+			attrs = append(attrs, fg)
+			continue
+		}
+		if n, err := strconv.Atoi(word); err == nil && n >= 0 && n <= 255 {
+			attrs = append(attrs, 38, 5, color.Attribute(n))
+			continue
+		}
+		if rgb, err := strconv.ParseUint(strings.TrimPrefix(word, "#"), 16, 32); err == nil && len(word) == 7 && word[0] == '#' {
+			attrs = append(attrs, 38, 2, color.Attribute(rgb>>16), color.Attribute(rgb>>8&0xff), color.Attribute(rgb&0xff))
+			continue
+		}
+		return nil, fmt.Errorf("unknown color or attribute '%s'", word)
+	}
+	return attrs, nil
+}
+
+// namedColor returns the foreground attribute of a color name, optionally
+// prefixed with "bright-"
+func namedColor(word string) (color.Attribute, bool) {
+	base := color.FgBlack
+	if name := strings.TrimPrefix(word, "bright-"); name != word {
+		base, word = color.FgHiBlack, name
+	}
+	for i, name := range colorNames {
+		if name == word {
+			return base + color.Attribute(i), true
+		}
+	}
+	return 0, false
+}
+
+// bold returns a palette color in bold
+func bold(spec string) *color.Color {
+	attrs, _ := parseColor(spec, nil)
+	return color.New(append(attrs, color.Bold)...)
+}
+
+// mergeColors returns a copy of base with the colors in overrides replaced
+func mergeColors(base, overrides map[string]string) map[string]string {
+	merged := make(map[string]string, len(base))
+	for name, spec := range base {
+		merged[name] = spec
+	}
+	for name, spec := range overrides {
+		merged[name] = spec
+	}
+	return merged
+}
@@ -0,0 +1,40 @@
+package ui
+
+import (
+	"errors"
+	"os"
+	"strings"
+)
+
+// verbose is set by --verbose: errors are printed with the errors they wrap
+var verbose bool
+
+// SetVerbose sets whether PrintError also prints the full chain of each error
+// it is given, on standard error
+func SetVerbose(enabled bool) {
+	verbose = enabled
+}
+
+// printErrorChains prints each error among args and the errors it wraps, with
+// their types, one per line and indented by depth
+func printErrorChains(args []interface{}) {
+	for _, arg := range args {
+		if err, ok := arg.(error); ok {
+			printErrorChain(err, 1)
+		}
+	}
+}
+
+func printErrorChain(err error, depth int) {
+	Dim.Fprintf(os.Stderr, "%s%T: %v\n", strings.Repeat("  ", depth), err, err)
+	switch wrapped := err.(type) {
+	case interface{ Unwrap() []error }:
+		for _, inner := range wrapped.Unwrap() {
+			printErrorChain(inner, depth+1)
+		}
+	default:
+		if inner := errors.Unwrap(err); inner != nil {
+			printErrorChain(inner, depth+1)
+		}
+	}
+}
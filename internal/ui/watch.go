@@ -0,0 +1,47 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/mrbooshehri/qix-go/internal/i18n"
+)
+
+// Watch clears the terminal and calls render every interval until the user
+// presses Ctrl+C, for a live view of a listing or report. It draws on the
+// terminal's alternate screen, so the terminal is as it was afterwards, and
+// fails if standard output is not a terminal.
+func Watch(interval time.Duration, render func()) error {
+	if !isTerminal(os.Stdout) || jsonOut != nil || porcelain || tableFormat == TableFormatCSV {
+		return errors.New("--watch needs a terminal")
+	}
+
+	interrupt := make(chan os.Signal, 1)
+	signal.Notify(interrupt, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(interrupt)
+
+	// Alternate screen, cursor hidden
+	fmt.Print("\x1b[?1049h\x1b[?25l")
+	defer fmt.Print("\x1b[?25h\x1b[?1049l")
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		// The terminal may have been resized since the last time
+		terminalWidth = detectTerminalWidth()
+
+		fmt.Print("\x1b[H\x1b[2J")
+		render()
+		Dim.Print(i18n.Sprintf("Every %s, updated %s. Ctrl+C to quit.", interval, time.Now().Format("15:04:05")))
+
+		select {
+		case <-ticker.C:
+		case <-interrupt:
+			return nil
+		}
+	}
+}
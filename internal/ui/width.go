@@ -0,0 +1,100 @@
+package ui
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/width"
+)
+
+// ansiPattern matches terminal escape sequences such as colors
+var ansiPattern = regexp.MustCompile(`\x1b\[[0-9;?]*[ -/]*[@-~]`)
+
+// stripAnsiCodes removes ANSI color codes for length calculation
+func stripAnsiCodes(s string) string {
+	if !strings.Contains(s, "\x1b") {
+		return s
+	}
+	return ansiPattern.ReplaceAllString(s, "")
+}
+
+// DisplayWidth returns the number of terminal columns s takes up: color codes
+// take none, and emoji and East Asian wide characters take two
+func DisplayWidth(s string) int {
+	total := 0
+	last := 0       // Width of the previous character
+	joined := false // The previous character was a zero width joiner
+	for _, r := range stripAnsiCodes(s) {
+		switch {
+		case r == 0xFE0F:
+			// Emoji presentation widens a character drawn as text by default (⏱️)
+			if last == 1 {
+				total++
+				last = 2
+			}
+			continue
+		case r == 0x200D:
+			joined = true
+			continue
+		case joined:
+			// Joined emoji (👨‍👩‍👧) are drawn as one
+			joined = false
+			continue
+		}
+		last = runeWidth(r)
+		total += last
+	}
+	return total
+}
+
+// runeWidth returns the number of terminal columns one character takes up
+func runeWidth(r rune) int {
+	switch {
+	case r < 0x20 || r == 0x7F:
+		return 0
+	case r < 0x300:
+		return 1
+	case unicode.In(r, unicode.Mn, unicode.Me, unicode.Cf):
+		return 0
+	case r >= 0x1F3FB && r <= 0x1F3FF:
+		// Skin tone modifiers merge into the emoji before them
+		return 0
+	}
+	switch width.LookupRune(r).Kind() {
+	case width.EastAsianWide, width.EastAsianFullwidth:
+		return 2
+	}
+	return 1
+}
+
+// PadRight pads s with spaces to the given display width
+func PadRight(s string, w int) string {
+	if pad := w - DisplayWidth(s); pad > 0 {
+		return s + strings.Repeat(" ", pad)
+	}
+	return s
+}
+
+// TruncateWidth shortens s to at most w display columns, ending it with "..."
+// if anything was cut. Color codes are dropped from shortened strings.
+func TruncateWidth(s string, w int) string {
+	if DisplayWidth(s) <= w {
+		return s
+	}
+	if w <= 3 {
+		return strings.Repeat(".", w)
+	}
+
+	var b strings.Builder
+	used := 0
+	for _, r := range stripAnsiCodes(s) {
+		rw := runeWidth(r)
+		if used+rw > w-3 {
+			break
+		}
+		b.WriteRune(r)
+		used += rw
+	}
+	return b.String() + "..."
+}
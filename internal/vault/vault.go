@@ -0,0 +1,502 @@
+// Package vault writes projects as folders of Markdown notes with YAML
+// frontmatter, for note tools such as Obsidian and Notion. A project gets a
+// note of its own, each module a folder with a note, and each task a note in
+// its module's folder. Notes link to each other, so the vault can be browsed
+// like the project.
+package vault
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/mrbooshehri/qix-go/pkg/qix/models"
+)
+
+// ManifestFile records the notes a sync wrote, in the project's folder
+const ManifestFile = ".qix-vault.json"
+
+// Options changes how notes are written
+type Options struct {
+	WikiLinks   bool   // [[Note|title]] links, as Obsidian uses, instead of Markdown links
+	JiraBaseURL string // Links Jira issues if set
+}
+
+// Note is a Markdown file of the vault
+type Note struct {
+	Path    string // Relative to the project's folder, slash-separated
+	Content []byte
+}
+
+// Notes returns the notes of a project
+func Notes(project *models.Project, opts Options) []Note {
+	b := &builder{
+		project: project,
+		opts:    opts,
+		paths:   make(map[string]string),
+		modules: make(map[string]string),
+		tasks:   make(map[string]models.Task),
+		sprints: make(map[string][]string),
+	}
+	b.place()
+
+	notes := []Note{{Path: b.projectPath(), Content: b.projectNote()}}
+	for _, module := range project.Modules {
+		notes = append(notes, Note{Path: b.modulePath(module.Name), Content: b.moduleNote(module)})
+	}
+	for _, task := range project.GetAllTasks() {
+		notes = append(notes, Note{Path: b.paths[task.ID], Content: b.taskNote(task)})
+	}
+	return notes
+}
+
+// builder renders the notes of one project
+type builder struct {
+	project *models.Project
+	opts    Options
+	paths   map[string]string // Note path by task ID
+	modules map[string]string // Module name by task ID
+	tasks   map[string]models.Task
+	sprints map[string][]string // Sprint names by task ID
+}
+
+// place decides the path of every note
+func (b *builder) place() {
+	for _, task := range b.project.Tasks {
+		b.paths[task.ID] = fileName(task.Title) + " (" + task.ID + ").md"
+		b.tasks[task.ID] = task
+	}
+	for _, module := range b.project.Modules {
+		dir := fileName(module.Name)
+		for _, task := range module.Tasks {
+			b.paths[task.ID] = dir + "/" + fileName(task.Title) + " (" + task.ID + ").md"
+			b.modules[task.ID] = module.Name
+			b.tasks[task.ID] = task
+		}
+	}
+	for _, sprint := range b.project.Sprints {
+		for _, id := range sprint.TaskIDs {
+			b.sprints[id] = append(b.sprints[id], sprint.Name)
+		}
+	}
+}
+
+func (b *builder) projectPath() string {
+	return fileName(b.project.Name) + ".md"
+}
+
+func (b *builder) modulePath(name string) string {
+	return fileName(name) + "/" + fileName(name) + ".md"
+}
+
+// link returns a link from the note at from to the note at to
+func (b *builder) link(from, to, title string) string {
+	if b.opts.WikiLinks {
+		return strings.TrimSuffix(b.wikiLink(to), "]]") + "|" + strings.ReplaceAll(title, "|", "-") + "]]"
+	}
+	rel, err := filepath.Rel(path.Dir(from), to)
+	if err != nil {
+		rel = to
+	}
+	escaped := (&url.URL{Path: filepath.ToSlash(rel)}).EscapedPath()
+	return "[" + strings.NewReplacer("[", "\\[", "]", "\\]").Replace(title) + "](" + escaped + ")"
+}
+
+// wikiLink is a link for frontmatter, where Obsidian only follows wiki links.
+// It starts at the project's folder, which Obsidian finds anywhere in the vault.
+func (b *builder) wikiLink(to string) string {
+	return "[[" + fileName(b.project.Name) + "/" + strings.TrimSuffix(to, ".md") + "]]"
+}
+
+func (b *builder) taskLink(from, id string) string {
+	task, ok := b.tasks[id]
+	if !ok {
+		return id
+	}
+	return b.link(from, b.paths[id], task.Title)
+}
+
+func (b *builder) projectNote() []byte {
+	p := b.project
+	self := b.projectPath()
+	counts := p.CountByStatus()
+
+	fm := &frontmatter{}
+	fm.add("type", "project")
+	fm.add("name", p.Name)
+	fm.add("description", p.Description)
+	fm.add("tags", p.Tags)
+	fm.add("deadline", p.Deadline)
+	fm.add("tasks", len(p.GetAllTasks()))
+	fm.add("done", models.CountDone(counts))
+	fm.add("completion", round(p.GetCompletionPercentage()))
+	fm.add("estimated_hours", round(p.CalculateTotalEstimated()))
+	fm.add("actual_hours", round(p.CalculateTotalActual()))
+	fm.add("created", p.CreatedAt.Format("2006-01-02"))
+
+	var out bytes.Buffer
+	fm.write(&out)
+	fmt.Fprintf(&out, "# %s\n\n", p.Name)
+	if p.Description != "" {
+		fmt.Fprintf(&out, "%s\n\n", p.Description)
+	}
+	fmt.Fprintf(&out, "%d of %d task(s) done (%.1f%%), %.2fh of %.2fh logged.\n\n",
+		models.CountDone(counts), len(p.GetAllTasks()), p.GetCompletionPercentage(), p.CalculateTotalActual(), p.CalculateTotalEstimated())
+
+	if len(p.Modules) > 0 {
+		out.WriteString("## Modules\n\n")
+		for _, module := range p.Modules {
+			done := 0
+			for _, task := range module.Tasks {
+				if task.Status.IsDone() {
+					done++
+				}
+			}
+			fmt.Fprintf(&out, "- %s: %d/%d done\n", b.link(self, b.modulePath(module.Name), module.Name), done, len(module.Tasks))
+		}
+		out.WriteString("\n")
+	}
+	if len(p.Tasks) > 0 {
+		out.WriteString("## Tasks\n\n")
+		b.writeTaskList(&out, self, p.Tasks)
+	}
+	if len(p.Sprints) > 0 {
+		out.WriteString("## Sprints\n\n| Sprint | Start | End | Tasks |\n| --- | --- | --- | --- |\n")
+		for _, sprint := range p.Sprints {
+			links := make([]string, 0, len(sprint.TaskIDs))
+			for _, id := range sprint.TaskIDs {
+				links = append(links, b.taskLink(self, id))
+			}
+			fmt.Fprintf(&out, "| %s | %s | %s | %s |\n", cell(sprint.Name), sprint.StartDate, sprint.EndDate, cell(strings.Join(links, ", ")))
+		}
+		out.WriteString("\n")
+	}
+	return out.Bytes()
+}
+
+func (b *builder) moduleNote(module models.Module) []byte {
+	self := b.modulePath(module.Name)
+	done, estimated, actual := 0, 0.0, 0.0
+	for _, task := range module.Tasks {
+		if task.Status.IsDone() {
+			done++
+		}
+		estimated += task.EstimatedHours
+		actual += task.CalculateActualHours()
+	}
+
+	fm := &frontmatter{}
+	fm.add("type", "module")
+	fm.add("name", module.Name)
+	fm.add("project", b.wikiLink(b.projectPath()))
+	fm.add("description", module.Description)
+	fm.add("tags", module.Tags)
+	fm.add("tasks", len(module.Tasks))
+	fm.add("done", done)
+	fm.add("estimated_hours", round(estimated))
+	fm.add("actual_hours", round(actual))
+	fm.add("created", module.CreatedAt.Format("2006-01-02"))
+
+	var out bytes.Buffer
+	fm.write(&out)
+	fmt.Fprintf(&out, "# %s\n\n", module.Name)
+	fmt.Fprintf(&out, "Module of %s.\n\n", b.link(self, b.projectPath(), b.project.Name))
+	if module.Description != "" {
+		fmt.Fprintf(&out, "%s\n\n", module.Description)
+	}
+	if len(module.Tasks) > 0 {
+		out.WriteString("## Tasks\n\n")
+		b.writeTaskList(&out, self, module.Tasks)
+	}
+	return out.Bytes()
+}
+
+// writeTaskList writes tasks as a checklist, open ones first
+func (b *builder) writeTaskList(out *bytes.Buffer, from string, tasks []models.Task) {
+	sorted := append([]models.Task(nil), tasks...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return !sorted[i].Status.IsDone() && sorted[j].Status.IsDone()
+	})
+	for _, task := range sorted {
+		box := " "
+		if task.Status.IsDone() {
+			box = "x"
+		}
+		fmt.Fprintf(out, "- [%s] %s (%s, %s)\n", box, b.link(from, b.paths[task.ID], task.Title), task.Status, task.Priority)
+	}
+	out.WriteString("\n")
+}
+
+func (b *builder) taskNote(task models.Task) []byte {
+	self := b.paths[task.ID]
+	module := b.modules[task.ID]
+
+	fm := &frontmatter{}
+	fm.add("type", "task")
+	fm.add("id", task.ID)
+	fm.add("title", task.Title)
+	fm.add("project", b.wikiLink(b.projectPath()))
+	if module != "" {
+		fm.add("module", b.wikiLink(b.modulePath(module)))
+	}
+	fm.add("status", string(task.Status))
+	fm.add("priority", string(task.Priority))
+	fm.add("assignee", task.Assignee)
+	fm.add("tags", task.Tags)
+	fm.add("estimated_hours", round(task.EstimatedHours))
+	fm.add("actual_hours", round(task.CalculateActualHours()))
+	fm.add("due", task.DueDate)
+	if task.ParentID != "" {
+		if parent, ok := b.paths[task.ParentID]; ok {
+			fm.add("parent", b.wikiLink(parent))
+		}
+	}
+	depends := make([]string, 0, len(task.Dependencies))
+	for _, id := range task.Dependencies {
+		if dep, ok := b.paths[id]; ok {
+			depends = append(depends, b.wikiLink(dep))
+		}
+	}
+	fm.add("depends_on", depends)
+	fm.add("sprints", b.sprints[task.ID])
+	fm.add("jira", task.JiraIssue)
+	if task.JiraIssue != "" && b.opts.JiraBaseURL != "" {
+		fm.add("jira_url", strings.TrimRight(b.opts.JiraBaseURL, "/")+"/"+task.JiraIssue)
+	}
+	fm.add("created", task.CreatedAt.Format("2006-01-02"))
+	fm.add("updated", task.UpdatedAt.Format("2006-01-02"))
+
+	var out bytes.Buffer
+	fm.write(&out)
+	fmt.Fprintf(&out, "# %s\n\n", task.Title)
+	where := b.link(self, b.projectPath(), b.project.Name)
+	if module != "" {
+		where = b.link(self, b.modulePath(module), module) + " in " + where
+	}
+	fmt.Fprintf(&out, "Task %s in %s: **%s**, %s priority", task.ID, where, task.Status, task.Priority)
+	if task.DueDate != "" {
+		fmt.Fprintf(&out, ", due %s", task.DueDate)
+	}
+	out.WriteString(".\n\n")
+	if task.Description != "" {
+		fmt.Fprintf(&out, "%s\n\n", task.Description)
+	}
+
+	if task.ParentID != "" {
+		fmt.Fprintf(&out, "Subtask of %s.\n\n", b.taskLink(self, task.ParentID))
+	}
+	children := make([]models.Task, 0)
+	for _, other := range b.project.GetAllTasks() {
+		if other.ParentID == task.ID {
+			children = append(children, other)
+		}
+	}
+	if len(children) > 0 {
+		out.WriteString("## Subtasks\n\n")
+		b.writeTaskList(&out, self, children)
+	}
+	if len(task.Dependencies) > 0 {
+		out.WriteString("## Depends on\n\n")
+		for _, id := range task.Dependencies {
+			fmt.Fprintf(&out, "- %s\n", b.taskLink(self, id))
+		}
+		out.WriteString("\n")
+	}
+	if len(task.TimeEntries) > 0 {
+		out.WriteString("## Time log\n\n| Date | Hours |\n| --- | ---: |\n")
+		for _, entry := range task.TimeEntries {
+			fmt.Fprintf(&out, "| %s | %.2f |\n", entry.Date, entry.Hours)
+		}
+		out.WriteString("\n")
+	}
+	if len(task.Commits) > 0 {
+		out.WriteString("## Commits\n\n")
+		for _, commit := range task.Commits {
+			fmt.Fprintf(&out, "- `%s` %s\n", shortHash(commit.Hash), commit.Subject)
+		}
+		out.WriteString("\n")
+	}
+	return out.Bytes()
+}
+
+// frontmatter is YAML written in the order fields were added; empty values
+// are left out
+type frontmatter struct {
+	lines []string
+}
+
+func (f *frontmatter) add(key string, value interface{}) {
+	switch v := value.(type) {
+	case string:
+		if v != "" {
+			f.lines = append(f.lines, key+": "+yamlString(v))
+		}
+	case []string:
+		if len(v) > 0 {
+			f.lines = append(f.lines, key+":")
+			for _, item := range v {
+				f.lines = append(f.lines, "  - "+yamlString(item))
+			}
+		}
+	case float64:
+		if v != 0 {
+			f.lines = append(f.lines, fmt.Sprintf("%s: %g", key, v))
+		}
+	default:
+		f.lines = append(f.lines, fmt.Sprintf("%s: %v", key, v))
+	}
+}
+
+func (f *frontmatter) write(out *bytes.Buffer) {
+	out.WriteString("---\n")
+	for _, line := range f.lines {
+		out.WriteString(line + "\n")
+	}
+	out.WriteString("---\n\n")
+}
+
+// yamlString quotes a string unless YAML reads it back unchanged as is
+func yamlString(s string) string {
+	plain := s == strings.TrimSpace(s) && !strings.ContainsAny(s, ":#[]{},&*!|>'\"%@`\n") && !strings.HasPrefix(s, "-")
+	if plain {
+		switch strings.ToLower(s) {
+		case "true", "false", "yes", "no", "on", "off", "null", "~":
+			plain = false
+		}
+	}
+	if plain {
+		if _, err := fmt.Sscanf(s, "%g", new(float64)); err == nil {
+			plain = false
+		}
+	}
+	if plain {
+		return s
+	}
+	// JSON strings are valid double-quoted YAML
+	data, _ := json.Marshal(s)
+	return string(data)
+}
+
+// fileName makes a title safe as a file name on every platform and in links
+func fileName(title string) string {
+	name := strings.Map(func(r rune) rune {
+		switch {
+		case r < 32, strings.ContainsRune(`/\:*?"<>|#^[]`, r):
+			return '-'
+		}
+		return r
+	}, title)
+	name = strings.Trim(strings.TrimSpace(name), ".")
+	if runes := []rune(name); len(runes) > 80 {
+		name = strings.TrimSpace(string(runes[:80]))
+	}
+	if name == "" {
+		name = "untitled"
+	}
+	return name
+}
+
+// cell escapes text for a Markdown table cell
+func cell(s string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(s, "|", "\\|"), "\n", " ")
+}
+
+func round(v float64) float64 {
+	return float64(int64(v*100+0.5)) / 100
+}
+
+func shortHash(hash string) string {
+	if len(hash) > 8 {
+		return hash[:8]
+	}
+	return hash
+}
+
+// Result is what a Sync changed, as paths relative to the project's folder
+type Result struct {
+	Dir       string   `json:"dir"` // The project's folder
+	Written   []string `json:"written"`
+	Removed   []string `json:"removed"`
+	Unchanged int      `json:"unchanged"`
+}
+
+// manifest is the content hash of each note a sync wrote
+type manifest struct {
+	Project string            `json:"project"`
+	Notes   map[string]string `json:"notes"`
+}
+
+// Sync writes a project's notes to its folder under dir, only rewriting
+// notes whose content changed. Notes an earlier sync wrote that are no longer
+// part of the project are removed; other files in the folder are left alone.
+func Sync(dir, projectName string, notes []Note) (Result, error) {
+	root := filepath.Join(dir, fileName(projectName))
+	result := Result{Dir: root, Written: make([]string, 0), Removed: make([]string, 0)}
+	if err := os.MkdirAll(root, 0755); err != nil {
+		return result, err
+	}
+
+	previous := manifest{Notes: make(map[string]string)}
+	manifestPath := filepath.Join(root, ManifestFile)
+	if data, err := os.ReadFile(manifestPath); err == nil {
+		if err := json.Unmarshal(data, &previous); err != nil {
+			return result, fmt.Errorf("invalid %s: %w", manifestPath, err)
+		}
+		if previous.Notes == nil {
+			previous.Notes = make(map[string]string)
+		}
+	}
+
+	current := manifest{Project: projectName, Notes: make(map[string]string, len(notes))}
+	for _, note := range notes {
+		current.Notes[note.Path] = contentHash(note.Content)
+		target := filepath.Join(root, filepath.FromSlash(note.Path))
+		if existing, err := os.ReadFile(target); err == nil && bytes.Equal(existing, note.Content) {
+			result.Unchanged++
+			continue
+		}
+		if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+			return result, err
+		}
+		if err := os.WriteFile(target, note.Content, 0644); err != nil {
+			return result, err
+		}
+		result.Written = append(result.Written, note.Path)
+	}
+
+	for notePath := range previous.Notes {
+		if _, ok := current.Notes[notePath]; ok {
+			continue
+		}
+		target := filepath.Join(root, filepath.FromSlash(notePath))
+		if err := os.Remove(target); err != nil && !os.IsNotExist(err) {
+			return result, err
+		}
+		result.Removed = append(result.Removed, notePath)
+		// Drop the module folder once its last note is gone
+		if parent := filepath.Dir(target); parent != root {
+			os.Remove(parent)
+		}
+	}
+	sort.Strings(result.Written)
+	sort.Strings(result.Removed)
+
+	data, err := json.MarshalIndent(current, "", "  ")
+	if err != nil {
+		return result, err
+	}
+	return result, os.WriteFile(manifestPath, data, 0644)
+}
+
+func contentHash(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
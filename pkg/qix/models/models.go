@@ -0,0 +1,492 @@
+// Package models defines the qix data model: projects, their modules and
+// tasks, time entries, sprints and the files qix keeps them in.
+package models
+
+import (
+	"strings"
+	"time"
+)
+
+// Project represents a QIX project
+type Project struct {
+	SchemaVersion int                `json:"schema_version"`
+	Name          string             `json:"name"`
+	Description   string             `json:"description"`
+	Tags          []string           `json:"tags"`
+	Modules       []Module           `json:"modules"`
+	Tasks         []Task             `json:"tasks"`
+	Sprints       []Sprint           `json:"sprints"`
+	Deadline      string             `json:"deadline,omitempty"` // YYYY-MM-DD
+	Budget        float64            `json:"budget,omitempty"`
+	HourlyRate    float64            `json:"hourly_rate,omitempty"`
+	Rates         map[string]float64 `json:"rates,omitempty"` // Per-assignee hourly rates
+	CreatedAt     time.Time          `json:"created_at"`
+}
+
+// Module represents a sub-component of a project
+type Module struct {
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	Tags        []string  `json:"tags"`
+	Tasks       []Task    `json:"tasks"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// Task represents a work item
+type Task struct {
+	ID             string         `json:"id"`
+	Title          string         `json:"title"`
+	Description    string         `json:"description"`
+	Status         TaskStatus     `json:"status"`
+	Priority       Priority       `json:"priority"`
+	EstimatedHours float64        `json:"estimated_hours"`
+	Tags           []string       `json:"tags"`
+	Dependencies   []string       `json:"dependencies"`
+	JiraIssue      string         `json:"jira_issue,omitempty"`
+	Jira           *JiraInfo      `json:"jira,omitempty"` // Pulled by 'jira sync'; read-only
+	Assignee       string         `json:"assignee,omitempty"`
+	ParentID       string         `json:"parent_id,omitempty"`
+	TimeEntries    []TimeEntry    `json:"time_entries"`
+	Commits        []CommitRef    `json:"commits,omitempty"` // Recorded by the post-commit hook
+	Branch         string         `json:"branch,omitempty"`  // Created by 'task branch'
+	Recurrence     *Recurrence    `json:"recurrence,omitempty"`
+	DueDate        string         `json:"due_date,omitempty"` // YYYY-MM-DD
+	StatusHistory  []StatusChange `json:"status_history,omitempty"`
+	CreatedAt      time.Time      `json:"created_at"`
+	UpdatedAt      time.Time      `json:"updated_at"`
+}
+
+// JiraInfo is what a task's Jira issue looked like when it was last synced
+type JiraInfo struct {
+	Key            string    `json:"key"`
+	Summary        string    `json:"summary"`
+	Status         string    `json:"status"`
+	StatusCategory string    `json:"status_category"` // new, indeterminate or done
+	Assignee       string    `json:"assignee,omitempty"`
+	Updated        time.Time `json:"updated"` // When the issue last changed in Jira
+}
+
+// CommitRef is a git commit made for a task
+type CommitRef struct {
+	Hash    string    `json:"hash"`
+	Subject string    `json:"subject"`
+	Repo    string    `json:"repo"` // Name of the repository's directory
+	At      time.Time `json:"at"`
+}
+
+// TaskStatus represents the state of a task
+type TaskStatus string
+
+const (
+	StatusTodo    TaskStatus = "todo"
+	StatusDoing   TaskStatus = "doing"
+	StatusDone    TaskStatus = "done"
+	StatusBlocked TaskStatus = "blocked"
+)
+
+// StatusChange records when a task entered a status
+type StatusChange struct {
+	Status TaskStatus `json:"status"`
+	At     time.Time  `json:"at"`
+}
+
+// TaskEvent is a single entry in a task's activity history
+type TaskEvent struct {
+	Type   TaskEventType
+	Status TaskStatus // New status for EventStatusChanged
+	At     time.Time
+}
+
+// TaskEventType identifies what happened to a task
+type TaskEventType string
+
+const (
+	EventCreated       TaskEventType = "created"
+	EventStatusChanged TaskEventType = "status_changed"
+)
+
+// Priority represents task priority
+type Priority string
+
+const (
+	PriorityLow    Priority = "low"
+	PriorityMedium Priority = "medium"
+	PriorityHigh   Priority = "high"
+)
+
+// TimeEntry represents logged work time
+type TimeEntry struct {
+	Date        string    `json:"date"`
+	Hours       float64   `json:"hours"`
+	LoggedAt    time.Time `json:"logged_at"`
+	JiraWorklog string    `json:"jira_worklog,omitempty"` // ID of the Jira worklog 'jira pushtime' created
+	TogglEntry  int64     `json:"toggl_entry,omitempty"`  // ID of the Toggl time entry 'toggl sync' pulled or pushed
+}
+
+// Recurrence represents recurring task configuration
+type Recurrence struct {
+	Type          RecurrenceType `json:"type"`
+	Value         string         `json:"value"`
+	NextDue       string         `json:"next_due"`
+	LastCompleted string         `json:"last_completed,omitempty"`
+	Completions   []string       `json:"completions,omitempty"` // Dates (YYYY-MM-DD) of every completion
+	Enabled       bool           `json:"enabled"`
+}
+
+// RecurrenceType defines how often a task repeats
+type RecurrenceType string
+
+const (
+	RecurDaily    RecurrenceType = "daily"
+	RecurWeekly   RecurrenceType = "weekly"
+	RecurMonthly  RecurrenceType = "monthly"
+	RecurInterval RecurrenceType = "interval"
+)
+
+// Sprint represents a time-boxed work period
+type Sprint struct {
+	Name      string               `json:"name"`
+	StartDate string               `json:"start_date"`
+	EndDate   string               `json:"end_date"`
+	TaskIDs   []string             `json:"task_ids"`
+	Capacity  float64              `json:"capacity,omitempty"`   // Hours available per assignee
+	AddedAt   map[string]time.Time `json:"added_at,omitempty"`   // When each task was assigned
+	RemovedAt map[string]time.Time `json:"removed_at,omitempty"` // When tasks were unassigned
+	ClosedAt  *time.Time           `json:"closed_at,omitempty"`  // Set by 'sprint close'
+	CreatedAt time.Time            `json:"created_at"`
+}
+
+// TrackingSession represents an active time tracking session
+type TrackingSession struct {
+	Path      string    `json:"path"`
+	TaskID    string    `json:"task_id"`
+	StartTime time.Time `json:"start"`
+}
+
+// TrackingData stores all tracking sessions
+type TrackingData struct {
+	SchemaVersion int              `json:"schema_version"`
+	ActiveSession *TrackingSession `json:"active_session"`
+	Sessions      []interface{}    `json:"sessions"` // Historical sessions
+}
+
+// CalendarSync records the events 'calendar sync' created, so syncing again
+// updates them instead of adding duplicates
+type CalendarSync struct {
+	Calendars map[string]map[string]SyncedEvent `json:"calendars"` // By calendar, then by event UID
+}
+
+// SyncedEvent is an event created in a calendar
+type SyncedEvent struct {
+	Ref      string    `json:"ref"`  // Where the calendar keeps it: a CalDAV URL or Google event ID
+	Hash     string    `json:"hash"` // Of the event when it was last written
+	Project  string    `json:"project"`
+	SyncedAt time.Time `json:"synced_at"`
+}
+
+// ReportSchedule is a report command run automatically on a cron schedule
+type ReportSchedule struct {
+	ID        string    `json:"id"`
+	Command   string    `json:"command"`
+	Cron      string    `json:"cron"`
+	LastRun   time.Time `json:"last_run,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// UsageStats counts the commands run since Since, by command path, such as
+// "qix task list"
+type UsageStats struct {
+	Since    time.Time                `json:"since"`
+	Commands map[string]*CommandUsage `json:"commands"`
+}
+
+// CommandUsage is how often a command was run and how long it took
+type CommandUsage struct {
+	Runs     int       `json:"runs"`
+	Failures int       `json:"failures"`
+	TotalMs  float64   `json:"total_ms"`
+	MaxMs    float64   `json:"max_ms"`
+	LastRun  time.Time `json:"last_run"`
+}
+
+// JournalOp identifies the kind of mutation recorded in the journal
+type JournalOp string
+
+const (
+	OpTaskCreated   JournalOp = "task_created"
+	OpTaskUpdated   JournalOp = "task_updated"
+	OpStatusChanged JournalOp = "status_changed"
+	OpTimeLogged    JournalOp = "time_logged"
+	OpTaskRemoved   JournalOp = "task_removed"
+	OpCommit        JournalOp = "commit" // Marks entry Ref as applied
+	OpAbort         JournalOp = "abort"  // Marks entry Ref as never applied
+)
+
+// JournalEntry records one mutation, written before it is applied.
+// Before is nil for created tasks and After is nil for removed ones.
+type JournalEntry struct {
+	ID      string    `json:"id"`
+	At      time.Time `json:"at"`
+	Op      JournalOp `json:"op"`
+	Ref     string    `json:"ref,omitempty"`
+	Project string    `json:"project,omitempty"`
+	Module  string    `json:"module,omitempty"`
+	TaskID  string    `json:"task_id,omitempty"`
+	Before  *Task     `json:"before,omitempty"`
+	After   *Task     `json:"after,omitempty"`
+}
+
+// TrashKind identifies what a trash item holds
+type TrashKind string
+
+const (
+	TrashTask    TrashKind = "task"
+	TrashModule  TrashKind = "module"
+	TrashProject TrashKind = "project"
+)
+
+// TrashItem is a deleted task, module or project kept so it can be restored
+type TrashItem struct {
+	ID          string    `json:"id"`
+	Kind        TrashKind `json:"kind"`
+	Project     string    `json:"project"`
+	Module      string    `json:"module,omitempty"` // Module the task or module came from
+	DeletedAt   time.Time `json:"deleted_at"`
+	Task        *Task     `json:"task,omitempty"`
+	ModuleData  *Module   `json:"module_data,omitempty"`
+	ProjectData *Project  `json:"project_data,omitempty"`
+}
+
+// Label returns a short description of the trashed item
+func (t TrashItem) Label() string {
+	switch {
+	case t.Task != nil:
+		return t.Task.Title
+	case t.ModuleData != nil:
+		return t.Project + "/" + t.ModuleData.Name
+	default:
+		return t.Project
+	}
+}
+
+// TaskIndex maps task IDs to their locations for fast lookup
+type TaskIndex map[string]TaskLocation
+
+// TaskLocation describes where a task is stored, with the text needed to search it
+type TaskLocation struct {
+	Project  string         `json:"project"`
+	Location string         `json:"location"` // "project" or "module:<name>"
+	Title    string         `json:"title,omitempty"`
+	Status   TaskStatus     `json:"status,omitempty"`
+	Terms    map[string]int `json:"terms"` // Search token -> weight; nil in indexes built before search
+}
+
+// CalculateActualHours returns total hours from time entries
+func (t *Task) CalculateActualHours() float64 {
+	total := 0.0
+	for _, entry := range t.TimeEntries {
+		total += entry.Hours
+	}
+	return total
+}
+
+// IsOverBudget checks if task exceeded estimated hours
+func (t *Task) IsOverBudget() bool {
+	if t.EstimatedHours == 0 {
+		return false
+	}
+	return t.CalculateActualHours() > t.EstimatedHours
+}
+
+// GetVariance returns the difference between actual and estimated hours
+func (t *Task) GetVariance() float64 {
+	return t.CalculateActualHours() - t.EstimatedHours
+}
+
+// GetVariancePercentage returns variance as a percentage
+func (t *Task) GetVariancePercentage() float64 {
+	if t.EstimatedHours == 0 {
+		return 0
+	}
+	return (t.GetVariance() / t.EstimatedHours) * 100
+}
+
+// IsOverdue checks if an open task's due date is before the given date (YYYY-MM-DD)
+func (t *Task) IsOverdue(date string) bool {
+	return t.DueDate != "" && !t.Status.IsDone() && t.DueDate < date
+}
+
+// SyncedJira returns the Jira fields last synced for the task's linked issue,
+// or nil if the issue was not synced since it was linked
+func (t *Task) SyncedJira() *JiraInfo {
+	if t.Jira == nil || !strings.EqualFold(t.Jira.Key, strings.TrimSpace(t.JiraIssue)) {
+		return nil
+	}
+	return t.Jira
+}
+
+// JiraMismatch reports whether the task is done and its Jira issue is not, or
+// the other way round
+func (t *Task) JiraMismatch() bool {
+	info := t.SyncedJira()
+	return info != nil && t.Status.IsDone() != (info.StatusCategory == "done")
+}
+
+// RecordStatus appends a status change to the history if the status differs from the last one
+func (t *Task) RecordStatus(status TaskStatus, at time.Time) {
+	if n := len(t.StatusHistory); n > 0 && t.StatusHistory[n-1].Status == status {
+		return
+	}
+	t.StatusHistory = append(t.StatusHistory, StatusChange{Status: status, At: at})
+}
+
+// RecordStatusChange records a move from previous to the current status.
+// Tasks created before history was kept are seeded with previous at creation.
+func (t *Task) RecordStatusChange(previous TaskStatus, at time.Time) {
+	if t.Status == previous {
+		return
+	}
+	if len(t.StatusHistory) == 0 {
+		t.RecordStatus(previous, t.CreatedAt)
+	}
+	t.RecordStatus(t.Status, at)
+}
+
+// StatusAt returns the task's status at the given time, and false if the task did not exist yet.
+// Tasks without history are assumed to be todo from creation until their last update.
+func (t *Task) StatusAt(at time.Time) (TaskStatus, bool) {
+	if at.Before(t.CreatedAt) {
+		return "", false
+	}
+
+	if len(t.StatusHistory) == 0 {
+		if t.Status != StatusTodo && !at.Before(t.UpdatedAt) {
+			return t.Status, true
+		}
+		return StatusTodo, true
+	}
+
+	status := t.StatusHistory[0].Status
+	for _, change := range t.StatusHistory {
+		if change.At.After(at) {
+			break
+		}
+		status = change.Status
+	}
+	return status, true
+}
+
+// CompletedAt returns when the task was last marked done, if it is done
+func (t *Task) CompletedAt() (time.Time, bool) {
+	if !t.Status.IsDone() {
+		return time.Time{}, false
+	}
+	for i := len(t.StatusHistory) - 1; i >= 0; i-- {
+		if t.StatusHistory[i].Status.IsDone() {
+			return t.StatusHistory[i].At, true
+		}
+	}
+	return t.UpdatedAt, true
+}
+
+// Events returns the task's creation and status changes in chronological order.
+// A done task without history is reported as completed at its last update.
+func (t *Task) Events() []TaskEvent {
+	events := []TaskEvent{{Type: EventCreated, At: t.CreatedAt}}
+
+	if len(t.StatusHistory) == 0 {
+		if t.Status != StatusTodo {
+			events = append(events, TaskEvent{Type: EventStatusChanged, Status: t.Status, At: t.UpdatedAt})
+		}
+		return events
+	}
+
+	for i, change := range t.StatusHistory {
+		// Starting out as todo is part of creation, not a change
+		if i == 0 && change.Status == StatusTodo {
+			continue
+		}
+		events = append(events, TaskEvent{Type: EventStatusChanged, Status: change.Status, At: change.At})
+	}
+	return events
+}
+
+// AddCommit records a commit made for the task, unless it already is
+func (t *Task) AddCommit(commit CommitRef) bool {
+	for _, c := range t.Commits {
+		if c.Hash == commit.Hash {
+			return false
+		}
+	}
+	t.Commits = append(t.Commits, commit)
+	return true
+}
+
+// IsRecurring checks if task has recurrence configured
+func (t *Task) IsRecurring() bool {
+	return t.Recurrence != nil && t.Recurrence.Enabled
+}
+
+// GetAllTasks returns all tasks from project (including modules)
+func (p *Project) GetAllTasks() []Task {
+	tasks := make([]Task, 0, len(p.Tasks))
+	tasks = append(tasks, p.Tasks...)
+
+	for _, module := range p.Modules {
+		tasks = append(tasks, module.Tasks...)
+	}
+
+	return tasks
+}
+
+// CountByStatus returns task counts grouped by status
+func (p *Project) CountByStatus() map[TaskStatus]int {
+	counts := make(map[TaskStatus]int)
+	for _, def := range statuses {
+		counts[def.Name] = 0
+	}
+
+	for _, task := range p.GetAllTasks() {
+		counts[task.Status]++
+	}
+
+	return counts
+}
+
+// CalculateTotalEstimated returns sum of all estimated hours
+func (p *Project) CalculateTotalEstimated() float64 {
+	total := 0.0
+	for _, task := range p.GetAllTasks() {
+		total += task.EstimatedHours
+	}
+	return total
+}
+
+// CalculateTotalActual returns sum of all actual hours
+func (p *Project) CalculateTotalActual() float64 {
+	total := 0.0
+	for _, task := range p.GetAllTasks() {
+		total += task.CalculateActualHours()
+	}
+	return total
+}
+
+// RateFor returns the hourly rate for an assignee, falling back to the project rate
+func (p *Project) RateFor(assignee string) float64 {
+	if rate, ok := p.Rates[assignee]; ok && assignee != "" {
+		return rate
+	}
+	return p.HourlyRate
+}
+
+// GetCompletionPercentage returns percentage of completed tasks
+func (p *Project) GetCompletionPercentage() float64 {
+	counts := p.CountByStatus()
+	total := len(p.GetAllTasks())
+
+	if total == 0 {
+		return 0
+	}
+
+	return (float64(CountDone(counts)) / float64(total)) * 100
+}
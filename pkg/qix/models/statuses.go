@@ -0,0 +1,153 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+)
+
+// StatusDef describes a status tasks can have
+type StatusDef struct {
+	Name  TaskStatus `json:"name"`
+	Icon  string     `json:"icon"`
+	ASCII string     `json:"ascii"`           // Shown in place of Icon in ASCII output
+	Color string     `json:"color,omitempty"` // Color or theme role; the role named after the status if empty
+	Done  bool       `json:"done"`            // Tasks in the status are finished
+}
+
+// PriorityDef describes a priority tasks can have
+type PriorityDef struct {
+	Name  Priority `json:"name"`
+	Icon  string   `json:"icon"`
+	ASCII string   `json:"ascii"`
+	Color string   `json:"color,omitempty"`
+}
+
+// DefaultStatuses returns the built-in statuses, in the order they are listed
+func DefaultStatuses() []StatusDef {
+	return []StatusDef{
+		{Name: StatusTodo, Icon: "⭕", ASCII: "[ ]"},
+		{Name: StatusDoing, Icon: "🔄", ASCII: "[~]"},
+		{Name: StatusDone, Icon: "✅", ASCII: "[x]", Done: true},
+		{Name: StatusBlocked, Icon: "🚫", ASCII: "[!]"},
+	}
+}
+
+// DefaultPriorities returns the built-in priorities, from lowest to highest
+func DefaultPriorities() []PriorityDef {
+	return []PriorityDef{
+		{Name: PriorityLow, Icon: "🟢", ASCII: "(L)"},
+		{Name: PriorityMedium, Icon: "🟡", ASCII: "(M)"},
+		{Name: PriorityHigh, Icon: "🔴", ASCII: "(H)"},
+	}
+}
+
+var (
+	statuses   = DefaultStatuses()
+	priorities = DefaultPriorities()
+)
+
+// Statuses returns the statuses tasks can have, in the order they are listed
+func Statuses() []StatusDef {
+	return append([]StatusDef(nil), statuses...)
+}
+
+// SetStatuses replaces the statuses tasks can have. The built-in ones missing
+// from defs are kept, since qix's own commands move tasks to them.
+func SetStatuses(defs []StatusDef) {
+	statuses = append([]StatusDef(nil), defs...)
+	for _, def := range DefaultStatuses() {
+		if _, ok := LookupStatus(def.Name); !ok {
+			statuses = append(statuses, def)
+		}
+	}
+}
+
+// Priorities returns the priorities tasks can have, from lowest to highest
+func Priorities() []PriorityDef {
+	return append([]PriorityDef(nil), priorities...)
+}
+
+// SetPriorities replaces the priorities tasks can have, keeping the built-in
+// ones missing from defs
+func SetPriorities(defs []PriorityDef) {
+	priorities = append([]PriorityDef(nil), defs...)
+	for _, def := range DefaultPriorities() {
+		if _, ok := LookupPriority(def.Name); !ok {
+			priorities = append(priorities, def)
+		}
+	}
+}
+
+// LookupStatus returns the definition of a status
+func LookupStatus(status TaskStatus) (StatusDef, bool) {
+	for _, def := range statuses {
+		if def.Name == status {
+			return def, true
+		}
+	}
+	return StatusDef{}, false
+}
+
+// LookupPriority returns the definition of a priority
+func LookupPriority(priority Priority) (PriorityDef, bool) {
+	for _, def := range priorities {
+		if def.Name == priority {
+			return def, true
+		}
+	}
+	return PriorityDef{}, false
+}
+
+// StatusNames returns the names of the statuses, in the order they are listed
+func StatusNames() []string {
+	names := make([]string, 0, len(statuses))
+	for _, def := range statuses {
+		names = append(names, string(def.Name))
+	}
+	return names
+}
+
+// PriorityNames returns the names of the priorities, from lowest to highest
+func PriorityNames() []string {
+	names := make([]string, 0, len(priorities))
+	for _, def := range priorities {
+		names = append(names, string(def.Name))
+	}
+	return names
+}
+
+// ParseStatus returns the status named name, ignoring case
+func ParseStatus(name string) (TaskStatus, error) {
+	status := TaskStatus(strings.ToLower(strings.TrimSpace(name)))
+	if _, ok := LookupStatus(status); !ok {
+		return "", fmt.Errorf("invalid status %q (use %s)", name, strings.Join(StatusNames(), ", "))
+	}
+	return status, nil
+}
+
+// ParsePriority returns the priority named name, ignoring case
+func ParsePriority(name string) (Priority, error) {
+	priority := Priority(strings.ToLower(strings.TrimSpace(name)))
+	if _, ok := LookupPriority(priority); !ok {
+		return "", fmt.Errorf("invalid priority %q (use %s)", name, strings.Join(PriorityNames(), ", "))
+	}
+	return priority, nil
+}
+
+// IsDone reports whether tasks in the status are finished
+func (s TaskStatus) IsDone() bool {
+	def, ok := LookupStatus(s)
+	return ok && def.Done
+}
+
+// CountDone returns how many of the counts, as returned by CountByStatus, are
+// of finished tasks
+func CountDone(counts map[TaskStatus]int) int {
+	done := 0
+	for status, count := range counts {
+		if status.IsDone() {
+			done += count
+		}
+	}
+	return done
+}
@@ -0,0 +1,331 @@
+// Package qix reads and changes qix data from Go programs, so bots, exporters
+// and plugins don't have to run the qix command and parse its output.
+//
+// A Client works on the same data directory as the CLI, with the same file
+// locks, journal and task index, so both can be used at the same time:
+//
+//	client, err := qix.Open(qix.Options{})
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	defer client.Close()
+//
+//	id, err := client.AddTask("web", "api", models.Task{
+//		Title:    "Rate limit the login endpoint",
+//		Priority: models.PriorityHigh,
+//	})
+//	...
+//	err = client.SetStatus("web", id, models.StatusDoing)
+//
+// Projects, modules, tasks and the other data types are defined in the
+// models package.
+//
+// Changes are published to the subscribers of the config file, the webhooks
+// and the hooks directory as when made with the CLI, once each method has
+// saved them. A subscriber that fails is logged and undoes nothing.
+package qix
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/mrbooshehri/qix-go/internal/config"
+	"github.com/mrbooshehri/qix-go/internal/encryption"
+	"github.com/mrbooshehri/qix-go/internal/logging"
+	"github.com/mrbooshehri/qix-go/internal/storage"
+	"github.com/mrbooshehri/qix-go/internal/subscribers"
+	"github.com/mrbooshehri/qix-go/pkg/qix/models"
+)
+
+// Options selects the data a Client opens
+type Options struct {
+	// Dir is the data directory. If empty, the directory the CLI uses is
+	// opened: the profile's, $QIX_DIR or ~/.qix.
+	Dir string
+
+	// Profile is a profile defined in the config file, used when Dir is
+	// empty. If both are empty, $QIX_PROFILE is honored as by the CLI.
+	Profile string
+}
+
+// Client reads and writes one qix data directory. Settings such as the
+// storage backend and encryption come from the qix config file ($QIX_DIR/config).
+// A Client is not safe for concurrent use.
+type Client struct {
+	store *storage.Storage
+	dir   string
+
+	// shared is set when the storage belongs to the qix command, which reads
+	// it through its cache and publishes the events itself
+	shared bool
+}
+
+// Open opens a data directory, creating it if it doesn't exist. The
+// configuration is read for this client alone; the statuses and priorities
+// it defines are the only setting shared by the whole process.
+func Open(opts Options) (*Client, error) {
+	cfg, err := config.Load(opts.Profile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read configuration: %w", err)
+	}
+	if err := cfg.ApplyStatuses(); err != nil {
+		return nil, fmt.Errorf("failed to read configuration: %w", err)
+	}
+
+	if opts.Dir != "" {
+		dir, err := filepath.Abs(opts.Dir)
+		if err != nil {
+			return nil, err
+		}
+		cfg = cfg.WithDataDir(dir)
+		if err := os.MkdirAll(cfg.ProjectsDir, 0700); err != nil {
+			return nil, err
+		}
+	}
+
+	key, _, err := encryption.LoadKey(cfg.EncryptionPassphrase, cfg.EncryptionKeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize encryption: %w", err)
+	}
+	encryption.SetDirKey(cfg.QixDir, key)
+
+	store, err := storage.Open(cfg)
+	if err != nil {
+		return nil, err
+	}
+	bus, errs := subscribers.Bus(cfg, os.Stderr)
+	for _, err := range errs {
+		logging.Warnf("%v", err)
+	}
+	store.SetBus(bus)
+	return &Client{store: store, dir: cfg.QixDir}, nil
+}
+
+// New returns a Client on a storage the qix command opened. Its changes are
+// left for the command to publish, along with those it makes itself.
+func New(store *storage.Storage) *Client {
+	return &Client{store: store, dir: store.Dir(), shared: true}
+}
+
+// Dir returns the data directory the client opened
+func (c *Client) Dir() string {
+	return c.dir
+}
+
+// Close saves pending changes to the task index. The client must not be
+// used afterwards.
+func (c *Client) Close() error {
+	return c.store.FlushAll()
+}
+
+// Projects returns the names of all projects
+func (c *Client) Projects() ([]string, error) {
+	return c.store.ListProjects()
+}
+
+// Project reads a project with its modules, tasks and sprints. The result
+// is a snapshot: change the project with UpdateProject or the task methods.
+func (c *Client) Project(name string) (*models.Project, error) {
+	// Always read from disk, in case another process changed it
+	c.refresh(name)
+	project, err := c.store.LoadProject(name)
+	if err != nil {
+		return nil, err
+	}
+	return clone(project)
+}
+
+// CreateProject creates an empty project
+func (c *Client) CreateProject(name, description string, tags []string) error {
+	if tags == nil {
+		tags = make([]string, 0)
+	}
+	_, err := c.store.CreateProject(name, description, tags)
+	return c.published(err)
+}
+
+// UpdateProject changes a project with fn and saves it, holding the project
+// lock so concurrent qix processes don't lose updates. Nothing is saved if
+// fn returns an error.
+func (c *Client) UpdateProject(name string, fn func(*models.Project) error) error {
+	return c.published(c.store.UpdateProject(name, func(project *models.Project) error {
+		// fn changes a copy, so a failed update leaves the cached project as it was
+		draft, err := clone(project)
+		if err != nil {
+			return err
+		}
+		if err := fn(draft); err != nil {
+			return err
+		}
+		*project = *draft
+		return nil
+	}))
+}
+
+// DeleteProject moves a project to the trash, where 'qix trash restore' can
+// bring it back
+func (c *Client) DeleteProject(name string) error {
+	return c.published(c.store.DeleteProject(name))
+}
+
+// AddModule adds an empty module to a project
+func (c *Client) AddModule(project, name, description string) error {
+	return c.published(c.store.AddModule(project, models.Module{
+		Name:        name,
+		Description: description,
+		Tags:        make([]string, 0),
+		Tasks:       make([]models.Task, 0),
+		CreatedAt:   time.Now(),
+	}))
+}
+
+// Task returns a task and the module it is in ("" for the project itself)
+func (c *Client) Task(project, id string) (models.Task, string, error) {
+	c.refresh(project)
+	task, location, err := c.store.FindTask(project, id)
+	if err != nil {
+		return models.Task{}, "", err
+	}
+	copied, err := clone(*task)
+	return copied, moduleOf(location), err
+}
+
+// FindTask returns the project and module a task is in, given only its ID
+func (c *Client) FindTask(id string) (project, module string, err error) {
+	project, location, err := c.store.LookupTask(id)
+	if err != nil {
+		return "", "", err
+	}
+	return project, moduleOf(location), nil
+}
+
+// AddTask adds a task to a project, or to one of its modules if module isn't
+// empty, and returns its ID. Unset fields get the defaults 'qix task add'
+// uses: a new ID, status todo and priority medium.
+func (c *Client) AddTask(project, module string, task models.Task) (string, error) {
+	if task.ID == "" {
+		task.ID = c.store.UniqueTaskID()
+	}
+	if module != "" {
+		if _, err := c.store.GetModule(project, module); err != nil {
+			return "", err
+		}
+	}
+	if err := c.published(c.store.AddTask(project, module, task)); err != nil {
+		return "", err
+	}
+	return task.ID, nil
+}
+
+// UpdateTask changes a task with fn and saves it. Status changes are added
+// to the task's history, as when made with the CLI. Nothing is saved if fn
+// returns an error.
+func (c *Client) UpdateTask(project, id string, fn func(*models.Task) error) error {
+	return c.published(c.store.UpdateTask(project, id, fn))
+}
+
+// SetStatus changes the status of a task
+func (c *Client) SetStatus(project, id string, status models.TaskStatus) error {
+	if _, ok := models.LookupStatus(status); !ok {
+		return fmt.Errorf("invalid status: %s", status)
+	}
+	return c.published(c.store.UpdateTaskStatus(project, id, status))
+}
+
+// SetStatuses changes the status of several tasks of a project together: if
+// any can't be changed, none are
+func (c *Client) SetStatuses(project string, ids []string, status models.TaskStatus) error {
+	if _, ok := models.LookupStatus(status); !ok {
+		return fmt.Errorf("invalid status: %s", status)
+	}
+	return c.published(c.store.WithTx(project, func() error {
+		for _, id := range ids {
+			if err := c.store.UpdateTaskStatus(project, id, status); err != nil {
+				return fmt.Errorf("failed to update task %s: %w", id, err)
+			}
+		}
+		return nil
+	}))
+}
+
+// RemoveTask moves a task to the trash
+func (c *Client) RemoveTask(project, id string) error {
+	return c.published(c.store.RemoveTask(project, id))
+}
+
+// LogTime adds hours of work on date (YYYY-MM-DD) to a task
+func (c *Client) LogTime(project, id string, hours float64, date string) error {
+	if hours <= 0 {
+		return fmt.Errorf("hours must be positive: %g", hours)
+	}
+	if _, err := time.Parse("2006-01-02", date); err != nil {
+		return fmt.Errorf("invalid date %q (use YYYY-MM-DD)", date)
+	}
+	return c.published(c.store.AddTimeEntry(project, id, models.TimeEntry{Date: date, Hours: hours}))
+}
+
+// StartTimer starts tracking time on a task. Only one timer runs at a time,
+// shared with 'qix track start'.
+func (c *Client) StartTimer(project, id string) error {
+	_, location, err := c.store.FindTask(project, id)
+	if err != nil {
+		return err
+	}
+	return c.published(c.store.StartTracking(project, moduleOf(location), id))
+}
+
+// StopTimer stops the running timer and logs the time to its task
+func (c *Client) StopTimer() (taskID string, elapsed time.Duration, err error) {
+	elapsed, _, taskID, err = c.store.StopTracking()
+	return taskID, elapsed, c.published(err)
+}
+
+// ActiveTimer returns the running timer, or nil if there is none
+func (c *Client) ActiveTimer() (*models.TrackingSession, error) {
+	return c.store.GetActiveSession()
+}
+
+// published delivers the events of the changes saved so far and returns err,
+// the error of the change. Subscribers that fail are logged.
+func (c *Client) published(err error) error {
+	if c.shared {
+		return err
+	}
+	_, failures := c.store.PublishEvents(context.Background())
+	for _, failure := range failures {
+		logging.Warnf("%v", failure)
+	}
+	return err
+}
+
+// refresh drops a project from the cache, so it is read again from disk
+func (c *Client) refresh(project string) {
+	if !c.shared {
+		c.store.InvalidateCache(project)
+	}
+}
+
+// moduleOf returns the module named by a task location from storage, or ""
+// for tasks in the project itself
+func moduleOf(location string) string {
+	if name, ok := strings.CutPrefix(location, "module:"); ok {
+		return name
+	}
+	return ""
+}
+
+// clone deep-copies v, so callers can't change the client's cached data
+func clone[T any](v T) (T, error) {
+	var copied T
+	data, err := json.Marshal(v)
+	if err != nil {
+		return copied, err
+	}
+	err = json.Unmarshal(data, &copied)
+	return copied, err
+}